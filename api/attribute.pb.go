@@ -342,6 +342,7 @@ type Attribute struct {
 	//	*Attribute_LargeCommunities
 	//	*Attribute_Ls
 	//	*Attribute_PrefixSid
+	//	*Attribute_BgpsecPath
 	Attr          isAttribute_Attr `protobuf_oneof:"attr"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -591,6 +592,15 @@ func (x *Attribute) GetPrefixSid() *PrefixSID {
 	return nil
 }
 
+func (x *Attribute) GetBgpsecPath() *BgpsecPathAttribute {
+	if x != nil {
+		if x, ok := x.Attr.(*Attribute_BgpsecPath); ok {
+			return x.BgpsecPath
+		}
+	}
+	return nil
+}
+
 type isAttribute_Attr interface {
 	isAttribute_Attr()
 }
@@ -687,6 +697,10 @@ type Attribute_PrefixSid struct {
 	PrefixSid *PrefixSID `protobuf:"bytes,23,opt,name=prefix_sid,json=prefixSid,proto3,oneof"`
 }
 
+type Attribute_BgpsecPath struct {
+	BgpsecPath *BgpsecPathAttribute `protobuf:"bytes,24,opt,name=bgpsec_path,json=bgpsecPath,proto3,oneof"`
+}
+
 func (*Attribute_Unknown) isAttribute_Attr() {}
 
 func (*Attribute_Origin) isAttribute_Attr() {}
@@ -733,6 +747,8 @@ func (*Attribute_Ls) isAttribute_Attr() {}
 
 func (*Attribute_PrefixSid) isAttribute_Attr() {}
 
+func (*Attribute_BgpsecPath) isAttribute_Attr() {}
+
 type OriginAttribute struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Origin        uint32                 `protobuf:"varint,1,opt,name=origin,proto3" json:"origin,omitempty"`
@@ -4958,6 +4974,60 @@ func (x *PrefixSID) GetTlvs() []*PrefixSID_TLV {
 	return nil
 }
 
+// https://www.rfc-editor.org/rfc/rfc8205.html#section-3
+type BgpsecPathAttribute struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Secure_Path segments, the most recently added AS first.
+	SecurePath      []*BgpsecPathAttribute_SecurePathSegment `protobuf:"bytes,1,rep,name=secure_path,json=securePath,proto3" json:"secure_path,omitempty"`
+	SignatureBlocks []*BgpsecPathAttribute_SignatureBlock    `protobuf:"bytes,2,rep,name=signature_blocks,json=signatureBlocks,proto3" json:"signature_blocks,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *BgpsecPathAttribute) Reset() {
+	*x = BgpsecPathAttribute{}
+	mi := &file_api_attribute_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BgpsecPathAttribute) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BgpsecPathAttribute) ProtoMessage() {}
+
+func (x *BgpsecPathAttribute) ProtoReflect() protoreflect.Message {
+	mi := &file_api_attribute_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BgpsecPathAttribute.ProtoReflect.Descriptor instead.
+func (*BgpsecPathAttribute) Descriptor() ([]byte, []int) {
+	return file_api_attribute_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *BgpsecPathAttribute) GetSecurePath() []*BgpsecPathAttribute_SecurePathSegment {
+	if x != nil {
+		return x.SecurePath
+	}
+	return nil
+}
+
+func (x *BgpsecPathAttribute) GetSignatureBlocks() []*BgpsecPathAttribute_SignatureBlock {
+	if x != nil {
+		return x.SignatureBlocks
+	}
+	return nil
+}
+
 type TunnelEncapSubTLVSRSegmentList_Segment struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Types that are valid to be assigned to Segment:
@@ -4971,7 +5041,7 @@ type TunnelEncapSubTLVSRSegmentList_Segment struct {
 
 func (x *TunnelEncapSubTLVSRSegmentList_Segment) Reset() {
 	*x = TunnelEncapSubTLVSRSegmentList_Segment{}
-	mi := &file_api_attribute_proto_msgTypes[75]
+	mi := &file_api_attribute_proto_msgTypes[76]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4983,7 +5053,7 @@ func (x *TunnelEncapSubTLVSRSegmentList_Segment) String() string {
 func (*TunnelEncapSubTLVSRSegmentList_Segment) ProtoMessage() {}
 
 func (x *TunnelEncapSubTLVSRSegmentList_Segment) ProtoReflect() protoreflect.Message {
-	mi := &file_api_attribute_proto_msgTypes[75]
+	mi := &file_api_attribute_proto_msgTypes[76]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5063,7 +5133,7 @@ type TunnelEncapTLV_TLV struct {
 
 func (x *TunnelEncapTLV_TLV) Reset() {
 	*x = TunnelEncapTLV_TLV{}
-	mi := &file_api_attribute_proto_msgTypes[76]
+	mi := &file_api_attribute_proto_msgTypes[77]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5075,7 +5145,7 @@ func (x *TunnelEncapTLV_TLV) String() string {
 func (*TunnelEncapTLV_TLV) ProtoMessage() {}
 
 func (x *TunnelEncapTLV_TLV) ProtoReflect() protoreflect.Message {
-	mi := &file_api_attribute_proto_msgTypes[76]
+	mi := &file_api_attribute_proto_msgTypes[77]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5295,7 +5365,7 @@ type IP6ExtendedCommunitiesAttribute_Community struct {
 
 func (x *IP6ExtendedCommunitiesAttribute_Community) Reset() {
 	*x = IP6ExtendedCommunitiesAttribute_Community{}
-	mi := &file_api_attribute_proto_msgTypes[77]
+	mi := &file_api_attribute_proto_msgTypes[78]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5307,7 +5377,7 @@ func (x *IP6ExtendedCommunitiesAttribute_Community) String() string {
 func (*IP6ExtendedCommunitiesAttribute_Community) ProtoMessage() {}
 
 func (x *IP6ExtendedCommunitiesAttribute_Community) ProtoReflect() protoreflect.Message {
-	mi := &file_api_attribute_proto_msgTypes[77]
+	mi := &file_api_attribute_proto_msgTypes[78]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5379,7 +5449,7 @@ type AigpAttribute_TLV struct {
 
 func (x *AigpAttribute_TLV) Reset() {
 	*x = AigpAttribute_TLV{}
-	mi := &file_api_attribute_proto_msgTypes[78]
+	mi := &file_api_attribute_proto_msgTypes[79]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5391,7 +5461,7 @@ func (x *AigpAttribute_TLV) String() string {
 func (*AigpAttribute_TLV) ProtoMessage() {}
 
 func (x *AigpAttribute_TLV) ProtoReflect() protoreflect.Message {
-	mi := &file_api_attribute_proto_msgTypes[78]
+	mi := &file_api_attribute_proto_msgTypes[79]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5462,7 +5532,7 @@ type PrefixSID_TLV struct {
 
 func (x *PrefixSID_TLV) Reset() {
 	*x = PrefixSID_TLV{}
-	mi := &file_api_attribute_proto_msgTypes[82]
+	mi := &file_api_attribute_proto_msgTypes[83]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -5474,7 +5544,7 @@ func (x *PrefixSID_TLV) String() string {
 func (*PrefixSID_TLV) ProtoMessage() {}
 
 func (x *PrefixSID_TLV) ProtoReflect() protoreflect.Message {
-	mi := &file_api_attribute_proto_msgTypes[82]
+	mi := &file_api_attribute_proto_msgTypes[83]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -5533,11 +5603,175 @@ func (*PrefixSID_TLV_L3Service) isPrefixSID_TLV_Tlv() {}
 
 func (*PrefixSID_TLV_L2Service) isPrefixSID_TLV_Tlv() {}
 
+type BgpsecPathAttribute_SecurePathSegment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pcount        uint32                 `protobuf:"varint,1,opt,name=pcount,proto3" json:"pcount,omitempty"`
+	Confed        bool                   `protobuf:"varint,2,opt,name=confed,proto3" json:"confed,omitempty"`
+	Asn           uint32                 `protobuf:"varint,3,opt,name=asn,proto3" json:"asn,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BgpsecPathAttribute_SecurePathSegment) Reset() {
+	*x = BgpsecPathAttribute_SecurePathSegment{}
+	mi := &file_api_attribute_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BgpsecPathAttribute_SecurePathSegment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BgpsecPathAttribute_SecurePathSegment) ProtoMessage() {}
+
+func (x *BgpsecPathAttribute_SecurePathSegment) ProtoReflect() protoreflect.Message {
+	mi := &file_api_attribute_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BgpsecPathAttribute_SecurePathSegment.ProtoReflect.Descriptor instead.
+func (*BgpsecPathAttribute_SecurePathSegment) Descriptor() ([]byte, []int) {
+	return file_api_attribute_proto_rawDescGZIP(), []int{75, 0}
+}
+
+func (x *BgpsecPathAttribute_SecurePathSegment) GetPcount() uint32 {
+	if x != nil {
+		return x.Pcount
+	}
+	return 0
+}
+
+func (x *BgpsecPathAttribute_SecurePathSegment) GetConfed() bool {
+	if x != nil {
+		return x.Confed
+	}
+	return false
+}
+
+func (x *BgpsecPathAttribute_SecurePathSegment) GetAsn() uint32 {
+	if x != nil {
+		return x.Asn
+	}
+	return 0
+}
+
+type BgpsecPathAttribute_SignatureSegment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ski           []byte                 `protobuf:"bytes,1,opt,name=ski,proto3" json:"ski,omitempty"`
+	Signature     []byte                 `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BgpsecPathAttribute_SignatureSegment) Reset() {
+	*x = BgpsecPathAttribute_SignatureSegment{}
+	mi := &file_api_attribute_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BgpsecPathAttribute_SignatureSegment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BgpsecPathAttribute_SignatureSegment) ProtoMessage() {}
+
+func (x *BgpsecPathAttribute_SignatureSegment) ProtoReflect() protoreflect.Message {
+	mi := &file_api_attribute_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BgpsecPathAttribute_SignatureSegment.ProtoReflect.Descriptor instead.
+func (*BgpsecPathAttribute_SignatureSegment) Descriptor() ([]byte, []int) {
+	return file_api_attribute_proto_rawDescGZIP(), []int{75, 1}
+}
+
+func (x *BgpsecPathAttribute_SignatureSegment) GetSki() []byte {
+	if x != nil {
+		return x.Ski
+	}
+	return nil
+}
+
+func (x *BgpsecPathAttribute_SignatureSegment) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+type BgpsecPathAttribute_SignatureBlock struct {
+	state          protoimpl.MessageState                  `protogen:"open.v1"`
+	AlgorithmSuite uint32                                  `protobuf:"varint,1,opt,name=algorithm_suite,json=algorithmSuite,proto3" json:"algorithm_suite,omitempty"`
+	Segments       []*BgpsecPathAttribute_SignatureSegment `protobuf:"bytes,2,rep,name=segments,proto3" json:"segments,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *BgpsecPathAttribute_SignatureBlock) Reset() {
+	*x = BgpsecPathAttribute_SignatureBlock{}
+	mi := &file_api_attribute_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BgpsecPathAttribute_SignatureBlock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BgpsecPathAttribute_SignatureBlock) ProtoMessage() {}
+
+func (x *BgpsecPathAttribute_SignatureBlock) ProtoReflect() protoreflect.Message {
+	mi := &file_api_attribute_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BgpsecPathAttribute_SignatureBlock.ProtoReflect.Descriptor instead.
+func (*BgpsecPathAttribute_SignatureBlock) Descriptor() ([]byte, []int) {
+	return file_api_attribute_proto_rawDescGZIP(), []int{75, 2}
+}
+
+func (x *BgpsecPathAttribute_SignatureBlock) GetAlgorithmSuite() uint32 {
+	if x != nil {
+		return x.AlgorithmSuite
+	}
+	return 0
+}
+
+func (x *BgpsecPathAttribute_SignatureBlock) GetSegments() []*BgpsecPathAttribute_SignatureSegment {
+	if x != nil {
+		return x.Segments
+	}
+	return nil
+}
+
 var File_api_attribute_proto protoreflect.FileDescriptor
 
 const file_api_attribute_proto_rawDesc = "" +
 	"\n" +
-	"\x13api/attribute.proto\x12\x03api\x1a\x10api/common.proto\x1a\x10api/extcom.proto\x1a\x0eapi/nlri.proto\"\x9b\v\n" +
+	"\x13api/attribute.proto\x12\x03api\x1a\x10api/common.proto\x1a\x10api/extcom.proto\x1a\x0eapi/nlri.proto\"\xd8\v\n" +
 	"\tAttribute\x121\n" +
 	"\aunknown\x18\x01 \x01(\v2\x15.api.UnknownAttributeH\x00R\aunknown\x12.\n" +
 	"\x06origin\x18\x02 \x01(\v2\x14.api.OriginAttributeH\x00R\x06origin\x12/\n" +
@@ -5568,7 +5802,9 @@ const file_api_attribute_proto_rawDesc = "" +
 	"\x11large_communities\x18\x15 \x01(\v2\x1e.api.LargeCommunitiesAttributeH\x00R\x10largeCommunities\x12\"\n" +
 	"\x02ls\x18\x16 \x01(\v2\x10.api.LsAttributeH\x00R\x02ls\x12/\n" +
 	"\n" +
-	"prefix_sid\x18\x17 \x01(\v2\x0e.api.PrefixSIDH\x00R\tprefixSidB\x06\n" +
+	"prefix_sid\x18\x17 \x01(\v2\x0e.api.PrefixSIDH\x00R\tprefixSid\x12;\n" +
+	"\vbgpsec_path\x18\x18 \x01(\v2\x18.api.BgpsecPathAttributeH\x00R\n" +
+	"bgpsecPathB\x06\n" +
 	"\x04attr\")\n" +
 	"\x0fOriginAttribute\x12\x16\n" +
 	"\x06origin\x18\x01 \x01(\rR\x06origin\"\xc8\x01\n" +
@@ -5900,7 +6136,21 @@ const file_api_attribute_proto_rawDesc = "" +
 	"l3_service\x18\x03 \x01(\v2\x15.api.SRv6L3ServiceTLVH\x00R\tl3Service\x126\n" +
 	"\n" +
 	"l2_service\x18\x04 \x01(\v2\x15.api.SRv6L2ServiceTLVH\x00R\tl2ServiceB\x05\n" +
-	"\x03tlv*\xae\n" +
+	"\x03tlv\"\xd4\x03\n" +
+	"\x13BgpsecPathAttribute\x12K\n" +
+	"\vsecure_path\x18\x01 \x03(\v2*.api.BgpsecPathAttribute.SecurePathSegmentR\n" +
+	"securePath\x12R\n" +
+	"\x10signature_blocks\x18\x02 \x03(\v2'.api.BgpsecPathAttribute.SignatureBlockR\x0fsignatureBlocks\x1aU\n" +
+	"\x11SecurePathSegment\x12\x16\n" +
+	"\x06pcount\x18\x01 \x01(\rR\x06pcount\x12\x16\n" +
+	"\x06confed\x18\x02 \x01(\bR\x06confed\x12\x10\n" +
+	"\x03asn\x18\x03 \x01(\rR\x03asn\x1aB\n" +
+	"\x10SignatureSegment\x12\x10\n" +
+	"\x03ski\x18\x01 \x01(\fR\x03ski\x12\x1c\n" +
+	"\tsignature\x18\x02 \x01(\fR\tsignature\x1a\x80\x01\n" +
+	"\x0eSignatureBlock\x12'\n" +
+	"\x0falgorithm_suite\x18\x01 \x01(\rR\x0ealgorithmSuite\x12E\n" +
+	"\bsegments\x18\x02 \x03(\v2).api.BgpsecPathAttribute.SignatureSegmentR\bsegments*\xae\n" +
 	"\n" +
 	"\fSRV6Behavior\x12\x1d\n" +
 	"\x19SRV6_BEHAVIOR_UNSPECIFIED\x10\x00\x12\x15\n" +
@@ -5965,7 +6215,7 @@ func file_api_attribute_proto_rawDescGZIP() []byte {
 }
 
 var file_api_attribute_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_api_attribute_proto_msgTypes = make([]protoimpl.MessageInfo, 83)
+var file_api_attribute_proto_msgTypes = make([]protoimpl.MessageInfo, 87)
 var file_api_attribute_proto_goTypes = []any{
 	(SRV6Behavior)(0),                                 // 0: api.SRV6Behavior
 	(ENLPType)(0),                                     // 1: api.ENLPType
@@ -6045,122 +6295,130 @@ var file_api_attribute_proto_goTypes = []any{
 	(*SRv6L3ServiceTLV)(nil),                          // 75: api.SRv6L3ServiceTLV
 	(*SRv6L2ServiceTLV)(nil),                          // 76: api.SRv6L2ServiceTLV
 	(*PrefixSID)(nil),                                 // 77: api.PrefixSID
-	(*TunnelEncapSubTLVSRSegmentList_Segment)(nil),    // 78: api.TunnelEncapSubTLVSRSegmentList.Segment
-	(*TunnelEncapTLV_TLV)(nil),                        // 79: api.TunnelEncapTLV.TLV
-	(*IP6ExtendedCommunitiesAttribute_Community)(nil), // 80: api.IP6ExtendedCommunitiesAttribute.Community
-	(*AigpAttribute_TLV)(nil),                         // 81: api.AigpAttribute.TLV
-	nil,                                               // 82: api.SRv6InformationSubTLV.SubSubTlvsEntry
-	nil,                                               // 83: api.SRv6L3ServiceTLV.SubTlvsEntry
-	nil,                                               // 84: api.SRv6L2ServiceTLV.SubTlvsEntry
-	(*PrefixSID_TLV)(nil),                             // 85: api.PrefixSID.TLV
-	(*Family)(nil),                                    // 86: api.Family
-	(*NLRI)(nil),                                      // 87: api.NLRI
-	(*ExtendedCommunity)(nil),                         // 88: api.ExtendedCommunity
+	(*BgpsecPathAttribute)(nil),                       // 78: api.BgpsecPathAttribute
+	(*TunnelEncapSubTLVSRSegmentList_Segment)(nil),    // 79: api.TunnelEncapSubTLVSRSegmentList.Segment
+	(*TunnelEncapTLV_TLV)(nil),                        // 80: api.TunnelEncapTLV.TLV
+	(*IP6ExtendedCommunitiesAttribute_Community)(nil), // 81: api.IP6ExtendedCommunitiesAttribute.Community
+	(*AigpAttribute_TLV)(nil),                         // 82: api.AigpAttribute.TLV
+	nil,                                               // 83: api.SRv6InformationSubTLV.SubSubTlvsEntry
+	nil,                                               // 84: api.SRv6L3ServiceTLV.SubTlvsEntry
+	nil,                                               // 85: api.SRv6L2ServiceTLV.SubTlvsEntry
+	(*PrefixSID_TLV)(nil),                             // 86: api.PrefixSID.TLV
+	(*BgpsecPathAttribute_SecurePathSegment)(nil),     // 87: api.BgpsecPathAttribute.SecurePathSegment
+	(*BgpsecPathAttribute_SignatureSegment)(nil),      // 88: api.BgpsecPathAttribute.SignatureSegment
+	(*BgpsecPathAttribute_SignatureBlock)(nil),        // 89: api.BgpsecPathAttribute.SignatureBlock
+	(*Family)(nil),                                    // 90: api.Family
+	(*NLRI)(nil),                                      // 91: api.NLRI
+	(*ExtendedCommunity)(nil),                         // 92: api.ExtendedCommunity
 }
 var file_api_attribute_proto_depIdxs = []int32{
-	67, // 0: api.Attribute.unknown:type_name -> api.UnknownAttribute
-	4,  // 1: api.Attribute.origin:type_name -> api.OriginAttribute
-	6,  // 2: api.Attribute.as_path:type_name -> api.AsPathAttribute
-	7,  // 3: api.Attribute.next_hop:type_name -> api.NextHopAttribute
-	8,  // 4: api.Attribute.multi_exit_disc:type_name -> api.MultiExitDiscAttribute
-	9,  // 5: api.Attribute.local_pref:type_name -> api.LocalPrefAttribute
-	10, // 6: api.Attribute.atomic_aggregate:type_name -> api.AtomicAggregateAttribute
-	11, // 7: api.Attribute.aggregator:type_name -> api.AggregatorAttribute
-	12, // 8: api.Attribute.communities:type_name -> api.CommunitiesAttribute
-	13, // 9: api.Attribute.originator_id:type_name -> api.OriginatorIdAttribute
-	14, // 10: api.Attribute.cluster_list:type_name -> api.ClusterListAttribute
-	15, // 11: api.Attribute.mp_reach:type_name -> api.MpReachNLRIAttribute
-	16, // 12: api.Attribute.mp_unreach:type_name -> api.MpUnreachNLRIAttribute
-	17, // 13: api.Attribute.extended_communities:type_name -> api.ExtendedCommunitiesAttribute
-	18, // 14: api.Attribute.as4_path:type_name -> api.As4PathAttribute
-	19, // 15: api.Attribute.as4_aggregator:type_name -> api.As4AggregatorAttribute
-	20, // 16: api.Attribute.pmsi_tunnel:type_name -> api.PmsiTunnelAttribute
-	41, // 17: api.Attribute.tunnel_encap:type_name -> api.TunnelEncapAttribute
-	44, // 18: api.Attribute.ip6_extended_communities:type_name -> api.IP6ExtendedCommunitiesAttribute
-	47, // 19: api.Attribute.aigp:type_name -> api.AigpAttribute
-	49, // 20: api.Attribute.large_communities:type_name -> api.LargeCommunitiesAttribute
-	66, // 21: api.Attribute.ls:type_name -> api.LsAttribute
-	77, // 22: api.Attribute.prefix_sid:type_name -> api.PrefixSID
-	2,  // 23: api.AsSegment.type:type_name -> api.AsSegment.Type
-	5,  // 24: api.AsPathAttribute.segments:type_name -> api.AsSegment
-	86, // 25: api.MpReachNLRIAttribute.family:type_name -> api.Family
-	87, // 26: api.MpReachNLRIAttribute.nlris:type_name -> api.NLRI
-	86, // 27: api.MpUnreachNLRIAttribute.family:type_name -> api.Family
-	87, // 28: api.MpUnreachNLRIAttribute.nlris:type_name -> api.NLRI
-	88, // 29: api.ExtendedCommunitiesAttribute.communities:type_name -> api.ExtendedCommunity
-	5,  // 30: api.As4PathAttribute.segments:type_name -> api.AsSegment
-	28, // 31: api.TunnelEncapSubTLVSRBindingSID.sr_binding_sid:type_name -> api.SRBindingSID
-	30, // 32: api.TunnelEncapSubTLVSRBindingSID.srv6_binding_sid:type_name -> api.SRv6BindingSID
-	0,  // 33: api.SRv6EndPointBehavior.behavior:type_name -> api.SRV6Behavior
-	29, // 34: api.SRv6BindingSID.endpoint_behavior_structure:type_name -> api.SRv6EndPointBehavior
-	1,  // 35: api.TunnelEncapSubTLVSRENLP.enlp:type_name -> api.ENLPType
-	33, // 36: api.SegmentTypeA.flags:type_name -> api.SegmentFlags
-	33, // 37: api.SegmentTypeB.flags:type_name -> api.SegmentFlags
-	29, // 38: api.SegmentTypeB.endpoint_behavior_structure:type_name -> api.SRv6EndPointBehavior
-	32, // 39: api.TunnelEncapSubTLVSRSegmentList.weight:type_name -> api.SRWeight
-	78, // 40: api.TunnelEncapSubTLVSRSegmentList.segments:type_name -> api.TunnelEncapSubTLVSRSegmentList.Segment
-	79, // 41: api.TunnelEncapTLV.tlvs:type_name -> api.TunnelEncapTLV.TLV
-	40, // 42: api.TunnelEncapAttribute.tlvs:type_name -> api.TunnelEncapTLV
-	80, // 43: api.IP6ExtendedCommunitiesAttribute.communities:type_name -> api.IP6ExtendedCommunitiesAttribute.Community
-	81, // 44: api.AigpAttribute.tlvs:type_name -> api.AigpAttribute.TLV
-	48, // 45: api.LargeCommunitiesAttribute.communities:type_name -> api.LargeCommunity
-	52, // 46: api.LsSrCapabilities.ranges:type_name -> api.LsSrRange
-	52, // 47: api.LsSrLocalBlock.ranges:type_name -> api.LsSrRange
-	50, // 48: api.LsAttributeNode.flags:type_name -> api.LsNodeFlags
-	53, // 49: api.LsAttributeNode.sr_capabilities:type_name -> api.LsSrCapabilities
-	54, // 50: api.LsAttributeNode.sr_local_block:type_name -> api.LsSrLocalBlock
-	61, // 51: api.LsAttributeLink.srv6_end_x_sid:type_name -> api.LsSrv6EndXSID
-	51, // 52: api.LsAttributePrefix.igp_flags:type_name -> api.LsIGPFlags
-	58, // 53: api.LsBgpPeerSegmentSID.flags:type_name -> api.LsBgpPeerSegmentSIDFlags
-	59, // 54: api.LsAttributeBgpPeerSegment.bgp_peer_node_sid:type_name -> api.LsBgpPeerSegmentSID
-	59, // 55: api.LsAttributeBgpPeerSegment.bgp_peer_adjacency_sid:type_name -> api.LsBgpPeerSegmentSID
-	59, // 56: api.LsAttributeBgpPeerSegment.bgp_peer_set_sid:type_name -> api.LsBgpPeerSegmentSID
-	62, // 57: api.LsSrv6EndXSID.srv6_sid_structure:type_name -> api.LsSrv6SIDStructure
-	62, // 58: api.LsAttributeSrv6SID.srv6_sid_structure:type_name -> api.LsSrv6SIDStructure
-	63, // 59: api.LsAttributeSrv6SID.srv6_endpoint_behavior:type_name -> api.LsSrv6EndpointBehavior
-	64, // 60: api.LsAttributeSrv6SID.srv6_bgp_peer_node_sid:type_name -> api.LsSrv6BgpPeerNodeSID
-	55, // 61: api.LsAttribute.node:type_name -> api.LsAttributeNode
-	56, // 62: api.LsAttribute.link:type_name -> api.LsAttributeLink
-	57, // 63: api.LsAttribute.prefix:type_name -> api.LsAttributePrefix
-	60, // 64: api.LsAttribute.bgp_peer_segment:type_name -> api.LsAttributeBgpPeerSegment
-	65, // 65: api.LsAttribute.srv6_sid:type_name -> api.LsAttributeSrv6SID
-	68, // 66: api.SRv6SubSubTLV.structure:type_name -> api.SRv6StructureSubSubTLV
-	69, // 67: api.SRv6SubSubTLVs.tlvs:type_name -> api.SRv6SubSubTLV
-	71, // 68: api.SRv6InformationSubTLV.flags:type_name -> api.SRv6SIDFlags
-	82, // 69: api.SRv6InformationSubTLV.sub_sub_tlvs:type_name -> api.SRv6InformationSubTLV.SubSubTlvsEntry
-	72, // 70: api.SRv6SubTLV.information:type_name -> api.SRv6InformationSubTLV
-	73, // 71: api.SRv6SubTLVs.tlvs:type_name -> api.SRv6SubTLV
-	83, // 72: api.SRv6L3ServiceTLV.sub_tlvs:type_name -> api.SRv6L3ServiceTLV.SubTlvsEntry
-	84, // 73: api.SRv6L2ServiceTLV.sub_tlvs:type_name -> api.SRv6L2ServiceTLV.SubTlvsEntry
-	85, // 74: api.PrefixSID.tlvs:type_name -> api.PrefixSID.TLV
-	34, // 75: api.TunnelEncapSubTLVSRSegmentList.Segment.a:type_name -> api.SegmentTypeA
-	35, // 76: api.TunnelEncapSubTLVSRSegmentList.Segment.b:type_name -> api.SegmentTypeB
-	39, // 77: api.TunnelEncapTLV.TLV.unknown:type_name -> api.TunnelEncapSubTLVUnknown
-	21, // 78: api.TunnelEncapTLV.TLV.encapsulation:type_name -> api.TunnelEncapSubTLVEncapsulation
-	22, // 79: api.TunnelEncapTLV.TLV.protocol:type_name -> api.TunnelEncapSubTLVProtocol
-	23, // 80: api.TunnelEncapTLV.TLV.color:type_name -> api.TunnelEncapSubTLVColor
-	37, // 81: api.TunnelEncapTLV.TLV.egress_endpoint:type_name -> api.TunnelEncapSubTLVEgressEndpoint
-	38, // 82: api.TunnelEncapTLV.TLV.udp_dest_port:type_name -> api.TunnelEncapSubTLVUDPDestPort
-	24, // 83: api.TunnelEncapTLV.TLV.sr_preference:type_name -> api.TunnelEncapSubTLVSRPreference
-	26, // 84: api.TunnelEncapTLV.TLV.sr_priority:type_name -> api.TunnelEncapSubTLVSRPriority
-	25, // 85: api.TunnelEncapTLV.TLV.sr_candidate_path_name:type_name -> api.TunnelEncapSubTLVSRCandidatePathName
-	31, // 86: api.TunnelEncapTLV.TLV.sr_enlp:type_name -> api.TunnelEncapSubTLVSRENLP
-	27, // 87: api.TunnelEncapTLV.TLV.sr_binding_sid:type_name -> api.TunnelEncapSubTLVSRBindingSID
-	36, // 88: api.TunnelEncapTLV.TLV.sr_segment_list:type_name -> api.TunnelEncapSubTLVSRSegmentList
-	42, // 89: api.IP6ExtendedCommunitiesAttribute.Community.ipv6_address_specific:type_name -> api.IPv6AddressSpecificExtended
-	43, // 90: api.IP6ExtendedCommunitiesAttribute.Community.redirect_ipv6_address_specific:type_name -> api.RedirectIPv6AddressSpecificExtended
-	46, // 91: api.AigpAttribute.TLV.unknown:type_name -> api.AigpTLVUnknown
-	45, // 92: api.AigpAttribute.TLV.igp_metric:type_name -> api.AigpTLVIGPMetric
-	70, // 93: api.SRv6InformationSubTLV.SubSubTlvsEntry.value:type_name -> api.SRv6SubSubTLVs
-	74, // 94: api.SRv6L3ServiceTLV.SubTlvsEntry.value:type_name -> api.SRv6SubTLVs
-	74, // 95: api.SRv6L2ServiceTLV.SubTlvsEntry.value:type_name -> api.SRv6SubTLVs
-	75, // 96: api.PrefixSID.TLV.l3_service:type_name -> api.SRv6L3ServiceTLV
-	76, // 97: api.PrefixSID.TLV.l2_service:type_name -> api.SRv6L2ServiceTLV
-	98, // [98:98] is the sub-list for method output_type
-	98, // [98:98] is the sub-list for method input_type
-	98, // [98:98] is the sub-list for extension type_name
-	98, // [98:98] is the sub-list for extension extendee
-	0,  // [0:98] is the sub-list for field type_name
+	67,  // 0: api.Attribute.unknown:type_name -> api.UnknownAttribute
+	4,   // 1: api.Attribute.origin:type_name -> api.OriginAttribute
+	6,   // 2: api.Attribute.as_path:type_name -> api.AsPathAttribute
+	7,   // 3: api.Attribute.next_hop:type_name -> api.NextHopAttribute
+	8,   // 4: api.Attribute.multi_exit_disc:type_name -> api.MultiExitDiscAttribute
+	9,   // 5: api.Attribute.local_pref:type_name -> api.LocalPrefAttribute
+	10,  // 6: api.Attribute.atomic_aggregate:type_name -> api.AtomicAggregateAttribute
+	11,  // 7: api.Attribute.aggregator:type_name -> api.AggregatorAttribute
+	12,  // 8: api.Attribute.communities:type_name -> api.CommunitiesAttribute
+	13,  // 9: api.Attribute.originator_id:type_name -> api.OriginatorIdAttribute
+	14,  // 10: api.Attribute.cluster_list:type_name -> api.ClusterListAttribute
+	15,  // 11: api.Attribute.mp_reach:type_name -> api.MpReachNLRIAttribute
+	16,  // 12: api.Attribute.mp_unreach:type_name -> api.MpUnreachNLRIAttribute
+	17,  // 13: api.Attribute.extended_communities:type_name -> api.ExtendedCommunitiesAttribute
+	18,  // 14: api.Attribute.as4_path:type_name -> api.As4PathAttribute
+	19,  // 15: api.Attribute.as4_aggregator:type_name -> api.As4AggregatorAttribute
+	20,  // 16: api.Attribute.pmsi_tunnel:type_name -> api.PmsiTunnelAttribute
+	41,  // 17: api.Attribute.tunnel_encap:type_name -> api.TunnelEncapAttribute
+	44,  // 18: api.Attribute.ip6_extended_communities:type_name -> api.IP6ExtendedCommunitiesAttribute
+	47,  // 19: api.Attribute.aigp:type_name -> api.AigpAttribute
+	49,  // 20: api.Attribute.large_communities:type_name -> api.LargeCommunitiesAttribute
+	66,  // 21: api.Attribute.ls:type_name -> api.LsAttribute
+	77,  // 22: api.Attribute.prefix_sid:type_name -> api.PrefixSID
+	78,  // 23: api.Attribute.bgpsec_path:type_name -> api.BgpsecPathAttribute
+	2,   // 24: api.AsSegment.type:type_name -> api.AsSegment.Type
+	5,   // 25: api.AsPathAttribute.segments:type_name -> api.AsSegment
+	90,  // 26: api.MpReachNLRIAttribute.family:type_name -> api.Family
+	91,  // 27: api.MpReachNLRIAttribute.nlris:type_name -> api.NLRI
+	90,  // 28: api.MpUnreachNLRIAttribute.family:type_name -> api.Family
+	91,  // 29: api.MpUnreachNLRIAttribute.nlris:type_name -> api.NLRI
+	92,  // 30: api.ExtendedCommunitiesAttribute.communities:type_name -> api.ExtendedCommunity
+	5,   // 31: api.As4PathAttribute.segments:type_name -> api.AsSegment
+	28,  // 32: api.TunnelEncapSubTLVSRBindingSID.sr_binding_sid:type_name -> api.SRBindingSID
+	30,  // 33: api.TunnelEncapSubTLVSRBindingSID.srv6_binding_sid:type_name -> api.SRv6BindingSID
+	0,   // 34: api.SRv6EndPointBehavior.behavior:type_name -> api.SRV6Behavior
+	29,  // 35: api.SRv6BindingSID.endpoint_behavior_structure:type_name -> api.SRv6EndPointBehavior
+	1,   // 36: api.TunnelEncapSubTLVSRENLP.enlp:type_name -> api.ENLPType
+	33,  // 37: api.SegmentTypeA.flags:type_name -> api.SegmentFlags
+	33,  // 38: api.SegmentTypeB.flags:type_name -> api.SegmentFlags
+	29,  // 39: api.SegmentTypeB.endpoint_behavior_structure:type_name -> api.SRv6EndPointBehavior
+	32,  // 40: api.TunnelEncapSubTLVSRSegmentList.weight:type_name -> api.SRWeight
+	79,  // 41: api.TunnelEncapSubTLVSRSegmentList.segments:type_name -> api.TunnelEncapSubTLVSRSegmentList.Segment
+	80,  // 42: api.TunnelEncapTLV.tlvs:type_name -> api.TunnelEncapTLV.TLV
+	40,  // 43: api.TunnelEncapAttribute.tlvs:type_name -> api.TunnelEncapTLV
+	81,  // 44: api.IP6ExtendedCommunitiesAttribute.communities:type_name -> api.IP6ExtendedCommunitiesAttribute.Community
+	82,  // 45: api.AigpAttribute.tlvs:type_name -> api.AigpAttribute.TLV
+	48,  // 46: api.LargeCommunitiesAttribute.communities:type_name -> api.LargeCommunity
+	52,  // 47: api.LsSrCapabilities.ranges:type_name -> api.LsSrRange
+	52,  // 48: api.LsSrLocalBlock.ranges:type_name -> api.LsSrRange
+	50,  // 49: api.LsAttributeNode.flags:type_name -> api.LsNodeFlags
+	53,  // 50: api.LsAttributeNode.sr_capabilities:type_name -> api.LsSrCapabilities
+	54,  // 51: api.LsAttributeNode.sr_local_block:type_name -> api.LsSrLocalBlock
+	61,  // 52: api.LsAttributeLink.srv6_end_x_sid:type_name -> api.LsSrv6EndXSID
+	51,  // 53: api.LsAttributePrefix.igp_flags:type_name -> api.LsIGPFlags
+	58,  // 54: api.LsBgpPeerSegmentSID.flags:type_name -> api.LsBgpPeerSegmentSIDFlags
+	59,  // 55: api.LsAttributeBgpPeerSegment.bgp_peer_node_sid:type_name -> api.LsBgpPeerSegmentSID
+	59,  // 56: api.LsAttributeBgpPeerSegment.bgp_peer_adjacency_sid:type_name -> api.LsBgpPeerSegmentSID
+	59,  // 57: api.LsAttributeBgpPeerSegment.bgp_peer_set_sid:type_name -> api.LsBgpPeerSegmentSID
+	62,  // 58: api.LsSrv6EndXSID.srv6_sid_structure:type_name -> api.LsSrv6SIDStructure
+	62,  // 59: api.LsAttributeSrv6SID.srv6_sid_structure:type_name -> api.LsSrv6SIDStructure
+	63,  // 60: api.LsAttributeSrv6SID.srv6_endpoint_behavior:type_name -> api.LsSrv6EndpointBehavior
+	64,  // 61: api.LsAttributeSrv6SID.srv6_bgp_peer_node_sid:type_name -> api.LsSrv6BgpPeerNodeSID
+	55,  // 62: api.LsAttribute.node:type_name -> api.LsAttributeNode
+	56,  // 63: api.LsAttribute.link:type_name -> api.LsAttributeLink
+	57,  // 64: api.LsAttribute.prefix:type_name -> api.LsAttributePrefix
+	60,  // 65: api.LsAttribute.bgp_peer_segment:type_name -> api.LsAttributeBgpPeerSegment
+	65,  // 66: api.LsAttribute.srv6_sid:type_name -> api.LsAttributeSrv6SID
+	68,  // 67: api.SRv6SubSubTLV.structure:type_name -> api.SRv6StructureSubSubTLV
+	69,  // 68: api.SRv6SubSubTLVs.tlvs:type_name -> api.SRv6SubSubTLV
+	71,  // 69: api.SRv6InformationSubTLV.flags:type_name -> api.SRv6SIDFlags
+	83,  // 70: api.SRv6InformationSubTLV.sub_sub_tlvs:type_name -> api.SRv6InformationSubTLV.SubSubTlvsEntry
+	72,  // 71: api.SRv6SubTLV.information:type_name -> api.SRv6InformationSubTLV
+	73,  // 72: api.SRv6SubTLVs.tlvs:type_name -> api.SRv6SubTLV
+	84,  // 73: api.SRv6L3ServiceTLV.sub_tlvs:type_name -> api.SRv6L3ServiceTLV.SubTlvsEntry
+	85,  // 74: api.SRv6L2ServiceTLV.sub_tlvs:type_name -> api.SRv6L2ServiceTLV.SubTlvsEntry
+	86,  // 75: api.PrefixSID.tlvs:type_name -> api.PrefixSID.TLV
+	87,  // 76: api.BgpsecPathAttribute.secure_path:type_name -> api.BgpsecPathAttribute.SecurePathSegment
+	89,  // 77: api.BgpsecPathAttribute.signature_blocks:type_name -> api.BgpsecPathAttribute.SignatureBlock
+	34,  // 78: api.TunnelEncapSubTLVSRSegmentList.Segment.a:type_name -> api.SegmentTypeA
+	35,  // 79: api.TunnelEncapSubTLVSRSegmentList.Segment.b:type_name -> api.SegmentTypeB
+	39,  // 80: api.TunnelEncapTLV.TLV.unknown:type_name -> api.TunnelEncapSubTLVUnknown
+	21,  // 81: api.TunnelEncapTLV.TLV.encapsulation:type_name -> api.TunnelEncapSubTLVEncapsulation
+	22,  // 82: api.TunnelEncapTLV.TLV.protocol:type_name -> api.TunnelEncapSubTLVProtocol
+	23,  // 83: api.TunnelEncapTLV.TLV.color:type_name -> api.TunnelEncapSubTLVColor
+	37,  // 84: api.TunnelEncapTLV.TLV.egress_endpoint:type_name -> api.TunnelEncapSubTLVEgressEndpoint
+	38,  // 85: api.TunnelEncapTLV.TLV.udp_dest_port:type_name -> api.TunnelEncapSubTLVUDPDestPort
+	24,  // 86: api.TunnelEncapTLV.TLV.sr_preference:type_name -> api.TunnelEncapSubTLVSRPreference
+	26,  // 87: api.TunnelEncapTLV.TLV.sr_priority:type_name -> api.TunnelEncapSubTLVSRPriority
+	25,  // 88: api.TunnelEncapTLV.TLV.sr_candidate_path_name:type_name -> api.TunnelEncapSubTLVSRCandidatePathName
+	31,  // 89: api.TunnelEncapTLV.TLV.sr_enlp:type_name -> api.TunnelEncapSubTLVSRENLP
+	27,  // 90: api.TunnelEncapTLV.TLV.sr_binding_sid:type_name -> api.TunnelEncapSubTLVSRBindingSID
+	36,  // 91: api.TunnelEncapTLV.TLV.sr_segment_list:type_name -> api.TunnelEncapSubTLVSRSegmentList
+	42,  // 92: api.IP6ExtendedCommunitiesAttribute.Community.ipv6_address_specific:type_name -> api.IPv6AddressSpecificExtended
+	43,  // 93: api.IP6ExtendedCommunitiesAttribute.Community.redirect_ipv6_address_specific:type_name -> api.RedirectIPv6AddressSpecificExtended
+	46,  // 94: api.AigpAttribute.TLV.unknown:type_name -> api.AigpTLVUnknown
+	45,  // 95: api.AigpAttribute.TLV.igp_metric:type_name -> api.AigpTLVIGPMetric
+	70,  // 96: api.SRv6InformationSubTLV.SubSubTlvsEntry.value:type_name -> api.SRv6SubSubTLVs
+	74,  // 97: api.SRv6L3ServiceTLV.SubTlvsEntry.value:type_name -> api.SRv6SubTLVs
+	74,  // 98: api.SRv6L2ServiceTLV.SubTlvsEntry.value:type_name -> api.SRv6SubTLVs
+	75,  // 99: api.PrefixSID.TLV.l3_service:type_name -> api.SRv6L3ServiceTLV
+	76,  // 100: api.PrefixSID.TLV.l2_service:type_name -> api.SRv6L2ServiceTLV
+	88,  // 101: api.BgpsecPathAttribute.SignatureBlock.segments:type_name -> api.BgpsecPathAttribute.SignatureSegment
+	102, // [102:102] is the sub-list for method output_type
+	102, // [102:102] is the sub-list for method input_type
+	102, // [102:102] is the sub-list for extension type_name
+	102, // [102:102] is the sub-list for extension extendee
+	0,   // [0:102] is the sub-list for field type_name
 }
 
 func init() { file_api_attribute_proto_init() }
@@ -6195,6 +6453,7 @@ func file_api_attribute_proto_init() {
 		(*Attribute_LargeCommunities)(nil),
 		(*Attribute_Ls)(nil),
 		(*Attribute_PrefixSid)(nil),
+		(*Attribute_BgpsecPath)(nil),
 	}
 	file_api_attribute_proto_msgTypes[24].OneofWrappers = []any{
 		(*TunnelEncapSubTLVSRBindingSID_SrBindingSid)(nil),
@@ -6206,11 +6465,11 @@ func file_api_attribute_proto_init() {
 	file_api_attribute_proto_msgTypes[70].OneofWrappers = []any{
 		(*SRv6SubTLV_Information)(nil),
 	}
-	file_api_attribute_proto_msgTypes[75].OneofWrappers = []any{
+	file_api_attribute_proto_msgTypes[76].OneofWrappers = []any{
 		(*TunnelEncapSubTLVSRSegmentList_Segment_A)(nil),
 		(*TunnelEncapSubTLVSRSegmentList_Segment_B)(nil),
 	}
-	file_api_attribute_proto_msgTypes[76].OneofWrappers = []any{
+	file_api_attribute_proto_msgTypes[77].OneofWrappers = []any{
 		(*TunnelEncapTLV_TLV_Unknown)(nil),
 		(*TunnelEncapTLV_TLV_Encapsulation)(nil),
 		(*TunnelEncapTLV_TLV_Protocol)(nil),
@@ -6224,15 +6483,15 @@ func file_api_attribute_proto_init() {
 		(*TunnelEncapTLV_TLV_SrBindingSid)(nil),
 		(*TunnelEncapTLV_TLV_SrSegmentList)(nil),
 	}
-	file_api_attribute_proto_msgTypes[77].OneofWrappers = []any{
+	file_api_attribute_proto_msgTypes[78].OneofWrappers = []any{
 		(*IP6ExtendedCommunitiesAttribute_Community_Ipv6AddressSpecific)(nil),
 		(*IP6ExtendedCommunitiesAttribute_Community_RedirectIpv6AddressSpecific)(nil),
 	}
-	file_api_attribute_proto_msgTypes[78].OneofWrappers = []any{
+	file_api_attribute_proto_msgTypes[79].OneofWrappers = []any{
 		(*AigpAttribute_TLV_Unknown)(nil),
 		(*AigpAttribute_TLV_IgpMetric)(nil),
 	}
-	file_api_attribute_proto_msgTypes[82].OneofWrappers = []any{
+	file_api_attribute_proto_msgTypes[83].OneofWrappers = []any{
 		(*PrefixSID_TLV_L3Service)(nil),
 		(*PrefixSID_TLV_L2Service)(nil),
 	}
@@ -6242,7 +6501,7 @@ func file_api_attribute_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_attribute_proto_rawDesc), len(file_api_attribute_proto_rawDesc)),
 			NumEnums:      3,
-			NumMessages:   83,
+			NumMessages:   87,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -94,6 +94,107 @@ func (AddPathCapabilityTuple_Mode) EnumDescriptor() ([]byte, []int) {
 	return file_api_capability_proto_rawDescGZIP(), []int{9, 0}
 }
 
+type OutboundRouteFilteringCapabilityEntry_Mode int32
+
+const (
+	OutboundRouteFilteringCapabilityEntry_MODE_UNSPECIFIED OutboundRouteFilteringCapabilityEntry_Mode = 0
+	OutboundRouteFilteringCapabilityEntry_MODE_RECEIVE     OutboundRouteFilteringCapabilityEntry_Mode = 1
+	OutboundRouteFilteringCapabilityEntry_MODE_SEND        OutboundRouteFilteringCapabilityEntry_Mode = 2
+	OutboundRouteFilteringCapabilityEntry_MODE_BOTH        OutboundRouteFilteringCapabilityEntry_Mode = 3
+)
+
+// Enum value maps for OutboundRouteFilteringCapabilityEntry_Mode.
+var (
+	OutboundRouteFilteringCapabilityEntry_Mode_name = map[int32]string{
+		0: "MODE_UNSPECIFIED",
+		1: "MODE_RECEIVE",
+		2: "MODE_SEND",
+		3: "MODE_BOTH",
+	}
+	OutboundRouteFilteringCapabilityEntry_Mode_value = map[string]int32{
+		"MODE_UNSPECIFIED": 0,
+		"MODE_RECEIVE":     1,
+		"MODE_SEND":        2,
+		"MODE_BOTH":        3,
+	}
+)
+
+func (x OutboundRouteFilteringCapabilityEntry_Mode) Enum() *OutboundRouteFilteringCapabilityEntry_Mode {
+	p := new(OutboundRouteFilteringCapabilityEntry_Mode)
+	*p = x
+	return p
+}
+
+func (x OutboundRouteFilteringCapabilityEntry_Mode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OutboundRouteFilteringCapabilityEntry_Mode) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_capability_proto_enumTypes[1].Descriptor()
+}
+
+func (OutboundRouteFilteringCapabilityEntry_Mode) Type() protoreflect.EnumType {
+	return &file_api_capability_proto_enumTypes[1]
+}
+
+func (x OutboundRouteFilteringCapabilityEntry_Mode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OutboundRouteFilteringCapabilityEntry_Mode.Descriptor instead.
+func (OutboundRouteFilteringCapabilityEntry_Mode) EnumDescriptor() ([]byte, []int) {
+	return file_api_capability_proto_rawDescGZIP(), []int{17, 0}
+}
+
+type BgpsecCapability_Direction int32
+
+const (
+	BgpsecCapability_DIRECTION_UNSPECIFIED BgpsecCapability_Direction = 0
+	BgpsecCapability_DIRECTION_RECEIVE     BgpsecCapability_Direction = 1
+	BgpsecCapability_DIRECTION_SEND        BgpsecCapability_Direction = 2
+)
+
+// Enum value maps for BgpsecCapability_Direction.
+var (
+	BgpsecCapability_Direction_name = map[int32]string{
+		0: "DIRECTION_UNSPECIFIED",
+		1: "DIRECTION_RECEIVE",
+		2: "DIRECTION_SEND",
+	}
+	BgpsecCapability_Direction_value = map[string]int32{
+		"DIRECTION_UNSPECIFIED": 0,
+		"DIRECTION_RECEIVE":     1,
+		"DIRECTION_SEND":        2,
+	}
+)
+
+func (x BgpsecCapability_Direction) Enum() *BgpsecCapability_Direction {
+	p := new(BgpsecCapability_Direction)
+	*p = x
+	return p
+}
+
+func (x BgpsecCapability_Direction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BgpsecCapability_Direction) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_capability_proto_enumTypes[2].Descriptor()
+}
+
+func (BgpsecCapability_Direction) Type() protoreflect.EnumType {
+	return &file_api_capability_proto_enumTypes[2]
+}
+
+func (x BgpsecCapability_Direction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BgpsecCapability_Direction.Descriptor instead.
+func (BgpsecCapability_Direction) EnumDescriptor() ([]byte, []int) {
+	return file_api_capability_proto_rawDescGZIP(), []int{20, 0}
+}
+
 type Capability struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Types that are valid to be assigned to Cap:
@@ -111,6 +212,8 @@ type Capability struct {
 	//	*Capability_RouteRefreshCisco
 	//	*Capability_Fqdn
 	//	*Capability_SoftwareVersion
+	//	*Capability_OutboundRouteFiltering
+	//	*Capability_Bgpsec
 	Cap           isCapability_Cap `protobuf_oneof:"cap"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -270,6 +373,24 @@ func (x *Capability) GetSoftwareVersion() *SoftwareVersionCapability {
 	return nil
 }
 
+func (x *Capability) GetOutboundRouteFiltering() *OutboundRouteFilteringCapability {
+	if x != nil {
+		if x, ok := x.Cap.(*Capability_OutboundRouteFiltering); ok {
+			return x.OutboundRouteFiltering
+		}
+	}
+	return nil
+}
+
+func (x *Capability) GetBgpsec() *BgpsecCapability {
+	if x != nil {
+		if x, ok := x.Cap.(*Capability_Bgpsec); ok {
+			return x.Bgpsec
+		}
+	}
+	return nil
+}
+
 type isCapability_Cap interface {
 	isCapability_Cap()
 }
@@ -326,6 +447,14 @@ type Capability_SoftwareVersion struct {
 	SoftwareVersion *SoftwareVersionCapability `protobuf:"bytes,13,opt,name=software_version,json=softwareVersion,proto3,oneof"`
 }
 
+type Capability_OutboundRouteFiltering struct {
+	OutboundRouteFiltering *OutboundRouteFilteringCapability `protobuf:"bytes,14,opt,name=outbound_route_filtering,json=outboundRouteFiltering,proto3,oneof"`
+}
+
+type Capability_Bgpsec struct {
+	Bgpsec *BgpsecCapability `protobuf:"bytes,15,opt,name=bgpsec,proto3,oneof"`
+}
+
 func (*Capability_Unknown) isCapability_Cap() {}
 
 func (*Capability_MultiProtocol) isCapability_Cap() {}
@@ -352,6 +481,10 @@ func (*Capability_Fqdn) isCapability_Cap() {}
 
 func (*Capability_SoftwareVersion) isCapability_Cap() {}
 
+func (*Capability_OutboundRouteFiltering) isCapability_Cap() {}
+
+func (*Capability_Bgpsec) isCapability_Cap() {}
+
 type MultiProtocolCapability struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Family        *Family                `protobuf:"bytes,1,opt,name=family,proto3" json:"family,omitempty"`
@@ -1091,6 +1224,215 @@ func (x *SoftwareVersionCapability) GetSoftwareVersion() string {
 	return ""
 }
 
+type OutboundRouteFilteringCapabilityEntry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ORF type, 64 for Address Prefix ORF.
+	Type          uint32                                     `protobuf:"varint,1,opt,name=type,proto3" json:"type,omitempty"`
+	Mode          OutboundRouteFilteringCapabilityEntry_Mode `protobuf:"varint,2,opt,name=mode,proto3,enum=api.OutboundRouteFilteringCapabilityEntry_Mode" json:"mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OutboundRouteFilteringCapabilityEntry) Reset() {
+	*x = OutboundRouteFilteringCapabilityEntry{}
+	mi := &file_api_capability_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OutboundRouteFilteringCapabilityEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OutboundRouteFilteringCapabilityEntry) ProtoMessage() {}
+
+func (x *OutboundRouteFilteringCapabilityEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_api_capability_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OutboundRouteFilteringCapabilityEntry.ProtoReflect.Descriptor instead.
+func (*OutboundRouteFilteringCapabilityEntry) Descriptor() ([]byte, []int) {
+	return file_api_capability_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *OutboundRouteFilteringCapabilityEntry) GetType() uint32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *OutboundRouteFilteringCapabilityEntry) GetMode() OutboundRouteFilteringCapabilityEntry_Mode {
+	if x != nil {
+		return x.Mode
+	}
+	return OutboundRouteFilteringCapabilityEntry_MODE_UNSPECIFIED
+}
+
+type OutboundRouteFilteringCapabilityTuple struct {
+	state         protoimpl.MessageState                   `protogen:"open.v1"`
+	Family        *Family                                  `protobuf:"bytes,1,opt,name=family,proto3" json:"family,omitempty"`
+	Entries       []*OutboundRouteFilteringCapabilityEntry `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OutboundRouteFilteringCapabilityTuple) Reset() {
+	*x = OutboundRouteFilteringCapabilityTuple{}
+	mi := &file_api_capability_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OutboundRouteFilteringCapabilityTuple) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OutboundRouteFilteringCapabilityTuple) ProtoMessage() {}
+
+func (x *OutboundRouteFilteringCapabilityTuple) ProtoReflect() protoreflect.Message {
+	mi := &file_api_capability_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OutboundRouteFilteringCapabilityTuple.ProtoReflect.Descriptor instead.
+func (*OutboundRouteFilteringCapabilityTuple) Descriptor() ([]byte, []int) {
+	return file_api_capability_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *OutboundRouteFilteringCapabilityTuple) GetFamily() *Family {
+	if x != nil {
+		return x.Family
+	}
+	return nil
+}
+
+func (x *OutboundRouteFilteringCapabilityTuple) GetEntries() []*OutboundRouteFilteringCapabilityEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type OutboundRouteFilteringCapability struct {
+	state         protoimpl.MessageState                   `protogen:"open.v1"`
+	Tuples        []*OutboundRouteFilteringCapabilityTuple `protobuf:"bytes,1,rep,name=tuples,proto3" json:"tuples,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OutboundRouteFilteringCapability) Reset() {
+	*x = OutboundRouteFilteringCapability{}
+	mi := &file_api_capability_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OutboundRouteFilteringCapability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OutboundRouteFilteringCapability) ProtoMessage() {}
+
+func (x *OutboundRouteFilteringCapability) ProtoReflect() protoreflect.Message {
+	mi := &file_api_capability_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OutboundRouteFilteringCapability.ProtoReflect.Descriptor instead.
+func (*OutboundRouteFilteringCapability) Descriptor() ([]byte, []int) {
+	return file_api_capability_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *OutboundRouteFilteringCapability) GetTuples() []*OutboundRouteFilteringCapabilityTuple {
+	if x != nil {
+		return x.Tuples
+	}
+	return nil
+}
+
+type BgpsecCapability struct {
+	state         protoimpl.MessageState     `protogen:"open.v1"`
+	Version       uint32                     `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Direction     BgpsecCapability_Direction `protobuf:"varint,2,opt,name=direction,proto3,enum=api.BgpsecCapability_Direction" json:"direction,omitempty"`
+	Afi           Family_Afi                 `protobuf:"varint,3,opt,name=afi,proto3,enum=api.Family_Afi" json:"afi,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BgpsecCapability) Reset() {
+	*x = BgpsecCapability{}
+	mi := &file_api_capability_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BgpsecCapability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BgpsecCapability) ProtoMessage() {}
+
+func (x *BgpsecCapability) ProtoReflect() protoreflect.Message {
+	mi := &file_api_capability_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BgpsecCapability.ProtoReflect.Descriptor instead.
+func (*BgpsecCapability) Descriptor() ([]byte, []int) {
+	return file_api_capability_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *BgpsecCapability) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *BgpsecCapability) GetDirection() BgpsecCapability_Direction {
+	if x != nil {
+		return x.Direction
+	}
+	return BgpsecCapability_DIRECTION_UNSPECIFIED
+}
+
+func (x *BgpsecCapability) GetAfi() Family_Afi {
+	if x != nil {
+		return x.Afi
+	}
+	return Family_AFI_UNSPECIFIED
+}
+
 type UnknownCapability struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Code          uint32                 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
@@ -1101,7 +1443,7 @@ type UnknownCapability struct {
 
 func (x *UnknownCapability) Reset() {
 	*x = UnknownCapability{}
-	mi := &file_api_capability_proto_msgTypes[17]
+	mi := &file_api_capability_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1113,7 +1455,7 @@ func (x *UnknownCapability) String() string {
 func (*UnknownCapability) ProtoMessage() {}
 
 func (x *UnknownCapability) ProtoReflect() protoreflect.Message {
-	mi := &file_api_capability_proto_msgTypes[17]
+	mi := &file_api_capability_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1126,7 +1468,7 @@ func (x *UnknownCapability) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UnknownCapability.ProtoReflect.Descriptor instead.
 func (*UnknownCapability) Descriptor() ([]byte, []int) {
-	return file_api_capability_proto_rawDescGZIP(), []int{17}
+	return file_api_capability_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *UnknownCapability) GetCode() uint32 {
@@ -1147,7 +1489,7 @@ var File_api_capability_proto protoreflect.FileDescriptor
 
 const file_api_capability_proto_rawDesc = "" +
 	"\n" +
-	"\x14api/capability.proto\x12\x03api\x1a\x10api/common.proto\"\xcd\a\n" +
+	"\x14api/capability.proto\x12\x03api\x1a\x10api/common.proto\"\xe1\b\n" +
 	"\n" +
 	"Capability\x122\n" +
 	"\aunknown\x18\x01 \x01(\v2\x16.api.UnknownCapabilityH\x00R\aunknown\x12E\n" +
@@ -1163,7 +1505,9 @@ const file_api_capability_proto_rawDesc = "" +
 	" \x01(\v2'.api.LongLivedGracefulRestartCapabilityH\x00R\x18longLivedGracefulRestart\x12R\n" +
 	"\x13route_refresh_cisco\x18\v \x01(\v2 .api.RouteRefreshCiscoCapabilityH\x00R\x11routeRefreshCisco\x12)\n" +
 	"\x04fqdn\x18\f \x01(\v2\x13.api.FqdnCapabilityH\x00R\x04fqdn\x12K\n" +
-	"\x10software_version\x18\r \x01(\v2\x1e.api.SoftwareVersionCapabilityH\x00R\x0fsoftwareVersionB\x05\n" +
+	"\x10software_version\x18\r \x01(\v2\x1e.api.SoftwareVersionCapabilityH\x00R\x0fsoftwareVersion\x12a\n" +
+	"\x18outbound_route_filtering\x18\x0e \x01(\v2%.api.OutboundRouteFilteringCapabilityH\x00R\x16outboundRouteFiltering\x12/\n" +
+	"\x06bgpsec\x18\x0f \x01(\v2\x15.api.BgpsecCapabilityH\x00R\x06bgpsecB\x05\n" +
 	"\x03cap\">\n" +
 	"\x17MultiProtocolCapability\x12#\n" +
 	"\x06family\x18\x01 \x01(\v2\v.api.FamilyR\x06family\"\x18\n" +
@@ -1207,7 +1551,28 @@ const file_api_capability_proto_rawDesc = "" +
 	"\vdomain_name\x18\x02 \x01(\tR\n" +
 	"domainName\"F\n" +
 	"\x19SoftwareVersionCapability\x12)\n" +
-	"\x10software_version\x18\x01 \x01(\tR\x0fsoftwareVersion\"=\n" +
+	"\x10software_version\x18\x01 \x01(\tR\x0fsoftwareVersion\"\xce\x01\n" +
+	"%OutboundRouteFilteringCapabilityEntry\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\rR\x04type\x12C\n" +
+	"\x04mode\x18\x02 \x01(\x0e2/.api.OutboundRouteFilteringCapabilityEntry.ModeR\x04mode\"L\n" +
+	"\x04Mode\x12\x14\n" +
+	"\x10MODE_UNSPECIFIED\x10\x00\x12\x10\n" +
+	"\fMODE_RECEIVE\x10\x01\x12\r\n" +
+	"\tMODE_SEND\x10\x02\x12\r\n" +
+	"\tMODE_BOTH\x10\x03\"\x92\x01\n" +
+	"%OutboundRouteFilteringCapabilityTuple\x12#\n" +
+	"\x06family\x18\x01 \x01(\v2\v.api.FamilyR\x06family\x12D\n" +
+	"\aentries\x18\x02 \x03(\v2*.api.OutboundRouteFilteringCapabilityEntryR\aentries\"f\n" +
+	" OutboundRouteFilteringCapability\x12B\n" +
+	"\x06tuples\x18\x01 \x03(\v2*.api.OutboundRouteFilteringCapabilityTupleR\x06tuples\"\xe1\x01\n" +
+	"\x10BgpsecCapability\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\rR\aversion\x12=\n" +
+	"\tdirection\x18\x02 \x01(\x0e2\x1f.api.BgpsecCapability.DirectionR\tdirection\x12!\n" +
+	"\x03afi\x18\x03 \x01(\x0e2\x0f.api.Family.AfiR\x03afi\"Q\n" +
+	"\tDirection\x12\x19\n" +
+	"\x15DIRECTION_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11DIRECTION_RECEIVE\x10\x01\x12\x12\n" +
+	"\x0eDIRECTION_SEND\x10\x02\"=\n" +
 	"\x11UnknownCapability\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\rR\x04code\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\fR\x05valueB\"Z github.com/osrg/gobgp/v4/api;apib\x06proto3"
@@ -1224,60 +1589,75 @@ func file_api_capability_proto_rawDescGZIP() []byte {
 	return file_api_capability_proto_rawDescData
 }
 
-var file_api_capability_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_api_capability_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_api_capability_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_api_capability_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
 var file_api_capability_proto_goTypes = []any{
 	(AddPathCapabilityTuple_Mode)(0),                // 0: api.AddPathCapabilityTuple.Mode
-	(*Capability)(nil),                              // 1: api.Capability
-	(*MultiProtocolCapability)(nil),                 // 2: api.MultiProtocolCapability
-	(*RouteRefreshCapability)(nil),                  // 3: api.RouteRefreshCapability
-	(*CarryingLabelInfoCapability)(nil),             // 4: api.CarryingLabelInfoCapability
-	(*ExtendedNexthopCapabilityTuple)(nil),          // 5: api.ExtendedNexthopCapabilityTuple
-	(*ExtendedNexthopCapability)(nil),               // 6: api.ExtendedNexthopCapability
-	(*GracefulRestartCapabilityTuple)(nil),          // 7: api.GracefulRestartCapabilityTuple
-	(*GracefulRestartCapability)(nil),               // 8: api.GracefulRestartCapability
-	(*FourOctetASNCapability)(nil),                  // 9: api.FourOctetASNCapability
-	(*AddPathCapabilityTuple)(nil),                  // 10: api.AddPathCapabilityTuple
-	(*AddPathCapability)(nil),                       // 11: api.AddPathCapability
-	(*EnhancedRouteRefreshCapability)(nil),          // 12: api.EnhancedRouteRefreshCapability
-	(*LongLivedGracefulRestartCapabilityTuple)(nil), // 13: api.LongLivedGracefulRestartCapabilityTuple
-	(*LongLivedGracefulRestartCapability)(nil),      // 14: api.LongLivedGracefulRestartCapability
-	(*RouteRefreshCiscoCapability)(nil),             // 15: api.RouteRefreshCiscoCapability
-	(*FqdnCapability)(nil),                          // 16: api.FqdnCapability
-	(*SoftwareVersionCapability)(nil),               // 17: api.SoftwareVersionCapability
-	(*UnknownCapability)(nil),                       // 18: api.UnknownCapability
-	(*Family)(nil),                                  // 19: api.Family
+	(OutboundRouteFilteringCapabilityEntry_Mode)(0), // 1: api.OutboundRouteFilteringCapabilityEntry.Mode
+	(BgpsecCapability_Direction)(0),                 // 2: api.BgpsecCapability.Direction
+	(*Capability)(nil),                              // 3: api.Capability
+	(*MultiProtocolCapability)(nil),                 // 4: api.MultiProtocolCapability
+	(*RouteRefreshCapability)(nil),                  // 5: api.RouteRefreshCapability
+	(*CarryingLabelInfoCapability)(nil),             // 6: api.CarryingLabelInfoCapability
+	(*ExtendedNexthopCapabilityTuple)(nil),          // 7: api.ExtendedNexthopCapabilityTuple
+	(*ExtendedNexthopCapability)(nil),               // 8: api.ExtendedNexthopCapability
+	(*GracefulRestartCapabilityTuple)(nil),          // 9: api.GracefulRestartCapabilityTuple
+	(*GracefulRestartCapability)(nil),               // 10: api.GracefulRestartCapability
+	(*FourOctetASNCapability)(nil),                  // 11: api.FourOctetASNCapability
+	(*AddPathCapabilityTuple)(nil),                  // 12: api.AddPathCapabilityTuple
+	(*AddPathCapability)(nil),                       // 13: api.AddPathCapability
+	(*EnhancedRouteRefreshCapability)(nil),          // 14: api.EnhancedRouteRefreshCapability
+	(*LongLivedGracefulRestartCapabilityTuple)(nil), // 15: api.LongLivedGracefulRestartCapabilityTuple
+	(*LongLivedGracefulRestartCapability)(nil),      // 16: api.LongLivedGracefulRestartCapability
+	(*RouteRefreshCiscoCapability)(nil),             // 17: api.RouteRefreshCiscoCapability
+	(*FqdnCapability)(nil),                          // 18: api.FqdnCapability
+	(*SoftwareVersionCapability)(nil),               // 19: api.SoftwareVersionCapability
+	(*OutboundRouteFilteringCapabilityEntry)(nil),   // 20: api.OutboundRouteFilteringCapabilityEntry
+	(*OutboundRouteFilteringCapabilityTuple)(nil),   // 21: api.OutboundRouteFilteringCapabilityTuple
+	(*OutboundRouteFilteringCapability)(nil),        // 22: api.OutboundRouteFilteringCapability
+	(*BgpsecCapability)(nil),                        // 23: api.BgpsecCapability
+	(*UnknownCapability)(nil),                       // 24: api.UnknownCapability
+	(*Family)(nil),                                  // 25: api.Family
+	(Family_Afi)(0),                                 // 26: api.Family.Afi
 }
 var file_api_capability_proto_depIdxs = []int32{
-	18, // 0: api.Capability.unknown:type_name -> api.UnknownCapability
-	2,  // 1: api.Capability.multi_protocol:type_name -> api.MultiProtocolCapability
-	3,  // 2: api.Capability.route_refresh:type_name -> api.RouteRefreshCapability
-	4,  // 3: api.Capability.carrying_label_info:type_name -> api.CarryingLabelInfoCapability
-	6,  // 4: api.Capability.extended_nexthop:type_name -> api.ExtendedNexthopCapability
-	8,  // 5: api.Capability.graceful_restart:type_name -> api.GracefulRestartCapability
-	9,  // 6: api.Capability.four_octet_asn:type_name -> api.FourOctetASNCapability
-	11, // 7: api.Capability.add_path:type_name -> api.AddPathCapability
-	12, // 8: api.Capability.enhanced_route_refresh:type_name -> api.EnhancedRouteRefreshCapability
-	14, // 9: api.Capability.long_lived_graceful_restart:type_name -> api.LongLivedGracefulRestartCapability
-	15, // 10: api.Capability.route_refresh_cisco:type_name -> api.RouteRefreshCiscoCapability
-	16, // 11: api.Capability.fqdn:type_name -> api.FqdnCapability
-	17, // 12: api.Capability.software_version:type_name -> api.SoftwareVersionCapability
-	19, // 13: api.MultiProtocolCapability.family:type_name -> api.Family
-	19, // 14: api.ExtendedNexthopCapabilityTuple.nlri_family:type_name -> api.Family
-	19, // 15: api.ExtendedNexthopCapabilityTuple.nexthop_family:type_name -> api.Family
-	5,  // 16: api.ExtendedNexthopCapability.tuples:type_name -> api.ExtendedNexthopCapabilityTuple
-	19, // 17: api.GracefulRestartCapabilityTuple.family:type_name -> api.Family
-	7,  // 18: api.GracefulRestartCapability.tuples:type_name -> api.GracefulRestartCapabilityTuple
-	19, // 19: api.AddPathCapabilityTuple.family:type_name -> api.Family
-	0,  // 20: api.AddPathCapabilityTuple.mode:type_name -> api.AddPathCapabilityTuple.Mode
-	10, // 21: api.AddPathCapability.tuples:type_name -> api.AddPathCapabilityTuple
-	19, // 22: api.LongLivedGracefulRestartCapabilityTuple.family:type_name -> api.Family
-	13, // 23: api.LongLivedGracefulRestartCapability.tuples:type_name -> api.LongLivedGracefulRestartCapabilityTuple
-	24, // [24:24] is the sub-list for method output_type
-	24, // [24:24] is the sub-list for method input_type
-	24, // [24:24] is the sub-list for extension type_name
-	24, // [24:24] is the sub-list for extension extendee
-	0,  // [0:24] is the sub-list for field type_name
+	24, // 0: api.Capability.unknown:type_name -> api.UnknownCapability
+	4,  // 1: api.Capability.multi_protocol:type_name -> api.MultiProtocolCapability
+	5,  // 2: api.Capability.route_refresh:type_name -> api.RouteRefreshCapability
+	6,  // 3: api.Capability.carrying_label_info:type_name -> api.CarryingLabelInfoCapability
+	8,  // 4: api.Capability.extended_nexthop:type_name -> api.ExtendedNexthopCapability
+	10, // 5: api.Capability.graceful_restart:type_name -> api.GracefulRestartCapability
+	11, // 6: api.Capability.four_octet_asn:type_name -> api.FourOctetASNCapability
+	13, // 7: api.Capability.add_path:type_name -> api.AddPathCapability
+	14, // 8: api.Capability.enhanced_route_refresh:type_name -> api.EnhancedRouteRefreshCapability
+	16, // 9: api.Capability.long_lived_graceful_restart:type_name -> api.LongLivedGracefulRestartCapability
+	17, // 10: api.Capability.route_refresh_cisco:type_name -> api.RouteRefreshCiscoCapability
+	18, // 11: api.Capability.fqdn:type_name -> api.FqdnCapability
+	19, // 12: api.Capability.software_version:type_name -> api.SoftwareVersionCapability
+	22, // 13: api.Capability.outbound_route_filtering:type_name -> api.OutboundRouteFilteringCapability
+	23, // 14: api.Capability.bgpsec:type_name -> api.BgpsecCapability
+	25, // 15: api.MultiProtocolCapability.family:type_name -> api.Family
+	25, // 16: api.ExtendedNexthopCapabilityTuple.nlri_family:type_name -> api.Family
+	25, // 17: api.ExtendedNexthopCapabilityTuple.nexthop_family:type_name -> api.Family
+	7,  // 18: api.ExtendedNexthopCapability.tuples:type_name -> api.ExtendedNexthopCapabilityTuple
+	25, // 19: api.GracefulRestartCapabilityTuple.family:type_name -> api.Family
+	9,  // 20: api.GracefulRestartCapability.tuples:type_name -> api.GracefulRestartCapabilityTuple
+	25, // 21: api.AddPathCapabilityTuple.family:type_name -> api.Family
+	0,  // 22: api.AddPathCapabilityTuple.mode:type_name -> api.AddPathCapabilityTuple.Mode
+	12, // 23: api.AddPathCapability.tuples:type_name -> api.AddPathCapabilityTuple
+	25, // 24: api.LongLivedGracefulRestartCapabilityTuple.family:type_name -> api.Family
+	15, // 25: api.LongLivedGracefulRestartCapability.tuples:type_name -> api.LongLivedGracefulRestartCapabilityTuple
+	1,  // 26: api.OutboundRouteFilteringCapabilityEntry.mode:type_name -> api.OutboundRouteFilteringCapabilityEntry.Mode
+	25, // 27: api.OutboundRouteFilteringCapabilityTuple.family:type_name -> api.Family
+	20, // 28: api.OutboundRouteFilteringCapabilityTuple.entries:type_name -> api.OutboundRouteFilteringCapabilityEntry
+	21, // 29: api.OutboundRouteFilteringCapability.tuples:type_name -> api.OutboundRouteFilteringCapabilityTuple
+	2,  // 30: api.BgpsecCapability.direction:type_name -> api.BgpsecCapability.Direction
+	26, // 31: api.BgpsecCapability.afi:type_name -> api.Family.Afi
+	32, // [32:32] is the sub-list for method output_type
+	32, // [32:32] is the sub-list for method input_type
+	32, // [32:32] is the sub-list for extension type_name
+	32, // [32:32] is the sub-list for extension extendee
+	0,  // [0:32] is the sub-list for field type_name
 }
 
 func init() { file_api_capability_proto_init() }
@@ -1300,14 +1680,16 @@ func file_api_capability_proto_init() {
 		(*Capability_RouteRefreshCisco)(nil),
 		(*Capability_Fqdn)(nil),
 		(*Capability_SoftwareVersion)(nil),
+		(*Capability_OutboundRouteFiltering)(nil),
+		(*Capability_Bgpsec)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_capability_proto_rawDesc), len(file_api_capability_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   18,
+			NumEnums:      3,
+			NumMessages:   22,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -41,68 +41,99 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	GoBgpService_StartBgp_FullMethodName               = "/api.GoBgpService/StartBgp"
-	GoBgpService_StopBgp_FullMethodName                = "/api.GoBgpService/StopBgp"
-	GoBgpService_GetBgp_FullMethodName                 = "/api.GoBgpService/GetBgp"
-	GoBgpService_WatchEvent_FullMethodName             = "/api.GoBgpService/WatchEvent"
-	GoBgpService_AddPeer_FullMethodName                = "/api.GoBgpService/AddPeer"
-	GoBgpService_DeletePeer_FullMethodName             = "/api.GoBgpService/DeletePeer"
-	GoBgpService_ListPeer_FullMethodName               = "/api.GoBgpService/ListPeer"
-	GoBgpService_UpdatePeer_FullMethodName             = "/api.GoBgpService/UpdatePeer"
-	GoBgpService_ResetPeer_FullMethodName              = "/api.GoBgpService/ResetPeer"
-	GoBgpService_ShutdownPeer_FullMethodName           = "/api.GoBgpService/ShutdownPeer"
-	GoBgpService_EnablePeer_FullMethodName             = "/api.GoBgpService/EnablePeer"
-	GoBgpService_DisablePeer_FullMethodName            = "/api.GoBgpService/DisablePeer"
-	GoBgpService_AddPeerGroup_FullMethodName           = "/api.GoBgpService/AddPeerGroup"
-	GoBgpService_DeletePeerGroup_FullMethodName        = "/api.GoBgpService/DeletePeerGroup"
-	GoBgpService_ListPeerGroup_FullMethodName          = "/api.GoBgpService/ListPeerGroup"
-	GoBgpService_UpdatePeerGroup_FullMethodName        = "/api.GoBgpService/UpdatePeerGroup"
-	GoBgpService_AddDynamicNeighbor_FullMethodName     = "/api.GoBgpService/AddDynamicNeighbor"
-	GoBgpService_ListDynamicNeighbor_FullMethodName    = "/api.GoBgpService/ListDynamicNeighbor"
-	GoBgpService_DeleteDynamicNeighbor_FullMethodName  = "/api.GoBgpService/DeleteDynamicNeighbor"
-	GoBgpService_AddPath_FullMethodName                = "/api.GoBgpService/AddPath"
-	GoBgpService_DeletePath_FullMethodName             = "/api.GoBgpService/DeletePath"
-	GoBgpService_ListPath_FullMethodName               = "/api.GoBgpService/ListPath"
-	GoBgpService_AddPathStream_FullMethodName          = "/api.GoBgpService/AddPathStream"
-	GoBgpService_GetTable_FullMethodName               = "/api.GoBgpService/GetTable"
-	GoBgpService_AddVrf_FullMethodName                 = "/api.GoBgpService/AddVrf"
-	GoBgpService_DeleteVrf_FullMethodName              = "/api.GoBgpService/DeleteVrf"
-	GoBgpService_ListVrf_FullMethodName                = "/api.GoBgpService/ListVrf"
-	GoBgpService_AddPolicy_FullMethodName              = "/api.GoBgpService/AddPolicy"
-	GoBgpService_DeletePolicy_FullMethodName           = "/api.GoBgpService/DeletePolicy"
-	GoBgpService_ListPolicy_FullMethodName             = "/api.GoBgpService/ListPolicy"
-	GoBgpService_SetPolicies_FullMethodName            = "/api.GoBgpService/SetPolicies"
-	GoBgpService_AddDefinedSet_FullMethodName          = "/api.GoBgpService/AddDefinedSet"
-	GoBgpService_DeleteDefinedSet_FullMethodName       = "/api.GoBgpService/DeleteDefinedSet"
-	GoBgpService_ListDefinedSet_FullMethodName         = "/api.GoBgpService/ListDefinedSet"
-	GoBgpService_AddStatement_FullMethodName           = "/api.GoBgpService/AddStatement"
-	GoBgpService_DeleteStatement_FullMethodName        = "/api.GoBgpService/DeleteStatement"
-	GoBgpService_ListStatement_FullMethodName          = "/api.GoBgpService/ListStatement"
-	GoBgpService_AddPolicyAssignment_FullMethodName    = "/api.GoBgpService/AddPolicyAssignment"
-	GoBgpService_DeletePolicyAssignment_FullMethodName = "/api.GoBgpService/DeletePolicyAssignment"
-	GoBgpService_ListPolicyAssignment_FullMethodName   = "/api.GoBgpService/ListPolicyAssignment"
-	GoBgpService_SetPolicyAssignment_FullMethodName    = "/api.GoBgpService/SetPolicyAssignment"
-	GoBgpService_AddRpki_FullMethodName                = "/api.GoBgpService/AddRpki"
-	GoBgpService_DeleteRpki_FullMethodName             = "/api.GoBgpService/DeleteRpki"
-	GoBgpService_ListRpki_FullMethodName               = "/api.GoBgpService/ListRpki"
-	GoBgpService_EnableRpki_FullMethodName             = "/api.GoBgpService/EnableRpki"
-	GoBgpService_DisableRpki_FullMethodName            = "/api.GoBgpService/DisableRpki"
-	GoBgpService_ResetRpki_FullMethodName              = "/api.GoBgpService/ResetRpki"
-	GoBgpService_ListRpkiTable_FullMethodName          = "/api.GoBgpService/ListRpkiTable"
-	GoBgpService_EnableZebra_FullMethodName            = "/api.GoBgpService/EnableZebra"
-	GoBgpService_GetNetlink_FullMethodName             = "/api.GoBgpService/GetNetlink"
-	GoBgpService_EnableNetlink_FullMethodName          = "/api.GoBgpService/EnableNetlink"
-	GoBgpService_GetNetlinkImportStats_FullMethodName  = "/api.GoBgpService/GetNetlinkImportStats"
-	GoBgpService_ListNetlinkExport_FullMethodName      = "/api.GoBgpService/ListNetlinkExport"
-	GoBgpService_GetNetlinkExportStats_FullMethodName  = "/api.GoBgpService/GetNetlinkExportStats"
-	GoBgpService_FlushNetlinkExport_FullMethodName     = "/api.GoBgpService/FlushNetlinkExport"
-	GoBgpService_ListNetlinkExportRules_FullMethodName = "/api.GoBgpService/ListNetlinkExportRules"
-	GoBgpService_EnableMrt_FullMethodName              = "/api.GoBgpService/EnableMrt"
-	GoBgpService_DisableMrt_FullMethodName             = "/api.GoBgpService/DisableMrt"
-	GoBgpService_AddBmp_FullMethodName                 = "/api.GoBgpService/AddBmp"
-	GoBgpService_DeleteBmp_FullMethodName              = "/api.GoBgpService/DeleteBmp"
-	GoBgpService_ListBmp_FullMethodName                = "/api.GoBgpService/ListBmp"
-	GoBgpService_SetLogLevel_FullMethodName            = "/api.GoBgpService/SetLogLevel"
+	GoBgpService_StartBgp_FullMethodName                = "/api.GoBgpService/StartBgp"
+	GoBgpService_StopBgp_FullMethodName                 = "/api.GoBgpService/StopBgp"
+	GoBgpService_GetBgp_FullMethodName                  = "/api.GoBgpService/GetBgp"
+	GoBgpService_WatchEvent_FullMethodName              = "/api.GoBgpService/WatchEvent"
+	GoBgpService_AddPeer_FullMethodName                 = "/api.GoBgpService/AddPeer"
+	GoBgpService_DeletePeer_FullMethodName              = "/api.GoBgpService/DeletePeer"
+	GoBgpService_ListPeer_FullMethodName                = "/api.GoBgpService/ListPeer"
+	GoBgpService_UpdatePeer_FullMethodName              = "/api.GoBgpService/UpdatePeer"
+	GoBgpService_ResetPeer_FullMethodName               = "/api.GoBgpService/ResetPeer"
+	GoBgpService_ShutdownPeer_FullMethodName            = "/api.GoBgpService/ShutdownPeer"
+	GoBgpService_EnablePeer_FullMethodName              = "/api.GoBgpService/EnablePeer"
+	GoBgpService_DisablePeer_FullMethodName             = "/api.GoBgpService/DisablePeer"
+	GoBgpService_ListUpdateGroup_FullMethodName         = "/api.GoBgpService/ListUpdateGroup"
+	GoBgpService_AddPeerGroup_FullMethodName            = "/api.GoBgpService/AddPeerGroup"
+	GoBgpService_DeletePeerGroup_FullMethodName         = "/api.GoBgpService/DeletePeerGroup"
+	GoBgpService_ListPeerGroup_FullMethodName           = "/api.GoBgpService/ListPeerGroup"
+	GoBgpService_UpdatePeerGroup_FullMethodName         = "/api.GoBgpService/UpdatePeerGroup"
+	GoBgpService_AddDynamicNeighbor_FullMethodName      = "/api.GoBgpService/AddDynamicNeighbor"
+	GoBgpService_ListDynamicNeighbor_FullMethodName     = "/api.GoBgpService/ListDynamicNeighbor"
+	GoBgpService_DeleteDynamicNeighbor_FullMethodName   = "/api.GoBgpService/DeleteDynamicNeighbor"
+	GoBgpService_AddPath_FullMethodName                 = "/api.GoBgpService/AddPath"
+	GoBgpService_DeletePath_FullMethodName              = "/api.GoBgpService/DeletePath"
+	GoBgpService_ListPath_FullMethodName                = "/api.GoBgpService/ListPath"
+	GoBgpService_AddPathStream_FullMethodName           = "/api.GoBgpService/AddPathStream"
+	GoBgpService_UpdateLsTopology_FullMethodName        = "/api.GoBgpService/UpdateLsTopology"
+	GoBgpService_GetTable_FullMethodName                = "/api.GoBgpService/GetTable"
+	GoBgpService_AddVrf_FullMethodName                  = "/api.GoBgpService/AddVrf"
+	GoBgpService_DeleteVrf_FullMethodName               = "/api.GoBgpService/DeleteVrf"
+	GoBgpService_ListVrf_FullMethodName                 = "/api.GoBgpService/ListVrf"
+	GoBgpService_AddPolicy_FullMethodName               = "/api.GoBgpService/AddPolicy"
+	GoBgpService_DeletePolicy_FullMethodName            = "/api.GoBgpService/DeletePolicy"
+	GoBgpService_ListPolicy_FullMethodName              = "/api.GoBgpService/ListPolicy"
+	GoBgpService_SetPolicies_FullMethodName             = "/api.GoBgpService/SetPolicies"
+	GoBgpService_TestPolicy_FullMethodName              = "/api.GoBgpService/TestPolicy"
+	GoBgpService_StartPolicyTransaction_FullMethodName  = "/api.GoBgpService/StartPolicyTransaction"
+	GoBgpService_DiffPolicyTransaction_FullMethodName   = "/api.GoBgpService/DiffPolicyTransaction"
+	GoBgpService_CommitPolicyTransaction_FullMethodName = "/api.GoBgpService/CommitPolicyTransaction"
+	GoBgpService_AbortPolicyTransaction_FullMethodName  = "/api.GoBgpService/AbortPolicyTransaction"
+	GoBgpService_ListPolicyTransaction_FullMethodName   = "/api.GoBgpService/ListPolicyTransaction"
+	GoBgpService_ListPolicyVersion_FullMethodName       = "/api.GoBgpService/ListPolicyVersion"
+	GoBgpService_RollbackPolicy_FullMethodName          = "/api.GoBgpService/RollbackPolicy"
+	GoBgpService_AddDefinedSet_FullMethodName           = "/api.GoBgpService/AddDefinedSet"
+	GoBgpService_DeleteDefinedSet_FullMethodName        = "/api.GoBgpService/DeleteDefinedSet"
+	GoBgpService_ListDefinedSet_FullMethodName          = "/api.GoBgpService/ListDefinedSet"
+	GoBgpService_AddDefinedSetFile_FullMethodName       = "/api.GoBgpService/AddDefinedSetFile"
+	GoBgpService_DeleteDefinedSetFile_FullMethodName    = "/api.GoBgpService/DeleteDefinedSetFile"
+	GoBgpService_ListDefinedSetFile_FullMethodName      = "/api.GoBgpService/ListDefinedSetFile"
+	GoBgpService_ReloadDefinedSetFile_FullMethodName    = "/api.GoBgpService/ReloadDefinedSetFile"
+	GoBgpService_AddStatement_FullMethodName            = "/api.GoBgpService/AddStatement"
+	GoBgpService_DeleteStatement_FullMethodName         = "/api.GoBgpService/DeleteStatement"
+	GoBgpService_ListStatement_FullMethodName           = "/api.GoBgpService/ListStatement"
+	GoBgpService_AddPolicyAssignment_FullMethodName     = "/api.GoBgpService/AddPolicyAssignment"
+	GoBgpService_DeletePolicyAssignment_FullMethodName  = "/api.GoBgpService/DeletePolicyAssignment"
+	GoBgpService_ListPolicyAssignment_FullMethodName    = "/api.GoBgpService/ListPolicyAssignment"
+	GoBgpService_SetPolicyAssignment_FullMethodName     = "/api.GoBgpService/SetPolicyAssignment"
+	GoBgpService_ResetPolicyStatistics_FullMethodName   = "/api.GoBgpService/ResetPolicyStatistics"
+	GoBgpService_AddRpki_FullMethodName                 = "/api.GoBgpService/AddRpki"
+	GoBgpService_DeleteRpki_FullMethodName              = "/api.GoBgpService/DeleteRpki"
+	GoBgpService_ListRpki_FullMethodName                = "/api.GoBgpService/ListRpki"
+	GoBgpService_EnableRpki_FullMethodName              = "/api.GoBgpService/EnableRpki"
+	GoBgpService_DisableRpki_FullMethodName             = "/api.GoBgpService/DisableRpki"
+	GoBgpService_ResetRpki_FullMethodName               = "/api.GoBgpService/ResetRpki"
+	GoBgpService_ListRpkiTable_FullMethodName           = "/api.GoBgpService/ListRpkiTable"
+	GoBgpService_AddRpkiFile_FullMethodName             = "/api.GoBgpService/AddRpkiFile"
+	GoBgpService_DeleteRpkiFile_FullMethodName          = "/api.GoBgpService/DeleteRpkiFile"
+	GoBgpService_ListRpkiFile_FullMethodName            = "/api.GoBgpService/ListRpkiFile"
+	GoBgpService_ReloadRpkiFile_FullMethodName          = "/api.GoBgpService/ReloadRpkiFile"
+	GoBgpService_EnableZebra_FullMethodName             = "/api.GoBgpService/EnableZebra"
+	GoBgpService_GetNetlink_FullMethodName              = "/api.GoBgpService/GetNetlink"
+	GoBgpService_EnableNetlink_FullMethodName           = "/api.GoBgpService/EnableNetlink"
+	GoBgpService_GetNetlinkImportStats_FullMethodName   = "/api.GoBgpService/GetNetlinkImportStats"
+	GoBgpService_ListNetlinkExport_FullMethodName       = "/api.GoBgpService/ListNetlinkExport"
+	GoBgpService_GetNetlinkExportStats_FullMethodName   = "/api.GoBgpService/GetNetlinkExportStats"
+	GoBgpService_FlushNetlinkExport_FullMethodName      = "/api.GoBgpService/FlushNetlinkExport"
+	GoBgpService_ListNetlinkExportRules_FullMethodName  = "/api.GoBgpService/ListNetlinkExportRules"
+	GoBgpService_EnableMrt_FullMethodName               = "/api.GoBgpService/EnableMrt"
+	GoBgpService_DisableMrt_FullMethodName              = "/api.GoBgpService/DisableMrt"
+	GoBgpService_ListMrt_FullMethodName                 = "/api.GoBgpService/ListMrt"
+	GoBgpService_StartMrtReplay_FullMethodName          = "/api.GoBgpService/StartMrtReplay"
+	GoBgpService_StopMrtReplay_FullMethodName           = "/api.GoBgpService/StopMrtReplay"
+	GoBgpService_PauseMrtReplay_FullMethodName          = "/api.GoBgpService/PauseMrtReplay"
+	GoBgpService_ResumeMrtReplay_FullMethodName         = "/api.GoBgpService/ResumeMrtReplay"
+	GoBgpService_SeekMrtReplay_FullMethodName           = "/api.GoBgpService/SeekMrtReplay"
+	GoBgpService_ListMrtReplay_FullMethodName           = "/api.GoBgpService/ListMrtReplay"
+	GoBgpService_AddBmp_FullMethodName                  = "/api.GoBgpService/AddBmp"
+	GoBgpService_DeleteBmp_FullMethodName               = "/api.GoBgpService/DeleteBmp"
+	GoBgpService_ListBmp_FullMethodName                 = "/api.GoBgpService/ListBmp"
+	GoBgpService_EnableBmpStation_FullMethodName        = "/api.GoBgpService/EnableBmpStation"
+	GoBgpService_DisableBmpStation_FullMethodName       = "/api.GoBgpService/DisableBmpStation"
+	GoBgpService_ListBmpRouter_FullMethodName           = "/api.GoBgpService/ListBmpRouter"
+	GoBgpService_ListBmpMonitoredPeer_FullMethodName    = "/api.GoBgpService/ListBmpMonitoredPeer"
+	GoBgpService_ListBmpRoute_FullMethodName            = "/api.GoBgpService/ListBmpRoute"
+	GoBgpService_SetLogLevel_FullMethodName             = "/api.GoBgpService/SetLogLevel"
 )
 
 // GoBgpServiceClient is the client API for GoBgpService service.
@@ -123,6 +154,7 @@ type GoBgpServiceClient interface {
 	ShutdownPeer(ctx context.Context, in *ShutdownPeerRequest, opts ...grpc.CallOption) (*ShutdownPeerResponse, error)
 	EnablePeer(ctx context.Context, in *EnablePeerRequest, opts ...grpc.CallOption) (*EnablePeerResponse, error)
 	DisablePeer(ctx context.Context, in *DisablePeerRequest, opts ...grpc.CallOption) (*DisablePeerResponse, error)
+	ListUpdateGroup(ctx context.Context, in *ListUpdateGroupRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListUpdateGroupResponse], error)
 	AddPeerGroup(ctx context.Context, in *AddPeerGroupRequest, opts ...grpc.CallOption) (*AddPeerGroupResponse, error)
 	DeletePeerGroup(ctx context.Context, in *DeletePeerGroupRequest, opts ...grpc.CallOption) (*DeletePeerGroupResponse, error)
 	ListPeerGroup(ctx context.Context, in *ListPeerGroupRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListPeerGroupResponse], error)
@@ -134,6 +166,7 @@ type GoBgpServiceClient interface {
 	DeletePath(ctx context.Context, in *DeletePathRequest, opts ...grpc.CallOption) (*DeletePathResponse, error)
 	ListPath(ctx context.Context, in *ListPathRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListPathResponse], error)
 	AddPathStream(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[AddPathStreamRequest, AddPathStreamResponse], error)
+	UpdateLsTopology(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UpdateLsTopologyRequest, UpdateLsTopologyResponse], error)
 	GetTable(ctx context.Context, in *GetTableRequest, opts ...grpc.CallOption) (*GetTableResponse, error)
 	AddVrf(ctx context.Context, in *AddVrfRequest, opts ...grpc.CallOption) (*AddVrfResponse, error)
 	DeleteVrf(ctx context.Context, in *DeleteVrfRequest, opts ...grpc.CallOption) (*DeleteVrfResponse, error)
@@ -142,9 +175,21 @@ type GoBgpServiceClient interface {
 	DeletePolicy(ctx context.Context, in *DeletePolicyRequest, opts ...grpc.CallOption) (*DeletePolicyResponse, error)
 	ListPolicy(ctx context.Context, in *ListPolicyRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListPolicyResponse], error)
 	SetPolicies(ctx context.Context, in *SetPoliciesRequest, opts ...grpc.CallOption) (*SetPoliciesResponse, error)
+	TestPolicy(ctx context.Context, in *TestPolicyRequest, opts ...grpc.CallOption) (*TestPolicyResponse, error)
+	StartPolicyTransaction(ctx context.Context, in *StartPolicyTransactionRequest, opts ...grpc.CallOption) (*StartPolicyTransactionResponse, error)
+	DiffPolicyTransaction(ctx context.Context, in *DiffPolicyTransactionRequest, opts ...grpc.CallOption) (*DiffPolicyTransactionResponse, error)
+	CommitPolicyTransaction(ctx context.Context, in *CommitPolicyTransactionRequest, opts ...grpc.CallOption) (*CommitPolicyTransactionResponse, error)
+	AbortPolicyTransaction(ctx context.Context, in *AbortPolicyTransactionRequest, opts ...grpc.CallOption) (*AbortPolicyTransactionResponse, error)
+	ListPolicyTransaction(ctx context.Context, in *ListPolicyTransactionRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListPolicyTransactionResponse], error)
+	ListPolicyVersion(ctx context.Context, in *ListPolicyVersionRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListPolicyVersionResponse], error)
+	RollbackPolicy(ctx context.Context, in *RollbackPolicyRequest, opts ...grpc.CallOption) (*RollbackPolicyResponse, error)
 	AddDefinedSet(ctx context.Context, in *AddDefinedSetRequest, opts ...grpc.CallOption) (*AddDefinedSetResponse, error)
 	DeleteDefinedSet(ctx context.Context, in *DeleteDefinedSetRequest, opts ...grpc.CallOption) (*DeleteDefinedSetResponse, error)
 	ListDefinedSet(ctx context.Context, in *ListDefinedSetRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListDefinedSetResponse], error)
+	AddDefinedSetFile(ctx context.Context, in *AddDefinedSetFileRequest, opts ...grpc.CallOption) (*AddDefinedSetFileResponse, error)
+	DeleteDefinedSetFile(ctx context.Context, in *DeleteDefinedSetFileRequest, opts ...grpc.CallOption) (*DeleteDefinedSetFileResponse, error)
+	ListDefinedSetFile(ctx context.Context, in *ListDefinedSetFileRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListDefinedSetFileResponse], error)
+	ReloadDefinedSetFile(ctx context.Context, in *ReloadDefinedSetFileRequest, opts ...grpc.CallOption) (*ReloadDefinedSetFileResponse, error)
 	AddStatement(ctx context.Context, in *AddStatementRequest, opts ...grpc.CallOption) (*AddStatementResponse, error)
 	DeleteStatement(ctx context.Context, in *DeleteStatementRequest, opts ...grpc.CallOption) (*DeleteStatementResponse, error)
 	ListStatement(ctx context.Context, in *ListStatementRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListStatementResponse], error)
@@ -152,6 +197,7 @@ type GoBgpServiceClient interface {
 	DeletePolicyAssignment(ctx context.Context, in *DeletePolicyAssignmentRequest, opts ...grpc.CallOption) (*DeletePolicyAssignmentResponse, error)
 	ListPolicyAssignment(ctx context.Context, in *ListPolicyAssignmentRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListPolicyAssignmentResponse], error)
 	SetPolicyAssignment(ctx context.Context, in *SetPolicyAssignmentRequest, opts ...grpc.CallOption) (*SetPolicyAssignmentResponse, error)
+	ResetPolicyStatistics(ctx context.Context, in *ResetPolicyStatisticsRequest, opts ...grpc.CallOption) (*ResetPolicyStatisticsResponse, error)
 	AddRpki(ctx context.Context, in *AddRpkiRequest, opts ...grpc.CallOption) (*AddRpkiResponse, error)
 	DeleteRpki(ctx context.Context, in *DeleteRpkiRequest, opts ...grpc.CallOption) (*DeleteRpkiResponse, error)
 	ListRpki(ctx context.Context, in *ListRpkiRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListRpkiResponse], error)
@@ -159,6 +205,10 @@ type GoBgpServiceClient interface {
 	DisableRpki(ctx context.Context, in *DisableRpkiRequest, opts ...grpc.CallOption) (*DisableRpkiResponse, error)
 	ResetRpki(ctx context.Context, in *ResetRpkiRequest, opts ...grpc.CallOption) (*ResetRpkiResponse, error)
 	ListRpkiTable(ctx context.Context, in *ListRpkiTableRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListRpkiTableResponse], error)
+	AddRpkiFile(ctx context.Context, in *AddRpkiFileRequest, opts ...grpc.CallOption) (*AddRpkiFileResponse, error)
+	DeleteRpkiFile(ctx context.Context, in *DeleteRpkiFileRequest, opts ...grpc.CallOption) (*DeleteRpkiFileResponse, error)
+	ListRpkiFile(ctx context.Context, in *ListRpkiFileRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListRpkiFileResponse], error)
+	ReloadRpkiFile(ctx context.Context, in *ReloadRpkiFileRequest, opts ...grpc.CallOption) (*ReloadRpkiFileResponse, error)
 	EnableZebra(ctx context.Context, in *EnableZebraRequest, opts ...grpc.CallOption) (*EnableZebraResponse, error)
 	GetNetlink(ctx context.Context, in *GetNetlinkRequest, opts ...grpc.CallOption) (*GetNetlinkResponse, error)
 	EnableNetlink(ctx context.Context, in *EnableNetlinkRequest, opts ...grpc.CallOption) (*EnableNetlinkResponse, error)
@@ -169,9 +219,21 @@ type GoBgpServiceClient interface {
 	ListNetlinkExportRules(ctx context.Context, in *ListNetlinkExportRulesRequest, opts ...grpc.CallOption) (*ListNetlinkExportRulesResponse, error)
 	EnableMrt(ctx context.Context, in *EnableMrtRequest, opts ...grpc.CallOption) (*EnableMrtResponse, error)
 	DisableMrt(ctx context.Context, in *DisableMrtRequest, opts ...grpc.CallOption) (*DisableMrtResponse, error)
+	ListMrt(ctx context.Context, in *ListMrtRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListMrtResponse], error)
+	StartMrtReplay(ctx context.Context, in *StartMrtReplayRequest, opts ...grpc.CallOption) (*StartMrtReplayResponse, error)
+	StopMrtReplay(ctx context.Context, in *StopMrtReplayRequest, opts ...grpc.CallOption) (*StopMrtReplayResponse, error)
+	PauseMrtReplay(ctx context.Context, in *PauseMrtReplayRequest, opts ...grpc.CallOption) (*PauseMrtReplayResponse, error)
+	ResumeMrtReplay(ctx context.Context, in *ResumeMrtReplayRequest, opts ...grpc.CallOption) (*ResumeMrtReplayResponse, error)
+	SeekMrtReplay(ctx context.Context, in *SeekMrtReplayRequest, opts ...grpc.CallOption) (*SeekMrtReplayResponse, error)
+	ListMrtReplay(ctx context.Context, in *ListMrtReplayRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListMrtReplayResponse], error)
 	AddBmp(ctx context.Context, in *AddBmpRequest, opts ...grpc.CallOption) (*AddBmpResponse, error)
 	DeleteBmp(ctx context.Context, in *DeleteBmpRequest, opts ...grpc.CallOption) (*DeleteBmpResponse, error)
 	ListBmp(ctx context.Context, in *ListBmpRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListBmpResponse], error)
+	EnableBmpStation(ctx context.Context, in *EnableBmpStationRequest, opts ...grpc.CallOption) (*EnableBmpStationResponse, error)
+	DisableBmpStation(ctx context.Context, in *DisableBmpStationRequest, opts ...grpc.CallOption) (*DisableBmpStationResponse, error)
+	ListBmpRouter(ctx context.Context, in *ListBmpRouterRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListBmpRouterResponse], error)
+	ListBmpMonitoredPeer(ctx context.Context, in *ListBmpMonitoredPeerRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListBmpMonitoredPeerResponse], error)
+	ListBmpRoute(ctx context.Context, in *ListBmpRouteRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListBmpRouteResponse], error)
 	SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*SetLogLevelResponse, error)
 }
 
@@ -321,6 +383,25 @@ func (c *goBgpServiceClient) DisablePeer(ctx context.Context, in *DisablePeerReq
 	return out, nil
 }
 
+func (c *goBgpServiceClient) ListUpdateGroup(ctx context.Context, in *ListUpdateGroupRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListUpdateGroupResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &GoBgpService_ServiceDesc.Streams[2], GoBgpService_ListUpdateGroup_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListUpdateGroupRequest, ListUpdateGroupResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GoBgpService_ListUpdateGroupClient = grpc.ServerStreamingClient[ListUpdateGroupResponse]
+
 func (c *goBgpServiceClient) AddPeerGroup(ctx context.Context, in *AddPeerGroupRequest, opts ...grpc.CallOption) (*AddPeerGroupResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(AddPeerGroupResponse)
@@ -343,7 +424,7 @@ func (c *goBgpServiceClient) DeletePeerGroup(ctx context.Context, in *DeletePeer
 
 func (c *goBgpServiceClient) ListPeerGroup(ctx context.Context, in *ListPeerGroupRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListPeerGroupResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &GoBgpService_ServiceDesc.Streams[2], GoBgpService_ListPeerGroup_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &GoBgpService_ServiceDesc.Streams[3], GoBgpService_ListPeerGroup_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -382,7 +463,7 @@ func (c *goBgpServiceClient) AddDynamicNeighbor(ctx context.Context, in *AddDyna
 
 func (c *goBgpServiceClient) ListDynamicNeighbor(ctx context.Context, in *ListDynamicNeighborRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListDynamicNeighborResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &GoBgpService_ServiceDesc.Streams[3], GoBgpService_ListDynamicNeighbor_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &GoBgpService_ServiceDesc.Streams[4], GoBgpService_ListDynamicNeighbor_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -431,7 +512,7 @@ func (c *goBgpServiceClient) DeletePath(ctx context.Context, in *DeletePathReque
 
 func (c *goBgpServiceClient) ListPath(ctx context.Context, in *ListPathRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListPathResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &GoBgpService_ServiceDesc.Streams[4], GoBgpService_ListPath_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &GoBgpService_ServiceDesc.Streams[5], GoBgpService_ListPath_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -450,7 +531,7 @@ type GoBgpService_ListPathClient = grpc.ServerStreamingClient[ListPathResponse]
 
 func (c *goBgpServiceClient) AddPathStream(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[AddPathStreamRequest, AddPathStreamResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &GoBgpService_ServiceDesc.Streams[5], GoBgpService_AddPathStream_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &GoBgpService_ServiceDesc.Streams[6], GoBgpService_AddPathStream_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -461,6 +542,19 @@ func (c *goBgpServiceClient) AddPathStream(ctx context.Context, opts ...grpc.Cal
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type GoBgpService_AddPathStreamClient = grpc.ClientStreamingClient[AddPathStreamRequest, AddPathStreamResponse]
 
+func (c *goBgpServiceClient) UpdateLsTopology(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UpdateLsTopologyRequest, UpdateLsTopologyResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &GoBgpService_ServiceDesc.Streams[7], GoBgpService_UpdateLsTopology_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[UpdateLsTopologyRequest, UpdateLsTopologyResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GoBgpService_UpdateLsTopologyClient = grpc.ClientStreamingClient[UpdateLsTopologyRequest, UpdateLsTopologyResponse]
+
 func (c *goBgpServiceClient) GetTable(ctx context.Context, in *GetTableRequest, opts ...grpc.CallOption) (*GetTableResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetTableResponse)
@@ -493,7 +587,7 @@ func (c *goBgpServiceClient) DeleteVrf(ctx context.Context, in *DeleteVrfRequest
 
 func (c *goBgpServiceClient) ListVrf(ctx context.Context, in *ListVrfRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListVrfResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &GoBgpService_ServiceDesc.Streams[6], GoBgpService_ListVrf_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &GoBgpService_ServiceDesc.Streams[8], GoBgpService_ListVrf_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -532,7 +626,7 @@ func (c *goBgpServiceClient) DeletePolicy(ctx context.Context, in *DeletePolicyR
 
 func (c *goBgpServiceClient) ListPolicy(ctx context.Context, in *ListPolicyRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListPolicyResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &GoBgpService_ServiceDesc.Streams[7], GoBgpService_ListPolicy_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &GoBgpService_ServiceDesc.Streams[9], GoBgpService_ListPolicy_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -559,6 +653,104 @@ func (c *goBgpServiceClient) SetPolicies(ctx context.Context, in *SetPoliciesReq
 	return out, nil
 }
 
+func (c *goBgpServiceClient) TestPolicy(ctx context.Context, in *TestPolicyRequest, opts ...grpc.CallOption) (*TestPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TestPolicyResponse)
+	err := c.cc.Invoke(ctx, GoBgpService_TestPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goBgpServiceClient) StartPolicyTransaction(ctx context.Context, in *StartPolicyTransactionRequest, opts ...grpc.CallOption) (*StartPolicyTransactionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartPolicyTransactionResponse)
+	err := c.cc.Invoke(ctx, GoBgpService_StartPolicyTransaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goBgpServiceClient) DiffPolicyTransaction(ctx context.Context, in *DiffPolicyTransactionRequest, opts ...grpc.CallOption) (*DiffPolicyTransactionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiffPolicyTransactionResponse)
+	err := c.cc.Invoke(ctx, GoBgpService_DiffPolicyTransaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goBgpServiceClient) CommitPolicyTransaction(ctx context.Context, in *CommitPolicyTransactionRequest, opts ...grpc.CallOption) (*CommitPolicyTransactionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CommitPolicyTransactionResponse)
+	err := c.cc.Invoke(ctx, GoBgpService_CommitPolicyTransaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goBgpServiceClient) AbortPolicyTransaction(ctx context.Context, in *AbortPolicyTransactionRequest, opts ...grpc.CallOption) (*AbortPolicyTransactionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AbortPolicyTransactionResponse)
+	err := c.cc.Invoke(ctx, GoBgpService_AbortPolicyTransaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goBgpServiceClient) ListPolicyTransaction(ctx context.Context, in *ListPolicyTransactionRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListPolicyTransactionResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &GoBgpService_ServiceDesc.Streams[10], GoBgpService_ListPolicyTransaction_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListPolicyTransactionRequest, ListPolicyTransactionResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GoBgpService_ListPolicyTransactionClient = grpc.ServerStreamingClient[ListPolicyTransactionResponse]
+
+func (c *goBgpServiceClient) ListPolicyVersion(ctx context.Context, in *ListPolicyVersionRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListPolicyVersionResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &GoBgpService_ServiceDesc.Streams[11], GoBgpService_ListPolicyVersion_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListPolicyVersionRequest, ListPolicyVersionResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GoBgpService_ListPolicyVersionClient = grpc.ServerStreamingClient[ListPolicyVersionResponse]
+
+func (c *goBgpServiceClient) RollbackPolicy(ctx context.Context, in *RollbackPolicyRequest, opts ...grpc.CallOption) (*RollbackPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RollbackPolicyResponse)
+	err := c.cc.Invoke(ctx, GoBgpService_RollbackPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *goBgpServiceClient) AddDefinedSet(ctx context.Context, in *AddDefinedSetRequest, opts ...grpc.CallOption) (*AddDefinedSetResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(AddDefinedSetResponse)
@@ -581,7 +773,7 @@ func (c *goBgpServiceClient) DeleteDefinedSet(ctx context.Context, in *DeleteDef
 
 func (c *goBgpServiceClient) ListDefinedSet(ctx context.Context, in *ListDefinedSetRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListDefinedSetResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &GoBgpService_ServiceDesc.Streams[8], GoBgpService_ListDefinedSet_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &GoBgpService_ServiceDesc.Streams[12], GoBgpService_ListDefinedSet_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -598,6 +790,55 @@ func (c *goBgpServiceClient) ListDefinedSet(ctx context.Context, in *ListDefined
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type GoBgpService_ListDefinedSetClient = grpc.ServerStreamingClient[ListDefinedSetResponse]
 
+func (c *goBgpServiceClient) AddDefinedSetFile(ctx context.Context, in *AddDefinedSetFileRequest, opts ...grpc.CallOption) (*AddDefinedSetFileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddDefinedSetFileResponse)
+	err := c.cc.Invoke(ctx, GoBgpService_AddDefinedSetFile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goBgpServiceClient) DeleteDefinedSetFile(ctx context.Context, in *DeleteDefinedSetFileRequest, opts ...grpc.CallOption) (*DeleteDefinedSetFileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteDefinedSetFileResponse)
+	err := c.cc.Invoke(ctx, GoBgpService_DeleteDefinedSetFile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goBgpServiceClient) ListDefinedSetFile(ctx context.Context, in *ListDefinedSetFileRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListDefinedSetFileResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &GoBgpService_ServiceDesc.Streams[13], GoBgpService_ListDefinedSetFile_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListDefinedSetFileRequest, ListDefinedSetFileResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GoBgpService_ListDefinedSetFileClient = grpc.ServerStreamingClient[ListDefinedSetFileResponse]
+
+func (c *goBgpServiceClient) ReloadDefinedSetFile(ctx context.Context, in *ReloadDefinedSetFileRequest, opts ...grpc.CallOption) (*ReloadDefinedSetFileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReloadDefinedSetFileResponse)
+	err := c.cc.Invoke(ctx, GoBgpService_ReloadDefinedSetFile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *goBgpServiceClient) AddStatement(ctx context.Context, in *AddStatementRequest, opts ...grpc.CallOption) (*AddStatementResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(AddStatementResponse)
@@ -620,7 +861,7 @@ func (c *goBgpServiceClient) DeleteStatement(ctx context.Context, in *DeleteStat
 
 func (c *goBgpServiceClient) ListStatement(ctx context.Context, in *ListStatementRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListStatementResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &GoBgpService_ServiceDesc.Streams[9], GoBgpService_ListStatement_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &GoBgpService_ServiceDesc.Streams[14], GoBgpService_ListStatement_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -659,7 +900,7 @@ func (c *goBgpServiceClient) DeletePolicyAssignment(ctx context.Context, in *Del
 
 func (c *goBgpServiceClient) ListPolicyAssignment(ctx context.Context, in *ListPolicyAssignmentRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListPolicyAssignmentResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &GoBgpService_ServiceDesc.Streams[10], GoBgpService_ListPolicyAssignment_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &GoBgpService_ServiceDesc.Streams[15], GoBgpService_ListPolicyAssignment_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -686,6 +927,16 @@ func (c *goBgpServiceClient) SetPolicyAssignment(ctx context.Context, in *SetPol
 	return out, nil
 }
 
+func (c *goBgpServiceClient) ResetPolicyStatistics(ctx context.Context, in *ResetPolicyStatisticsRequest, opts ...grpc.CallOption) (*ResetPolicyStatisticsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResetPolicyStatisticsResponse)
+	err := c.cc.Invoke(ctx, GoBgpService_ResetPolicyStatistics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *goBgpServiceClient) AddRpki(ctx context.Context, in *AddRpkiRequest, opts ...grpc.CallOption) (*AddRpkiResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(AddRpkiResponse)
@@ -708,7 +959,7 @@ func (c *goBgpServiceClient) DeleteRpki(ctx context.Context, in *DeleteRpkiReque
 
 func (c *goBgpServiceClient) ListRpki(ctx context.Context, in *ListRpkiRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListRpkiResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &GoBgpService_ServiceDesc.Streams[11], GoBgpService_ListRpki_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &GoBgpService_ServiceDesc.Streams[16], GoBgpService_ListRpki_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -757,7 +1008,7 @@ func (c *goBgpServiceClient) ResetRpki(ctx context.Context, in *ResetRpkiRequest
 
 func (c *goBgpServiceClient) ListRpkiTable(ctx context.Context, in *ListRpkiTableRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListRpkiTableResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &GoBgpService_ServiceDesc.Streams[12], GoBgpService_ListRpkiTable_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &GoBgpService_ServiceDesc.Streams[17], GoBgpService_ListRpkiTable_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -774,6 +1025,55 @@ func (c *goBgpServiceClient) ListRpkiTable(ctx context.Context, in *ListRpkiTabl
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type GoBgpService_ListRpkiTableClient = grpc.ServerStreamingClient[ListRpkiTableResponse]
 
+func (c *goBgpServiceClient) AddRpkiFile(ctx context.Context, in *AddRpkiFileRequest, opts ...grpc.CallOption) (*AddRpkiFileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddRpkiFileResponse)
+	err := c.cc.Invoke(ctx, GoBgpService_AddRpkiFile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goBgpServiceClient) DeleteRpkiFile(ctx context.Context, in *DeleteRpkiFileRequest, opts ...grpc.CallOption) (*DeleteRpkiFileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteRpkiFileResponse)
+	err := c.cc.Invoke(ctx, GoBgpService_DeleteRpkiFile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goBgpServiceClient) ListRpkiFile(ctx context.Context, in *ListRpkiFileRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListRpkiFileResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &GoBgpService_ServiceDesc.Streams[18], GoBgpService_ListRpkiFile_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListRpkiFileRequest, ListRpkiFileResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GoBgpService_ListRpkiFileClient = grpc.ServerStreamingClient[ListRpkiFileResponse]
+
+func (c *goBgpServiceClient) ReloadRpkiFile(ctx context.Context, in *ReloadRpkiFileRequest, opts ...grpc.CallOption) (*ReloadRpkiFileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReloadRpkiFileResponse)
+	err := c.cc.Invoke(ctx, GoBgpService_ReloadRpkiFile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *goBgpServiceClient) EnableZebra(ctx context.Context, in *EnableZebraRequest, opts ...grpc.CallOption) (*EnableZebraResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(EnableZebraResponse)
@@ -816,7 +1116,7 @@ func (c *goBgpServiceClient) GetNetlinkImportStats(ctx context.Context, in *GetN
 
 func (c *goBgpServiceClient) ListNetlinkExport(ctx context.Context, in *ListNetlinkExportRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListNetlinkExportResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &GoBgpService_ServiceDesc.Streams[13], GoBgpService_ListNetlinkExport_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &GoBgpService_ServiceDesc.Streams[19], GoBgpService_ListNetlinkExport_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -883,6 +1183,94 @@ func (c *goBgpServiceClient) DisableMrt(ctx context.Context, in *DisableMrtReque
 	return out, nil
 }
 
+func (c *goBgpServiceClient) ListMrt(ctx context.Context, in *ListMrtRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListMrtResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &GoBgpService_ServiceDesc.Streams[20], GoBgpService_ListMrt_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListMrtRequest, ListMrtResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GoBgpService_ListMrtClient = grpc.ServerStreamingClient[ListMrtResponse]
+
+func (c *goBgpServiceClient) StartMrtReplay(ctx context.Context, in *StartMrtReplayRequest, opts ...grpc.CallOption) (*StartMrtReplayResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartMrtReplayResponse)
+	err := c.cc.Invoke(ctx, GoBgpService_StartMrtReplay_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goBgpServiceClient) StopMrtReplay(ctx context.Context, in *StopMrtReplayRequest, opts ...grpc.CallOption) (*StopMrtReplayResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StopMrtReplayResponse)
+	err := c.cc.Invoke(ctx, GoBgpService_StopMrtReplay_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goBgpServiceClient) PauseMrtReplay(ctx context.Context, in *PauseMrtReplayRequest, opts ...grpc.CallOption) (*PauseMrtReplayResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PauseMrtReplayResponse)
+	err := c.cc.Invoke(ctx, GoBgpService_PauseMrtReplay_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goBgpServiceClient) ResumeMrtReplay(ctx context.Context, in *ResumeMrtReplayRequest, opts ...grpc.CallOption) (*ResumeMrtReplayResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResumeMrtReplayResponse)
+	err := c.cc.Invoke(ctx, GoBgpService_ResumeMrtReplay_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goBgpServiceClient) SeekMrtReplay(ctx context.Context, in *SeekMrtReplayRequest, opts ...grpc.CallOption) (*SeekMrtReplayResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SeekMrtReplayResponse)
+	err := c.cc.Invoke(ctx, GoBgpService_SeekMrtReplay_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goBgpServiceClient) ListMrtReplay(ctx context.Context, in *ListMrtReplayRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListMrtReplayResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &GoBgpService_ServiceDesc.Streams[21], GoBgpService_ListMrtReplay_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListMrtReplayRequest, ListMrtReplayResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GoBgpService_ListMrtReplayClient = grpc.ServerStreamingClient[ListMrtReplayResponse]
+
 func (c *goBgpServiceClient) AddBmp(ctx context.Context, in *AddBmpRequest, opts ...grpc.CallOption) (*AddBmpResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(AddBmpResponse)
@@ -905,7 +1293,7 @@ func (c *goBgpServiceClient) DeleteBmp(ctx context.Context, in *DeleteBmpRequest
 
 func (c *goBgpServiceClient) ListBmp(ctx context.Context, in *ListBmpRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListBmpResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &GoBgpService_ServiceDesc.Streams[14], GoBgpService_ListBmp_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &GoBgpService_ServiceDesc.Streams[22], GoBgpService_ListBmp_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -922,6 +1310,83 @@ func (c *goBgpServiceClient) ListBmp(ctx context.Context, in *ListBmpRequest, op
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type GoBgpService_ListBmpClient = grpc.ServerStreamingClient[ListBmpResponse]
 
+func (c *goBgpServiceClient) EnableBmpStation(ctx context.Context, in *EnableBmpStationRequest, opts ...grpc.CallOption) (*EnableBmpStationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EnableBmpStationResponse)
+	err := c.cc.Invoke(ctx, GoBgpService_EnableBmpStation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goBgpServiceClient) DisableBmpStation(ctx context.Context, in *DisableBmpStationRequest, opts ...grpc.CallOption) (*DisableBmpStationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DisableBmpStationResponse)
+	err := c.cc.Invoke(ctx, GoBgpService_DisableBmpStation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goBgpServiceClient) ListBmpRouter(ctx context.Context, in *ListBmpRouterRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListBmpRouterResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &GoBgpService_ServiceDesc.Streams[23], GoBgpService_ListBmpRouter_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListBmpRouterRequest, ListBmpRouterResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GoBgpService_ListBmpRouterClient = grpc.ServerStreamingClient[ListBmpRouterResponse]
+
+func (c *goBgpServiceClient) ListBmpMonitoredPeer(ctx context.Context, in *ListBmpMonitoredPeerRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListBmpMonitoredPeerResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &GoBgpService_ServiceDesc.Streams[24], GoBgpService_ListBmpMonitoredPeer_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListBmpMonitoredPeerRequest, ListBmpMonitoredPeerResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GoBgpService_ListBmpMonitoredPeerClient = grpc.ServerStreamingClient[ListBmpMonitoredPeerResponse]
+
+func (c *goBgpServiceClient) ListBmpRoute(ctx context.Context, in *ListBmpRouteRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListBmpRouteResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &GoBgpService_ServiceDesc.Streams[25], GoBgpService_ListBmpRoute_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListBmpRouteRequest, ListBmpRouteResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GoBgpService_ListBmpRouteClient = grpc.ServerStreamingClient[ListBmpRouteResponse]
+
 func (c *goBgpServiceClient) SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*SetLogLevelResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(SetLogLevelResponse)
@@ -950,6 +1415,7 @@ type GoBgpServiceServer interface {
 	ShutdownPeer(context.Context, *ShutdownPeerRequest) (*ShutdownPeerResponse, error)
 	EnablePeer(context.Context, *EnablePeerRequest) (*EnablePeerResponse, error)
 	DisablePeer(context.Context, *DisablePeerRequest) (*DisablePeerResponse, error)
+	ListUpdateGroup(*ListUpdateGroupRequest, grpc.ServerStreamingServer[ListUpdateGroupResponse]) error
 	AddPeerGroup(context.Context, *AddPeerGroupRequest) (*AddPeerGroupResponse, error)
 	DeletePeerGroup(context.Context, *DeletePeerGroupRequest) (*DeletePeerGroupResponse, error)
 	ListPeerGroup(*ListPeerGroupRequest, grpc.ServerStreamingServer[ListPeerGroupResponse]) error
@@ -961,6 +1427,7 @@ type GoBgpServiceServer interface {
 	DeletePath(context.Context, *DeletePathRequest) (*DeletePathResponse, error)
 	ListPath(*ListPathRequest, grpc.ServerStreamingServer[ListPathResponse]) error
 	AddPathStream(grpc.ClientStreamingServer[AddPathStreamRequest, AddPathStreamResponse]) error
+	UpdateLsTopology(grpc.ClientStreamingServer[UpdateLsTopologyRequest, UpdateLsTopologyResponse]) error
 	GetTable(context.Context, *GetTableRequest) (*GetTableResponse, error)
 	AddVrf(context.Context, *AddVrfRequest) (*AddVrfResponse, error)
 	DeleteVrf(context.Context, *DeleteVrfRequest) (*DeleteVrfResponse, error)
@@ -969,9 +1436,21 @@ type GoBgpServiceServer interface {
 	DeletePolicy(context.Context, *DeletePolicyRequest) (*DeletePolicyResponse, error)
 	ListPolicy(*ListPolicyRequest, grpc.ServerStreamingServer[ListPolicyResponse]) error
 	SetPolicies(context.Context, *SetPoliciesRequest) (*SetPoliciesResponse, error)
+	TestPolicy(context.Context, *TestPolicyRequest) (*TestPolicyResponse, error)
+	StartPolicyTransaction(context.Context, *StartPolicyTransactionRequest) (*StartPolicyTransactionResponse, error)
+	DiffPolicyTransaction(context.Context, *DiffPolicyTransactionRequest) (*DiffPolicyTransactionResponse, error)
+	CommitPolicyTransaction(context.Context, *CommitPolicyTransactionRequest) (*CommitPolicyTransactionResponse, error)
+	AbortPolicyTransaction(context.Context, *AbortPolicyTransactionRequest) (*AbortPolicyTransactionResponse, error)
+	ListPolicyTransaction(*ListPolicyTransactionRequest, grpc.ServerStreamingServer[ListPolicyTransactionResponse]) error
+	ListPolicyVersion(*ListPolicyVersionRequest, grpc.ServerStreamingServer[ListPolicyVersionResponse]) error
+	RollbackPolicy(context.Context, *RollbackPolicyRequest) (*RollbackPolicyResponse, error)
 	AddDefinedSet(context.Context, *AddDefinedSetRequest) (*AddDefinedSetResponse, error)
 	DeleteDefinedSet(context.Context, *DeleteDefinedSetRequest) (*DeleteDefinedSetResponse, error)
 	ListDefinedSet(*ListDefinedSetRequest, grpc.ServerStreamingServer[ListDefinedSetResponse]) error
+	AddDefinedSetFile(context.Context, *AddDefinedSetFileRequest) (*AddDefinedSetFileResponse, error)
+	DeleteDefinedSetFile(context.Context, *DeleteDefinedSetFileRequest) (*DeleteDefinedSetFileResponse, error)
+	ListDefinedSetFile(*ListDefinedSetFileRequest, grpc.ServerStreamingServer[ListDefinedSetFileResponse]) error
+	ReloadDefinedSetFile(context.Context, *ReloadDefinedSetFileRequest) (*ReloadDefinedSetFileResponse, error)
 	AddStatement(context.Context, *AddStatementRequest) (*AddStatementResponse, error)
 	DeleteStatement(context.Context, *DeleteStatementRequest) (*DeleteStatementResponse, error)
 	ListStatement(*ListStatementRequest, grpc.ServerStreamingServer[ListStatementResponse]) error
@@ -979,6 +1458,7 @@ type GoBgpServiceServer interface {
 	DeletePolicyAssignment(context.Context, *DeletePolicyAssignmentRequest) (*DeletePolicyAssignmentResponse, error)
 	ListPolicyAssignment(*ListPolicyAssignmentRequest, grpc.ServerStreamingServer[ListPolicyAssignmentResponse]) error
 	SetPolicyAssignment(context.Context, *SetPolicyAssignmentRequest) (*SetPolicyAssignmentResponse, error)
+	ResetPolicyStatistics(context.Context, *ResetPolicyStatisticsRequest) (*ResetPolicyStatisticsResponse, error)
 	AddRpki(context.Context, *AddRpkiRequest) (*AddRpkiResponse, error)
 	DeleteRpki(context.Context, *DeleteRpkiRequest) (*DeleteRpkiResponse, error)
 	ListRpki(*ListRpkiRequest, grpc.ServerStreamingServer[ListRpkiResponse]) error
@@ -986,6 +1466,10 @@ type GoBgpServiceServer interface {
 	DisableRpki(context.Context, *DisableRpkiRequest) (*DisableRpkiResponse, error)
 	ResetRpki(context.Context, *ResetRpkiRequest) (*ResetRpkiResponse, error)
 	ListRpkiTable(*ListRpkiTableRequest, grpc.ServerStreamingServer[ListRpkiTableResponse]) error
+	AddRpkiFile(context.Context, *AddRpkiFileRequest) (*AddRpkiFileResponse, error)
+	DeleteRpkiFile(context.Context, *DeleteRpkiFileRequest) (*DeleteRpkiFileResponse, error)
+	ListRpkiFile(*ListRpkiFileRequest, grpc.ServerStreamingServer[ListRpkiFileResponse]) error
+	ReloadRpkiFile(context.Context, *ReloadRpkiFileRequest) (*ReloadRpkiFileResponse, error)
 	EnableZebra(context.Context, *EnableZebraRequest) (*EnableZebraResponse, error)
 	GetNetlink(context.Context, *GetNetlinkRequest) (*GetNetlinkResponse, error)
 	EnableNetlink(context.Context, *EnableNetlinkRequest) (*EnableNetlinkResponse, error)
@@ -996,9 +1480,21 @@ type GoBgpServiceServer interface {
 	ListNetlinkExportRules(context.Context, *ListNetlinkExportRulesRequest) (*ListNetlinkExportRulesResponse, error)
 	EnableMrt(context.Context, *EnableMrtRequest) (*EnableMrtResponse, error)
 	DisableMrt(context.Context, *DisableMrtRequest) (*DisableMrtResponse, error)
+	ListMrt(*ListMrtRequest, grpc.ServerStreamingServer[ListMrtResponse]) error
+	StartMrtReplay(context.Context, *StartMrtReplayRequest) (*StartMrtReplayResponse, error)
+	StopMrtReplay(context.Context, *StopMrtReplayRequest) (*StopMrtReplayResponse, error)
+	PauseMrtReplay(context.Context, *PauseMrtReplayRequest) (*PauseMrtReplayResponse, error)
+	ResumeMrtReplay(context.Context, *ResumeMrtReplayRequest) (*ResumeMrtReplayResponse, error)
+	SeekMrtReplay(context.Context, *SeekMrtReplayRequest) (*SeekMrtReplayResponse, error)
+	ListMrtReplay(*ListMrtReplayRequest, grpc.ServerStreamingServer[ListMrtReplayResponse]) error
 	AddBmp(context.Context, *AddBmpRequest) (*AddBmpResponse, error)
 	DeleteBmp(context.Context, *DeleteBmpRequest) (*DeleteBmpResponse, error)
 	ListBmp(*ListBmpRequest, grpc.ServerStreamingServer[ListBmpResponse]) error
+	EnableBmpStation(context.Context, *EnableBmpStationRequest) (*EnableBmpStationResponse, error)
+	DisableBmpStation(context.Context, *DisableBmpStationRequest) (*DisableBmpStationResponse, error)
+	ListBmpRouter(*ListBmpRouterRequest, grpc.ServerStreamingServer[ListBmpRouterResponse]) error
+	ListBmpMonitoredPeer(*ListBmpMonitoredPeerRequest, grpc.ServerStreamingServer[ListBmpMonitoredPeerResponse]) error
+	ListBmpRoute(*ListBmpRouteRequest, grpc.ServerStreamingServer[ListBmpRouteResponse]) error
 	SetLogLevel(context.Context, *SetLogLevelRequest) (*SetLogLevelResponse, error)
 	mustEmbedUnimplementedGoBgpServiceServer()
 }
@@ -1046,6 +1542,9 @@ func (UnimplementedGoBgpServiceServer) EnablePeer(context.Context, *EnablePeerRe
 func (UnimplementedGoBgpServiceServer) DisablePeer(context.Context, *DisablePeerRequest) (*DisablePeerResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DisablePeer not implemented")
 }
+func (UnimplementedGoBgpServiceServer) ListUpdateGroup(*ListUpdateGroupRequest, grpc.ServerStreamingServer[ListUpdateGroupResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ListUpdateGroup not implemented")
+}
 func (UnimplementedGoBgpServiceServer) AddPeerGroup(context.Context, *AddPeerGroupRequest) (*AddPeerGroupResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddPeerGroup not implemented")
 }
@@ -1079,6 +1578,9 @@ func (UnimplementedGoBgpServiceServer) ListPath(*ListPathRequest, grpc.ServerStr
 func (UnimplementedGoBgpServiceServer) AddPathStream(grpc.ClientStreamingServer[AddPathStreamRequest, AddPathStreamResponse]) error {
 	return status.Errorf(codes.Unimplemented, "method AddPathStream not implemented")
 }
+func (UnimplementedGoBgpServiceServer) UpdateLsTopology(grpc.ClientStreamingServer[UpdateLsTopologyRequest, UpdateLsTopologyResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method UpdateLsTopology not implemented")
+}
 func (UnimplementedGoBgpServiceServer) GetTable(context.Context, *GetTableRequest) (*GetTableResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTable not implemented")
 }
@@ -1103,6 +1605,30 @@ func (UnimplementedGoBgpServiceServer) ListPolicy(*ListPolicyRequest, grpc.Serve
 func (UnimplementedGoBgpServiceServer) SetPolicies(context.Context, *SetPoliciesRequest) (*SetPoliciesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetPolicies not implemented")
 }
+func (UnimplementedGoBgpServiceServer) TestPolicy(context.Context, *TestPolicyRequest) (*TestPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TestPolicy not implemented")
+}
+func (UnimplementedGoBgpServiceServer) StartPolicyTransaction(context.Context, *StartPolicyTransactionRequest) (*StartPolicyTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartPolicyTransaction not implemented")
+}
+func (UnimplementedGoBgpServiceServer) DiffPolicyTransaction(context.Context, *DiffPolicyTransactionRequest) (*DiffPolicyTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiffPolicyTransaction not implemented")
+}
+func (UnimplementedGoBgpServiceServer) CommitPolicyTransaction(context.Context, *CommitPolicyTransactionRequest) (*CommitPolicyTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CommitPolicyTransaction not implemented")
+}
+func (UnimplementedGoBgpServiceServer) AbortPolicyTransaction(context.Context, *AbortPolicyTransactionRequest) (*AbortPolicyTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AbortPolicyTransaction not implemented")
+}
+func (UnimplementedGoBgpServiceServer) ListPolicyTransaction(*ListPolicyTransactionRequest, grpc.ServerStreamingServer[ListPolicyTransactionResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ListPolicyTransaction not implemented")
+}
+func (UnimplementedGoBgpServiceServer) ListPolicyVersion(*ListPolicyVersionRequest, grpc.ServerStreamingServer[ListPolicyVersionResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ListPolicyVersion not implemented")
+}
+func (UnimplementedGoBgpServiceServer) RollbackPolicy(context.Context, *RollbackPolicyRequest) (*RollbackPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RollbackPolicy not implemented")
+}
 func (UnimplementedGoBgpServiceServer) AddDefinedSet(context.Context, *AddDefinedSetRequest) (*AddDefinedSetResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddDefinedSet not implemented")
 }
@@ -1112,6 +1638,18 @@ func (UnimplementedGoBgpServiceServer) DeleteDefinedSet(context.Context, *Delete
 func (UnimplementedGoBgpServiceServer) ListDefinedSet(*ListDefinedSetRequest, grpc.ServerStreamingServer[ListDefinedSetResponse]) error {
 	return status.Errorf(codes.Unimplemented, "method ListDefinedSet not implemented")
 }
+func (UnimplementedGoBgpServiceServer) AddDefinedSetFile(context.Context, *AddDefinedSetFileRequest) (*AddDefinedSetFileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddDefinedSetFile not implemented")
+}
+func (UnimplementedGoBgpServiceServer) DeleteDefinedSetFile(context.Context, *DeleteDefinedSetFileRequest) (*DeleteDefinedSetFileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteDefinedSetFile not implemented")
+}
+func (UnimplementedGoBgpServiceServer) ListDefinedSetFile(*ListDefinedSetFileRequest, grpc.ServerStreamingServer[ListDefinedSetFileResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ListDefinedSetFile not implemented")
+}
+func (UnimplementedGoBgpServiceServer) ReloadDefinedSetFile(context.Context, *ReloadDefinedSetFileRequest) (*ReloadDefinedSetFileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReloadDefinedSetFile not implemented")
+}
 func (UnimplementedGoBgpServiceServer) AddStatement(context.Context, *AddStatementRequest) (*AddStatementResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddStatement not implemented")
 }
@@ -1133,6 +1671,9 @@ func (UnimplementedGoBgpServiceServer) ListPolicyAssignment(*ListPolicyAssignmen
 func (UnimplementedGoBgpServiceServer) SetPolicyAssignment(context.Context, *SetPolicyAssignmentRequest) (*SetPolicyAssignmentResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetPolicyAssignment not implemented")
 }
+func (UnimplementedGoBgpServiceServer) ResetPolicyStatistics(context.Context, *ResetPolicyStatisticsRequest) (*ResetPolicyStatisticsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetPolicyStatistics not implemented")
+}
 func (UnimplementedGoBgpServiceServer) AddRpki(context.Context, *AddRpkiRequest) (*AddRpkiResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddRpki not implemented")
 }
@@ -1154,6 +1695,18 @@ func (UnimplementedGoBgpServiceServer) ResetRpki(context.Context, *ResetRpkiRequ
 func (UnimplementedGoBgpServiceServer) ListRpkiTable(*ListRpkiTableRequest, grpc.ServerStreamingServer[ListRpkiTableResponse]) error {
 	return status.Errorf(codes.Unimplemented, "method ListRpkiTable not implemented")
 }
+func (UnimplementedGoBgpServiceServer) AddRpkiFile(context.Context, *AddRpkiFileRequest) (*AddRpkiFileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddRpkiFile not implemented")
+}
+func (UnimplementedGoBgpServiceServer) DeleteRpkiFile(context.Context, *DeleteRpkiFileRequest) (*DeleteRpkiFileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRpkiFile not implemented")
+}
+func (UnimplementedGoBgpServiceServer) ListRpkiFile(*ListRpkiFileRequest, grpc.ServerStreamingServer[ListRpkiFileResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ListRpkiFile not implemented")
+}
+func (UnimplementedGoBgpServiceServer) ReloadRpkiFile(context.Context, *ReloadRpkiFileRequest) (*ReloadRpkiFileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReloadRpkiFile not implemented")
+}
 func (UnimplementedGoBgpServiceServer) EnableZebra(context.Context, *EnableZebraRequest) (*EnableZebraResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method EnableZebra not implemented")
 }
@@ -1184,6 +1737,27 @@ func (UnimplementedGoBgpServiceServer) EnableMrt(context.Context, *EnableMrtRequ
 func (UnimplementedGoBgpServiceServer) DisableMrt(context.Context, *DisableMrtRequest) (*DisableMrtResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DisableMrt not implemented")
 }
+func (UnimplementedGoBgpServiceServer) ListMrt(*ListMrtRequest, grpc.ServerStreamingServer[ListMrtResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ListMrt not implemented")
+}
+func (UnimplementedGoBgpServiceServer) StartMrtReplay(context.Context, *StartMrtReplayRequest) (*StartMrtReplayResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartMrtReplay not implemented")
+}
+func (UnimplementedGoBgpServiceServer) StopMrtReplay(context.Context, *StopMrtReplayRequest) (*StopMrtReplayResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopMrtReplay not implemented")
+}
+func (UnimplementedGoBgpServiceServer) PauseMrtReplay(context.Context, *PauseMrtReplayRequest) (*PauseMrtReplayResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseMrtReplay not implemented")
+}
+func (UnimplementedGoBgpServiceServer) ResumeMrtReplay(context.Context, *ResumeMrtReplayRequest) (*ResumeMrtReplayResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeMrtReplay not implemented")
+}
+func (UnimplementedGoBgpServiceServer) SeekMrtReplay(context.Context, *SeekMrtReplayRequest) (*SeekMrtReplayResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SeekMrtReplay not implemented")
+}
+func (UnimplementedGoBgpServiceServer) ListMrtReplay(*ListMrtReplayRequest, grpc.ServerStreamingServer[ListMrtReplayResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ListMrtReplay not implemented")
+}
 func (UnimplementedGoBgpServiceServer) AddBmp(context.Context, *AddBmpRequest) (*AddBmpResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddBmp not implemented")
 }
@@ -1193,6 +1767,21 @@ func (UnimplementedGoBgpServiceServer) DeleteBmp(context.Context, *DeleteBmpRequ
 func (UnimplementedGoBgpServiceServer) ListBmp(*ListBmpRequest, grpc.ServerStreamingServer[ListBmpResponse]) error {
 	return status.Errorf(codes.Unimplemented, "method ListBmp not implemented")
 }
+func (UnimplementedGoBgpServiceServer) EnableBmpStation(context.Context, *EnableBmpStationRequest) (*EnableBmpStationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnableBmpStation not implemented")
+}
+func (UnimplementedGoBgpServiceServer) DisableBmpStation(context.Context, *DisableBmpStationRequest) (*DisableBmpStationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisableBmpStation not implemented")
+}
+func (UnimplementedGoBgpServiceServer) ListBmpRouter(*ListBmpRouterRequest, grpc.ServerStreamingServer[ListBmpRouterResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ListBmpRouter not implemented")
+}
+func (UnimplementedGoBgpServiceServer) ListBmpMonitoredPeer(*ListBmpMonitoredPeerRequest, grpc.ServerStreamingServer[ListBmpMonitoredPeerResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ListBmpMonitoredPeer not implemented")
+}
+func (UnimplementedGoBgpServiceServer) ListBmpRoute(*ListBmpRouteRequest, grpc.ServerStreamingServer[ListBmpRouteResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ListBmpRoute not implemented")
+}
 func (UnimplementedGoBgpServiceServer) SetLogLevel(context.Context, *SetLogLevelRequest) (*SetLogLevelResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetLogLevel not implemented")
 }
@@ -1419,6 +2008,17 @@ func _GoBgpService_DisablePeer_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GoBgpService_ListUpdateGroup_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListUpdateGroupRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GoBgpServiceServer).ListUpdateGroup(m, &grpc.GenericServerStream[ListUpdateGroupRequest, ListUpdateGroupResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GoBgpService_ListUpdateGroupServer = grpc.ServerStreamingServer[ListUpdateGroupResponse]
+
 func _GoBgpService_AddPeerGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AddPeerGroupRequest)
 	if err := dec(in); err != nil {
@@ -1585,6 +2185,13 @@ func _GoBgpService_AddPathStream_Handler(srv interface{}, stream grpc.ServerStre
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type GoBgpService_AddPathStreamServer = grpc.ClientStreamingServer[AddPathStreamRequest, AddPathStreamResponse]
 
+func _GoBgpService_UpdateLsTopology_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(GoBgpServiceServer).UpdateLsTopology(&grpc.GenericServerStream[UpdateLsTopologyRequest, UpdateLsTopologyResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GoBgpService_UpdateLsTopologyServer = grpc.ClientStreamingServer[UpdateLsTopologyRequest, UpdateLsTopologyResponse]
+
 func _GoBgpService_GetTable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetTableRequest)
 	if err := dec(in); err != nil {
@@ -1715,6 +2322,136 @@ func _GoBgpService_SetPolicies_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GoBgpService_TestPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TestPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoBgpServiceServer).TestPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoBgpService_TestPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoBgpServiceServer).TestPolicy(ctx, req.(*TestPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoBgpService_StartPolicyTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartPolicyTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoBgpServiceServer).StartPolicyTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoBgpService_StartPolicyTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoBgpServiceServer).StartPolicyTransaction(ctx, req.(*StartPolicyTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoBgpService_DiffPolicyTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffPolicyTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoBgpServiceServer).DiffPolicyTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoBgpService_DiffPolicyTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoBgpServiceServer).DiffPolicyTransaction(ctx, req.(*DiffPolicyTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoBgpService_CommitPolicyTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommitPolicyTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoBgpServiceServer).CommitPolicyTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoBgpService_CommitPolicyTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoBgpServiceServer).CommitPolicyTransaction(ctx, req.(*CommitPolicyTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoBgpService_AbortPolicyTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AbortPolicyTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoBgpServiceServer).AbortPolicyTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoBgpService_AbortPolicyTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoBgpServiceServer).AbortPolicyTransaction(ctx, req.(*AbortPolicyTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoBgpService_ListPolicyTransaction_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListPolicyTransactionRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GoBgpServiceServer).ListPolicyTransaction(m, &grpc.GenericServerStream[ListPolicyTransactionRequest, ListPolicyTransactionResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GoBgpService_ListPolicyTransactionServer = grpc.ServerStreamingServer[ListPolicyTransactionResponse]
+
+func _GoBgpService_ListPolicyVersion_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListPolicyVersionRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GoBgpServiceServer).ListPolicyVersion(m, &grpc.GenericServerStream[ListPolicyVersionRequest, ListPolicyVersionResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GoBgpService_ListPolicyVersionServer = grpc.ServerStreamingServer[ListPolicyVersionResponse]
+
+func _GoBgpService_RollbackPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoBgpServiceServer).RollbackPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoBgpService_RollbackPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoBgpServiceServer).RollbackPolicy(ctx, req.(*RollbackPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _GoBgpService_AddDefinedSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AddDefinedSetRequest)
 	if err := dec(in); err != nil {
@@ -1762,6 +2499,71 @@ func _GoBgpService_ListDefinedSet_Handler(srv interface{}, stream grpc.ServerStr
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type GoBgpService_ListDefinedSetServer = grpc.ServerStreamingServer[ListDefinedSetResponse]
 
+func _GoBgpService_AddDefinedSetFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddDefinedSetFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoBgpServiceServer).AddDefinedSetFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoBgpService_AddDefinedSetFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoBgpServiceServer).AddDefinedSetFile(ctx, req.(*AddDefinedSetFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoBgpService_DeleteDefinedSetFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteDefinedSetFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoBgpServiceServer).DeleteDefinedSetFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoBgpService_DeleteDefinedSetFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoBgpServiceServer).DeleteDefinedSetFile(ctx, req.(*DeleteDefinedSetFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoBgpService_ListDefinedSetFile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListDefinedSetFileRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GoBgpServiceServer).ListDefinedSetFile(m, &grpc.GenericServerStream[ListDefinedSetFileRequest, ListDefinedSetFileResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GoBgpService_ListDefinedSetFileServer = grpc.ServerStreamingServer[ListDefinedSetFileResponse]
+
+func _GoBgpService_ReloadDefinedSetFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReloadDefinedSetFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoBgpServiceServer).ReloadDefinedSetFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoBgpService_ReloadDefinedSetFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoBgpServiceServer).ReloadDefinedSetFile(ctx, req.(*ReloadDefinedSetFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _GoBgpService_AddStatement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AddStatementRequest)
 	if err := dec(in); err != nil {
@@ -1850,26 +2652,44 @@ func _GoBgpService_ListPolicyAssignment_Handler(srv interface{}, stream grpc.Ser
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(GoBgpServiceServer).ListPolicyAssignment(m, &grpc.GenericServerStream[ListPolicyAssignmentRequest, ListPolicyAssignmentResponse]{ServerStream: stream})
+	return srv.(GoBgpServiceServer).ListPolicyAssignment(m, &grpc.GenericServerStream[ListPolicyAssignmentRequest, ListPolicyAssignmentResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GoBgpService_ListPolicyAssignmentServer = grpc.ServerStreamingServer[ListPolicyAssignmentResponse]
+
+func _GoBgpService_SetPolicyAssignment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPolicyAssignmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoBgpServiceServer).SetPolicyAssignment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoBgpService_SetPolicyAssignment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoBgpServiceServer).SetPolicyAssignment(ctx, req.(*SetPolicyAssignmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type GoBgpService_ListPolicyAssignmentServer = grpc.ServerStreamingServer[ListPolicyAssignmentResponse]
-
-func _GoBgpService_SetPolicyAssignment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SetPolicyAssignmentRequest)
+func _GoBgpService_ResetPolicyStatistics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetPolicyStatisticsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GoBgpServiceServer).SetPolicyAssignment(ctx, in)
+		return srv.(GoBgpServiceServer).ResetPolicyStatistics(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GoBgpService_SetPolicyAssignment_FullMethodName,
+		FullMethod: GoBgpService_ResetPolicyStatistics_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GoBgpServiceServer).SetPolicyAssignment(ctx, req.(*SetPolicyAssignmentRequest))
+		return srv.(GoBgpServiceServer).ResetPolicyStatistics(ctx, req.(*ResetPolicyStatisticsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -1986,6 +2806,71 @@ func _GoBgpService_ListRpkiTable_Handler(srv interface{}, stream grpc.ServerStre
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type GoBgpService_ListRpkiTableServer = grpc.ServerStreamingServer[ListRpkiTableResponse]
 
+func _GoBgpService_AddRpkiFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddRpkiFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoBgpServiceServer).AddRpkiFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoBgpService_AddRpkiFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoBgpServiceServer).AddRpkiFile(ctx, req.(*AddRpkiFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoBgpService_DeleteRpkiFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRpkiFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoBgpServiceServer).DeleteRpkiFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoBgpService_DeleteRpkiFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoBgpServiceServer).DeleteRpkiFile(ctx, req.(*DeleteRpkiFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoBgpService_ListRpkiFile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListRpkiFileRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GoBgpServiceServer).ListRpkiFile(m, &grpc.GenericServerStream[ListRpkiFileRequest, ListRpkiFileResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GoBgpService_ListRpkiFileServer = grpc.ServerStreamingServer[ListRpkiFileResponse]
+
+func _GoBgpService_ReloadRpkiFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReloadRpkiFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoBgpServiceServer).ReloadRpkiFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoBgpService_ReloadRpkiFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoBgpServiceServer).ReloadRpkiFile(ctx, req.(*ReloadRpkiFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _GoBgpService_EnableZebra_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(EnableZebraRequest)
 	if err := dec(in); err != nil {
@@ -2159,6 +3044,118 @@ func _GoBgpService_DisableMrt_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GoBgpService_ListMrt_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListMrtRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GoBgpServiceServer).ListMrt(m, &grpc.GenericServerStream[ListMrtRequest, ListMrtResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GoBgpService_ListMrtServer = grpc.ServerStreamingServer[ListMrtResponse]
+
+func _GoBgpService_StartMrtReplay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartMrtReplayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoBgpServiceServer).StartMrtReplay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoBgpService_StartMrtReplay_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoBgpServiceServer).StartMrtReplay(ctx, req.(*StartMrtReplayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoBgpService_StopMrtReplay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopMrtReplayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoBgpServiceServer).StopMrtReplay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoBgpService_StopMrtReplay_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoBgpServiceServer).StopMrtReplay(ctx, req.(*StopMrtReplayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoBgpService_PauseMrtReplay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseMrtReplayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoBgpServiceServer).PauseMrtReplay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoBgpService_PauseMrtReplay_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoBgpServiceServer).PauseMrtReplay(ctx, req.(*PauseMrtReplayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoBgpService_ResumeMrtReplay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeMrtReplayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoBgpServiceServer).ResumeMrtReplay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoBgpService_ResumeMrtReplay_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoBgpServiceServer).ResumeMrtReplay(ctx, req.(*ResumeMrtReplayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoBgpService_SeekMrtReplay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SeekMrtReplayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoBgpServiceServer).SeekMrtReplay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoBgpService_SeekMrtReplay_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoBgpServiceServer).SeekMrtReplay(ctx, req.(*SeekMrtReplayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoBgpService_ListMrtReplay_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListMrtReplayRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GoBgpServiceServer).ListMrtReplay(m, &grpc.GenericServerStream[ListMrtReplayRequest, ListMrtReplayResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GoBgpService_ListMrtReplayServer = grpc.ServerStreamingServer[ListMrtReplayResponse]
+
 func _GoBgpService_AddBmp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AddBmpRequest)
 	if err := dec(in); err != nil {
@@ -2206,6 +3203,75 @@ func _GoBgpService_ListBmp_Handler(srv interface{}, stream grpc.ServerStream) er
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type GoBgpService_ListBmpServer = grpc.ServerStreamingServer[ListBmpResponse]
 
+func _GoBgpService_EnableBmpStation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnableBmpStationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoBgpServiceServer).EnableBmpStation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoBgpService_EnableBmpStation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoBgpServiceServer).EnableBmpStation(ctx, req.(*EnableBmpStationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoBgpService_DisableBmpStation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisableBmpStationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoBgpServiceServer).DisableBmpStation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoBgpService_DisableBmpStation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoBgpServiceServer).DisableBmpStation(ctx, req.(*DisableBmpStationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoBgpService_ListBmpRouter_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListBmpRouterRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GoBgpServiceServer).ListBmpRouter(m, &grpc.GenericServerStream[ListBmpRouterRequest, ListBmpRouterResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GoBgpService_ListBmpRouterServer = grpc.ServerStreamingServer[ListBmpRouterResponse]
+
+func _GoBgpService_ListBmpMonitoredPeer_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListBmpMonitoredPeerRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GoBgpServiceServer).ListBmpMonitoredPeer(m, &grpc.GenericServerStream[ListBmpMonitoredPeerRequest, ListBmpMonitoredPeerResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GoBgpService_ListBmpMonitoredPeerServer = grpc.ServerStreamingServer[ListBmpMonitoredPeerResponse]
+
+func _GoBgpService_ListBmpRoute_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListBmpRouteRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GoBgpServiceServer).ListBmpRoute(m, &grpc.GenericServerStream[ListBmpRouteRequest, ListBmpRouteResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GoBgpService_ListBmpRouteServer = grpc.ServerStreamingServer[ListBmpRouteResponse]
+
 func _GoBgpService_SetLogLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SetLogLevelRequest)
 	if err := dec(in); err != nil {
@@ -2323,6 +3389,30 @@ var GoBgpService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SetPolicies",
 			Handler:    _GoBgpService_SetPolicies_Handler,
 		},
+		{
+			MethodName: "TestPolicy",
+			Handler:    _GoBgpService_TestPolicy_Handler,
+		},
+		{
+			MethodName: "StartPolicyTransaction",
+			Handler:    _GoBgpService_StartPolicyTransaction_Handler,
+		},
+		{
+			MethodName: "DiffPolicyTransaction",
+			Handler:    _GoBgpService_DiffPolicyTransaction_Handler,
+		},
+		{
+			MethodName: "CommitPolicyTransaction",
+			Handler:    _GoBgpService_CommitPolicyTransaction_Handler,
+		},
+		{
+			MethodName: "AbortPolicyTransaction",
+			Handler:    _GoBgpService_AbortPolicyTransaction_Handler,
+		},
+		{
+			MethodName: "RollbackPolicy",
+			Handler:    _GoBgpService_RollbackPolicy_Handler,
+		},
 		{
 			MethodName: "AddDefinedSet",
 			Handler:    _GoBgpService_AddDefinedSet_Handler,
@@ -2331,6 +3421,18 @@ var GoBgpService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteDefinedSet",
 			Handler:    _GoBgpService_DeleteDefinedSet_Handler,
 		},
+		{
+			MethodName: "AddDefinedSetFile",
+			Handler:    _GoBgpService_AddDefinedSetFile_Handler,
+		},
+		{
+			MethodName: "DeleteDefinedSetFile",
+			Handler:    _GoBgpService_DeleteDefinedSetFile_Handler,
+		},
+		{
+			MethodName: "ReloadDefinedSetFile",
+			Handler:    _GoBgpService_ReloadDefinedSetFile_Handler,
+		},
 		{
 			MethodName: "AddStatement",
 			Handler:    _GoBgpService_AddStatement_Handler,
@@ -2351,6 +3453,10 @@ var GoBgpService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SetPolicyAssignment",
 			Handler:    _GoBgpService_SetPolicyAssignment_Handler,
 		},
+		{
+			MethodName: "ResetPolicyStatistics",
+			Handler:    _GoBgpService_ResetPolicyStatistics_Handler,
+		},
 		{
 			MethodName: "AddRpki",
 			Handler:    _GoBgpService_AddRpki_Handler,
@@ -2371,6 +3477,18 @@ var GoBgpService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ResetRpki",
 			Handler:    _GoBgpService_ResetRpki_Handler,
 		},
+		{
+			MethodName: "AddRpkiFile",
+			Handler:    _GoBgpService_AddRpkiFile_Handler,
+		},
+		{
+			MethodName: "DeleteRpkiFile",
+			Handler:    _GoBgpService_DeleteRpkiFile_Handler,
+		},
+		{
+			MethodName: "ReloadRpkiFile",
+			Handler:    _GoBgpService_ReloadRpkiFile_Handler,
+		},
 		{
 			MethodName: "EnableZebra",
 			Handler:    _GoBgpService_EnableZebra_Handler,
@@ -2407,6 +3525,26 @@ var GoBgpService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DisableMrt",
 			Handler:    _GoBgpService_DisableMrt_Handler,
 		},
+		{
+			MethodName: "StartMrtReplay",
+			Handler:    _GoBgpService_StartMrtReplay_Handler,
+		},
+		{
+			MethodName: "StopMrtReplay",
+			Handler:    _GoBgpService_StopMrtReplay_Handler,
+		},
+		{
+			MethodName: "PauseMrtReplay",
+			Handler:    _GoBgpService_PauseMrtReplay_Handler,
+		},
+		{
+			MethodName: "ResumeMrtReplay",
+			Handler:    _GoBgpService_ResumeMrtReplay_Handler,
+		},
+		{
+			MethodName: "SeekMrtReplay",
+			Handler:    _GoBgpService_SeekMrtReplay_Handler,
+		},
 		{
 			MethodName: "AddBmp",
 			Handler:    _GoBgpService_AddBmp_Handler,
@@ -2415,6 +3553,14 @@ var GoBgpService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteBmp",
 			Handler:    _GoBgpService_DeleteBmp_Handler,
 		},
+		{
+			MethodName: "EnableBmpStation",
+			Handler:    _GoBgpService_EnableBmpStation_Handler,
+		},
+		{
+			MethodName: "DisableBmpStation",
+			Handler:    _GoBgpService_DisableBmpStation_Handler,
+		},
 		{
 			MethodName: "SetLogLevel",
 			Handler:    _GoBgpService_SetLogLevel_Handler,
@@ -2431,6 +3577,11 @@ var GoBgpService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _GoBgpService_ListPeer_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ListUpdateGroup",
+			Handler:       _GoBgpService_ListUpdateGroup_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "ListPeerGroup",
 			Handler:       _GoBgpService_ListPeerGroup_Handler,
@@ -2451,6 +3602,11 @@ var GoBgpService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _GoBgpService_AddPathStream_Handler,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "UpdateLsTopology",
+			Handler:       _GoBgpService_UpdateLsTopology_Handler,
+			ClientStreams: true,
+		},
 		{
 			StreamName:    "ListVrf",
 			Handler:       _GoBgpService_ListVrf_Handler,
@@ -2461,11 +3617,26 @@ var GoBgpService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _GoBgpService_ListPolicy_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ListPolicyTransaction",
+			Handler:       _GoBgpService_ListPolicyTransaction_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListPolicyVersion",
+			Handler:       _GoBgpService_ListPolicyVersion_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "ListDefinedSet",
 			Handler:       _GoBgpService_ListDefinedSet_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ListDefinedSetFile",
+			Handler:       _GoBgpService_ListDefinedSetFile_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "ListStatement",
 			Handler:       _GoBgpService_ListStatement_Handler,
@@ -2486,16 +3657,46 @@ var GoBgpService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _GoBgpService_ListRpkiTable_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ListRpkiFile",
+			Handler:       _GoBgpService_ListRpkiFile_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "ListNetlinkExport",
 			Handler:       _GoBgpService_ListNetlinkExport_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ListMrt",
+			Handler:       _GoBgpService_ListMrt_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListMrtReplay",
+			Handler:       _GoBgpService_ListMrtReplay_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "ListBmp",
 			Handler:       _GoBgpService_ListBmp_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ListBmpRouter",
+			Handler:       _GoBgpService_ListBmpRouter_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListBmpMonitoredPeer",
+			Handler:       _GoBgpService_ListBmpMonitoredPeer_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListBmpRoute",
+			Handler:       _GoBgpService_ListBmpRoute_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "api/gobgp.proto",
 }
@@ -16,12 +16,18 @@
 package main
 
 import (
+	"encoding/binary"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net"
+	"net/netip"
+	"sort"
 	"strconv"
 
 	"github.com/osrg/gobgp/v4/api"
+	"github.com/osrg/gobgp/v4/pkg/apiutil"
+	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
 	"github.com/osrg/gobgp/v4/pkg/packet/bmp"
 	"github.com/spf13/cobra"
 )
@@ -42,8 +48,8 @@ func showStations() error {
 		}
 		stations = append(stations, rsp.Station)
 	}
-	format := "%-23s %-6s %-10s\n"
-	fmt.Printf(format, "Session", "State", "Uptime")
+	format := "%-23s %-6s %-10s %-10s %-10s\n"
+	fmt.Printf(format, "Session", "State", "Uptime", "Dropped", "Resyncs")
 	for _, r := range stations {
 		s := "Down"
 		uptime := "Never"
@@ -56,7 +62,7 @@ func showStations() error {
 				s = "Down"
 			}
 		}
-		fmt.Printf(format, net.JoinHostPort(r.Conf.Address, fmt.Sprintf("%d", r.Conf.Port)), s, uptime)
+		fmt.Printf(format, net.JoinHostPort(r.Conf.Address, fmt.Sprintf("%d", r.Conf.Port)), s, uptime, fmt.Sprint(r.State.Dropped), fmt.Sprint(r.State.Resyncs))
 	}
 
 	return nil
@@ -64,7 +70,7 @@ func showStations() error {
 
 func modBmpServer(cmdType string, args []string) error {
 	if len(args) < 1 {
-		return fmt.Errorf("usage: gobgp bmp %s <addr>[:<port>] [{pre|post|both|local-rib|all}]", cmdType)
+		return fmt.Errorf("usage: gobgp bmp %s <addr>[:<port>] [{pre|post|both|local-rib|all}] [adj-rib-out {pre|post|both}]", cmdType)
 	}
 
 	var address string
@@ -93,6 +99,30 @@ func modBmpServer(cmdType string, args []string) error {
 			return fmt.Errorf("invalid statistics-timeout value. it must be in the range 0-65535. default value is 0 and means disabled")
 		}
 
+		var adjRibOutPre, adjRibOutPost bool
+		if len(args) > 2 && args[len(args)-2] == "adj-rib-out" {
+			switch args[len(args)-1] {
+			case "pre":
+				adjRibOutPre = true
+			case "post":
+				adjRibOutPost = true
+			case "both":
+				adjRibOutPre, adjRibOutPost = true, true
+			default:
+				return fmt.Errorf("invalid adj-rib-out policy type. valid type is {pre|post|both}")
+			}
+			args = args[:len(args)-2]
+		}
+		overflowAction := api.AddBmpRequest_QUEUE_OVERFLOW_ACTION_UNSPECIFIED
+		switch bmpOpts.QueueOverflowAction {
+		case "":
+		case "drop":
+			overflowAction = api.AddBmpRequest_QUEUE_OVERFLOW_ACTION_DROP
+		case "disconnect":
+			overflowAction = api.AddBmpRequest_QUEUE_OVERFLOW_ACTION_DISCONNECT
+		default:
+			return fmt.Errorf("invalid queue overflow action. valid action is {drop|disconnect}")
+		}
 		policyType := api.AddBmpRequest_MONITORING_POLICY_PRE
 		if len(args) > 1 {
 			switch args[1] {
@@ -111,10 +141,19 @@ func modBmpServer(cmdType string, args []string) error {
 			}
 		}
 		_, err = client.AddBmp(ctx, &api.AddBmpRequest{
-			Address:           address,
-			Port:              port,
-			Policy:            policyType,
-			StatisticsTimeout: int32(statisticsTimeout),
+			Address:             address,
+			Port:                port,
+			Policy:              policyType,
+			StatisticsTimeout:   int32(statisticsTimeout),
+			AdjRibOutPrePolicy:  adjRibOutPre,
+			AdjRibOutPostPolicy: adjRibOutPost,
+			Tls:                 bmpOpts.Tls,
+			TlsCaFile:           bmpOpts.TlsCaFile,
+			TlsCertFile:         bmpOpts.TlsCertFile,
+			TlsKeyFile:          bmpOpts.TlsKeyFile,
+			TlsServerName:       bmpOpts.TlsServerName,
+			QueueSize:           bmpOpts.QueueSize,
+			QueueOverflowAction: overflowAction,
 		})
 	case cmdDel:
 		_, err = client.DeleteBmp(ctx, &api.DeleteBmpRequest{
@@ -125,6 +164,220 @@ func modBmpServer(cmdType string, args []string) error {
 	return err
 }
 
+func showBmpRouters() error {
+	stream, err := client.ListBmpRouter(ctx, &api.ListBmpRouterRequest{})
+	if err != nil {
+		return err
+	}
+	routers := make([]*api.BmpRouter, 0)
+	for {
+		rsp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		routers = append(routers, rsp.Router)
+	}
+	if globalOpts.Json {
+		j, _ := json.Marshal(routers)
+		fmt.Println(string(j))
+		return nil
+	}
+	format := "%-39s %-20s %-10s %5s %10s\n"
+	fmt.Printf(format, "Router", "SysName", "Uptime", "Peers", "Messages")
+	for _, r := range routers {
+		fmt.Printf(format, r.Address, r.SysName, formatTimedelta(r.Uptime.AsTime()), fmt.Sprint(r.Peers), fmt.Sprint(r.Messages))
+	}
+	return nil
+}
+
+func showBmpMonitoredPeers(router string) error {
+	stream, err := client.ListBmpMonitoredPeer(ctx, &api.ListBmpMonitoredPeerRequest{Router: router})
+	if err != nil {
+		return err
+	}
+	peers := make([]*api.BmpMonitoredPeer, 0)
+	for {
+		rsp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		peers = append(peers, rsp.Peer)
+	}
+	if globalOpts.Json {
+		j, _ := json.Marshal(peers)
+		fmt.Println(string(j))
+		return nil
+	}
+	typeStr := map[api.BmpMonitoredPeer_Type]string{
+		api.BmpMonitoredPeer_TYPE_GLOBAL:  "global",
+		api.BmpMonitoredPeer_TYPE_L3VPN:   "l3vpn",
+		api.BmpMonitoredPeer_TYPE_LOCAL:   "local",
+		api.BmpMonitoredPeer_TYPE_LOC_RIB: "loc-rib",
+	}
+	format := "%-39s %-8s %-21s %10s %-5s %-10s %8s %8s %8s\n"
+	fmt.Printf(format, "Peer", "Type", "RD", "AS", "State", "Up/Down", "Pre", "Post", "Loc-RIB")
+	for _, p := range peers {
+		state := "Down"
+		if p.Up {
+			state = "Up"
+		}
+		rd := ""
+		if p.Distinguisher != 0 {
+			b := make([]byte, 8)
+			binary.BigEndian.PutUint64(b, p.Distinguisher)
+			rd = bgp.GetRouteDistinguisher(b).String()
+		}
+		fmt.Printf(format, p.Address, typeStr[p.Type], rd, fmt.Sprint(p.Asn), state, formatTimedelta(p.Timestamp.AsTime()), fmt.Sprint(p.AdjRibInPre), fmt.Sprint(p.AdjRibInPost), fmt.Sprint(p.LocRib))
+	}
+	return nil
+}
+
+func showBmpRoutes(router string, args []string) error {
+	usage := fmt.Errorf("usage: gobgp bmp station <router> {pre <peer>|post <peer>|loc-rib} [rd <rd>] [-a <address family>]")
+	if len(args) < 1 {
+		return usage
+	}
+	req := &api.ListBmpRouteRequest{Router: router}
+	switch args[0] {
+	case "pre":
+		req.RibType = api.ListBmpRouteRequest_RIB_TYPE_ADJ_IN_PRE
+	case "post":
+		req.RibType = api.ListBmpRouteRequest_RIB_TYPE_ADJ_IN_POST
+	case "loc-rib":
+		req.RibType = api.ListBmpRouteRequest_RIB_TYPE_LOC_RIB
+	default:
+		return usage
+	}
+	args = args[1:]
+	var def *api.Family
+	if req.RibType != api.ListBmpRouteRequest_RIB_TYPE_LOC_RIB {
+		if len(args) < 1 {
+			return usage
+		}
+		addr, err := netip.ParseAddr(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid peer address: %s", args[0])
+		}
+		req.Peer = args[0]
+		def = addr2AddressFamily(addr)
+		args = args[1:]
+	}
+	if len(args) > 0 {
+		if len(args) != 2 || args[0] != "rd" {
+			return usage
+		}
+		rd, err := bgp.ParseRouteDistinguisher(args[1])
+		if err != nil {
+			return err
+		}
+		b, _ := rd.Serialize()
+		req.Distinguisher = binary.BigEndian.Uint64(b)
+	}
+	family, err := checkAddressFamily(def)
+	if err != nil {
+		return err
+	}
+	req.Family = family
+
+	stream, err := client.ListBmpRoute(ctx, req)
+	if err != nil {
+		return err
+	}
+	rib := make([]*api.Destination, 0)
+	for {
+		rsp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		rib = append(rib, rsp.Destination)
+	}
+	if globalOpts.Json {
+		d := make(map[string]*apiutil.Destination)
+		for _, dst := range rib {
+			d[dst.Prefix] = apiutil.NewDestination(dst)
+		}
+		j, _ := json.Marshal(d)
+		fmt.Println(string(j))
+		return nil
+	}
+	if len(rib) == 0 {
+		fmt.Println("Network not in table")
+		return nil
+	}
+	sort.Slice(rib, func(i, j int) bool {
+		return rib[i].Prefix < rib[j].Prefix
+	})
+	showLabel := false
+	switch apiutil.ToFamily(family) {
+	case bgp.RF_IPv4_MPLS, bgp.RF_IPv6_MPLS, bgp.RF_IPv4_VPN, bgp.RF_IPv6_VPN, bgp.RF_EVPN:
+		showLabel = true
+	}
+	showRoute(rib, true, req.RibType == api.ListBmpRouteRequest_RIB_TYPE_LOC_RIB, showLabel, false, false, bgp.BGP_ADD_PATH_RECEIVE)
+	return nil
+}
+
+func modBmpStation(cmdType string, args []string) error {
+	var err error
+	switch cmdType {
+	case cmdEnable:
+		req := &api.EnableBmpStationRequest{}
+		if len(args) > 0 {
+			if host, p, err := net.SplitHostPort(args[0]); err == nil {
+				req.Address = host
+				pn, err := strconv.ParseUint(p, 10, 16)
+				if err != nil {
+					return fmt.Errorf("invalid port: %s", p)
+				}
+				req.Port = uint32(pn)
+			} else {
+				req.Address = args[0]
+			}
+		}
+		_, err = client.EnableBmpStation(ctx, req)
+	case cmdDisable:
+		_, err = client.DisableBmpStation(ctx, &api.DisableBmpStationRequest{})
+	}
+	return err
+}
+
+func newBmpStationCmd() *cobra.Command {
+	stationCmd := &cobra.Command{
+		Use: cmdStation,
+		Run: func(cmd *cobra.Command, args []string) {
+			var err error
+			switch len(args) {
+			case 0:
+				err = showBmpRouters()
+			case 1:
+				err = showBmpMonitoredPeers(args[0])
+			default:
+				err = showBmpRoutes(args[0], args[1:])
+			}
+			if err != nil {
+				exitWithError(err)
+			}
+		},
+	}
+	stationCmd.PersistentFlags().StringVarP(&subOpts.AddressFamily, "address-family", "a", "", "address family")
+	for _, w := range []string{cmdEnable, cmdDisable} {
+		stationCmd.AddCommand(&cobra.Command{
+			Use: w,
+			Run: func(cmd *cobra.Command, args []string) {
+				if err := modBmpStation(cmd.Use, args); err != nil {
+					exitWithError(err)
+				}
+			},
+		})
+	}
+	return stationCmd
+}
+
 func newBmpCmd() *cobra.Command {
 	bmpCmd := &cobra.Command{
 		Use: cmdBMP,
@@ -145,9 +398,17 @@ func newBmpCmd() *cobra.Command {
 		}
 		if w == cmdAdd {
 			subcmd.PersistentFlags().IntVarP(&bmpOpts.StatisticsTimeout, "statistics-timeout", "s", 0, "Timeout of statistics report")
+			subcmd.PersistentFlags().BoolVarP(&bmpOpts.Tls, "tls", "", false, "Connect with TLS")
+			subcmd.PersistentFlags().StringVarP(&bmpOpts.TlsCaFile, "tls-ca-file", "", "", "The CA certificates to verify the BMP server with")
+			subcmd.PersistentFlags().StringVarP(&bmpOpts.TlsCertFile, "tls-cert-file", "", "", "The client certificate file")
+			subcmd.PersistentFlags().StringVarP(&bmpOpts.TlsKeyFile, "tls-key-file", "", "", "The client key file")
+			subcmd.PersistentFlags().StringVarP(&bmpOpts.TlsServerName, "tls-server-name", "", "", "The name to verify the certificate of the BMP server with")
+			subcmd.PersistentFlags().Uint32VarP(&bmpOpts.QueueSize, "queue-size", "", 0, "The number of the queued messages, unbounded if 0")
+			subcmd.PersistentFlags().StringVarP(&bmpOpts.QueueOverflowAction, "queue-overflow-action", "", "", "The action when the queue is full {drop|disconnect}")
 		}
 		bmpCmd.AddCommand(subcmd)
 	}
+	bmpCmd.AddCommand(newBmpStationCmd())
 
 	return bmpCmd
 }
@@ -68,9 +68,18 @@ const (
 	cmdMonitor        = "monitor"
 	cmdMRT            = "mrt"
 	cmdInject         = "inject"
+	cmdReplay         = "replay"
+	cmdStart          = "start"
+	cmdStop           = "stop"
+	cmdPause          = "pause"
+	cmdResume         = "resume"
+	cmdSeek           = "seek"
 	cmdRPKI           = "rpki"
 	cmdRPKITable      = "table"
 	cmdRPKIServer     = "server"
+	cmdRPKIFile       = "file"
+	cmdReload         = "reload"
+	cmdFile           = "file"
 	cmdVRF            = "vrf"
 	cmdAccepted       = "accepted"
 	cmdRejected       = "rejected"
@@ -79,8 +88,10 @@ const (
 	cmdAction         = "action"
 	cmdUpdate         = "update"
 	cmdBMP            = "bmp"
+	cmdStation        = "station"
 	cmdLargecommunity = "large-community"
 	cmdSummary        = "summary"
+	cmdUpdateGroups   = "update-groups"
 	cmdLogLevel       = "log-level"
 	cmdPanic          = "panic"
 	cmdFatal          = "fatal"
@@ -89,6 +100,15 @@ const (
 	cmdInfo           = "info"
 	cmdDebug          = "debug"
 	cmdTrace          = "trace"
+	cmdTest           = "test"
+	cmdPath           = "path"
+	cmdTransaction    = "transaction"
+	cmdCommit         = "commit"
+	cmdAbort          = "abort"
+	cmdDiff           = "diff"
+	cmdVersion        = "version"
+	cmdRollback       = "rollback"
+	cmdPrefixLimit    = "prefix-limit"
 )
 
 const (
@@ -102,25 +122,57 @@ var subOpts struct {
 	BatchSize     uint64 `short:"b" long:"batch-size" description:"Size of the temporary buffer in the server memory. Zero is unlimited (default)"`
 }
 
+var policyOpts struct {
+	Transaction string `long:"transaction" description:"The policy transaction to stage the change in"`
+	FileType    string `long:"type" description:"The type of the defined set of the file"`
+	FileFormat  string `long:"format" description:"The format of the defined set file"`
+}
+
 var neighborsOpts struct {
 	Reason    string `short:"r" long:"reason" description:"specifying communication field on Cease NOTIFICATION message with Administrative Shutdown subcode"`
 	Transport string `short:"t" long:"transport" description:"specifying a transport protocol"`
 }
 
 var mrtOpts struct {
-	Filename    string `long:"filename" description:"MRT file name"`
-	RecordCount int64  `long:"count" description:"Number of records to inject"`
-	RecordSkip  int64  `long:"skip" description:"Number of records to skip before injecting"`
-	QueueSize   int    `long:"batch-size" description:"Maximum number of updates to keep queued"`
-	Best        bool   `long:"only-best" description:"only keep best path routes"`
-	SkipV4      bool   `long:"no-ipv4" description:"Skip importing IPv4 routes"`
-	SkipV6      bool   `long:"no-ipv4" description:"Skip importing IPv6 routes"`
-	NextHop     net.IP `long:"nexthop" description:"Rewrite nexthop"`
-	PeerASN     uint32 `long:"peer-asn" description:"Inject prefixes only from specified AS number"`
+	Filename    string   `long:"filename" description:"MRT file name"`
+	RecordCount int64    `long:"count" description:"Number of records to inject"`
+	RecordSkip  int64    `long:"skip" description:"Number of records to skip before injecting"`
+	QueueSize   int      `long:"batch-size" description:"Maximum number of updates to keep queued"`
+	Best        bool     `long:"only-best" description:"only keep best path routes"`
+	SkipV4      bool     `long:"no-ipv4" description:"Skip importing IPv4 routes"`
+	SkipV6      bool     `long:"no-ipv4" description:"Skip importing IPv6 routes"`
+	NextHop     net.IP   `long:"nexthop" description:"Rewrite nexthop"`
+	PeerASN     uint32   `long:"peer-asn" description:"Inject prefixes only from specified AS number"`
+	Name        string   `long:"name" description:"The name of the replay"`
+	Speed       float64  `long:"speed" description:"The pacing multiplier of the replay"`
+	Unpaced     bool     `long:"unpaced" description:"Replay as fast as possible"`
+	Peers       []string `long:"peer" description:"Map a peer in the file to a synthetic neighbor"`
+	MappedOnly  bool     `long:"mapped-only" description:"Replay only the mapped peers"`
 }
 
 var bmpOpts struct {
-	StatisticsTimeout int `short:"s" long:"statistics-timeout" description:"Interval for Statistics Report"`
+	StatisticsTimeout   int    `short:"s" long:"statistics-timeout" description:"Interval for Statistics Report"`
+	Tls                 bool   `long:"tls" description:"Connect with TLS"`
+	TlsCaFile           string `long:"tls-ca-file" description:"The CA certificates to verify the BMP server with"`
+	TlsCertFile         string `long:"tls-cert-file" description:"The client certificate file"`
+	TlsKeyFile          string `long:"tls-key-file" description:"The client key file"`
+	TlsServerName       string `long:"tls-server-name" description:"The name to verify the certificate of the BMP server with"`
+	QueueSize           uint32 `long:"queue-size" description:"The number of the queued messages"`
+	QueueOverflowAction string `long:"queue-overflow-action" description:"The action when the queue is full"`
+}
+
+var rpkiOpts struct {
+	Port              uint32 `long:"port" description:"The port of the RPKI cache server"`
+	Transport         string `long:"transport" description:"The transport to the RPKI cache server"`
+	MaxVersion        int    `long:"max-version" description:"The highest RTR protocol version to negotiate"`
+	TlsCaFile         string `long:"tls-ca-file" description:"The CA certificates to verify the RPKI cache server with"`
+	TlsCertFile       string `long:"tls-cert-file" description:"The client certificate file"`
+	TlsKeyFile        string `long:"tls-key-file" description:"The client key file"`
+	TlsServerName     string `long:"tls-server-name" description:"The name to verify the certificate of the RPKI cache server with"`
+	SshUsername       string `long:"ssh-username" description:"The SSH user name"`
+	SshPrivateKeyFile string `long:"ssh-private-key-file" description:"The SSH private key file"`
+	SshKnownHostsFile string `long:"ssh-known-hosts-file" description:"The SSH known hosts file"`
+	FileFormat        string `long:"format" description:"The format of the local RPKI file"`
 }
 
 func formatTimedelta(t time.Time) string {
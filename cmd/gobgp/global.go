@@ -3308,6 +3308,9 @@ func showGlobalConfig() error {
 	if g.UseMultiplePaths {
 		fmt.Printf("Multipath: enabled")
 	}
+	if g.BgpsecSigning != nil && g.BgpsecSigning.Ski != "" {
+		fmt.Println("BGPsec SKI:", g.BgpsecSigning.Ski)
+	}
 	return nil
 }
 
@@ -3459,12 +3462,22 @@ func newGlobalCmd() *cobra.Command {
 					}
 				},
 			}
+			subcmd.Flags().StringVarP(&policyOpts.Transaction, "transaction", "", "", "stage the change in the policy transaction")
 			cmd.AddCommand(subcmd)
 		}
 
 		policyCmd.AddCommand(cmd)
 	}
 
+	policyCmd.AddCommand(&cobra.Command{
+		Use: cmdReset,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := resetPolicyStatistics("", table.GLOBAL_RIB_NAME); err != nil {
+				exitWithError(err)
+			}
+		},
+	})
+
 	delCmd := &cobra.Command{
 		Use: cmdDel,
 	}
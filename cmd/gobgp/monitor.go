@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -91,6 +92,42 @@ func monitorRoute(pathList []*api.Path, showIdentifier bgp.BGPAddPathMode) {
 	}
 }
 
+func validationStateString(v *api.Validation) string {
+	return strings.TrimPrefix(v.GetState().String(), "VALIDATION_STATE_")
+}
+
+func monitorRpki(changes []*api.WatchEventResponse_RpkiEvent_Change) {
+	now := time.Now().UTC().Format(time.RFC3339)
+	for _, c := range changes {
+		p := c.Path
+		nlri, _ := apiutil.GetNativeNlri(p)
+		attrs, _ := apiutil.GetNativePathAttributes(p)
+		aspath := ""
+		for _, attr := range attrs {
+			if a, ok := attr.(*bgp.PathAttributeAsPath); ok {
+				aspath = bgp.AsPathString(a)
+			}
+		}
+		fmt.Printf("%s [RPKI] %s from %s aspath [%s] %s -> %s\n", now, nlri, p.NeighborIp, aspath,
+			validationStateString(c.OldValidation), validationStateString(p.Validation))
+	}
+}
+
+func monitorPrefixLimit(e *api.WatchEventResponse_PrefixLimitEvent) {
+	now := time.Now().UTC().Format(time.RFC3339)
+	t := strings.TrimPrefix(e.Type.String(), "TYPE_")
+	if e.Type == api.WatchEventResponse_PrefixLimitEvent_TYPE_RESTART {
+		fmt.Printf("%s [PREFIX LIMIT] %s %s\n", now, e.PeerAddress, t)
+		return
+	}
+	rf := apiutil.ToFamily(e.Family)
+	fmt.Printf("%s [PREFIX LIMIT] %s %s %s %d/%d", now, e.PeerAddress, bgp.AddressFamilyNameMap[rf], t, e.Count, e.Limit)
+	if e.Shutdown {
+		fmt.Print(" shutdown")
+	}
+	fmt.Println()
+}
+
 func newMonitorCmd() *cobra.Command {
 	var current bool
 	var batchSize uint32
@@ -219,12 +256,71 @@ func newMonitorCmd() *cobra.Command {
 	}
 	adjInCmd.PersistentFlags().StringVarP(&subOpts.AddressFamily, "address-family", "a", "", "address family")
 
+	rpkiCmd := &cobra.Command{
+		Use: cmdRPKI,
+		Run: func(cmd *cobra.Command, args []string) {
+			stream, err := client.WatchEvent(ctx, &api.WatchEventRequest{
+				Rpki:      &api.WatchEventRequest_Rpki{},
+				BatchSize: batchSize,
+			})
+			if err != nil {
+				exitWithError(err)
+			}
+			for {
+				r, err := stream.Recv()
+				if err == io.EOF {
+					break
+				} else if err != nil {
+					exitWithError(err)
+				}
+				if e := r.GetRpki(); e != nil {
+					if globalOpts.Json {
+						j, _ := json.Marshal(e.Changes)
+						fmt.Println(string(j))
+					} else {
+						monitorRpki(e.Changes)
+					}
+				}
+			}
+		},
+	}
+
+	prefixLimitCmd := &cobra.Command{
+		Use: cmdPrefixLimit,
+		Run: func(cmd *cobra.Command, args []string) {
+			stream, err := client.WatchEvent(ctx, &api.WatchEventRequest{
+				PrefixLimit: &api.WatchEventRequest_PrefixLimit{},
+			})
+			if err != nil {
+				exitWithError(err)
+			}
+			for {
+				r, err := stream.Recv()
+				if err == io.EOF {
+					break
+				} else if err != nil {
+					exitWithError(err)
+				}
+				if e := r.GetPrefixLimit(); e != nil {
+					if globalOpts.Json {
+						j, _ := json.Marshal(e)
+						fmt.Println(string(j))
+					} else {
+						monitorPrefixLimit(e)
+					}
+				}
+			}
+		},
+	}
+
 	monitorCmd := &cobra.Command{
 		Use: cmdMonitor,
 	}
 	monitorCmd.AddCommand(globalCmd)
 	monitorCmd.AddCommand(neighborCmd)
 	monitorCmd.AddCommand(adjInCmd)
+	monitorCmd.AddCommand(rpkiCmd)
+	monitorCmd.AddCommand(prefixLimitCmd)
 
 	monitorCmd.PersistentFlags().BoolVarP(&current, "current", "", false, "dump current contents")
 	monitorCmd.PersistentFlags().Uint32VarP(&batchSize, "batch-size", "", 0, "max paths per event message")
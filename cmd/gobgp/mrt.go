@@ -218,6 +218,199 @@ func injectMrt() error {
 	return nil
 }
 
+// parseMrtReplayPeer parses <mrt address>=<address>[,<asn>[,<router id>]].
+func parseMrtReplayPeer(arg string) (*api.MrtReplayPeer, error) {
+	mrtAddr, rest, ok := strings.Cut(arg, "=")
+	if !ok {
+		return nil, fmt.Errorf("invalid peer mapping: %s", arg)
+	}
+	if _, err := netip.ParseAddr(mrtAddr); err != nil {
+		return nil, fmt.Errorf("invalid mrt address: %s", mrtAddr)
+	}
+	p := &api.MrtReplayPeer{MrtAddress: mrtAddr}
+	elems := strings.Split(rest, ",")
+	if len(elems) > 3 {
+		return nil, fmt.Errorf("invalid peer mapping: %s", arg)
+	}
+	p.Address = elems[0]
+	if len(elems) > 1 && elems[1] != "" {
+		asn, err := strconv.ParseUint(elems[1], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid asn: %s", elems[1])
+		}
+		p.Asn = uint32(asn)
+	}
+	if len(elems) > 2 {
+		p.RouterId = elems[2]
+	}
+	return p, nil
+}
+
+func mrtReplayStateString(s api.MrtReplay_State) string {
+	switch s {
+	case api.MrtReplay_STATE_RUNNING:
+		return "running"
+	case api.MrtReplay_STATE_PAUSED:
+		return "paused"
+	case api.MrtReplay_STATE_FINISHED:
+		return "finished"
+	case api.MrtReplay_STATE_FAILED:
+		return "failed"
+	}
+	return "unknown"
+}
+
+func showMrtReplay(args []string) error {
+	name := ""
+	if len(args) > 0 {
+		name = args[0]
+	}
+	stream, err := client.ListMrtReplay(ctx, &api.ListMrtReplayRequest{Name: name})
+	if err != nil {
+		return err
+	}
+	replays := make([]*api.MrtReplay, 0)
+	for {
+		r, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		replays = append(replays, r.Replay)
+	}
+	if name == "" {
+		format := "%-20s %-40s %-9s %-10s %-10s %s\n"
+		fmt.Printf(format, "Name", "File", "State", "Offset", "Records", "Error")
+		for _, r := range replays {
+			offset := ""
+			if r.Start != nil && r.Position != nil {
+				offset = fmt.Sprint(r.Position.AsTime().Sub(r.Start.AsTime()))
+			}
+			fmt.Printf(format, r.Name, r.Filename, mrtReplayStateString(r.State), offset, fmt.Sprint(r.Records), r.Error)
+		}
+		return nil
+	}
+	for _, r := range replays {
+		fmt.Printf("Replay: %s, File: %s\n", r.Name, r.Filename)
+		fmt.Println("  State:", mrtReplayStateString(r.State))
+		if r.Unpaced {
+			fmt.Println("  Speed: unpaced")
+		} else {
+			fmt.Printf("  Speed: %gx\n", r.Speed)
+		}
+		if r.Start != nil {
+			fmt.Println("  Start:", r.Start.AsTime().Local())
+		}
+		if r.Position != nil {
+			fmt.Println("  Position:", r.Position.AsTime().Local())
+		}
+		fmt.Println("  Records:", r.Records)
+		fmt.Println("  Updates:", r.Updates)
+		fmt.Println("  Withdraws:", r.Withdraws)
+		fmt.Println("  Errors:", r.Errors)
+		for _, p := range r.Peers {
+			fmt.Printf("  Peer: %s -> %s AS %d Router ID %s\n", p.MrtAddress, p.Address, p.Asn, p.RouterId)
+		}
+		if r.MappedPeersOnly {
+			fmt.Println("  Mapped peers only")
+		}
+		if r.Error != "" {
+			fmt.Println("  Error:", r.Error)
+		}
+	}
+	return nil
+}
+
+func newMrtReplayCmd() *cobra.Command {
+	replayCmd := &cobra.Command{
+		Use: cmdReplay,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return showMrtReplay(args)
+		},
+	}
+	startCmd := &cobra.Command{
+		Use: cmdStart + " <filename>",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				exitWithError(fmt.Errorf("usage: gobgp mrt replay start <filename> [--name <name>] [--speed <speed>|--unpaced] [--peer <mrt address>=<address>[,<asn>[,<router id>]]]... [--mapped-only]"))
+			}
+			req := &api.StartMrtReplayRequest{
+				Name:            mrtOpts.Name,
+				Filename:        args[0],
+				Speed:           mrtOpts.Speed,
+				Unpaced:         mrtOpts.Unpaced,
+				MappedPeersOnly: mrtOpts.MappedOnly,
+			}
+			for _, arg := range mrtOpts.Peers {
+				p, err := parseMrtReplayPeer(arg)
+				if err != nil {
+					exitWithError(err)
+				}
+				req.Peers = append(req.Peers, p)
+			}
+			if _, err := client.StartMrtReplay(ctx, req); err != nil {
+				exitWithError(err)
+			}
+		},
+	}
+	startCmd.Flags().StringVarP(&mrtOpts.Name, "name", "", "", "The name of the replay; the filename by default")
+	startCmd.Flags().Float64VarP(&mrtOpts.Speed, "speed", "", 1, "The pacing multiplier of the intervals between the records")
+	startCmd.Flags().BoolVarP(&mrtOpts.Unpaced, "unpaced", "", false, "Replay the records as fast as possible")
+	startCmd.Flags().StringArrayVarP(&mrtOpts.Peers, "peer", "", nil, "Map a peer in the file to a synthetic neighbor: <mrt address>=<address>[,<asn>[,<router id>]]")
+	startCmd.Flags().BoolVarP(&mrtOpts.MappedOnly, "mapped-only", "", false, "Replay only the records of the mapped peers")
+
+	nameCmd := func(use string, f func(name string) error) *cobra.Command {
+		return &cobra.Command{
+			Use: use + " <name>",
+			Run: func(cmd *cobra.Command, args []string) {
+				if len(args) != 1 {
+					exitWithError(fmt.Errorf("usage: gobgp mrt replay %s <name>", use))
+				}
+				if err := f(args[0]); err != nil {
+					exitWithError(err)
+				}
+			},
+		}
+	}
+	stopCmd := nameCmd(cmdStop, func(name string) error {
+		_, err := client.StopMrtReplay(ctx, &api.StopMrtReplayRequest{Name: name})
+		return err
+	})
+	pauseCmd := nameCmd(cmdPause, func(name string) error {
+		_, err := client.PauseMrtReplay(ctx, &api.PauseMrtReplayRequest{Name: name})
+		return err
+	})
+	resumeCmd := nameCmd(cmdResume, func(name string) error {
+		_, err := client.ResumeMrtReplay(ctx, &api.ResumeMrtReplayRequest{Name: name})
+		return err
+	})
+	seekCmd := &cobra.Command{
+		Use: cmdSeek + " <name> <offset>",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				exitWithError(fmt.Errorf("usage: gobgp mrt replay seek <name> <offset>"))
+			}
+			offset, err := time.ParseDuration(args[1])
+			if err != nil {
+				var sec uint64
+				if sec, err = strconv.ParseUint(args[1], 10, 64); err != nil {
+					exitWithError(fmt.Errorf("invalid offset: %s", args[1]))
+				}
+				offset = time.Duration(sec) * time.Second
+			}
+			if offset < 0 {
+				exitWithError(fmt.Errorf("invalid offset: %s", args[1]))
+			}
+			if _, err := client.SeekMrtReplay(ctx, &api.SeekMrtReplayRequest{Name: args[0], Offset: uint64(offset / time.Second)}); err != nil {
+				exitWithError(err)
+			}
+		},
+	}
+	replayCmd.AddCommand(startCmd, stopCmd, pauseCmd, resumeCmd, seekCmd)
+	return replayCmd
+}
+
 func newMrtCmd() *cobra.Command {
 	globalInjectCmd := &cobra.Command{
 		Use: cmdGlobal,
@@ -257,7 +450,7 @@ func newMrtCmd() *cobra.Command {
 	mrtCmd := &cobra.Command{
 		Use: cmdMRT,
 	}
-	mrtCmd.AddCommand(injectCmd)
+	mrtCmd.AddCommand(injectCmd, newMrtReplayCmd())
 
 	mrtCmd.PersistentFlags().BoolVarP(&mrtOpts.Best, "only-best", "", false, "inject only best paths")
 	mrtCmd.PersistentFlags().BoolVarP(&mrtOpts.SkipV4, "no-ipv4", "", false, "Do not import IPv4 routes")
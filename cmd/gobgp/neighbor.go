@@ -33,6 +33,7 @@ import (
 	"golang.org/x/text/language"
 
 	"github.com/osrg/gobgp/v4/api"
+	"github.com/osrg/gobgp/v4/internal/pkg/table"
 	"github.com/osrg/gobgp/v4/pkg/apiutil"
 	"github.com/osrg/gobgp/v4/pkg/config/oc"
 	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
@@ -282,13 +283,23 @@ func showNeighbor(args []string) error {
 		id = p.State.RouterId
 	}
 	fmt.Printf("  BGP version 4, remote router ID %s\n", id)
+	if p.RouteReflector.AdvertiseBestExternal {
+		fmt.Printf("  Advertising the best external path\n")
+	}
+	if p.RouteReflector.DiversePath {
+		fmt.Printf("  Advertising the diverse path\n")
+	}
 	fmt.Printf("  BGP state = %s", p.State.SessionState)
 	if p.Timers.State.Uptime != nil {
 		fmt.Printf(", up for %s\n", formatTimedelta(p.Timers.State.Uptime.AsTime()))
 	} else {
 		fmt.Print("\n")
 	}
-	fmt.Printf("  BGP OutQ = %d, Flops = %d\n", p.State.Queues.Output, p.State.Flops)
+	fmt.Printf("  BGP OutQ = %d, Flops = %d", p.State.Queues.Output, p.State.Flops)
+	if blocked := p.State.Queues.OutputBlockedSeconds; blocked >= 1 {
+		fmt.Printf(", send blocked for %s", formatTimedelta(time.Now().Add(-time.Duration(blocked*float64(time.Second)))))
+	}
+	fmt.Print("\n")
 	fmt.Printf("  Local address is %s, local ASN: %s\n", p.Transport.LocalAddress, getLocalASN(p))
 
 	// Display nexthop information
@@ -308,6 +319,9 @@ func showNeighbor(args []string) error {
 
 	fmt.Printf("  Hold time is %d, keepalive interval is %d seconds\n", int(p.Timers.State.NegotiatedHoldTime), int(p.Timers.State.KeepaliveInterval))
 	fmt.Printf("  Configured hold time is %d, keepalive interval is %d seconds\n", int(p.Timers.Config.HoldTime), int(p.Timers.Config.KeepaliveInterval))
+	if t := p.Timers.State.SendHoldTime; t > 0 {
+		fmt.Printf("  Send hold time is %d seconds\n", int(t))
+	}
 
 	elems := make([]string, 0, 3)
 	if as := p.Conf.AllowOwnAsn; as > 0 {
@@ -358,6 +372,7 @@ func showNeighbor(args []string) error {
 	})
 
 	firstMp := true
+	firstBgpsec := true
 
 	for _, c := range caps {
 		support := ""
@@ -493,6 +508,45 @@ func showNeighbor(args []string) error {
 					fmt.Printf("         %s:\t%s\n", item.Family, item.Mode)
 				}
 			}
+		case bgp.BGP_CAP_OUTBOUND_ROUTE_FILTERING:
+			fmt.Printf("    %s:\t%s\n", c.Code(), support)
+			orfStr := func(o *bgp.CapOutboundRouteFiltering) {
+				for _, t := range o.Tuples {
+					for _, e := range t.Entries {
+						fmt.Printf("         %s:\t%s %s\n", t.Family, e.Type, e.Mode)
+					}
+				}
+			}
+			if m := lookup(c, lcaps); m != nil {
+				fmt.Println("      Local:")
+				orfStr(m.(*bgp.CapOutboundRouteFiltering))
+			}
+			if m := lookup(c, rcaps); m != nil {
+				fmt.Println("      Remote:")
+				orfStr(m.(*bgp.CapOutboundRouteFiltering))
+			}
+		case bgp.BGP_CAP_BGPSEC:
+			// one capability is advertised per AFI and direction
+			if !firstBgpsec {
+				continue
+			}
+			firstBgpsec = false
+			fmt.Printf("    %s:\t%s\n", c.Code(), support)
+			bgpsecStr := func(l []bgp.ParameterCapabilityInterface) {
+				for _, v := range l {
+					if b, ok := v.(*bgp.CapBGPsec); ok {
+						fmt.Printf("         %s:\t%s\n", bgp.NewFamily(b.AFI, bgp.SAFI_UNICAST), b.Direction)
+					}
+				}
+			}
+			if m := lookup(c, lcaps); m != nil {
+				fmt.Println("      Local:")
+				bgpsecStr(lcaps)
+			}
+			if m := lookup(c, rcaps); m != nil {
+				fmt.Println("      Remote:")
+				bgpsecStr(rcaps)
+			}
 		case bgp.BGP_CAP_FQDN:
 			fmt.Printf("    %s:\t%s\n", c.Code(), support)
 			if m := lookup(c, lcaps); m != nil {
@@ -537,17 +591,88 @@ func showNeighbor(args []string) error {
 	first := true
 	for _, a := range p.AfiSafis {
 		limit := a.PrefixLimits
-		if limit != nil && limit.MaxPrefixes > 0 {
+		if limit != nil && (limit.MaxPrefixes > 0 || limit.MaxPrefixesOut > 0) {
 			if first {
 				fmt.Println("  Prefix Limits:")
 				first = false
 			}
 			rf := apiutil.ToFamily(limit.Family)
-			fmt.Printf("    %s:\tMaximum prefixes allowed %d", bgp.AddressFamilyNameMap[rf], limit.MaxPrefixes)
-			if limit.ShutdownThresholdPct > 0 {
-				fmt.Printf(", Threshold for warning message %d%%\n", limit.ShutdownThresholdPct)
-			} else {
-				fmt.Printf("\n")
+			state := limit.State
+			if limit.MaxPrefixes > 0 {
+				fmt.Printf("    %s:\tMaximum prefixes allowed %d", bgp.AddressFamilyNameMap[rf], limit.MaxPrefixes)
+				if limit.ShutdownThresholdPct > 0 {
+					fmt.Printf(", Threshold for warning message %d%%\n", limit.ShutdownThresholdPct)
+				} else {
+					fmt.Printf("\n")
+				}
+				action := "shutdown"
+				if limit.WarningOnly {
+					action = "warning-only"
+				} else if limit.RestartTimer > 0 {
+					action = fmt.Sprintf("shutdown, restart after %vs", limit.RestartTimer)
+				}
+				fmt.Printf("      Counting %s prefixes, %s\n", strings.ToLower(strings.TrimPrefix(limit.CountType.String(), "COUNT_TYPE_")), action)
+				if state != nil {
+					fmt.Printf("      Current %d (%d%%)", state.Count, state.UtilizationPct)
+					if state.LimitExceeded {
+						fmt.Print(", limit exceeded")
+					} else if state.ThresholdExceeded {
+						fmt.Print(", threshold exceeded")
+					}
+					if state.RestartTime != nil {
+						fmt.Printf(", restart at %s", state.RestartTime.AsTime().Local().Format(time.RFC3339))
+					}
+					fmt.Printf("\n")
+				}
+			}
+			if limit.MaxPrefixesOut > 0 {
+				fmt.Printf("    %s:\tMaximum prefixes advertised %d\n", bgp.AddressFamilyNameMap[rf], limit.MaxPrefixesOut)
+				if state != nil {
+					fmt.Printf("      Current %d (%d%%)", state.CountOut, state.UtilizationOutPct)
+					if state.LimitOutExceeded {
+						fmt.Print(", limit exceeded")
+					}
+					fmt.Printf("\n")
+				}
+			}
+		}
+	}
+	orfEntryStr := func(e *api.OrfPrefixEntry) string {
+		match := "permit"
+		if e.Deny {
+			match = "deny"
+		}
+		str := fmt.Sprintf("seq %d %s %s", e.Sequence, match, e.Prefix)
+		if e.MinLen > 0 {
+			str += fmt.Sprintf(" ge %d", e.MinLen)
+		}
+		if e.MaxLen > 0 {
+			str += fmt.Sprintf(" le %d", e.MaxLen)
+		}
+		return str
+	}
+	first = true
+	for _, a := range p.AfiSafis {
+		orf := a.OutboundRouteFiltering
+		if orf == nil || orf.State == nil || len(orf.State.Received)+len(orf.State.Sent) == 0 {
+			continue
+		}
+		if first {
+			fmt.Println("  Outbound Route Filtering:")
+			first = false
+		}
+		rf := apiutil.ToFamily(a.Config.Family)
+		fmt.Printf("    %s:\n", bgp.AddressFamilyNameMap[rf])
+		if len(orf.State.Sent) > 0 {
+			fmt.Printf("      Sent: %d entries\n", len(orf.State.Sent))
+			for _, e := range orf.State.Sent {
+				fmt.Printf("        %s\n", orfEntryStr(e))
+			}
+		}
+		if len(orf.State.Received) > 0 {
+			fmt.Printf("      Received: %d entries\n", len(orf.State.Received))
+			for _, e := range orf.State.Received {
+				fmt.Printf("        %s\n", orfEntryStr(e))
 			}
 		}
 	}
@@ -749,6 +874,33 @@ func checkOriginAsWasNotShown(p *api.Path, asPath []bgp.AsPathParamInterface, sh
 	return true
 }
 
+func showBestPathReason(d *api.Destination) {
+	fmt.Printf("Target Prefix: %s\n", d.Prefix)
+	for idx, p := range d.GetPaths() {
+		attrs, _ := apiutil.GetNativePathAttributes(p)
+		nexthop := "fictitious"
+		if n := getNextHopFromPathAttributes(attrs); n.IsValid() {
+			nexthop = n.String()
+		}
+		neighbor := "local"
+		if p.NeighborIp != "" {
+			neighbor = p.NeighborIp
+		}
+		aspath := ""
+		for _, attr := range attrs {
+			if a, ok := attr.(*bgp.PathAttributeAsPath); ok {
+				aspath = bgp.AsPathString(a)
+			}
+		}
+		fmt.Printf("  %s %s from %s [%s]\n", getPathSymbolString(p, idx, true), nexthop, neighbor, aspath)
+		if idx == 0 {
+			fmt.Printf("    Best path, reason: %s\n", table.BestPathReasonStringMap[table.BestPathReason(p.BestPathReason)])
+		} else {
+			fmt.Printf("    Not best, lost to the previous path by: %s\n", table.BestPathReasonStringMap[table.BestPathReason(p.BestPathReason)])
+		}
+	}
+}
+
 func showValidationInfo(p *api.Path, shownAs map[uint32]struct{}) error {
 	var asPath []bgp.AsPathParamInterface
 	attrs, _ := apiutil.GetNativePathAttributes(p)
@@ -881,6 +1033,7 @@ func showNeighborRib(r string, name string, args []string) error {
 	showSendMaxFiltered := false
 	showIdentifier := bgp.BGP_ADD_PATH_NONE
 	validationTarget := ""
+	reasonTarget := ""
 	rd := ""
 
 	var def *api.Family
@@ -960,6 +1113,13 @@ func showNeighborRib(r string, name string, args []string) error {
 					return fmt.Errorf("RPKI information is supported for only adj-in")
 				}
 				validationTarget = target
+			case "best-path-reason":
+				switch r {
+				case cmdGlobal, cmdLocal, cmdVRF:
+				default:
+					return fmt.Errorf("best path reason is supported for only global, local and vrf rib")
+				}
+				reasonTarget = target
 			default:
 				return fmt.Errorf("invalid format for route filtering")
 			}
@@ -1004,6 +1164,8 @@ func showNeighborRib(r string, name string, args []string) error {
 		SortType:       api.ListPathRequest_SORT_TYPE_PREFIX,
 		EnableFiltered: enableFiltered,
 		BatchSize:      subOpts.BatchSize,
+
+		EnableBestPathReason: reasonTarget != "",
 	})
 	if err != nil {
 		return err
@@ -1063,6 +1225,20 @@ func showNeighborRib(r string, name string, args []string) error {
 				return err
 			}
 		}
+	} else if reasonTarget != "" {
+		// show best path reasons
+		var d *api.Destination
+		for _, dst := range rib {
+			if dst.Prefix == reasonTarget || strings.HasSuffix(dst.Prefix, ":"+reasonTarget) {
+				d = dst
+				break
+			}
+		}
+		if d == nil {
+			fmt.Println("Network not in table")
+			return nil
+		}
+		showBestPathReason(d)
 	} else {
 		// show RIB
 		var dsts []*api.Destination
@@ -1204,6 +1380,7 @@ func showNeighborPolicy(remoteIP, policyType string, indent int) error {
 
 	fmt.Printf("%s policy:\n", cases.Title(language.English).String(policyType))
 	fmt.Printf("%sDefault: %s\n", strings.Repeat(" ", indent), assignment.DefaultAction.String())
+	printPolicyStatistics(indent, assignment.Statistics)
 	for _, p := range assignment.Policies {
 		fmt.Printf("%sName %s:\n", strings.Repeat(" ", indent), p.Name)
 		printPolicy(indent+4, p)
@@ -1270,11 +1447,13 @@ func modNeighborPolicy(remoteIP, policyType, cmdType string, args []string) erro
 	switch cmdType {
 	case cmdAdd:
 		_, err = client.AddPolicyAssignment(ctx, &api.AddPolicyAssignmentRequest{
-			Assignment: assign,
+			Assignment:    assign,
+			TransactionId: policyOpts.Transaction,
 		})
 	case cmdSet:
 		_, err = client.SetPolicyAssignment(ctx, &api.SetPolicyAssignmentRequest{
-			Assignment: assign,
+			Assignment:    assign,
+			TransactionId: policyOpts.Transaction,
 		})
 	case cmdDel:
 		all := false
@@ -1282,8 +1461,9 @@ func modNeighborPolicy(remoteIP, policyType, cmdType string, args []string) erro
 			all = true
 		}
 		_, err = client.DeletePolicyAssignment(ctx, &api.DeletePolicyAssignmentRequest{
-			Assignment: assign,
-			All:        all,
+			Assignment:    assign,
+			All:           all,
+			TransactionId: policyOpts.Transaction,
 		})
 	}
 	return err
@@ -1307,11 +1487,13 @@ func modNeighbor(cmdType string, args []string) error {
 		params["vrf"] = paramSingle
 		params["route-reflector-client"] = paramSingle
 		params["route-server-client"] = paramFlag
+		params["advertise-best-external"] = paramFlag
+		params["diverse-path"] = paramFlag
 		params["allow-own-as"] = paramSingle
 		params["remove-private-as"] = paramSingle
 		params["replace-peer-as"] = paramFlag
 		params["ebgp-multihop-ttl"] = paramSingle
-		usage += " [ local-as <VALUE> | family <address-families-list> | vrf <vrf-name> | route-reflector-client [<cluster-id>] | route-server-client | allow-own-as <num> | remove-private-as (all|replace) | replace-peer-as | ebgp-multihop-ttl <ttl>]"
+		usage += " [ local-as <VALUE> | family <address-families-list> | vrf <vrf-name> | route-reflector-client [<cluster-id>] | route-server-client | advertise-best-external | diverse-path | allow-own-as <num> | remove-private-as (all|replace) | replace-peer-as | ebgp-multihop-ttl <ttl>]"
 	}
 
 	m, err := extractReserved(args, params)
@@ -1402,6 +1584,12 @@ func modNeighbor(cmdType string, args []string) error {
 		if _, ok := m["route-server-client"]; ok {
 			peer.RouteServer.RouteServerClient = true
 		}
+		if _, ok := m["advertise-best-external"]; ok {
+			peer.RouteReflector.AdvertiseBestExternal = true
+		}
+		if _, ok := m["diverse-path"]; ok {
+			peer.RouteReflector.DiversePath = true
+		}
 		if option, ok := m["allow-own-as"]; ok {
 			as, err := strconv.ParseUint(option[0], 10, 8)
 			if err != nil {
@@ -1465,6 +1653,78 @@ func modNeighbor(cmdType string, args []string) error {
 	return err
 }
 
+func showUpdateGroups(args []string) error {
+	req := &api.ListUpdateGroupRequest{}
+	if len(args) > 0 {
+		l, err := getNeighbors(args[0], false)
+		if err != nil {
+			return err
+		}
+		req.Address = l[0].State.NeighborAddress
+	}
+	stream, err := client.ListUpdateGroup(ctx, req)
+	if err != nil {
+		return err
+	}
+	groups := make([]*api.UpdateGroup, 0)
+	for {
+		rsp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		groups = append(groups, rsp.UpdateGroup)
+	}
+
+	if globalOpts.Json {
+		j, _ := json.Marshal(groups)
+		fmt.Println(string(j))
+		return nil
+	}
+
+	for _, g := range groups {
+		typ := "eBGP"
+		if g.Type == api.PeerType_PEER_TYPE_INTERNAL {
+			typ = "iBGP"
+		}
+		var flags []string
+		if g.RouteServerClient {
+			flags = append(flags, "route-server-client")
+		}
+		if g.RouteReflectorClient {
+			flags = append(flags, "route-reflector-client")
+		}
+		remoteAS := "any"
+		if g.PeerAsn != 0 {
+			remoteAS = fmt.Sprint(g.PeerAsn)
+		}
+		fmt.Printf("Update group %d, %s, remote AS %s, local AS %d", g.Id, typ, remoteAS, g.LocalAsn)
+		if len(flags) > 0 {
+			fmt.Printf(", %s", strings.Join(flags, ", "))
+		}
+		fmt.Println()
+		fmt.Printf("  Local address: %s\n", g.LocalAddress)
+		families := make([]string, 0, len(g.Families))
+		for _, f := range g.Families {
+			families = append(families, apiutil.ToFamily(f).String())
+		}
+		fmt.Printf("  Address families: %s\n", strings.Join(families, ", "))
+		def := "accept"
+		if g.DefaultExportAction == api.RouteAction_ROUTE_ACTION_REJECT {
+			def = "reject"
+		}
+		fmt.Printf("  Export policy: default %s", def)
+		if len(g.ExportPolicies) > 0 {
+			fmt.Printf(", %s", strings.Join(g.ExportPolicies, ", "))
+		}
+		fmt.Println()
+		fmt.Printf("  Created %s ago, %d updates built, %d sent\n", formatTimedelta(g.Created.AsTime()), g.UpdatesBuilt, g.UpdatesSent)
+		fmt.Printf("  Members (%d): %s\n", len(g.Members), strings.Join(g.Members, ", "))
+	}
+	return nil
+}
+
 func newNeighborCmd() *cobra.Command {
 	neighborCmdImpl := &cobra.Command{}
 
@@ -1567,12 +1827,26 @@ func newNeighborCmd() *cobra.Command {
 					}
 				},
 			}
+			subcmd.Flags().StringVarP(&policyOpts.Transaction, "transaction", "", "", "stage the change in the policy transaction")
 			cmd.AddCommand(subcmd)
 		}
 
 		policyCmd.AddCommand(cmd)
 	}
 
+	policyCmd.AddCommand(&cobra.Command{
+		Use: cmdReset,
+		Run: func(cmd *cobra.Command, args []string) {
+			l, err := getNeighbors(args[len(args)-1], false)
+			if err != nil {
+				exitWithError(err)
+			}
+			if err := resetPolicyStatistics("", l[0].State.NeighborAddress); err != nil {
+				exitWithError(err)
+			}
+		},
+	})
+
 	neighborCmdImpl.AddCommand(policyCmd)
 
 	neighborCmd := &cobra.Command{
@@ -1595,6 +1869,15 @@ func newNeighborCmd() *cobra.Command {
 		},
 	}
 
+	neighborCmd.AddCommand(&cobra.Command{
+		Use: cmdUpdateGroups,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := showUpdateGroups(args); err != nil {
+				exitWithError(err)
+			}
+		},
+	})
+
 	for _, v := range []string{cmdAdd, cmdDel, cmdUpdate} {
 		cmd := &cobra.Command{
 			Use: v,
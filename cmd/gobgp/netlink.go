@@ -19,6 +19,8 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -213,7 +215,15 @@ func showNetlinkExportRules() error {
 			}
 		}
 
-		if len(rule.CommunityList) == 0 && len(rule.LargeCommunityList) == 0 {
+		if len(rule.TagList) > 0 {
+			tags := make([]string, 0, len(rule.TagList))
+			for _, tag := range rule.TagList {
+				tags = append(tags, strconv.FormatUint(uint64(tag), 10))
+			}
+			fmt.Printf("  Tags:             %s\n", strings.Join(tags, ", "))
+		}
+
+		if len(rule.CommunityList) == 0 && len(rule.LargeCommunityList) == 0 && len(rule.TagList) == 0 {
 			fmt.Printf("  Communities:      (match all routes)\n")
 		}
 
@@ -281,6 +291,18 @@ func showNetlinkExportStats() error {
 		fmt.Printf("  Last Error Message:          %s\n", res.LastErrorMsg)
 	}
 
+	if len(res.Rules) > 0 {
+		fmt.Printf("\nPer Rule Statistics:\n")
+		fmt.Printf("  %-20s %-16s %10s %10s %8s %16s\n", "Rule", "VRF", "Exported", "Withdrawn", "Errors", "Nexthop Failures")
+		for _, r := range res.Rules {
+			vrf := r.Vrf
+			if vrf == "" {
+				vrf = "-"
+			}
+			fmt.Printf("  %-20s %-16s %10d %10d %8d %16d\n", r.Rule, vrf, r.Exported, r.Withdrawn, r.Errors, r.NexthopValidationFailures)
+		}
+	}
+
 	return nil
 }
 
@@ -75,6 +75,23 @@ func prettyString(v any) string {
 			typ = "<="
 		}
 		return fmt.Sprintf("%s%d", typ, a.Length)
+	case *api.PathAge:
+		var typ string
+		switch a.Type {
+		case api.Comparison_COMPARISON_EQ:
+			typ = "="
+		case api.Comparison_COMPARISON_GE:
+			typ = ">="
+		case api.Comparison_COMPARISON_LE:
+			typ = "<="
+		}
+		return fmt.Sprintf("%s%ds", typ, a.Seconds)
+	case []uint32:
+		l := make([]string, 0, len(a))
+		for _, v := range a {
+			l = append(l, strconv.FormatUint(uint64(v), 10))
+		}
+		return "[ " + strings.Join(l, ", ") + " ]"
 	case *api.CommunityAction:
 		l := regexpCommunityString.ReplaceAllString(strings.Join(a.Communities, ", "), "")
 		var typ string
@@ -108,6 +125,43 @@ func prettyString(v any) string {
 		return fmt.Sprintf("prepend %d %d times", a.Asn, a.Repeat)
 	case *api.OriginAction:
 		return fmt.Sprintf("%v", a.GetOrigin())
+	case *api.AsPathReplaceAction:
+		as := "local-as"
+		if a.ReplaceAsn != 0 {
+			as = fmt.Sprintf("%d", a.ReplaceAsn)
+		}
+		if a.Any {
+			return fmt.Sprintf("replace any with %s", as)
+		}
+		l := make([]string, 0, len(a.Asns))
+		for _, asn := range a.Asns {
+			l = append(l, fmt.Sprintf("%d", asn))
+		}
+		return fmt.Sprintf("replace %s with %s", strings.Join(l, ","), as)
+	case *api.AsPathRemovePrivateAction:
+		switch a.RemovePrivate {
+		case api.RemovePrivate_REMOVE_PRIVATE_ALL:
+			return "all"
+		case api.RemovePrivate_REMOVE_PRIVATE_REPLACE:
+			return "replace"
+		}
+	case *api.AsPathExcludeAction:
+		l := make([]string, 0, len(a.Asns))
+		for _, asn := range a.Asns {
+			l = append(l, fmt.Sprintf("%d", asn))
+		}
+		return fmt.Sprintf("[%s]", strings.Join(l, ", "))
+	case *api.WeightAction:
+		return fmt.Sprintf("%d", a.Value)
+	case *api.TagAction:
+		return fmt.Sprintf("%d", a.Value)
+	case *api.AigpAction:
+		if a.Type == api.AigpAction_TYPE_MOD && a.Value >= 0 {
+			return fmt.Sprintf("+%d", a.Value)
+		}
+		return fmt.Sprintf("%d", a.Value)
+	case *api.LinkBandwidthAction:
+		return fmt.Sprintf("%dMbps", a.Bandwidth)
 	}
 	return "unknown"
 }
@@ -417,7 +471,8 @@ func modDefinedSet(settype string, modtype string, args []string) error {
 	switch modtype {
 	case cmdAdd:
 		_, err = client.AddDefinedSet(ctx, &api.AddDefinedSetRequest{
-			DefinedSet: d,
+			DefinedSet:    d,
+			TransactionId: policyOpts.Transaction,
 		})
 	case cmdDel:
 		all := false
@@ -425,8 +480,9 @@ func modDefinedSet(settype string, modtype string, args []string) error {
 			all = true
 		}
 		_, err = client.DeleteDefinedSet(ctx, &api.DeleteDefinedSetRequest{
-			DefinedSet: d,
-			All:        all,
+			DefinedSet:    d,
+			All:           all,
+			TransactionId: policyOpts.Transaction,
 		})
 	}
 	return err
@@ -437,6 +493,7 @@ func printStatement(indent int, s *api.Statement) {
 		return strings.Repeat(" ", indent)
 	}
 	fmt.Printf("%sStatementName %s:\n", sIndent(indent), s.Name)
+	printPolicyStatistics(indent+2, s.Statistics)
 	fmt.Printf("%sConditions:\n", sIndent(indent+2))
 
 	ind := sIndent(indent + 4)
@@ -486,12 +543,48 @@ func printStatement(indent int, s *api.Statement) {
 	if c.RpkiResult != -1 {
 		fmt.Printf("%sRPKI result: %s\n", ind, state)
 	}
+	switch c.BgpsecResult {
+	case api.BgpsecValidationState_BGPSEC_VALIDATION_STATE_NOT_SIGNED:
+		fmt.Printf("%sBGPsec result: NOT_SIGNED\n", ind)
+	case api.BgpsecValidationState_BGPSEC_VALIDATION_STATE_VALID:
+		fmt.Printf("%sBGPsec result: VALID\n", ind)
+	case api.BgpsecValidationState_BGPSEC_VALIDATION_STATE_INVALID:
+		fmt.Printf("%sBGPsec result: INVALID\n", ind)
+	}
 	if c.RouteType != api.Conditions_ROUTE_TYPE_UNSPECIFIED {
 		fmt.Printf("%sRoute Type: %s\n", ind, routeTypePrettyString(c.RouteType))
 	}
 	if c.AfiSafiIn != nil {
 		fmt.Printf("%sAFI SAFI In: %s\n", ind, c.AfiSafiIn)
 	}
+	if c.CallPolicy != "" {
+		fmt.Printf("%sCall Policy: %s\n", ind, c.CallPolicy)
+	}
+	if len(c.OriginAsns) > 0 {
+		fmt.Printf("%sOrigin AS: %s\n", ind, prettyString(c.OriginAsns))
+	}
+	if len(c.NeighborAsns) > 0 {
+		fmt.Printf("%sNeighbor AS: %s\n", ind, prettyString(c.NeighborAsns))
+	}
+	if c.PrefixLengthRange != nil {
+		fmt.Printf("%sPrefix Length: %d..%d\n", ind, c.PrefixLengthRange.Min, c.PrefixLengthRange.Max)
+	}
+	if len(c.SourcePeerTypes) > 0 {
+		types := make([]string, 0, len(c.SourcePeerTypes))
+		for _, t := range c.SourcePeerTypes {
+			types = append(types, strings.ToLower(strings.TrimPrefix(t.String(), "SOURCE_PEER_TYPE_")))
+		}
+		fmt.Printf("%sSource Peer Type: %s\n", ind, "[ "+strings.Join(types, ", ")+" ]")
+	}
+	if c.PathAge != nil {
+		fmt.Printf("%sPath Age: %s\n", ind, prettyString(c.PathAge))
+	}
+	if c.AigpRange != nil {
+		fmt.Printf("%sAIGP: %d..%d\n", ind, c.AigpRange.Min, c.AigpRange.Max)
+	}
+	if c.AttributeTypePresent != 0 {
+		fmt.Printf("%sAttribute Present: %s\n", ind, bgp.BGPAttrType(c.AttributeTypePresent))
+	}
 
 	fmt.Printf("%sActions:\n", sIndent(indent+2))
 	a := s.Actions
@@ -516,6 +609,27 @@ func printStatement(indent int, s *api.Statement) {
 	if a.Nexthop != nil {
 		fmt.Println(ind, "Nexthop: ", prettyString(a.Nexthop))
 	}
+	if a.AsPathReplace != nil {
+		fmt.Println(ind, "ASPathReplace: ", prettyString(a.AsPathReplace))
+	}
+	if a.AsPathRemovePrivate != nil {
+		fmt.Println(ind, "RemovePrivateAS: ", prettyString(a.AsPathRemovePrivate))
+	}
+	if a.AsPathExclude != nil {
+		fmt.Println(ind, "ASPathExclude: ", prettyString(a.AsPathExclude))
+	}
+	if a.Weight != nil {
+		fmt.Println(ind, "Weight: ", prettyString(a.Weight))
+	}
+	if a.Tag != nil {
+		fmt.Println(ind, "Tag: ", prettyString(a.Tag))
+	}
+	if a.Aigp != nil {
+		fmt.Println(ind, "AIGP: ", prettyString(a.Aigp))
+	}
+	if a.LinkBandwidth != nil {
+		fmt.Println(ind, "LinkBandwidth: ", prettyString(a.LinkBandwidth))
+	}
 
 	if a.RouteAction != api.RouteAction_ROUTE_ACTION_UNSPECIFIED {
 		action := "accept"
@@ -524,9 +638,33 @@ func printStatement(indent int, s *api.Statement) {
 		}
 		fmt.Println(ind, action)
 	}
+	switch a.FlowControl {
+	case api.FlowControl_FLOW_CONTROL_NEXT_STATEMENT:
+		fmt.Println(ind, "next-statement")
+	case api.FlowControl_FLOW_CONTROL_NEXT_POLICY:
+		fmt.Println(ind, "next-policy")
+	case api.FlowControl_FLOW_CONTROL_GOTO:
+		fmt.Println(ind, "goto", a.GotoStatement)
+	}
+}
+
+func printPolicyStatistics(indent int, s *api.PolicyStatistics) {
+	if s == nil {
+		return
+	}
+	fmt.Printf("%sStatistics: evaluated %d, matched %d, accepted %d, rejected %d\n", strings.Repeat(" ", indent), s.Evaluations, s.Matches, s.Accepts, s.Rejects)
+}
+
+func resetPolicyStatistics(name, assignment string) error {
+	_, err := client.ResetPolicyStatistics(ctx, &api.ResetPolicyStatisticsRequest{
+		Name:       name,
+		Assignment: assignment,
+	})
+	return err
 }
 
 func printPolicy(indent int, pd *api.Policy) {
+	printPolicyStatistics(indent, pd.Statistics)
 	for _, s := range pd.Statements {
 		printStatement(indent, s)
 	}
@@ -639,12 +777,14 @@ func modStatement(op string, args []string) error {
 	switch op {
 	case cmdAdd:
 		_, err = client.AddStatement(ctx, &api.AddStatementRequest{
-			Statement: stmt,
+			Statement:     stmt,
+			TransactionId: policyOpts.Transaction,
 		})
 	case cmdDel:
 		_, err = client.DeleteStatement(ctx, &api.DeleteStatementRequest{
-			Statement: stmt,
-			All:       true,
+			Statement:     stmt,
+			All:           true,
+			TransactionId: policyOpts.Transaction,
 		})
 	default:
 		return fmt.Errorf("invalid operation: %s", op)
@@ -652,6 +792,22 @@ func modStatement(op string, args []string) error {
 	return err
 }
 
+func parseConditionRange(arg string, bitSize int) (uint64, uint64, bool) {
+	low, high, found := strings.Cut(arg, "..")
+	if !found {
+		return 0, 0, false
+	}
+	minValue, err := strconv.ParseUint(low, 10, bitSize)
+	if err != nil {
+		return 0, 0, false
+	}
+	maxValue, err := strconv.ParseUint(high, 10, bitSize)
+	if err != nil {
+		return 0, 0, false
+	}
+	return minValue, maxValue, true
+}
+
 func modCondition(name, op string, args []string) error {
 	stmt := &api.Statement{
 		Name:       name,
@@ -659,7 +815,7 @@ func modCondition(name, op string, args []string) error {
 	}
 	usage := fmt.Sprintf("usage: gobgp policy statement %s %s condition", name, op)
 	if len(args) < 1 {
-		return fmt.Errorf("%s { prefix | neighbor | as-path | community | ext-community | large-community | as-path-length | rpki | route-type | next-hop-in-list | afi-safi-in | local-pref-eq | med-eq }", usage)
+		return fmt.Errorf("%s { prefix | neighbor | as-path | community | ext-community | large-community | as-path-length | rpki | bgpsec | route-type | next-hop-in-list | afi-safi-in | local-pref-eq | med-eq | call-policy | origin-as | neighbor-as | prefix-length | source-peer-type | path-age | aigp | attribute-present }", usage)
 	}
 	typ := args[0]
 	args = args[1:]
@@ -838,6 +994,21 @@ func modCondition(name, op string, args []string) error {
 		default:
 			return fmt.Errorf("%s rpki { valid | invalid | not-found }", usage)
 		}
+	case "bgpsec":
+		err := fmt.Errorf("%s bgpsec { valid | invalid | not-signed }", usage)
+		if len(args) < 1 {
+			return err
+		}
+		switch strings.ToLower(args[0]) {
+		case "valid":
+			stmt.Conditions.BgpsecResult = api.BgpsecValidationState_BGPSEC_VALIDATION_STATE_VALID
+		case "invalid":
+			stmt.Conditions.BgpsecResult = api.BgpsecValidationState_BGPSEC_VALIDATION_STATE_INVALID
+		case "not-signed":
+			stmt.Conditions.BgpsecResult = api.BgpsecValidationState_BGPSEC_VALIDATION_STATE_NOT_SIGNED
+		default:
+			return err
+		}
 	case "route-type":
 		err := fmt.Errorf("%s route-type { internal | external | local }", usage)
 		if len(args) < 1 {
@@ -862,19 +1033,122 @@ func modCondition(name, op string, args []string) error {
 			afiSafisInList = append(afiSafisInList, apiutil.ToApiFamily(family.Afi(), family.Safi()))
 		}
 		stmt.Conditions.AfiSafiIn = afiSafisInList
+	case "call-policy":
+		if len(args) != 1 {
+			return fmt.Errorf("%s call-policy <policy name>", usage)
+		}
+		stmt.Conditions.CallPolicy = args[0]
+	case "origin-as", "neighbor-as":
+		if len(args) < 1 {
+			return fmt.Errorf("%s %s <asn>...", usage, typ)
+		}
+		asns := make([]uint32, 0, len(args))
+		for _, arg := range args {
+			asn, err := strconv.ParseUint(arg, 10, 32)
+			if err != nil {
+				return err
+			}
+			asns = append(asns, uint32(asn))
+		}
+		if typ == "origin-as" {
+			stmt.Conditions.OriginAsns = asns
+		} else {
+			stmt.Conditions.NeighborAsns = asns
+		}
+	case "prefix-length":
+		err := fmt.Errorf("%s prefix-length <min>..<max>", usage)
+		if len(args) != 1 {
+			return err
+		}
+		minLen, maxLen, ok := parseConditionRange(args[0], 8)
+		if !ok {
+			return err
+		}
+		stmt.Conditions.PrefixLengthRange = &api.PrefixLengthRange{
+			Min: uint32(minLen),
+			Max: uint32(maxLen),
+		}
+	case "source-peer-type":
+		err := fmt.Errorf("%s source-peer-type { ebgp | ibgp | confed | local | netlink }...", usage)
+		if len(args) < 1 {
+			return err
+		}
+		for _, arg := range args {
+			var t api.SourcePeerType
+			switch strings.ToLower(arg) {
+			case "ebgp":
+				t = api.SourcePeerType_SOURCE_PEER_TYPE_EBGP
+			case "ibgp":
+				t = api.SourcePeerType_SOURCE_PEER_TYPE_IBGP
+			case "confed":
+				t = api.SourcePeerType_SOURCE_PEER_TYPE_CONFED
+			case "local":
+				t = api.SourcePeerType_SOURCE_PEER_TYPE_LOCAL
+			case "netlink":
+				t = api.SourcePeerType_SOURCE_PEER_TYPE_NETLINK
+			default:
+				return err
+			}
+			stmt.Conditions.SourcePeerTypes = append(stmt.Conditions.SourcePeerTypes, t)
+		}
+	case "path-age":
+		err := fmt.Errorf("%s path-age { eq | ge | le } <seconds>", usage)
+		if len(args) != 2 {
+			return err
+		}
+		stmt.Conditions.PathAge = &api.PathAge{}
+		switch strings.ToLower(args[0]) {
+		case "eq":
+			stmt.Conditions.PathAge.Type = api.Comparison_COMPARISON_EQ
+		case "ge":
+			stmt.Conditions.PathAge.Type = api.Comparison_COMPARISON_GE
+		case "le":
+			stmt.Conditions.PathAge.Type = api.Comparison_COMPARISON_LE
+		default:
+			return err
+		}
+		seconds, err := strconv.ParseUint(args[1], 10, 32)
+		if err != nil {
+			return err
+		}
+		stmt.Conditions.PathAge.Seconds = uint32(seconds)
+	case "aigp":
+		err := fmt.Errorf("%s aigp <min>..<max>", usage)
+		if len(args) != 1 {
+			return err
+		}
+		minMetric, maxMetric, ok := parseConditionRange(args[0], 64)
+		if !ok {
+			return err
+		}
+		stmt.Conditions.AigpRange = &api.AigpRange{
+			Min: minMetric,
+			Max: maxMetric,
+		}
+	case "attribute-present":
+		if len(args) != 1 {
+			return fmt.Errorf("%s attribute-present <type code>", usage)
+		}
+		code, err := strconv.ParseUint(args[0], 10, 8)
+		if err != nil {
+			return err
+		}
+		stmt.Conditions.AttributeTypePresent = uint32(code)
 	default:
-		return fmt.Errorf("%s { prefix | neighbor | as-path | community | ext-community | large-community | as-path-length | rpki | route-type | next-hop-in-list | afi-safi-in }", usage)
+		return fmt.Errorf("%s { prefix | neighbor | as-path | community | ext-community | large-community | as-path-length | rpki | bgpsec | route-type | next-hop-in-list | afi-safi-in | call-policy | origin-as | neighbor-as | prefix-length | source-peer-type | path-age | aigp | attribute-present }", usage)
 	}
 
 	var err error
 	switch op {
 	case cmdAdd:
 		_, err = client.AddStatement(ctx, &api.AddStatementRequest{
-			Statement: stmt,
+			Statement:     stmt,
+			TransactionId: policyOpts.Transaction,
 		})
 	case cmdDel:
 		_, err = client.DeleteStatement(ctx, &api.DeleteStatementRequest{
-			Statement: stmt,
+			Statement:     stmt,
+			TransactionId: policyOpts.Transaction,
 		})
 	default:
 		return fmt.Errorf("invalid operation: %s", op)
@@ -889,7 +1163,7 @@ func modAction(name, op string, args []string) error {
 	}
 	usage := fmt.Sprintf("usage: gobgp policy statement %s %s action", name, op)
 	if len(args) < 1 {
-		return fmt.Errorf("%s { reject | accept | community | ext-community | large-community | med | local-pref | as-prepend | next-hop }", usage)
+		return fmt.Errorf("%s { reject | accept | community | ext-community | large-community | med | local-pref | as-prepend | as-path-replace | remove-private-as | as-path-exclude | weight | tag | aigp | link-bandwidth | next-hop | next-statement | next-policy | goto }", usage)
 	}
 	typ := args[0]
 	args = args[1:]
@@ -990,22 +1264,130 @@ func modAction(name, op string, args []string) error {
 			return err
 		}
 		stmt.Actions.AsPrepend.Repeat = uint32(repeat)
+	case "as-path-replace":
+		if len(args) < 1 || len(args) > 2 {
+			return fmt.Errorf("%s as-path-replace { any | <asn>[,<asn>...] } [<replace-asn>]", usage)
+		}
+		stmt.Actions.AsPathReplace = &api.AsPathReplaceAction{}
+		if args[0] == "any" {
+			stmt.Actions.AsPathReplace.Any = true
+		} else {
+			for _, a := range strings.Split(args[0], ",") {
+				asn, err := strconv.ParseUint(a, 10, 32)
+				if err != nil {
+					return err
+				}
+				stmt.Actions.AsPathReplace.Asns = append(stmt.Actions.AsPathReplace.Asns, uint32(asn))
+			}
+		}
+		if len(args) == 2 {
+			asn, err := strconv.ParseUint(args[1], 10, 32)
+			if err != nil {
+				return err
+			}
+			stmt.Actions.AsPathReplace.ReplaceAsn = uint32(asn)
+		}
+	case "remove-private-as":
+		if len(args) != 1 {
+			return fmt.Errorf("%s remove-private-as { all | replace }", usage)
+		}
+		stmt.Actions.AsPathRemovePrivate = &api.AsPathRemovePrivateAction{}
+		switch strings.ToLower(args[0]) {
+		case "all":
+			stmt.Actions.AsPathRemovePrivate.RemovePrivate = api.RemovePrivate_REMOVE_PRIVATE_ALL
+		case "replace":
+			stmt.Actions.AsPathRemovePrivate.RemovePrivate = api.RemovePrivate_REMOVE_PRIVATE_REPLACE
+		default:
+			return fmt.Errorf("%s remove-private-as { all | replace }", usage)
+		}
+	case "as-path-exclude":
+		if len(args) < 1 {
+			return fmt.Errorf("%s as-path-exclude <asn>...", usage)
+		}
+		stmt.Actions.AsPathExclude = &api.AsPathExcludeAction{}
+		for _, a := range args {
+			asn, err := strconv.ParseUint(a, 10, 32)
+			if err != nil {
+				return err
+			}
+			stmt.Actions.AsPathExclude.Asns = append(stmt.Actions.AsPathExclude.Asns, uint32(asn))
+		}
+	case "weight":
+		if len(args) != 1 {
+			return fmt.Errorf("%s weight <value>", usage)
+		}
+		value, err := strconv.ParseUint(args[0], 10, 32)
+		if err != nil {
+			return err
+		}
+		stmt.Actions.Weight = &api.WeightAction{Value: uint32(value)}
+	case "tag":
+		if len(args) != 1 {
+			return fmt.Errorf("%s tag <value>", usage)
+		}
+		value, err := strconv.ParseUint(args[0], 0, 32)
+		if err != nil {
+			return err
+		}
+		stmt.Actions.Tag = &api.TagAction{Value: uint32(value)}
+	case "aigp":
+		stmt.Actions.Aigp = &api.AigpAction{}
+		if len(args) < 2 {
+			return fmt.Errorf("%s aigp { add | sub | set } <value>", usage)
+		}
+		aigp, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return err
+		}
+		stmt.Actions.Aigp.Value = aigp
+		switch strings.ToLower(args[0]) {
+		case "add":
+			stmt.Actions.Aigp.Type = api.AigpAction_TYPE_MOD
+		case "sub":
+			stmt.Actions.Aigp.Type = api.AigpAction_TYPE_MOD
+			stmt.Actions.Aigp.Value = -1 * stmt.Actions.Aigp.Value
+		case "set":
+			stmt.Actions.Aigp.Type = api.AigpAction_TYPE_REPLACE
+		default:
+			return fmt.Errorf("%s aigp { add | sub | set } <value>", usage)
+		}
+	case "link-bandwidth":
+		if len(args) != 1 {
+			return fmt.Errorf("%s link-bandwidth <Mbps>", usage)
+		}
+		value, err := strconv.ParseUint(args[0], 10, 32)
+		if err != nil {
+			return err
+		}
+		stmt.Actions.LinkBandwidth = &api.LinkBandwidthAction{Bandwidth: uint32(value)}
 	case "next-hop":
 		stmt.Actions.Nexthop = &api.NexthopAction{}
 		if len(args) != 1 {
 			return fmt.Errorf("%s next-hop { <value> | self | unchanged | peer-address }", usage)
 		}
 		stmt.Actions.Nexthop.Address = args[0]
+	case "next-statement":
+		stmt.Actions.FlowControl = api.FlowControl_FLOW_CONTROL_NEXT_STATEMENT
+	case "next-policy":
+		stmt.Actions.FlowControl = api.FlowControl_FLOW_CONTROL_NEXT_POLICY
+	case "goto":
+		if len(args) != 1 {
+			return fmt.Errorf("%s goto <statement name>", usage)
+		}
+		stmt.Actions.FlowControl = api.FlowControl_FLOW_CONTROL_GOTO
+		stmt.Actions.GotoStatement = args[0]
 	}
 	var err error
 	switch op {
 	case cmdAdd:
 		_, err = client.AddStatement(ctx, &api.AddStatementRequest{
-			Statement: stmt,
+			Statement:     stmt,
+			TransactionId: policyOpts.Transaction,
 		})
 	case cmdDel:
 		_, err = client.DeleteStatement(ctx, &api.DeleteStatementRequest{
-			Statement: stmt,
+			Statement:     stmt,
+			TransactionId: policyOpts.Transaction,
 		})
 	default:
 		return fmt.Errorf("invalid operation: %s", op)
@@ -1034,6 +1416,7 @@ func modPolicy(modtype string, args []string) error {
 		_, err = client.AddPolicy(ctx, &api.AddPolicyRequest{
 			Policy:                  policy,
 			ReferExistingStatements: true,
+			TransactionId:           policyOpts.Transaction,
 		})
 	case cmdDel:
 		all := false
@@ -1044,11 +1427,396 @@ func modPolicy(modtype string, args []string) error {
 			Policy:             policy,
 			All:                all,
 			PreserveStatements: true,
+			TransactionId:      policyOpts.Transaction,
 		})
 	}
 	return err
 }
 
+func showPolicyTransactions() error {
+	stream, err := client.ListPolicyTransaction(ctx, &api.ListPolicyTransactionRequest{})
+	if err != nil {
+		return err
+	}
+	txs := make([]*api.PolicyTransaction, 0)
+	for {
+		rsp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		txs = append(txs, rsp.Transaction)
+	}
+	if globalOpts.Json {
+		j, _ := json.Marshal(txs)
+		fmt.Println(string(j))
+		return nil
+	}
+	format := "%-36s %-10s %7s\n"
+	fmt.Printf(format, "ID", "Age", "Changes")
+	for _, tx := range txs {
+		fmt.Printf(format, tx.Id, formatTimedelta(tx.Created.AsTime()), fmt.Sprint(tx.Changes))
+	}
+	return nil
+}
+
+func showPolicyTransactionDiff(id string) error {
+	rsp, err := client.DiffPolicyTransaction(ctx, &api.DiffPolicyTransactionRequest{Id: id})
+	if err != nil {
+		return err
+	}
+	if globalOpts.Json {
+		j, _ := json.Marshal(rsp.Diffs)
+		fmt.Println(string(j))
+		return nil
+	}
+	for _, d := range rsp.Diffs {
+		fmt.Printf("%s %s:\n", d.Kind, d.Name)
+		if d.Before != "" {
+			fmt.Printf("  - %s\n", d.Before)
+		}
+		if d.After != "" {
+			fmt.Printf("  + %s\n", d.After)
+		}
+	}
+	return nil
+}
+
+func modPolicyTransaction(op string, args []string) error {
+	usage := fmt.Sprintf("usage: gobgp policy %s { %s [<id>] | %s <id> | %s <id> [<comment>] | %s <id> }", cmdTransaction, cmdStart, cmdDiff, cmdCommit, cmdAbort)
+	if op == cmdStart {
+		if len(args) > 1 {
+			return fmt.Errorf("%s", usage)
+		}
+		req := &api.StartPolicyTransactionRequest{}
+		if len(args) == 1 {
+			req.Id = args[0]
+		}
+		rsp, err := client.StartPolicyTransaction(ctx, req)
+		if err != nil {
+			return err
+		}
+		fmt.Println(rsp.Id)
+		return nil
+	}
+	if len(args) < 1 || (op != cmdCommit && len(args) > 1) {
+		return fmt.Errorf("%s", usage)
+	}
+	switch op {
+	case cmdDiff:
+		return showPolicyTransactionDiff(args[0])
+	case cmdCommit:
+		rsp, err := client.CommitPolicyTransaction(ctx, &api.CommitPolicyTransactionRequest{
+			Id:      args[0],
+			Comment: strings.Join(args[1:], " "),
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("version %d\n", rsp.Version)
+		return nil
+	case cmdAbort:
+		_, err := client.AbortPolicyTransaction(ctx, &api.AbortPolicyTransactionRequest{Id: args[0]})
+		return err
+	}
+	return fmt.Errorf("%s", usage)
+}
+
+func showPolicyVersions() error {
+	stream, err := client.ListPolicyVersion(ctx, &api.ListPolicyVersionRequest{})
+	if err != nil {
+		return err
+	}
+	versions := make([]*api.PolicyVersion, 0)
+	for {
+		rsp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		versions = append(versions, rsp.Version)
+	}
+	if globalOpts.Json {
+		j, _ := json.Marshal(versions)
+		fmt.Println(string(j))
+		return nil
+	}
+	format := "%1s %7s %-10s %s\n"
+	fmt.Printf(format, "", "Version", "Age", "Comment")
+	for _, v := range versions {
+		running := ""
+		if v.Running {
+			running = "*"
+		}
+		fmt.Printf(format, running, fmt.Sprint(v.Version), formatTimedelta(v.Committed.AsTime()), v.Comment)
+	}
+	return nil
+}
+
+func rollbackPolicy(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: gobgp policy %s <version>", cmdRollback)
+	}
+	version, err := strconv.ParseUint(args[0], 10, 32)
+	if err != nil {
+		return err
+	}
+	rsp, err := client.RollbackPolicy(ctx, &api.RollbackPolicyRequest{Version: uint32(version)})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("version %d\n", rsp.Version)
+	return nil
+}
+
+func testPolicyDecisionString(d api.TestPolicyResponse_Decision) string {
+	switch d {
+	case api.TestPolicyResponse_DECISION_ACCEPT:
+		return "accept"
+	case api.TestPolicyResponse_DECISION_REJECT:
+		return "reject"
+	}
+	return "continue"
+}
+
+func testPolicy(args []string) error {
+	usage := fmt.Sprintf("usage: gobgp policy test [-a <FAMILY>] [%s <ADDRESS>] [{ %s | %s }] [%s <NAME>[,<NAME>...]] { %s [<SOURCE>] <PREFIX> | %s <PREFIX> [<ATTRIBUTES>...] }", cmdNeighbor, cmdImport, cmdExport, cmdPolicy, cmdRib, cmdPath)
+	f, err := checkAddressFamily(ipv4UC)
+	if err != nil {
+		return err
+	}
+	req := &api.TestPolicyRequest{TransactionId: policyOpts.Transaction}
+	for len(args) > 0 {
+		switch args[0] {
+		case cmdNeighbor:
+			if len(args) < 2 {
+				return fmt.Errorf("%s", usage)
+			}
+			req.Neighbor = args[1]
+			args = args[2:]
+			continue
+		case cmdImport:
+			req.Direction = api.PolicyDirection_POLICY_DIRECTION_IMPORT
+		case cmdExport:
+			req.Direction = api.PolicyDirection_POLICY_DIRECTION_EXPORT
+		case cmdPolicy:
+			if len(args) < 2 {
+				return fmt.Errorf("%s", usage)
+			}
+			req.Policies = strings.Split(args[1], ",")
+			args = args[2:]
+			continue
+		case cmdRib:
+			req.Lookup = &api.TestPolicyRequest_Lookup{Family: f}
+			switch len(args) {
+			case 2:
+				req.Lookup.Prefix = args[1]
+			case 3:
+				req.Lookup.Neighbor = args[1]
+				req.Lookup.Prefix = args[2]
+			default:
+				return fmt.Errorf("%s", usage)
+			}
+		case cmdPath:
+			if req.Path, err = parsePath(apiutil.ToFamily(f), args[1:]); err != nil {
+				return fmt.Errorf("%s\n%s", err, usage)
+			}
+		default:
+			return fmt.Errorf("%s", usage)
+		}
+		if args[0] == cmdRib || args[0] == cmdPath {
+			break
+		}
+		args = args[1:]
+	}
+	if req.Lookup == nil && req.Path == nil {
+		return fmt.Errorf("%s", usage)
+	}
+
+	rsp, err := client.TestPolicy(ctx, req)
+	if err != nil {
+		return err
+	}
+	if globalOpts.Json {
+		j, _ := json.Marshal(rsp)
+		fmt.Println(string(j))
+		return nil
+	}
+	for _, st := range rsp.Statements {
+		fmt.Printf("%s %s: ", st.Policy, st.Name)
+		if !st.Matched {
+			fmt.Println("not matched")
+		} else {
+			fmt.Println(testPolicyDecisionString(st.Decision))
+		}
+		for _, c := range st.Conditions {
+			m := "not matched"
+			if c.Matched {
+				m = "matched"
+			}
+			fmt.Printf("    %s %s: %s\n", c.Type, c.Set, m)
+		}
+		for _, a := range st.Actions {
+			fmt.Printf("    %s\n", a)
+		}
+	}
+	decision := testPolicyDecisionString(rsp.Decision)
+	switch {
+	case rsp.PrePolicyFiltered:
+		decision += " (filtered before policies)"
+	case rsp.DefaultPolicy:
+		decision += " (default policy)"
+	}
+	fmt.Printf("Decision: %s\n", decision)
+	if len(rsp.Diff) > 0 {
+		fmt.Println("Attributes:")
+		for _, d := range rsp.Diff {
+			fmt.Printf("    %s: %q -> %q\n", d.Type, d.Before, d.After)
+		}
+	}
+	return nil
+}
+
+func definedSetFileFormatString(f api.AddDefinedSetFileRequest_Format) string {
+	if f == api.AddDefinedSetFileRequest_FORMAT_BGPQ4_JSON {
+		return "bgpq4-json"
+	}
+	return "text"
+}
+
+func definedSetFileTypeString(t api.DefinedType) string {
+	if t == api.DefinedType_DEFINED_TYPE_AS_PATH {
+		return cmdAspath
+	}
+	return cmdPrefix
+}
+
+func showDefinedSetFile(args []string) error {
+	path := ""
+	if len(args) > 0 {
+		path = args[0]
+	}
+	stream, err := client.ListDefinedSetFile(ctx, &api.ListDefinedSetFileRequest{Path: path})
+	if err != nil {
+		return err
+	}
+	files := make([]*api.DefinedSetFile, 0)
+	for {
+		r, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		files = append(files, r.File)
+	}
+	if globalOpts.Json {
+		j, _ := json.Marshal(files)
+		fmt.Println(string(j))
+		return nil
+	}
+	if path == "" {
+		format := "%-40s %-20s %-8s %-10s %-10s %-8s %s\n"
+		fmt.Printf(format, "File", "Name", "Type", "Format", "Loaded", "#Entries", "Error")
+		for _, f := range files {
+			loaded := "never"
+			if f.Loaded != nil {
+				loaded = formatTimedelta(f.Loaded.AsTime())
+			}
+			fmt.Printf(format, f.Path, f.Name, definedSetFileTypeString(f.DefinedType), definedSetFileFormatString(f.Format), loaded, fmt.Sprint(f.Entries), f.Error)
+		}
+		return nil
+	}
+	for _, f := range files {
+		fmt.Printf("File: %s, Format: %s\n", f.Path, definedSetFileFormatString(f.Format))
+		fmt.Printf("  Defined Set: %s %s\n", definedSetFileTypeString(f.DefinedType), f.Name)
+		if f.Loaded != nil {
+			fmt.Println("  Loaded:", f.Loaded.AsTime().Local())
+		}
+		fmt.Println("  Loads:", f.Loads)
+		fmt.Println("  Entries:", f.Entries)
+		fmt.Printf("  Last Change: %d added, %d removed\n", f.Added, f.Removed)
+		if f.Error != "" {
+			fmt.Println("  Error:", f.Error)
+		}
+	}
+	return nil
+}
+
+func newDefinedSetFileCmd() *cobra.Command {
+	fileCmd := &cobra.Command{
+		Use: cmdFile,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := showDefinedSetFile(args); err != nil {
+				exitWithError(err)
+			}
+		},
+	}
+	addCmd := &cobra.Command{
+		Use: cmdAdd + " <path> <defined set name>",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				exitWithError(fmt.Errorf("usage: gobgp policy file add <path> <defined set name> [--type prefix|as-path] [--format text|bgpq4-json]"))
+			}
+			var typ api.DefinedType
+			switch policyOpts.FileType {
+			case "", cmdPrefix:
+				typ = api.DefinedType_DEFINED_TYPE_PREFIX
+			case cmdAspath:
+				typ = api.DefinedType_DEFINED_TYPE_AS_PATH
+			default:
+				exitWithError(fmt.Errorf("invalid type: %s", policyOpts.FileType))
+			}
+			var format api.AddDefinedSetFileRequest_Format
+			switch policyOpts.FileFormat {
+			case "", "text":
+				format = api.AddDefinedSetFileRequest_FORMAT_TEXT
+			case "bgpq4-json":
+				format = api.AddDefinedSetFileRequest_FORMAT_BGPQ4_JSON
+			default:
+				exitWithError(fmt.Errorf("invalid format: %s", policyOpts.FileFormat))
+			}
+			if _, err := client.AddDefinedSetFile(ctx, &api.AddDefinedSetFileRequest{
+				Path:        args[0],
+				Name:        args[1],
+				DefinedType: typ,
+				Format:      format,
+			}); err != nil {
+				exitWithError(err)
+			}
+		},
+	}
+	addCmd.Flags().StringVarP(&policyOpts.FileType, "type", "", cmdPrefix, "The type of the defined set {prefix|as-path}")
+	addCmd.Flags().StringVarP(&policyOpts.FileFormat, "format", "", "text", "The format of the file {text|bgpq4-json}")
+	delCmd := &cobra.Command{
+		Use: cmdDel + " <path>",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				exitWithError(fmt.Errorf("usage: gobgp policy file del <path>"))
+			}
+			if _, err := client.DeleteDefinedSetFile(ctx, &api.DeleteDefinedSetFileRequest{Path: args[0]}); err != nil {
+				exitWithError(err)
+			}
+		},
+	}
+	reloadCmd := &cobra.Command{
+		Use: cmdReload + " [<path>]",
+		Run: func(cmd *cobra.Command, args []string) {
+			path := ""
+			if len(args) > 0 {
+				path = args[0]
+			}
+			if _, err := client.ReloadDefinedSetFile(ctx, &api.ReloadDefinedSetFileRequest{Path: path}); err != nil {
+				exitWithError(err)
+			}
+		},
+	}
+	fileCmd.AddCommand(addCmd, delCmd, reloadCmd)
+	return fileCmd
+}
+
 func newPolicyCmd() *cobra.Command {
 	policyCmd := &cobra.Command{
 		Use: cmdPolicy,
@@ -1059,6 +1827,7 @@ func newPolicyCmd() *cobra.Command {
 			}
 		},
 	}
+	policyCmd.PersistentFlags().StringVarP(&policyOpts.Transaction, "transaction", "", "", "stage the change in the policy transaction")
 
 	for _, v := range []string{cmdPrefix, cmdNeighbor, cmdAspath, cmdCommunity, cmdExtcommunity, cmdLargecommunity} {
 		cmd := &cobra.Command{
@@ -1153,5 +1922,75 @@ func newPolicyCmd() *cobra.Command {
 		policyCmd.AddCommand(cmd)
 	}
 
+	txCmd := &cobra.Command{
+		Use: cmdTransaction,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := showPolicyTransactions(); err != nil {
+				exitWithError(err)
+			}
+		},
+	}
+	for _, v := range []string{cmdStart, cmdDiff, cmdCommit, cmdAbort} {
+		cmd := &cobra.Command{
+			Use: v,
+			Run: func(c *cobra.Command, args []string) {
+				if err := modPolicyTransaction(c.Use, args); err != nil {
+					exitWithError(err)
+				}
+			},
+		}
+		txCmd.AddCommand(cmd)
+	}
+	policyCmd.AddCommand(txCmd)
+
+	versionCmd := &cobra.Command{
+		Use: cmdVersion,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := showPolicyVersions(); err != nil {
+				exitWithError(err)
+			}
+		},
+	}
+	policyCmd.AddCommand(versionCmd)
+
+	rollbackCmd := &cobra.Command{
+		Use: cmdRollback,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := rollbackPolicy(args); err != nil {
+				exitWithError(err)
+			}
+		},
+	}
+	policyCmd.AddCommand(rollbackCmd)
+
+	testCmd := &cobra.Command{
+		Use: cmdTest,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := testPolicy(args); err != nil {
+				exitWithError(err)
+			}
+		},
+	}
+	testCmd.Flags().StringVarP(&subOpts.AddressFamily, "address-family", "a", "", "address family")
+	policyCmd.AddCommand(testCmd)
+
+	resetCmd := &cobra.Command{
+		Use: cmdReset,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) > 1 {
+				exitWithError(fmt.Errorf("usage: gobgp policy %s [<policy name>]", cmdReset))
+			}
+			name := ""
+			if len(args) == 1 {
+				name = args[0]
+			}
+			if err := resetPolicyStatistics(name, ""); err != nil {
+				exitWithError(err)
+			}
+		},
+	}
+	policyCmd.AddCommand(resetCmd)
+	policyCmd.AddCommand(newDefinedSetFileCmd())
+
 	return policyCmd
 }
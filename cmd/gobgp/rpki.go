@@ -66,12 +66,19 @@ func showRPKIServer(args []string) error {
 			}
 			fmt.Printf("Session: %s, State: %s\n", r.Conf.Address, up)
 			fmt.Println("  Port:", r.Conf.RemotePort)
+			fmt.Println("  Transport:", rpkiTransportString(r.Conf.Transport))
+			fmt.Println("  Version:", r.State.Version)
 			fmt.Println("  Serial:", r.State.Serial)
+			fmt.Printf("  Refresh/Retry/Expire: %d/%d/%d\n", r.State.RefreshInterval, r.State.RetryInterval, r.State.ExpireInterval)
 			fmt.Printf("  Prefix: %d/%d\n", r.State.PrefixIpv4, r.State.PrefixIpv6)
 			fmt.Printf("  Record: %d/%d\n", r.State.RecordIpv4, r.State.RecordIpv6)
+			fmt.Println("  Router Keys:", r.State.RouterKeys)
+			fmt.Println("  ASPAs:", r.State.Aspas)
 			fmt.Println("  Message statistics:")
 			fmt.Printf("    Receivedv4:    %10d\n", r.State.ReceivedIpv4)
 			fmt.Printf("    Receivedv6:    %10d\n", r.State.ReceivedIpv6)
+			fmt.Printf("    RouterKey:     %10d\n", r.State.RouterKey)
+			fmt.Printf("    ASPA:          %10d\n", r.State.Aspa)
 			fmt.Printf("    SerialNotify:  %10d\n", r.State.SerialNotify)
 			fmt.Printf("    CacheReset:    %10d\n", r.State.CacheReset)
 			fmt.Printf("    CacheResponse: %10d\n", r.State.CacheResponse)
@@ -84,6 +91,51 @@ func showRPKIServer(args []string) error {
 	return nil
 }
 
+func rpkiTransportString(t api.AddRpkiRequest_Transport) string {
+	switch t {
+	case api.AddRpkiRequest_TRANSPORT_TLS:
+		return "tls"
+	case api.AddRpkiRequest_TRANSPORT_SSH:
+		return "ssh"
+	}
+	return "tcp"
+}
+
+func newAddRpkiRequest(address string) (*api.AddRpkiRequest, error) {
+	r := &api.AddRpkiRequest{
+		Address:           address,
+		Port:              rpkiOpts.Port,
+		TlsCaFile:         rpkiOpts.TlsCaFile,
+		TlsCertFile:       rpkiOpts.TlsCertFile,
+		TlsKeyFile:        rpkiOpts.TlsKeyFile,
+		TlsServerName:     rpkiOpts.TlsServerName,
+		SshUsername:       rpkiOpts.SshUsername,
+		SshPrivateKeyFile: rpkiOpts.SshPrivateKeyFile,
+		SshKnownHostsFile: rpkiOpts.SshKnownHostsFile,
+	}
+	switch rpkiOpts.Transport {
+	case "", "tcp":
+		r.Transport = api.AddRpkiRequest_TRANSPORT_TCP
+	case "tls":
+		r.Transport = api.AddRpkiRequest_TRANSPORT_TLS
+	case "ssh":
+		r.Transport = api.AddRpkiRequest_TRANSPORT_SSH
+	default:
+		return nil, fmt.Errorf("invalid transport: %s", rpkiOpts.Transport)
+	}
+	switch rpkiOpts.MaxVersion {
+	case 0:
+		r.MaxVersion = api.AddRpkiRequest_VERSION_0
+	case 1:
+		r.MaxVersion = api.AddRpkiRequest_VERSION_1
+	case 2:
+		r.MaxVersion = api.AddRpkiRequest_VERSION_2
+	default:
+		return nil, fmt.Errorf("invalid version: %d", rpkiOpts.MaxVersion)
+	}
+	return r, nil
+}
+
 func showRPKITable(args []string) error {
 	family, err := checkAddressFamily(ipv4UC)
 	if err != nil {
@@ -112,9 +164,9 @@ func showRPKITable(args []string) error {
 	} else {
 		format = "%-42s %-6s %-10s %s\n"
 	}
-	fmt.Printf(format, "Network", "Maxlen", "AS", "Server")
+	fmt.Printf(format, "Network", "Maxlen", "AS", "Source")
 	for _, r := range roas {
-		if len(args) > 0 && args[0] != r.Conf.Address {
+		if len(args) > 0 && args[0] != r.Conf.Address && args[0] != r.File {
 			continue
 		}
 		bits := net.IPv4len * 8
@@ -125,11 +177,125 @@ func showRPKITable(args []string) error {
 			IP:   net.ParseIP(r.GetPrefix()),
 			Mask: net.CIDRMask(int(r.GetPrefixlen()), bits),
 		}
-		fmt.Printf(format, n.String(), fmt.Sprint(r.Maxlen), fmt.Sprint(r.Asn), net.JoinHostPort(r.Conf.Address, strconv.Itoa(int(r.Conf.RemotePort))))
+		src := r.File
+		if src == "" {
+			src = net.JoinHostPort(r.Conf.Address, strconv.Itoa(int(r.Conf.RemotePort)))
+		}
+		fmt.Printf(format, n.String(), fmt.Sprint(r.Maxlen), fmt.Sprint(r.Asn), src)
+	}
+	return nil
+}
+
+func rpkiFileFormatString(f api.AddRpkiFileRequest_Format) string {
+	if f == api.AddRpkiFileRequest_FORMAT_SLURM {
+		return "slurm"
+	}
+	return "json-vrp"
+}
+
+func showRPKIFile(args []string) error {
+	path := ""
+	if len(args) > 0 {
+		path = args[0]
+	}
+	stream, err := client.ListRpkiFile(ctx, &api.ListRpkiFileRequest{Path: path})
+	if err != nil {
+		return err
+	}
+	files := make([]*api.RpkiFile, 0)
+	for {
+		r, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		files = append(files, r.File)
+	}
+	if path == "" {
+		format := "%-40s %-8s %-10s %-18s %-8s %s\n"
+		fmt.Printf(format, "File", "Format", "Loaded", "#IPv4/IPv6 records", "#Filters", "Error")
+		for _, f := range files {
+			loaded := "never"
+			if f.Loaded != nil {
+				loaded = formatTimedelta(f.Loaded.AsTime())
+			}
+			fmt.Printf(format, f.Path, rpkiFileFormatString(f.Format), loaded, fmt.Sprintf("%d/%d", f.RecordIpv4, f.RecordIpv6), fmt.Sprint(f.Filters), f.Error)
+		}
+		return nil
+	}
+	for _, f := range files {
+		fmt.Printf("File: %s, Format: %s\n", f.Path, rpkiFileFormatString(f.Format))
+		if f.Loaded != nil {
+			fmt.Println("  Loaded:", f.Loaded.AsTime().Local())
+		}
+		fmt.Println("  Loads:", f.Loads)
+		fmt.Printf("  Record: %d/%d\n", f.RecordIpv4, f.RecordIpv6)
+		fmt.Println("  Router Keys:", f.RouterKeys)
+		fmt.Println("  ASPAs:", f.Aspas)
+		fmt.Println("  Filters:", f.Filters)
+		if f.Error != "" {
+			fmt.Println("  Error:", f.Error)
+		}
 	}
 	return nil
 }
 
+func newRPKIFileCmd() *cobra.Command {
+	fileCmd := &cobra.Command{
+		Use: cmdRPKIFile,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return showRPKIFile(args)
+		},
+	}
+	addCmd := &cobra.Command{
+		Use: cmdAdd + " <path>",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				exitWithError(fmt.Errorf("usage: gobgp rpki file add <path> [--format json-vrp|slurm]"))
+			}
+			var format api.AddRpkiFileRequest_Format
+			switch rpkiOpts.FileFormat {
+			case "", "json-vrp":
+				format = api.AddRpkiFileRequest_FORMAT_JSON_VRP
+			case "slurm":
+				format = api.AddRpkiFileRequest_FORMAT_SLURM
+			default:
+				exitWithError(fmt.Errorf("invalid format: %s", rpkiOpts.FileFormat))
+			}
+			if _, err := client.AddRpkiFile(ctx, &api.AddRpkiFileRequest{Path: args[0], Format: format}); err != nil {
+				exitWithError(err)
+			}
+		},
+	}
+	addCmd.Flags().StringVarP(&rpkiOpts.FileFormat, "format", "", "json-vrp", "The format of the file {json-vrp|slurm}")
+	delCmd := &cobra.Command{
+		Use: cmdDel + " <path>",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				exitWithError(fmt.Errorf("usage: gobgp rpki file del <path>"))
+			}
+			if _, err := client.DeleteRpkiFile(ctx, &api.DeleteRpkiFileRequest{Path: args[0]}); err != nil {
+				exitWithError(err)
+			}
+		},
+	}
+	reloadCmd := &cobra.Command{
+		Use: cmdReload + " [<path>]",
+		Run: func(cmd *cobra.Command, args []string) {
+			path := ""
+			if len(args) > 0 {
+				path = args[0]
+			}
+			if _, err := client.ReloadRpkiFile(ctx, &api.ReloadRpkiFileRequest{Path: path}); err != nil {
+				exitWithError(err)
+			}
+		},
+	}
+	fileCmd.AddCommand(addCmd, delCmd, reloadCmd)
+	return fileCmd
+}
+
 func newRPKICmd() *cobra.Command {
 	rpkiCmd := &cobra.Command{
 		Use: cmdRPKI,
@@ -153,10 +319,10 @@ func newRPKICmd() *cobra.Command {
 			var err error
 			switch args[1] {
 			case "add":
-				_, err = client.AddRpki(ctx, &api.AddRpkiRequest{
-					Address: addr.String(),
-					Port:    323,
-				})
+				var r *api.AddRpkiRequest
+				if r, err = newAddRpkiRequest(addr.String()); err == nil {
+					_, err = client.AddRpki(ctx, r)
+				}
 			case "reset", "softreset":
 				_, err = client.ResetRpki(ctx, &api.ResetRpkiRequest{
 					Address: addr.String(),
@@ -175,7 +341,7 @@ func newRPKICmd() *cobra.Command {
 			case "delete":
 				_, err = client.DeleteRpki(ctx, &api.DeleteRpkiRequest{
 					Address: addr.String(),
-					Port:    323,
+					Port:    rpkiOpts.Port,
 				})
 			default:
 				exitWithError(fmt.Errorf("unknown operation: %s", args[1]))
@@ -185,6 +351,16 @@ func newRPKICmd() *cobra.Command {
 			}
 		},
 	}
+	serverCmd.PersistentFlags().Uint32VarP(&rpkiOpts.Port, "port", "", 0, "The port of the RPKI cache server, the default of the transport if 0")
+	serverCmd.PersistentFlags().StringVarP(&rpkiOpts.Transport, "transport", "", "tcp", "The transport to the RPKI cache server {tcp|tls|ssh}")
+	serverCmd.PersistentFlags().IntVarP(&rpkiOpts.MaxVersion, "max-version", "", 2, "The highest RTR protocol version to negotiate {0|1|2}")
+	serverCmd.PersistentFlags().StringVarP(&rpkiOpts.TlsCaFile, "tls-ca-file", "", "", "The CA certificates to verify the RPKI cache server with")
+	serverCmd.PersistentFlags().StringVarP(&rpkiOpts.TlsCertFile, "tls-cert-file", "", "", "The client certificate file")
+	serverCmd.PersistentFlags().StringVarP(&rpkiOpts.TlsKeyFile, "tls-key-file", "", "", "The client key file")
+	serverCmd.PersistentFlags().StringVarP(&rpkiOpts.TlsServerName, "tls-server-name", "", "", "The name to verify the certificate of the RPKI cache server with")
+	serverCmd.PersistentFlags().StringVarP(&rpkiOpts.SshUsername, "ssh-username", "", "", "The SSH user name")
+	serverCmd.PersistentFlags().StringVarP(&rpkiOpts.SshPrivateKeyFile, "ssh-private-key-file", "", "", "The SSH private key file")
+	serverCmd.PersistentFlags().StringVarP(&rpkiOpts.SshKnownHostsFile, "ssh-known-hosts-file", "", "", "The SSH known hosts file")
 	rpkiCmd.AddCommand(serverCmd)
 
 	tableCmd := &cobra.Command{
@@ -195,5 +371,6 @@ func newRPKICmd() *cobra.Command {
 	}
 	tableCmd.PersistentFlags().StringVarP(&subOpts.AddressFamily, "address-family", "a", "", "address family")
 	rpkiCmd.AddCommand(tableCmd)
+	rpkiCmd.AddCommand(newRPKIFileCmd())
 	return rpkiCmd
 }
@@ -163,13 +163,14 @@ func modVrf(typ string, args []string) error {
 	switch typ {
 	case cmdAdd:
 		a, err := extractReserved(args, map[string]int{
-			"rd": paramSingle,
-			"rt": paramList,
-			"id": paramSingle,
+			"rd":              paramSingle,
+			"rt":              paramList,
+			"id":              paramSingle,
+			"route-selection": paramList,
 		})
 		if err != nil || len(a[""]) != 1 || len(a["rd"]) != 1 || len(a["rt"]) < 2 {
 			//nolint:staticcheck // cli example
-			return fmt.Errorf("usage: gobgp vrf add <vrf name> [ id <id> ] rd <rd> rt { import | export | both } <rt>...")
+			return fmt.Errorf("usage: gobgp vrf add <vrf name> [ id <id> ] rd <rd> rt { import | export | both } <rt>... [ route-selection <option>... ]")
 		}
 		name := a[""][0]
 		var rd bgp.RouteDistinguisherInterface
@@ -199,7 +200,7 @@ func modVrf(typ string, args []string) error {
 				exportRt = append(exportRt, rt)
 			default:
 				//nolint:staticcheck // cli example
-				return fmt.Errorf("usage: gobgp vrf add <vrf name> [ id <id> ] rd <rd> rt { import | export | both } <rt>...")
+				return fmt.Errorf("usage: gobgp vrf add <vrf name> [ id <id> ] rd <rd> rt { import | export | both } <rt>... [ route-selection <option>... ]")
 			}
 		}
 		var id uint64
@@ -209,17 +210,44 @@ func modVrf(typ string, args []string) error {
 				return err
 			}
 		}
+		var opts *api.RouteSelectionOptionsConfig
+		if _, ok := a["route-selection"]; ok {
+			opts = &api.RouteSelectionOptionsConfig{}
+			for _, elem := range a["route-selection"] {
+				switch elem {
+				case "always-compare-med":
+					opts.AlwaysCompareMed = true
+				case "deterministic-med":
+					opts.DeterministicMed = true
+				case "med-missing-as-worst":
+					opts.MedMissingAsWorst = true
+				case "confed-compare":
+					opts.ConfedCompare = true
+				case "ignore-as-path-length":
+					opts.IgnoreAsPathLength = true
+				case "external-compare-router-id":
+					opts.ExternalCompareRouterId = true
+				case "multipath-as-path-match":
+					opts.MultipathAsPathMatch = true
+				case "multipath-relax":
+					opts.MultipathRelax = true
+				default:
+					return fmt.Errorf("invalid route selection option: %s", elem)
+				}
+			}
+		}
 		v, _ := apiutil.MarshalRD(rd)
 		irt, _ := apiutil.MarshalRTs(importRt)
 		ert, _ := apiutil.MarshalRTs(exportRt)
 
 		_, err = client.AddVrf(ctx, &api.AddVrfRequest{
 			Vrf: &api.Vrf{
-				Name:     name,
-				Rd:       v,
-				ImportRt: irt,
-				ExportRt: ert,
-				Id:       uint32(id),
+				Name:                  name,
+				Rd:                    v,
+				ImportRt:              irt,
+				ExportRt:              ert,
+				Id:                    uint32(id),
+				RouteSelectionOptions: opts,
 			},
 		})
 		return err
@@ -221,13 +221,16 @@ func main() {
 
 	logger.Info("gobgpd started", slog.String("version", version.Version()))
 	fsmTimingCollector := metrics.NewFSMTimingsCollector()
+	netlinkTimingCollector := metrics.NewNetlinkTimingsCollector()
 	bgpServer := server.NewBgpServer(
 		server.GrpcListenAddress(opts.GrpcHosts),
 		server.GrpcOption(grpcOpts),
 		server.LoggerOption(logger, lvl),
-		server.TimingHookOption(fsmTimingCollector))
+		server.TimingHookOption(fsmTimingCollector),
+		server.NetlinkTimingHookOption(netlinkTimingCollector))
 	prometheus.MustRegister(metrics.NewBgpCollector(bgpServer))
 	prometheus.MustRegister(fsmTimingCollector)
+	prometheus.MustRegister(netlinkTimingCollector)
 	go bgpServer.Serve()
 
 	if opts.UseSdNotify {
@@ -0,0 +1,116 @@
+// Copyright (C) 2025 Acnodal Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package table
+
+import (
+	"slices"
+)
+
+// ASPA is the Autonomous System Provider Authorization of a customer AS
+// received from an RPKI cache.
+type ASPA struct {
+	CustomerAS  uint32
+	ProviderASs []uint32
+	Src         string
+}
+
+func NewASPA(customer uint32, providers []uint32, src string) *ASPA {
+	return &ASPA{
+		CustomerAS:  customer,
+		ProviderASs: providers,
+		Src:         src,
+	}
+}
+
+// ASPATable holds the ASPAs by the customer AS. Like ROATable, it is owned
+// by the server goroutine and has no lock.
+type ASPATable struct {
+	aspas map[uint32][]*ASPA
+}
+
+func NewASPATable() *ASPATable {
+	return &ASPATable{
+		aspas: make(map[uint32][]*ASPA),
+	}
+}
+
+// Add replaces the ASPA of the customer AS from the same source.
+func (t *ASPATable) Add(a *ASPA) {
+	l := t.aspas[a.CustomerAS]
+	if i := slices.IndexFunc(l, func(b *ASPA) bool { return a.Src == b.Src }); i >= 0 {
+		l[i] = a
+		return
+	}
+	t.aspas[a.CustomerAS] = append(l, a)
+}
+
+// Delete removes the ASPA of the customer AS from the source and reports
+// whether it was found.
+func (t *ASPATable) Delete(customer uint32, src string) bool {
+	l := t.aspas[customer]
+	i := slices.IndexFunc(l, func(a *ASPA) bool { return a.Src == src })
+	if i < 0 {
+		return false
+	}
+	if l = slices.Delete(l, i, i+1); len(l) == 0 {
+		delete(t.aspas, customer)
+	} else {
+		t.aspas[customer] = l
+	}
+	return true
+}
+
+// DeleteAll removes all the ASPAs learned from the source.
+func (t *ASPATable) DeleteAll(src string) {
+	for customer, l := range t.aspas {
+		l = slices.DeleteFunc(l, func(a *ASPA) bool { return a.Src == src })
+		if len(l) == 0 {
+			delete(t.aspas, customer)
+		} else {
+			t.aspas[customer] = l
+		}
+	}
+}
+
+// Lookup returns the providers of the customer AS authorized by any
+// source, and whether the customer AS has an ASPA.
+func (t *ASPATable) Lookup(customer uint32) ([]uint32, bool) {
+	l, ok := t.aspas[customer]
+	if !ok {
+		return nil, false
+	}
+	var providers []uint32
+	for _, a := range l {
+		for _, p := range a.ProviderASs {
+			if !slices.Contains(providers, p) {
+				providers = append(providers, p)
+			}
+		}
+	}
+	slices.Sort(providers)
+	return providers, true
+}
+
+// Info returns the number of ASPAs per source.
+func (t *ASPATable) Info() map[string]uint32 {
+	m := make(map[string]uint32)
+	for _, l := range t.aspas {
+		for _, a := range l {
+			m[a.Src]++
+		}
+	}
+	return m
+}
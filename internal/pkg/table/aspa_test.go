@@ -0,0 +1,47 @@
+// Copyright (C) 2025 Acnodal Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package table
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestASPATable(t *testing.T) {
+	assert := assert.New(t)
+	tbl := NewASPATable()
+
+	tbl.Add(NewASPA(65001, []uint32{65010, 65011}, "a"))
+	tbl.Add(NewASPA(65001, []uint32{65012}, "b"))
+	tbl.Add(NewASPA(65002, []uint32{65010}, "a"))
+	providers, ok := tbl.Lookup(65001)
+	assert.True(ok)
+	assert.Equal([]uint32{65010, 65011, 65012}, providers)
+	assert.Equal(map[string]uint32{"a": 2, "b": 1}, tbl.Info())
+
+	// an announcement replaces the providers from the same source
+	tbl.Add(NewASPA(65001, []uint32{65013}, "a"))
+	providers, _ = tbl.Lookup(65001)
+	assert.Equal([]uint32{65012, 65013}, providers)
+
+	assert.True(tbl.Delete(65001, "b"))
+	assert.False(tbl.Delete(65001, "b"))
+	tbl.DeleteAll("a")
+	_, ok = tbl.Lookup(65001)
+	assert.False(ok)
+	assert.Empty(tbl.Info())
+}
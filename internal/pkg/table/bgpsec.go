@@ -0,0 +1,332 @@
+// Copyright (C) 2025 Acnodal Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package table
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"fmt"
+	"slices"
+
+	"github.com/osrg/gobgp/v4/pkg/config/oc"
+	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
+)
+
+// RouterKey is a BGPsec router key (RFC 8635) received from an RPKI cache
+// or configured locally.
+type RouterKey struct {
+	AS        uint32
+	SKI       [bgp.BGPSEC_SKI_LEN]byte
+	PublicKey *ecdsa.PublicKey
+	Src       string
+}
+
+func NewRouterKey(as uint32, ski [bgp.BGPSEC_SKI_LEN]byte, pub *ecdsa.PublicKey, src string) *RouterKey {
+	return &RouterKey{
+		AS:        as,
+		SKI:       ski,
+		PublicKey: pub,
+		Src:       src,
+	}
+}
+
+func (k *RouterKey) Equal(key *RouterKey) bool {
+	return k.AS == key.AS && k.SKI == key.SKI && k.Src == key.Src && k.PublicKey.Equal(key.PublicKey)
+}
+
+type routerKeyID struct {
+	as  uint32
+	ski [bgp.BGPSEC_SKI_LEN]byte
+}
+
+// RouterKeyTable holds the router keys used to verify BGPsec signatures.
+// Like ROATable, it is owned by the server goroutine and has no lock.
+type RouterKeyTable struct {
+	keys map[routerKeyID][]*RouterKey
+}
+
+func NewRouterKeyTable() *RouterKeyTable {
+	return &RouterKeyTable{
+		keys: make(map[routerKeyID][]*RouterKey),
+	}
+}
+
+func (t *RouterKeyTable) Add(key *RouterKey) {
+	id := routerKeyID{as: key.AS, ski: key.SKI}
+	if slices.ContainsFunc(t.keys[id], key.Equal) {
+		return
+	}
+	t.keys[id] = append(t.keys[id], key)
+}
+
+// Delete removes the key and reports whether it was found.
+func (t *RouterKeyTable) Delete(key *RouterKey) bool {
+	id := routerKeyID{as: key.AS, ski: key.SKI}
+	l := t.keys[id]
+	i := slices.IndexFunc(l, key.Equal)
+	if i < 0 {
+		return false
+	}
+	if l = slices.Delete(l, i, i+1); len(l) == 0 {
+		delete(t.keys, id)
+	} else {
+		t.keys[id] = l
+	}
+	return true
+}
+
+// DeleteAll removes all the keys learned from the source.
+func (t *RouterKeyTable) DeleteAll(src string) {
+	for id, l := range t.keys {
+		l = slices.DeleteFunc(l, func(k *RouterKey) bool { return k.Src == src })
+		if len(l) == 0 {
+			delete(t.keys, id)
+		} else {
+			t.keys[id] = l
+		}
+	}
+}
+
+func (t *RouterKeyTable) Lookup(as uint32, ski [bgp.BGPSEC_SKI_LEN]byte) []*RouterKey {
+	return t.keys[routerKeyID{as: as, ski: ski}]
+}
+
+// Info returns the number of keys per source.
+func (t *RouterKeyTable) Info() map[string]uint32 {
+	m := make(map[string]uint32)
+	for _, l := range t.keys {
+		for _, k := range l {
+			m[k.Src]++
+		}
+	}
+	return m
+}
+
+func (t *RouterKeyTable) List() []*RouterKey {
+	l := make([]*RouterKey, 0, len(t.keys))
+	for _, keys := range t.keys {
+		l = append(l, keys...)
+	}
+	return l
+}
+
+func (t *RouterKeyTable) verify(as uint32, ski [bgp.BGPSEC_SKI_LEN]byte, digest, sig []byte) bool {
+	for _, k := range t.Lookup(as, ski) {
+		if ecdsa.VerifyASN1(k.PublicKey, digest, sig) {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate verifies the BGPsec_PATH attribute of the path as described in
+// RFC 8205 section 5. Only the algorithm suite 1 (RFC 8208) is supported,
+// so a path carrying signatures of other suites alone is considered as
+// not signed. peerAS is the AS of the peer which sent the path, zero for
+// the locally originated paths.
+func (t *RouterKeyTable) Validate(path *Path, peerAS uint32) oc.BgpsecValidationResultType {
+	if path.IsWithdraw || path.IsEOR() {
+		return oc.BGPSEC_VALIDATION_RESULT_TYPE_NONE
+	}
+	attr := path.GetBGPsecPath()
+	if attr == nil {
+		return oc.BGPSEC_VALIDATION_RESULT_TYPE_NOT_SIGNED
+	}
+	block := attr.SignatureBlock(bgp.BGPSEC_ALGORITHM_SUITE_1)
+	if block == nil {
+		return oc.BGPSEC_VALIDATION_RESULT_TYPE_NOT_SIGNED
+	}
+	if len(block.Segments) != len(attr.SecurePath) || len(attr.SecurePath) == 0 {
+		return oc.BGPSEC_VALIDATION_RESULT_TYPE_INVALID
+	}
+	targetAS := path.GetSource().LocalAS
+	// RFC 8205 5.2
+	// the most recently added Secure_Path segment must be the one of the
+	// external peer which sent the path.
+	if peerAS != 0 && peerAS != targetAS && attr.SecurePath[0].AS != peerAS {
+		return oc.BGPSEC_VALIDATION_RESULT_TYPE_INVALID
+	}
+	for i, s := range attr.SecurePath {
+		data, err := attr.SignedData(block, i, targetAS, path.GetFamily(), path.GetNlri())
+		if err != nil {
+			return oc.BGPSEC_VALIDATION_RESULT_TYPE_INVALID
+		}
+		digest := sha256.Sum256(data)
+		if !t.verify(s.AS, block.Segments[i].SKI, digest[:], block.Segments[i].Signature) {
+			return oc.BGPSEC_VALIDATION_RESULT_TYPE_INVALID
+		}
+		targetAS = s.AS
+	}
+	return oc.BGPSEC_VALIDATION_RESULT_TYPE_VALID
+}
+
+// BGPsecSKI returns the Subject Key Identifier of the public key, that is
+// the SHA-1 hash of the subjectPublicKey of the router certificate.
+func BGPsecSKI(pub *ecdsa.PublicKey) ([bgp.BGPSEC_SKI_LEN]byte, error) {
+	k, err := pub.ECDH()
+	if err != nil {
+		return [bgp.BGPSEC_SKI_LEN]byte{}, err
+	}
+	return sha1.Sum(k.Bytes()), nil
+}
+
+// BGPsecSigner signs the routes advertised to BGPsec peers with the
+// private key of the local router.
+type BGPsecSigner struct {
+	SKI [bgp.BGPSEC_SKI_LEN]byte
+	key *ecdsa.PrivateKey
+}
+
+func NewBGPsecSigner(key *ecdsa.PrivateKey) (*BGPsecSigner, error) {
+	if key.Curve != elliptic.P256() {
+		return nil, fmt.Errorf("BGPsec requires a P-256 key")
+	}
+	ski, err := BGPsecSKI(&key.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return &BGPsecSigner{
+		SKI: ski,
+		key: key,
+	}, nil
+}
+
+// Sign returns the BGPsec_PATH attribute to send to the neighbor in
+// targetAS, adding the Secure_Path segment of localAS and its signature
+// in front of the ones of the received attribute. in is nil for the
+// routes originated locally.
+func (s *BGPsecSigner) Sign(path *Path, in *bgp.PathAttributeBGPsecPath, localAS, targetAS uint32, pCount uint8) (*bgp.PathAttributeBGPsecPath, error) {
+	securePath := []*bgp.SecurePathSegment{{PCount: pCount, AS: localAS}}
+	segments := []*bgp.BGPsecSignatureSegment{{SKI: s.SKI}}
+	if in != nil {
+		block := in.SignatureBlock(bgp.BGPSEC_ALGORITHM_SUITE_1)
+		if block == nil {
+			return nil, fmt.Errorf("no signature block of algorithm suite %d", bgp.BGPSEC_ALGORITHM_SUITE_1)
+		}
+		securePath = append(securePath, in.SecurePath...)
+		segments = append(segments, block.Segments...)
+	}
+	block := &bgp.BGPsecSignatureBlock{
+		AlgorithmSuite: bgp.BGPSEC_ALGORITHM_SUITE_1,
+		Segments:       segments,
+	}
+	attr := bgp.NewPathAttributeBGPsecPath(securePath, []*bgp.BGPsecSignatureBlock{block})
+	data, err := attr.SignedData(block, 0, targetAS, path.GetFamily(), path.GetNlri())
+	if err != nil {
+		return nil, err
+	}
+	digest := sha256.Sum256(data)
+	sig, err := ecdsa.SignASN1(rand.Reader, s.key, digest[:])
+	if err != nil {
+		return nil, err
+	}
+	segments[0] = &bgp.BGPsecSignatureSegment{SKI: s.SKI, Signature: sig}
+	return bgp.NewPathAttributeBGPsecPath(securePath, []*bgp.BGPsecSignatureBlock{block}), nil
+}
+
+// UpdatePathAttrsBGPsec adds the AS_PATH attribute reconstructed from the
+// BGPsec_PATH attribute of a received update, so that the rest of the
+// code, the best path selection and the policies can work on it.
+func UpdatePathAttrsBGPsec(msg *bgp.BGPUpdate) {
+	var attr *bgp.PathAttributeBGPsecPath
+	for _, a := range msg.PathAttributes {
+		switch v := a.(type) {
+		case *bgp.PathAttributeAsPath:
+			return
+		case *bgp.PathAttributeBGPsecPath:
+			attr = v
+		}
+	}
+	if attr != nil {
+		msg.PathAttributes = append(msg.PathAttributes, attr.AsPath())
+	}
+}
+
+// UpdateBGPsecPath prepares the BGPsec_PATH attribute of the path to be
+// sent to a peer; send tells whether BGPsec updates can be sent to the
+// peer for the family. To an eBGP peer, the path is signed when the
+// AS_PATH attribute, prepended with localAS, still matches the received
+// Secure_Path; to an iBGP peer, the received attribute is forwarded
+// unchanged. In the other cases, the path is sent as an unsigned one.
+func (path *Path) UpdateBGPsecPath(signer *BGPsecSigner, send, ebgp bool, localAS, peerAS uint32) error {
+	in := path.GetBGPsecPath()
+	strip := func() {
+		if in != nil {
+			path.delPathAttr(bgp.BGP_ATTR_TYPE_BGPSEC_PATH)
+		}
+	}
+	if !send {
+		strip()
+		return nil
+	}
+	if !ebgp {
+		if in != nil {
+			path.delPathAttr(bgp.BGP_ATTR_TYPE_AS_PATH)
+		}
+		return nil
+	}
+	if signer == nil || (in == nil && !path.IsLocal()) {
+		strip()
+		return nil
+	}
+
+	var asList []uint32
+	if aspath := path.GetAsPath(); aspath != nil {
+		for _, param := range aspath.Value {
+			if param.GetType() != bgp.BGP_ASPATH_ATTR_TYPE_SEQ {
+				strip()
+				return nil
+			}
+			asList = append(asList, param.GetAS()...)
+		}
+	}
+	pCount := 0
+	for pCount < len(asList) && asList[pCount] == localAS {
+		pCount++
+	}
+	if pCount == 0 || pCount > 255 {
+		strip()
+		return nil
+	}
+	var inList []uint32
+	if in != nil {
+		for _, param := range in.AsPath().Value {
+			if param.GetType() != bgp.BGP_ASPATH_ATTR_TYPE_SEQ {
+				strip()
+				return nil
+			}
+			inList = append(inList, param.GetAS()...)
+		}
+	}
+	if !slices.Equal(asList[pCount:], inList) {
+		// the AS_PATH was modified after being received
+		strip()
+		return nil
+	}
+
+	attr, err := signer.Sign(path, in, localAS, peerAS, uint8(pCount))
+	if err != nil {
+		strip()
+		return err
+	}
+	path.delPathAttr(bgp.BGP_ATTR_TYPE_AS_PATH)
+	path.delPathAttr(bgp.BGP_ATTR_TYPE_AS4_PATH)
+	path.setPathAttr(attr)
+	return nil
+}
@@ -0,0 +1,150 @@
+package table
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/osrg/gobgp/v4/pkg/config/oc"
+	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBGPsecSigner(t *testing.T) *BGPsecSigner {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	s, err := NewBGPsecSigner(key)
+	require.NoError(t, err)
+	return s
+}
+
+// receiveBGPsecPath returns the path as it is received by localAS from
+// peerAS, keeping only BGPsec_PATH and rebuilding AS_PATH from it.
+func receiveBGPsecPath(sent *Path, peerAS, localAS uint32) *Path {
+	msg := &bgp.BGPUpdate{}
+	for _, a := range sent.GetPathAttrs() {
+		if a.GetType() != bgp.BGP_ATTR_TYPE_AS_PATH {
+			msg.PathAttributes = append(msg.PathAttributes, a)
+		}
+	}
+	UpdatePathAttrsBGPsec(msg)
+	source := &PeerInfo{AS: peerAS, LocalAS: localAS, Address: netip.MustParseAddr("192.0.2.1")}
+	return NewPath(sent.GetFamily(), source, bgp.PathNLRI{NLRI: sent.GetNlri()}, false, msg.PathAttributes, time.Now(), false)
+}
+
+func TestBGPsecSignAndValidate(t *testing.T) {
+	assert := assert.New(t)
+	signer1 := newTestBGPsecSigner(t)
+	signer2 := newTestBGPsecSigner(t)
+	keys := NewRouterKeyTable()
+	keys.Add(NewRouterKey(65001, signer1.SKI, &signer1.key.PublicKey, "cache"))
+	keys.Add(NewRouterKey(65002, signer2.SKI, &signer2.key.PublicKey, "cache"))
+
+	nlri, _ := bgp.NewIPAddrPrefix(netip.MustParsePrefix("10.0.0.0/24"))
+	nexthop, _ := bgp.NewPathAttributeNextHop(netip.MustParseAddr("192.0.2.2"))
+	attrs := []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(0),
+		nexthop,
+	}
+	local := NewPath(bgp.RF_IPv4_UC, &PeerInfo{}, bgp.PathNLRI{NLRI: nlri}, false, attrs, time.Now(), false)
+	assert.Equal(oc.BGPSEC_VALIDATION_RESULT_TYPE_NOT_SIGNED, keys.Validate(local, 0))
+
+	// AS 65002 originates the route to AS 65001
+	sent := local.Clone(false)
+	sent.PrependAsn(65002, 1, false)
+	require.NoError(t, sent.UpdateBGPsecPath(signer2, true, true, 65002, 65001))
+	assert.Nil(sent.GetAsPath())
+	assert.NotNil(sent.GetBGPsecPath())
+
+	received := receiveBGPsecPath(sent, 65002, 65001)
+	assert.Equal([]uint32{65002}, received.GetAsList())
+	assert.Equal(oc.BGPSEC_VALIDATION_RESULT_TYPE_VALID, keys.Validate(received, 65002))
+	assert.Equal(oc.BGPSEC_VALIDATION_RESULT_TYPE_INVALID, NewRouterKeyTable().Validate(received, 65002))
+	// the signature was made for AS 65001
+	assert.Equal(oc.BGPSEC_VALIDATION_RESULT_TYPE_INVALID, keys.Validate(receiveBGPsecPath(sent, 65002, 65003), 65002))
+
+	// the path signed for AS 65001 is replayed by another neighbor
+	replayed := receiveBGPsecPath(sent, 65004, 65001)
+	assert.Equal(oc.BGPSEC_VALIDATION_RESULT_TYPE_INVALID, keys.Validate(replayed, 65004))
+	// an iBGP peer doesn't add its own segment
+	assert.Equal(oc.BGPSEC_VALIDATION_RESULT_TYPE_VALID, keys.Validate(receiveBGPsecPath(sent, 65001, 65001), 65001))
+
+	// AS 65001 forwards it to AS 65003 with prepending
+	forwarded := received.Clone(false)
+	forwarded.PrependAsn(65001, 2, false)
+	require.NoError(t, forwarded.UpdateBGPsecPath(signer1, true, true, 65001, 65003))
+	attr := forwarded.GetBGPsecPath()
+	require.Len(t, attr.SecurePath, 2)
+	assert.Equal(&bgp.SecurePathSegment{PCount: 2, AS: 65001}, attr.SecurePath[0])
+
+	received = receiveBGPsecPath(forwarded, 65001, 65003)
+	assert.Equal([]uint32{65001, 65001, 65002}, received.GetAsList())
+	assert.Equal(oc.BGPSEC_VALIDATION_RESULT_TYPE_VALID, keys.Validate(received, 65001))
+
+	keys.DeleteAll("cache")
+	assert.Equal(oc.BGPSEC_VALIDATION_RESULT_TYPE_INVALID, keys.Validate(received, 65001))
+	assert.Empty(keys.List())
+}
+
+func TestUpdateBGPsecPath(t *testing.T) {
+	assert := assert.New(t)
+	signer := newTestBGPsecSigner(t)
+	nlri, _ := bgp.NewIPAddrPrefix(netip.MustParsePrefix("10.0.0.0/24"))
+	local := NewPath(bgp.RF_IPv4_UC, &PeerInfo{}, bgp.PathNLRI{NLRI: nlri}, false, []bgp.PathAttributeInterface{bgp.NewPathAttributeOrigin(0)}, time.Now(), false)
+	sent := local.Clone(false)
+	sent.PrependAsn(65002, 1, false)
+	require.NoError(t, sent.UpdateBGPsecPath(signer, true, true, 65002, 65001))
+	received := receiveBGPsecPath(sent, 65002, 65001)
+
+	// the peer doesn't support BGPsec
+	p := received.Clone(false)
+	p.PrependAsn(65001, 1, false)
+	require.NoError(t, p.UpdateBGPsecPath(signer, false, true, 65001, 65003))
+	assert.Nil(p.GetBGPsecPath())
+	assert.Equal([]uint32{65001, 65002}, p.GetAsList())
+
+	// AS_PATH modified by a policy can't be signed
+	p = received.Clone(false)
+	p.PrependAsn(65009, 1, false)
+	p.PrependAsn(65001, 1, false)
+	require.NoError(t, p.UpdateBGPsecPath(signer, true, true, 65001, 65003))
+	assert.Nil(p.GetBGPsecPath())
+	assert.Equal([]uint32{65001, 65009, 65002}, p.GetAsList())
+
+	// no local key
+	p = received.Clone(false)
+	p.PrependAsn(65001, 1, false)
+	require.NoError(t, p.UpdateBGPsecPath(nil, true, true, 65001, 65003))
+	assert.Nil(p.GetBGPsecPath())
+
+	// forwarded unchanged to iBGP peers
+	p = received.Clone(false)
+	require.NoError(t, p.UpdateBGPsecPath(nil, true, false, 65001, 65001))
+	assert.Equal(received.GetBGPsecPath(), p.GetBGPsecPath())
+	assert.Nil(p.GetAsPath())
+}
+
+func TestCreateMPReachMessageBGPsec(t *testing.T) {
+	assert := assert.New(t)
+	signer := newTestBGPsecSigner(t)
+	nlri, _ := bgp.NewIPAddrPrefix(netip.MustParsePrefix("10.0.0.0/24"))
+	nexthop, _ := bgp.NewPathAttributeNextHop(netip.MustParseAddr("192.0.2.2"))
+	p := NewPath(bgp.RF_IPv4_UC, &PeerInfo{}, bgp.PathNLRI{NLRI: nlri}, false, []bgp.PathAttributeInterface{bgp.NewPathAttributeOrigin(0), nexthop}, time.Now(), false)
+	p = p.Clone(false)
+	p.PrependAsn(65002, 1, false)
+	require.NoError(t, p.UpdateBGPsecPath(signer, true, true, 65002, 65001))
+
+	msgs := CreateUpdateMsgFromPaths([]*Path{p})
+	require.Len(t, msgs, 1)
+	u := msgs[0].Body.(*bgp.BGPUpdate)
+	assert.Empty(u.NLRI)
+	var types []bgp.BGPAttrType
+	for _, a := range u.PathAttributes {
+		types = append(types, a.GetType())
+	}
+	assert.ElementsMatch([]bgp.BGPAttrType{bgp.BGP_ATTR_TYPE_MP_REACH_NLRI, bgp.BGP_ATTR_TYPE_ORIGIN, bgp.BGP_ATTR_TYPE_BGPSEC_PATH}, types)
+}
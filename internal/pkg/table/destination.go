@@ -22,6 +22,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"maps"
+	"math"
 	"net"
 	"net/netip"
 	"slices"
@@ -81,6 +83,15 @@ func (r *BestPathReason) String() string {
 	return BestPathReasonStringMap[*r]
 }
 
+// selectionOptions returns the given route selection options, or the
+// global ones if nil.
+func selectionOptions(opts *oc.RouteSelectionOptionsConfig) *oc.RouteSelectionOptionsConfig {
+	if opts == nil {
+		return &SelectionOptions
+	}
+	return opts
+}
+
 type PeerInfo struct {
 	AS                      uint32
 	LocalAS                 uint32
@@ -214,8 +225,12 @@ func rsFilter(id string, as uint32, path *Path) bool {
 }
 
 func (dd *Destination) GetKnownPathList(id string, as uint32) []*Path {
-	list := make([]*Path, 0, len(dd.knownPathList))
-	for _, p := range dd.knownPathList {
+	return getKnownPathList(id, as, dd.knownPathList)
+}
+
+func getKnownPathList(id string, as uint32, pathList []*Path) []*Path {
+	list := make([]*Path, 0, len(pathList))
+	for _, p := range pathList {
 		if rsFilter(id, as, p) {
 			continue
 		}
@@ -242,15 +257,120 @@ func (dd *Destination) GetBestPath(id string, as uint32) *Path {
 	return p
 }
 
-func (dd *Destination) GetMultiBestPath(id string) []*Path {
-	return getMultiBestPath(id, dd.knownPathList)
+func (dd *Destination) GetMultiBestPath(id string, opts *oc.RouteSelectionOptionsConfig) []*Path {
+	return getMultiBestPath(id, dd.knownPathList, selectionOptions(opts))
+}
+
+// AlternativePath is the path advertised to a neighbor instead of, or
+// with add-path in addition to, the best path.
+type AlternativePath int
+
+const (
+	ALTERNATIVE_PATH_NONE AlternativePath = iota
+	// the best eBGP path if the best path is learned via iBGP and can't
+	// be advertised to the neighbor
+	ALTERNATIVE_PATH_BEST_EXTERNAL
+	// the best of the paths with a next hop or a cluster different from
+	// the best path
+	ALTERNATIVE_PATH_DIVERSE
+)
+
+// GetAlternativePath returns the alternative path advertised to the
+// neighbor described by target.
+func (dd *Destination) GetAlternativePath(id string, as uint32, alt AlternativePath, target *PeerInfo) *Path {
+	return getAlternativePath(alt, getKnownPathList(id, as, dd.knownPathList), target)
+}
+
+func getAlternativePath(alt AlternativePath, pathList []*Path, target *PeerInfo) *Path {
+	// as in getMultiBestPath, none of the paths is reachable if the
+	// first one isn't.
+	if len(pathList) == 0 || pathList[0].IsNexthopInvalid {
+		return nil
+	}
+	best := pathList[0]
+	switch alt {
+	case ALTERNATIVE_PATH_BEST_EXTERNAL:
+		if best.IsLocal() || !best.IsIBGP() || canReflect(best, target) {
+			return best
+		}
+		for _, path := range pathList[1:] {
+			if path.IsNexthopInvalid {
+				break
+			}
+			if !path.IsLocal() && !path.IsIBGP() {
+				return path
+			}
+		}
+		// no eBGP path, the best one is advertised as usual
+		return best
+	case ALTERNATIVE_PATH_DIVERSE:
+		for _, path := range pathList[1:] {
+			if path.IsNexthopInvalid {
+				break
+			}
+			if path.GetNexthop() != best.GetNexthop() || clusterID(path) != clusterID(best) {
+				return path
+			}
+		}
+	}
+	return nil
+}
+
+// canReflect reports whether the iBGP path can be advertised to the
+// neighbor described by target: an eBGP neighbor, or an iBGP neighbor
+// other than the source if either of them is a route reflector client.
+func canReflect(path *Path, target *PeerInfo) bool {
+	if target == nil {
+		return false
+	}
+	if target.AS != target.LocalAS {
+		return true
+	}
+	source := path.GetSource()
+	if source.Address == target.Address {
+		return false
+	}
+	return target.RouteReflectorClient || source.RouteReflectorClient
+}
+
+// clusterID returns the cluster which reflected the path last, the first
+// entry of CLUSTER_LIST, or the zero value if the path isn't reflected.
+func clusterID(path *Path) netip.Addr {
+	if l := path.GetClusterList(); len(l) > 0 {
+		return l[0]
+	}
+	return netip.Addr{}
+}
+
+// BestPathReasons returns the reason why each known path is ordered as
+// it is. The reason of the best path is why it's preferred over the
+// second one, and the reason of any other path is why the previous one
+// is preferred over it.
+func (dd *Destination) BestPathReasons(opts *oc.RouteSelectionOptionsConfig) map[*Path]BestPathReason {
+	opts = selectionOptions(opts)
+	reasons := make(map[*Path]BestPathReason, len(dd.knownPathList))
+	for i, path := range dd.knownPathList {
+		switch {
+		case opts.DisableBestPathSelection:
+			reasons[path] = BPR_DISABLED
+		case len(dd.knownPathList) == 1:
+			reasons[path] = BPR_ONLY_PATH
+		case i == 0:
+			_, reasons[path] = compare(path, dd.knownPathList[1], opts)
+		default:
+			_, reasons[path] = compare(dd.knownPathList[i-1], path, opts)
+		}
+	}
+	return reasons
 }
 
 // Calculates best-path among known paths for this destination.
 //
 // Modifies destination's state related to stored paths. Removes withdrawn
-// paths from known paths. Also, adds new paths to known paths.
-func (dest *Destination) Calculate(logger *slog.Logger, newPath *Path) *Update {
+// paths from known paths. Also, adds new paths to known paths. If opts is
+// nil, the global route selection options are used.
+func (dest *Destination) Calculate(logger *slog.Logger, newPath *Path, opts *oc.RouteSelectionOptionsConfig) *Update {
+	opts = selectionOptions(opts)
 	oldKnownPathList := make([]*Path, len(dest.knownPathList))
 	copy(oldKnownPathList, dest.knownPathList)
 
@@ -263,7 +383,10 @@ func (dest *Destination) Calculate(logger *slog.Logger, newPath *Path) *Update {
 		}
 	} else {
 		dest.implicitWithdraw(logger, newPath)
-		dest.insertSort(newPath)
+		dest.insertSort(newPath, opts)
+	}
+	if opts.DeterministicMed && len(dest.knownPathList) > 2 {
+		dest.knownPathList = deterministicMedSort(dest.knownPathList, opts)
 	}
 
 	for _, path := range dest.knownPathList {
@@ -282,6 +405,7 @@ func (dest *Destination) Calculate(logger *slog.Logger, newPath *Path) *Update {
 	return &Update{
 		KnownPathList:    l,
 		OldKnownPathList: oldKnownPathList,
+		options:          opts,
 	}
 }
 
@@ -360,9 +484,13 @@ func (dest *Destination) implicitWithdraw(logger *slog.Logger, newPath *Path) {
 	}
 }
 
-func (dest *Destination) insertSort(newPath *Path) {
+func (dest *Destination) insertSort(newPath *Path, opts *oc.RouteSelectionOptionsConfig) {
+	dest.knownPathList = insertPath(dest.knownPathList, newPath, opts)
+}
+
+func insertPath(pathList []*Path, newPath *Path, opts *oc.RouteSelectionOptionsConfig) []*Path {
 	// Find the correct position for newPath
-	insertIdx := sort.Search(len(dest.knownPathList), func(i int) bool {
+	insertIdx := sort.Search(len(pathList), func(i int) bool {
 		//Determine where in the array newPath belongs. The slice
 		//is assumed to be in descending order: most preferred to least.
 		//
@@ -389,87 +517,132 @@ func (dest *Destination) insertSort(newPath *Path) {
 		//	path.
 		//	Assumes paths from NC has source equal to None.
 		//
-		path1 := newPath
-		path2 := dest.knownPathList[i]
-
-		if b := compareByLLGRStaleCommunity(path1, path2); b == path1 {
-			return true
-		} else if b == path2 {
-			return false
-		}
-
-		if b := compareByReachableNexthop(path1, path2); b == path1 {
-			return true
-		} else if b == path2 {
-			return false
-		}
-
-		if b := compareByLocalPref(path1, path2); b == path1 {
-			return true
-		} else if b == path2 {
-			return false
-		}
-
-		if b := compareByLocalOrigin(path1, path2); b == path1 {
-			return true
-		} else if b == path2 {
-			return false
-		}
-
-		if b := compareByASPath(path1, path2); b == path1 {
-			return true
-		} else if b == path2 {
-			return false
-		}
-
-		if b := compareByOrigin(path1, path2); b == path1 {
-			return true
-		} else if b == path2 {
-			return false
-		}
-
-		if b := compareByMED(path1, path2); b == path1 {
-			return true
-		} else if b == path2 {
-			return false
-		}
-
-		if b := compareByASNumber(path1, path2); b == path1 {
-			return true
-		} else if b == path2 {
-			return false
-		}
+		b, _ := compare(newPath, pathList[i], opts)
+		return b != pathList[i]
+	})
 
-		if b := compareByAge(path1, path2); b == path1 {
-			return true
-		} else if b == path2 {
-			return false
-		}
+	// Insert at the found position
+	return slices.Insert(pathList, insertIdx, newPath)
+}
 
-		if b, _ := compareByRouterID(path1, path2); b == path1 {
-			return true
-		} else if b == path2 {
-			return false
-		}
+// compare returns the preferred one of the two paths and the reason why
+// it's preferred. If the paths can't be told apart, nil is returned.
+func compare(path1, path2 *Path, opts *oc.RouteSelectionOptionsConfig) (*Path, BestPathReason) {
+	if b := compareByLLGRStaleCommunity(path1, path2); b != nil {
+		return b, BPR_NON_LLGR_STALE
+	}
+	if b := compareByReachableNexthop(path1, path2); b != nil {
+		return b, BPR_REACHABLE_NEXT_HOP
+	}
+	if b := compareByWeight(path1, path2); b != nil {
+		return b, BPR_HIGHEST_WEIGHT
+	}
+	if b := compareByLocalPref(path1, path2); b != nil {
+		return b, BPR_LOCAL_PREF
+	}
+	if b := compareByLocalOrigin(path1, path2); b != nil {
+		return b, BPR_LOCAL_ORIGIN
+	}
+	if b := compareByASPath(path1, path2, opts); b != nil {
+		return b, BPR_ASPATH
+	}
+	if b := compareByOrigin(path1, path2); b != nil {
+		return b, BPR_ORIGIN
+	}
+	if b := compareByMED(path1, path2, opts); b != nil {
+		return b, BPR_MED
+	}
+	if b := compareByASNumber(path1, path2); b != nil {
+		return b, BPR_ASN
+	}
+	if b := compareByAge(path1, path2, opts); b != nil {
+		return b, BPR_OLDER
+	}
+	if b, _ := compareByRouterID(path1, path2, opts); b != nil {
+		return b, BPR_ROUTER_ID
+	}
+	if b := compareByNeighborAddress(path1, path2); b != nil {
+		return b, BPR_NEIGH_ADDR
+	}
+	return nil, BPR_UNKNOWN
+}
 
-		if b := compareByNeighborAddress(path1, path2); b == path1 {
-			return true
-		} else if b == path2 {
-			return false
+// deterministicMedSort groups the paths by their neighboring AS and sorts
+// each group, so that MED is always compared within a group. The groups
+// are then merged by repeatedly taking the best of the group heads. The
+// result doesn't depend on the order in which the paths were received.
+func deterministicMedSort(pathList []*Path, opts *oc.RouteSelectionOptionsConfig) []*Path {
+	groups := make(map[uint32][]*Path)
+	for _, path := range pathList {
+		as := neighborAS(path)
+		groups[as] = insertPath(groups[as], path, opts)
+	}
+	keys := slices.Sorted(maps.Keys(groups))
+	sorted := make([]*Path, 0, len(pathList))
+	for len(sorted) < len(pathList) {
+		var best *Path
+		var bestAS uint32
+		for _, as := range keys {
+			if len(groups[as]) == 0 {
+				continue
+			}
+			if head := groups[as][0]; best == nil {
+				best, bestAS = head, as
+			} else if b, _ := compare(head, best, opts); b == head {
+				best, bestAS = head, as
+			}
 		}
-		return true
-	})
-
-	// Insert at the found position
-	dest.knownPathList = slices.Insert(dest.knownPathList, insertIdx, newPath)
+		sorted = append(sorted, best)
+		groups[bestAS] = groups[bestAS][1:]
+	}
+	return sorted
 }
 
 type Update struct {
 	KnownPathList    []*Path
 	OldKnownPathList []*Path
+	options          *oc.RouteSelectionOptionsConfig
 }
 
-func getMultiBestPath(id string, pathList []*Path) []*Path {
+// multipathAsPathMatch reports whether multipath needs the same AS path.
+func multipathAsPathMatch(opts *oc.RouteSelectionOptionsConfig) bool {
+	return opts.MultipathAsPathMatch && !opts.MultipathRelax
+}
+
+// IsMultiPath reports whether the path is as good as the best path so
+// that both can be used for multipath. With multipath-as-path-match, the
+// paths must have the same AS path too. With multipath-relax, the paths
+// from different neighbor ASes only need AS paths of the same length.
+func IsMultiPath(best, path *Path, opts *oc.RouteSelectionOptionsConfig) bool {
+	opts = selectionOptions(opts)
+	if !multipathEqual(best, path, opts) {
+		return false
+	}
+	return !multipathAsPathMatch(opts) || slices.Equal(best.GetAsList(), path.GetAsList())
+}
+
+// multipathEqual reports whether the two paths can't be told apart by the
+// steps of compare before the tie-breakers. Locally originated paths are
+// equal whatever their source. Unlike compare, MED is always compared
+// unless multipath-relax is set, so by default the paths from different
+// neighbor ASes must have the same MED.
+func multipathEqual(path1, path2 *Path, opts *oc.RouteSelectionOptionsConfig) bool {
+	medOpts := *opts
+	if !opts.MultipathRelax {
+		medOpts.AlwaysCompareMed = true
+	}
+	return compareByLLGRStaleCommunity(path1, path2) == nil &&
+		compareByReachableNexthop(path1, path2) == nil &&
+		compareByWeight(path1, path2) == nil &&
+		compareByLocalPref(path1, path2) == nil &&
+		path1.IsLocal() == path2.IsLocal() &&
+		compareByASPath(path1, path2, opts) == nil &&
+		compareByOrigin(path1, path2) == nil &&
+		compareByMED(path1, path2, &medOpts) == nil &&
+		compareByASNumber(path1, path2) == nil
+}
+
+func getMultiBestPath(id string, pathList []*Path, opts *oc.RouteSelectionOptionsConfig) []*Path {
 	// The path list of destinations in the global RIB are sorted
 	// in descending order. One of the criteria for being a better
 	// path is that it has a reachable next hop. Technically, if the
@@ -481,12 +654,19 @@ func getMultiBestPath(id string, pathList []*Path) []*Path {
 	}
 	best := pathList[0]
 
-	// Attempt to find the first path that is both reachable and worse than the
-	// best path. Then return a slice paths from the best to that index.
-	index := sort.Search(len(pathList), func(i int) bool {
-		return pathList[i].IsNexthopInvalid || pathList[i].Compare(best) != 0
-	})
-	return pathList[:index]
+	// The paths as good as the best path aren't always next to each
+	// other since MED isn't compared between different neighbor ASes
+	// when sorting, so look at all the reachable ones.
+	multi := make([]*Path, 0, len(pathList))
+	for _, path := range pathList {
+		if path.IsNexthopInvalid {
+			break
+		}
+		if IsMultiPath(best, path, opts) {
+			multi = append(multi, path)
+		}
+	}
+	return multi
 }
 
 func (u *Update) GetWithdrawnPath() []*Path {
@@ -507,46 +687,53 @@ func (u *Update) GetWithdrawnPath() []*Path {
 	return l
 }
 
-func (u *Update) GetChanges(id string, as uint32, peerDown bool) (*Path, *Path, []*Path) {
-	best, old := func(id string) (*Path, *Path) {
-		old := getBestPath(id, as, u.OldKnownPathList)
-		best := getBestPath(id, as, u.KnownPathList)
-		if best != nil && best.Equal(old) {
-			// RFC4684 3.2. Intra-AS VPN Route Distribution
-			// When processing RT membership NLRIs received from internal iBGP
-			// peers, it is necessary to consider all available iBGP paths for a
-			// given RT prefix, for building the outbound route filter, and not just
-			// the best path.
-			if best.GetFamily() == bgp.RF_RTC_UC {
-				return best, old
-			}
-			// For BGP Nexthop Tracking, checks if the nexthop reachability
-			// was changed or not.
-			if best.IsNexthopInvalid != old.IsNexthopInvalid {
-				// If the nexthop of the best path became unreachable, we need
-				// to withdraw that path.
-				if best.IsNexthopInvalid {
-					return best.Clone(true), old
-				}
-				return best, old
-			}
-			return nil, old
+// getChanges returns the new and the old paths chosen by pick among the
+// known paths, or nil as the new path if it doesn't need to be advertised
+// again.
+func (u *Update) getChanges(peerDown bool, pick func(pathList []*Path) *Path) (*Path, *Path) {
+	old := pick(u.OldKnownPathList)
+	best := pick(u.KnownPathList)
+	if best != nil && best.Equal(old) {
+		// RFC4684 3.2. Intra-AS VPN Route Distribution
+		// When processing RT membership NLRIs received from internal iBGP
+		// peers, it is necessary to consider all available iBGP paths for a
+		// given RT prefix, for building the outbound route filter, and not just
+		// the best path.
+		if best.GetFamily() == bgp.RF_RTC_UC {
+			return best, old
 		}
-		if best == nil {
-			if old == nil {
-				return nil, nil
+		// For BGP Nexthop Tracking, checks if the nexthop reachability
+		// was changed or not.
+		if best.IsNexthopInvalid != old.IsNexthopInvalid {
+			// If the nexthop of the best path became unreachable, we need
+			// to withdraw that path.
+			if best.IsNexthopInvalid {
+				return best.Clone(true), old
 			}
-			if peerDown {
-				// withdraws were generated by peer
-				// down so paths are not in knowpath
-				// or adjin.
-				old.IsWithdraw = true
-				return old, old
-			}
-			return old.Clone(true), old
+			return best, old
+		}
+		return nil, old
+	}
+	if best == nil {
+		if old == nil {
+			return nil, nil
 		}
-		return best, old
-	}(id)
+		if peerDown {
+			// withdraws were generated by peer
+			// down so paths are not in knowpath
+			// or adjin.
+			old.IsWithdraw = true
+			return old, old
+		}
+		return old.Clone(true), old
+	}
+	return best, old
+}
+
+func (u *Update) GetChanges(id string, as uint32, peerDown bool) (*Path, *Path, []*Path) {
+	best, old := u.getChanges(peerDown, func(pathList []*Path) *Path {
+		return getBestPath(id, as, pathList)
+	})
 
 	var multi []*Path
 
@@ -562,8 +749,9 @@ func (u *Update) GetChanges(id string, as uint32, peerDown bool) (*Path, *Path,
 			}
 			return false
 		}
-		oldM := getMultiBestPath(id, u.OldKnownPathList)
-		newM := getMultiBestPath(id, u.KnownPathList)
+		opts := selectionOptions(u.options)
+		oldM := getMultiBestPath(id, u.OldKnownPathList, opts)
+		newM := getMultiBestPath(id, u.KnownPathList, opts)
 		if diff(oldM, newM) {
 			multi = newM
 			if len(newM) == 0 {
@@ -574,6 +762,21 @@ func (u *Update) GetChanges(id string, as uint32, peerDown bool) (*Path, *Path,
 	return best, old, multi
 }
 
+// GetAlternativeChanges returns the new and the old alternative paths
+// advertised to the neighbor described by target like GetChanges does for
+// the best paths.
+func (u *Update) GetAlternativeChanges(id string, as uint32, alt AlternativePath, target *PeerInfo) (*Path, *Path) {
+	return u.getChanges(false, func(pathList []*Path) *Path {
+		return getAlternativePath(alt, getKnownPathList(id, as, pathList), target)
+	})
+}
+
+// GetAlternativePath returns the alternative path advertised to the
+// neighbor described by target after the update.
+func (u *Update) GetAlternativePath(id string, as uint32, alt AlternativePath, target *PeerInfo) *Path {
+	return getAlternativePath(alt, getKnownPathList(id, as, u.KnownPathList), target)
+}
+
 func compareByLLGRStaleCommunity(path1, path2 *Path) *Path {
 	p1 := path1.IsLLGRStale()
 	p2 := path2.IsLLGRStale()
@@ -600,6 +803,21 @@ func compareByReachableNexthop(path1, path2 *Path) *Path {
 	return nil
 }
 
+func compareByWeight(path1, path2 *Path) *Path {
+	//	Selects a path with highest weight.
+	//
+	//	The weight is set by the policy and only relevant to the local
+	//	router. The default weight is 0.
+	weight1 := path1.GetWeight()
+	weight2 := path2.GetWeight()
+	if weight1 > weight2 {
+		return path1
+	} else if weight1 < weight2 {
+		return path2
+	}
+	return nil
+}
+
 func compareByLocalPref(path1, path2 *Path) *Path {
 	//	Selects a path with highest local-preference.
 	//
@@ -644,12 +862,12 @@ func compareByLocalOrigin(path1, path2 *Path) *Path {
 	return nil
 }
 
-func compareByASPath(path1, path2 *Path) *Path {
+func compareByASPath(path1, path2 *Path, opts *oc.RouteSelectionOptionsConfig) *Path {
 	// Calculated the best-paths by comparing as-path lengths.
 	//
 	// Shortest as-path length is preferred. If both path have same lengths,
 	// we return None.
-	if SelectionOptions.IgnoreAsPathLength {
+	if opts.IgnoreAsPathLength {
 		return nil
 	}
 
@@ -691,12 +909,44 @@ func compareByOrigin(path1, path2 *Path) *Path {
 	}
 }
 
-func compareByMED(path1, path2 *Path) *Path {
+// neighborAS returns the leftmost AS outside the confederation in the AS
+// path, or zero if there is none.
+func neighborAS(path *Path) uint32 {
+	if asPath := path.GetAsPath(); asPath != nil {
+		for _, v := range asPath.Value {
+			asList := v.GetAS()
+			if len(asList) == 0 {
+				continue
+			}
+			switch v.GetType() {
+			case bgp.BGP_ASPATH_ATTR_TYPE_CONFED_SET, bgp.BGP_ASPATH_ATTR_TYPE_CONFED_SEQ:
+				continue
+			}
+			return asList[0]
+		}
+	}
+	return 0
+}
+
+// isConfedPath reports whether the path was learned within the
+// confederation, that is, its AS path starts with a confederation segment.
+func isConfedPath(path *Path) bool {
+	if asPath := path.GetAsPath(); asPath != nil && len(asPath.Value) > 0 {
+		switch asPath.Value[0].GetType() {
+		case bgp.BGP_ASPATH_ATTR_TYPE_CONFED_SET, bgp.BGP_ASPATH_ATTR_TYPE_CONFED_SEQ:
+			return true
+		}
+	}
+	return false
+}
+
+func compareByMED(path1, path2 *Path, opts *oc.RouteSelectionOptionsConfig) *Path {
 	//	Select the path based with lowest MED value.
 	//
 	//	If both paths have same MED, return None.
 	//	By default, a route that arrives with no MED value is treated as if it
-	//	had a MED of 0, the most preferred value.
+	//	had a MED of 0, the most preferred value, unless med-missing-as-worst
+	//	is set.
 	//	RFC says lower MED is preferred over higher MED value.
 	//  compare MED among not only same AS path but also all path,
 	//  like bgp always-compare-med
@@ -704,29 +954,18 @@ func compareByMED(path1, path2 *Path) *Path {
 	isInternal := func() bool { return path1.GetAsPathLen() == 0 && path2.GetAsPathLen() == 0 }()
 
 	isSameAS := func() bool {
-		firstAS := func(path *Path) uint32 {
-			if asPath := path.GetAsPath(); asPath != nil {
-				for _, v := range asPath.Value {
-					asList := v.GetAS()
-					if len(asList) == 0 {
-						continue
-					}
-					switch v.GetType() {
-					case bgp.BGP_ASPATH_ATTR_TYPE_CONFED_SET, bgp.BGP_ASPATH_ATTR_TYPE_CONFED_SEQ:
-						continue
-					}
-					return asList[0]
-				}
-			}
-			return 0
-		}
-		return firstAS(path1) != 0 && firstAS(path1) == firstAS(path2)
+		return neighborAS(path1) != 0 && neighborAS(path1) == neighborAS(path2)
 	}()
 
-	if SelectionOptions.AlwaysCompareMed || isInternal || isSameAS {
+	isConfed := opts.ConfedCompare && isConfedPath(path1) && isConfedPath(path2)
+
+	if opts.AlwaysCompareMed || isInternal || isSameAS || isConfed {
 		getMed := func(path *Path) uint32 {
 			attribute := path.getPathAttr(bgp.BGP_ATTR_TYPE_MULTI_EXIT_DISC)
 			if attribute == nil {
+				if opts.MedMissingAsWorst {
+					return math.MaxUint32
+				}
 				return 0
 			}
 			med := attribute.(*bgp.PathAttributeMultiExitDisc).Value
@@ -767,7 +1006,7 @@ func compareByASNumber(path1, path2 *Path) *Path {
 	return nil
 }
 
-func compareByRouterID(path1, path2 *Path) (*Path, error) {
+func compareByRouterID(path1, path2 *Path, opts *oc.RouteSelectionOptionsConfig) (*Path, error) {
 	//	Select the route received from the peer with the lowest BGP router ID.
 	//
 	//	If both paths are eBGP paths, then we do not do any tie breaking, i.e we do
@@ -782,11 +1021,11 @@ func compareByRouterID(path1, path2 *Path) (*Path, error) {
 
 	// If both paths are from eBGP peers, then according to RFC we need
 	// not tie break using router id.
-	if !SelectionOptions.ExternalCompareRouterId && !path1.IsIBGP() && !path2.IsIBGP() {
+	if !opts.ExternalCompareRouterId && !path1.IsIBGP() && !path2.IsIBGP() {
 		return nil, nil
 	}
 
-	if !SelectionOptions.ExternalCompareRouterId && path1.IsIBGP() != path2.IsIBGP() {
+	if !opts.ExternalCompareRouterId && path1.IsIBGP() != path2.IsIBGP() {
 		return nil, fmt.Errorf("this method does not support comparing ebgp with ibgp path")
 	}
 
@@ -827,8 +1066,8 @@ func compareByNeighborAddress(path1, path2 *Path) *Path {
 	return nil
 }
 
-func compareByAge(path1, path2 *Path) *Path {
-	if !path1.IsIBGP() && !path2.IsIBGP() && !SelectionOptions.ExternalCompareRouterId {
+func compareByAge(path1, path2 *Path, opts *oc.RouteSelectionOptionsConfig) *Path {
+	if !path1.IsIBGP() && !path2.IsIBGP() && !opts.ExternalCompareRouterId {
 		age1 := path1.GetTimestamp().UnixNano()
 		age2 := path2.GetTimestamp().UnixNano()
 		if age1 == age2 {
@@ -899,13 +1138,17 @@ func (d *Destination) Select(option ...DestinationSelectOption) *Destination {
 			if !mp {
 				paths = []*Path{paths[0]}
 			} else {
+				var opts *oc.RouteSelectionOptionsConfig
+				if vrf != nil {
+					opts = vrf.SelectionOptions
+				}
 				ps := make([]*Path, 0, len(paths))
 				var best *Path
 				for _, p := range paths {
 					if best == nil {
 						best = p
 						ps = append(ps, p)
-					} else if best.Compare(p) == 0 {
+					} else if IsMultiPath(best, p, opts) {
 						ps = append(ps, p)
 					}
 				}
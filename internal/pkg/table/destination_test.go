@@ -23,6 +23,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/osrg/gobgp/v4/pkg/config/oc"
 	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
 
 	"github.com/stretchr/testify/assert"
@@ -73,7 +74,7 @@ func TestCalculate2(t *testing.T) {
 	path1 := ProcessMessage(update1, peer1, time.Now(), false)[0]
 
 	d := NewDestination(nlri, 0)
-	d.Calculate(logger, path1)
+	d.Calculate(logger, path1, nil)
 
 	// suppose peer2 sends grammaatically correct but semantically flawed update message
 	// which has a withdrawal nlri not advertised before
@@ -82,7 +83,7 @@ func TestCalculate2(t *testing.T) {
 	path2 := ProcessMessage(update2, peer2, time.Now(), false)[0]
 	assert.Equal(t, path2.IsWithdraw, true)
 
-	d.Calculate(logger, path2)
+	d.Calculate(logger, path2, nil)
 
 	// we have a path from peer1 here
 	assert.Equal(t, len(d.knownPathList), 1)
@@ -92,7 +93,7 @@ func TestCalculate2(t *testing.T) {
 	path3 := ProcessMessage(update3, peer2, time.Now(), false)[0]
 	assert.Equal(t, path3.IsWithdraw, false)
 
-	d.Calculate(logger, path3)
+	d.Calculate(logger, path3, nil)
 
 	// this time, we have paths from peer1 and peer2
 	assert.Equal(t, len(d.knownPathList), 2)
@@ -102,7 +103,7 @@ func TestCalculate2(t *testing.T) {
 	update4 := bgp.NewBGPUpdateMessage(nil, pathAttributes, []bgp.PathNLRI{{NLRI: nlri}})
 	path4 := ProcessMessage(update4, peer3, time.Now(), false)[0]
 
-	d.Calculate(logger, path4)
+	d.Calculate(logger, path4, nil)
 
 	// we must have paths from peer1, peer2 and peer3
 	assert.Equal(t, len(d.knownPathList), 3)
@@ -146,7 +147,7 @@ func TestMedTieBreaker(t *testing.T) {
 	}()
 
 	// same AS
-	assert.Equal(t, compareByMED(p0, p1), p0)
+	assert.Equal(t, compareByMED(p0, p1, &SelectionOptions), p0)
 
 	p2 := func() *Path {
 		aspath := bgp.NewPathAttributeAsPath([]bgp.AsPathParamInterface{bgp.NewAs4PathParam(bgp.BGP_ASPATH_ATTR_TYPE_SEQ, []uint32{65003})})
@@ -155,7 +156,7 @@ func TestMedTieBreaker(t *testing.T) {
 	}()
 
 	// different AS
-	assert.Equal(t, compareByMED(p0, p2), (*Path)(nil))
+	assert.Equal(t, compareByMED(p0, p2, &SelectionOptions), (*Path)(nil))
 
 	p3 := func() *Path {
 		aspath := bgp.NewPathAttributeAsPath([]bgp.AsPathParamInterface{bgp.NewAs4PathParam(bgp.BGP_ASPATH_ATTR_TYPE_CONFED_SEQ, []uint32{65003, 65004}), bgp.NewAs4PathParam(bgp.BGP_ASPATH_ATTR_TYPE_SEQ, []uint32{65001, 65003})})
@@ -170,7 +171,7 @@ func TestMedTieBreaker(t *testing.T) {
 	}()
 
 	// ignore confed
-	assert.Equal(t, compareByMED(p3, p4), p3)
+	assert.Equal(t, compareByMED(p3, p4, &SelectionOptions), p3)
 
 	p5 := func() *Path {
 		attrs := []bgp.PathAttributeInterface{bgp.NewPathAttributeMultiExitDisc(0)}
@@ -183,7 +184,7 @@ func TestMedTieBreaker(t *testing.T) {
 	}()
 
 	// no aspath
-	assert.Equal(t, compareByMED(p5, p6), p5)
+	assert.Equal(t, compareByMED(p5, p6, &SelectionOptions), p5)
 }
 
 func TestTimeTieBreaker(t *testing.T) {
@@ -202,8 +203,8 @@ func TestTimeTieBreaker(t *testing.T) {
 	path2 := ProcessMessage(updateMsg, peer2, time.Now().Add(-1*time.Hour), false)[0]                                  // older than path1
 
 	d := NewDestination(nlri, 0)
-	d.Calculate(logger, path1)
-	d.Calculate(logger, path2)
+	d.Calculate(logger, path1, nil)
+	d.Calculate(logger, path2, nil)
 
 	assert.Equal(t, len(d.knownPathList), 2)
 	assert.Equal(t, true, d.GetBestPath("", 0).GetSource().ID == netip.MustParseAddr("2.2.2.2")) // path from peer2 win
@@ -211,8 +212,8 @@ func TestTimeTieBreaker(t *testing.T) {
 	// this option disables tie breaking by age
 	SelectionOptions.ExternalCompareRouterId = true
 	d = NewDestination(nlri, 0)
-	d.Calculate(logger, path1)
-	d.Calculate(logger, path2)
+	d.Calculate(logger, path1, nil)
+	d.Calculate(logger, path2, nil)
 
 	assert.Equal(t, len(d.knownPathList), 2)
 	assert.Equal(t, true, d.GetBestPath("", 0).GetSource().ID == netip.MustParseAddr("1.1.1.1")) // path from peer1 win
@@ -335,16 +336,16 @@ func TestMultipath(t *testing.T) {
 	path2 := ProcessMessage(updateMsg, peer2, time.Now(), false)[0]
 
 	d := NewDestination(nlri, 0)
-	d.Calculate(logger, path2)
+	d.Calculate(logger, path2, nil)
 
-	best, old, multi := d.Calculate(logger, path1).GetChanges(GLOBAL_RIB_NAME, 0, false)
+	best, old, multi := d.Calculate(logger, path1, nil).GetChanges(GLOBAL_RIB_NAME, 0, false)
 	assert.NotNil(t, best)
 	assert.Equal(t, old, path2)
 	assert.Equal(t, len(multi), 2)
 	assert.Equal(t, len(d.GetKnownPathList(GLOBAL_RIB_NAME, 0)), 2)
 
 	path3 := path2.Clone(true)
-	dd := d.Calculate(logger, path3)
+	dd := d.Calculate(logger, path3, nil)
 	best, old, multi = dd.GetChanges(GLOBAL_RIB_NAME, 0, false)
 	assert.Nil(t, best)
 	assert.Equal(t, old, path1)
@@ -362,7 +363,7 @@ func TestMultipath(t *testing.T) {
 	}
 	updateMsg = bgp.NewBGPUpdateMessage(nil, pathAttributes, []bgp.PathNLRI{{NLRI: nlri}})
 	path4 := ProcessMessage(updateMsg, peer3, time.Now(), false)[0]
-	dd = d.Calculate(logger, path4)
+	dd = d.Calculate(logger, path4, nil)
 	best, _, multi = dd.GetChanges(GLOBAL_RIB_NAME, 0, false)
 	assert.NotNil(t, best)
 	assert.Equal(t, len(multi), 1)
@@ -377,7 +378,7 @@ func TestMultipath(t *testing.T) {
 	}
 	updateMsg = bgp.NewBGPUpdateMessage(nil, pathAttributes, []bgp.PathNLRI{{NLRI: nlri}})
 	path5 := ProcessMessage(updateMsg, peer2, time.Now(), false)[0]
-	best, _, multi = d.Calculate(logger, path5).GetChanges(GLOBAL_RIB_NAME, 0, false)
+	best, _, multi = d.Calculate(logger, path5, nil).GetChanges(GLOBAL_RIB_NAME, 0, false)
 	assert.NotNil(t, best)
 	assert.Equal(t, len(multi), 2)
 	assert.Equal(t, len(d.GetKnownPathList(GLOBAL_RIB_NAME, 0)), 3)
@@ -444,7 +445,7 @@ func TestDestination_Calculate_ExplicitWithdraw(t *testing.T) {
 
 	// Test explicit withdraw
 	withdrawPath := NewPath(bgp.RF_IPv4_UC, peer1, bgp.PathNLRI{NLRI: nlri}, true, attrs, time.Now(), false)
-	update := d.Calculate(logger, withdrawPath)
+	update := d.Calculate(logger, withdrawPath, nil)
 
 	assert.Len(t, update.KnownPathList, 1)
 	assert.Equal(t, peer2.Address.String(), update.KnownPathList[0].GetSource().Address.String())
@@ -468,7 +469,7 @@ func TestDestination_Calculate_ImplicitWithdraw(t *testing.T) {
 		bgp.NewPathAttributeMultiExitDisc(100),
 	}
 	p2 := NewPath(bgp.RF_IPv4_UC, peer1, bgp.PathNLRI{NLRI: nlri}, false, newAttrs, time.Now(), false)
-	update := d.Calculate(logger, p2)
+	update := d.Calculate(logger, p2, nil)
 
 	assert.Len(t, update.KnownPathList, 1)
 	assert.Equal(t, uint32(100), update.KnownPathList[0].getPathAttr(bgp.BGP_ATTR_TYPE_MULTI_EXIT_DISC).(*bgp.PathAttributeMultiExitDisc).Value)
@@ -569,6 +570,27 @@ func TestCompareByLocalOrigin(t *testing.T) {
 	assert.Nil(t, result)
 }
 
+func TestCompareByWeight(t *testing.T) {
+	nlri, _ := bgp.NewIPAddrPrefix(netip.MustParsePrefix("10.0.0.0/24"))
+	peer1 := &PeerInfo{AS: 65001, LocalAS: 65000, Address: netip.MustParseAddr("1.1.1.1"), ID: netip.MustParseAddr("1.1.1.1")}
+	peer2 := &PeerInfo{AS: 65002, LocalAS: 65000, Address: netip.MustParseAddr("2.2.2.2"), ID: netip.MustParseAddr("2.2.2.2")}
+
+	p1 := NewPath(bgp.RF_IPv4_UC, peer1, bgp.PathNLRI{NLRI: nlri}, false, []bgp.PathAttributeInterface{bgp.NewPathAttributeLocalPref(200)}, time.Now(), false)
+	p2 := NewPath(bgp.RF_IPv4_UC, peer2, bgp.PathNLRI{NLRI: nlri}, false, []bgp.PathAttributeInterface{bgp.NewPathAttributeLocalPref(100)}, time.Now(), false)
+
+	assert.Nil(t, compareByWeight(p1, p2))
+
+	// the weight is preferred before the local preference
+	p2 = p2.Clone(false)
+	p2.SetWeight(10)
+	assert.Equal(t, p2, compareByWeight(p1, p2))
+
+	d := NewDestination(nlri, 0)
+	d.insertSort(p1, nil)
+	d.insertSort(p2, nil)
+	assert.Equal(t, p2, d.knownPathList[0])
+}
+
 func TestCompareByASPath_IgnoreLength(t *testing.T) {
 	oldIgnoreAsPathLength := SelectionOptions.IgnoreAsPathLength
 	defer func() {
@@ -590,13 +612,13 @@ func TestCompareByASPath_IgnoreLength(t *testing.T) {
 	p2 := NewPath(bgp.RF_IPv4_UC, nil, bgp.PathNLRI{NLRI: nlri}, false, attrs2, time.Now(), false)
 
 	SelectionOptions.IgnoreAsPathLength = false
-	result := compareByASPath(p1, p2)
+	result := compareByASPath(p1, p2, &SelectionOptions)
 	assert.Equal(t, result, p1)
-	result = compareByASPath(p2, p1)
+	result = compareByASPath(p2, p1, &SelectionOptions)
 	assert.Equal(t, result, p1)
 
 	SelectionOptions.IgnoreAsPathLength = true
-	result = compareByASPath(p1, p2)
+	result = compareByASPath(p1, p2, &SelectionOptions)
 	assert.Nil(t, result)
 }
 
@@ -622,14 +644,185 @@ func TestCompareByMED_AlwaysCompare(t *testing.T) {
 	p2 := NewPath(bgp.RF_IPv4_UC, nil, bgp.PathNLRI{NLRI: nlri}, false, attrs2, time.Now(), false)
 
 	SelectionOptions.AlwaysCompareMed = false
-	result := compareByMED(p1, p2)
+	result := compareByMED(p1, p2, &SelectionOptions)
 	assert.Nil(t, result)
 
 	SelectionOptions.AlwaysCompareMed = true
-	result = compareByMED(p1, p2)
+	result = compareByMED(p1, p2, &SelectionOptions)
 	assert.Equal(t, p1, result)
 }
 
+func newSelectionTestPath(nlri bgp.NLRI, id string, as uint32, segments []bgp.AsPathParamInterface, med ...uint32) *Path {
+	peer := &PeerInfo{AS: as, LocalAS: 65000, Address: netip.MustParseAddr(id), ID: netip.MustParseAddr(id)}
+	if segments == nil {
+		segments = []bgp.AsPathParamInterface{bgp.NewAs4PathParam(bgp.BGP_ASPATH_ATTR_TYPE_SEQ, []uint32{as})}
+	}
+	attrs := []bgp.PathAttributeInterface{bgp.NewPathAttributeOrigin(0), bgp.NewPathAttributeAsPath(segments)}
+	for _, m := range med {
+		attrs = append(attrs, bgp.NewPathAttributeMultiExitDisc(m))
+	}
+	return NewPath(bgp.RF_IPv4_UC, peer, bgp.PathNLRI{NLRI: nlri}, false, attrs, time.Now(), false)
+}
+
+func TestDeterministicMed(t *testing.T) {
+	nlri, _ := bgp.NewIPAddrPrefix(netip.MustParsePrefix("10.0.0.0/24"))
+	a := newSelectionTestPath(nlri, "1.1.1.1", 100, nil, 20)
+	b := newSelectionTestPath(nlri, "2.2.2.2", 200, nil, 10)
+	c := newSelectionTestPath(nlri, "3.3.3.3", 100, nil, 10)
+
+	calculate := func(opts *oc.RouteSelectionOptionsConfig, paths ...*Path) *Destination {
+		d := NewDestination(nlri, 0)
+		for _, p := range paths {
+			d.Calculate(logger, p, opts)
+		}
+		return d
+	}
+
+	// without deterministic MED, the best path depends on the order
+	opts := &oc.RouteSelectionOptionsConfig{ExternalCompareRouterId: true}
+	assert.Equal(t, a, calculate(opts, a, b, c).knownPathList[0])
+	assert.Equal(t, b, calculate(opts, b, c, a).knownPathList[0])
+
+	opts.DeterministicMed = true
+	for _, order := range [][]*Path{{a, b, c}, {b, c, a}, {c, a, b}} {
+		assert.Equal(t, []*Path{b, c, a}, calculate(opts, order...).knownPathList)
+	}
+}
+
+func TestCompareByMED_MissingAsWorst(t *testing.T) {
+	nlri, _ := bgp.NewIPAddrPrefix(netip.MustParsePrefix("10.0.0.0/24"))
+	p1 := newSelectionTestPath(nlri, "1.1.1.1", 100, nil)
+	p2 := newSelectionTestPath(nlri, "2.2.2.2", 100, nil, 10)
+
+	assert.Equal(t, p1, compareByMED(p1, p2, &oc.RouteSelectionOptionsConfig{}))
+	assert.Equal(t, p2, compareByMED(p1, p2, &oc.RouteSelectionOptionsConfig{MedMissingAsWorst: true}))
+}
+
+func TestCompareByMED_ConfedCompare(t *testing.T) {
+	nlri, _ := bgp.NewIPAddrPrefix(netip.MustParsePrefix("10.0.0.0/24"))
+	p1 := newSelectionTestPath(nlri, "1.1.1.1", 65010, []bgp.AsPathParamInterface{
+		bgp.NewAs4PathParam(bgp.BGP_ASPATH_ATTR_TYPE_CONFED_SEQ, []uint32{65010}),
+		bgp.NewAs4PathParam(bgp.BGP_ASPATH_ATTR_TYPE_SEQ, []uint32{100}),
+	}, 10)
+	p2 := newSelectionTestPath(nlri, "2.2.2.2", 65020, []bgp.AsPathParamInterface{
+		bgp.NewAs4PathParam(bgp.BGP_ASPATH_ATTR_TYPE_CONFED_SEQ, []uint32{65020}),
+		bgp.NewAs4PathParam(bgp.BGP_ASPATH_ATTR_TYPE_SEQ, []uint32{200}),
+	}, 5)
+	p3 := newSelectionTestPath(nlri, "3.3.3.3", 300, nil, 1)
+
+	assert.Nil(t, compareByMED(p1, p2, &oc.RouteSelectionOptionsConfig{}))
+	opts := &oc.RouteSelectionOptionsConfig{ConfedCompare: true}
+	assert.Equal(t, p2, compareByMED(p1, p2, opts))
+	// a path from outside the confederation isn't compared
+	assert.Nil(t, compareByMED(p1, p3, opts))
+}
+
+func TestMultipathWeight(t *testing.T) {
+	nlri, _ := bgp.NewIPAddrPrefix(netip.MustParsePrefix("10.0.0.0/24"))
+	newPath := func(id string, weight, localPref uint32) *Path {
+		p := newSelectionTestPath(nlri, id, 100, nil, 10)
+		p.setPathAttr(bgp.NewPathAttributeLocalPref(localPref))
+		p.SetWeight(weight)
+		return p
+	}
+	p1 := newPath("1.1.1.1", 100, 100)
+	p2 := newPath("2.2.2.2", 50, 200)
+	p3 := newPath("3.3.3.3", 50, 100)
+	p4 := newPath("4.4.4.4", 100, 100)
+
+	opts := &oc.RouteSelectionOptionsConfig{}
+	d := NewDestination(nlri, 0)
+	for _, p := range []*Path{p1, p2, p3, p4} {
+		d.Calculate(logger, p, opts)
+	}
+	assert.Equal(t, []*Path{p1, p4, p2, p3}, d.knownPathList)
+	// the paths with a lower weight aren't as good as the best path
+	// even if the other attributes are the same
+	assert.Equal(t, []*Path{p1, p4}, getMultiBestPath(GLOBAL_RIB_NAME, d.knownPathList, opts))
+	assert.True(t, IsMultiPath(p1, p4, opts))
+	assert.False(t, IsMultiPath(p1, p3, opts))
+}
+
+func TestMultipathAsPathMatch(t *testing.T) {
+	nlri, _ := bgp.NewIPAddrPrefix(netip.MustParsePrefix("10.0.0.0/24"))
+	p1 := newSelectionTestPath(nlri, "1.1.1.1", 100, nil, 10)
+	p2 := newSelectionTestPath(nlri, "2.2.2.2", 200, nil, 10)
+	p3 := newSelectionTestPath(nlri, "3.3.3.3", 100, nil, 10)
+	pathList := []*Path{p1, p2, p3}
+
+	opts := &oc.RouteSelectionOptionsConfig{}
+	assert.Equal(t, pathList, getMultiBestPath(GLOBAL_RIB_NAME, pathList, opts))
+	assert.True(t, IsMultiPath(p1, p2, opts))
+	assert.True(t, IsMultiPath(p1, p2, nil))
+
+	opts.MultipathAsPathMatch = true
+	assert.Equal(t, []*Path{p1, p3}, getMultiBestPath(GLOBAL_RIB_NAME, pathList, opts))
+	assert.False(t, IsMultiPath(p1, p2, opts))
+
+	// multipath-relax overrides multipath-as-path-match
+	opts.MultipathRelax = true
+	assert.Equal(t, pathList, getMultiBestPath(GLOBAL_RIB_NAME, pathList, opts))
+	assert.True(t, IsMultiPath(p1, p2, opts))
+}
+
+func TestMultipathRelax(t *testing.T) {
+	nlri, _ := bgp.NewIPAddrPrefix(netip.MustParsePrefix("10.0.0.0/24"))
+	p1 := newSelectionTestPath(nlri, "1.1.1.1", 100, nil, 10)
+	p2 := newSelectionTestPath(nlri, "2.2.2.2", 200, nil, 20)
+	p3 := newSelectionTestPath(nlri, "3.3.3.3", 100, nil, 20)
+	p4 := newSelectionTestPath(nlri, "4.4.4.4", 300, []bgp.AsPathParamInterface{
+		bgp.NewAs4PathParam(bgp.BGP_ASPATH_ATTR_TYPE_SEQ, []uint32{300, 400}),
+	}, 10)
+
+	opts := &oc.RouteSelectionOptionsConfig{}
+	d := NewDestination(nlri, 0)
+	for _, p := range []*Path{p1, p2, p3, p4} {
+		d.Calculate(logger, p, opts)
+	}
+	assert.Equal(t, []*Path{p1, p2, p3, p4}, d.knownPathList)
+	// the paths from different neighbor ASes need the same MED
+	assert.Equal(t, []*Path{p1}, getMultiBestPath(GLOBAL_RIB_NAME, d.knownPathList, opts))
+
+	// only the AS path length matters among different neighbor ASes
+	opts.MultipathRelax = true
+	assert.Equal(t, []*Path{p1, p2}, getMultiBestPath(GLOBAL_RIB_NAME, d.knownPathList, opts))
+	assert.False(t, IsMultiPath(p1, p3, opts))
+	assert.False(t, IsMultiPath(p1, p4, opts))
+
+	// multipath follows the options of the selection
+	opts.IgnoreAsPathLength = true
+	assert.True(t, IsMultiPath(p1, p4, opts))
+	opts.AlwaysCompareMed = true
+	assert.False(t, IsMultiPath(p1, p2, opts))
+	assert.True(t, IsMultiPath(p1, p4, opts))
+}
+
+func TestBestPathReasons(t *testing.T) {
+	nlri, _ := bgp.NewIPAddrPrefix(netip.MustParsePrefix("10.0.0.0/24"))
+	p1 := newSelectionTestPath(nlri, "1.1.1.1", 100, nil, 10)
+	p2 := newSelectionTestPath(nlri, "2.2.2.2", 100, nil, 20)
+	p3 := newSelectionTestPath(nlri, "3.3.3.3", 300, []bgp.AsPathParamInterface{
+		bgp.NewAs4PathParam(bgp.BGP_ASPATH_ATTR_TYPE_SEQ, []uint32{300, 400}),
+	})
+
+	opts := &oc.RouteSelectionOptionsConfig{}
+	d := NewDestination(nlri, 0)
+	d.Calculate(logger, p1, opts)
+	assert.Equal(t, map[*Path]BestPathReason{p1: BPR_ONLY_PATH}, d.BestPathReasons(opts))
+
+	d.Calculate(logger, p2, opts)
+	d.Calculate(logger, p3, opts)
+	assert.Equal(t, []*Path{p1, p2, p3}, d.knownPathList)
+	assert.Equal(t, map[*Path]BestPathReason{
+		p1: BPR_MED,
+		p2: BPR_MED,
+		p3: BPR_ASPATH,
+	}, d.BestPathReasons(opts))
+
+	opts.DisableBestPathSelection = true
+	assert.Equal(t, BPR_DISABLED, d.BestPathReasons(opts)[p1])
+}
+
 func BenchmarkMultiPath(b *testing.B) {
 	b.StopTimer()
 	nlri, _ := bgp.NewIPAddrPrefix(netip.MustParsePrefix("10.10.0.0/24"))
@@ -656,7 +849,7 @@ func BenchmarkMultiPath(b *testing.B) {
 			d := NewDestination(nlri, 0)
 			b.StartTimer()
 			for j := range pathList {
-				d.Calculate(logger, pathList[j])
+				d.Calculate(logger, pathList[j], nil)
 			}
 			b.StopTimer()
 		}
@@ -665,16 +858,121 @@ func BenchmarkMultiPath(b *testing.B) {
 	b.Run("Benchmark GetMultiBestPath", func(b *testing.B) {
 		d := NewDestination(nlri, 0)
 		for j := range pathList {
-			d.Calculate(logger, pathList[j])
+			d.Calculate(logger, pathList[j], nil)
 		}
 		for range b.N {
 			b.StartTimer()
-			d.GetMultiBestPath(GLOBAL_RIB_NAME)
+			d.GetMultiBestPath(GLOBAL_RIB_NAME, nil)
 			b.StopTimer()
 		}
 	})
 }
 
+func newAlternativeTestPath(nlri bgp.NLRI, id string, as, localPref uint32, nexthop string, clusters ...string) *Path {
+	peer := &PeerInfo{AS: as, LocalAS: 65000, Address: netip.MustParseAddr(id), ID: netip.MustParseAddr(id)}
+	nh, _ := bgp.NewPathAttributeNextHop(netip.MustParseAddr(nexthop))
+	attrs := []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(0),
+		bgp.NewPathAttributeAsPath(nil),
+		nh,
+		bgp.NewPathAttributeLocalPref(localPref),
+	}
+	if len(clusters) > 0 {
+		l := make([]netip.Addr, 0, len(clusters))
+		for _, c := range clusters {
+			l = append(l, netip.MustParseAddr(c))
+		}
+		cl, _ := bgp.NewPathAttributeClusterList(l)
+		attrs = append(attrs, cl)
+	}
+	return NewPath(bgp.RF_IPv4_UC, peer, bgp.PathNLRI{NLRI: nlri}, false, attrs, time.Now(), false)
+}
+
+func TestAlternativePath_BestExternal(t *testing.T) {
+	nlri, _ := bgp.NewIPAddrPrefix(netip.MustParsePrefix("10.0.0.0/24"))
+	i1 := newAlternativeTestPath(nlri, "1.1.1.1", 65000, 200, "192.168.0.1")
+	i2 := newAlternativeTestPath(nlri, "2.2.2.2", 65000, 150, "192.168.0.2")
+	e1 := newAlternativeTestPath(nlri, "3.3.3.3", 100, 100, "192.168.0.3")
+	e2 := newAlternativeTestPath(nlri, "4.4.4.4", 200, 50, "192.168.0.4")
+
+	alt := ALTERNATIVE_PATH_BEST_EXTERNAL
+	assert.Equal(t, e1, getAlternativePath(alt, []*Path{i1, i2, e1, e2}, nil))
+	// the best path is advertised if it's eBGP, or if no eBGP path exists
+	assert.Equal(t, e1, getAlternativePath(alt, []*Path{e1, i1, e2}, nil))
+	assert.Equal(t, i1, getAlternativePath(alt, []*Path{i1, i2}, nil))
+	assert.Nil(t, getAlternativePath(alt, nil, nil))
+
+	// the best path is advertised if it can be reflected to the neighbor
+	client := &PeerInfo{AS: 65000, LocalAS: 65000, Address: netip.MustParseAddr("5.5.5.5"), RouteReflectorClient: true}
+	assert.Equal(t, i1, getAlternativePath(alt, []*Path{i1, i2, e1}, client))
+	ebgp := &PeerInfo{AS: 300, LocalAS: 65000, Address: netip.MustParseAddr("6.6.6.6")}
+	assert.Equal(t, i1, getAlternativePath(alt, []*Path{i1, i2, e1}, ebgp))
+	nonClient := &PeerInfo{AS: 65000, LocalAS: 65000, Address: netip.MustParseAddr("7.7.7.7")}
+	assert.Equal(t, e1, getAlternativePath(alt, []*Path{i1, i2, e1}, nonClient))
+	// but not back to its source
+	source := &PeerInfo{AS: 65000, LocalAS: 65000, Address: netip.MustParseAddr("1.1.1.1"), RouteReflectorClient: true}
+	assert.Equal(t, e1, getAlternativePath(alt, []*Path{i1, i2, e1}, source))
+
+	// an unreachable path isn't advertised
+	e1.IsNexthopInvalid = true
+	assert.Equal(t, i1, getAlternativePath(alt, []*Path{i1, i2, e1}, nil))
+}
+
+func TestAlternativePath_Diverse(t *testing.T) {
+	nlri, _ := bgp.NewIPAddrPrefix(netip.MustParsePrefix("10.0.0.0/24"))
+	i1 := newAlternativeTestPath(nlri, "1.1.1.1", 65000, 200, "192.168.0.1", "10.0.0.1")
+	i2 := newAlternativeTestPath(nlri, "2.2.2.2", 65000, 150, "192.168.0.1", "10.0.0.1")
+	i3 := newAlternativeTestPath(nlri, "3.3.3.3", 65000, 100, "192.168.0.1", "10.0.0.2")
+	i4 := newAlternativeTestPath(nlri, "4.4.4.4", 65000, 100, "192.168.0.4", "10.0.0.1")
+
+	alt := ALTERNATIVE_PATH_DIVERSE
+	// a different cluster
+	assert.Equal(t, i3, getAlternativePath(alt, []*Path{i1, i2, i3}, nil))
+	// a different next hop
+	assert.Equal(t, i4, getAlternativePath(alt, []*Path{i1, i2, i4}, nil))
+	// no diverse path
+	assert.Nil(t, getAlternativePath(alt, []*Path{i1, i2}, nil))
+	assert.Nil(t, getAlternativePath(alt, []*Path{i1}, nil))
+}
+
+func TestUpdate_GetAlternativeChanges(t *testing.T) {
+	nlri, _ := bgp.NewIPAddrPrefix(netip.MustParsePrefix("10.0.0.0/24"))
+	i1 := newAlternativeTestPath(nlri, "1.1.1.1", 65000, 200, "192.168.0.1")
+	e1 := newAlternativeTestPath(nlri, "2.2.2.2", 100, 100, "192.168.0.2")
+	e2 := newAlternativeTestPath(nlri, "3.3.3.3", 200, 50, "192.168.0.3")
+
+	u := &Update{OldKnownPathList: []*Path{i1}, KnownPathList: []*Path{i1, e1}}
+	best, old := u.GetAlternativeChanges(GLOBAL_RIB_NAME, 0, ALTERNATIVE_PATH_BEST_EXTERNAL, nil)
+	assert.Equal(t, e1, best)
+	assert.Equal(t, i1, old)
+
+	u = &Update{OldKnownPathList: []*Path{i1, e1}, KnownPathList: []*Path{i1, e1, e2}}
+	best, old = u.GetAlternativeChanges(GLOBAL_RIB_NAME, 0, ALTERNATIVE_PATH_BEST_EXTERNAL, nil)
+	assert.Nil(t, best)
+	assert.Equal(t, e1, old)
+
+	// the diverse path is withdrawn when none is left
+	u = &Update{OldKnownPathList: []*Path{i1, e1}, KnownPathList: []*Path{i1}}
+	best, old = u.GetAlternativeChanges(GLOBAL_RIB_NAME, 0, ALTERNATIVE_PATH_DIVERSE, nil)
+	assert.True(t, best.IsWithdraw)
+	assert.Equal(t, e1.GetLocalKey(), best.GetLocalKey())
+	assert.Equal(t, e1, old)
+
+	// the paths are filtered as for the best path
+	u = &Update{OldKnownPathList: []*Path{i1}, KnownPathList: []*Path{i1, e1, e2}}
+	best, _ = u.GetAlternativeChanges("2.2.2.2", 0, ALTERNATIVE_PATH_BEST_EXTERNAL, nil)
+	assert.Equal(t, e2, best)
+
+	// the alternative path is withdrawn when its next hop becomes
+	// unreachable
+	e1Invalid := e1.Clone(false)
+	e1Invalid.IsNexthopInvalid = true
+	u = &Update{OldKnownPathList: []*Path{e1}, KnownPathList: []*Path{e1Invalid}}
+	best, old = u.GetAlternativeChanges(GLOBAL_RIB_NAME, 0, ALTERNATIVE_PATH_BEST_EXTERNAL, nil)
+	assert.True(t, best.IsWithdraw)
+	assert.Equal(t, e1, old)
+}
+
 func TestDestination_Calculate_AddAndWithdrawPath(t *testing.T) {
 	attrs := []bgp.PathAttributeInterface{
 		bgp.NewPathAttributeOrigin(0),
@@ -689,7 +987,7 @@ func TestDestination_Calculate_AddAndWithdrawPath(t *testing.T) {
 
 	nlri, _ = bgp.NewIPAddrPrefix(netip.MustParsePrefix("13.2.6.0/24"))
 	p4 := NewPath(bgp.RF_IPv4_UC, nil, bgp.PathNLRI{NLRI: nlri}, false, attrs, time.Now(), false)
-	update := d.Calculate(logger, p4)
+	update := d.Calculate(logger, p4, nil)
 	assert.Len(t, update.KnownPathList, 3)
 	assert.Len(t, update.KnownPathList, 3)
 	assert.NotEqualValues(t, update.OldKnownPathList, update.KnownPathList)
@@ -700,7 +998,7 @@ func TestDestination_Calculate_AddAndWithdrawPath(t *testing.T) {
 	nlri, _ = bgp.NewIPAddrPrefix(netip.MustParsePrefix("13.2.3.0/24"))
 	p1 = NewPath(bgp.RF_IPv4_UC, nil, bgp.PathNLRI{NLRI: nlri}, false, attrs, time.Now(), true)
 	d = NewDestination(nlri, 0, p1, p2, p3)
-	update = d.Calculate(logger, p4)
+	update = d.Calculate(logger, p4, nil)
 	assert.Len(t, update.KnownPathList, 3)
 	assert.Len(t, update.KnownPathList, 3)
 	assert.NotEqualValues(t, update.OldKnownPathList, update.KnownPathList)
@@ -712,7 +1010,7 @@ func TestDestination_Calculate_AddAndWithdrawPath(t *testing.T) {
 	nlri, _ = bgp.NewIPAddrPrefix(netip.MustParsePrefix("13.2.8.0/24"))
 	p5 := NewPath(bgp.RF_IPv4_UC, nil, bgp.PathNLRI{NLRI: nlri}, false, attrs, time.Now(), false)
 	d = NewDestination(nlri, 0, p1, p2, p3, p5)
-	update = d.Calculate(logger, p4)
+	update = d.Calculate(logger, p4, nil)
 
 	assert.Len(t, update.KnownPathList, 4)
 	assert.Len(t, update.KnownPathList, 4)
@@ -311,8 +311,18 @@ func (p *packerMP) add(path *Path) {
 
 func createMPReachMessage(path *Path) *bgp.BGPMessage {
 	oattrs := path.GetPathAttrs()
-	attrs := make([]bgp.PathAttributeInterface, 0, len(oattrs))
+	attrs := make([]bgp.PathAttributeInterface, 0, len(oattrs)+1)
+	hasMP := path.getPathAttr(bgp.BGP_ATTR_TYPE_MP_REACH_NLRI) != nil
+	if !hasMP {
+		// IPv4 unicast paths carrying BGPsec_PATH have to be sent with
+		// MP_REACH_NLRI (RFC 8205 section 3), in place of NEXT_HOP.
+		attr, _ := bgp.NewPathAttributeMpReachNLRI(path.GetFamily(), []bgp.PathNLRI{{NLRI: path.GetNlri(), ID: path.localID}}, path.GetNexthop())
+		attrs = append(attrs, attr)
+	}
 	for _, a := range oattrs {
+		if a.GetType() == bgp.BGP_ATTR_TYPE_NEXT_HOP && !hasMP {
+			continue
+		}
 		if a.GetType() == bgp.BGP_ATTR_TYPE_MP_REACH_NLRI {
 			// For netlink routes, preserve the existing MP_REACH_NLRI which contains
 			// both global and link-local nexthops set by UpdatePathAttrs().
@@ -385,6 +395,11 @@ func (p *packerV4) add(path *Path) {
 		return
 	}
 
+	if path.getPathAttr(bgp.BGP_ATTR_TYPE_BGPSEC_PATH) != nil {
+		p.mpPaths = append(p.mpPaths, path)
+		return
+	}
+
 	key := path.GetHash()
 	attrsB := bytes.NewBuffer(make([]byte, 0))
 	for _, v := range path.GetPathAttrs() {
@@ -0,0 +1,94 @@
+// Copyright (C) 2025 Acnodal Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package table
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
+)
+
+// PrefixORF is the Address Prefix ORF (RFC 5292) list of one address
+// family, kept sorted by sequence number.
+type PrefixORF struct {
+	entries []*bgp.AddressPrefixORFEntry
+}
+
+func NewPrefixORF() *PrefixORF {
+	return &PrefixORF{}
+}
+
+// Update applies the ADD, REMOVE and REMOVE-ALL actions of the given
+// entries in order.
+func (o *PrefixORF) Update(entries []*bgp.AddressPrefixORFEntry) {
+	for _, e := range entries {
+		switch e.Action {
+		case bgp.ORF_ACTION_REMOVE_ALL:
+			o.entries = nil
+		case bgp.ORF_ACTION_ADD:
+			i, found := slices.BinarySearchFunc(o.entries, e.Sequence, func(x *bgp.AddressPrefixORFEntry, seq uint32) int {
+				return cmp.Compare(x.Sequence, seq)
+			})
+			if found {
+				o.entries[i] = e
+			} else {
+				o.entries = slices.Insert(o.entries, i, e)
+			}
+		case bgp.ORF_ACTION_REMOVE:
+			o.entries = slices.DeleteFunc(o.entries, func(x *bgp.AddressPrefixORFEntry) bool {
+				return x.Sequence == e.Sequence && x.Match == e.Match && x.Prefix == e.Prefix && x.MinLen == e.MinLen && x.MaxLen == e.MaxLen
+			})
+		}
+	}
+}
+
+func (o *PrefixORF) Clone() *PrefixORF {
+	return &PrefixORF{entries: o.Entries()}
+}
+
+func (o *PrefixORF) Entries() []*bgp.AddressPrefixORFEntry {
+	if o == nil {
+		return nil
+	}
+	return slices.Clone(o.entries)
+}
+
+func (o *PrefixORF) Len() int {
+	if o == nil {
+		return 0
+	}
+	return len(o.entries)
+}
+
+// Permit reports whether the path may be advertised. An empty list
+// permits everything; otherwise the first matching entry decides and a
+// path matching no entry is denied.
+func (o *PrefixORF) Permit(path *Path) bool {
+	if o.Len() == 0 || path == nil {
+		return true
+	}
+	nlri, ok := path.GetNlri().(*bgp.IPAddrPrefix)
+	if !ok {
+		return true
+	}
+	for _, e := range o.entries {
+		if e.Matches(nlri.Prefix) {
+			return e.Match == bgp.ORF_MATCH_PERMIT
+		}
+	}
+	return false
+}
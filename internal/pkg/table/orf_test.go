@@ -0,0 +1,70 @@
+// Copyright (C) 2025 Acnodal Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package table
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrefixORF(t *testing.T) {
+	assert := assert.New(t)
+
+	newPath := func(prefix string) *Path {
+		nlri, _ := bgp.NewIPAddrPrefix(netip.MustParsePrefix(prefix))
+		return NewPath(bgp.RF_IPv4_UC, &PeerInfo{}, bgp.PathNLRI{NLRI: nlri}, false, []bgp.PathAttributeInterface{bgp.NewPathAttributeOrigin(0)}, time.Now(), false)
+	}
+
+	o := NewPrefixORF()
+	// empty list permits everything
+	assert.True(o.Permit(newPath("10.0.0.0/24")))
+
+	o.Update([]*bgp.AddressPrefixORFEntry{
+		bgp.NewAddressPrefixORFEntry(bgp.ORF_ACTION_ADD, bgp.ORF_MATCH_PERMIT, 20, 0, 24, netip.MustParsePrefix("10.0.0.0/8")),
+		bgp.NewAddressPrefixORFEntry(bgp.ORF_ACTION_ADD, bgp.ORF_MATCH_DENY, 10, 0, 0, netip.MustParsePrefix("10.1.0.0/16")),
+	})
+	assert.Equal(2, o.Len())
+	assert.Equal(uint32(10), o.Entries()[0].Sequence)
+
+	assert.True(o.Permit(newPath("10.0.0.0/24")))
+	assert.False(o.Permit(newPath("10.1.0.0/16")))
+	// implicit deny
+	assert.False(o.Permit(newPath("192.168.0.0/24")))
+
+	// replace the entry with the same sequence
+	o.Update([]*bgp.AddressPrefixORFEntry{
+		bgp.NewAddressPrefixORFEntry(bgp.ORF_ACTION_ADD, bgp.ORF_MATCH_PERMIT, 10, 0, 0, netip.MustParsePrefix("10.1.0.0/16")),
+	})
+	assert.Equal(2, o.Len())
+	assert.True(o.Permit(newPath("10.1.0.0/16")))
+
+	o.Update([]*bgp.AddressPrefixORFEntry{
+		bgp.NewAddressPrefixORFEntry(bgp.ORF_ACTION_REMOVE, bgp.ORF_MATCH_PERMIT, 20, 0, 24, netip.MustParsePrefix("10.0.0.0/8")),
+	})
+	assert.Equal(1, o.Len())
+	assert.False(o.Permit(newPath("10.0.0.0/24")))
+
+	o.Update([]*bgp.AddressPrefixORFEntry{
+		bgp.NewAddressPrefixORFEntry(bgp.ORF_ACTION_REMOVE_ALL, bgp.ORF_MATCH_PERMIT, 0, 0, 0, netip.Prefix{}),
+	})
+	assert.Equal(0, o.Len())
+	assert.True(o.Permit(newPath("192.168.0.0/24")))
+}
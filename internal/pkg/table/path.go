@@ -143,6 +143,9 @@ type Path struct {
 	rejected  bool
 	// doesn't exist in the adj
 	dropped bool
+	// local to the router, set by the policy
+	weight uint32
+	tag    uint32
 
 	// For BGP Nexthop Tracking, this field shows if nexthop is invalidated by IGP.
 	IsNexthopInvalid bool
@@ -436,6 +439,8 @@ func (path *Path) Clone(isWithdraw bool) *Path {
 		attrsHash:        path.attrsHash,
 		localID:          path.localID,
 		remoteID:         path.remoteID,
+		weight:           path.weight,
+		tag:              path.tag,
 	}
 }
 
@@ -748,6 +753,14 @@ func (path *Path) GetAsPath() *bgp.PathAttributeAsPath {
 	return nil
 }
 
+func (path *Path) GetBGPsecPath() *bgp.PathAttributeBGPsecPath {
+	attr := path.getPathAttr(bgp.BGP_ATTR_TYPE_BGPSEC_PATH)
+	if attr != nil {
+		return attr.(*bgp.PathAttributeBGPsecPath)
+	}
+	return nil
+}
+
 // GetAsPathLen returns the number of AS_PATH
 func (path *Path) GetAsPathLen() int {
 	length := 0
@@ -895,6 +908,50 @@ func (path *Path) RemovePrivateAS(localAS uint32, option oc.RemovePrivateAsOptio
 	}
 }
 
+// ReplaceASList replaces the AS numbers in the list, or all of them if
+// the list is empty, with the AS number.
+func (path *Path) ReplaceASList(list []uint32, as uint32) {
+	original := path.GetAsPath()
+	if original == nil {
+		return
+	}
+	newASParams := make([]bgp.AsPathParamInterface, 0, len(original.Value))
+	for _, param := range original.Value {
+		asList := param.GetAS()
+		newASParam := make([]uint32, 0, len(asList))
+		for _, a := range asList {
+			if len(list) == 0 || slices.Contains(list, a) {
+				a = as
+			}
+			newASParam = append(newASParam, a)
+		}
+		newASParams = append(newASParams, bgp.NewAs4PathParam(param.GetType(), newASParam))
+	}
+	path.setPathAttr(bgp.NewPathAttributeAsPath(newASParams))
+}
+
+// ExcludeAS removes the AS numbers in the list from the AS-path.
+func (path *Path) ExcludeAS(list []uint32) {
+	original := path.GetAsPath()
+	if original == nil {
+		return
+	}
+	newASParams := make([]bgp.AsPathParamInterface, 0, len(original.Value))
+	for _, param := range original.Value {
+		asList := param.GetAS()
+		newASParam := make([]uint32, 0, len(asList))
+		for _, a := range asList {
+			if !slices.Contains(list, a) {
+				newASParam = append(newASParam, a)
+			}
+		}
+		if len(newASParam) > 0 {
+			newASParams = append(newASParams, bgp.NewAs4PathParam(param.GetType(), newASParam))
+		}
+	}
+	path.setPathAttr(bgp.NewPathAttributeAsPath(newASParams))
+}
+
 func (path *Path) removeConfedAs() {
 	original := path.GetAsPath()
 	if original == nil {
@@ -1140,6 +1197,63 @@ func (path *Path) GetLocalPref() (uint32, error) {
 	return lp, nil
 }
 
+// GetWeight returns the weight local to the router, set by the policy.
+func (path *Path) GetWeight() uint32 {
+	return path.weight
+}
+
+func (path *Path) SetWeight(weight uint32) {
+	path.weight = weight
+}
+
+// GetTag returns the route tag local to the router, set by the policy
+// and exported to the routing table of the kernel or zebra.
+func (path *Path) GetTag() uint32 {
+	return path.tag
+}
+
+func (path *Path) SetTag(tag uint32) {
+	path.tag = tag
+}
+
+func (path *Path) GetAigp() (uint64, bool) {
+	if attr := path.getPathAttr(bgp.BGP_ATTR_TYPE_AIGP); attr != nil {
+		for _, tlv := range attr.(*bgp.PathAttributeAigp).Values {
+			if m, ok := tlv.(*bgp.AigpTLVIgpMetric); ok {
+				return m.Metric, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// SetAigp replaces the AIGP metric, or adds the value to the one of the
+// path. The path without AIGP is left as it is unless replaced.
+func (path *Path) SetAigp(metric int64, doReplace bool) error {
+	if doReplace {
+		path.setPathAttr(bgp.NewPathAttributeAigp([]bgp.AigpTLVInterface{bgp.NewAigpTLVIgpMetric(uint64(metric))}))
+		return nil
+	}
+	org, ok := path.GetAigp()
+	if !ok {
+		return nil
+	}
+	var v uint64
+	if metric < 0 {
+		if uint64(-metric) > org {
+			return fmt.Errorf("aigp value invalid. it's underflow threshold: %d%d", org, metric)
+		}
+		v = org - uint64(-metric)
+	} else {
+		if uint64(metric) > math.MaxUint64-org {
+			return fmt.Errorf("aigp value invalid. it's overflow threshold: %d+%d", org, metric)
+		}
+		v = org + uint64(metric)
+	}
+	path.setPathAttr(bgp.NewPathAttributeAigp([]bgp.AigpTLVInterface{bgp.NewAigpTLVIgpMetric(v)}))
+	return nil
+}
+
 func (lhs *Path) EqualBySourceAndPathID(rhs *Path) bool {
 	if rhs == nil {
 		return false
@@ -1175,6 +1289,9 @@ func (lhs *Path) Equal(rhs *Path) bool {
 	if !lhs.GetSource().Equal(rhs.GetSource()) {
 		return false
 	}
+	if lhs.weight != rhs.weight || lhs.tag != rhs.tag {
+		return false
+	}
 	// The idea here is to calculate the hash of the attributes on demand
 	if lhs.attrsHash > 0 && rhs.attrsHash > 0 { // direct access to the hash to avoid unnecessary hash calculation
 		return lhs.attrsHash == rhs.attrsHash
@@ -1224,45 +1341,6 @@ func (path *Path) MarshalJSON() ([]byte, error) {
 	})
 }
 
-// Return > 0 if lhs is preferred over the rhs
-// Return 0 if they are equal
-// Return < 0 if rhs is preferred over the lhs
-func (lhs *Path) Compare(rhs *Path) int {
-	if lhs.IsLocal() && !rhs.IsLocal() {
-		return 1
-	} else if !lhs.IsLocal() && rhs.IsLocal() {
-		return -1
-	}
-
-	if !lhs.IsIBGP() && rhs.IsIBGP() {
-		return 1
-	} else if lhs.IsIBGP() && !rhs.IsIBGP() {
-		return -1
-	}
-
-	lp1, _ := lhs.GetLocalPref()
-	lp2, _ := rhs.GetLocalPref()
-	if lp1 != lp2 {
-		return int(lp1) - int(lp2)
-	}
-
-	l1 := lhs.GetAsPathLen()
-	l2 := rhs.GetAsPathLen()
-	if l1 != l2 {
-		return l2 - l1
-	}
-
-	o1, _ := lhs.GetOrigin()
-	o2, _ := rhs.GetOrigin()
-	if o1 != o2 {
-		return int(o2) - int(o1)
-	}
-
-	m1, _ := lhs.GetMed()
-	m2, _ := rhs.GetMed()
-	return int(m2) - int(m1)
-}
-
 func (v *Vrf) ToGlobalPath(path *Path) error {
 	nlri := path.GetNlri()
 	nh := path.GetNexthop()
@@ -1382,6 +1460,8 @@ func (p *Path) ToGlobal(vrf *Vrf) *Path {
 	path.setPathAttr(attr)
 	path.localID = p.localID
 	path.remoteID = p.remoteID
+	path.weight = p.weight
+	path.tag = p.tag
 	return path
 }
 
@@ -1434,6 +1514,8 @@ func (p *Path) ToLocal() *Path {
 	path.IsNexthopInvalid = p.IsNexthopInvalid
 	path.localID = p.localID
 	path.remoteID = p.remoteID
+	path.weight = p.weight
+	path.tag = p.tag
 	return path
 }
 
@@ -19,6 +19,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"maps"
+	"math"
 	"net"
 	"net/netip"
 	"reflect"
@@ -28,6 +30,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/k-sone/critbitgo"
 	"github.com/osrg/gobgp/v4/api"
@@ -39,6 +42,15 @@ type PolicyOptions struct {
 	Info       *PeerInfo
 	OldNextHop net.IP
 	Validate   func(*Path) *Validation
+	// ValidateBGPsec returns the BGPsec validation state of the path.
+	ValidateBGPsec func(*Path) oc.BgpsecValidationResultType
+	// NoCount leaves the hit counters alone, for the evaluations which
+	// don't decide on the paths received or sent.
+	NoCount bool
+	// SharedWith is the ids of the assignments of the other peers the
+	// decision is shared with, like the members of an update group. The
+	// decision is counted for each of them as well.
+	SharedWith []string
 }
 
 type DefinedType int
@@ -173,8 +185,71 @@ const (
 	CONDITION_ORIGIN
 	CONDITION_LOCAL_PREF_EQ
 	CONDITION_MED_EQ
+	CONDITION_BGPSEC
+	CONDITION_CALL_POLICY
+	CONDITION_ORIGIN_AS
+	CONDITION_NEIGHBOR_AS
+	CONDITION_PREFIX_LENGTH
+	CONDITION_SOURCE_PEER_TYPE
+	CONDITION_PATH_AGE
+	CONDITION_AIGP
+	CONDITION_ATTRIBUTE_PRESENT
 )
 
+func (t ConditionType) String() string {
+	switch t {
+	case CONDITION_PREFIX:
+		return "prefix-set"
+	case CONDITION_NEIGHBOR:
+		return "neighbor-set"
+	case CONDITION_AS_PATH:
+		return "as-path-set"
+	case CONDITION_COMMUNITY:
+		return "community-set"
+	case CONDITION_EXT_COMMUNITY:
+		return "ext-community-set"
+	case CONDITION_AS_PATH_LENGTH:
+		return "as-path-length"
+	case CONDITION_RPKI:
+		return "rpki-validation-result"
+	case CONDITION_ROUTE_TYPE:
+		return "route-type"
+	case CONDITION_LARGE_COMMUNITY:
+		return "large-community-set"
+	case CONDITION_NEXT_HOP:
+		return "next-hop-in-list"
+	case CONDITION_AFI_SAFI_IN:
+		return "afi-safi-in"
+	case CONDITION_COMMUNITY_COUNT:
+		return "community-count"
+	case CONDITION_ORIGIN:
+		return "origin-eq"
+	case CONDITION_LOCAL_PREF_EQ:
+		return "local-pref-eq"
+	case CONDITION_MED_EQ:
+		return "med-eq"
+	case CONDITION_BGPSEC:
+		return "bgpsec-validation-result"
+	case CONDITION_CALL_POLICY:
+		return "call-policy"
+	case CONDITION_ORIGIN_AS:
+		return "origin-as-in-list"
+	case CONDITION_NEIGHBOR_AS:
+		return "neighbor-as-in-list"
+	case CONDITION_PREFIX_LENGTH:
+		return "prefix-length-range"
+	case CONDITION_SOURCE_PEER_TYPE:
+		return "source-peer-type-list"
+	case CONDITION_PATH_AGE:
+		return "path-age"
+	case CONDITION_AIGP:
+		return "aigp-range"
+	case CONDITION_ATTRIBUTE_PRESENT:
+		return "attribute-type-present"
+	}
+	return fmt.Sprintf("unknown(%d)", t)
+}
+
 type ActionType int
 
 const (
@@ -187,8 +262,53 @@ const (
 	ACTION_LOCAL_PREF
 	ACTION_LARGE_COMMUNITY
 	ACTION_ORIGIN
+	ACTION_AS_PATH_REPLACE
+	ACTION_AS_PATH_REMOVE_PRIVATE_AS
+	ACTION_AS_PATH_EXCLUDE
+	ACTION_WEIGHT
+	ACTION_TAG
+	ACTION_AIGP
+	ACTION_LINK_BANDWIDTH
 )
 
+func (t ActionType) String() string {
+	switch t {
+	case ACTION_ROUTING:
+		return "route-disposition"
+	case ACTION_COMMUNITY:
+		return "set-community"
+	case ACTION_EXT_COMMUNITY:
+		return "set-ext-community"
+	case ACTION_MED:
+		return "set-med"
+	case ACTION_AS_PATH_PREPEND:
+		return "set-as-path-prepend"
+	case ACTION_NEXTHOP:
+		return "set-next-hop"
+	case ACTION_LOCAL_PREF:
+		return "set-local-pref"
+	case ACTION_LARGE_COMMUNITY:
+		return "set-large-community"
+	case ACTION_ORIGIN:
+		return "set-route-origin"
+	case ACTION_AS_PATH_REPLACE:
+		return "set-as-path-replace"
+	case ACTION_AS_PATH_REMOVE_PRIVATE_AS:
+		return "set-as-path-remove-private-as"
+	case ACTION_AS_PATH_EXCLUDE:
+		return "set-as-path-exclude"
+	case ACTION_WEIGHT:
+		return "set-weight"
+	case ACTION_TAG:
+		return "set-tag"
+	case ACTION_AIGP:
+		return "set-aigp"
+	case ACTION_LINK_BANDWIDTH:
+		return "set-link-bandwidth"
+	}
+	return fmt.Sprintf("unknown(%d)", t)
+}
+
 func NewMatchOption(c any) (MatchOption, error) {
 	switch t := c.(type) {
 	case oc.MatchSetOptionsType:
@@ -863,6 +983,23 @@ type AsPathSet struct {
 	name       string
 	list       []*regexp.Regexp
 	singleList []*singleAsPathMatch
+	// singleList split into the origin AS numbers, looked up at once
+	// unless all the entries need to match, and the others
+	origins map[uint32]struct{}
+	others  []*singleAsPathMatch
+}
+
+// index splits singleList into the origin AS numbers and the others.
+func (s *AsPathSet) index() {
+	s.origins = make(map[uint32]struct{})
+	s.others = nil
+	for _, m := range s.singleList {
+		if m.mode == ORIGIN {
+			s.origins[m.asn] = struct{}{}
+		} else {
+			s.others = append(s.others, m)
+		}
+	}
 }
 
 func (s *AsPathSet) Name() string {
@@ -879,6 +1016,7 @@ func (lhs *AsPathSet) Append(arg DefinedSet) error {
 	}
 	lhs.list = append(lhs.list, arg.(*AsPathSet).list...)
 	lhs.singleList = append(lhs.singleList, arg.(*AsPathSet).singleList...)
+	lhs.index()
 	return nil
 }
 
@@ -908,6 +1046,7 @@ func (lhs *AsPathSet) Remove(arg DefinedSet) error {
 		}
 	}
 	lhs.singleList = newSingleList
+	lhs.index()
 	return nil
 }
 
@@ -918,6 +1057,8 @@ func (lhs *AsPathSet) Replace(arg DefinedSet) error {
 	}
 	lhs.list = rhs.list
 	lhs.singleList = rhs.singleList
+	lhs.origins = rhs.origins
+	lhs.others = rhs.others
 	return nil
 }
 
@@ -968,12 +1109,14 @@ func NewAsPathSet(c oc.AsPathSet) (*AsPathSet, error) {
 			list = append(list, exp)
 		}
 	}
-	return &AsPathSet{
+	s := &AsPathSet{
 		typ:        DEFINED_TYPE_AS_PATH,
 		name:       name,
 		list:       list,
 		singleList: singleList,
-	}, nil
+	}
+	s.index()
+	return s, nil
 }
 
 type regExpSet struct {
@@ -1586,7 +1729,16 @@ func (c *AsPathCondition) Option() MatchOption {
 func (c *AsPathCondition) Evaluate(path *Path, _ *PolicyOptions) bool {
 	if len(c.set.singleList) > 0 {
 		aspath := path.GetAsSeqList()
-		for _, m := range c.set.singleList {
+		singleList := c.set.singleList
+		if c.option != MATCH_OPTION_ALL && len(c.set.origins) > 0 {
+			if len(aspath) > 0 {
+				if _, ok := c.set.origins[aspath[len(aspath)-1]]; ok {
+					return c.option == MATCH_OPTION_ANY
+				}
+			}
+			singleList = c.set.others
+		}
+		for _, m := range singleList {
 			result := m.Match(aspath)
 			if c.option == MATCH_OPTION_ALL && !result {
 				return false
@@ -2043,6 +2195,431 @@ func NewRpkiValidationCondition(c oc.RpkiValidationResultType) (*RpkiValidationC
 	}, nil
 }
 
+type BGPsecValidationCondition struct {
+	result oc.BgpsecValidationResultType
+}
+
+func (c *BGPsecValidationCondition) Type() ConditionType {
+	return CONDITION_BGPSEC
+}
+
+func (c *BGPsecValidationCondition) Evaluate(path *Path, options *PolicyOptions) bool {
+	if options != nil && options.ValidateBGPsec != nil {
+		return c.result == options.ValidateBGPsec(path)
+	}
+	return false
+}
+
+func (c *BGPsecValidationCondition) Set() DefinedSet {
+	return nil
+}
+
+func (c *BGPsecValidationCondition) Name() string { return "" }
+
+func (c *BGPsecValidationCondition) String() string {
+	return string(c.result)
+}
+
+func NewBGPsecValidationCondition(c oc.BgpsecValidationResultType) (*BGPsecValidationCondition, error) {
+	if c == oc.BgpsecValidationResultType("") || c == oc.BGPSEC_VALIDATION_RESULT_TYPE_NONE {
+		return nil, nil
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return &BGPsecValidationCondition{
+		result: c,
+	}, nil
+}
+
+// CallPolicyCondition calls the policy as a subroutine; it matches if the
+// policy accepts the path, and then the modifications made by the policy
+// are kept.
+type CallPolicyCondition struct {
+	name   string
+	policy *Policy
+}
+
+func (c *CallPolicyCondition) Type() ConditionType {
+	return CONDITION_CALL_POLICY
+}
+
+func (c *CallPolicyCondition) call(logger *slog.Logger, path *Path, options *PolicyOptions, trace *PolicyTrace) (bool, *Path) {
+	if c.policy == nil {
+		return false, path
+	}
+	result, after := c.policy.apply(logger, path, options, trace)
+	if result != ROUTE_TYPE_ACCEPT {
+		return false, path
+	}
+	return true, after
+}
+
+func (c *CallPolicyCondition) Evaluate(path *Path, options *PolicyOptions) bool {
+	matched, _ := c.call(slog.New(slog.DiscardHandler), path, options, nil)
+	return matched
+}
+
+func (c *CallPolicyCondition) Set() DefinedSet {
+	return nil
+}
+
+func (c *CallPolicyCondition) Name() string { return c.name }
+
+func (c *CallPolicyCondition) String() string {
+	return c.name
+}
+
+func NewCallPolicyCondition(name string) (*CallPolicyCondition, error) {
+	if name == "" {
+		return nil, nil
+	}
+	return &CallPolicyCondition{
+		name: name,
+	}, nil
+}
+
+type OriginAsCondition struct {
+	list []uint32
+}
+
+func (c *OriginAsCondition) Type() ConditionType {
+	return CONDITION_ORIGIN_AS
+}
+
+// compare the rightmost AS number in the AS_PATH attribute with the
+// ones in condition.
+func (c *OriginAsCondition) Evaluate(path *Path, _ *PolicyOptions) bool {
+	as := path.GetSourceAs()
+	return as != 0 && slices.Contains(c.list, as)
+}
+
+func (c *OriginAsCondition) Set() DefinedSet {
+	return nil
+}
+
+func (c *OriginAsCondition) Name() string { return "" }
+
+func (c *OriginAsCondition) String() string {
+	return asListString(c.list)
+}
+
+func NewOriginAsCondition(list []uint32) (*OriginAsCondition, error) {
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return &OriginAsCondition{
+		list: slices.Clone(list),
+	}, nil
+}
+
+type NeighborAsCondition struct {
+	list []uint32
+}
+
+func (c *NeighborAsCondition) Type() ConditionType {
+	return CONDITION_NEIGHBOR_AS
+}
+
+// compare the leftmost AS number in the AS_PATH attribute, skipping the
+// confederation segments, with the ones in condition.
+func (c *NeighborAsCondition) Evaluate(path *Path, _ *PolicyOptions) bool {
+	aspath := path.GetAsPath()
+	if aspath == nil {
+		return false
+	}
+	for _, param := range aspath.Value {
+		switch param.GetType() {
+		case bgp.BGP_ASPATH_ATTR_TYPE_CONFED_SEQ, bgp.BGP_ASPATH_ATTR_TYPE_CONFED_SET:
+			continue
+		case bgp.BGP_ASPATH_ATTR_TYPE_SEQ:
+			if l := param.GetAS(); len(l) > 0 {
+				return slices.Contains(c.list, l[0])
+			}
+		}
+		return false
+	}
+	return false
+}
+
+func (c *NeighborAsCondition) Set() DefinedSet {
+	return nil
+}
+
+func (c *NeighborAsCondition) Name() string { return "" }
+
+func (c *NeighborAsCondition) String() string {
+	return asListString(c.list)
+}
+
+func NewNeighborAsCondition(list []uint32) (*NeighborAsCondition, error) {
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return &NeighborAsCondition{
+		list: slices.Clone(list),
+	}, nil
+}
+
+func asListString(list []uint32) string {
+	l := make([]string, 0, len(list))
+	for _, as := range list {
+		l = append(l, strconv.FormatUint(uint64(as), 10))
+	}
+	return strings.Join(l, ",")
+}
+
+type PrefixLengthCondition struct {
+	min uint8
+	max uint8
+}
+
+func (c *PrefixLengthCondition) Type() ConditionType {
+	return CONDITION_PREFIX_LENGTH
+}
+
+func (c *PrefixLengthCondition) Evaluate(path *Path, _ *PolicyOptions) bool {
+	r := nlriToIPNet(path.GetNlri())
+	if r == nil {
+		return false
+	}
+	ones, _ := r.Mask.Size()
+	return c.min <= uint8(ones) && uint8(ones) <= c.max
+}
+
+func (c *PrefixLengthCondition) Set() DefinedSet {
+	return nil
+}
+
+func (c *PrefixLengthCondition) Name() string { return "" }
+
+// Range returns the minimum and maximum prefix length.
+func (c *PrefixLengthCondition) Range() (uint8, uint8) {
+	return c.min, c.max
+}
+
+func (c *PrefixLengthCondition) String() string {
+	return fmt.Sprintf("%d..%d", c.min, c.max)
+}
+
+func NewPrefixLengthCondition(c string) (*PrefixLengthCondition, error) {
+	if c == "" {
+		return nil, nil
+	}
+	elems := _regexpPrefixRange.FindStringSubmatch(c)
+	if len(elems) != 3 {
+		return nil, fmt.Errorf("prefix length range format is invalid: %s", c)
+	}
+	min, err := strconv.ParseUint(elems[1], 10, 8)
+	if err != nil {
+		return nil, fmt.Errorf("prefix length range format is invalid: %s", c)
+	}
+	max, err := strconv.ParseUint(elems[2], 10, 8)
+	if err != nil || min > max || max > 128 {
+		return nil, fmt.Errorf("prefix length range format is invalid: %s", c)
+	}
+	return &PrefixLengthCondition{
+		min: uint8(min),
+		max: uint8(max),
+	}, nil
+}
+
+type SourcePeerTypeCondition struct {
+	list []oc.SourcePeerType
+}
+
+func (c *SourcePeerTypeCondition) Type() ConditionType {
+	return CONDITION_SOURCE_PEER_TYPE
+}
+
+func sourcePeerType(path *Path) oc.SourcePeerType {
+	source := path.GetSource()
+	switch {
+	case source == nil:
+		return oc.SOURCE_PEER_TYPE_LOCAL
+	case source.IsNetlink:
+		return oc.SOURCE_PEER_TYPE_NETLINK
+	case path.IsLocal():
+		return oc.SOURCE_PEER_TYPE_LOCAL
+	case path.IsIBGP():
+		return oc.SOURCE_PEER_TYPE_IBGP
+	case source.Confederation:
+		return oc.SOURCE_PEER_TYPE_CONFED
+	}
+	return oc.SOURCE_PEER_TYPE_EBGP
+}
+
+func (c *SourcePeerTypeCondition) Evaluate(path *Path, _ *PolicyOptions) bool {
+	return slices.Contains(c.list, sourcePeerType(path))
+}
+
+func (c *SourcePeerTypeCondition) Set() DefinedSet {
+	return nil
+}
+
+func (c *SourcePeerTypeCondition) Name() string { return "" }
+
+func (c *SourcePeerTypeCondition) String() string {
+	l := make([]string, 0, len(c.list))
+	for _, t := range c.list {
+		l = append(l, string(t))
+	}
+	return strings.Join(l, ",")
+}
+
+func NewSourcePeerTypeCondition(list []oc.SourcePeerType) (*SourcePeerTypeCondition, error) {
+	if len(list) == 0 {
+		return nil, nil
+	}
+	for _, t := range list {
+		if err := t.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return &SourcePeerTypeCondition{
+		list: slices.Clone(list),
+	}, nil
+}
+
+type PathAgeCondition struct {
+	age      uint32
+	operator AttributeComparison
+}
+
+func (c *PathAgeCondition) Type() ConditionType {
+	return CONDITION_PATH_AGE
+}
+
+// compare the seconds since the path is received with the one in
+// condition.
+func (c *PathAgeCondition) Evaluate(path *Path, _ *PolicyOptions) bool {
+	age := time.Since(path.GetTimestamp())
+	value := time.Duration(c.age) * time.Second
+	switch c.operator {
+	case ATTRIBUTE_EQ:
+		return age.Truncate(time.Second) == value
+	case ATTRIBUTE_GE:
+		return age >= value
+	case ATTRIBUTE_LE:
+		return age <= value
+	default:
+		return false
+	}
+}
+
+func (c *PathAgeCondition) Set() DefinedSet {
+	return nil
+}
+
+func (c *PathAgeCondition) Name() string { return "" }
+
+func (c *PathAgeCondition) String() string {
+	return fmt.Sprintf("%s%ds", c.operator, c.age)
+}
+
+func NewPathAgeCondition(c oc.PathAge) (*PathAgeCondition, error) {
+	if c.Value == 0 && c.Operator == "" {
+		return nil, nil
+	}
+	var op AttributeComparison
+	if i := c.Operator.ToInt(); i < 0 {
+		return nil, fmt.Errorf("invalid path age operator: %s", c.Operator)
+	} else {
+		// take mod 3 because we have extended openconfig attribute-comparison
+		// for simple configuration. see oc.AttributeComparison definition
+		op = AttributeComparison(i % 3)
+	}
+	return &PathAgeCondition{
+		age:      c.Value,
+		operator: op,
+	}, nil
+}
+
+type AigpCondition struct {
+	min uint64
+	max uint64
+}
+
+func (c *AigpCondition) Type() ConditionType {
+	return CONDITION_AIGP
+}
+
+func (c *AigpCondition) Evaluate(path *Path, _ *PolicyOptions) bool {
+	v, ok := path.GetAigp()
+	if !ok {
+		return false
+	}
+	return c.min <= v && v <= c.max
+}
+
+func (c *AigpCondition) Set() DefinedSet {
+	return nil
+}
+
+func (c *AigpCondition) Name() string { return "" }
+
+// Range returns the minimum and maximum AIGP metric.
+func (c *AigpCondition) Range() (uint64, uint64) {
+	return c.min, c.max
+}
+
+func (c *AigpCondition) String() string {
+	return fmt.Sprintf("%d..%d", c.min, c.max)
+}
+
+func NewAigpCondition(c string) (*AigpCondition, error) {
+	if c == "" {
+		return nil, nil
+	}
+	elems := _regexpPrefixRange.FindStringSubmatch(c)
+	if len(elems) != 3 {
+		return nil, fmt.Errorf("aigp range format is invalid: %s", c)
+	}
+	min, err := strconv.ParseUint(elems[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("aigp range format is invalid: %s", c)
+	}
+	max, err := strconv.ParseUint(elems[2], 10, 64)
+	if err != nil || min > max {
+		return nil, fmt.Errorf("aigp range format is invalid: %s", c)
+	}
+	return &AigpCondition{
+		min: min,
+		max: max,
+	}, nil
+}
+
+type AttributePresentCondition struct {
+	typ bgp.BGPAttrType
+}
+
+func (c *AttributePresentCondition) Type() ConditionType {
+	return CONDITION_ATTRIBUTE_PRESENT
+}
+
+func (c *AttributePresentCondition) Evaluate(path *Path, _ *PolicyOptions) bool {
+	return path.getPathAttr(c.typ) != nil
+}
+
+func (c *AttributePresentCondition) Set() DefinedSet {
+	return nil
+}
+
+func (c *AttributePresentCondition) Name() string { return "" }
+
+func (c *AttributePresentCondition) String() string {
+	return fmt.Sprintf("%d", c.typ)
+}
+
+func NewAttributePresentCondition(typ uint8) (*AttributePresentCondition, error) {
+	if typ == 0 {
+		return nil, nil
+	}
+	return &AttributePresentCondition{
+		typ: bgp.BGPAttrType(typ),
+	}, nil
+}
+
 type RouteTypeCondition struct {
 	typ oc.RouteType
 }
@@ -2839,26 +3416,419 @@ func NewNexthopAction(c oc.BgpNextHopType) (*NexthopAction, error) {
 	}, nil
 }
 
-type Statement struct {
+type AsPathReplaceAction struct {
+	list []uint32
+	any  bool
+	// the local AS number if zero
+	as uint32
+}
+
+func (a *AsPathReplaceAction) Type() ActionType {
+	return ACTION_AS_PATH_REPLACE
+}
+
+func (a *AsPathReplaceAction) Apply(path *Path, options *PolicyOptions) (*Path, error) {
+	as := a.as
+	if as == 0 && options != nil && options.Info != nil {
+		as = options.Info.LocalAS
+	}
+	if as == 0 {
+		return path, nil
+	}
+	if a.any {
+		path.ReplaceASList(nil, as)
+	} else {
+		path.ReplaceASList(a.list, as)
+	}
+	return path, nil
+}
+
+func (a *AsPathReplaceAction) ToConfig() *oc.SetAsPathReplace {
+	c := &oc.SetAsPathReplace{
+		ReplaceAs: a.as,
+	}
+	if a.any {
+		c.AsList = []string{"any"}
+	} else {
+		c.AsList = make([]string, 0, len(a.list))
+		for _, as := range a.list {
+			c.AsList = append(c.AsList, strconv.FormatUint(uint64(as), 10))
+		}
+	}
+	return c
+}
+
+func (a *AsPathReplaceAction) String() string {
+	c := a.ToConfig()
+	as := "local-as"
+	if c.ReplaceAs != 0 {
+		as = strconv.FormatUint(uint64(c.ReplaceAs), 10)
+	}
+	return fmt.Sprintf("replace %s with %s", strings.Join(c.AsList, ","), as)
+}
+
+func (a *AsPathReplaceAction) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.ToConfig())
+}
+
+func NewAsPathReplaceAction(c oc.SetAsPathReplace) (*AsPathReplaceAction, error) {
+	if len(c.AsList) == 0 {
+		if c.ReplaceAs != 0 {
+			return nil, fmt.Errorf("specify as list to replace")
+		}
+		return nil, nil
+	}
+	a := &AsPathReplaceAction{
+		as: c.ReplaceAs,
+	}
+	for _, s := range c.AsList {
+		if s == "any" {
+			if len(c.AsList) != 1 {
+				return nil, fmt.Errorf("any can't be specified with AS numbers")
+			}
+			a.any = true
+			break
+		}
+		asn, err := strconv.ParseUint(s, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("AS number string invalid: %s", s)
+		}
+		a.list = append(a.list, uint32(asn))
+	}
+	return a, nil
+}
+
+type RemovePrivateAsAction struct {
+	option oc.RemovePrivateAsOption
+}
+
+func (a *RemovePrivateAsAction) Type() ActionType {
+	return ACTION_AS_PATH_REMOVE_PRIVATE_AS
+}
+
+func (a *RemovePrivateAsAction) Apply(path *Path, options *PolicyOptions) (*Path, error) {
+	var localAS uint32
+	if options != nil && options.Info != nil {
+		localAS = options.Info.LocalAS
+	}
+	if a.option == oc.REMOVE_PRIVATE_AS_OPTION_REPLACE && localAS == 0 {
+		return path, nil
+	}
+	path.RemovePrivateAS(localAS, a.option)
+	return path, nil
+}
+
+func (a *RemovePrivateAsAction) ToConfig() oc.RemovePrivateAsOption {
+	return a.option
+}
+
+func (a *RemovePrivateAsAction) String() string {
+	return string(a.option)
+}
+
+func (a *RemovePrivateAsAction) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.ToConfig())
+}
+
+func NewRemovePrivateAsAction(option oc.RemovePrivateAsOption) (*RemovePrivateAsAction, error) {
+	if option == "" {
+		return nil, nil
+	}
+	if err := option.Validate(); err != nil {
+		return nil, err
+	}
+	return &RemovePrivateAsAction{
+		option: option,
+	}, nil
+}
+
+type AsPathExcludeAction struct {
+	list []uint32
+}
+
+func (a *AsPathExcludeAction) Type() ActionType {
+	return ACTION_AS_PATH_EXCLUDE
+}
+
+func (a *AsPathExcludeAction) Apply(path *Path, _ *PolicyOptions) (*Path, error) {
+	path.ExcludeAS(a.list)
+	return path, nil
+}
+
+func (a *AsPathExcludeAction) ToConfig() []uint32 {
+	return a.list
+}
+
+func (a *AsPathExcludeAction) String() string {
+	l := make([]string, 0, len(a.list))
+	for _, as := range a.list {
+		l = append(l, strconv.FormatUint(uint64(as), 10))
+	}
+	return strings.Join(l, ",")
+}
+
+func (a *AsPathExcludeAction) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.ToConfig())
+}
+
+func NewAsPathExcludeAction(list []uint32) (*AsPathExcludeAction, error) {
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return &AsPathExcludeAction{
+		list: slices.Clone(list),
+	}, nil
+}
+
+type WeightAction struct {
+	value uint32
+}
+
+func (a *WeightAction) Type() ActionType {
+	return ACTION_WEIGHT
+}
+
+func (a *WeightAction) Apply(path *Path, _ *PolicyOptions) (*Path, error) {
+	path.SetWeight(a.value)
+	return path, nil
+}
+
+func (a *WeightAction) ToConfig() uint32 {
+	return a.value
+}
+
+func (a *WeightAction) String() string {
+	return fmt.Sprintf("%d", a.value)
+}
+
+func (a *WeightAction) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.ToConfig())
+}
+
+func NewWeightAction(value uint32) (*WeightAction, error) {
+	if value == 0 {
+		return nil, nil
+	}
+	return &WeightAction{
+		value: value,
+	}, nil
+}
+
+type TagAction struct {
+	value uint32
+}
+
+func (a *TagAction) Type() ActionType {
+	return ACTION_TAG
+}
+
+func (a *TagAction) Apply(path *Path, _ *PolicyOptions) (*Path, error) {
+	path.SetTag(a.value)
+	return path, nil
+}
+
+func (a *TagAction) ToConfig() oc.TagType {
+	return oc.TagType(strconv.FormatUint(uint64(a.value), 10))
+}
+
+func (a *TagAction) String() string {
+	return fmt.Sprintf("%d", a.value)
+}
+
+func (a *TagAction) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.ToConfig())
+}
+
+func NewTagAction(c oc.TagType) (*TagAction, error) {
+	if c == "" {
+		return nil, nil
+	}
+	value, err := strconv.ParseUint(string(c), 0, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tag: %s", c)
+	}
+	return &TagAction{
+		value: uint32(value),
+	}, nil
+}
+
+type AigpAction struct {
+	value  int64
+	action MedActionType
+}
+
+func (a *AigpAction) Type() ActionType {
+	return ACTION_AIGP
+}
+
+func (a *AigpAction) Apply(path *Path, _ *PolicyOptions) (*Path, error) {
+	switch a.action {
+	case MED_ACTION_MOD:
+		return path, path.SetAigp(a.value, false)
+	case MED_ACTION_REPLACE:
+		return path, path.SetAigp(a.value, true)
+	}
+	return path, nil
+}
+
+func (a *AigpAction) ToConfig() oc.BgpSetAigpType {
+	if a.action == MED_ACTION_MOD && a.value >= 0 {
+		return oc.BgpSetAigpType(fmt.Sprintf("+%d", a.value))
+	}
+	return oc.BgpSetAigpType(fmt.Sprintf("%d", a.value))
+}
+
+func (a *AigpAction) String() string {
+	return string(a.ToConfig())
+}
+
+func (a *AigpAction) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.ToConfig())
+}
+
+func NewAigpAction(c oc.BgpSetAigpType) (*AigpAction, error) {
+	if string(c) == "" {
+		return nil, nil
+	}
+
+	elems := _regexpParseMedAction.FindStringSubmatch(string(c))
+	if len(elems) != 3 {
+		return nil, fmt.Errorf("invalid aigp action format")
+	}
+	action := MED_ACTION_REPLACE
+	switch elems[1] {
+	case "+", "-":
+		action = MED_ACTION_MOD
+	}
+	value, err := strconv.ParseInt(string(c), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid aigp action value: %s", c)
+	}
+	return &AigpAction{
+		value:  value,
+		action: action,
+	}, nil
+}
+
+func NewAigpActionFromApiStruct(action MedActionType, value int64) *AigpAction {
+	return &AigpAction{action: action, value: value}
+}
+
+type LinkBandwidthAction struct {
+	// Mbps
+	value uint32
+}
+
+func (a *LinkBandwidthAction) Type() ActionType {
+	return ACTION_LINK_BANDWIDTH
+}
+
+func (a *LinkBandwidthAction) Apply(path *Path, options *PolicyOptions) (*Path, error) {
+	as := uint16(bgp.AS_TRANS)
+	if options != nil && options.Info != nil && options.Info.LocalAS <= math.MaxUint16 && options.Info.LocalAS != 0 {
+		as = uint16(options.Info.LocalAS)
+	}
+	// the link bandwidth extended community carries bytes per second
+	bw := bgp.NewLinkBandwidthExtended(as, float32(a.value)*1000*1000/8)
+	l := []bgp.ExtendedCommunityInterface{bw}
+	for _, ec := range path.GetExtCommunities() {
+		if _, ok := ec.(*bgp.LinkBandwidthExtended); ok {
+			continue
+		}
+		l = append(l, ec)
+	}
+	path.SetExtCommunities(l, true)
+	return path, nil
+}
+
+func (a *LinkBandwidthAction) ToConfig() uint32 {
+	return a.value
+}
+
+func (a *LinkBandwidthAction) String() string {
+	return fmt.Sprintf("%dMbps", a.value)
+}
+
+func (a *LinkBandwidthAction) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.ToConfig())
+}
+
+func NewLinkBandwidthAction(value uint32) (*LinkBandwidthAction, error) {
+	if value == 0 {
+		return nil, nil
+	}
+	return &LinkBandwidthAction{
+		value: value,
+	}, nil
+}
+
+type Statement struct {
 	Name        string
 	Conditions  []Condition
 	RouteAction Action
 	ModActions  []Action
+	// where the evaluation goes on when the statement matches without
+	// the route action
+	FlowControl   oc.FlowControlType
+	GotoStatement string
+	counters      *policyCounters
 }
 
 // evaluate each condition in the statement according to MatchSetOptions
 func (s *Statement) Evaluate(p *Path, options *PolicyOptions) bool {
+	matched, _ := s.evaluate(slog.New(slog.DiscardHandler), p, options, nil)
+	return matched
+}
+
+// evaluate returns the path modified by the policies called in the
+// conditions as well.
+func (s *Statement) evaluate(logger *slog.Logger, p *Path, options *PolicyOptions, trace *StatementTrace) (bool, *Path) {
 	for _, c := range s.Conditions {
-		if !c.Evaluate(p, options) {
-			return false
+		var matched bool
+		if call, ok := c.(*CallPolicyCondition); ok {
+			var ct *PolicyTrace
+			if trace != nil {
+				// not counted by the called policy either
+				ct = &PolicyTrace{}
+			}
+			matched, p = call.call(logger, p, options, ct)
+		} else {
+			matched = c.Evaluate(p, options)
+		}
+		if trace != nil {
+			trace.Conditions = append(trace.Conditions, ConditionTrace{
+				Type:    c.Type(),
+				Name:    c.Name(),
+				Matched: matched,
+			})
+		}
+		if !matched {
+			return false, p
 		}
 	}
-	return true
+	return true, p
 }
 
 func (s *Statement) Apply(logger *slog.Logger, path *Path, options *PolicyOptions) (RouteType, *Path) {
-	result := s.Evaluate(path, options)
+	result, path, _ := s.apply(logger, path, options, nil)
+	return result, path
+}
+
+// apply returns whether the statement matched as well; the path is given
+// back as it is unless matched. It's counted unless traced or asked not to.
+func (s *Statement) apply(logger *slog.Logger, path *Path, options *PolicyOptions, trace *StatementTrace) (RouteType, *Path, bool) {
+	result, path, matched := s.run(logger, path, options, trace)
+	s.counters.count(evaluations(options, trace != nil), matched, result)
+	return result, path, matched
+}
+
+func (s *Statement) run(logger *slog.Logger, path *Path, options *PolicyOptions, trace *StatementTrace) (RouteType, *Path, bool) {
+	result, after := s.evaluate(logger, path, options, trace)
 	if result {
+		path = after
+		if trace != nil {
+			trace.Matched = true
+		}
 		if len(s.ModActions) != 0 {
 			// apply all modification actions
 			path = path.Clone(path.IsWithdraw)
@@ -2870,19 +3840,29 @@ func (s *Statement) Apply(logger *slog.Logger, path *Path, options *PolicyOption
 						slog.String("Topic", "policy"),
 						slog.String("Error", err.Error()))
 				}
+				if trace != nil {
+					trace.Actions = append(trace.Actions, action.Type().String()+" "+action.String())
+				}
 			}
 		}
 		// Routing action
 		if s.RouteAction == nil || reflect.ValueOf(s.RouteAction).IsNil() {
-			return ROUTE_TYPE_NONE, path
+			return ROUTE_TYPE_NONE, path, true
 		}
 		p, _ := s.RouteAction.Apply(path, options)
 		if p == nil {
-			return ROUTE_TYPE_REJECT, path
+			return s.traceResult(trace, ROUTE_TYPE_REJECT), path, true
 		}
-		return ROUTE_TYPE_ACCEPT, path
+		return s.traceResult(trace, ROUTE_TYPE_ACCEPT), path, true
+	}
+	return ROUTE_TYPE_NONE, path, false
+}
+
+func (s *Statement) traceResult(trace *StatementTrace, result RouteType) RouteType {
+	if trace != nil {
+		trace.Result = result
 	}
-	return ROUTE_TYPE_NONE, path
+	return result
 }
 
 func (s *Statement) ToConfig() *oc.Statement {
@@ -2917,6 +3897,8 @@ func (s *Statement) ToConfig() *oc.Statement {
 					cond.BgpConditions.NextHopInList = l
 				case *RpkiValidationCondition:
 					cond.BgpConditions.RpkiValidationResult = v.result
+				case *BGPsecValidationCondition:
+					cond.BgpConditions.BgpsecValidationResult = v.result
 				case *RouteTypeCondition:
 					cond.BgpConditions.RouteType = v.typ
 				case *OriginCondition:
@@ -2931,6 +3913,22 @@ func (s *Statement) ToConfig() *oc.Statement {
 					cond.BgpConditions.LocalPrefEq = v.localPref
 				case *MedEqCondition:
 					cond.BgpConditions.MedEq = v.med
+				case *CallPolicyCondition:
+					cond.CallPolicy = v.name
+				case *OriginAsCondition:
+					cond.BgpConditions.OriginAsInList = v.list
+				case *NeighborAsCondition:
+					cond.BgpConditions.NeighborAsInList = v.list
+				case *PrefixLengthCondition:
+					cond.BgpConditions.PrefixLengthRange = v.String()
+				case *SourcePeerTypeCondition:
+					cond.BgpConditions.SourcePeerTypeList = v.list
+				case *PathAgeCondition:
+					cond.BgpConditions.PathAge = oc.PathAge{Operator: oc.IntToAttributeComparisonMap[int(v.operator)], Value: v.age}
+				case *AigpCondition:
+					cond.BgpConditions.AigpRange = v.String()
+				case *AttributePresentCondition:
+					cond.BgpConditions.AttributeTypePresent = uint8(v.typ)
 				}
 			}
 			return cond
@@ -2965,8 +3963,24 @@ func (s *Statement) ToConfig() *oc.Statement {
 					act.BgpActions.SetNextHop = v.ToConfig()
 				case *OriginAction:
 					act.BgpActions.SetRouteOrigin = v.ToConfig()
+				case *AsPathReplaceAction:
+					act.BgpActions.SetAsPathReplace = *v.ToConfig()
+				case *RemovePrivateAsAction:
+					act.BgpActions.SetAsPathRemovePrivateAs = v.ToConfig()
+				case *AsPathExcludeAction:
+					act.BgpActions.SetAsPathExclude = v.ToConfig()
+				case *WeightAction:
+					act.BgpActions.SetWeight = v.ToConfig()
+				case *TagAction:
+					act.IgpActions.SetTag = v.ToConfig()
+				case *AigpAction:
+					act.BgpActions.SetAigp = v.ToConfig()
+				case *LinkBandwidthAction:
+					act.BgpActions.SetLinkBandwidth = v.ToConfig()
 				}
 			}
+			act.FlowControl = s.FlowControl
+			act.GotoStatement = s.GotoStatement
 			return act
 		}(),
 	}
@@ -2990,6 +4004,7 @@ func (lhs *Statement) mod(op opType, rhs *Statement) error {
 	ra := lhs.RouteAction
 	as := make([]Action, len(lhs.ModActions))
 	copy(as, lhs.ModActions)
+	flow, to := lhs.FlowControl, lhs.GotoStatement
 	for _, x := range rhs.Conditions {
 		var c Condition
 		i := 0
@@ -3077,9 +4092,33 @@ func (lhs *Statement) mod(op opType, rhs *Statement) error {
 			as[i] = x
 		}
 	}
+	if rhs.FlowControl != "" {
+		switch op {
+		case ADD:
+			if flow != "" {
+				return fmt.Errorf("flow control is already set")
+			}
+			flow, to = rhs.FlowControl, rhs.GotoStatement
+		case REMOVE:
+			if flow == "" {
+				return fmt.Errorf("flow control is not set")
+			}
+			flow, to = "", ""
+		case REPLACE:
+			if flow == "" {
+				return fmt.Errorf("flow control is not set")
+			}
+			flow, to = rhs.FlowControl, rhs.GotoStatement
+		}
+	}
+	if flow != "" && ra != nil && !reflect.ValueOf(ra).IsNil() {
+		return fmt.Errorf("statement %s can't have both route action and flow control", lhs.Name)
+	}
 	lhs.Conditions = cs
 	lhs.RouteAction = ra
 	lhs.ModActions = as
+	lhs.FlowControl = flow
+	lhs.GotoStatement = to
 	return nil
 }
 
@@ -3119,6 +4158,9 @@ func NewStatement(c oc.Statement) (*Statement, error) {
 		func() (Condition, error) {
 			return NewRpkiValidationCondition(c.Conditions.BgpConditions.RpkiValidationResult)
 		},
+		func() (Condition, error) {
+			return NewBGPsecValidationCondition(c.Conditions.BgpConditions.BgpsecValidationResult)
+		},
 		func() (Condition, error) {
 			return NewRouteTypeCondition(c.Conditions.BgpConditions.RouteType)
 		},
@@ -3153,6 +4195,30 @@ func NewStatement(c oc.Statement) (*Statement, error) {
 		func() (Condition, error) {
 			return NewMedEqCondition(c.Conditions.BgpConditions.MedEq)
 		},
+		func() (Condition, error) {
+			return NewCallPolicyCondition(c.Conditions.CallPolicy)
+		},
+		func() (Condition, error) {
+			return NewOriginAsCondition(c.Conditions.BgpConditions.OriginAsInList)
+		},
+		func() (Condition, error) {
+			return NewNeighborAsCondition(c.Conditions.BgpConditions.NeighborAsInList)
+		},
+		func() (Condition, error) {
+			return NewPrefixLengthCondition(c.Conditions.BgpConditions.PrefixLengthRange)
+		},
+		func() (Condition, error) {
+			return NewSourcePeerTypeCondition(c.Conditions.BgpConditions.SourcePeerTypeList)
+		},
+		func() (Condition, error) {
+			return NewPathAgeCondition(c.Conditions.BgpConditions.PathAge)
+		},
+		func() (Condition, error) {
+			return NewAigpCondition(c.Conditions.BgpConditions.AigpRange)
+		},
+		func() (Condition, error) {
+			return NewAttributePresentCondition(c.Conditions.BgpConditions.AttributeTypePresent)
+		},
 	}
 	cs = make([]Condition, 0, len(cfs))
 	for _, f := range cfs {
@@ -3184,6 +4250,16 @@ func NewStatement(c oc.Statement) (*Statement, error) {
 		func() (Action, error) {
 			return NewLocalPrefAction(c.Actions.BgpActions.SetLocalPref)
 		},
+		// rewrite the AS-path received before prepending to it
+		func() (Action, error) {
+			return NewAsPathReplaceAction(c.Actions.BgpActions.SetAsPathReplace)
+		},
+		func() (Action, error) {
+			return NewRemovePrivateAsAction(c.Actions.BgpActions.SetAsPathRemovePrivateAs)
+		},
+		func() (Action, error) {
+			return NewAsPathExcludeAction(c.Actions.BgpActions.SetAsPathExclude)
+		},
 		func() (Action, error) {
 			return NewAsPathPrependAction(c.Actions.BgpActions.SetAsPathPrepend)
 		},
@@ -3193,6 +4269,18 @@ func NewStatement(c oc.Statement) (*Statement, error) {
 		func() (Action, error) {
 			return NewOriginAction(c.Actions.BgpActions.SetRouteOrigin)
 		},
+		func() (Action, error) {
+			return NewWeightAction(c.Actions.BgpActions.SetWeight)
+		},
+		func() (Action, error) {
+			return NewTagAction(c.Actions.IgpActions.SetTag)
+		},
+		func() (Action, error) {
+			return NewAigpAction(c.Actions.BgpActions.SetAigp)
+		},
+		func() (Action, error) {
+			return NewLinkBandwidthAction(c.Actions.BgpActions.SetLinkBandwidth)
+		},
 	}
 	as = make([]Action, 0, len(afs))
 	for _, f := range afs {
@@ -3204,31 +4292,112 @@ func NewStatement(c oc.Statement) (*Statement, error) {
 			as = append(as, a)
 		}
 	}
+	flow, to, err := NewFlowControl(c.Actions.FlowControl, c.Actions.GotoStatement)
+	if err != nil {
+		return nil, err
+	}
+	if flow != "" && ra != nil && !reflect.ValueOf(ra).IsNil() {
+		return nil, fmt.Errorf("statement %s can't have both route action and flow control", c.Name)
+	}
 	return &Statement{
-		Name:        c.Name,
-		Conditions:  cs,
-		RouteAction: ra,
-		ModActions:  as,
+		Name:          c.Name,
+		Conditions:    cs,
+		RouteAction:   ra,
+		ModActions:    as,
+		FlowControl:   flow,
+		GotoStatement: to,
+		counters:      &policyCounters{},
 	}, nil
 }
 
+// NewFlowControl validates the flow control and the statement to go to,
+// returning empty for the default, going on with the next statement.
+func NewFlowControl(c oc.FlowControlType, to string) (oc.FlowControlType, string, error) {
+	switch c {
+	case "", oc.FLOW_CONTROL_TYPE_NONE:
+		if to != "" {
+			return "", "", fmt.Errorf("goto statement %s without goto flow control", to)
+		}
+		return "", "", nil
+	case oc.FLOW_CONTROL_TYPE_NEXT_STATEMENT, oc.FLOW_CONTROL_TYPE_NEXT_POLICY:
+		if to != "" {
+			return "", "", fmt.Errorf("goto statement %s without goto flow control", to)
+		}
+		return c, "", nil
+	case oc.FLOW_CONTROL_TYPE_GOTO:
+		if to == "" {
+			return "", "", fmt.Errorf("empty goto statement")
+		}
+		return c, to, nil
+	}
+	return "", "", fmt.Errorf("invalid flow control: %s", c)
+}
+
 type Policy struct {
 	Name       string
 	Statements []*Statement
+	counters   *policyCounters
 }
 
 // Compare path with a policy's condition in stored order in the policy.
 // If a condition match, then this function stops evaluation and
 // subsequent conditions are skipped.
 func (p *Policy) Apply(logger *slog.Logger, path *Path, options *PolicyOptions) (RouteType, *Path) {
-	for _, stmt := range p.Statements {
-		var result RouteType
-		result, path = stmt.Apply(logger, path, options)
+	return p.apply(logger, path, options, nil)
+}
+
+// apply is counted unless traced or asked not to.
+func (p *Policy) apply(logger *slog.Logger, path *Path, options *PolicyOptions, trace *PolicyTrace) (RouteType, *Path) {
+	result, path, matched := p.run(logger, path, options, trace)
+	p.counters.count(evaluations(options, trace != nil), matched, result)
+	return result, path
+}
+
+// run returns whether any statement matched as well.
+func (p *Policy) run(logger *slog.Logger, path *Path, options *PolicyOptions, trace *PolicyTrace) (RouteType, *Path, bool) {
+	matchedAny := false
+	for i := 0; i < len(p.Statements); i++ {
+		stmt := p.Statements[i]
+		var st *StatementTrace
+		if trace != nil {
+			st = &StatementTrace{Policy: p.Name, Statement: stmt.Name}
+			trace.Statements = append(trace.Statements, st)
+		}
+		result, after, matched := stmt.apply(logger, path, options, st)
+		path = after
+		if !matched {
+			continue
+		}
+		matchedAny = true
 		if result != ROUTE_TYPE_NONE {
-			return result, path
+			return result, path, true
+		}
+		switch stmt.FlowControl {
+		case oc.FLOW_CONTROL_TYPE_NEXT_POLICY:
+			return ROUTE_TYPE_NONE, path, true
+		case oc.FLOW_CONTROL_TYPE_GOTO:
+			j := p.statementIndex(stmt.GotoStatement)
+			if j <= i {
+				// not validated, the policy was changed since
+				logger.Warn("goto statement not found",
+					slog.String("Topic", "Policy"),
+					slog.String("Key", p.Name),
+					slog.String("Statement", stmt.GotoStatement))
+				return ROUTE_TYPE_NONE, path, true
+			}
+			i = j - 1
 		}
 	}
-	return ROUTE_TYPE_NONE, path
+	return ROUTE_TYPE_NONE, path, matchedAny
+}
+
+func (p *Policy) statementIndex(name string) int {
+	for i, s := range p.Statements {
+		if s.Name == name {
+			return i
+		}
+	}
+	return -1
 }
 
 func (p *Policy) ToConfig() *oc.PolicyDefinition {
@@ -3309,6 +4478,7 @@ func NewPolicy(c oc.PolicyDefinition) (*Policy, error) {
 	return &Policy{
 		Name:       c.Name,
 		Statements: st,
+		counters:   &policyCounters{},
 	}, nil
 }
 
@@ -3329,8 +4499,17 @@ func (p Policies) Less(i, j int) bool {
 type Assignment struct {
 	importPolicies      []*Policy
 	defaultImportPolicy RouteType
+	importCounters      *policyCounters
 	exportPolicies      []*Policy
 	defaultExportPolicy RouteType
+	exportCounters      *policyCounters
+}
+
+func newAssignment() *Assignment {
+	return &Assignment{
+		importCounters: &policyCounters{},
+		exportCounters: &policyCounters{},
+	}
 }
 
 type RoutingPolicy struct {
@@ -3350,21 +4529,24 @@ func (r *RoutingPolicy) ApplyPolicy(id string, dir PolicyDirection, before *Path
 	if before.IsWithdraw {
 		return before
 	}
-	result := ROUTE_TYPE_NONE
-	after := before
-
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	for _, p := range r.getPolicy(id, dir) {
-		result, after = p.Apply(r.logger, after, options)
-		if result != ROUTE_TYPE_NONE {
-			break
+	policies, def, counters := r.getAssignment(id, dir)
+	result, after := r.apply(policies, ROUTE_TYPE_NONE, before, options, nil)
+	matched := result != ROUTE_TYPE_NONE
+	if !matched {
+		result = def
+	}
+	if evaluations(options, false) > 0 {
+		counters.count(1, matched, result)
+		if options != nil {
+			for _, shared := range options.SharedWith {
+				_, _, c := r.getAssignment(shared, dir)
+				c.count(1, matched, result)
+			}
 		}
 	}
-	if result == ROUTE_TYPE_NONE {
-		result = r.getDefaultPolicy(id, dir)
-	}
 	switch result {
 	case ROUTE_TYPE_ACCEPT:
 		return after
@@ -3373,6 +4555,93 @@ func (r *RoutingPolicy) ApplyPolicy(id string, dir PolicyDirection, before *Path
 	}
 }
 
+func (r *RoutingPolicy) apply(policies []*Policy, def RouteType, before *Path, options *PolicyOptions, trace *PolicyTrace) (RouteType, *Path) {
+	result := ROUTE_TYPE_NONE
+	after := before
+	for _, p := range policies {
+		result, after = p.apply(r.logger, after, options, trace)
+		if result != ROUTE_TYPE_NONE {
+			break
+		}
+	}
+	if result == ROUTE_TYPE_NONE {
+		if trace != nil {
+			trace.Default = true
+		}
+		result = def
+	}
+	return result, after
+}
+
+// ConditionTrace records a condition evaluated by TestPolicy.
+type ConditionTrace struct {
+	Type ConditionType
+	// the name of the defined set, empty for the conditions without one
+	Name    string
+	Matched bool
+}
+
+// StatementTrace records a statement evaluated by TestPolicy; the
+// evaluation of the conditions stops at the first one not matched.
+type StatementTrace struct {
+	Policy     string
+	Statement  string
+	Conditions []ConditionTrace
+	Matched    bool
+	// the modification actions applied
+	Actions []string
+	Result  RouteType
+}
+
+// PolicyTrace records how the policies decided on a path.
+type PolicyTrace struct {
+	Statements []*StatementTrace
+	// no statement decided and the default policy was used
+	Default bool
+}
+
+// TestPolicy runs the path through the named policies, or the ones assigned
+// to the id in the direction if no name is given, without applying anything.
+// It returns the decision, the resulting path and the trace of the
+// evaluation; the decision of the named policies is ROUTE_TYPE_NONE if no
+// statement decides.
+func (r *RoutingPolicy) TestPolicy(id string, dir PolicyDirection, names []string, path *Path, options *PolicyOptions) (RouteType, *Path, *PolicyTrace, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	policies := r.getPolicy(id, dir)
+	def := r.getDefaultPolicy(id, dir)
+	if len(names) > 0 {
+		policies = make([]*Policy, 0, len(names))
+		for _, name := range names {
+			p, ok := r.policyMap[name]
+			if !ok {
+				return ROUTE_TYPE_NONE, nil, nil, fmt.Errorf("not found policy %s", name)
+			}
+			policies = append(policies, p)
+		}
+		def = ROUTE_TYPE_NONE
+	}
+	trace := &PolicyTrace{}
+	result, after := r.apply(policies, def, path, options, trace)
+	return result, after, trace, nil
+}
+
+func (r *RoutingPolicy) getAssignment(id string, dir PolicyDirection) ([]*Policy, RouteType, *policyCounters) {
+	a, ok := r.assignmentMap[id]
+	if !ok {
+		return nil, ROUTE_TYPE_NONE, nil
+	}
+	switch dir {
+	case POLICY_DIRECTION_IMPORT:
+		return a.importPolicies, a.defaultImportPolicy, a.importCounters
+	case POLICY_DIRECTION_EXPORT:
+		return a.exportPolicies, a.defaultExportPolicy, a.exportCounters
+	default:
+		return nil, ROUTE_TYPE_NONE, nil
+	}
+}
+
 func (r *RoutingPolicy) getPolicy(id string, dir PolicyDirection) []*Policy {
 	a, ok := r.assignmentMap[id]
 	if !ok {
@@ -3406,7 +4675,7 @@ func (r *RoutingPolicy) getDefaultPolicy(id string, dir PolicyDirection) RouteTy
 func (r *RoutingPolicy) setPolicy(id string, dir PolicyDirection, policies []*Policy) {
 	a, ok := r.assignmentMap[id]
 	if !ok {
-		a = &Assignment{}
+		a = newAssignment()
 	}
 	switch dir {
 	case POLICY_DIRECTION_IMPORT:
@@ -3420,7 +4689,7 @@ func (r *RoutingPolicy) setPolicy(id string, dir PolicyDirection, policies []*Po
 func (r *RoutingPolicy) setDefaultPolicy(id string, dir PolicyDirection, typ RouteType) {
 	a, ok := r.assignmentMap[id]
 	if !ok {
-		a = &Assignment{}
+		a = newAssignment()
 	}
 	switch dir {
 	case POLICY_DIRECTION_IMPORT:
@@ -3515,10 +4784,17 @@ func (r *RoutingPolicy) validateCondition(v Condition) (err error) {
 			c := v.(*LargeCommunityCondition)
 			c.set = i.(*LargeCommunitySet)
 		}
+	case CONDITION_CALL_POLICY:
+		p, ok := r.policyMap[v.Name()]
+		if !ok {
+			return fmt.Errorf("not found policy %s", v.Name())
+		}
+		v.(*CallPolicyCondition).policy = p
 	case CONDITION_NEXT_HOP:
 	case CONDITION_AFI_SAFI_IN:
 	case CONDITION_AS_PATH_LENGTH:
 	case CONDITION_RPKI:
+	case CONDITION_BGPSEC:
 	case CONDITION_LOCAL_PREF_EQ:
 	case CONDITION_MED_EQ:
 	}
@@ -3539,6 +4815,98 @@ func (r *RoutingPolicy) inUse(d DefinedSet) bool {
 	return false
 }
 
+// policyCalled returns the statement calling the policy, nil if none.
+func (r *RoutingPolicy) policyCalled(name string) *Statement {
+	for _, st := range r.statementMap {
+		for _, c := range st.Conditions {
+			if c.Type() == CONDITION_CALL_POLICY && c.Name() == name {
+				return st
+			}
+		}
+	}
+	return nil
+}
+
+// checkPolicies validates the flow of the policies; a goto has to jump
+// forward to a statement of the same policy, and the policies can't call
+// each other in a loop.
+func checkPolicies(pmap map[string]*Policy) error {
+	names := slices.Sorted(maps.Keys(pmap))
+	for _, name := range names {
+		p := pmap[name]
+		for i, st := range p.Statements {
+			if st.FlowControl == oc.FLOW_CONTROL_TYPE_GOTO && p.statementIndex(st.GotoStatement) <= i {
+				return fmt.Errorf("statement %s in policy %s can't goto %s, which doesn't follow it", st.Name, name, st.GotoStatement)
+			}
+		}
+	}
+	const (
+		visiting = iota + 1
+		visited
+	)
+	state := make(map[string]int, len(pmap))
+	var visit func(calls []string) error
+	visit = func(calls []string) error {
+		name := calls[len(calls)-1]
+		switch state[name] {
+		case visiting:
+			return fmt.Errorf("policy call loop: %s", strings.Join(calls, " -> "))
+		case visited:
+			return nil
+		}
+		state[name] = visiting
+		if p, ok := pmap[name]; ok {
+			for _, st := range p.Statements {
+				for _, c := range st.Conditions {
+					if c.Type() != CONDITION_CALL_POLICY {
+						continue
+					}
+					if err := visit(append(slices.Clone(calls), c.Name())); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		state[name] = visited
+		return nil
+	}
+	for _, name := range names {
+		if err := visit([]string{name}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// walkStatements calls fn with the statements of the policies, including
+// the ones of the policies called, until fn returns true.
+func walkStatements(policies []*Policy, fn func(*Statement) bool) bool {
+	seen := make(map[*Policy]bool)
+	var walk func([]*Policy) bool
+	walk = func(policies []*Policy) bool {
+		for _, p := range policies {
+			if seen[p] {
+				continue
+			}
+			seen[p] = true
+			for _, st := range p.Statements {
+				if fn(st) {
+					return true
+				}
+				for _, c := range st.Conditions {
+					if call, ok := c.(*CallPolicyCondition); ok && call.policy != nil {
+						if walk([]*Policy{call.policy}) {
+							return true
+						}
+					}
+				}
+			}
+		}
+		return false
+	}
+	return walk(policies)
+}
+
 func (r *RoutingPolicy) statementInUse(x *Statement) bool {
 	for _, p := range r.policyMap {
 		for _, y := range p.Statements {
@@ -3650,18 +5018,22 @@ func (r *RoutingPolicy) reload(c oc.RoutingPolicy) error {
 	}
 
 	// hacky
-	oldMap := r.definedSetMap
-	r.definedSetMap = dmap
+	oldMap, oldPolicies := r.definedSetMap, r.policyMap
+	r.definedSetMap, r.policyMap = dmap, pmap
 	for _, y := range pmap {
 		for _, s := range y.Statements {
 			for _, c := range s.Conditions {
 				if err := r.validateCondition(c); err != nil {
-					r.definedSetMap = oldMap
+					r.definedSetMap, r.policyMap = oldMap, oldPolicies
 					return err
 				}
 			}
 		}
 	}
+	if err := checkPolicies(pmap); err != nil {
+		r.definedSetMap, r.policyMap = oldMap, oldPolicies
+		return err
+	}
 
 	r.definedSetMap = dmap
 	r.policyMap = pmap
@@ -3694,7 +5066,10 @@ func (r *RoutingPolicy) GetDefinedSet(typ DefinedType, name string) (*oc.Defined
 	}
 
 	sort.Sort(dl)
+	return definedSetsToConfig(dl, name), nil
+}
 
+func definedSetsToConfig(dl DefinedSetList, name string) *oc.DefinedSets {
 	sets := &oc.DefinedSets{
 		PrefixSets:   make([]oc.PrefixSet, 0),
 		NeighborSets: make([]oc.NeighborSet, 0),
@@ -3724,7 +5099,7 @@ func (r *RoutingPolicy) GetDefinedSet(typ DefinedType, name string) (*oc.Defined
 			sets.BgpDefinedSets.AsPathSets = append(sets.BgpDefinedSets.AsPathSets, *v.ToConfig())
 		}
 	}
-	return sets, nil
+	return sets
 }
 
 func (r *RoutingPolicy) AddDefinedSet(s DefinedSet, replace bool) error {
@@ -3745,6 +5120,47 @@ func (r *RoutingPolicy) AddDefinedSet(s DefinedSet, replace bool) error {
 	return nil
 }
 
+// ReplaceDefinedSet replaces the contents of the defined set in place, so
+// that the statements referring to it see the new contents at once, or
+// adds the set. It returns the entries added and removed.
+func (r *RoutingPolicy) ReplaceDefinedSet(s DefinedSet) (added, removed []string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	m, ok := r.definedSetMap[s.Type()]
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid defined-set type: %d", s.Type())
+	}
+	var old []string
+	if d, ok := m[s.Name()]; ok {
+		old = d.List()
+		if err := d.Replace(s); err != nil {
+			return nil, nil, err
+		}
+	} else {
+		m[s.Name()] = s
+	}
+	// the sets from IRR can be large
+	l := s.List()
+	oldMap := make(map[string]struct{}, len(old))
+	for _, e := range old {
+		oldMap[e] = struct{}{}
+	}
+	newMap := make(map[string]struct{}, len(l))
+	for _, e := range l {
+		newMap[e] = struct{}{}
+		if _, ok := oldMap[e]; !ok {
+			added = append(added, e)
+		}
+	}
+	for _, e := range old {
+		if _, ok := newMap[e]; !ok {
+			removed = append(removed, e)
+		}
+	}
+	return added, removed, nil
+}
+
 func (r *RoutingPolicy) DeleteDefinedSet(a DefinedSet, all bool) (err error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -3795,8 +5211,16 @@ func (r *RoutingPolicy) AddStatement(st *Statement) (err error) {
 	m := r.statementMap
 	name := st.Name
 	if d, ok := m[name]; ok {
-		err = d.Add(st)
+		old := *d
+		if err = d.Add(st); err != nil {
+			return err
+		}
+		// the statement may be in the policies already
+		if err = checkPolicies(r.policyMap); err != nil {
+			*d = old
+		}
 	} else {
+		st.initCounters()
 		m[name] = st
 	}
 
@@ -3866,19 +5290,37 @@ func (r *RoutingPolicy) AddPolicy(x *Policy, refer bool) (err error) {
 	name := x.Name
 	y, ok := pMap[name]
 	if refer {
-		err = x.FillUp(sMap)
+		if err = x.FillUp(sMap); err != nil {
+			return err
+		}
 	} else {
 		for _, st := range x.Statements {
 			if _, ok := sMap[st.Name]; ok {
 				err = fmt.Errorf("statement %s already defined", st.Name)
 				return err
 			}
+		}
+	}
+	// check the policy as it will be before changing anything
+	after := x
+	if ok {
+		after = &Policy{Name: name, Statements: append(slices.Clone(y.Statements), x.Statements...)}
+	}
+	m := maps.Clone(pMap)
+	m[name] = after
+	if err = checkPolicies(m); err != nil {
+		return err
+	}
+	if !refer {
+		for _, st := range x.Statements {
+			st.initCounters()
 			sMap[st.Name] = st
 		}
 	}
 	if ok {
 		err = y.Add(x)
 	} else {
+		x.initCounters()
 		pMap[name] = x
 	}
 
@@ -3915,12 +5357,22 @@ func (r *RoutingPolicy) DeletePolicy(x *Policy, all, preserve bool, activeId []s
 			err = fmt.Errorf("can't delete. policy %s is in use", name)
 			return err
 		}
+		if st := r.policyCalled(name); st != nil {
+			return fmt.Errorf("can't delete. policy %s is called by statement %s", name, st.Name)
+		}
 		r.logger.Debug("delete policy",
 			slog.String("Topic", "Policy"),
 			slog.String("Key", name))
 		delete(pMap, name)
 	} else {
-		err = y.Remove(x)
+		old := y.Statements
+		if err = y.Remove(x); err == nil {
+			// the statement a goto jumps to may be removed
+			if err = checkPolicies(pMap); err != nil {
+				y.Statements = old
+				return err
+			}
+		}
 	}
 	if err == nil && !preserve {
 		for _, st := range y.Statements {
@@ -3945,6 +5397,45 @@ func (r *RoutingPolicy) GetPolicyAssignment(id string, dir PolicyDirection) (Rou
 	return rt, l, nil
 }
 
+// PeerDependent reports whether the result of the policies assigned to id
+// for the direction depends on the neighbor given in PolicyOptions, that
+// is, a statement has a neighbor condition or sets the next hop to the
+// peer address.
+func (r *RoutingPolicy) PeerDependent(id string, dir PolicyDirection) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return walkStatements(r.getPolicy(id, dir), func(st *Statement) bool {
+		for _, c := range st.Conditions {
+			if _, ok := c.(*NeighborCondition); ok {
+				return true
+			}
+		}
+		for _, a := range st.ModActions {
+			if a, ok := a.(*NexthopAction); ok && a.peerAddress {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// UsesCondition reports whether a statement of the policies assigned to id
+// for the direction has the condition of the type.
+func (r *RoutingPolicy) UsesCondition(id string, dir PolicyDirection, typ ConditionType) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return walkStatements(r.getPolicy(id, dir), func(st *Statement) bool {
+		for _, c := range st.Conditions {
+			if c.Type() == typ {
+				return true
+			}
+		}
+		return false
+	})
+}
+
 func (r *RoutingPolicy) AddPolicyAssignment(id string, dir PolicyDirection, policies []*oc.PolicyDefinition, def RouteType) (err error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -4104,6 +5595,11 @@ func (r *RoutingPolicy) Reset(rp *oc.RoutingPolicy, ap map[string]oc.ApplyPolicy
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	old := &RoutingPolicy{
+		policyMap:     r.policyMap,
+		statementMap:  r.statementMap,
+		assignmentMap: r.assignmentMap,
+	}
 	if err := r.reload(*rp); err != nil {
 		r.logger.Error("failed to create routing policy",
 			slog.String("Topic", "Policy"),
@@ -4114,6 +5610,7 @@ func (r *RoutingPolicy) Reset(rp *oc.RoutingPolicy, ap map[string]oc.ApplyPolicy
 	for id, c := range ap {
 		r.setPeerPolicy(id, c)
 	}
+	r.keepCounters(old)
 	return nil
 }
 
@@ -4160,10 +5657,11 @@ func subTypeEqual(ec bgp.ExtendedCommunityInterface, st bgp.ExtendedCommunityAtt
 }
 
 type PolicyAssignment struct {
-	Name     string
-	Type     PolicyDirection
-	Policies []*Policy
-	Default  RouteType
+	Name       string
+	Type       PolicyDirection
+	Policies   []*Policy
+	Default    RouteType
+	Statistics PolicyStatistics
 }
 
 func ToComparisonApi(c oc.AttributeComparison) api.Comparison {
@@ -4283,6 +5781,41 @@ func toStatementApi(s *oc.Statement) *api.Statement {
 	case oc.RPKI_VALIDATION_RESULT_TYPE_INVALID:
 		cs.RpkiResult = api.ValidationState_VALIDATION_STATE_INVALID
 	}
+	switch s.Conditions.BgpConditions.BgpsecValidationResult {
+	case oc.BGPSEC_VALIDATION_RESULT_TYPE_NOT_SIGNED:
+		cs.BgpsecResult = api.BgpsecValidationState_BGPSEC_VALIDATION_STATE_NOT_SIGNED
+	case oc.BGPSEC_VALIDATION_RESULT_TYPE_VALID:
+		cs.BgpsecResult = api.BgpsecValidationState_BGPSEC_VALIDATION_STATE_VALID
+	case oc.BGPSEC_VALIDATION_RESULT_TYPE_INVALID:
+		cs.BgpsecResult = api.BgpsecValidationState_BGPSEC_VALIDATION_STATE_INVALID
+	}
+	cs.CallPolicy = s.Conditions.CallPolicy
+	cs.OriginAsns = s.Conditions.BgpConditions.OriginAsInList
+	cs.NeighborAsns = s.Conditions.BgpConditions.NeighborAsInList
+	if s.Conditions.BgpConditions.PrefixLengthRange != "" {
+		if c, err := NewPrefixLengthCondition(s.Conditions.BgpConditions.PrefixLengthRange); err == nil {
+			min, max := c.Range()
+			cs.PrefixLengthRange = &api.PrefixLengthRange{Min: uint32(min), Max: uint32(max)}
+		}
+	}
+	for _, t := range s.Conditions.BgpConditions.SourcePeerTypeList {
+		if i := t.ToInt(); i >= 0 {
+			cs.SourcePeerTypes = append(cs.SourcePeerTypes, api.SourcePeerType(i+1))
+		}
+	}
+	if s.Conditions.BgpConditions.PathAge.Operator != "" {
+		cs.PathAge = &api.PathAge{
+			Seconds: s.Conditions.BgpConditions.PathAge.Value,
+			Type:    ToComparisonApi(s.Conditions.BgpConditions.PathAge.Operator),
+		}
+	}
+	if s.Conditions.BgpConditions.AigpRange != "" {
+		if c, err := NewAigpCondition(s.Conditions.BgpConditions.AigpRange); err == nil {
+			min, max := c.Range()
+			cs.AigpRange = &api.AigpRange{Min: min, Max: max}
+		}
+	}
+	cs.AttributeTypePresent = uint32(s.Conditions.BgpConditions.AttributeTypePresent)
 	community_action := func(action string) api.CommunityAction_Type {
 		fmt.Println("action0", action)
 		switch oc.BgpSetCommunityOptionType(action) {
@@ -4421,6 +5954,92 @@ func toStatementApi(s *oc.Statement) *api.Statement {
 			}
 			return &api.OriginAction{Origin: apiOrigin}
 		}(),
+		AsPathReplace: func() *api.AsPathReplaceAction {
+			c := s.Actions.BgpActions.SetAsPathReplace
+			if len(c.AsList) == 0 {
+				return nil
+			}
+			a := &api.AsPathReplaceAction{
+				ReplaceAsn: c.ReplaceAs,
+			}
+			for _, as := range c.AsList {
+				if as == "any" {
+					a.Any = true
+					continue
+				}
+				asn, err := strconv.ParseUint(as, 10, 32)
+				if err != nil {
+					return nil
+				}
+				a.Asns = append(a.Asns, uint32(asn))
+			}
+			return a
+		}(),
+		AsPathRemovePrivate: func() *api.AsPathRemovePrivateAction {
+			var removePrivate api.RemovePrivate
+			switch s.Actions.BgpActions.SetAsPathRemovePrivateAs {
+			case oc.REMOVE_PRIVATE_AS_OPTION_ALL:
+				removePrivate = api.RemovePrivate_REMOVE_PRIVATE_ALL
+			case oc.REMOVE_PRIVATE_AS_OPTION_REPLACE:
+				removePrivate = api.RemovePrivate_REMOVE_PRIVATE_REPLACE
+			default:
+				return nil
+			}
+			return &api.AsPathRemovePrivateAction{RemovePrivate: removePrivate}
+		}(),
+		AsPathExclude: func() *api.AsPathExcludeAction {
+			if len(s.Actions.BgpActions.SetAsPathExclude) == 0 {
+				return nil
+			}
+			return &api.AsPathExcludeAction{Asns: s.Actions.BgpActions.SetAsPathExclude}
+		}(),
+		Weight: func() *api.WeightAction {
+			if s.Actions.BgpActions.SetWeight == 0 {
+				return nil
+			}
+			return &api.WeightAction{Value: s.Actions.BgpActions.SetWeight}
+		}(),
+		Tag: func() *api.TagAction {
+			if s.Actions.IgpActions.SetTag == "" {
+				return nil
+			}
+			tag, err := strconv.ParseUint(string(s.Actions.IgpActions.SetTag), 0, 32)
+			if err != nil {
+				return nil
+			}
+			return &api.TagAction{Value: uint32(tag)}
+		}(),
+		Aigp: func() *api.AigpAction {
+			aigpStr := strings.TrimSpace(string(s.Actions.BgpActions.SetAigp))
+			if len(aigpStr) == 0 {
+				return nil
+			}
+			matches := _regexpMedActionType.FindStringSubmatch(aigpStr)
+			if len(matches) == 0 {
+				return nil
+			}
+			action := api.AigpAction_TYPE_REPLACE
+			switch matches[1] {
+			case "+", "-":
+				action = api.AigpAction_TYPE_MOD
+			}
+			value, err := strconv.ParseInt(matches[1]+matches[2], 10, 64)
+			if err != nil {
+				return nil
+			}
+			return &api.AigpAction{
+				Value: value,
+				Type:  action,
+			}
+		}(),
+		LinkBandwidth: func() *api.LinkBandwidthAction {
+			if s.Actions.BgpActions.SetLinkBandwidth == 0 {
+				return nil
+			}
+			return &api.LinkBandwidthAction{Bandwidth: s.Actions.BgpActions.SetLinkBandwidth}
+		}(),
+		FlowControl:   api.FlowControl(oc.FlowControlTypeToIntMap[s.Actions.FlowControl]),
+		GotoStatement: s.Actions.GotoStatement,
 	}
 	return &api.Statement{
 		Name:       s.Name,
@@ -4430,7 +6049,12 @@ func toStatementApi(s *oc.Statement) *api.Statement {
 }
 
 func NewAPIPolicyFromTableStruct(p *Policy) *api.Policy {
-	return ToPolicyApi(p.ToConfig())
+	a := ToPolicyApi(p.ToConfig())
+	a.Statistics = p.Statistics().ToApi()
+	for i, st := range p.Statements {
+		a.Statements[i].Statistics = st.Statistics().ToApi()
+	}
+	return a
 }
 
 func ToPolicyApi(p *oc.PolicyDefinition) *api.Policy {
@@ -4474,6 +6098,7 @@ func NewAPIPolicyAssignmentFromTableStruct(t *PolicyAssignment) *api.PolicyAssig
 			}
 			return l
 		}(),
+		Statistics: t.Statistics.ToApi(),
 	}
 }
 
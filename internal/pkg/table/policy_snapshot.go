@@ -0,0 +1,315 @@
+// Copyright (C) 2025 Acnodal Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package table
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"sort"
+
+	"github.com/osrg/gobgp/v4/pkg/config/oc"
+)
+
+// PolicySnapshot is the configuration of the whole routing policy at some
+// point, which isn't changed after it's taken.
+type PolicySnapshot struct {
+	definedSets oc.DefinedSets
+	// all the statements, including the ones in the policies
+	statements  []oc.Statement
+	policies    []oc.PolicyDefinition
+	assignments map[string]oc.ApplyPolicy
+}
+
+func defaultPolicyToConfig(t RouteType) oc.DefaultPolicyType {
+	if t == ROUTE_TYPE_REJECT {
+		return oc.DEFAULT_POLICY_TYPE_REJECT_ROUTE
+	}
+	return oc.DEFAULT_POLICY_TYPE_ACCEPT_ROUTE
+}
+
+func policyNames(policies []*Policy) []string {
+	names := make([]string, 0, len(policies))
+	for _, p := range policies {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
+// Snapshot returns the configuration of the routing policy.
+func (r *RoutingPolicy) Snapshot() *PolicySnapshot {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var dl DefinedSetList
+	for _, m := range r.definedSetMap {
+		for _, d := range m {
+			dl = append(dl, d)
+		}
+	}
+	sort.Sort(dl)
+	s := &PolicySnapshot{
+		definedSets: *definedSetsToConfig(dl, ""),
+		statements:  make([]oc.Statement, 0, len(r.statementMap)),
+		policies:    make([]oc.PolicyDefinition, 0, len(r.policyMap)),
+		assignments: make(map[string]oc.ApplyPolicy, len(r.assignmentMap)),
+	}
+	for _, name := range slices.Sorted(maps.Keys(r.statementMap)) {
+		s.statements = append(s.statements, *r.statementMap[name].ToConfig())
+	}
+	for _, name := range slices.Sorted(maps.Keys(r.policyMap)) {
+		s.policies = append(s.policies, *r.policyMap[name].ToConfig())
+	}
+	for id, a := range r.assignmentMap {
+		c := oc.ApplyPolicy{}
+		c.Config.ImportPolicyList = policyNames(a.importPolicies)
+		c.Config.DefaultImportPolicy = defaultPolicyToConfig(a.defaultImportPolicy)
+		c.Config.ExportPolicyList = policyNames(a.exportPolicies)
+		c.Config.DefaultExportPolicy = defaultPolicyToConfig(a.defaultExportPolicy)
+		s.assignments[id] = c
+	}
+	return s
+}
+
+// HasAssignment reports whether the snapshot has the policy assignment of
+// the id.
+func (s *PolicySnapshot) HasAssignment(id string) bool {
+	_, ok := s.assignments[id]
+	return ok
+}
+
+// NewRoutingPolicyFromSnapshot creates the routing policy of the snapshot,
+// which shares nothing with the one the snapshot was taken from.
+func NewRoutingPolicyFromSnapshot(logger *slog.Logger, s *PolicySnapshot) (*RoutingPolicy, error) {
+	r := NewRoutingPolicy(logger)
+	if err := r.reload(oc.RoutingPolicy{DefinedSets: s.definedSets}); err != nil {
+		return nil, err
+	}
+	for _, c := range s.statements {
+		st, err := NewStatement(c)
+		if err != nil {
+			return nil, err
+		}
+		r.statementMap[st.Name] = st
+	}
+	for _, c := range s.policies {
+		p, err := NewPolicy(c)
+		if err != nil {
+			return nil, err
+		}
+		// the statements may be shared by the policies
+		if err := p.FillUp(r.statementMap); err != nil {
+			return nil, err
+		}
+		r.policyMap[p.Name] = p
+	}
+	// the statements may call the policies
+	for _, st := range r.statementMap {
+		for _, c := range st.Conditions {
+			if err := r.validateCondition(c); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := checkPolicies(r.policyMap); err != nil {
+		return nil, err
+	}
+	for id, c := range s.assignments {
+		for _, dir := range []PolicyDirection{POLICY_DIRECTION_IMPORT, POLICY_DIRECTION_EXPORT} {
+			ps, def, err := r.getAssignmentFromConfig(dir, c)
+			if err != nil {
+				return nil, err
+			}
+			r.setDefaultPolicy(id, dir, def)
+			r.setPolicy(id, dir, ps)
+		}
+	}
+	return r, nil
+}
+
+// Load replaces the whole routing policy with the snapshot at once.
+func (r *RoutingPolicy) Load(s *PolicySnapshot) error {
+	n, err := NewRoutingPolicyFromSnapshot(r.logger, s)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n.keepCounters(r)
+	r.definedSetMap = n.definedSetMap
+	r.policyMap = n.policyMap
+	r.statementMap = n.statementMap
+	r.assignmentMap = n.assignmentMap
+	return nil
+}
+
+// PolicyDiff is the change of a defined set, statement, policy or
+// assignment. Before and After are the configurations in JSON, empty when
+// it's added or deleted respectively.
+type PolicyDiff struct {
+	Kind   string
+	Name   string
+	Before string
+	After  string
+}
+
+func (s *PolicySnapshot) objects() map[[2]string]any {
+	m := make(map[[2]string]any)
+	d := s.definedSets
+	for _, x := range d.PrefixSets {
+		m[[2]string{"prefix-set", x.PrefixSetName}] = x
+	}
+	for _, x := range d.NeighborSets {
+		m[[2]string{"neighbor-set", x.NeighborSetName}] = x
+	}
+	bd := d.BgpDefinedSets
+	for _, x := range bd.AsPathSets {
+		m[[2]string{"as-path-set", x.AsPathSetName}] = x
+	}
+	for _, x := range bd.CommunitySets {
+		m[[2]string{"community-set", x.CommunitySetName}] = x
+	}
+	for _, x := range bd.ExtCommunitySets {
+		m[[2]string{"ext-community-set", x.ExtCommunitySetName}] = x
+	}
+	for _, x := range bd.LargeCommunitySets {
+		m[[2]string{"large-community-set", x.LargeCommunitySetName}] = x
+	}
+	for _, x := range s.statements {
+		m[[2]string{"statement", x.Name}] = x
+	}
+	for _, x := range s.policies {
+		m[[2]string{"policy", x.Name}] = x
+	}
+	for id, x := range s.assignments {
+		m[[2]string{"assignment", id}] = x.Config
+	}
+	return m
+}
+
+// Diff returns the changes from the snapshot to the other one, sorted by
+// the kinds and the names.
+func (s *PolicySnapshot) Diff(other *PolicySnapshot) []PolicyDiff {
+	before, after := s.objects(), other.objects()
+	keys := slices.Collect(maps.Keys(before))
+	for k := range after {
+		if _, ok := before[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	slices.SortFunc(keys, func(a, b [2]string) int {
+		return slices.Compare(a[:], b[:])
+	})
+	toJSON := func(v any) string {
+		if v == nil {
+			return ""
+		}
+		b, _ := json.Marshal(v)
+		return string(b)
+	}
+	var l []PolicyDiff
+	for _, k := range keys {
+		b, a := toJSON(before[k]), toJSON(after[k])
+		if b != a {
+			l = append(l, PolicyDiff{Kind: k[0], Name: k[1], Before: b, After: a})
+		}
+	}
+	return l
+}
+
+// effective returns the configuration which the policy assignment of the
+// direction evaluates the paths with, the policies, the ones they call and
+// the defined sets they refer to, in JSON.
+func (s *PolicySnapshot) effective(id string, dir PolicyDirection) string {
+	c, ok := s.assignments[id]
+	if !ok {
+		return ""
+	}
+	names, def := c.Config.ImportPolicyList, c.Config.DefaultImportPolicy
+	if dir == POLICY_DIRECTION_EXPORT {
+		names, def = c.Config.ExportPolicyList, c.Config.DefaultExportPolicy
+	}
+	names = slices.Clone(names)
+	objects := s.objects()
+	l := []any{def}
+	seen := make(map[string]bool)
+	for len(names) > 0 {
+		name := names[0]
+		names = names[1:]
+		p, ok := objects[[2]string{"policy", name}]
+		if !ok || seen[name] {
+			continue
+		}
+		seen[name] = true
+		l = append(l, p)
+		for _, st := range p.(oc.PolicyDefinition).Statements {
+			cond := st.Conditions
+			if cond.CallPolicy != "" {
+				names = append(names, cond.CallPolicy)
+			}
+			bc := cond.BgpConditions
+			for _, set := range [][2]string{
+				{"prefix-set", cond.MatchPrefixSet.PrefixSet},
+				{"neighbor-set", cond.MatchNeighborSet.NeighborSet},
+				{"as-path-set", bc.MatchAsPathSet.AsPathSet},
+				{"community-set", bc.MatchCommunitySet.CommunitySet},
+				{"ext-community-set", bc.MatchExtCommunitySet.ExtCommunitySet},
+				{"large-community-set", bc.MatchLargeCommunitySet.LargeCommunitySet},
+			} {
+				if d, ok := objects[set]; ok {
+					l = append(l, d)
+				}
+			}
+		}
+	}
+	b, _ := json.Marshal(l)
+	return string(b)
+}
+
+// PolicyAssignmentChange is the policy assignment evaluating the paths
+// differently.
+type PolicyAssignmentChange struct {
+	ID        string
+	Direction PolicyDirection
+}
+
+func (c PolicyAssignmentChange) String() string {
+	return fmt.Sprintf("%s %s", c.ID, c.Direction)
+}
+
+// ChangedAssignments returns the policy assignments whose policies, or the
+// defined sets they refer to, differ between the snapshots.
+func (s *PolicySnapshot) ChangedAssignments(other *PolicySnapshot) []PolicyAssignmentChange {
+	ids := slices.Collect(maps.Keys(s.assignments))
+	for id := range other.assignments {
+		if _, ok := s.assignments[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	var l []PolicyAssignmentChange
+	for _, id := range ids {
+		for _, dir := range []PolicyDirection{POLICY_DIRECTION_IMPORT, POLICY_DIRECTION_EXPORT} {
+			if s.effective(id, dir) != other.effective(id, dir) {
+				l = append(l, PolicyAssignmentChange{ID: id, Direction: dir})
+			}
+		}
+	}
+	return l
+}
@@ -0,0 +1,82 @@
+// Copyright (C) 2025 Acnodal Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package table
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/osrg/gobgp/v4/pkg/config/oc"
+)
+
+func TestPolicySnapshot(t *testing.T) {
+	ds := oc.DefinedSets{}
+	ds.PrefixSets = []oc.PrefixSet{createPrefixSet("ps1", "10.10.0.0/16", "16..24"), createPrefixSet("ps2", "10.20.0.0/16", "")}
+	ds.NeighborSets = []oc.NeighborSet{createNeighborSet("ns1", "10.0.0.1")}
+	r := NewRoutingPolicy(logger)
+	require.NoError(t, r.reload(createRoutingPolicy(ds,
+		createPolicyDefinition("pd1", createStatement("st1", "ps1", "ns1", true)),
+		createPolicyDefinition("pd2", createStatement("st2", "ps2", "", false)))))
+	require.NoError(t, r.AddPolicyAssignment(GLOBAL_RIB_NAME, POLICY_DIRECTION_IMPORT, []*oc.PolicyDefinition{{Name: "pd1"}}, ROUTE_TYPE_REJECT))
+	require.NoError(t, r.AddPolicyAssignment("10.0.0.2", POLICY_DIRECTION_EXPORT, []*oc.PolicyDefinition{{Name: "pd2"}}, ROUTE_TYPE_ACCEPT))
+	// the statement shared by the policies and the one not in any policy
+	st3, err := NewStatement(createStatement("st3", "ps2", "", true))
+	require.NoError(t, err)
+	require.NoError(t, r.AddStatement(st3))
+	p3, err := NewPolicy(oc.PolicyDefinition{Name: "pd3", Statements: []oc.Statement{{Name: "st1"}}})
+	require.NoError(t, err)
+	require.NoError(t, r.AddPolicy(p3, true))
+
+	running := r.Snapshot()
+	c, err := NewRoutingPolicyFromSnapshot(logger, running)
+	require.NoError(t, err)
+	assert.Empty(t, running.Diff(c.Snapshot()))
+	assert.Empty(t, running.ChangedAssignments(c.Snapshot()))
+
+	// the candidate shares nothing with the running one
+	set, err := NewPrefixSet(createPrefixSet("ps2", "10.30.0.0/16", ""))
+	require.NoError(t, err)
+	require.NoError(t, c.AddDefinedSet(set, false))
+	require.NoError(t, c.SetPolicyAssignment(GLOBAL_RIB_NAME, POLICY_DIRECTION_EXPORT, []*oc.PolicyDefinition{{Name: "pd3"}}, ROUTE_TYPE_ACCEPT))
+	assert.Empty(t, running.Diff(r.Snapshot()))
+
+	candidate := c.Snapshot()
+	diff := running.Diff(candidate)
+	require.Len(t, diff, 2)
+	assert.Equal(t, "assignment", diff[0].Kind)
+	assert.Equal(t, GLOBAL_RIB_NAME, diff[0].Name)
+	assert.Equal(t, "prefix-set", diff[1].Kind)
+	assert.Equal(t, "ps2", diff[1].Name)
+	assert.NotEqual(t, diff[1].Before, diff[1].After)
+	// pd2 refers to ps2
+	assert.Equal(t, []PolicyAssignmentChange{
+		{ID: "10.0.0.2", Direction: POLICY_DIRECTION_EXPORT},
+		{ID: GLOBAL_RIB_NAME, Direction: POLICY_DIRECTION_EXPORT},
+	}, running.ChangedAssignments(candidate))
+
+	require.NoError(t, r.Load(candidate))
+	assert.Empty(t, candidate.Diff(r.Snapshot()))
+	_, policies, err := r.GetPolicyAssignment(GLOBAL_RIB_NAME, POLICY_DIRECTION_EXPORT)
+	require.NoError(t, err)
+	require.Len(t, policies, 1)
+	assert.Equal(t, "pd3", policies[0].Name)
+	assert.Len(t, r.GetStatement("st3"), 1)
+
+	require.NoError(t, r.Load(running))
+	assert.Empty(t, running.Diff(r.Snapshot()))
+}
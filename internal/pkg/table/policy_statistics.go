@@ -0,0 +1,244 @@
+// Copyright (C) 2025 Acnodal Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package table
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/osrg/gobgp/v4/api"
+)
+
+// PolicyStatistics is the counters of an assignment, a policy or a
+// statement.
+//
+// For a statement, Matches counts the paths matching all the conditions;
+// for a policy, the ones matching any statement; for an assignment, the
+// ones decided by a statement instead of the default policy. Accepts and
+// Rejects count the decisions made.
+type PolicyStatistics struct {
+	Evaluations uint64
+	Matches     uint64
+	Accepts     uint64
+	Rejects     uint64
+}
+
+func (s PolicyStatistics) ToApi() *api.PolicyStatistics {
+	return &api.PolicyStatistics{
+		Evaluations: s.Evaluations,
+		Matches:     s.Matches,
+		Accepts:     s.Accepts,
+		Rejects:     s.Rejects,
+	}
+}
+
+// policyCounters is updated by ApplyPolicy with the read lock of the
+// routing policy held, so the counters are atomic. It's shared by the
+// copies of the statement or the policy and nil for the ones not added to
+// a routing policy yet.
+type policyCounters struct {
+	evaluations atomic.Uint64
+	matches     atomic.Uint64
+	accepts     atomic.Uint64
+	rejects     atomic.Uint64
+}
+
+// count counts n evaluations with the same outcome.
+func (c *policyCounters) count(n uint64, matched bool, result RouteType) {
+	if c == nil || n == 0 {
+		return
+	}
+	c.evaluations.Add(n)
+	if matched {
+		c.matches.Add(n)
+	}
+	switch result {
+	case ROUTE_TYPE_ACCEPT:
+		c.accepts.Add(n)
+	case ROUTE_TYPE_REJECT:
+		c.rejects.Add(n)
+	}
+}
+
+// evaluations returns how many evaluations the one counts for. Only the
+// decisions on the paths received or sent are counted, once for each peer
+// sharing the decision; the traced ones and the ones of the views like
+// Adj-RIB-Out aren't.
+func evaluations(options *PolicyOptions, traced bool) uint64 {
+	switch {
+	case traced:
+		return 0
+	case options == nil:
+		return 1
+	case options.NoCount:
+		return 0
+	}
+	return 1 + uint64(len(options.SharedWith))
+}
+
+func (c *policyCounters) statistics() PolicyStatistics {
+	if c == nil {
+		return PolicyStatistics{}
+	}
+	return PolicyStatistics{
+		Evaluations: c.evaluations.Load(),
+		Matches:     c.matches.Load(),
+		Accepts:     c.accepts.Load(),
+		Rejects:     c.rejects.Load(),
+	}
+}
+
+func (c *policyCounters) reset() {
+	if c == nil {
+		return
+	}
+	c.evaluations.Store(0)
+	c.matches.Store(0)
+	c.accepts.Store(0)
+	c.rejects.Store(0)
+}
+
+func (s *Statement) Statistics() PolicyStatistics {
+	return s.counters.statistics()
+}
+
+func (p *Policy) Statistics() PolicyStatistics {
+	return p.counters.statistics()
+}
+
+// initCounters allocates the counters of the policy and its statements
+// created outside of the table package; called with the write lock held.
+func (p *Policy) initCounters() {
+	if p.counters == nil {
+		p.counters = &policyCounters{}
+	}
+	for _, st := range p.Statements {
+		st.initCounters()
+	}
+}
+
+func (s *Statement) initCounters() {
+	if s.counters == nil {
+		s.counters = &policyCounters{}
+	}
+}
+
+// keepCounters moves the counters of the statements, policies and
+// assignments of old to the ones with the same names, so that reloading
+// the configuration doesn't reset them.
+func (r *RoutingPolicy) keepCounters(old *RoutingPolicy) {
+	for name, st := range r.statementMap {
+		if o, ok := old.statementMap[name]; ok && o.counters != nil {
+			st.counters = o.counters
+		}
+	}
+	for name, p := range r.policyMap {
+		if o, ok := old.policyMap[name]; ok && o.counters != nil {
+			p.counters = o.counters
+		}
+	}
+	for id, a := range r.assignmentMap {
+		if o, ok := old.assignmentMap[id]; ok {
+			a.importCounters, a.exportCounters = o.importCounters, o.exportCounters
+		}
+	}
+}
+
+// PolicyStatistics returns the counters of the policy and its statements
+// in order.
+func (r *RoutingPolicy) PolicyStatistics(name string) (PolicyStatistics, []PolicyStatistics, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	p, ok := r.policyMap[name]
+	if !ok {
+		return PolicyStatistics{}, nil, fmt.Errorf("not found policy %s", name)
+	}
+	l := make([]PolicyStatistics, 0, len(p.Statements))
+	for _, st := range p.Statements {
+		l = append(l, st.Statistics())
+	}
+	return p.Statistics(), l, nil
+}
+
+func (r *RoutingPolicy) StatementStatistics(name string) (PolicyStatistics, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	st, ok := r.statementMap[name]
+	if !ok {
+		return PolicyStatistics{}, fmt.Errorf("not found statement %s", name)
+	}
+	return st.Statistics(), nil
+}
+
+func (r *RoutingPolicy) AssignmentStatistics(id string, dir PolicyDirection) PolicyStatistics {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, _, counters := r.getAssignment(id, dir)
+	return counters.statistics()
+}
+
+// ResetStatistics clears the counters of the policy and its statements,
+// or the ones of all the policies, statements and assignments if no name
+// is given.
+func (r *RoutingPolicy) ResetStatistics(name string) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if name == "" {
+		for _, st := range r.statementMap {
+			st.counters.reset()
+		}
+		for _, p := range r.policyMap {
+			p.counters.reset()
+		}
+		for _, a := range r.assignmentMap {
+			a.importCounters.reset()
+			a.exportCounters.reset()
+		}
+		return nil
+	}
+	p, ok := r.policyMap[name]
+	if !ok {
+		return fmt.Errorf("not found policy %s", name)
+	}
+	p.counters.reset()
+	for _, st := range p.Statements {
+		st.counters.reset()
+	}
+	return nil
+}
+
+// ResetAssignmentStatistics clears the counters of the assignment in the
+// direction, in both directions for POLICY_DIRECTION_NONE.
+func (r *RoutingPolicy) ResetAssignmentStatistics(id string, dir PolicyDirection) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	a, ok := r.assignmentMap[id]
+	if !ok {
+		return fmt.Errorf("not found policy assignment %s", id)
+	}
+	if dir != POLICY_DIRECTION_EXPORT {
+		a.importCounters.reset()
+	}
+	if dir != POLICY_DIRECTION_IMPORT {
+		a.exportCounters.reset()
+	}
+	return nil
+}
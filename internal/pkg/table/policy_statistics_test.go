@@ -0,0 +1,93 @@
+// Copyright (C) 2025 Acnodal Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package table
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/osrg/gobgp/v4/pkg/config/oc"
+	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
+)
+
+func TestPolicyStatistics(t *testing.T) {
+	newPath := func(prefix string) *Path {
+		nlri, _ := bgp.NewIPAddrPrefix(netip.MustParsePrefix(prefix))
+		nexthop, _ := bgp.NewPathAttributeNextHop(netip.MustParseAddr("10.0.0.1"))
+		attrs := []bgp.PathAttributeInterface{bgp.NewPathAttributeOrigin(0), nexthop}
+		return NewPath(bgp.RF_IPv4_UC, &PeerInfo{}, bgp.PathNLRI{NLRI: nlri}, false, attrs, time.Now(), false)
+	}
+	ds := oc.DefinedSets{}
+	ds.PrefixSets = []oc.PrefixSet{createPrefixSet("ps1", "10.10.0.0/16", "16..24"), createPrefixSet("ps2", "10.20.0.0/16", "16..24")}
+	rp := createRoutingPolicy(ds, createPolicyDefinition("pd1",
+		createStatement("st1", "ps1", "", false),
+		createStatement("st2", "ps2", "", true)))
+	r := NewRoutingPolicy(logger)
+	require.NoError(t, r.reload(rp))
+	require.NoError(t, r.AddPolicyAssignment(GLOBAL_RIB_NAME, POLICY_DIRECTION_IMPORT, []*oc.PolicyDefinition{{Name: "pd1"}}, ROUTE_TYPE_ACCEPT))
+
+	assert.Nil(t, r.ApplyPolicy(GLOBAL_RIB_NAME, POLICY_DIRECTION_IMPORT, newPath("10.10.1.0/24"), nil))
+	assert.NotNil(t, r.ApplyPolicy(GLOBAL_RIB_NAME, POLICY_DIRECTION_IMPORT, newPath("10.20.1.0/24"), nil))
+	assert.NotNil(t, r.ApplyPolicy(GLOBAL_RIB_NAME, POLICY_DIRECTION_IMPORT, newPath("10.30.1.0/24"), nil))
+	// not counted
+	_, _, _, err := r.TestPolicy(GLOBAL_RIB_NAME, POLICY_DIRECTION_IMPORT, nil, newPath("10.10.1.0/24"), nil)
+	require.NoError(t, err)
+	assert.Nil(t, r.ApplyPolicy(GLOBAL_RIB_NAME, POLICY_DIRECTION_IMPORT, newPath("10.10.1.0/24"), &PolicyOptions{NoCount: true}))
+
+	check := func() {
+		assert.Equal(t, PolicyStatistics{Evaluations: 3, Matches: 2, Accepts: 2, Rejects: 1}, r.AssignmentStatistics(GLOBAL_RIB_NAME, POLICY_DIRECTION_IMPORT))
+		assert.Equal(t, PolicyStatistics{}, r.AssignmentStatistics(GLOBAL_RIB_NAME, POLICY_DIRECTION_EXPORT))
+		p, stmts, err := r.PolicyStatistics("pd1")
+		require.NoError(t, err)
+		assert.Equal(t, PolicyStatistics{Evaluations: 3, Matches: 2, Accepts: 1, Rejects: 1}, p)
+		assert.Equal(t, []PolicyStatistics{
+			{Evaluations: 3, Matches: 1, Rejects: 1},
+			{Evaluations: 2, Matches: 1, Accepts: 1},
+		}, stmts)
+	}
+	check()
+
+	// kept over reloading the same configuration
+	require.NoError(t, r.Reset(&rp, map[string]oc.ApplyPolicy{GLOBAL_RIB_NAME: {Config: oc.ApplyPolicyConfig{
+		ImportPolicyList:    []string{"pd1"},
+		DefaultImportPolicy: oc.DEFAULT_POLICY_TYPE_ACCEPT_ROUTE,
+	}}}))
+	check()
+	require.NoError(t, r.Load(r.Snapshot()))
+	check()
+
+	require.NoError(t, r.ResetAssignmentStatistics(GLOBAL_RIB_NAME, POLICY_DIRECTION_NONE))
+	assert.Equal(t, PolicyStatistics{}, r.AssignmentStatistics(GLOBAL_RIB_NAME, POLICY_DIRECTION_IMPORT))
+	require.NoError(t, r.ResetStatistics("pd1"))
+	p, stmts, err := r.PolicyStatistics("pd1")
+	require.NoError(t, err)
+	assert.Equal(t, PolicyStatistics{}, p)
+	assert.Equal(t, []PolicyStatistics{{}, {}}, stmts)
+	assert.Error(t, r.ResetStatistics("pd2"))
+
+	// the statements added by the API are counted too
+	require.NoError(t, r.AddPolicy(&Policy{Name: "pd3", Statements: []*Statement{{Name: "st4"}}}, false))
+	require.NoError(t, r.AddPolicyAssignment(GLOBAL_RIB_NAME, POLICY_DIRECTION_EXPORT, []*oc.PolicyDefinition{{Name: "pd3"}}, ROUTE_TYPE_REJECT))
+	assert.Nil(t, r.ApplyPolicy(GLOBAL_RIB_NAME, POLICY_DIRECTION_EXPORT, newPath("10.10.1.0/24"), nil))
+	st, err := r.StatementStatistics("st4")
+	require.NoError(t, err)
+	assert.Equal(t, PolicyStatistics{Evaluations: 1, Matches: 1}, st)
+	assert.Equal(t, PolicyStatistics{Evaluations: 1, Rejects: 1}, r.AssignmentStatistics(GLOBAL_RIB_NAME, POLICY_DIRECTION_EXPORT))
+}
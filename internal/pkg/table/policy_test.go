@@ -1414,6 +1414,71 @@ func TestAsPathCondition(t *testing.T) {
 	}
 }
 
+func TestAsPathConditionOrigins(t *testing.T) {
+	list := []string{"_65100_", "^65200_"}
+	for asn := 1; asn <= 1000; asn++ {
+		list = append(list, fmt.Sprintf("_%d$", asn))
+	}
+	s, err := NewAsPathSet(oc.AsPathSet{
+		AsPathSetName: "origins",
+		AsPathList:    list,
+	})
+	require.NoError(t, err)
+	assert.Len(t, s.origins, 1000)
+	assert.Len(t, s.others, 2)
+
+	makePath := func(ases ...uint32) *Path {
+		aspath := bgp.NewPathAttributeAsPath([]bgp.AsPathParamInterface{
+			bgp.NewAs4PathParam(bgp.BGP_ASPATH_ATTR_TYPE_SEQ, ases),
+		})
+		return NewPath(bgp.RF_IPv4_UC, nil, bgp.PathNLRI{}, false, []bgp.PathAttributeInterface{aspath}, time.Time{}, false)
+	}
+
+	tests := []struct {
+		path   *Path
+		any    bool
+		invert bool
+		all    bool
+	}{
+		{makePath(65001, 500), true, false, false},
+		{makePath(65001, 65100, 1001), true, false, false},
+		{makePath(65200, 1001), true, false, false},
+		{makePath(65001, 1001), false, true, false},
+		{makePath(500, 65001), false, true, false},
+		{makePath(), false, true, false},
+	}
+	for _, option := range []oc.MatchSetOptionsType{oc.MATCH_SET_OPTIONS_TYPE_ANY, oc.MATCH_SET_OPTIONS_TYPE_INVERT, oc.MATCH_SET_OPTIONS_TYPE_ALL} {
+		c, err := NewAsPathCondition(oc.MatchAsPathSet{
+			AsPathSet:       "origins",
+			MatchSetOptions: option,
+		})
+		require.NoError(t, err)
+		c.set = s
+		for _, tt := range tests {
+			expected := tt.any
+			switch option {
+			case oc.MATCH_SET_OPTIONS_TYPE_INVERT:
+				expected = tt.invert
+			case oc.MATCH_SET_OPTIONS_TYPE_ALL:
+				expected = tt.all
+			}
+			assert.Equal(t, expected, c.Evaluate(tt.path, nil), "%s %s", option, tt.path.GetAsString())
+		}
+	}
+
+	// the index follows the changes of the set
+	r, err := NewAsPathSet(oc.AsPathSet{
+		AsPathSetName: "origins",
+		AsPathList:    []string{"_500$"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, s.Remove(r))
+	assert.Len(t, s.origins, 999)
+	assert.NotContains(t, s.origins, uint32(500))
+	require.NoError(t, s.Append(r))
+	assert.Contains(t, s.origins, uint32(500))
+}
+
 func TestAsPathConditionWithOtherCondition(t *testing.T) {
 	// setup
 	// create path
@@ -3940,3 +4005,440 @@ func TestNewSingleAsPathMatch(t *testing.T) {
 	r = NewSingleAsPathMatch("^65100$")
 	assert.Equal(t, r.mode, ONLY)
 }
+
+func TestTestPolicy(t *testing.T) {
+	peer := &PeerInfo{AS: 65001, Address: netip.MustParseAddr("10.0.0.1")}
+	origin := bgp.NewPathAttributeOrigin(0)
+	aspath := bgp.NewPathAttributeAsPath([]bgp.AsPathParamInterface{bgp.NewAs4PathParam(2, []uint32{65001})})
+	nexthop, _ := bgp.NewPathAttributeNextHop(netip.MustParseAddr("10.0.0.1"))
+	nlri, _ := bgp.NewIPAddrPrefix(netip.MustParsePrefix("10.10.0.0/24"))
+	updateMsg := bgp.NewBGPUpdateMessage(nil, []bgp.PathAttributeInterface{origin, aspath, nexthop}, []bgp.PathNLRI{{NLRI: nlri}})
+	path := ProcessMessage(updateMsg, peer, time.Now(), false)[0]
+
+	ds := oc.DefinedSets{}
+	ds.PrefixSets = []oc.PrefixSet{createPrefixSet("ps1", "10.10.0.0/16", "16..24"), createPrefixSet("ps2", "10.20.0.0/16", "")}
+	ds.NeighborSets = []oc.NeighborSet{createNeighborSet("ns1", "10.0.0.1")}
+	s1 := createStatement("st1", "ps2", "", false)
+	s2 := createStatement("st2", "ps1", "ns1", true)
+	s2.Actions.BgpActions.SetCommunity = createSetCommunity("ADD", "65000:100")
+	r := NewRoutingPolicy(logger)
+	require.NoError(t, r.reload(createRoutingPolicy(ds, createPolicyDefinition("pd1", s1, s2), createPolicyDefinition("pd2", createStatement("st3", "ps2", "", true)))))
+	require.NoError(t, r.AddPolicyAssignment(GLOBAL_RIB_NAME, POLICY_DIRECTION_IMPORT, []*oc.PolicyDefinition{{Name: "pd2"}}, ROUTE_TYPE_REJECT))
+
+	result, after, trace, err := r.TestPolicy(GLOBAL_RIB_NAME, POLICY_DIRECTION_IMPORT, []string{"pd1"}, path, nil)
+	require.NoError(t, err)
+	assert.Equal(t, ROUTE_TYPE_ACCEPT, result)
+	assert.Equal(t, []uint32{stringToCommunityValue("65000:100")}, after.GetCommunities())
+	// the path under test isn't modified
+	assert.Empty(t, path.GetCommunities())
+	assert.False(t, trace.Default)
+	assert.Equal(t, []*StatementTrace{
+		{
+			Policy:     "pd1",
+			Statement:  "st1",
+			Conditions: []ConditionTrace{{Type: CONDITION_PREFIX, Name: "ps2"}},
+		},
+		{
+			Policy:    "pd1",
+			Statement: "st2",
+			Conditions: []ConditionTrace{
+				{Type: CONDITION_PREFIX, Name: "ps1", Matched: true},
+				{Type: CONDITION_NEIGHBOR, Name: "ns1", Matched: true},
+			},
+			Matched: true,
+			Actions: []string{"set-community add[65000:100]"},
+			Result:  ROUTE_TYPE_ACCEPT,
+		},
+	}, trace.Statements)
+
+	// the assignment and its default
+	result, after, trace, err = r.TestPolicy(GLOBAL_RIB_NAME, POLICY_DIRECTION_IMPORT, nil, path, nil)
+	require.NoError(t, err)
+	assert.Equal(t, ROUTE_TYPE_REJECT, result)
+	assert.Equal(t, path, after)
+	assert.True(t, trace.Default)
+	assert.Len(t, trace.Statements, 1)
+
+	_, _, _, err = r.TestPolicy(GLOBAL_RIB_NAME, POLICY_DIRECTION_IMPORT, []string{"none"}, path, nil)
+	assert.Error(t, err)
+}
+
+func TestPolicyChaining(t *testing.T) {
+	newPath := func(prefix string) *Path {
+		origin := bgp.NewPathAttributeOrigin(0)
+		aspath := bgp.NewPathAttributeAsPath([]bgp.AsPathParamInterface{bgp.NewAs4PathParam(2, []uint32{65001})})
+		nexthop, _ := bgp.NewPathAttributeNextHop(netip.MustParseAddr("10.0.0.1"))
+		nlri, _ := bgp.NewIPAddrPrefix(netip.MustParsePrefix(prefix))
+		return NewPath(bgp.RF_IPv4_UC, nil, bgp.PathNLRI{NLRI: nlri}, false, []bgp.PathAttributeInterface{origin, aspath, nexthop}, time.Now(), false)
+	}
+	ds := oc.DefinedSets{}
+	ds.PrefixSets = []oc.PrefixSet{createPrefixSet("ps1", "10.10.0.0/16", "16..24")}
+
+	sub := createStatement("sub1", "ps1", "", true)
+	sub.Actions.BgpActions.SetMed = "100"
+	call := oc.Statement{Name: "call1"}
+	call.Conditions.CallPolicy = "sub"
+	call.Actions.RouteDisposition = oc.ROUTE_DISPOSITION_ACCEPT_ROUTE
+	call.Actions.BgpActions.SetLocalPref = 200
+
+	next := createStatement("next1", "ps1", "", false)
+	next.Actions.RouteDisposition = oc.ROUTE_DISPOSITION_NONE
+	next.Actions.FlowControl = oc.FLOW_CONTROL_TYPE_NEXT_POLICY
+
+	jump := createStatement("goto1", "ps1", "", false)
+	jump.Actions.RouteDisposition = oc.ROUTE_DISPOSITION_NONE
+	jump.Actions.BgpActions.SetMed = "10"
+	jump.Actions.FlowControl = oc.FLOW_CONTROL_TYPE_GOTO
+	jump.Actions.GotoStatement = "goto3"
+
+	r := NewRoutingPolicy(logger)
+	require.NoError(t, r.reload(createRoutingPolicy(ds,
+		createPolicyDefinition("sub", sub),
+		createPolicyDefinition("main", call),
+		createPolicyDefinition("skip", next, createStatement("next2", "", "", false)),
+		createPolicyDefinition("jump", jump, createStatement("goto2", "", "", false), createStatement("goto3", "", "", true)),
+		createPolicyDefinition("accept", createStatement("accept1", "", "", true)))))
+
+	// the modifications made by the policy called are kept
+	result, after := r.policyMap["main"].Apply(logger, newPath("10.10.0.0/24"), nil)
+	assert.Equal(t, ROUTE_TYPE_ACCEPT, result)
+	med, _ := after.GetMed()
+	assert.Equal(t, uint32(100), med)
+	localPref, _ := after.GetLocalPref()
+	assert.Equal(t, uint32(200), localPref)
+	result, after = r.policyMap["main"].Apply(logger, newPath("10.20.0.0/24"), nil)
+	assert.Equal(t, ROUTE_TYPE_NONE, result)
+	_, err := after.GetMed()
+	assert.Error(t, err)
+
+	result, _ = r.apply([]*Policy{r.policyMap["skip"], r.policyMap["accept"]}, ROUTE_TYPE_REJECT, newPath("10.10.0.0/24"), nil, nil)
+	assert.Equal(t, ROUTE_TYPE_ACCEPT, result)
+	result, _ = r.apply([]*Policy{r.policyMap["skip"], r.policyMap["accept"]}, ROUTE_TYPE_REJECT, newPath("10.20.0.0/24"), nil, nil)
+	assert.Equal(t, ROUTE_TYPE_REJECT, result)
+
+	result, after = r.policyMap["jump"].Apply(logger, newPath("10.10.0.0/24"), nil)
+	assert.Equal(t, ROUTE_TYPE_ACCEPT, result)
+	med, _ = after.GetMed()
+	assert.Equal(t, uint32(10), med)
+	result, _ = r.policyMap["jump"].Apply(logger, newPath("10.20.0.0/24"), nil)
+	assert.Equal(t, ROUTE_TYPE_REJECT, result)
+
+	// the policy called can't be deleted
+	assert.Error(t, r.DeletePolicy(&Policy{Name: "sub"}, true, false, nil))
+	// nor the statement a goto jumps to
+	assert.Error(t, r.DeletePolicy(&Policy{Name: "jump", Statements: []*Statement{{Name: "goto3"}}}, false, true, nil))
+	assert.Len(t, r.policyMap["jump"].Statements, 3)
+
+	// the statement calling the policy it's in
+	loop, err := NewStatement(oc.Statement{Name: "sub1", Conditions: oc.Conditions{CallPolicy: "main"}})
+	require.NoError(t, err)
+	assert.Error(t, r.AddStatement(loop))
+	assert.Len(t, r.statementMap["sub1"].Conditions, 1)
+
+	running := r.Snapshot()
+	c, err := NewRoutingPolicyFromSnapshot(logger, running)
+	require.NoError(t, err)
+	assert.Empty(t, running.Diff(c.Snapshot()))
+
+	for _, pd := range [][]oc.PolicyDefinition{
+		{
+			{Name: "p1", Statements: []oc.Statement{{Name: "s1", Conditions: oc.Conditions{CallPolicy: "p2"}}}},
+			{Name: "p2", Statements: []oc.Statement{{Name: "s2", Conditions: oc.Conditions{CallPolicy: "p1"}}}},
+		},
+		{
+			{Name: "p1", Statements: []oc.Statement{{Name: "s1", Conditions: oc.Conditions{CallPolicy: "p1"}}}},
+		},
+		{
+			{Name: "p1", Statements: []oc.Statement{{Name: "s1"}, {Name: "s2", Actions: oc.Actions{FlowControl: oc.FLOW_CONTROL_TYPE_GOTO, GotoStatement: "s1"}}}},
+		},
+		{
+			{Name: "p1", Statements: []oc.Statement{{Name: "s1", Actions: oc.Actions{FlowControl: oc.FLOW_CONTROL_TYPE_GOTO, GotoStatement: "s3"}}}},
+		},
+		{
+			{Name: "p1", Statements: []oc.Statement{{Name: "s1", Actions: oc.Actions{RouteDisposition: oc.ROUTE_DISPOSITION_ACCEPT_ROUTE, FlowControl: oc.FLOW_CONTROL_TYPE_NEXT_POLICY}}}},
+		},
+	} {
+		assert.Error(t, NewRoutingPolicy(logger).reload(createRoutingPolicy(oc.DefinedSets{}, pd...)))
+	}
+}
+
+func TestPolicyExpandedActions(t *testing.T) {
+	nlri, _ := bgp.NewIPAddrPrefix(netip.MustParsePrefix("10.0.0.0/24"))
+	newPath := func() *Path {
+		aspath := bgp.NewPathAttributeAsPath([]bgp.AsPathParamInterface{
+			bgp.NewAs4PathParam(bgp.BGP_ASPATH_ATTR_TYPE_SEQ, []uint32{100, 64512, 200, 4200000000}),
+		})
+		nexthop, _ := bgp.NewPathAttributeNextHop(netip.MustParseAddr("10.0.0.1"))
+		attrs := []bgp.PathAttributeInterface{bgp.NewPathAttributeOrigin(0), aspath, nexthop}
+		return NewPath(bgp.RF_IPv4_UC, nil, bgp.PathNLRI{NLRI: nlri}, false, attrs, time.Now(), false)
+	}
+	options := &PolicyOptions{Info: &PeerInfo{LocalAS: 65100}}
+
+	t.Run("as-path replace", func(t *testing.T) {
+		a, err := NewAsPathReplaceAction(oc.SetAsPathReplace{AsList: []string{"100", "200"}})
+		require.NoError(t, err)
+		p, _ := a.Apply(newPath(), options)
+		assert.Equal(t, []uint32{65100, 64512, 65100, 4200000000}, p.GetAsSeqList())
+
+		a, err = NewAsPathReplaceAction(oc.SetAsPathReplace{AsList: []string{"any"}, ReplaceAs: 65200})
+		require.NoError(t, err)
+		p, _ = a.Apply(newPath(), options)
+		assert.Equal(t, []uint32{65200, 65200, 65200, 65200}, p.GetAsSeqList())
+		assert.Equal(t, &oc.SetAsPathReplace{AsList: []string{"any"}, ReplaceAs: 65200}, a.ToConfig())
+
+		_, err = NewAsPathReplaceAction(oc.SetAsPathReplace{AsList: []string{"any", "100"}})
+		assert.Error(t, err)
+		_, err = NewAsPathReplaceAction(oc.SetAsPathReplace{ReplaceAs: 65200})
+		assert.Error(t, err)
+	})
+
+	t.Run("remove private as", func(t *testing.T) {
+		a, err := NewRemovePrivateAsAction(oc.REMOVE_PRIVATE_AS_OPTION_ALL)
+		require.NoError(t, err)
+		p, _ := a.Apply(newPath(), options)
+		assert.Equal(t, []uint32{100, 200}, p.GetAsSeqList())
+
+		a, err = NewRemovePrivateAsAction(oc.REMOVE_PRIVATE_AS_OPTION_REPLACE)
+		require.NoError(t, err)
+		p, _ = a.Apply(newPath(), options)
+		assert.Equal(t, []uint32{100, 65100, 200, 65100}, p.GetAsSeqList())
+
+		_, err = NewRemovePrivateAsAction("invalid")
+		assert.Error(t, err)
+	})
+
+	t.Run("as-path exclude", func(t *testing.T) {
+		a, err := NewAsPathExcludeAction([]uint32{100, 200})
+		require.NoError(t, err)
+		p, _ := a.Apply(newPath(), options)
+		assert.Equal(t, []uint32{64512, 4200000000}, p.GetAsSeqList())
+	})
+
+	t.Run("weight and tag", func(t *testing.T) {
+		w, err := NewWeightAction(100)
+		require.NoError(t, err)
+		tag, err := NewTagAction("0x10")
+		require.NoError(t, err)
+		p := newPath()
+		p, _ = w.Apply(p, options)
+		p, _ = tag.Apply(p, options)
+		assert.Equal(t, uint32(100), p.GetWeight())
+		assert.Equal(t, uint32(16), p.GetTag())
+		// kept over the clone
+		p = p.Clone(false)
+		assert.Equal(t, uint32(100), p.GetWeight())
+		assert.Equal(t, uint32(16), p.GetTag())
+		assert.Equal(t, oc.TagType("16"), tag.ToConfig())
+
+		_, err = NewTagAction("foo")
+		assert.Error(t, err)
+	})
+
+	t.Run("aigp", func(t *testing.T) {
+		p := newPath()
+		a, err := NewAigpAction("+10")
+		require.NoError(t, err)
+		// doesn't add AIGP to the path without it
+		p, err = a.Apply(p, options)
+		assert.NoError(t, err)
+		_, ok := p.GetAigp()
+		assert.False(t, ok)
+
+		set, err := NewAigpAction("100")
+		require.NoError(t, err)
+		p, _ = set.Apply(p, options)
+		p, _ = a.Apply(p, options)
+		v, ok := p.GetAigp()
+		assert.True(t, ok)
+		assert.Equal(t, uint64(110), v)
+
+		sub, err := NewAigpAction("-200")
+		require.NoError(t, err)
+		_, err = sub.Apply(p, options)
+		assert.Error(t, err)
+		assert.Equal(t, oc.BgpSetAigpType("-200"), sub.ToConfig())
+
+		_, err = NewAigpAction("foo")
+		assert.Error(t, err)
+	})
+
+	t.Run("link bandwidth", func(t *testing.T) {
+		a, err := NewLinkBandwidthAction(100)
+		require.NoError(t, err)
+		p := newPath()
+		p.SetExtCommunities([]bgp.ExtendedCommunityInterface{bgp.NewLinkBandwidthExtended(65001, 1000)}, false)
+		p, _ = a.Apply(p, options)
+		l := p.GetExtCommunities()
+		require.Len(t, l, 1)
+		assert.Equal(t, bgp.NewLinkBandwidthExtended(65100, 12500000), l[0])
+
+		// AS_TRANS for the 4-octet local AS
+		p, _ = a.Apply(p, &PolicyOptions{Info: &PeerInfo{LocalAS: 4200000000}})
+		assert.Equal(t, uint16(bgp.AS_TRANS), p.GetExtCommunities()[0].(*bgp.LinkBandwidthExtended).AS)
+	})
+
+	t.Run("statement", func(t *testing.T) {
+		s := oc.Statement{Name: "s1"}
+		s.Actions.RouteDisposition = oc.ROUTE_DISPOSITION_NONE
+		s.Actions.BgpActions.SetAsPathExclude = []uint32{200}
+		s.Actions.BgpActions.SetAsPathRemovePrivateAs = oc.REMOVE_PRIVATE_AS_OPTION_ALL
+		s.Actions.BgpActions.SetAsPathPrepend = oc.SetAsPathPrepend{As: "65100", RepeatN: 1}
+		s.Actions.BgpActions.SetWeight = 10
+		s.Actions.IgpActions.SetTag = "20"
+		s.Actions.BgpActions.SetAigp = "30"
+		s.Actions.BgpActions.SetLinkBandwidth = 1000
+		stmt, err := NewStatement(s)
+		require.NoError(t, err)
+		assert.Len(t, stmt.ModActions, 7)
+		assert.True(t, stmt.ToConfig().Actions.Equal(&s.Actions))
+
+		_, p, matched := stmt.apply(logger, newPath(), options, nil)
+		assert.True(t, matched)
+		// the AS-path received is rewritten before prepending to it
+		assert.Equal(t, []uint32{65100, 100}, p.GetAsSeqList())
+		assert.Equal(t, uint32(10), p.GetWeight())
+		assert.Equal(t, uint32(20), p.GetTag())
+	})
+}
+
+func TestPolicyAdditionalConditions(t *testing.T) {
+	newPath := func(prefix string, source *PeerInfo, age time.Duration, attrs ...bgp.PathAttributeInterface) *Path {
+		nlri, _ := bgp.NewIPAddrPrefix(netip.MustParsePrefix(prefix))
+		aspath := bgp.NewPathAttributeAsPath([]bgp.AsPathParamInterface{
+			bgp.NewAs4PathParam(bgp.BGP_ASPATH_ATTR_TYPE_CONFED_SEQ, []uint32{65001}),
+			bgp.NewAs4PathParam(bgp.BGP_ASPATH_ATTR_TYPE_SEQ, []uint32{100, 200, 300}),
+		})
+		nexthop, _ := bgp.NewPathAttributeNextHop(netip.MustParseAddr("10.0.0.1"))
+		attrs = append([]bgp.PathAttributeInterface{bgp.NewPathAttributeOrigin(0), aspath, nexthop}, attrs...)
+		return NewPath(bgp.RF_IPv4_UC, source, bgp.PathNLRI{NLRI: nlri}, false, attrs, time.Now().Add(-age), false)
+	}
+	ebgp := &PeerInfo{AS: 100, LocalAS: 65000, Address: netip.MustParseAddr("10.0.0.1")}
+	ibgp := &PeerInfo{AS: 65000, LocalAS: 65000, Address: netip.MustParseAddr("10.0.0.2")}
+	confed := &PeerInfo{AS: 65001, LocalAS: 65000, Address: netip.MustParseAddr("10.0.0.3"), Confederation: true}
+	netlink := &PeerInfo{IsNetlink: true}
+
+	t.Run("origin and neighbor as", func(t *testing.T) {
+		p := newPath("10.0.0.0/24", ebgp, 0)
+		c, err := NewOriginAsCondition([]uint32{300, 400})
+		require.NoError(t, err)
+		assert.True(t, c.Evaluate(p, nil))
+		c, _ = NewOriginAsCondition([]uint32{100})
+		assert.False(t, c.Evaluate(p, nil))
+
+		n, err := NewNeighborAsCondition([]uint32{100})
+		require.NoError(t, err)
+		assert.True(t, n.Evaluate(p, nil))
+		n, _ = NewNeighborAsCondition([]uint32{65001})
+		assert.False(t, n.Evaluate(p, nil))
+	})
+
+	t.Run("prefix length", func(t *testing.T) {
+		c, err := NewPrefixLengthCondition("16..24")
+		require.NoError(t, err)
+		assert.True(t, c.Evaluate(newPath("10.0.0.0/24", ebgp, 0), nil))
+		assert.True(t, c.Evaluate(newPath("10.0.0.0/16", ebgp, 0), nil))
+		assert.False(t, c.Evaluate(newPath("10.0.0.0/25", ebgp, 0), nil))
+
+		for _, r := range []string{"24..16", "0..129", "16-24"} {
+			_, err = NewPrefixLengthCondition(r)
+			assert.Error(t, err, r)
+		}
+	})
+
+	t.Run("source peer type", func(t *testing.T) {
+		c, err := NewSourcePeerTypeCondition([]oc.SourcePeerType{oc.SOURCE_PEER_TYPE_IBGP, oc.SOURCE_PEER_TYPE_CONFED})
+		require.NoError(t, err)
+		assert.False(t, c.Evaluate(newPath("10.0.0.0/24", ebgp, 0), nil))
+		assert.True(t, c.Evaluate(newPath("10.0.0.0/24", ibgp, 0), nil))
+		assert.True(t, c.Evaluate(newPath("10.0.0.0/24", confed, 0), nil))
+		assert.False(t, c.Evaluate(newPath("10.0.0.0/24", netlink, 0), nil))
+		assert.False(t, c.Evaluate(newPath("10.0.0.0/24", &PeerInfo{}, 0), nil))
+
+		c, _ = NewSourcePeerTypeCondition([]oc.SourcePeerType{oc.SOURCE_PEER_TYPE_LOCAL, oc.SOURCE_PEER_TYPE_NETLINK})
+		assert.True(t, c.Evaluate(newPath("10.0.0.0/24", netlink, 0), nil))
+		assert.True(t, c.Evaluate(newPath("10.0.0.0/24", &PeerInfo{}, 0), nil))
+
+		_, err = NewSourcePeerTypeCondition([]oc.SourcePeerType{"invalid"})
+		assert.Error(t, err)
+	})
+
+	t.Run("path age", func(t *testing.T) {
+		c, err := NewPathAgeCondition(oc.PathAge{Operator: oc.ATTRIBUTE_COMPARISON_GE, Value: 60})
+		require.NoError(t, err)
+		assert.True(t, c.Evaluate(newPath("10.0.0.0/24", ebgp, 2*time.Minute), nil))
+		assert.False(t, c.Evaluate(newPath("10.0.0.0/24", ebgp, 0), nil))
+
+		c, _ = NewPathAgeCondition(oc.PathAge{Operator: oc.ATTRIBUTE_COMPARISON_LE, Value: 60})
+		assert.False(t, c.Evaluate(newPath("10.0.0.0/24", ebgp, 2*time.Minute), nil))
+		assert.True(t, c.Evaluate(newPath("10.0.0.0/24", ebgp, 0), nil))
+	})
+
+	t.Run("aigp", func(t *testing.T) {
+		aigp := bgp.NewPathAttributeAigp([]bgp.AigpTLVInterface{bgp.NewAigpTLVIgpMetric(100)})
+		c, err := NewAigpCondition("50..150")
+		require.NoError(t, err)
+		assert.True(t, c.Evaluate(newPath("10.0.0.0/24", ebgp, 0, aigp), nil))
+		assert.False(t, c.Evaluate(newPath("10.0.0.0/24", ebgp, 0), nil))
+		c, _ = NewAigpCondition("0..99")
+		assert.False(t, c.Evaluate(newPath("10.0.0.0/24", ebgp, 0, aigp), nil))
+	})
+
+	t.Run("attribute present", func(t *testing.T) {
+		c, err := NewAttributePresentCondition(uint8(bgp.BGP_ATTR_TYPE_MULTI_EXIT_DISC))
+		require.NoError(t, err)
+		assert.False(t, c.Evaluate(newPath("10.0.0.0/24", ebgp, 0), nil))
+		assert.True(t, c.Evaluate(newPath("10.0.0.0/24", ebgp, 0, bgp.NewPathAttributeMultiExitDisc(10)), nil))
+	})
+
+	t.Run("config", func(t *testing.T) {
+		conditions := oc.Conditions{
+			BgpConditions: oc.BgpConditions{
+				OriginAsInList:       []uint32{300},
+				NeighborAsInList:     []uint32{100},
+				PrefixLengthRange:    "16..24",
+				SourcePeerTypeList:   []oc.SourcePeerType{oc.SOURCE_PEER_TYPE_EBGP},
+				PathAge:              oc.PathAge{Operator: oc.ATTRIBUTE_COMPARISON_ATTRIBUTE_GE, Value: 60},
+				AigpRange:            "0..100",
+				AttributeTypePresent: uint8(bgp.BGP_ATTR_TYPE_MULTI_EXIT_DISC),
+			},
+		}
+		s, err := NewStatement(oc.Statement{Name: "stmt", Conditions: conditions})
+		require.NoError(t, err)
+		assert.Len(t, s.Conditions, 7)
+		assert.Equal(t, conditions.BgpConditions, s.ToConfig().Conditions.BgpConditions)
+	})
+}
+
+func TestReplaceDefinedSet(t *testing.T) {
+	nlri, _ := bgp.NewIPAddrPrefix(netip.MustParsePrefix("10.20.1.0/24"))
+	nexthop, _ := bgp.NewPathAttributeNextHop(netip.MustParseAddr("10.0.0.1"))
+	path := NewPath(bgp.RF_IPv4_UC, &PeerInfo{}, bgp.PathNLRI{NLRI: nlri}, false, []bgp.PathAttributeInterface{bgp.NewPathAttributeOrigin(0), nexthop}, time.Now(), false)
+
+	ds := oc.DefinedSets{}
+	ds.PrefixSets = []oc.PrefixSet{createPrefixSet("ps1", "10.10.0.0/16", "16..24")}
+	rp := createRoutingPolicy(ds, createPolicyDefinition("pd1", createStatement("st1", "ps1", "", false)))
+	r := NewRoutingPolicy(logger)
+	require.NoError(t, r.reload(rp))
+	require.NoError(t, r.AddPolicyAssignment(GLOBAL_RIB_NAME, POLICY_DIRECTION_IMPORT, []*oc.PolicyDefinition{{Name: "pd1"}}, ROUTE_TYPE_ACCEPT))
+	assert.NotNil(t, r.ApplyPolicy(GLOBAL_RIB_NAME, POLICY_DIRECTION_IMPORT, path, nil))
+
+	// the statement sees the new contents
+	set, err := NewPrefixSet(oc.PrefixSet{PrefixSetName: "ps1", PrefixList: []oc.Prefix{
+		{IpPrefix: netip.MustParsePrefix("10.10.0.0/16"), MasklengthRange: "16..24"},
+		{IpPrefix: netip.MustParsePrefix("10.20.0.0/16"), MasklengthRange: "16..24"},
+	}})
+	require.NoError(t, err)
+	added, removed, err := r.ReplaceDefinedSet(set)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.20.0.0/16 16..24"}, added)
+	assert.Empty(t, removed)
+	assert.Nil(t, r.ApplyPolicy(GLOBAL_RIB_NAME, POLICY_DIRECTION_IMPORT, path, nil))
+
+	set, err = NewPrefixSet(oc.PrefixSet{PrefixSetName: "ps2", PrefixList: []oc.Prefix{{IpPrefix: netip.MustParsePrefix("10.30.0.0/16")}}})
+	require.NoError(t, err)
+	added, _, err = r.ReplaceDefinedSet(set)
+	require.NoError(t, err)
+	assert.Len(t, added, 1)
+	_, err = r.GetDefinedSet(DEFINED_TYPE_PREFIX, "ps2")
+	assert.NoError(t, err)
+}
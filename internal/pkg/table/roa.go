@@ -16,6 +16,7 @@
 package table
 
 import (
+	"fmt"
 	"log/slog"
 	"net"
 	"sort"
@@ -68,9 +69,45 @@ func (r *roaBucket) GetEntries() []*ROA {
 	return r.entries
 }
 
+func (r *ROA) key() string {
+	return fmt.Sprintf("%s %d %d %s", r.Network, r.MaxLen, r.AS, r.Src)
+}
+
+type roaChange struct {
+	roa   *ROA
+	added bool
+}
+
+// ROAChanges are the ROAs added to and removed from the table between two
+// calls of TakeChanges, by the networks.
+type ROAChanges struct {
+	trees map[bgp.Family]*critbitgo.Net
+}
+
+type roaChangeBucket struct {
+	added   map[*ROA]struct{}
+	removed []*ROA
+}
+
+// Covers reports whether a changed ROA covers the prefix of the path.
+func (c *ROAChanges) Covers(path *Path) bool {
+	tree, ok := c.trees[path.GetFamily()]
+	if !ok {
+		return false
+	}
+	found := false
+	tree.WalkMatch(nlriToIPNet(path.GetNlri()), func(_ *net.IPNet, _ any) bool {
+		found = true
+		return false
+	})
+	return found
+}
+
 type ROATable struct {
 	trees  map[bgp.Family]*critbitgo.Net
 	logger *slog.Logger
+	// the net changes since the last TakeChanges
+	changes map[string]*roaChange
 }
 
 func NewROATable(logger *slog.Logger) *ROATable {
@@ -78,9 +115,55 @@ func NewROATable(logger *slog.Logger) *ROATable {
 	m[bgp.RF_IPv4_UC] = critbitgo.NewNet()
 	m[bgp.RF_IPv6_UC] = critbitgo.NewNet()
 	return &ROATable{
-		trees:  m,
-		logger: logger,
+		trees:   m,
+		logger:  logger,
+		changes: make(map[string]*roaChange),
+	}
+}
+
+func (rt *ROATable) recordChange(roa *ROA, added bool) {
+	key := roa.key()
+	if c, ok := rt.changes[key]; ok && c.added != added {
+		// withdrawn and announced again or vice versa
+		delete(rt.changes, key)
+		return
 	}
+	rt.changes[key] = &roaChange{roa: roa, added: added}
+}
+
+// TakeChanges returns the ROAs added and removed since the last call, or
+// nil if nothing is changed.
+func (rt *ROATable) TakeChanges() *ROAChanges {
+	if len(rt.changes) == 0 {
+		return nil
+	}
+	c := &ROAChanges{
+		trees: map[bgp.Family]*critbitgo.Net{
+			bgp.RF_IPv4_UC: critbitgo.NewNet(),
+			bgp.RF_IPv6_UC: critbitgo.NewNet(),
+		},
+	}
+	for _, change := range rt.changes {
+		family := bgp.RF_IPv4_UC
+		if change.roa.Family == bgp.AFI_IP6 {
+			family = bgp.RF_IPv6_UC
+		}
+		tree := c.trees[family]
+		var b *roaChangeBucket
+		if v, ok, _ := tree.Get(change.roa.Network); ok {
+			b = v.(*roaChangeBucket)
+		} else {
+			b = &roaChangeBucket{added: make(map[*ROA]struct{})}
+			_ = tree.Add(change.roa.Network, b)
+		}
+		if change.added {
+			b.added[change.roa] = struct{}{}
+		} else {
+			b.removed = append(b.removed, change.roa)
+		}
+	}
+	rt.changes = make(map[string]*roaChange)
+	return c
 }
 
 func (rt *ROATable) roa2tree(roa *ROA) *critbitgo.Net {
@@ -122,6 +205,7 @@ func (rt *ROATable) Add(roa *ROA) {
 		}
 	}
 	b.entries = append(b.entries, roa)
+	rt.recordChange(roa, true)
 	sort.Slice(b.entries, func(i, j int) bool {
 		r1 := b.entries[i]
 		r2 := b.entries[j]
@@ -146,6 +230,7 @@ func (rt *ROATable) Delete(roa *ROA) {
 		for i, r := range bucket.entries {
 			if r.Equal(roa) {
 				bucket.entries = append(bucket.entries[:i], bucket.entries[i+1:]...)
+				rt.recordChange(r, false)
 				return
 			}
 		}
@@ -167,6 +252,8 @@ func (rt *ROATable) DeleteAll(network string) {
 			for _, r := range b.entries {
 				if r.Src != network {
 					newEntries = append(newEntries, r)
+				} else {
+					rt.recordChange(r, false)
 				}
 			}
 			if len(newEntries) > 0 {
@@ -188,6 +275,18 @@ func (rt *ROATable) DeleteAll(network string) {
 }
 
 func (rt *ROATable) Validate(path *Path) *Validation {
+	return rt.validate(path, nil)
+}
+
+// ValidateChanges returns the validation results of the path before and
+// after the changes taken from the table.
+func (rt *ROATable) ValidateChanges(path *Path, c *ROAChanges) (*Validation, *Validation) {
+	return rt.validate(path, c), rt.validate(path, nil)
+}
+
+// validate checks the path with the ROAs in the table, or the ones before
+// the changes if c is given.
+func (rt *ROATable) validate(path *Path, c *ROAChanges) *Validation {
 	if path.IsWithdraw || path.IsEOR() {
 		// RPKI isn't enabled or invalid path
 		return nil
@@ -230,22 +329,44 @@ func (rt *ROATable) Validate(path *Path) *Validation {
 
 	r := nlriToIPNet(path.GetNlri())
 	prefixLen, _ := r.Mask.Size()
-	var bucket *roaBucket
-	tree.WalkMatch(r, func(r *net.IPNet, v any) bool {
-		bucket, _ = v.(*roaBucket)
-		for _, r := range bucket.entries {
-			if prefixLen <= int(r.MaxLen) {
-				if r.AS != 0 && r.AS == as {
-					validation.Matched = append(validation.Matched, r)
-				} else {
-					validation.UnmatchedAs = append(validation.UnmatchedAs, r)
-				}
+	check := func(r *ROA) {
+		if prefixLen <= int(r.MaxLen) {
+			if r.AS != 0 && r.AS == as {
+				validation.Matched = append(validation.Matched, r)
 			} else {
-				validation.UnmatchedLength = append(validation.UnmatchedLength, r)
+				validation.UnmatchedAs = append(validation.UnmatchedAs, r)
+			}
+		} else {
+			validation.UnmatchedLength = append(validation.UnmatchedLength, r)
+		}
+	}
+	var changes *critbitgo.Net
+	if c != nil {
+		changes = c.trees[path.GetFamily()]
+	}
+	tree.WalkMatch(r, func(n *net.IPNet, v any) bool {
+		bucket, _ := v.(*roaBucket)
+		var added map[*ROA]struct{}
+		if changes != nil {
+			if v, ok, _ := changes.Get(n); ok {
+				added = v.(*roaChangeBucket).added
+			}
+		}
+		for _, r := range bucket.entries {
+			if _, ok := added[r]; !ok {
+				check(r)
 			}
 		}
 		return true
 	})
+	if changes != nil {
+		changes.WalkMatch(r, func(_ *net.IPNet, v any) bool {
+			for _, r := range v.(*roaChangeBucket).removed {
+				check(r)
+			}
+			return true
+		})
+	}
 
 	if len(validation.Matched) != 0 {
 		validation.Status = oc.RPKI_VALIDATION_RESULT_TYPE_VALID
@@ -226,3 +226,40 @@ func TestValidate10(t *testing.T) {
 	r = validateOne(table, "10.0.0.0/24", "65001")
 	assert.Equal(r, oc.RPKI_VALIDATION_RESULT_TYPE_VALID)
 }
+
+func TestValidateChanges(t *testing.T) {
+	assert := assert.New(t)
+
+	table := NewROATable(logger)
+	table.Add(NewROA(bgp.AFI_IP, net.ParseIP("192.168.0.0").To4(), 16, 24, 100, "a"))
+	table.Add(NewROA(bgp.AFI_IP, net.ParseIP("10.0.0.0").To4(), 8, 8, 100, "a"))
+	assert.NotNil(table.TakeChanges())
+	assert.Nil(table.TakeChanges())
+
+	newPath := func(cidr string, as uint32) *Path {
+		nlri, _ := bgp.NewIPAddrPrefix(netip.MustParsePrefix(cidr))
+		attrs := []bgp.PathAttributeInterface{strToASParam(strconv.Itoa(int(as)))}
+		return NewPath(bgp.RF_IPv4_UC, &PeerInfo{LocalAS: 65500}, bgp.PathNLRI{NLRI: nlri}, false, attrs, time.Now(), false)
+	}
+
+	table.DeleteAll("a")
+	table.Add(NewROA(bgp.AFI_IP, net.ParseIP("192.168.0.0").To4(), 16, 24, 200, "b"))
+	// withdrawn and announced again
+	table.Add(NewROA(bgp.AFI_IP, net.ParseIP("10.0.0.0").To4(), 8, 8, 100, "a"))
+	c := table.TakeChanges()
+	assert.NotNil(c)
+
+	p := newPath("192.168.1.0/24", 100)
+	assert.True(c.Covers(p))
+	old, cur := table.ValidateChanges(p, c)
+	assert.Equal(oc.RPKI_VALIDATION_RESULT_TYPE_VALID, old.Status)
+	assert.Equal(oc.RPKI_VALIDATION_RESULT_TYPE_INVALID, cur.Status)
+
+	p = newPath("192.168.1.0/24", 200)
+	old, cur = table.ValidateChanges(p, c)
+	assert.Equal(oc.RPKI_VALIDATION_RESULT_TYPE_INVALID, old.Status)
+	assert.Equal(oc.RPKI_VALIDATION_RESULT_TYPE_VALID, cur.Status)
+
+	assert.False(c.Covers(newPath("10.0.0.0/8", 100)))
+	assert.False(c.Covers(newPath("172.16.0.0/12", 100)))
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/segmentio/fasthash/fnv1a"
 
 	"github.com/osrg/gobgp/v4/pkg/apiutil"
+	"github.com/osrg/gobgp/v4/pkg/config/oc"
 	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
 )
 
@@ -342,10 +343,10 @@ func (t *Table) getOrCreateDest(nlri bgp.NLRI, size int) *Destination {
 	return dest
 }
 
-func (t *Table) update(newPath *Path) *Update {
+func (t *Table) update(newPath *Path, opts *oc.RouteSelectionOptionsConfig) *Update {
 	t.validatePath(newPath)
 	dst := t.getOrCreateDest(newPath.GetNlri(), 64)
-	u := dst.Calculate(t.logger, newPath)
+	u := dst.Calculate(t.logger, newPath, opts)
 
 	if len(dst.knownPathList) == 0 {
 		t.deleteDest(dst)
@@ -557,10 +558,25 @@ func (t *Table) Bests(id string, as uint32) []*Path {
 	return paths
 }
 
-func (t *Table) MultiBests(id string) [][]*Path {
+func (t *Table) MultiBests(id string, opts func(*Path) *oc.RouteSelectionOptionsConfig) [][]*Path {
 	paths := make([][]*Path, 0, len(t.destinations))
 	for _, dst := range t.GetDestinations() {
-		path := dst.GetMultiBestPath(id)
+		var o *oc.RouteSelectionOptionsConfig
+		if len(dst.knownPathList) > 0 {
+			o = opts(dst.knownPathList[0])
+		}
+		path := dst.GetMultiBestPath(id, o)
+		if path != nil {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+func (t *Table) AlternativePaths(id string, as uint32, alt AlternativePath, target *PeerInfo) []*Path {
+	paths := make([]*Path, 0, len(t.destinations))
+	for _, dst := range t.GetDestinations() {
+		path := dst.GetAlternativePath(id, as, alt, target)
 		if path != nil {
 			paths = append(paths, path)
 		}
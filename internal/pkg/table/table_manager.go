@@ -19,11 +19,13 @@ import (
 	"bytes"
 	"fmt"
 	"log/slog"
+	"maps"
 	"net"
 	"net/netip"
 	"time"
 
 	"github.com/dgryski/go-farm"
+	"github.com/osrg/gobgp/v4/pkg/config/oc"
 	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
 )
 
@@ -145,6 +147,8 @@ type TableManager struct {
 	Vrfs   map[string]*Vrf
 	rfList []bgp.Family
 	logger *slog.Logger
+	// the VRFs with their own route selection options by RD
+	optionVrfs map[string]*Vrf
 }
 
 func NewTableManager(logger *slog.Logger, rfList []bgp.Family) *TableManager {
@@ -153,6 +157,8 @@ func NewTableManager(logger *slog.Logger, rfList []bgp.Family) *TableManager {
 		Vrfs:   make(map[string]*Vrf),
 		rfList: rfList,
 		logger: logger,
+
+		optionVrfs: make(map[string]*Vrf),
 	}
 	for _, rf := range rfList {
 		t.Tables[rf] = NewTable(logger, rf)
@@ -164,7 +170,7 @@ func (manager *TableManager) GetRFlist() []bgp.Family {
 	return manager.rfList
 }
 
-func (manager *TableManager) AddVrf(name string, id uint32, rd bgp.RouteDistinguisherInterface, importRt, exportRt []bgp.ExtendedCommunityInterface, info *PeerInfo) ([]*Path, error) {
+func (manager *TableManager) AddVrf(name string, id uint32, rd bgp.RouteDistinguisherInterface, importRt, exportRt []bgp.ExtendedCommunityInterface, info *PeerInfo, opts *oc.RouteSelectionOptionsConfig) ([]*Path, error) {
 	if _, ok := manager.Vrfs[name]; ok {
 		return nil, fmt.Errorf("vrf %s already exists", name)
 	}
@@ -179,13 +185,17 @@ func (manager *TableManager) AddVrf(name string, id uint32, rd bgp.RouteDistingu
 		slog.Any("ImportRt", rtMap.ToSlice()),
 		slog.Any("ExportRt", exportRt),
 	)
-	manager.Vrfs[name] = &Vrf{
+	vrf := &Vrf{
 		Name:     name,
 		Id:       id,
 		Rd:       rd,
 		ImportRt: rtMap,
 		ExportRt: exportRt,
+
+		SelectionOptions: opts,
 	}
+	manager.Vrfs[name] = vrf
+	manager.indexOptionVrf(rd.String())
 	msgs := make([]*Path, 0, len(importRt))
 	nexthop := netip.IPv4Unspecified()
 	for _, target := range importRt {
@@ -217,11 +227,26 @@ func (manager *TableManager) DeleteVrf(name string) ([]*Path, error) {
 		slog.Any("MplsLabel", vrf.MplsLabel),
 	)
 	delete(manager.Vrfs, name)
+	manager.indexOptionVrf(vrf.Rd.String())
 	rtcTable := manager.Tables[bgp.RF_RTC_UC]
 	msgs = append(msgs, rtcTable.deleteRTCPathsByVrf(vrf, manager.Vrfs)...)
 	return msgs, nil
 }
 
+// indexOptionVrf updates the VRF with route selection options indexed by
+// the RD, the one with the smallest name if several VRFs share the RD.
+func (manager *TableManager) indexOptionVrf(rd string) {
+	delete(manager.optionVrfs, rd)
+	for _, vrf := range manager.Vrfs {
+		if vrf.SelectionOptions == nil || vrf.Rd.String() != rd {
+			continue
+		}
+		if v, ok := manager.optionVrfs[rd]; !ok || vrf.Name < v.Name {
+			manager.optionVrfs[rd] = vrf
+		}
+	}
+}
+
 func (manager *TableManager) Update(newPath *Path) []*Update {
 	if newPath == nil || newPath.IsEOR() {
 		return nil
@@ -231,17 +256,86 @@ func (manager *TableManager) Update(newPath *Path) []*Update {
 	updates := make([]*Update, 0, 1)
 	family := newPath.GetFamily()
 	if table, ok := manager.Tables[family]; ok {
-		updates = append(updates, table.update(newPath))
+		updates = append(updates, table.update(newPath, manager.selectionOptions(newPath)))
 
 		if family == bgp.RF_EVPN {
 			for _, p := range manager.handleMacMobility(newPath) {
-				updates = append(updates, table.update(p))
+				updates = append(updates, table.update(p, manager.selectionOptions(p)))
 			}
 		}
 	}
 	return updates
 }
 
+// selectionOptions returns the route selection options for the destination
+// of the path. The options of a VRF apply to the VPN destinations with its
+// route distinguisher, or else to those it imports; the VRF with the
+// smallest name wins if several import the path. Otherwise, the global
+// options are used.
+func (manager *TableManager) selectionOptions(path *Path) *oc.RouteSelectionOptionsConfig {
+	if len(manager.optionVrfs) == 0 {
+		return &SelectionOptions
+	}
+	rd := getRouteDistinguisher(path.GetNlri())
+	if rd == nil {
+		return &SelectionOptions
+	}
+	if vrf, ok := manager.optionVrfs[rd.String()]; ok {
+		return vrf.SelectionOptions
+	}
+	// withdrawals have no route targets, so look at the known paths.
+	if t, ok := manager.Tables[path.GetFamily()]; ok && path.IsWithdraw {
+		if dst := t.GetDestination(path.GetNlri()); dst != nil && len(dst.knownPathList) > 0 {
+			path = dst.knownPathList[0]
+		}
+	}
+	var imported *Vrf
+	for _, vrf := range manager.optionVrfs {
+		if (imported == nil || vrf.Name < imported.Name) && CanImportToVrf(vrf, path) {
+			imported = vrf
+		}
+	}
+	if imported != nil {
+		return imported.SelectionOptions
+	}
+	return &SelectionOptions
+}
+
+// BestPathReasons returns the best path reason of every path in the table,
+// which is a copy of one of the tables of the manager or a VRF view of it
+// if vrf isn't empty.
+func (manager *TableManager) BestPathReasons(t *Table, vrf string) map[*Path]BestPathReason {
+	reasons := make(map[*Path]BestPathReason)
+	for _, dst := range t.GetDestinations() {
+		if len(dst.knownPathList) == 0 {
+			continue
+		}
+		maps.Copy(reasons, dst.BestPathReasons(manager.destinationSelectionOptions(dst, vrf)))
+	}
+	return reasons
+}
+
+// SelectionOptions returns the route selection options of every
+// destination with known paths in the table, which is a copy of one of
+// the tables of the manager or a VRF view of it if vrf isn't empty.
+func (manager *TableManager) SelectionOptions(t *Table, vrf string) map[*Destination]*oc.RouteSelectionOptionsConfig {
+	opts := make(map[*Destination]*oc.RouteSelectionOptionsConfig)
+	for _, dst := range t.GetDestinations() {
+		if len(dst.knownPathList) == 0 {
+			continue
+		}
+		opts[dst] = manager.destinationSelectionOptions(dst, vrf)
+	}
+	return opts
+}
+
+func (manager *TableManager) destinationSelectionOptions(dst *Destination, vrf string) *oc.RouteSelectionOptionsConfig {
+	if v, ok := manager.Vrfs[vrf]; ok {
+		return selectionOptions(v.SelectionOptions)
+	}
+	return manager.selectionOptions(dst.knownPathList[0])
+}
+
 // EVPN MAC MOBILITY HANDLING
 //
 // RFC7432 15. MAC Mobility
@@ -346,7 +440,18 @@ func (manager *TableManager) GetBestMultiPathList(id string, rfList []bgp.Family
 	}
 	paths := make([][]*Path, 0, manager.getDestinationCount(rfList))
 	for _, t := range manager.tables(rfList...) {
-		paths = append(paths, t.MultiBests(id)...)
+		paths = append(paths, t.MultiBests(id, manager.selectionOptions)...)
+	}
+	return paths
+}
+
+func (manager *TableManager) GetAlternativePathList(id string, as uint32, alt AlternativePath, target *PeerInfo, rfList []bgp.Family) []*Path {
+	if SelectionOptions.DisableBestPathSelection {
+		return nil
+	}
+	paths := make([]*Path, 0, manager.getDestinationCount(rfList))
+	for _, t := range manager.tables(rfList...) {
+		paths = append(paths, t.AlternativePaths(id, as, alt, target)...)
 	}
 	return paths
 }
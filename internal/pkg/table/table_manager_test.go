@@ -22,6 +22,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/osrg/gobgp/v4/pkg/config/oc"
 	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
 
 	"github.com/stretchr/testify/assert"
@@ -2355,7 +2356,7 @@ func addVrf(t *testing.T, tm *TableManager, peerInfo *PeerInfo, vrfName, rdStr s
 		exportRts = append(exportRts, rt)
 	}
 
-	outputRts, err := tm.AddVrf(vrfName, id, rd, importRts, exportRts, peerInfo)
+	outputRts, err := tm.AddVrf(vrfName, id, rd, importRts, exportRts, peerInfo, nil)
 	assert.NoError(t, err)
 	assert.Equal(t, len(importRtsStr), len(outputRts))
 	for _, outputRt := range outputRts {
@@ -2366,6 +2367,40 @@ func addVrf(t *testing.T, tm *TableManager, peerInfo *PeerInfo, vrfName, rdStr s
 	return tm.Vrfs[vrfName]
 }
 
+func TestVrfSelectionOptions(t *testing.T) {
+	tm := NewTableManager(logger, []bgp.Family{bgp.RF_IPv4_VPN, bgp.RF_RTC_UC})
+	peerInfo := createPeerInfo(64511, "127.0.0.11")
+	// no vrf has its own options
+	p := makeVpn4Path(t, peerInfo, "10.0.0.0", "8.8.8.8", "111:100", []string{"111:111"})
+	assert.Equal(t, &SelectionOptions, tm.selectionOptions(p))
+
+	rd, _, err := parseRDRT("111:100")
+	assert.NoError(t, err)
+	_, rt, err := parseRDRT("111:111")
+	assert.NoError(t, err)
+	_, err = tm.AddVrf("vrf1", 1, rd, []bgp.ExtendedCommunityInterface{rt}, nil, peerInfo, &oc.RouteSelectionOptionsConfig{AlwaysCompareMed: true})
+	assert.NoError(t, err)
+	vrf1 := tm.Vrfs["vrf1"]
+	vrf2 := addVrf(t, tm, peerInfo, "vrf2", "222:100", []string{"222:222"}, []string{"222:222"}, 2)
+
+	// the route distinguisher of the vrf
+	p = makeVpn4Path(t, peerInfo, "10.0.0.0", "8.8.8.8", "111:100", []string{"333:333"})
+	assert.Equal(t, vrf1.SelectionOptions, tm.selectionOptions(p))
+	// imported by the vrf
+	p = makeVpn4Path(t, peerInfo, "10.0.0.0", "8.8.8.8", "333:100", []string{"111:111"})
+	assert.Equal(t, vrf1.SelectionOptions, tm.selectionOptions(p))
+	// vrf2 uses the global options
+	p = makeVpn4Path(t, peerInfo, "10.0.0.0", "8.8.8.8", "222:100", []string{"222:222"})
+	assert.Equal(t, &SelectionOptions, tm.selectionOptions(p))
+	assert.Nil(t, vrf2.SelectionOptions)
+
+	_, err = tm.DeleteVrf("vrf1")
+	assert.NoError(t, err)
+	p = makeVpn4Path(t, peerInfo, "10.0.0.0", "8.8.8.8", "111:100", []string{"111:111"})
+	assert.Equal(t, &SelectionOptions, tm.selectionOptions(p))
+	assert.Empty(t, tm.optionVrfs)
+}
+
 func TestVRF(t *testing.T) {
 	tm := NewTableManager(logger, []bgp.Family{bgp.RF_IPv6_VPN, bgp.RF_IPv4_VPN, bgp.RF_RTC_UC})
 	peerInfo := createPeerInfo(64511, "127.0.0.11")
@@ -16,6 +16,7 @@
 package table
 
 import (
+	"github.com/osrg/gobgp/v4/pkg/config/oc"
 	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
 )
 
@@ -26,6 +27,8 @@ type Vrf struct {
 	ImportRt  routeTargetMap
 	ExportRt  []bgp.ExtendedCommunityInterface
 	MplsLabel uint32
+	// route selection options of this VRF, nil if the global ones are used
+	SelectionOptions *oc.RouteSelectionOptionsConfig
 }
 
 func (v *Vrf) Clone() *Vrf {
@@ -40,7 +43,21 @@ func (v *Vrf) Clone() *Vrf {
 		ImportRt:  v.ImportRt.Clone(),
 		ExportRt:  f(v.ExportRt),
 		MplsLabel: v.MplsLabel,
+
+		SelectionOptions: v.SelectionOptions,
+	}
+}
+
+func getRouteDistinguisher(nlri bgp.NLRI) bgp.RouteDistinguisherInterface {
+	switch v := nlri.(type) {
+	case *bgp.LabeledVPNIPAddrPrefix:
+		return v.RD
+	case *bgp.EVPNNLRI:
+		return v.RD()
+	case *bgp.MUPNLRI:
+		return v.RD()
 	}
+	return nil
 }
 
 func isLastTargetUser(vrfs map[string]*Vrf, target uint64) bool {
@@ -281,6 +281,8 @@ func UnmarshalAttribute(attr *api.Attribute) (bgp.PathAttributeInterface, error)
 			communities = append(communities, bgp.NewLargeCommunity(c.GlobalAdmin, c.LocalData1, c.LocalData2))
 		}
 		return bgp.NewPathAttributeLargeCommunities(communities), nil
+	case *api.Attribute_BgpsecPath:
+		return UnmarshalBgpsecPathAttribute(a.BgpsecPath)
 	case *api.Attribute_PrefixSid:
 		return UnmarshalPrefixSID(a.PrefixSid)
 	case *api.Attribute_Ls:
@@ -2586,6 +2588,69 @@ func NewLargeCommunitiesAttributeFromNative(a *bgp.PathAttributeLargeCommunities
 	}, nil
 }
 
+func NewBgpsecPathAttributeFromNative(a *bgp.PathAttributeBGPsecPath) (*api.BgpsecPathAttribute, error) {
+	securePath := make([]*api.BgpsecPathAttribute_SecurePathSegment, 0, len(a.SecurePath))
+	for _, s := range a.SecurePath {
+		securePath = append(securePath, &api.BgpsecPathAttribute_SecurePathSegment{
+			Pcount: uint32(s.PCount),
+			Confed: s.Flags&bgp.BGPSEC_SEGMENT_FLAG_CONFED != 0,
+			Asn:    s.AS,
+		})
+	}
+	blocks := make([]*api.BgpsecPathAttribute_SignatureBlock, 0, len(a.SignatureBlocks))
+	for _, b := range a.SignatureBlocks {
+		segments := make([]*api.BgpsecPathAttribute_SignatureSegment, 0, len(b.Segments))
+		for _, s := range b.Segments {
+			segments = append(segments, &api.BgpsecPathAttribute_SignatureSegment{
+				Ski:       s.SKI[:],
+				Signature: s.Signature,
+			})
+		}
+		blocks = append(blocks, &api.BgpsecPathAttribute_SignatureBlock{
+			AlgorithmSuite: uint32(b.AlgorithmSuite),
+			Segments:       segments,
+		})
+	}
+	return &api.BgpsecPathAttribute{
+		SecurePath:      securePath,
+		SignatureBlocks: blocks,
+	}, nil
+}
+
+func UnmarshalBgpsecPathAttribute(a *api.BgpsecPathAttribute) (*bgp.PathAttributeBGPsecPath, error) {
+	securePath := make([]*bgp.SecurePathSegment, 0, len(a.SecurePath))
+	for _, s := range a.SecurePath {
+		if s.Pcount > 255 {
+			return nil, fmt.Errorf("invalid bgpsec pcount: %d", s.Pcount)
+		}
+		segment := &bgp.SecurePathSegment{
+			PCount: uint8(s.Pcount),
+			AS:     s.Asn,
+		}
+		if s.Confed {
+			segment.Flags |= bgp.BGPSEC_SEGMENT_FLAG_CONFED
+		}
+		securePath = append(securePath, segment)
+	}
+	blocks := make([]*bgp.BGPsecSignatureBlock, 0, len(a.SignatureBlocks))
+	for _, b := range a.SignatureBlocks {
+		segments := make([]*bgp.BGPsecSignatureSegment, 0, len(b.Segments))
+		for _, s := range b.Segments {
+			if len(s.Ski) != bgp.BGPSEC_SKI_LEN {
+				return nil, fmt.Errorf("invalid bgpsec ski length: %d", len(s.Ski))
+			}
+			segment := &bgp.BGPsecSignatureSegment{Signature: s.Signature}
+			copy(segment.SKI[:], s.Ski)
+			segments = append(segments, segment)
+		}
+		blocks = append(blocks, &bgp.BGPsecSignatureBlock{
+			AlgorithmSuite: uint8(b.AlgorithmSuite),
+			Segments:       segments,
+		})
+	}
+	return bgp.NewPathAttributeBGPsecPath(securePath, blocks), nil
+}
+
 func stringOrDefault(s *string) string {
 	if s == nil {
 		return ""
@@ -2906,6 +2971,12 @@ func MarshalPathAttributes(attrList []bgp.PathAttributeInterface) ([]*api.Attrib
 				return nil, err
 			}
 			attribute.Attr = &api.Attribute_LargeCommunities{LargeCommunities: v}
+		case *bgp.PathAttributeBGPsecPath:
+			v, err := NewBgpsecPathAttributeFromNative(a)
+			if err != nil {
+				return nil, err
+			}
+			attribute.Attr = &api.Attribute_BgpsecPath{BgpsecPath: v}
 		case *bgp.PathAttributeLs:
 			v, err := NewLsAttributeFromNative(a)
 			if err != nil {
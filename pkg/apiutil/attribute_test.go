@@ -1420,6 +1420,37 @@ func Test_LargeCommunitiesAttribute(t *testing.T) {
 	assert.True(proto.Equal(input, output))
 }
 
+func Test_BgpsecPathAttribute(t *testing.T) {
+	assert := assert.New(t)
+
+	input := &api.BgpsecPathAttribute{
+		SecurePath: []*api.BgpsecPathAttribute_SecurePathSegment{
+			{Pcount: 2, Asn: 65002},
+			{Pcount: 1, Confed: true, Asn: 65001},
+		},
+		SignatureBlocks: []*api.BgpsecPathAttribute_SignatureBlock{
+			{
+				AlgorithmSuite: 1,
+				Segments: []*api.BgpsecPathAttribute_SignatureSegment{
+					{Ski: bytes.Repeat([]byte{0x02}, 20), Signature: []byte{0x22, 0x22}},
+					{Ski: bytes.Repeat([]byte{0x01}, 20), Signature: []byte{0x11}},
+				},
+			},
+		},
+	}
+
+	a := &api.Attribute{Attr: &api.Attribute_BgpsecPath{BgpsecPath: input}}
+	n, err := UnmarshalAttribute(a)
+	assert.NoError(err)
+
+	output, _ := NewBgpsecPathAttributeFromNative(n.(*bgp.PathAttributeBGPsecPath))
+	assert.True(proto.Equal(input, output))
+
+	input.SignatureBlocks[0].Segments[0].Ski = []byte{0x02}
+	_, err = UnmarshalAttribute(a)
+	assert.Error(err)
+}
+
 func Test_UnknownAttribute(t *testing.T) {
 	assert := assert.New(t)
 
@@ -118,6 +118,34 @@ func NewSoftwareVersionCapability(a *bgp.CapSoftwareVersion) *api.SoftwareVersio
 	}
 }
 
+func NewOutboundRouteFilteringCapability(a *bgp.CapOutboundRouteFiltering) *api.OutboundRouteFilteringCapability {
+	tuples := make([]*api.OutboundRouteFilteringCapabilityTuple, 0, len(a.Tuples))
+	for _, t := range a.Tuples {
+		entries := make([]*api.OutboundRouteFilteringCapabilityEntry, 0, len(t.Entries))
+		for _, e := range t.Entries {
+			entries = append(entries, &api.OutboundRouteFilteringCapabilityEntry{
+				Type: uint32(e.Type),
+				Mode: api.OutboundRouteFilteringCapabilityEntry_Mode(e.Mode),
+			})
+		}
+		tuples = append(tuples, &api.OutboundRouteFilteringCapabilityTuple{
+			Family:  ToApiFamily(t.Family.Afi(), t.Family.Safi()),
+			Entries: entries,
+		})
+	}
+	return &api.OutboundRouteFilteringCapability{
+		Tuples: tuples,
+	}
+}
+
+func NewBgpsecCapability(a *bgp.CapBGPsec) *api.BgpsecCapability {
+	return &api.BgpsecCapability{
+		Version:   uint32(a.Version),
+		Direction: api.BgpsecCapability_Direction(a.Direction + 1),
+		Afi:       api.Family_Afi(a.AFI),
+	}
+}
+
 func NewUnknownCapability(a *bgp.CapUnknown) *api.UnknownCapability {
 	return &api.UnknownCapability{
 		Code:  uint32(a.CapCode),
@@ -152,6 +180,10 @@ func MarshalCapability(value bgp.ParameterCapabilityInterface) (*api.Capability,
 		m.Cap = &api.Capability_Fqdn{Fqdn: NewFQDNCapability(n)}
 	case *bgp.CapSoftwareVersion:
 		m.Cap = &api.Capability_SoftwareVersion{SoftwareVersion: NewSoftwareVersionCapability(n)}
+	case *bgp.CapOutboundRouteFiltering:
+		m.Cap = &api.Capability_OutboundRouteFiltering{OutboundRouteFiltering: NewOutboundRouteFilteringCapability(n)}
+	case *bgp.CapBGPsec:
+		m.Cap = &api.Capability_Bgpsec{Bgpsec: NewBgpsecCapability(n)}
 	case *bgp.CapUnknown:
 		m.Cap = &api.Capability_Unknown{Unknown: NewUnknownCapability(n)}
 	default:
@@ -247,6 +279,25 @@ func unmarshalCapability(a *api.Capability) (bgp.ParameterCapabilityInterface, e
 	case *api.Capability_SoftwareVersion:
 		a := cap.SoftwareVersion
 		return bgp.NewCapSoftwareVersion(a.SoftwareVersion), nil
+	case *api.Capability_OutboundRouteFiltering:
+		a := cap.OutboundRouteFiltering
+		tuples := make([]*bgp.CapORFTuple, 0, len(a.Tuples))
+		for _, t := range a.Tuples {
+			entries := make([]*bgp.CapORFEntry, 0, len(t.Entries))
+			for _, e := range t.Entries {
+				entries = append(entries, &bgp.CapORFEntry{Type: bgp.ORFType(e.Type), Mode: bgp.ORFSendReceive(e.Mode)})
+			}
+			tuples = append(tuples, bgp.NewCapORFTuple(ToFamily(t.Family), entries))
+		}
+		return bgp.NewCapOutboundRouteFiltering(tuples), nil
+	case *api.Capability_Bgpsec:
+		a := cap.Bgpsec
+		if a.Direction == api.BgpsecCapability_DIRECTION_UNSPECIFIED {
+			return nil, fmt.Errorf("unspecified bgpsec direction")
+		}
+		c := bgp.NewCapBGPsec(bgp.BGPsecDirection(a.Direction-1), uint16(a.Afi))
+		c.Version = uint8(a.Version)
+		return c, nil
 	case *api.Capability_Unknown:
 		a := cap.Unknown
 		return bgp.NewCapUnknown(bgp.BGPCapabilityCode(a.Code), a.Value), nil
@@ -238,6 +238,59 @@ func Test_RouteRefreshCiscoCapability(t *testing.T) {
 	assert.True(proto.Equal(input, output))
 }
 
+func Test_OutboundRouteFilteringCapability(t *testing.T) {
+	assert := assert.New(t)
+
+	input := &api.OutboundRouteFilteringCapability{
+		Tuples: []*api.OutboundRouteFilteringCapabilityTuple{
+			{
+				Family: &api.Family{
+					Afi:  api.Family_AFI_IP6,
+					Safi: api.Family_SAFI_UNICAST,
+				},
+				Entries: []*api.OutboundRouteFilteringCapabilityEntry{
+					{
+						Type: 64,
+						Mode: api.OutboundRouteFilteringCapabilityEntry_MODE_BOTH,
+					},
+				},
+			},
+		},
+	}
+
+	a := &api.Capability{Cap: &api.Capability_OutboundRouteFiltering{OutboundRouteFiltering: input}}
+	n, err := unmarshalCapability(a)
+	assert.NoError(err)
+
+	c := n.(*bgp.CapOutboundRouteFiltering)
+	assert.Equal(1, len(c.Tuples))
+	assert.Equal(bgp.RF_IPv6_UC, c.Tuples[0].Family)
+	assert.Equal(bgp.ORF_BOTH, c.Tuples[0].Mode(bgp.ORF_TYPE_ADDRESS_PREFIX))
+
+	output := NewOutboundRouteFilteringCapability(c)
+	assert.True(proto.Equal(input, output))
+}
+
+func Test_BgpsecCapability(t *testing.T) {
+	assert := assert.New(t)
+
+	input := &api.BgpsecCapability{
+		Direction: api.BgpsecCapability_DIRECTION_SEND,
+		Afi:       api.Family_AFI_IP6,
+	}
+
+	a := &api.Capability{Cap: &api.Capability_Bgpsec{Bgpsec: input}}
+	n, err := unmarshalCapability(a)
+	assert.NoError(err)
+
+	c := n.(*bgp.CapBGPsec)
+	assert.Equal(bgp.BGPSEC_DIRECTION_SEND, c.Direction)
+	assert.Equal(uint16(bgp.AFI_IP6), c.AFI)
+
+	output := NewBgpsecCapability(c)
+	assert.True(proto.Equal(input, output))
+}
+
 func Test_UnknownCapability(t *testing.T) {
 	assert := assert.New(t)
 
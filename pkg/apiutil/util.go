@@ -43,6 +43,24 @@ type WatchEventMessage_PeerEvent struct {
 	Peer Peer
 }
 
+// WatchEventMessage_ValidationEvent is the path whose RPKI validation
+// state is changed; Path.Validation is the new one.
+type WatchEventMessage_ValidationEvent struct {
+	Path          *Path
+	OldValidation *api.Validation
+}
+
+// WatchEventMessage_PrefixLimitEvent is the prefix limit of a peer
+// crossing a threshold.
+type WatchEventMessage_PrefixLimitEvent struct {
+	Type        api.WatchEventResponse_PrefixLimitEvent_Type
+	PeerAddress netip.Addr
+	Family      bgp.Family
+	Count       uint32
+	Limit       uint32
+	Shutdown    bool
+}
+
 // ListPathRequest is used by server.ListPath API
 type ListPathRequest struct {
 	TableType      api.TableType
@@ -51,6 +69,8 @@ type ListPathRequest struct {
 	Prefixes       []*LookupPrefix
 	SortType       api.ListPathRequest_SortType
 	EnableFiltered bool
+
+	EnableBestPathReason bool
 }
 
 // AddPathRequest is used by server.AddPath API
@@ -103,11 +123,15 @@ type Path struct {
 	NoImplicitWithdraw bool                         `json:"no-implicit-withdraw,omitempty"`
 	IsNexthopInvalid   bool                         `json:"is-nexthop-invalid,omitempty"`
 	// the following fields are used only reported by GetList() API
-	SendMaxFiltered bool            `json:"send-max-filtered,omitempty"` // true if the path has been filtered out due to max path count reached
-	Filtered        bool            `json:"filtered,omitempty"`
-	Validation      *api.Validation `json:"validation,omitempty"`
+	SendMaxFiltered bool               `json:"send-max-filtered,omitempty"` // true if the path has been filtered out due to max path count reached
+	Filtered        bool               `json:"filtered,omitempty"`
+	Validation      *api.Validation    `json:"validation,omitempty"`
+	BestPathReason  api.BestPathReason `json:"best-path-reason,omitempty"`
 	RemoteID        uint32
 	LocalID         uint32
+	// local to the router, set by the policy
+	Weight uint32 `json:"weight,omitempty"`
+	Tag    uint32 `json:"tag,omitempty"`
 	// netlink integration fields
 	IsNetlink     bool   `json:"is-netlink,omitempty"`
 	NetlinkIfName string `json:"netlink-ifname,omitempty"`
@@ -167,6 +191,7 @@ func NewDestination(dst *api.Destination) *Destination {
 			Withdrawal:      p.IsWithdraw,
 			PeerID:          src,
 			PeerAddress:     neighbor,
+			BestPathReason:  p.BestPathReason,
 		})
 	}
 	return &Destination{Paths: l}
@@ -19,6 +19,7 @@ package config
 import (
 	"context"
 	"log/slog"
+	"slices"
 
 	"github.com/osrg/gobgp/v4/api"
 	"github.com/osrg/gobgp/v4/internal/pkg/table"
@@ -293,16 +294,44 @@ func InitialConfig(ctx context.Context, bgpServer *server.BgpServer, newConfig *
 			slog.String("Topic", "config"))
 	}
 
+	rpkiTransport := func(t oc.RpkiTransportType) api.AddRpkiRequest_Transport {
+		switch t {
+		case oc.RPKI_TRANSPORT_TYPE_TLS:
+			return api.AddRpkiRequest_TRANSPORT_TLS
+		case oc.RPKI_TRANSPORT_TYPE_SSH:
+			return api.AddRpkiRequest_TRANSPORT_SSH
+		}
+		return api.AddRpkiRequest_TRANSPORT_TCP
+	}
+	rpkiVersion := func(v oc.RtrProtocolVersionType) api.AddRpkiRequest_Version {
+		switch v {
+		case oc.RTR_PROTOCOL_VERSION_TYPE_V0:
+			return api.AddRpkiRequest_VERSION_0
+		case oc.RTR_PROTOCOL_VERSION_TYPE_V1:
+			return api.AddRpkiRequest_VERSION_1
+		}
+		return api.AddRpkiRequest_VERSION_2
+	}
 	for _, c := range newConfig.RpkiServers {
 		if err := bgpServer.AddRpki(ctx, &api.AddRpkiRequest{
-			Address:  c.Config.Address.String(),
-			Port:     c.Config.Port,
-			Lifetime: c.Config.RecordLifetime,
+			Address:           c.Config.Address.String(),
+			Port:              c.Config.Port,
+			Lifetime:          c.Config.RecordLifetime,
+			Transport:         rpkiTransport(c.Config.Transport),
+			MaxVersion:        rpkiVersion(c.Config.MaxProtocolVersion),
+			TlsCaFile:         c.Config.TlsCaFile,
+			TlsCertFile:       c.Config.TlsCertFile,
+			TlsKeyFile:        c.Config.TlsKeyFile,
+			TlsServerName:     c.Config.TlsServerName,
+			SshUsername:       c.Config.SshUsername,
+			SshPrivateKeyFile: c.Config.SshPrivateKeyFile,
+			SshKnownHostsFile: c.Config.SshKnownHostsFile,
 		}); err != nil {
 			bgpServer.Log().Error("failed to set rpki config",
 				slog.String("Topic", "config"), slog.Any("Error", err))
 		}
 	}
+	addRpkiFiles(ctx, bgpServer, newConfig.RpkiFiles)
 	f := func(t oc.BmpRouteMonitoringPolicyType) api.AddBmpRequest_MonitoringPolicy {
 		switch t {
 		case oc.BMP_ROUTE_MONITORING_POLICY_TYPE_PRE_POLICY:
@@ -318,20 +347,44 @@ func InitialConfig(ctx context.Context, bgpServer *server.BgpServer, newConfig *
 		}
 		return api.AddBmpRequest_MONITORING_POLICY_UNSPECIFIED
 	}
+	g := func(t oc.BmpQueueOverflowActionType) api.AddBmpRequest_QueueOverflowAction {
+		switch t {
+		case oc.BMP_QUEUE_OVERFLOW_ACTION_TYPE_DROP:
+			return api.AddBmpRequest_QUEUE_OVERFLOW_ACTION_DROP
+		case oc.BMP_QUEUE_OVERFLOW_ACTION_TYPE_DISCONNECT:
+			return api.AddBmpRequest_QUEUE_OVERFLOW_ACTION_DISCONNECT
+		}
+		return api.AddBmpRequest_QUEUE_OVERFLOW_ACTION_UNSPECIFIED
+	}
 
 	for _, c := range newConfig.BmpServers {
 		if err := bgpServer.AddBmp(ctx, &api.AddBmpRequest{
-			Address:           c.Config.Address.String(),
-			Port:              c.Config.Port,
-			SysName:           c.Config.SysName,
-			SysDescr:          c.Config.SysDescr,
-			Policy:            f(c.Config.RouteMonitoringPolicy),
-			StatisticsTimeout: int32(c.Config.StatisticsTimeout),
+			Address:             c.Config.Address.String(),
+			Port:                c.Config.Port,
+			SysName:             c.Config.SysName,
+			SysDescr:            c.Config.SysDescr,
+			Policy:              f(c.Config.RouteMonitoringPolicy),
+			StatisticsTimeout:   int32(c.Config.StatisticsTimeout),
+			AdjRibOutPrePolicy:  c.Config.AdjRibOutPrePolicyEnabled,
+			AdjRibOutPostPolicy: c.Config.AdjRibOutPostPolicyEnabled,
+			Tls:                 c.Config.TlsEnabled,
+			TlsCaFile:           c.Config.TlsCaFile,
+			TlsCertFile:         c.Config.TlsCertFile,
+			TlsKeyFile:          c.Config.TlsKeyFile,
+			TlsServerName:       c.Config.TlsServerName,
+			QueueSize:           c.Config.QueueSize,
+			QueueOverflowAction: g(c.Config.QueueOverflowAction),
 		}); err != nil {
 			bgpServer.Log().Error("failed to set bmp config",
 				slog.String("Topic", "config"), slog.Any("Error", err))
 		}
 	}
+	if newConfig.BmpStation.Config.Enabled {
+		if err := bgpServer.EnableBmpStation(ctx, bmpStationRequest(&newConfig.BmpStation.Config)); err != nil {
+			bgpServer.Log().Error("failed to enable bmp station",
+				slog.String("Topic", "config"), slog.Any("Error", err))
+		}
+	}
 	for _, vrf := range newConfig.Vrfs {
 		rd, err := bgp.ParseRouteDistinguisher(vrf.Config.Rd)
 		if err != nil {
@@ -355,13 +408,19 @@ func InitialConfig(ctx context.Context, bgpServer *server.BgpServer, newConfig *
 			bgpServer.Log().Error("failed to set vrf config",
 				slog.String("Topic", "config"), slog.Any("Error", err))
 		}
+		// the global route selection options are used unless any is set
+		var opts *api.RouteSelectionOptionsConfig
+		if !vrf.RouteSelectionOptions.Config.Equal(&oc.RouteSelectionOptionsConfig{}) {
+			opts = oc.NewRouteSelectionOptionsConfigFromConfigStruct(&vrf.RouteSelectionOptions.Config)
+		}
 		if err := bgpServer.AddVrf(ctx, &api.AddVrfRequest{
 			Vrf: &api.Vrf{
-				Name:     vrf.Config.Name,
-				Rd:       a,
-				Id:       vrf.Config.Id,
-				ImportRt: importRtList,
-				ExportRt: exportRtList,
+				Name:                  vrf.Config.Name,
+				Rd:                    a,
+				Id:                    vrf.Config.Id,
+				ImportRt:              importRtList,
+				ExportRt:              exportRtList,
+				RouteSelectionOptions: opts,
 			},
 		}); err != nil {
 			bgpServer.Log().Error("failed to set vrf config", slog.String("Topic", "config"), slog.Any("Error", err))
@@ -380,16 +439,42 @@ func InitialConfig(ctx context.Context, bgpServer *server.BgpServer, newConfig *
 			dump_type = api.EnableMrtRequest_DUMP_TYPE_TABLE
 		}
 
+		compression := api.EnableMrtRequest_COMPRESSION_UNSPECIFIED
+		switch c.Config.Compression {
+		case oc.MRT_COMPRESSION_TYPE_GZIP:
+			compression = api.EnableMrtRequest_COMPRESSION_GZIP
+		case oc.MRT_COMPRESSION_TYPE_BZIP2:
+			compression = api.EnableMrtRequest_COMPRESSION_BZIP2
+		case oc.MRT_COMPRESSION_TYPE_ZSTD:
+			compression = api.EnableMrtRequest_COMPRESSION_ZSTD
+		}
+
+		sink := api.EnableMrtRequest_SINK_UNSPECIFIED
+		switch c.Config.SinkType {
+		case oc.MRT_SINK_TYPE_PIPE:
+			sink = api.EnableMrtRequest_SINK_PIPE
+		case oc.MRT_SINK_TYPE_UNIX_SOCKET:
+			sink = api.EnableMrtRequest_SINK_UNIX_SOCKET
+		}
+
 		if err := bgpServer.EnableMrt(ctx, &api.EnableMrtRequest{
 			DumpType:         dump_type,
 			Filename:         c.Config.FileName,
 			DumpInterval:     c.Config.DumpInterval,
 			RotationInterval: c.Config.RotationInterval,
+			Vrf:              c.Config.Vrf,
+			Compression:      compression,
+			Sink:             sink,
+			RotationSize:     c.Config.RotationSize,
+			RetentionFiles:   c.Config.RetentionFiles,
+			RetentionSize:    c.Config.RetentionSize,
 		}); err != nil {
 			bgpServer.Log().Error("failed to set mrt config",
 				slog.String("Topic", "config"), slog.Any("Error", err))
 		}
 	}
+	// the policies may refer to the defined sets of the files
+	addDefinedSetFiles(ctx, bgpServer, newConfig.DefinedSetFiles)
 	p := oc.ConfigSetToRoutingPolicy(newConfig)
 	rp, err := table.NewAPIRoutingPolicyFromConfigStruct(p)
 	if err != nil {
@@ -432,6 +517,7 @@ func UpdateConfig(ctx context.Context, bgpServer *server.BgpServer, c, newConfig
 	added, deleted, updated := oc.UpdateNeighborConfig(bgpServer.Log(), c, newConfig)
 	updatePolicy := oc.CheckPolicyDifference(bgpServer.Log(), oc.ConfigSetToRoutingPolicy(c), oc.ConfigSetToRoutingPolicy(newConfig))
 
+	updateDefinedSetFiles(ctx, bgpServer, c.DefinedSetFiles, newConfig.DefinedSetFiles)
 	if updatePolicy {
 		bgpServer.Log().Info("policy config is update", slog.String("Topic", "config"))
 		p := oc.ConfigSetToRoutingPolicy(newConfig)
@@ -485,5 +571,139 @@ func UpdateConfig(ctx context.Context, bgpServer *server.BgpServer, c, newConfig
 		}
 	}
 
+	updateRpkiFiles(ctx, bgpServer, c.RpkiFiles, newConfig.RpkiFiles)
+
+	if !newConfig.BmpStation.Config.Equal(&c.BmpStation.Config) {
+		bgpServer.Log().Info("bmp station config changed, updating",
+			slog.String("Topic", "config"))
+		if c.BmpStation.Config.Enabled {
+			if err := bgpServer.DisableBmpStation(ctx, &api.DisableBmpStationRequest{}); err != nil {
+				bgpServer.Log().Warn("failed to disable bmp station",
+					slog.String("Topic", "config"), slog.Any("Error", err))
+			}
+		}
+		if newConfig.BmpStation.Config.Enabled {
+			if err := bgpServer.EnableBmpStation(ctx, bmpStationRequest(&newConfig.BmpStation.Config)); err != nil {
+				bgpServer.Log().Warn("failed to enable bmp station",
+					slog.String("Topic", "config"), slog.Any("Error", err))
+			}
+		}
+	}
+
 	return newConfig, nil
 }
+
+func addRpkiFiles(ctx context.Context, bgpServer *server.BgpServer, files []oc.RpkiFile) {
+	for _, f := range files {
+		format := api.AddRpkiFileRequest_FORMAT_JSON_VRP
+		if f.Config.Format == oc.RPKI_FILE_FORMAT_TYPE_SLURM {
+			format = api.AddRpkiFileRequest_FORMAT_SLURM
+		}
+		if err := bgpServer.AddRpkiFile(ctx, &api.AddRpkiFileRequest{
+			Path:   f.Config.Path,
+			Format: format,
+		}); err != nil {
+			bgpServer.Log().Error("failed to add rpki file",
+				slog.String("Topic", "config"), slog.String("File", f.Config.Path), slog.Any("Error", err))
+		}
+	}
+}
+
+// updateRpkiFiles adds and deletes the changed files and reloads the rest,
+// which lets SIGHUP reload the files too.
+func updateRpkiFiles(ctx context.Context, bgpServer *server.BgpServer, files, newFiles []oc.RpkiFile) {
+	var added, deleted []oc.RpkiFile
+	for _, f := range files {
+		if !slices.ContainsFunc(newFiles, func(n oc.RpkiFile) bool { return n.Equal(&f) }) {
+			deleted = append(deleted, f)
+		}
+	}
+	for _, f := range newFiles {
+		if !slices.ContainsFunc(files, func(o oc.RpkiFile) bool { return o.Equal(&f) }) {
+			added = append(added, f)
+		}
+	}
+	for _, f := range deleted {
+		if err := bgpServer.DeleteRpkiFile(ctx, &api.DeleteRpkiFileRequest{Path: f.Config.Path}); err != nil {
+			bgpServer.Log().Warn("failed to delete rpki file",
+				slog.String("Topic", "config"), slog.String("File", f.Config.Path), slog.Any("Error", err))
+		}
+	}
+	if len(newFiles) > len(added) {
+		if err := bgpServer.ReloadRpkiFile(ctx, &api.ReloadRpkiFileRequest{}); err != nil {
+			bgpServer.Log().Warn("failed to reload rpki files",
+				slog.String("Topic", "config"), slog.Any("Error", err))
+		}
+	}
+	addRpkiFiles(ctx, bgpServer, added)
+}
+
+func addDefinedSetFiles(ctx context.Context, bgpServer *server.BgpServer, files []oc.DefinedSetFile) {
+	for _, f := range files {
+		typ := api.DefinedType_DEFINED_TYPE_PREFIX
+		if f.Config.Type == oc.DEFINED_SET_FILE_TYPE_AS_PATH {
+			typ = api.DefinedType_DEFINED_TYPE_AS_PATH
+		}
+		format := api.AddDefinedSetFileRequest_FORMAT_TEXT
+		if f.Config.Format == oc.DEFINED_SET_FILE_FORMAT_TYPE_BGPQ4_JSON {
+			format = api.AddDefinedSetFileRequest_FORMAT_BGPQ4_JSON
+		}
+		if err := bgpServer.AddDefinedSetFile(ctx, &api.AddDefinedSetFileRequest{
+			Name:        f.Config.Name,
+			DefinedType: typ,
+			Path:        f.Config.Path,
+			Format:      format,
+		}); err != nil {
+			bgpServer.Log().Error("failed to add defined set file",
+				slog.String("Topic", "config"), slog.String("File", f.Config.Path), slog.Any("Error", err))
+		}
+	}
+}
+
+// updateDefinedSetFiles adds and deletes the changed files and reloads the
+// rest like updateRpkiFiles.
+func updateDefinedSetFiles(ctx context.Context, bgpServer *server.BgpServer, files, newFiles []oc.DefinedSetFile) {
+	var added, deleted []oc.DefinedSetFile
+	for _, f := range files {
+		if !slices.ContainsFunc(newFiles, func(n oc.DefinedSetFile) bool { return n.Equal(&f) }) {
+			deleted = append(deleted, f)
+		}
+	}
+	for _, f := range newFiles {
+		if !slices.ContainsFunc(files, func(o oc.DefinedSetFile) bool { return o.Equal(&f) }) {
+			added = append(added, f)
+		}
+	}
+	for _, f := range deleted {
+		if err := bgpServer.DeleteDefinedSetFile(ctx, &api.DeleteDefinedSetFileRequest{Path: f.Config.Path}); err != nil {
+			bgpServer.Log().Warn("failed to delete defined set file",
+				slog.String("Topic", "config"), slog.String("File", f.Config.Path), slog.Any("Error", err))
+		}
+	}
+	if len(newFiles) > len(added) {
+		if err := bgpServer.ReloadDefinedSetFile(ctx, &api.ReloadDefinedSetFileRequest{}); err != nil {
+			bgpServer.Log().Warn("failed to reload defined set files",
+				slog.String("Topic", "config"), slog.Any("Error", err))
+		}
+	}
+	addDefinedSetFiles(ctx, bgpServer, added)
+}
+
+func bmpStationRequest(c *oc.BmpStationConfig) *api.EnableBmpStationRequest {
+	r := &api.EnableBmpStationRequest{
+		Port:                c.Port,
+		MrtFilename:         c.MrtFileName,
+		MrtDumpInterval:     c.MrtDumpInterval,
+		MrtRotationInterval: c.MrtRotationInterval,
+	}
+	if c.Address.IsValid() {
+		r.Address = c.Address.String()
+	}
+	switch c.MrtDumpType {
+	case oc.MRT_TYPE_UPDATES:
+		r.MrtDumpType = api.EnableMrtRequest_DUMP_TYPE_UPDATES
+	case oc.MRT_TYPE_TABLE:
+		r.MrtDumpType = api.EnableMrtRequest_DUMP_TYPE_TABLE
+	}
+	return r
+}
@@ -614,6 +614,75 @@ func (v RemovePrivateAsOption) ToInt() int {
 	return i
 }
 
+// typedef for typedef gobgp:prefix-limit-count-type.
+type PrefixLimitCountType string
+
+const (
+	PREFIX_LIMIT_COUNT_TYPE_RECEIVED PrefixLimitCountType = "received"
+	PREFIX_LIMIT_COUNT_TYPE_ACCEPTED PrefixLimitCountType = "accepted"
+)
+
+var PrefixLimitCountTypeToIntMap = map[PrefixLimitCountType]int{
+	PREFIX_LIMIT_COUNT_TYPE_RECEIVED: 0,
+	PREFIX_LIMIT_COUNT_TYPE_ACCEPTED: 1,
+}
+
+var IntToPrefixLimitCountTypeMap = map[int]PrefixLimitCountType{
+	0: PREFIX_LIMIT_COUNT_TYPE_RECEIVED,
+	1: PREFIX_LIMIT_COUNT_TYPE_ACCEPTED,
+}
+
+func (v PrefixLimitCountType) Validate() error {
+	if _, ok := PrefixLimitCountTypeToIntMap[v]; !ok {
+		return fmt.Errorf("invalid PrefixLimitCountType: %s", v)
+	}
+	return nil
+}
+
+func (v PrefixLimitCountType) ToInt() int {
+	i, ok := PrefixLimitCountTypeToIntMap[v]
+	if !ok {
+		return -1
+	}
+	return i
+}
+
+// typedef for typedef gobgp:orf-mode-type.
+type OrfModeType string
+
+const (
+	ORF_MODE_TYPE_RECEIVE OrfModeType = "receive"
+	ORF_MODE_TYPE_SEND    OrfModeType = "send"
+	ORF_MODE_TYPE_BOTH    OrfModeType = "both"
+)
+
+var OrfModeTypeToIntMap = map[OrfModeType]int{
+	ORF_MODE_TYPE_RECEIVE: 0,
+	ORF_MODE_TYPE_SEND:    1,
+	ORF_MODE_TYPE_BOTH:    2,
+}
+
+var IntToOrfModeTypeMap = map[int]OrfModeType{
+	0: ORF_MODE_TYPE_RECEIVE,
+	1: ORF_MODE_TYPE_SEND,
+	2: ORF_MODE_TYPE_BOTH,
+}
+
+func (v OrfModeType) Validate() error {
+	if _, ok := OrfModeTypeToIntMap[v]; !ok {
+		return fmt.Errorf("invalid OrfModeType: %s", v)
+	}
+	return nil
+}
+
+func (v OrfModeType) ToInt() int {
+	i, ok := OrfModeTypeToIntMap[v]
+	if !ok {
+		return -1
+	}
+	return i
+}
+
 // typedef for typedef bgp-types:percentage.
 type Percentage uint8
 
@@ -899,6 +968,210 @@ func (v BmpRouteMonitoringPolicyType) ToInt() int {
 	return i
 }
 
+// typedef for identity gobgp:rpki-transport-type.
+type RpkiTransportType string
+
+const (
+	RPKI_TRANSPORT_TYPE_TCP RpkiTransportType = "tcp"
+	RPKI_TRANSPORT_TYPE_TLS RpkiTransportType = "tls"
+	RPKI_TRANSPORT_TYPE_SSH RpkiTransportType = "ssh"
+)
+
+var RpkiTransportTypeToIntMap = map[RpkiTransportType]int{
+	RPKI_TRANSPORT_TYPE_TCP: 0,
+	RPKI_TRANSPORT_TYPE_TLS: 1,
+	RPKI_TRANSPORT_TYPE_SSH: 2,
+}
+
+var IntToRpkiTransportTypeMap = map[int]RpkiTransportType{
+	0: RPKI_TRANSPORT_TYPE_TCP,
+	1: RPKI_TRANSPORT_TYPE_TLS,
+	2: RPKI_TRANSPORT_TYPE_SSH,
+}
+
+func (v RpkiTransportType) Validate() error {
+	if _, ok := RpkiTransportTypeToIntMap[v]; !ok {
+		return fmt.Errorf("invalid RpkiTransportType: %s", v)
+	}
+	return nil
+}
+
+func (v RpkiTransportType) ToInt() int {
+	i, ok := RpkiTransportTypeToIntMap[v]
+	if !ok {
+		return -1
+	}
+	return i
+}
+
+// typedef for identity gobgp:rpki-file-format-type.
+type RpkiFileFormatType string
+
+const (
+	RPKI_FILE_FORMAT_TYPE_JSON_VRP RpkiFileFormatType = "json-vrp"
+	RPKI_FILE_FORMAT_TYPE_SLURM    RpkiFileFormatType = "slurm"
+)
+
+var RpkiFileFormatTypeToIntMap = map[RpkiFileFormatType]int{
+	RPKI_FILE_FORMAT_TYPE_JSON_VRP: 0,
+	RPKI_FILE_FORMAT_TYPE_SLURM:    1,
+}
+
+var IntToRpkiFileFormatTypeMap = map[int]RpkiFileFormatType{
+	0: RPKI_FILE_FORMAT_TYPE_JSON_VRP,
+	1: RPKI_FILE_FORMAT_TYPE_SLURM,
+}
+
+func (v RpkiFileFormatType) Validate() error {
+	if _, ok := RpkiFileFormatTypeToIntMap[v]; !ok {
+		return fmt.Errorf("invalid RpkiFileFormatType: %s", v)
+	}
+	return nil
+}
+
+func (v RpkiFileFormatType) ToInt() int {
+	i, ok := RpkiFileFormatTypeToIntMap[v]
+	if !ok {
+		return -1
+	}
+	return i
+}
+
+// typedef for identity gobgp:defined-set-file-type.
+type DefinedSetFileType string
+
+const (
+	DEFINED_SET_FILE_TYPE_PREFIX  DefinedSetFileType = "prefix"
+	DEFINED_SET_FILE_TYPE_AS_PATH DefinedSetFileType = "as-path"
+)
+
+var DefinedSetFileTypeToIntMap = map[DefinedSetFileType]int{
+	DEFINED_SET_FILE_TYPE_PREFIX:  0,
+	DEFINED_SET_FILE_TYPE_AS_PATH: 1,
+}
+
+var IntToDefinedSetFileTypeMap = map[int]DefinedSetFileType{
+	0: DEFINED_SET_FILE_TYPE_PREFIX,
+	1: DEFINED_SET_FILE_TYPE_AS_PATH,
+}
+
+func (v DefinedSetFileType) Validate() error {
+	if _, ok := DefinedSetFileTypeToIntMap[v]; !ok {
+		return fmt.Errorf("invalid DefinedSetFileType: %s", v)
+	}
+	return nil
+}
+
+func (v DefinedSetFileType) ToInt() int {
+	i, ok := DefinedSetFileTypeToIntMap[v]
+	if !ok {
+		return -1
+	}
+	return i
+}
+
+// typedef for identity gobgp:defined-set-file-format-type.
+type DefinedSetFileFormatType string
+
+const (
+	DEFINED_SET_FILE_FORMAT_TYPE_TEXT       DefinedSetFileFormatType = "text"
+	DEFINED_SET_FILE_FORMAT_TYPE_BGPQ4_JSON DefinedSetFileFormatType = "bgpq4-json"
+)
+
+var DefinedSetFileFormatTypeToIntMap = map[DefinedSetFileFormatType]int{
+	DEFINED_SET_FILE_FORMAT_TYPE_TEXT:       0,
+	DEFINED_SET_FILE_FORMAT_TYPE_BGPQ4_JSON: 1,
+}
+
+var IntToDefinedSetFileFormatTypeMap = map[int]DefinedSetFileFormatType{
+	0: DEFINED_SET_FILE_FORMAT_TYPE_TEXT,
+	1: DEFINED_SET_FILE_FORMAT_TYPE_BGPQ4_JSON,
+}
+
+func (v DefinedSetFileFormatType) Validate() error {
+	if _, ok := DefinedSetFileFormatTypeToIntMap[v]; !ok {
+		return fmt.Errorf("invalid DefinedSetFileFormatType: %s", v)
+	}
+	return nil
+}
+
+func (v DefinedSetFileFormatType) ToInt() int {
+	i, ok := DefinedSetFileFormatTypeToIntMap[v]
+	if !ok {
+		return -1
+	}
+	return i
+}
+
+// typedef for identity gobgp:rtr-protocol-version-type.
+type RtrProtocolVersionType string
+
+const (
+	RTR_PROTOCOL_VERSION_TYPE_V0 RtrProtocolVersionType = "v0"
+	RTR_PROTOCOL_VERSION_TYPE_V1 RtrProtocolVersionType = "v1"
+	RTR_PROTOCOL_VERSION_TYPE_V2 RtrProtocolVersionType = "v2"
+)
+
+var RtrProtocolVersionTypeToIntMap = map[RtrProtocolVersionType]int{
+	RTR_PROTOCOL_VERSION_TYPE_V0: 0,
+	RTR_PROTOCOL_VERSION_TYPE_V1: 1,
+	RTR_PROTOCOL_VERSION_TYPE_V2: 2,
+}
+
+var IntToRtrProtocolVersionTypeMap = map[int]RtrProtocolVersionType{
+	0: RTR_PROTOCOL_VERSION_TYPE_V0,
+	1: RTR_PROTOCOL_VERSION_TYPE_V1,
+	2: RTR_PROTOCOL_VERSION_TYPE_V2,
+}
+
+func (v RtrProtocolVersionType) Validate() error {
+	if _, ok := RtrProtocolVersionTypeToIntMap[v]; !ok {
+		return fmt.Errorf("invalid RtrProtocolVersionType: %s", v)
+	}
+	return nil
+}
+
+func (v RtrProtocolVersionType) ToInt() int {
+	i, ok := RtrProtocolVersionTypeToIntMap[v]
+	if !ok {
+		return -1
+	}
+	return i
+}
+
+// typedef for identity gobgp:bmp-queue-overflow-action-type.
+type BmpQueueOverflowActionType string
+
+const (
+	BMP_QUEUE_OVERFLOW_ACTION_TYPE_DROP       BmpQueueOverflowActionType = "drop"
+	BMP_QUEUE_OVERFLOW_ACTION_TYPE_DISCONNECT BmpQueueOverflowActionType = "disconnect"
+)
+
+var BmpQueueOverflowActionTypeToIntMap = map[BmpQueueOverflowActionType]int{
+	BMP_QUEUE_OVERFLOW_ACTION_TYPE_DROP:       0,
+	BMP_QUEUE_OVERFLOW_ACTION_TYPE_DISCONNECT: 1,
+}
+
+var IntToBmpQueueOverflowActionTypeMap = map[int]BmpQueueOverflowActionType{
+	0: BMP_QUEUE_OVERFLOW_ACTION_TYPE_DROP,
+	1: BMP_QUEUE_OVERFLOW_ACTION_TYPE_DISCONNECT,
+}
+
+func (v BmpQueueOverflowActionType) Validate() error {
+	if _, ok := BmpQueueOverflowActionTypeToIntMap[v]; !ok {
+		return fmt.Errorf("invalid BmpQueueOverflowActionType: %s", v)
+	}
+	return nil
+}
+
+func (v BmpQueueOverflowActionType) ToInt() int {
+	i, ok := BmpQueueOverflowActionTypeToIntMap[v]
+	if !ok {
+		return -1
+	}
+	return i
+}
+
 // typedef for identity gobgp:rpki-validation-result-type.
 // indicate the validation result of RPKI based on ROA.
 type RpkiValidationResultType string
@@ -939,6 +1212,46 @@ func (v RpkiValidationResultType) ToInt() int {
 	return i
 }
 
+// typedef for identity gobgp:bgpsec-validation-result-type.
+// indicate the validation result of the BGPsec_PATH attribute.
+type BgpsecValidationResultType string
+
+const (
+	BGPSEC_VALIDATION_RESULT_TYPE_NONE       BgpsecValidationResultType = "none"
+	BGPSEC_VALIDATION_RESULT_TYPE_NOT_SIGNED BgpsecValidationResultType = "not-signed"
+	BGPSEC_VALIDATION_RESULT_TYPE_VALID      BgpsecValidationResultType = "valid"
+	BGPSEC_VALIDATION_RESULT_TYPE_INVALID    BgpsecValidationResultType = "invalid"
+)
+
+var BgpsecValidationResultTypeToIntMap = map[BgpsecValidationResultType]int{
+	BGPSEC_VALIDATION_RESULT_TYPE_NONE:       0,
+	BGPSEC_VALIDATION_RESULT_TYPE_NOT_SIGNED: 1,
+	BGPSEC_VALIDATION_RESULT_TYPE_VALID:      2,
+	BGPSEC_VALIDATION_RESULT_TYPE_INVALID:    3,
+}
+
+var IntToBgpsecValidationResultTypeMap = map[int]BgpsecValidationResultType{
+	0: BGPSEC_VALIDATION_RESULT_TYPE_NONE,
+	1: BGPSEC_VALIDATION_RESULT_TYPE_NOT_SIGNED,
+	2: BGPSEC_VALIDATION_RESULT_TYPE_VALID,
+	3: BGPSEC_VALIDATION_RESULT_TYPE_INVALID,
+}
+
+func (v BgpsecValidationResultType) Validate() error {
+	if _, ok := BgpsecValidationResultTypeToIntMap[v]; !ok {
+		return fmt.Errorf("invalid BgpsecValidationResultType: %s", v)
+	}
+	return nil
+}
+
+func (v BgpsecValidationResultType) ToInt() int {
+	i, ok := BgpsecValidationResultTypeToIntMap[v]
+	if !ok {
+		return -1
+	}
+	return i
+}
+
 // typedef for identity gobgp:mrt-type.
 type MrtType string
 
@@ -972,6 +1285,162 @@ func (v MrtType) ToInt() int {
 	return i
 }
 
+// typedef for identity gobgp:mrt-compression-type.
+type MrtCompressionType string
+
+const (
+	MRT_COMPRESSION_TYPE_NONE  MrtCompressionType = "none"
+	MRT_COMPRESSION_TYPE_GZIP  MrtCompressionType = "gzip"
+	MRT_COMPRESSION_TYPE_BZIP2 MrtCompressionType = "bzip2"
+	MRT_COMPRESSION_TYPE_ZSTD  MrtCompressionType = "zstd"
+)
+
+var MrtCompressionTypeToIntMap = map[MrtCompressionType]int{
+	MRT_COMPRESSION_TYPE_NONE:  0,
+	MRT_COMPRESSION_TYPE_GZIP:  1,
+	MRT_COMPRESSION_TYPE_BZIP2: 2,
+	MRT_COMPRESSION_TYPE_ZSTD:  3,
+}
+
+var IntToMrtCompressionTypeMap = map[int]MrtCompressionType{
+	0: MRT_COMPRESSION_TYPE_NONE,
+	1: MRT_COMPRESSION_TYPE_GZIP,
+	2: MRT_COMPRESSION_TYPE_BZIP2,
+	3: MRT_COMPRESSION_TYPE_ZSTD,
+}
+
+func (v MrtCompressionType) Validate() error {
+	if _, ok := MrtCompressionTypeToIntMap[v]; !ok {
+		return fmt.Errorf("invalid MrtCompressionType: %s", v)
+	}
+	return nil
+}
+
+func (v MrtCompressionType) ToInt() int {
+	i, ok := MrtCompressionTypeToIntMap[v]
+	if !ok {
+		return -1
+	}
+	return i
+}
+
+// typedef for identity gobgp:flow-control-type.
+type FlowControlType string
+
+const (
+	FLOW_CONTROL_TYPE_NONE           FlowControlType = "none"
+	FLOW_CONTROL_TYPE_NEXT_STATEMENT FlowControlType = "next-statement"
+	FLOW_CONTROL_TYPE_NEXT_POLICY    FlowControlType = "next-policy"
+	FLOW_CONTROL_TYPE_GOTO           FlowControlType = "goto"
+)
+
+var FlowControlTypeToIntMap = map[FlowControlType]int{
+	FLOW_CONTROL_TYPE_NONE:           0,
+	FLOW_CONTROL_TYPE_NEXT_STATEMENT: 1,
+	FLOW_CONTROL_TYPE_NEXT_POLICY:    2,
+	FLOW_CONTROL_TYPE_GOTO:           3,
+}
+
+var IntToFlowControlTypeMap = map[int]FlowControlType{
+	0: FLOW_CONTROL_TYPE_NONE,
+	1: FLOW_CONTROL_TYPE_NEXT_STATEMENT,
+	2: FLOW_CONTROL_TYPE_NEXT_POLICY,
+	3: FLOW_CONTROL_TYPE_GOTO,
+}
+
+func (v FlowControlType) Validate() error {
+	if _, ok := FlowControlTypeToIntMap[v]; !ok {
+		return fmt.Errorf("invalid FlowControlType: %s", v)
+	}
+	return nil
+}
+
+func (v FlowControlType) ToInt() int {
+	i, ok := FlowControlTypeToIntMap[v]
+	if !ok {
+		return -1
+	}
+	return i
+}
+
+// typedef for identity gobgp:source-peer-type.
+type SourcePeerType string
+
+const (
+	SOURCE_PEER_TYPE_EBGP    SourcePeerType = "ebgp"
+	SOURCE_PEER_TYPE_IBGP    SourcePeerType = "ibgp"
+	SOURCE_PEER_TYPE_CONFED  SourcePeerType = "confed"
+	SOURCE_PEER_TYPE_LOCAL   SourcePeerType = "local"
+	SOURCE_PEER_TYPE_NETLINK SourcePeerType = "netlink"
+)
+
+var SourcePeerTypeToIntMap = map[SourcePeerType]int{
+	SOURCE_PEER_TYPE_EBGP:    0,
+	SOURCE_PEER_TYPE_IBGP:    1,
+	SOURCE_PEER_TYPE_CONFED:  2,
+	SOURCE_PEER_TYPE_LOCAL:   3,
+	SOURCE_PEER_TYPE_NETLINK: 4,
+}
+
+var IntToSourcePeerTypeMap = map[int]SourcePeerType{
+	0: SOURCE_PEER_TYPE_EBGP,
+	1: SOURCE_PEER_TYPE_IBGP,
+	2: SOURCE_PEER_TYPE_CONFED,
+	3: SOURCE_PEER_TYPE_LOCAL,
+	4: SOURCE_PEER_TYPE_NETLINK,
+}
+
+func (v SourcePeerType) Validate() error {
+	if _, ok := SourcePeerTypeToIntMap[v]; !ok {
+		return fmt.Errorf("invalid SourcePeerType: %s", v)
+	}
+	return nil
+}
+
+func (v SourcePeerType) ToInt() int {
+	i, ok := SourcePeerTypeToIntMap[v]
+	if !ok {
+		return -1
+	}
+	return i
+}
+
+// typedef for identity gobgp:mrt-sink-type.
+type MrtSinkType string
+
+const (
+	MRT_SINK_TYPE_FILE        MrtSinkType = "file"
+	MRT_SINK_TYPE_PIPE        MrtSinkType = "pipe"
+	MRT_SINK_TYPE_UNIX_SOCKET MrtSinkType = "unix-socket"
+)
+
+var MrtSinkTypeToIntMap = map[MrtSinkType]int{
+	MRT_SINK_TYPE_FILE:        0,
+	MRT_SINK_TYPE_PIPE:        1,
+	MRT_SINK_TYPE_UNIX_SOCKET: 2,
+}
+
+var IntToMrtSinkTypeMap = map[int]MrtSinkType{
+	0: MRT_SINK_TYPE_FILE,
+	1: MRT_SINK_TYPE_PIPE,
+	2: MRT_SINK_TYPE_UNIX_SOCKET,
+}
+
+func (v MrtSinkType) Validate() error {
+	if _, ok := MrtSinkTypeToIntMap[v]; !ok {
+		return fmt.Errorf("invalid MrtSinkType: %s", v)
+	}
+	return nil
+}
+
+func (v MrtSinkType) ToInt() int {
+	i, ok := MrtSinkTypeToIntMap[v]
+	if !ok {
+		return -1
+	}
+	return i
+}
+
 // typedef for typedef bgp-pol:bgp-as-path-prepend-repeat.
 type BgpAsPathPrependRepeat uint8
 
@@ -1019,6 +1488,9 @@ type BgpNextHopType string
 // typedef for typedef bgp-pol:bgp-set-med-type.
 type BgpSetMedType string
 
+// typedef for typedef gobgp:bgp-set-aigp-type.
+type BgpSetAigpType string
+
 // struct for container gobgp:state.
 type DynamicNeighborState struct {
 	// original -> gobgp:prefix
@@ -1069,6 +1541,100 @@ func (lhs *DynamicNeighbor) Equal(rhs *DynamicNeighbor) bool {
 	return true
 }
 
+// struct for container gobgp:state.
+type BmpStationState struct {
+	// original -> gobgp:enabled
+	// gobgp:enabled's original type is boolean.
+	// Configure enabling to accept the BMP sessions from the monitored routers.
+	Enabled bool `mapstructure:"enabled" json:"enabled,omitempty"`
+	// original -> gobgp:address
+	// gobgp:address's original type is inet:ip-address.
+	// Configure the address to listen on. All the addresses if empty.
+	Address netip.Addr `mapstructure:"address" json:"address,omitempty"`
+	// original -> gobgp:port
+	// Configure the port to listen on. 11019 if zero.
+	Port uint32 `mapstructure:"port" json:"port,omitempty"`
+	// original -> gobgp:mrt-file-name
+	// Configure a MRT file name to re-export the collected routes.
+	MrtFileName string `mapstructure:"mrt-file-name" json:"mrt-file-name,omitempty"`
+	// original -> gobgp:mrt-dump-type
+	MrtDumpType MrtType `mapstructure:"mrt-dump-type" json:"mrt-dump-type,omitempty"`
+	// original -> gobgp:mrt-dump-interval
+	MrtDumpInterval uint64 `mapstructure:"mrt-dump-interval" json:"mrt-dump-interval,omitempty"`
+	// original -> gobgp:mrt-rotation-interval
+	MrtRotationInterval uint64 `mapstructure:"mrt-rotation-interval" json:"mrt-rotation-interval,omitempty"`
+}
+
+// struct for container gobgp:config.
+type BmpStationConfig struct {
+	// original -> gobgp:enabled
+	// gobgp:enabled's original type is boolean.
+	// Configure enabling to accept the BMP sessions from the monitored routers.
+	Enabled bool `mapstructure:"enabled" json:"enabled,omitempty"`
+	// original -> gobgp:address
+	// gobgp:address's original type is inet:ip-address.
+	// Configure the address to listen on. All the addresses if empty.
+	Address netip.Addr `mapstructure:"address" json:"address,omitempty"`
+	// original -> gobgp:port
+	// Configure the port to listen on. 11019 if zero.
+	Port uint32 `mapstructure:"port" json:"port,omitempty"`
+	// original -> gobgp:mrt-file-name
+	// Configure a MRT file name to re-export the collected routes.
+	MrtFileName string `mapstructure:"mrt-file-name" json:"mrt-file-name,omitempty"`
+	// original -> gobgp:mrt-dump-type
+	MrtDumpType MrtType `mapstructure:"mrt-dump-type" json:"mrt-dump-type,omitempty"`
+	// original -> gobgp:mrt-dump-interval
+	MrtDumpInterval uint64 `mapstructure:"mrt-dump-interval" json:"mrt-dump-interval,omitempty"`
+	// original -> gobgp:mrt-rotation-interval
+	MrtRotationInterval uint64 `mapstructure:"mrt-rotation-interval" json:"mrt-rotation-interval,omitempty"`
+}
+
+func (lhs *BmpStationConfig) Equal(rhs *BmpStationConfig) bool {
+	if lhs == nil || rhs == nil {
+		return false
+	}
+	if lhs.Enabled != rhs.Enabled {
+		return false
+	}
+	if lhs.Address != rhs.Address {
+		return false
+	}
+	if lhs.Port != rhs.Port {
+		return false
+	}
+	if lhs.MrtFileName != rhs.MrtFileName {
+		return false
+	}
+	if lhs.MrtDumpType != rhs.MrtDumpType {
+		return false
+	}
+	if lhs.MrtDumpInterval != rhs.MrtDumpInterval {
+		return false
+	}
+	if lhs.MrtRotationInterval != rhs.MrtRotationInterval {
+		return false
+	}
+	return true
+}
+
+// struct for container gobgp:bmp-station.
+type BmpStation struct {
+	// original -> gobgp:bmp-station-config
+	Config BmpStationConfig `mapstructure:"config" json:"config,omitempty"`
+	// original -> gobgp:bmp-station-state
+	State BmpStationState `mapstructure:"state" json:"state,omitempty"`
+}
+
+func (lhs *BmpStation) Equal(rhs *BmpStation) bool {
+	if lhs == nil || rhs == nil {
+		return false
+	}
+	if !lhs.Config.Equal(&(rhs.Config)) {
+		return false
+	}
+	return true
+}
+
 // struct for container gobgp:state.
 type CollectorState struct {
 	// original -> gobgp:url
@@ -1292,6 +1858,17 @@ type Netlink struct {
 	State NetlinkState `mapstructure:"state" json:"state,omitempty"`
 	Import NetlinkImport `mapstructure:"import" json:"import,omitempty"`
 	Export NetlinkExport `mapstructure:"export" json:"export,omitempty"`
+	Ls     NetlinkLs     `mapstructure:"ls" json:"ls,omitempty"`
+}
+
+// struct for container gobgp:netlink-ls.
+// Originates the host topology from the Linux link and address tables as
+// BGP-LS NLRIs.
+type NetlinkLs struct {
+	Enabled       bool     `mapstructure:"enabled" json:"enabled,omitempty"`
+	InterfaceList []string `mapstructure:"interface-list" json:"interface-list,omitempty"` // all the interfaces except loopback if empty
+	Identifier    uint64   `mapstructure:"identifier" json:"identifier,omitempty"`         // BGP-LS Identifier of the NLRI header
+	BgpLsId       uint32   `mapstructure:"bgp-ls-id" json:"bgp-ls-id,omitempty"`
 }
 
 // struct for container gobgp:netlink-import.
@@ -1314,6 +1891,7 @@ type NetlinkExportRule struct {
 	Name               string   `mapstructure:"name" json:"name,omitempty"`
 	CommunityList      []string `mapstructure:"community-list" json:"community-list,omitempty"`
 	LargeCommunityList []string `mapstructure:"large-community-list" json:"large-community-list,omitempty"`
+	TagList            []uint32 `mapstructure:"tag-list" json:"tag-list,omitempty"` // route tags set by the policy
 	Vrf                string   `mapstructure:"vrf" json:"vrf,omitempty"`
 	TableId            int      `mapstructure:"table-id" json:"table-id,omitempty"`
 	Metric             uint32   `mapstructure:"metric" json:"metric,omitempty"`
@@ -1353,6 +1931,30 @@ func (lhs *NetlinkImport) Equal(rhs *NetlinkImport) bool {
 	return true
 }
 
+func (lhs *NetlinkLs) Equal(rhs *NetlinkLs) bool {
+	if lhs == nil || rhs == nil {
+		return false
+	}
+	if lhs.Enabled != rhs.Enabled {
+		return false
+	}
+	if lhs.Identifier != rhs.Identifier {
+		return false
+	}
+	if lhs.BgpLsId != rhs.BgpLsId {
+		return false
+	}
+	if len(lhs.InterfaceList) != len(rhs.InterfaceList) {
+		return false
+	}
+	for idx, l := range lhs.InterfaceList {
+		if l != rhs.InterfaceList[idx] {
+			return false
+		}
+	}
+	return true
+}
+
 func (lhs *NetlinkExport) Equal(rhs *NetlinkExport) bool {
 	if lhs == nil || rhs == nil {
 		return false
@@ -1418,6 +2020,15 @@ func (lhs *NetlinkExportRule) Equal(rhs *NetlinkExportRule) bool {
 			return false
 		}
 	}
+	// Compare tag lists
+	if len(lhs.TagList) != len(rhs.TagList) {
+		return false
+	}
+	for idx, l := range lhs.TagList {
+		if l != rhs.TagList[idx] {
+			return false
+		}
+	}
 	return true
 }
 
@@ -1480,6 +2091,10 @@ func (lhs *Netlink) Equal(rhs *Netlink) bool {
 	if !lhs.Export.Equal(&(rhs.Export)) {
 		return false
 	}
+	// Compare BGP-LS config
+	if !lhs.Ls.Equal(&(rhs.Ls)) {
+		return false
+	}
 	return true
 }
 
@@ -1498,6 +2113,26 @@ type MrtConfig struct {
 	DumpInterval uint64 `mapstructure:"dump-interval" json:"dump-interval,omitempty"`
 	// original -> gobgp:rotation-interval
 	RotationInterval uint64 `mapstructure:"rotation-interval" json:"rotation-interval,omitempty"`
+	// original -> gobgp:vrf
+	// specify the vrf name to dump its routes.
+	Vrf string `mapstructure:"vrf" json:"vrf,omitempty"`
+	// original -> gobgp:compression
+	Compression MrtCompressionType `mapstructure:"compression" json:"compression,omitempty"`
+	// original -> gobgp:sink-type
+	// specify the destination of the records; file-name is the path
+	// of the pipe or the unix domain socket.
+	SinkType MrtSinkType `mapstructure:"sink-type" json:"sink-type,omitempty"`
+	// original -> gobgp:rotation-size
+	// rotates the file when the size of the records written to it
+	// reaches the bytes.
+	RotationSize uint64 `mapstructure:"rotation-size" json:"rotation-size,omitempty"`
+	// original -> gobgp:retention-files
+	// the number of the files to keep, including the one being
+	// written.
+	RetentionFiles uint32 `mapstructure:"retention-files" json:"retention-files,omitempty"`
+	// original -> gobgp:retention-size
+	// the total bytes of the files to keep.
+	RetentionSize uint64 `mapstructure:"retention-size" json:"retention-size,omitempty"`
 }
 
 func (lhs *MrtConfig) Equal(rhs *MrtConfig) bool {
@@ -1519,6 +2154,24 @@ func (lhs *MrtConfig) Equal(rhs *MrtConfig) bool {
 	if lhs.RotationInterval != rhs.RotationInterval {
 		return false
 	}
+	if lhs.Vrf != rhs.Vrf {
+		return false
+	}
+	if lhs.Compression != rhs.Compression {
+		return false
+	}
+	if lhs.SinkType != rhs.SinkType {
+		return false
+	}
+	if lhs.RotationSize != rhs.RotationSize {
+		return false
+	}
+	if lhs.RetentionFiles != rhs.RetentionFiles {
+		return false
+	}
+	if lhs.RetentionSize != rhs.RetentionSize {
+		return false
+	}
 	return true
 }
 
@@ -1639,6 +2292,10 @@ type Vrf struct {
 	// original -> gobgp:netlink-export
 	// Netlink export configuration for this VRF.
 	NetlinkExport VrfNetlinkExport `mapstructure:"netlink-export" json:"netlink-export,omitempty"`
+	// original -> bgp-mp:route-selection-options
+	// Route selection options for this VRF. The global options are
+	// used if not set.
+	RouteSelectionOptions RouteSelectionOptions `mapstructure:"route-selection-options" json:"route-selection-options,omitempty"`
 }
 
 func (lhs *Vrf) Equal(rhs *Vrf) bool {
@@ -1654,6 +2311,9 @@ func (lhs *Vrf) Equal(rhs *Vrf) bool {
 	if !lhs.NetlinkExport.Equal(&(rhs.NetlinkExport)) {
 		return false
 	}
+	if !lhs.RouteSelectionOptions.Equal(&(rhs.RouteSelectionOptions)) {
+		return false
+	}
 	return true
 }
 
@@ -1678,6 +2338,41 @@ type BmpServerState struct {
 	// Enable feature for mirroring of received BGP messages
 	// mainly for debugging purpose.
 	RouteMirroringEnabled bool `mapstructure:"route-mirroring-enabled" json:"route-mirroring-enabled,omitempty"`
+	// original -> gobgp:adj-rib-out-pre-policy-enabled
+	// gobgp:adj-rib-out-pre-policy-enabled's original type is boolean.
+	// Enable Adj-RIB-Out route monitoring of the routes sent to the
+	// peers before the export policy is applied (RFC 8671).
+	AdjRibOutPrePolicyEnabled bool `mapstructure:"adj-rib-out-pre-policy-enabled" json:"adj-rib-out-pre-policy-enabled,omitempty"`
+	// original -> gobgp:adj-rib-out-post-policy-enabled
+	// gobgp:adj-rib-out-post-policy-enabled's original type is boolean.
+	// Enable Adj-RIB-Out route monitoring of the routes sent to the
+	// peers after the export policy is applied (RFC 8671).
+	AdjRibOutPostPolicyEnabled bool `mapstructure:"adj-rib-out-post-policy-enabled" json:"adj-rib-out-post-policy-enabled,omitempty"`
+	// original -> gobgp:tls-enabled
+	// gobgp:tls-enabled's original type is boolean.
+	// Enable TLS for the BMP session.
+	TlsEnabled bool `mapstructure:"tls-enabled" json:"tls-enabled,omitempty"`
+	// original -> gobgp:tls-ca-file
+	// The CA certificates to verify the BMP server with; only these
+	// are trusted if set.
+	TlsCaFile string `mapstructure:"tls-ca-file" json:"tls-ca-file,omitempty"`
+	// original -> gobgp:tls-cert-file
+	// The client certificate file.
+	TlsCertFile string `mapstructure:"tls-cert-file" json:"tls-cert-file,omitempty"`
+	// original -> gobgp:tls-key-file
+	// The client key file.
+	TlsKeyFile string `mapstructure:"tls-key-file" json:"tls-key-file,omitempty"`
+	// original -> gobgp:tls-server-name
+	// The name to verify the certificate of the BMP server with,
+	// the address of the BMP server if empty.
+	TlsServerName string `mapstructure:"tls-server-name" json:"tls-server-name,omitempty"`
+	// original -> gobgp:queue-size
+	// The number of the messages queued for the BMP server,
+	// unbounded if zero.
+	QueueSize uint32 `mapstructure:"queue-size" json:"queue-size,omitempty"`
+	// original -> gobgp:queue-overflow-action
+	// The action taken when the queue is full.
+	QueueOverflowAction BmpQueueOverflowActionType `mapstructure:"queue-overflow-action" json:"queue-overflow-action,omitempty"`
 	// original -> gobgp:sys-name
 	// Reference to the SysName of the BMP server.
 	SysName string `mapstructure:"sys-name" json:"sys-name,omitempty"`
@@ -1707,6 +2402,41 @@ type BmpServerConfig struct {
 	// Enable feature for mirroring of received BGP messages
 	// mainly for debugging purpose.
 	RouteMirroringEnabled bool `mapstructure:"route-mirroring-enabled" json:"route-mirroring-enabled,omitempty"`
+	// original -> gobgp:adj-rib-out-pre-policy-enabled
+	// gobgp:adj-rib-out-pre-policy-enabled's original type is boolean.
+	// Enable Adj-RIB-Out route monitoring of the routes sent to the
+	// peers before the export policy is applied (RFC 8671).
+	AdjRibOutPrePolicyEnabled bool `mapstructure:"adj-rib-out-pre-policy-enabled" json:"adj-rib-out-pre-policy-enabled,omitempty"`
+	// original -> gobgp:adj-rib-out-post-policy-enabled
+	// gobgp:adj-rib-out-post-policy-enabled's original type is boolean.
+	// Enable Adj-RIB-Out route monitoring of the routes sent to the
+	// peers after the export policy is applied (RFC 8671).
+	AdjRibOutPostPolicyEnabled bool `mapstructure:"adj-rib-out-post-policy-enabled" json:"adj-rib-out-post-policy-enabled,omitempty"`
+	// original -> gobgp:tls-enabled
+	// gobgp:tls-enabled's original type is boolean.
+	// Enable TLS for the BMP session.
+	TlsEnabled bool `mapstructure:"tls-enabled" json:"tls-enabled,omitempty"`
+	// original -> gobgp:tls-ca-file
+	// The CA certificates to verify the BMP server with; only these
+	// are trusted if set.
+	TlsCaFile string `mapstructure:"tls-ca-file" json:"tls-ca-file,omitempty"`
+	// original -> gobgp:tls-cert-file
+	// The client certificate file.
+	TlsCertFile string `mapstructure:"tls-cert-file" json:"tls-cert-file,omitempty"`
+	// original -> gobgp:tls-key-file
+	// The client key file.
+	TlsKeyFile string `mapstructure:"tls-key-file" json:"tls-key-file,omitempty"`
+	// original -> gobgp:tls-server-name
+	// The name to verify the certificate of the BMP server with,
+	// the address of the BMP server if empty.
+	TlsServerName string `mapstructure:"tls-server-name" json:"tls-server-name,omitempty"`
+	// original -> gobgp:queue-size
+	// The number of the messages queued for the BMP server,
+	// unbounded if zero.
+	QueueSize uint32 `mapstructure:"queue-size" json:"queue-size,omitempty"`
+	// original -> gobgp:queue-overflow-action
+	// The action taken when the queue is full.
+	QueueOverflowAction BmpQueueOverflowActionType `mapstructure:"queue-overflow-action" json:"queue-overflow-action,omitempty"`
 	// original -> gobgp:sys-name
 	// Reference to the SysName of the BMP server.
 	SysName string `mapstructure:"sys-name" json:"sys-name,omitempty"`
@@ -1734,6 +2464,33 @@ func (lhs *BmpServerConfig) Equal(rhs *BmpServerConfig) bool {
 	if lhs.RouteMirroringEnabled != rhs.RouteMirroringEnabled {
 		return false
 	}
+	if lhs.AdjRibOutPrePolicyEnabled != rhs.AdjRibOutPrePolicyEnabled {
+		return false
+	}
+	if lhs.AdjRibOutPostPolicyEnabled != rhs.AdjRibOutPostPolicyEnabled {
+		return false
+	}
+	if lhs.TlsEnabled != rhs.TlsEnabled {
+		return false
+	}
+	if lhs.TlsCaFile != rhs.TlsCaFile {
+		return false
+	}
+	if lhs.TlsCertFile != rhs.TlsCertFile {
+		return false
+	}
+	if lhs.TlsKeyFile != rhs.TlsKeyFile {
+		return false
+	}
+	if lhs.TlsServerName != rhs.TlsServerName {
+		return false
+	}
+	if lhs.QueueSize != rhs.QueueSize {
+		return false
+	}
+	if lhs.QueueOverflowAction != rhs.QueueOverflowAction {
+		return false
+	}
 	if lhs.SysName != rhs.SysName {
 		return false
 	}
@@ -1789,6 +2546,12 @@ type RpkiReceived struct {
 	// original -> gobgp:error
 	// Number of error message received from RPKI server.
 	Error int64 `mapstructure:"error" json:"error,omitempty"`
+	// original -> gobgp:router-key
+	// Number of router key message received from RPKI server.
+	RouterKey int64 `mapstructure:"router-key" json:"router-key,omitempty"`
+	// original -> gobgp:aspa
+	// Number of ASPA message received from RPKI server.
+	Aspa int64 `mapstructure:"aspa" json:"aspa,omitempty"`
 }
 
 func (lhs *RpkiReceived) Equal(rhs *RpkiReceived) bool {
@@ -1816,6 +2579,12 @@ func (lhs *RpkiReceived) Equal(rhs *RpkiReceived) bool {
 	if lhs.Error != rhs.Error {
 		return false
 	}
+	if lhs.RouterKey != rhs.RouterKey {
+		return false
+	}
+	if lhs.Aspa != rhs.Aspa {
+		return false
+	}
 	return true
 }
 
@@ -1889,6 +2658,22 @@ type RpkiServerState struct {
 	PrefixesV4 uint32 `mapstructure:"prefixes-v4" json:"prefixes-v4,omitempty"`
 	// original -> gobgp:prefixes-v6
 	PrefixesV6 uint32 `mapstructure:"prefixes-v6" json:"prefixes-v6,omitempty"`
+	// original -> gobgp:router-keys
+	RouterKeys uint32 `mapstructure:"router-keys" json:"router-keys,omitempty"`
+	// original -> gobgp:aspas
+	Aspas uint32 `mapstructure:"aspas" json:"aspas,omitempty"`
+	// original -> gobgp:protocol-version
+	// The RTR protocol version negotiated with the RPKI server.
+	ProtocolVersion uint8 `mapstructure:"protocol-version" json:"protocol-version,omitempty"`
+	// original -> gobgp:refresh-interval
+	// The interval seconds of the serial queries.
+	RefreshInterval uint32 `mapstructure:"refresh-interval" json:"refresh-interval,omitempty"`
+	// original -> gobgp:retry-interval
+	// The interval seconds of the retries after failures.
+	RetryInterval uint32 `mapstructure:"retry-interval" json:"retry-interval,omitempty"`
+	// original -> gobgp:expire-interval
+	// How long the data is kept without a successful refresh.
+	ExpireInterval uint32 `mapstructure:"expire-interval" json:"expire-interval,omitempty"`
 	// original -> gobgp:uptime
 	// This timer determines the amount of time since the
 	// RPKI last transitioned in of the Established state.
@@ -1905,73 +2690,275 @@ type RpkiServerState struct {
 	RpkiMessages RpkiMessages `mapstructure:"rpki-messages" json:"rpki-messages,omitempty"`
 }
 
-// struct for container gobgp:config.
-// Configuration parameters relating to RPKI server.
-type RpkiServerConfig struct {
-	// original -> gobgp:address
-	// gobgp:address's original type is inet:ip-address.
-	// Reference to the address of the RPKI server used as
-	// a key in the RPKI server list.
-	Address netip.Addr `mapstructure:"address" json:"address,omitempty"`
-	// original -> gobgp:port
-	// Reference to the port of the RPKI server.
-	Port uint32 `mapstructure:"port" json:"port,omitempty"`
-	// original -> gobgp:refresh-time
-	// Check interval for a configured RPKI server.
-	RefreshTime int64 `mapstructure:"refresh-time" json:"refresh-time,omitempty"`
-	// original -> gobgp:hold-time
-	// Specify the length of time in seconds that the session between
-	// the router and RPKI server is to be considered operational
-	// without any activity.
-	HoldTime int64 `mapstructure:"hold-time" json:"hold-time,omitempty"`
-	// original -> gobgp:record-lifetime
-	// Indicate the expiration date of the route validation recode
-	// received from RPKI server.
-	RecordLifetime int64 `mapstructure:"record-lifetime" json:"record-lifetime,omitempty"`
-	// original -> gobgp:preference
-	// RPKI server has a static preference.
-	// Higher the preference values indicates a higher priority RPKI server.
-	Preference uint8 `mapstructure:"preference" json:"preference,omitempty"`
+// struct for container gobgp:config.
+// Configuration parameters relating to RPKI server.
+type RpkiServerConfig struct {
+	// original -> gobgp:address
+	// gobgp:address's original type is inet:ip-address.
+	// Reference to the address of the RPKI server used as
+	// a key in the RPKI server list.
+	Address netip.Addr `mapstructure:"address" json:"address,omitempty"`
+	// original -> gobgp:port
+	// Reference to the port of the RPKI server.
+	Port uint32 `mapstructure:"port" json:"port,omitempty"`
+	// original -> gobgp:refresh-time
+	// Check interval for a configured RPKI server.
+	RefreshTime int64 `mapstructure:"refresh-time" json:"refresh-time,omitempty"`
+	// original -> gobgp:hold-time
+	// Specify the length of time in seconds that the session between
+	// the router and RPKI server is to be considered operational
+	// without any activity.
+	HoldTime int64 `mapstructure:"hold-time" json:"hold-time,omitempty"`
+	// original -> gobgp:record-lifetime
+	// Indicate the expiration date of the route validation recode
+	// received from RPKI server.
+	RecordLifetime int64 `mapstructure:"record-lifetime" json:"record-lifetime,omitempty"`
+	// original -> gobgp:preference
+	// RPKI server has a static preference.
+	// Higher the preference values indicates a higher priority RPKI server.
+	Preference uint8 `mapstructure:"preference" json:"preference,omitempty"`
+	// original -> gobgp:transport
+	// The transport of the RTR session.
+	Transport RpkiTransportType `mapstructure:"transport" json:"transport,omitempty"`
+	// original -> gobgp:max-protocol-version
+	// The highest RTR protocol version to negotiate.
+	MaxProtocolVersion RtrProtocolVersionType `mapstructure:"max-protocol-version" json:"max-protocol-version,omitempty"`
+	// original -> gobgp:tls-ca-file
+	// The CA certificates to verify the RPKI server with; only these
+	// are trusted if set.
+	TlsCaFile string `mapstructure:"tls-ca-file" json:"tls-ca-file,omitempty"`
+	// original -> gobgp:tls-cert-file
+	// The client certificate file.
+	TlsCertFile string `mapstructure:"tls-cert-file" json:"tls-cert-file,omitempty"`
+	// original -> gobgp:tls-key-file
+	// The client key file.
+	TlsKeyFile string `mapstructure:"tls-key-file" json:"tls-key-file,omitempty"`
+	// original -> gobgp:tls-server-name
+	// The name to verify the certificate of the RPKI server with,
+	// the address of the RPKI server if empty.
+	TlsServerName string `mapstructure:"tls-server-name" json:"tls-server-name,omitempty"`
+	// original -> gobgp:ssh-username
+	// The user name of the SSH session.
+	SshUsername string `mapstructure:"ssh-username" json:"ssh-username,omitempty"`
+	// original -> gobgp:ssh-private-key-file
+	// The private key file to authenticate the SSH session with.
+	SshPrivateKeyFile string `mapstructure:"ssh-private-key-file" json:"ssh-private-key-file,omitempty"`
+	// original -> gobgp:ssh-known-hosts-file
+	// The known hosts file to verify the RPKI server with.
+	SshKnownHostsFile string `mapstructure:"ssh-known-hosts-file" json:"ssh-known-hosts-file,omitempty"`
+}
+
+func (lhs *RpkiServerConfig) Equal(rhs *RpkiServerConfig) bool {
+	if lhs == nil || rhs == nil {
+		return false
+	}
+	if lhs.Address != rhs.Address {
+		return false
+	}
+	if lhs.Port != rhs.Port {
+		return false
+	}
+	if lhs.RefreshTime != rhs.RefreshTime {
+		return false
+	}
+	if lhs.HoldTime != rhs.HoldTime {
+		return false
+	}
+	if lhs.RecordLifetime != rhs.RecordLifetime {
+		return false
+	}
+	if lhs.Preference != rhs.Preference {
+		return false
+	}
+	if lhs.Transport != rhs.Transport {
+		return false
+	}
+	if lhs.MaxProtocolVersion != rhs.MaxProtocolVersion {
+		return false
+	}
+	if lhs.TlsCaFile != rhs.TlsCaFile {
+		return false
+	}
+	if lhs.TlsCertFile != rhs.TlsCertFile {
+		return false
+	}
+	if lhs.TlsKeyFile != rhs.TlsKeyFile {
+		return false
+	}
+	if lhs.TlsServerName != rhs.TlsServerName {
+		return false
+	}
+	if lhs.SshUsername != rhs.SshUsername {
+		return false
+	}
+	if lhs.SshPrivateKeyFile != rhs.SshPrivateKeyFile {
+		return false
+	}
+	if lhs.SshKnownHostsFile != rhs.SshKnownHostsFile {
+		return false
+	}
+	return true
+}
+
+// struct for container gobgp:rpki-server.
+// List of RPKI servers configured on the local system.
+type RpkiServer struct {
+	// original -> gobgp:address
+	// original -> gobgp:rpki-server-config
+	// Configuration parameters relating to RPKI server.
+	Config RpkiServerConfig `mapstructure:"config" json:"config,omitempty"`
+	// original -> gobgp:rpki-server-state
+	// State information relating to RPKI server.
+	State RpkiServerState `mapstructure:"state" json:"state,omitempty"`
+}
+
+func (lhs *RpkiServer) Equal(rhs *RpkiServer) bool {
+	if lhs == nil || rhs == nil {
+		return false
+	}
+	if !lhs.Config.Equal(&(rhs.Config)) {
+		return false
+	}
+	return true
+}
+
+// struct for container gobgp:state.
+type RpkiFileState struct {
+	// original -> gobgp:records-v4
+	RecordsV4 uint32 `mapstructure:"records-v4" json:"records-v4,omitempty"`
+	// original -> gobgp:records-v6
+	RecordsV6 uint32 `mapstructure:"records-v6" json:"records-v6,omitempty"`
+	// original -> gobgp:router-keys
+	RouterKeys uint32 `mapstructure:"router-keys" json:"router-keys,omitempty"`
+	// original -> gobgp:aspas
+	Aspas uint32 `mapstructure:"aspas" json:"aspas,omitempty"`
+	// original -> gobgp:filters
+	// The number of the SLURM filters.
+	Filters uint32 `mapstructure:"filters" json:"filters,omitempty"`
+	// original -> gobgp:loaded
+	// The time when the file was loaded last.
+	Loaded int64 `mapstructure:"loaded" json:"loaded,omitempty"`
+	// original -> gobgp:loads
+	// The number of the successful loads.
+	Loads uint64 `mapstructure:"loads" json:"loads,omitempty"`
+	// original -> gobgp:error
+	// The error of the last failed load.
+	Error string `mapstructure:"error" json:"error,omitempty"`
+}
+
+// struct for container gobgp:config.
+type RpkiFileConfig struct {
+	// original -> gobgp:path
+	// The path of the file, used as the source of the data.
+	Path string `mapstructure:"path" json:"path,omitempty"`
+	// original -> gobgp:format
+	// The format of the file.
+	Format RpkiFileFormatType `mapstructure:"format" json:"format,omitempty"`
+}
+
+func (lhs *RpkiFileConfig) Equal(rhs *RpkiFileConfig) bool {
+	if lhs == nil || rhs == nil {
+		return false
+	}
+	if lhs.Path != rhs.Path {
+		return false
+	}
+	if lhs.Format != rhs.Format {
+		return false
+	}
+	return true
+}
+
+// struct for container gobgp:rpki-file.
+// List of the local files of RPKI data, loaded without an RPKI
+// server and reloaded on change.
+type RpkiFile struct {
+	// original -> gobgp:path
+	// original -> gobgp:rpki-file-config
+	Config RpkiFileConfig `mapstructure:"config" json:"config,omitempty"`
+	// original -> gobgp:rpki-file-state
+	State RpkiFileState `mapstructure:"state" json:"state,omitempty"`
 }
 
-func (lhs *RpkiServerConfig) Equal(rhs *RpkiServerConfig) bool {
+func (lhs *RpkiFile) Equal(rhs *RpkiFile) bool {
 	if lhs == nil || rhs == nil {
 		return false
 	}
-	if lhs.Address != rhs.Address {
+	if !lhs.Config.Equal(&(rhs.Config)) {
 		return false
 	}
-	if lhs.Port != rhs.Port {
+	return true
+}
+
+// struct for container gobgp:state.
+type DefinedSetFileState struct {
+	// original -> gobgp:entries
+	// The number of the entries in the defined set.
+	Entries uint32 `mapstructure:"entries" json:"entries,omitempty"`
+	// original -> gobgp:added
+	// The number of the entries added by the last load.
+	Added uint32 `mapstructure:"added" json:"added,omitempty"`
+	// original -> gobgp:removed
+	// The number of the entries removed by the last load.
+	Removed uint32 `mapstructure:"removed" json:"removed,omitempty"`
+	// original -> gobgp:loaded
+	// The time when the file was loaded last.
+	Loaded int64 `mapstructure:"loaded" json:"loaded,omitempty"`
+	// original -> gobgp:loads
+	// The number of the successful loads.
+	Loads uint64 `mapstructure:"loads" json:"loads,omitempty"`
+	// original -> gobgp:error
+	// The error of the last failed load.
+	Error string `mapstructure:"error" json:"error,omitempty"`
+}
+
+// struct for container gobgp:config.
+type DefinedSetFileConfig struct {
+	// original -> gobgp:path
+	// The path of the file.
+	Path string `mapstructure:"path" json:"path,omitempty"`
+	// original -> gobgp:name
+	// The name of the defined set replaced with the contents of the file.
+	Name string `mapstructure:"name" json:"name,omitempty"`
+	// original -> gobgp:type
+	// The type of the defined set.
+	Type DefinedSetFileType `mapstructure:"type" json:"type,omitempty"`
+	// original -> gobgp:format
+	// The format of the file.
+	Format DefinedSetFileFormatType `mapstructure:"format" json:"format,omitempty"`
+}
+
+func (lhs *DefinedSetFileConfig) Equal(rhs *DefinedSetFileConfig) bool {
+	if lhs == nil || rhs == nil {
 		return false
 	}
-	if lhs.RefreshTime != rhs.RefreshTime {
+	if lhs.Path != rhs.Path {
 		return false
 	}
-	if lhs.HoldTime != rhs.HoldTime {
+	if lhs.Name != rhs.Name {
 		return false
 	}
-	if lhs.RecordLifetime != rhs.RecordLifetime {
+	if lhs.Type != rhs.Type {
 		return false
 	}
-	if lhs.Preference != rhs.Preference {
+	if lhs.Format != rhs.Format {
 		return false
 	}
 	return true
 }
 
-// struct for container gobgp:rpki-server.
-// List of RPKI servers configured on the local system.
-type RpkiServer struct {
-	// original -> gobgp:address
-	// original -> gobgp:rpki-server-config
-	// Configuration parameters relating to RPKI server.
-	Config RpkiServerConfig `mapstructure:"config" json:"config,omitempty"`
-	// original -> gobgp:rpki-server-state
-	// State information relating to RPKI server.
-	State RpkiServerState `mapstructure:"state" json:"state,omitempty"`
+// struct for container gobgp:defined-set-file.
+// List of the local files which the prefix sets and the AS path sets
+// are loaded from and reloaded on change.
+type DefinedSetFile struct {
+	// original -> gobgp:path
+	// original -> gobgp:defined-set-file-config
+	Config DefinedSetFileConfig `mapstructure:"config" json:"config,omitempty"`
+	// original -> gobgp:defined-set-file-state
+	State DefinedSetFileState `mapstructure:"state" json:"state,omitempty"`
 }
 
-func (lhs *RpkiServer) Equal(rhs *RpkiServer) bool {
+func (lhs *DefinedSetFile) Equal(rhs *DefinedSetFile) bool {
 	if lhs == nil || rhs == nil {
 		return false
 	}
@@ -2555,6 +3542,17 @@ type RouteReflectorState struct {
 	// bgp:route-reflector-client's original type is boolean.
 	// Configure the neighbor as a route reflector client.
 	RouteReflectorClient bool `mapstructure:"route-reflector-client" json:"route-reflector-client,omitempty"`
+	// original -> gobgp:advertise-best-external
+	// gobgp:advertise-best-external's original type is boolean.
+	// Advertise the best eBGP path to the neighbor when the
+	// overall best path is learned via iBGP and can't be advertised
+	// to the neighbor.
+	AdvertiseBestExternal bool `mapstructure:"advertise-best-external" json:"advertise-best-external,omitempty"`
+	// original -> gobgp:diverse-path
+	// gobgp:diverse-path's original type is boolean.
+	// Advertise the diverse path, the best of the paths with a next hop
+	// or a cluster different from the best path, to the neighbor.
+	DiversePath bool `mapstructure:"diverse-path" json:"diverse-path,omitempty"`
 }
 
 // struct for container bgp:config.
@@ -2572,6 +3570,17 @@ type RouteReflectorConfig struct {
 	// bgp:route-reflector-client's original type is boolean.
 	// Configure the neighbor as a route reflector client.
 	RouteReflectorClient bool `mapstructure:"route-reflector-client" json:"route-reflector-client,omitempty"`
+	// original -> gobgp:advertise-best-external
+	// gobgp:advertise-best-external's original type is boolean.
+	// Advertise the best eBGP path to the neighbor when the
+	// overall best path is learned via iBGP and can't be advertised
+	// to the neighbor.
+	AdvertiseBestExternal bool `mapstructure:"advertise-best-external" json:"advertise-best-external,omitempty"`
+	// original -> gobgp:diverse-path
+	// gobgp:diverse-path's original type is boolean.
+	// Advertise the diverse path, the best of the paths with a next hop
+	// or a cluster different from the best path, to the neighbor.
+	DiversePath bool `mapstructure:"diverse-path" json:"diverse-path,omitempty"`
 }
 
 func (lhs *RouteReflectorConfig) Equal(rhs *RouteReflectorConfig) bool {
@@ -2584,6 +3593,12 @@ func (lhs *RouteReflectorConfig) Equal(rhs *RouteReflectorConfig) bool {
 	if lhs.RouteReflectorClient != rhs.RouteReflectorClient {
 		return false
 	}
+	if lhs.AdvertiseBestExternal != rhs.AdvertiseBestExternal {
+		return false
+	}
+	if lhs.DiversePath != rhs.DiversePath {
+		return false
+	}
 	return true
 }
 
@@ -2973,6 +3988,13 @@ type TimersState struct {
 	// Time interval in seconds that a BGP session will be
 	// in idle state after neighbor reset operation.
 	IdleHoldTimeAfterReset float64 `mapstructure:"idle-hold-time-after-reset" json:"idle-hold-time-after-reset,omitempty"`
+	// original -> gobgp:send-hold-time
+	// gobgp:send-hold-time's original type is decimal64.
+	// Time interval in seconds after which the BGP session is
+	// closed when no data can be sent to the peer (RFC 9687).
+	// Zero means the larger of 480 seconds and twice the
+	// negotiated hold-time.
+	SendHoldTime float64 `mapstructure:"send-hold-time" json:"send-hold-time,omitempty"`
 	// original -> gobgp:downtime
 	// gobgp:downtime's original type is yang:timeticks.
 	// This timer determines the amount of time since the
@@ -3020,6 +4042,13 @@ type TimersConfig struct {
 	// Time interval in seconds that a BGP session will be
 	// in idle state after neighbor reset operation.
 	IdleHoldTimeAfterReset float64 `mapstructure:"idle-hold-time-after-reset" json:"idle-hold-time-after-reset,omitempty"`
+	// original -> gobgp:send-hold-time
+	// gobgp:send-hold-time's original type is decimal64.
+	// Time interval in seconds after which the BGP session is
+	// closed when no data can be sent to the peer (RFC 9687).
+	// Zero means the larger of 480 seconds and twice the
+	// negotiated hold-time.
+	SendHoldTime float64 `mapstructure:"send-hold-time" json:"send-hold-time,omitempty"`
 }
 
 func (lhs *TimersConfig) Equal(rhs *TimersConfig) bool {
@@ -3041,6 +4070,9 @@ func (lhs *TimersConfig) Equal(rhs *TimersConfig) bool {
 	if lhs.IdleHoldTimeAfterReset != rhs.IdleHoldTimeAfterReset {
 		return false
 	}
+	if lhs.SendHoldTime != rhs.SendHoldTime {
+		return false
+	}
 	return true
 }
 
@@ -3691,6 +4723,170 @@ func (lhs *LongLivedGracefulRestart) Equal(rhs *LongLivedGracefulRestart) bool {
 	return true
 }
 
+// struct for container gobgp:state.
+type BgpsecState struct {
+	// original -> gobgp:send
+	// gobgp:send's original type is boolean.
+	// Advertise the capability to send BGPsec UPDATE messages for
+	// the AFI-SAFI.
+	Send bool `mapstructure:"send" json:"send,omitempty"`
+	// original -> gobgp:receive
+	// gobgp:receive's original type is boolean.
+	// Advertise the capability to receive BGPsec UPDATE messages
+	// for the AFI-SAFI.
+	Receive bool `mapstructure:"receive" json:"receive,omitempty"`
+}
+
+// struct for container gobgp:config.
+type BgpsecConfig struct {
+	// original -> gobgp:send
+	// gobgp:send's original type is boolean.
+	// Advertise the capability to send BGPsec UPDATE messages for
+	// the AFI-SAFI.
+	Send bool `mapstructure:"send" json:"send,omitempty"`
+	// original -> gobgp:receive
+	// gobgp:receive's original type is boolean.
+	// Advertise the capability to receive BGPsec UPDATE messages
+	// for the AFI-SAFI.
+	Receive bool `mapstructure:"receive" json:"receive,omitempty"`
+}
+
+func (lhs *BgpsecConfig) Equal(rhs *BgpsecConfig) bool {
+	if lhs == nil || rhs == nil {
+		return false
+	}
+	if lhs.Send != rhs.Send {
+		return false
+	}
+	if lhs.Receive != rhs.Receive {
+		return false
+	}
+	return true
+}
+
+// struct for container gobgp:bgpsec.
+// BGPsec (RFC 8205) configuration options related to a
+// particular AFI-SAFI.
+type Bgpsec struct {
+	// original -> gobgp:bgpsec-config
+	Config BgpsecConfig `mapstructure:"config" json:"config,omitempty"`
+	// original -> gobgp:bgpsec-state
+	State BgpsecState `mapstructure:"state" json:"state,omitempty"`
+}
+
+func (lhs *Bgpsec) Equal(rhs *Bgpsec) bool {
+	if lhs == nil || rhs == nil {
+		return false
+	}
+	if !lhs.Config.Equal(&(rhs.Config)) {
+		return false
+	}
+	return true
+}
+
+// struct for container gobgp:state.
+type BgpsecSigningState struct {
+	// original -> gobgp:private-key-file
+	// Path to the PEM encoded ECDSA P-256 private key used to sign
+	// BGPsec_PATH attributes.
+	PrivateKeyFile string `mapstructure:"private-key-file" json:"private-key-file,omitempty"`
+	// original -> gobgp:ski
+	// Subject Key Identifier of the router key in hex.
+	Ski string `mapstructure:"ski" json:"ski,omitempty"`
+}
+
+// struct for container gobgp:config.
+type BgpsecSigningConfig struct {
+	// original -> gobgp:private-key-file
+	// Path to the PEM encoded ECDSA P-256 private key used to sign
+	// BGPsec_PATH attributes.
+	PrivateKeyFile string `mapstructure:"private-key-file" json:"private-key-file,omitempty"`
+}
+
+func (lhs *BgpsecSigningConfig) Equal(rhs *BgpsecSigningConfig) bool {
+	if lhs == nil || rhs == nil {
+		return false
+	}
+	if lhs.PrivateKeyFile != rhs.PrivateKeyFile {
+		return false
+	}
+	return true
+}
+
+// struct for container gobgp:bgpsec-signing.
+// BGPsec (RFC 8205) signing configuration.
+type BgpsecSigning struct {
+	// original -> gobgp:bgpsec-signing-config
+	Config BgpsecSigningConfig `mapstructure:"config" json:"config,omitempty"`
+	// original -> gobgp:bgpsec-signing-state
+	State BgpsecSigningState `mapstructure:"state" json:"state,omitempty"`
+}
+
+func (lhs *BgpsecSigning) Equal(rhs *BgpsecSigning) bool {
+	if lhs == nil || rhs == nil {
+		return false
+	}
+	if !lhs.Config.Equal(&(rhs.Config)) {
+		return false
+	}
+	return true
+}
+
+// struct for container gobgp:state.
+type OutboundRouteFilteringState struct {
+	// original -> gobgp:mode
+	// Address Prefix ORF capability advertised for the AFI-SAFI.
+	Mode OrfModeType `mapstructure:"mode" json:"mode,omitempty"`
+	// original -> gobgp:prefix-set
+	// Name of the prefix-set pushed to the neighbor as Address
+	// Prefix ORF entries.
+	PrefixSet string `mapstructure:"prefix-set" json:"prefix-set,omitempty"`
+}
+
+// struct for container gobgp:config.
+type OutboundRouteFilteringConfig struct {
+	// original -> gobgp:mode
+	// Address Prefix ORF capability advertised for the AFI-SAFI.
+	Mode OrfModeType `mapstructure:"mode" json:"mode,omitempty"`
+	// original -> gobgp:prefix-set
+	// Name of the prefix-set pushed to the neighbor as Address
+	// Prefix ORF entries.
+	PrefixSet string `mapstructure:"prefix-set" json:"prefix-set,omitempty"`
+}
+
+func (lhs *OutboundRouteFilteringConfig) Equal(rhs *OutboundRouteFilteringConfig) bool {
+	if lhs == nil || rhs == nil {
+		return false
+	}
+	if lhs.Mode != rhs.Mode {
+		return false
+	}
+	if lhs.PrefixSet != rhs.PrefixSet {
+		return false
+	}
+	return true
+}
+
+// struct for container gobgp:outbound-route-filtering.
+// Outbound Route Filtering (RFC 5291) configuration options
+// related to a particular AFI-SAFI.
+type OutboundRouteFiltering struct {
+	// original -> gobgp:outbound-route-filtering-config
+	Config OutboundRouteFilteringConfig `mapstructure:"config" json:"config,omitempty"`
+	// original -> gobgp:outbound-route-filtering-state
+	State OutboundRouteFilteringState `mapstructure:"state" json:"state,omitempty"`
+}
+
+func (lhs *OutboundRouteFiltering) Equal(rhs *OutboundRouteFiltering) bool {
+	if lhs == nil || rhs == nil {
+		return false
+	}
+	if !lhs.Config.Equal(&(rhs.Config)) {
+		return false
+	}
+	return true
+}
+
 // struct for container gobgp:state.
 type RouteTargetMembershipState struct {
 	// original -> gobgp:deferral-time
@@ -3993,6 +5189,19 @@ type PrefixLimitState struct {
 	// is re-established after being torn down due to exceeding
 	// the max-prefix limit.
 	RestartTimer float64 `mapstructure:"restart-timer" json:"restart-timer,omitempty"`
+	// original -> gobgp:warning-only
+	// gobgp:warning-only's original type is boolean.
+	// Only log and notify when the limit is exceeded, without
+	// shutting down the session.
+	WarningOnly bool `mapstructure:"warning-only" json:"warning-only,omitempty"`
+	// original -> gobgp:count-type
+	// Prefixes counted for the limit: received before the import
+	// policy or accepted by it.
+	CountType PrefixLimitCountType `mapstructure:"count-type" json:"count-type,omitempty"`
+	// original -> gobgp:max-prefixes-out
+	// Maximum number of prefixes that will be advertised
+	// to the neighbour.
+	MaxPrefixesOut uint32 `mapstructure:"max-prefixes-out" json:"max-prefixes-out,omitempty"`
 }
 
 // struct for container bgp-mp:config.
@@ -4015,6 +5224,19 @@ type PrefixLimitConfig struct {
 	// is re-established after being torn down due to exceeding
 	// the max-prefix limit.
 	RestartTimer float64 `mapstructure:"restart-timer" json:"restart-timer,omitempty"`
+	// original -> gobgp:warning-only
+	// gobgp:warning-only's original type is boolean.
+	// Only log and notify when the limit is exceeded, without
+	// shutting down the session.
+	WarningOnly bool `mapstructure:"warning-only" json:"warning-only,omitempty"`
+	// original -> gobgp:count-type
+	// Prefixes counted for the limit: received before the import
+	// policy or accepted by it.
+	CountType PrefixLimitCountType `mapstructure:"count-type" json:"count-type,omitempty"`
+	// original -> gobgp:max-prefixes-out
+	// Maximum number of prefixes that will be advertised
+	// to the neighbour.
+	MaxPrefixesOut uint32 `mapstructure:"max-prefixes-out" json:"max-prefixes-out,omitempty"`
 }
 
 func (lhs *PrefixLimitConfig) Equal(rhs *PrefixLimitConfig) bool {
@@ -4030,6 +5252,15 @@ func (lhs *PrefixLimitConfig) Equal(rhs *PrefixLimitConfig) bool {
 	if lhs.RestartTimer != rhs.RestartTimer {
 		return false
 	}
+	if lhs.WarningOnly != rhs.WarningOnly {
+		return false
+	}
+	if lhs.CountType != rhs.CountType {
+		return false
+	}
+	if lhs.MaxPrefixesOut != rhs.MaxPrefixesOut {
+		return false
+	}
 	return true
 }
 
@@ -4377,6 +5608,14 @@ type AfiSafi struct {
 	// original -> gobgp:add-paths
 	// add-paths configuration options related to a particular AFI-SAFI.
 	AddPaths AddPaths `mapstructure:"add-paths" json:"add-paths,omitempty"`
+	// original -> gobgp:outbound-route-filtering
+	// Outbound Route Filtering (RFC 5291) configuration options
+	// related to a particular AFI-SAFI.
+	OutboundRouteFiltering OutboundRouteFiltering `mapstructure:"outbound-route-filtering" json:"outbound-route-filtering,omitempty"`
+	// original -> gobgp:bgpsec
+	// BGPsec (RFC 8205) configuration options related to a
+	// particular AFI-SAFI.
+	Bgpsec Bgpsec `mapstructure:"bgpsec" json:"bgpsec,omitempty"`
 }
 
 func (lhs *AfiSafi) Equal(rhs *AfiSafi) bool {
@@ -4440,6 +5679,12 @@ func (lhs *AfiSafi) Equal(rhs *AfiSafi) bool {
 	if !lhs.AddPaths.Equal(&(rhs.AddPaths)) {
 		return false
 	}
+	if !lhs.OutboundRouteFiltering.Equal(&(rhs.OutboundRouteFiltering)) {
+		return false
+	}
+	if !lhs.Bgpsec.Equal(&(rhs.Bgpsec)) {
+		return false
+	}
 	return true
 }
 
@@ -4962,6 +6207,34 @@ type RouteSelectionOptionsState struct {
 	// gobgp:disable-best-path-selection's original type is boolean.
 	// Disables best path selection process.
 	DisableBestPathSelection bool `mapstructure:"disable-best-path-selection" json:"disable-best-path-selection,omitempty"`
+	// original -> gobgp:deterministic-med
+	// gobgp:deterministic-med's original type is boolean.
+	// Group paths by neighboring AS and compare MED within each
+	// group first, so that the result doesn't depend on the order
+	// in which paths were received.
+	DeterministicMed bool `mapstructure:"deterministic-med" json:"deterministic-med,omitempty"`
+	// original -> gobgp:med-missing-as-worst
+	// gobgp:med-missing-as-worst's original type is boolean.
+	// Treat a path without MED as having the worst MED instead
+	// of zero.
+	MedMissingAsWorst bool `mapstructure:"med-missing-as-worst" json:"med-missing-as-worst,omitempty"`
+	// original -> gobgp:confed-compare
+	// gobgp:confed-compare's original type is boolean.
+	// Compare MED among paths learned within the confederation
+	// even if their neighboring ASs differ.
+	ConfedCompare bool `mapstructure:"confed-compare" json:"confed-compare,omitempty"`
+	// original -> gobgp:multipath-as-path-match
+	// gobgp:multipath-as-path-match's original type is boolean.
+	// Use only the paths with the same AS path as the best path
+	// for multipath.
+	MultipathAsPathMatch bool `mapstructure:"multipath-as-path-match" json:"multipath-as-path-match,omitempty"`
+	// original -> gobgp:multipath-relax
+	// gobgp:multipath-relax's original type is boolean.
+	// Use the paths from different neighbor ASes with AS paths
+	// of the same length for multipath even if their MEDs differ,
+	// comparing MED only as in the best path selection. Overrides
+	// multipath-as-path-match.
+	MultipathRelax bool `mapstructure:"multipath-relax" json:"multipath-relax,omitempty"`
 }
 
 // struct for container bgp-mp:config.
@@ -5007,6 +6280,34 @@ type RouteSelectionOptionsConfig struct {
 	// gobgp:disable-best-path-selection's original type is boolean.
 	// Disables best path selection process.
 	DisableBestPathSelection bool `mapstructure:"disable-best-path-selection" json:"disable-best-path-selection,omitempty"`
+	// original -> gobgp:deterministic-med
+	// gobgp:deterministic-med's original type is boolean.
+	// Group paths by neighboring AS and compare MED within each
+	// group first, so that the result doesn't depend on the order
+	// in which paths were received.
+	DeterministicMed bool `mapstructure:"deterministic-med" json:"deterministic-med,omitempty"`
+	// original -> gobgp:med-missing-as-worst
+	// gobgp:med-missing-as-worst's original type is boolean.
+	// Treat a path without MED as having the worst MED instead
+	// of zero.
+	MedMissingAsWorst bool `mapstructure:"med-missing-as-worst" json:"med-missing-as-worst,omitempty"`
+	// original -> gobgp:confed-compare
+	// gobgp:confed-compare's original type is boolean.
+	// Compare MED among paths learned within the confederation
+	// even if their neighboring ASs differ.
+	ConfedCompare bool `mapstructure:"confed-compare" json:"confed-compare,omitempty"`
+	// original -> gobgp:multipath-as-path-match
+	// gobgp:multipath-as-path-match's original type is boolean.
+	// Use only the paths with the same AS path as the best path
+	// for multipath.
+	MultipathAsPathMatch bool `mapstructure:"multipath-as-path-match" json:"multipath-as-path-match,omitempty"`
+	// original -> gobgp:multipath-relax
+	// gobgp:multipath-relax's original type is boolean.
+	// Use the paths from different neighbor ASes with AS paths
+	// of the same length for multipath even if their MEDs differ,
+	// comparing MED only as in the best path selection. Overrides
+	// multipath-as-path-match.
+	MultipathRelax bool `mapstructure:"multipath-relax" json:"multipath-relax,omitempty"`
 }
 
 func (lhs *RouteSelectionOptionsConfig) Equal(rhs *RouteSelectionOptionsConfig) bool {
@@ -5034,6 +6335,21 @@ func (lhs *RouteSelectionOptionsConfig) Equal(rhs *RouteSelectionOptionsConfig)
 	if lhs.DisableBestPathSelection != rhs.DisableBestPathSelection {
 		return false
 	}
+	if lhs.DeterministicMed != rhs.DeterministicMed {
+		return false
+	}
+	if lhs.MedMissingAsWorst != rhs.MedMissingAsWorst {
+		return false
+	}
+	if lhs.ConfedCompare != rhs.ConfedCompare {
+		return false
+	}
+	if lhs.MultipathAsPathMatch != rhs.MultipathAsPathMatch {
+		return false
+	}
+	if lhs.MultipathRelax != rhs.MultipathRelax {
+		return false
+	}
 	return true
 }
 
@@ -5166,6 +6482,9 @@ type Global struct {
 	// routing table, i.e., export (send) and import (receive),
 	// depending on the context.
 	ApplyPolicy ApplyPolicy `mapstructure:"apply-policy" json:"apply-policy,omitempty"`
+	// original -> gobgp:bgpsec-signing
+	// BGPsec (RFC 8205) signing configuration.
+	BgpsecSigning BgpsecSigning `mapstructure:"bgpsec-signing" json:"bgpsec-signing,omitempty"`
 }
 
 func (lhs *Global) Equal(rhs *Global) bool {
@@ -5201,6 +6520,9 @@ func (lhs *Global) Equal(rhs *Global) bool {
 	if !lhs.ApplyPolicy.Equal(&(rhs.ApplyPolicy)) {
 		return false
 	}
+	if !lhs.BgpsecSigning.Equal(&(rhs.BgpsecSigning)) {
+		return false
+	}
 	return true
 }
 
@@ -5218,6 +6540,10 @@ type Bgp struct {
 	PeerGroups []PeerGroup `mapstructure:"peer-groups" json:"peer-groups,omitempty"`
 	// original -> gobgp:rpki-servers
 	RpkiServers []RpkiServer `mapstructure:"rpki-servers" json:"rpki-servers,omitempty"`
+	// original -> gobgp:rpki-files
+	RpkiFiles []RpkiFile `mapstructure:"rpki-files" json:"rpki-files,omitempty"`
+	// original -> gobgp:defined-set-files
+	DefinedSetFiles []DefinedSetFile `mapstructure:"defined-set-files" json:"defined-set-files,omitempty"`
 	// original -> gobgp:bmp-servers
 	BmpServers []BmpServer `mapstructure:"bmp-servers" json:"bmp-servers,omitempty"`
 	// original -> gobgp:vrfs
@@ -5230,6 +6556,8 @@ type Bgp struct {
 	Netlink Netlink `mapstructure:"netlink" json:"netlink,omitempty"`
 	// original -> gobgp:collector
 	Collector Collector `mapstructure:"collector" json:"collector,omitempty"`
+	// original -> gobgp:bmp-station
+	BmpStation BmpStation `mapstructure:"bmp-station" json:"bmp-station,omitempty"`
 	// original -> gobgp:dynamic-neighbors
 	DynamicNeighbors []DynamicNeighbor `mapstructure:"dynamic-neighbors" json:"dynamic-neighbors,omitempty"`
 }
@@ -5265,6 +6593,22 @@ func (lhs *Bgp) Equal(rhs *Bgp) bool {
 			return false
 		}
 	}
+	if len(lhs.RpkiFiles) != len(rhs.RpkiFiles) {
+		return false
+	}
+	for i, r := range rhs.RpkiFiles {
+		if !r.Equal(&lhs.RpkiFiles[i]) {
+			return false
+		}
+	}
+	if len(lhs.DefinedSetFiles) != len(rhs.DefinedSetFiles) {
+		return false
+	}
+	for i, r := range rhs.DefinedSetFiles {
+		if !r.Equal(&lhs.DefinedSetFiles[i]) {
+			return false
+		}
+	}
 	if len(lhs.BmpServers) != len(rhs.BmpServers) {
 		return false
 	}
@@ -5298,6 +6642,9 @@ func (lhs *Bgp) Equal(rhs *Bgp) bool {
 	if !lhs.Collector.Equal(&(rhs.Collector)) {
 		return false
 	}
+	if !lhs.BmpStation.Equal(&(rhs.BmpStation)) {
+		return false
+	}
 	if len(lhs.DynamicNeighbors) != len(rhs.DynamicNeighbors) {
 		return false
 	}
@@ -5538,6 +6885,56 @@ type BgpActions struct {
 	SetMed BgpSetMedType `mapstructure:"set-med" json:"set-med,omitempty"`
 	// original -> gobgp:set-large-community
 	SetLargeCommunity SetLargeCommunity `mapstructure:"set-large-community" json:"set-large-community,omitempty"`
+	// original -> gobgp:set-as-path-replace
+	SetAsPathReplace SetAsPathReplace `mapstructure:"set-as-path-replace" json:"set-as-path-replace,omitempty"`
+	// original -> gobgp:set-as-path-remove-private-as
+	// Remove the private AS numbers from the AS-path, or replace
+	// them with the local AS number.
+	SetAsPathRemovePrivateAs RemovePrivateAsOption `mapstructure:"set-as-path-remove-private-as" json:"set-as-path-remove-private-as,omitempty"`
+	// original -> gobgp:set-as-path-exclude
+	// The AS numbers removed from the AS-path.
+	SetAsPathExclude []uint32 `mapstructure:"set-as-path-exclude" json:"set-as-path-exclude,omitempty"`
+	// original -> gobgp:set-weight
+	// The weight local to the router, preferred before the
+	// local preference in the best path selection.
+	SetWeight uint32 `mapstructure:"set-weight" json:"set-weight,omitempty"`
+	// original -> gobgp:set-aigp
+	// Set the AIGP metric, or add to or subtract from the one of
+	// the route with the leading '+' or '-'.
+	SetAigp BgpSetAigpType `mapstructure:"set-aigp" json:"set-aigp,omitempty"`
+	// original -> gobgp:set-link-bandwidth
+	// Set the link bandwidth extended community in Mbps.
+	SetLinkBandwidth uint32 `mapstructure:"set-link-bandwidth" json:"set-link-bandwidth,omitempty"`
+}
+
+// struct for container gobgp:set-as-path-replace.
+// action to replace the AS numbers in the AS-path.
+type SetAsPathReplace struct {
+	// original -> gobgp:as-list
+	// The AS numbers replaced, or "any" for all of them.
+	AsList []string `mapstructure:"as-list" json:"as-list,omitempty"`
+	// original -> gobgp:replace-as
+	// The AS number replacing them, the local AS number if
+	// not specified.
+	ReplaceAs uint32 `mapstructure:"replace-as" json:"replace-as,omitempty"`
+}
+
+func (lhs *SetAsPathReplace) Equal(rhs *SetAsPathReplace) bool {
+	if lhs == nil || rhs == nil {
+		return false
+	}
+	if len(lhs.AsList) != len(rhs.AsList) {
+		return false
+	}
+	for idx, l := range lhs.AsList {
+		if l != rhs.AsList[idx] {
+			return false
+		}
+	}
+	if lhs.ReplaceAs != rhs.ReplaceAs {
+		return false
+	}
+	return true
 }
 
 func (lhs *BgpActions) Equal(rhs *BgpActions) bool {
@@ -5568,6 +6965,29 @@ func (lhs *BgpActions) Equal(rhs *BgpActions) bool {
 	if !lhs.SetLargeCommunity.Equal(&(rhs.SetLargeCommunity)) {
 		return false
 	}
+	if !lhs.SetAsPathReplace.Equal(&(rhs.SetAsPathReplace)) {
+		return false
+	}
+	if lhs.SetAsPathRemovePrivateAs != rhs.SetAsPathRemovePrivateAs {
+		return false
+	}
+	if len(lhs.SetAsPathExclude) != len(rhs.SetAsPathExclude) {
+		return false
+	}
+	for idx, l := range lhs.SetAsPathExclude {
+		if l != rhs.SetAsPathExclude[idx] {
+			return false
+		}
+	}
+	if lhs.SetWeight != rhs.SetWeight {
+		return false
+	}
+	if lhs.SetAigp != rhs.SetAigp {
+		return false
+	}
+	if lhs.SetLinkBandwidth != rhs.SetLinkBandwidth {
+		return false
+	}
 	return true
 }
 
@@ -5606,6 +7026,11 @@ type Actions struct {
 	// Definitions for policy action statements that
 	// change BGP-specific attributes of the route.
 	BgpActions BgpActions `mapstructure:"bgp-actions" json:"bgp-actions,omitempty"`
+	// original -> gobgp:flow-control
+	FlowControl FlowControlType `mapstructure:"flow-control" json:"flow-control,omitempty"`
+	// original -> gobgp:goto-statement
+	// The statement the evaluation goes on with.
+	GotoStatement string `mapstructure:"goto-statement" json:"goto-statement,omitempty"`
 }
 
 func (lhs *Actions) Equal(rhs *Actions) bool {
@@ -5621,6 +7046,12 @@ func (lhs *Actions) Equal(rhs *Actions) bool {
 	if !lhs.BgpActions.Equal(&(rhs.BgpActions)) {
 		return false
 	}
+	if lhs.FlowControl != rhs.FlowControl {
+		return false
+	}
+	if lhs.GotoStatement != rhs.GotoStatement {
+		return false
+	}
 	return true
 }
 
@@ -5827,8 +7258,61 @@ type BgpConditions struct {
 	// original -> gobgp:rpki-validation-result
 	// specify the validation result of RPKI based on ROA as conditions.
 	RpkiValidationResult RpkiValidationResultType `mapstructure:"rpki-validation-result" json:"rpki-validation-result,omitempty"`
+	// original -> gobgp:bgpsec-validation-result
+	// specify the validation result of BGPsec as conditions.
+	BgpsecValidationResult BgpsecValidationResultType `mapstructure:"bgpsec-validation-result" json:"bgpsec-validation-result,omitempty"`
 	// original -> gobgp:match-large-community-set
 	MatchLargeCommunitySet MatchLargeCommunitySet `mapstructure:"match-large-community-set" json:"match-large-community-set,omitempty"`
+	// original -> gobgp:origin-as-in-list
+	// List of AS numbers which the rightmost AS number in the
+	// AS-path may be.
+	OriginAsInList []uint32 `mapstructure:"origin-as-in-list" json:"origin-as-in-list,omitempty"`
+	// original -> gobgp:neighbor-as-in-list
+	// List of AS numbers which the leftmost AS number in the
+	// AS-path may be.
+	NeighborAsInList []uint32 `mapstructure:"neighbor-as-in-list" json:"neighbor-as-in-list,omitempty"`
+	// original -> gobgp:prefix-length-range
+	// Range of the prefix length like "24..32".
+	PrefixLengthRange string `mapstructure:"prefix-length-range" json:"prefix-length-range,omitempty"`
+	// original -> gobgp:source-peer-type-list
+	// List of the types of the peer which the route is received
+	// from.
+	SourcePeerTypeList []SourcePeerType `mapstructure:"source-peer-type-list" json:"source-peer-type-list,omitempty"`
+	// original -> gobgp:path-age
+	// Value in seconds and comparison operations for conditions
+	// based on the time since the route is received.
+	PathAge PathAge `mapstructure:"path-age" json:"path-age,omitempty"`
+	// original -> gobgp:aigp-range
+	// Range of the AIGP metric like "0..100".
+	AigpRange string `mapstructure:"aigp-range" json:"aigp-range,omitempty"`
+	// original -> gobgp:attribute-type-present
+	// Type code of the path attribute which the route has.
+	AttributeTypePresent uint8 `mapstructure:"attribute-type-present" json:"attribute-type-present,omitempty"`
+}
+
+// struct for container gobgp:path-age.
+// Value in seconds and comparison operations for conditions
+// based on the time since the route is received.
+type PathAge struct {
+	// original -> ptypes:operator
+	// type of comparison to be performed.
+	Operator AttributeComparison `mapstructure:"operator" json:"operator,omitempty"`
+	// original -> ptypes:value
+	// value in seconds to compare with the path age.
+	Value uint32 `mapstructure:"value" json:"value,omitempty"`
+}
+
+func (lhs *PathAge) Equal(rhs *PathAge) bool {
+	if lhs == nil || rhs == nil {
+		return false
+	}
+	if lhs.Operator != rhs.Operator {
+		return false
+	}
+	if lhs.Value != rhs.Value {
+		return false
+	}
+	return true
 }
 
 func (lhs *BgpConditions) Equal(rhs *BgpConditions) bool {
@@ -5881,9 +7365,48 @@ func (lhs *BgpConditions) Equal(rhs *BgpConditions) bool {
 	if lhs.RpkiValidationResult != rhs.RpkiValidationResult {
 		return false
 	}
+	if lhs.BgpsecValidationResult != rhs.BgpsecValidationResult {
+		return false
+	}
 	if !lhs.MatchLargeCommunitySet.Equal(&(rhs.MatchLargeCommunitySet)) {
 		return false
 	}
+	if len(lhs.OriginAsInList) != len(rhs.OriginAsInList) {
+		return false
+	}
+	for idx, l := range lhs.OriginAsInList {
+		if l != rhs.OriginAsInList[idx] {
+			return false
+		}
+	}
+	if len(lhs.NeighborAsInList) != len(rhs.NeighborAsInList) {
+		return false
+	}
+	for idx, l := range lhs.NeighborAsInList {
+		if l != rhs.NeighborAsInList[idx] {
+			return false
+		}
+	}
+	if lhs.PrefixLengthRange != rhs.PrefixLengthRange {
+		return false
+	}
+	if len(lhs.SourcePeerTypeList) != len(rhs.SourcePeerTypeList) {
+		return false
+	}
+	for idx, l := range lhs.SourcePeerTypeList {
+		if l != rhs.SourcePeerTypeList[idx] {
+			return false
+		}
+	}
+	if !lhs.PathAge.Equal(&(rhs.PathAge)) {
+		return false
+	}
+	if lhs.AigpRange != rhs.AigpRange {
+		return false
+	}
+	if lhs.AttributeTypePresent != rhs.AttributeTypePresent {
+		return false
+	}
 	return true
 }
 
@@ -229,6 +229,21 @@ func setDefaultNeighborConfigValuesWithViper(v *viper.Viper, n *Neighbor, g *Glo
 				}
 			}
 			n.AfiSafis[i].AddPaths.State.SendMax = n.AfiSafis[i].AddPaths.Config.SendMax
+			if mode := n.AfiSafis[i].OutboundRouteFiltering.Config.Mode; mode != "" {
+				if err := mode.Validate(); err != nil {
+					return err
+				}
+			}
+			n.AfiSafis[i].OutboundRouteFiltering.State.Mode = n.AfiSafis[i].OutboundRouteFiltering.Config.Mode
+			n.AfiSafis[i].OutboundRouteFiltering.State.PrefixSet = n.AfiSafis[i].OutboundRouteFiltering.Config.PrefixSet
+			if n.AfiSafis[i].PrefixLimit.Config.CountType == "" {
+				n.AfiSafis[i].PrefixLimit.Config.CountType = PREFIX_LIMIT_COUNT_TYPE_RECEIVED
+			} else if err := n.AfiSafis[i].PrefixLimit.Config.CountType.Validate(); err != nil {
+				return err
+			}
+			n.AfiSafis[i].PrefixLimit.State = PrefixLimitState(n.AfiSafis[i].PrefixLimit.Config)
+			n.AfiSafis[i].Bgpsec.State.Send = n.AfiSafis[i].Bgpsec.Config.Send
+			n.AfiSafis[i].Bgpsec.State.Receive = n.AfiSafis[i].Bgpsec.Config.Receive
 		}
 	}
 
@@ -374,6 +389,9 @@ func setDefaultConfigValuesWithViper(v *viper.Viper, b *BgpConfigSet) error {
 		if server.Config.RouteMonitoringPolicy == "" {
 			server.Config.RouteMonitoringPolicy = BMP_ROUTE_MONITORING_POLICY_TYPE_PRE_POLICY
 		}
+		if server.Config.QueueOverflowAction == "" {
+			server.Config.QueueOverflowAction = BMP_QUEUE_OVERFLOW_ACTION_TYPE_DROP
+		}
 		// statistics-timeout is uint16 value and implicitly less than 65536
 		if server.Config.StatisticsTimeout != 0 && server.Config.StatisticsTimeout < 15 {
 			return fmt.Errorf("too small statistics-timeout value: %d", server.Config.StatisticsTimeout)
@@ -469,8 +487,67 @@ func setDefaultConfigValuesWithViper(v *viper.Viper, b *BgpConfigSet) error {
 	}
 
 	for idx, r := range b.RpkiServers {
+		c := &b.RpkiServers[idx].Config
+		if r.Config.Transport == "" {
+			c.Transport = RPKI_TRANSPORT_TYPE_TCP
+		}
+		if r.Config.MaxProtocolVersion == "" {
+			c.MaxProtocolVersion = RTR_PROTOCOL_VERSION_TYPE_V2
+		}
 		if r.Config.Port == 0 {
-			b.RpkiServers[idx].Config.Port = rtr.RPKI_DEFAULT_PORT
+			switch c.Transport {
+			case RPKI_TRANSPORT_TYPE_TLS:
+				c.Port = rtr.RPKI_TLS_DEFAULT_PORT
+			case RPKI_TRANSPORT_TYPE_SSH:
+				c.Port = rtr.RPKI_SSH_DEFAULT_PORT
+			default:
+				c.Port = rtr.RPKI_DEFAULT_PORT
+			}
+		}
+	}
+
+	for idx, f := range b.RpkiFiles {
+		if f.Config.Path == "" {
+			return fmt.Errorf("rpki file path is empty")
+		}
+		if f.Config.Format == "" {
+			b.RpkiFiles[idx].Config.Format = RPKI_FILE_FORMAT_TYPE_JSON_VRP
+		} else if err := f.Config.Format.Validate(); err != nil {
+			return err
+		}
+	}
+
+	for idx := range b.DefinedSetFiles {
+		c := &b.DefinedSetFiles[idx].Config
+		if c.Path == "" {
+			return fmt.Errorf("defined set file path is empty")
+		}
+		if c.Name == "" {
+			return fmt.Errorf("defined set name of %s is empty", c.Path)
+		}
+		if c.Type == "" {
+			c.Type = DEFINED_SET_FILE_TYPE_PREFIX
+		} else if err := c.Type.Validate(); err != nil {
+			return err
+		}
+		if c.Format == "" {
+			c.Format = DEFINED_SET_FILE_FORMAT_TYPE_TEXT
+		} else if err := c.Format.Validate(); err != nil {
+			return err
+		}
+	}
+
+	for idx, m := range b.MrtDump {
+		c := &b.MrtDump[idx].Config
+		if m.Config.Compression == "" {
+			c.Compression = MRT_COMPRESSION_TYPE_NONE
+		} else if err := m.Config.Compression.Validate(); err != nil {
+			return err
+		}
+		if m.Config.SinkType == "" {
+			c.SinkType = MRT_SINK_TYPE_FILE
+		} else if err := m.Config.SinkType.Validate(); err != nil {
+			return err
 		}
 	}
 
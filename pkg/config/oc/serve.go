@@ -29,12 +29,15 @@ type BgpConfigSet struct {
 	Neighbors         []Neighbor         `mapstructure:"neighbors"`
 	PeerGroups        []PeerGroup        `mapstructure:"peer-groups"`
 	RpkiServers       []RpkiServer       `mapstructure:"rpki-servers"`
+	RpkiFiles         []RpkiFile         `mapstructure:"rpki-files"`
+	DefinedSetFiles   []DefinedSetFile   `mapstructure:"defined-set-files"`
 	BmpServers        []BmpServer        `mapstructure:"bmp-servers"`
 	Vrfs              []Vrf              `mapstructure:"vrfs"`
 	MrtDump           []Mrt              `mapstructure:"mrt-dump"`
 	Zebra             Zebra              `mapstructure:"zebra"`
 	Netlink           Netlink            `mapstructure:"netlink"`
 	Collector         Collector          `mapstructure:"collector"`
+	BmpStation        BmpStation         `mapstructure:"bmp-station"`
 	DefinedSets       DefinedSets        `mapstructure:"defined-sets"`
 	PolicyDefinitions []PolicyDefinition `mapstructure:"policy-definitions"`
 	DynamicNeighbors  []DynamicNeighbor  `mapstructure:"dynamic-neighbors"`
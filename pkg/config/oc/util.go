@@ -209,7 +209,7 @@ func isAfiSafiChanged(x, y []AfiSafi) bool {
 		m[string(e.Config.AfiSafiName)] = x[i]
 	}
 	for _, e := range y {
-		if v, ok := m[string(e.Config.AfiSafiName)]; !ok || !v.Config.Equal(&e.Config) || !v.AddPaths.Config.Equal(&e.AddPaths.Config) || !v.MpGracefulRestart.Config.Equal(&e.MpGracefulRestart.Config) {
+		if v, ok := m[string(e.Config.AfiSafiName)]; !ok || !v.Config.Equal(&e.Config) || !v.AddPaths.Config.Equal(&e.AddPaths.Config) || !v.MpGracefulRestart.Config.Equal(&e.MpGracefulRestart.Config) || v.OutboundRouteFiltering.Config.Mode != e.OutboundRouteFiltering.Config.Mode || !v.Bgpsec.Config.Equal(&e.Bgpsec.Config) {
 			return true
 		}
 	}
@@ -327,13 +327,21 @@ func newApplyPolicyFromConfigStruct(c *ApplyPolicy) *api.ApplyPolicy {
 }
 
 func newPrefixLimitFromConfigStruct(c *AfiSafi) *api.PrefixLimit {
-	if c.PrefixLimit.Config.MaxPrefixes == 0 {
+	if c.PrefixLimit.Config.MaxPrefixes == 0 && c.PrefixLimit.Config.MaxPrefixesOut == 0 {
 		return nil
 	}
+	countType := api.PrefixLimit_COUNT_TYPE_RECEIVED
+	if c.PrefixLimit.Config.CountType == PREFIX_LIMIT_COUNT_TYPE_ACCEPTED {
+		countType = api.PrefixLimit_COUNT_TYPE_ACCEPTED
+	}
 	return &api.PrefixLimit{
 		Family:               &api.Family{Afi: api.Family_Afi(c.State.Family.Afi()), Safi: api.Family_Safi(c.State.Family.Safi())},
 		MaxPrefixes:          c.PrefixLimit.Config.MaxPrefixes,
 		ShutdownThresholdPct: uint32(c.PrefixLimit.Config.ShutdownThresholdPct),
+		WarningOnly:          c.PrefixLimit.Config.WarningOnly,
+		RestartTimer:         c.PrefixLimit.Config.RestartTimer,
+		CountType:            countType,
+		MaxPrefixesOut:       c.PrefixLimit.Config.MaxPrefixesOut,
 	}
 }
 
@@ -371,6 +379,32 @@ func newAddPathsFromConfigStruct(c *AddPaths) *api.AddPaths {
 	}
 }
 
+func newOutboundRouteFilteringFromConfigStruct(c *OutboundRouteFiltering) *api.OutboundRouteFiltering {
+	mode := api.OutboundRouteFilteringConfig_MODE_UNSPECIFIED
+	if i := c.Config.Mode.ToInt(); i >= 0 {
+		mode = api.OutboundRouteFilteringConfig_Mode(i + 1)
+	}
+	return &api.OutboundRouteFiltering{
+		Config: &api.OutboundRouteFilteringConfig{
+			Mode:      mode,
+			PrefixSet: c.Config.PrefixSet,
+		},
+	}
+}
+
+func newBgpsecFromConfigStruct(c *Bgpsec) *api.Bgpsec {
+	return &api.Bgpsec{
+		Config: &api.BgpsecConfig{
+			Send:    c.Config.Send,
+			Receive: c.Config.Receive,
+		},
+		State: &api.BgpsecState{
+			Send:    c.State.Send,
+			Receive: c.State.Receive,
+		},
+	}
+}
+
 func newRouteSelectionOptionsFromConfigStruct(c *RouteSelectionOptions) *api.RouteSelectionOptions {
 	return &api.RouteSelectionOptions{
 		Config: &api.RouteSelectionOptionsConfig{
@@ -380,10 +414,32 @@ func newRouteSelectionOptionsFromConfigStruct(c *RouteSelectionOptions) *api.Rou
 			AdvertiseInactiveRoutes: c.Config.AdvertiseInactiveRoutes,
 			EnableAigp:              c.Config.EnableAigp,
 			IgnoreNextHopIgpMetric:  c.Config.IgnoreNextHopIgpMetric,
+			DeterministicMed:        c.Config.DeterministicMed,
+			MedMissingAsWorst:       c.Config.MedMissingAsWorst,
+			ConfedCompare:           c.Config.ConfedCompare,
+			MultipathRelax:          c.Config.MultipathRelax,
+			MultipathAsPathMatch:    c.Config.MultipathAsPathMatch,
 		},
 	}
 }
 
+func NewRouteSelectionOptionsConfigFromConfigStruct(c *RouteSelectionOptionsConfig) *api.RouteSelectionOptionsConfig {
+	return &api.RouteSelectionOptionsConfig{
+		AlwaysCompareMed:         c.AlwaysCompareMed,
+		IgnoreAsPathLength:       c.IgnoreAsPathLength,
+		ExternalCompareRouterId:  c.ExternalCompareRouterId,
+		AdvertiseInactiveRoutes:  c.AdvertiseInactiveRoutes,
+		EnableAigp:               c.EnableAigp,
+		IgnoreNextHopIgpMetric:   c.IgnoreNextHopIgpMetric,
+		DisableBestPathSelection: c.DisableBestPathSelection,
+		DeterministicMed:         c.DeterministicMed,
+		MedMissingAsWorst:        c.MedMissingAsWorst,
+		ConfedCompare:            c.ConfedCompare,
+		MultipathRelax:           c.MultipathRelax,
+		MultipathAsPathMatch:     c.MultipathAsPathMatch,
+	}
+}
+
 func newMpGracefulRestartFromConfigStruct(c *MpGracefulRestart) *api.MpGracefulRestart {
 	return &api.MpGracefulRestart{
 		Config: &api.MpGracefulRestartConfig{
@@ -430,6 +486,8 @@ func newAfiSafiFromConfigStruct(c *AfiSafi) *api.AfiSafi {
 		RouteTargetMembership:    newRouteTargetMembershipFromConfigStruct(&c.RouteTargetMembership),
 		LongLivedGracefulRestart: newLongLivedGracefulRestartFromConfigStruct(&c.LongLivedGracefulRestart),
 		AddPaths:                 newAddPathsFromConfigStruct(&c.AddPaths),
+		OutboundRouteFiltering:   newOutboundRouteFilteringFromConfigStruct(&c.OutboundRouteFiltering),
+		Bgpsec:                   newBgpsecFromConfigStruct(&c.Bgpsec),
 	}
 }
 
@@ -572,10 +630,12 @@ func NewPeerFromConfigStruct(pconf *Neighbor) *api.Peer {
 				HoldTime:               uint64(timer.Config.HoldTime),
 				KeepaliveInterval:      uint64(timer.Config.KeepaliveInterval),
 				IdleHoldTimeAfterReset: uint64(timer.Config.IdleHoldTimeAfterReset),
+				SendHoldTime:           uint64(timer.Config.SendHoldTime),
 			},
 			State: &api.TimersState{
 				KeepaliveInterval:  uint64(timer.State.KeepaliveInterval),
 				NegotiatedHoldTime: uint64(timer.State.NegotiatedHoldTime),
+				SendHoldTime:       uint64(timer.State.SendHoldTime),
 				Uptime:             ProtoTimestamp(timer.State.Uptime),
 				Downtime:           ProtoTimestamp(timer.State.Downtime),
 			},
@@ -583,6 +643,8 @@ func NewPeerFromConfigStruct(pconf *Neighbor) *api.Peer {
 		RouteReflector: &api.RouteReflector{
 			RouteReflectorClient:    pconf.RouteReflector.Config.RouteReflectorClient,
 			RouteReflectorClusterId: pconf.RouteReflector.State.RouteReflectorClusterId.String(),
+			AdvertiseBestExternal:   pconf.RouteReflector.Config.AdvertiseBestExternal,
+			DiversePath:             pconf.RouteReflector.Config.DiversePath,
 		},
 		RouteServer: &api.RouteServer{
 			RouteServerClient: pconf.RouteServer.Config.RouteServerClient,
@@ -655,10 +717,12 @@ func NewPeerGroupFromConfigStruct(pconf *PeerGroup) *api.PeerGroup {
 				HoldTime:               uint64(timer.Config.HoldTime),
 				KeepaliveInterval:      uint64(timer.Config.KeepaliveInterval),
 				IdleHoldTimeAfterReset: uint64(timer.Config.IdleHoldTimeAfterReset),
+				SendHoldTime:           uint64(timer.Config.SendHoldTime),
 			},
 			State: &api.TimersState{
 				KeepaliveInterval:  uint64(timer.State.KeepaliveInterval),
 				NegotiatedHoldTime: uint64(timer.State.NegotiatedHoldTime),
+				SendHoldTime:       uint64(timer.State.SendHoldTime),
 				Uptime:             ProtoTimestamp(timer.State.Uptime),
 				Downtime:           ProtoTimestamp(timer.State.Downtime),
 			},
@@ -666,6 +730,8 @@ func NewPeerGroupFromConfigStruct(pconf *PeerGroup) *api.PeerGroup {
 		RouteReflector: &api.RouteReflector{
 			RouteReflectorClient:    pconf.RouteReflector.Config.RouteReflectorClient,
 			RouteReflectorClusterId: pconf.RouteReflector.Config.RouteReflectorClusterId.String(),
+			AdvertiseBestExternal:   pconf.RouteReflector.Config.AdvertiseBestExternal,
+			DiversePath:             pconf.RouteReflector.Config.DiversePath,
 		},
 		RouteServer: &api.RouteServer{
 			RouteServerClient: pconf.RouteServer.Config.RouteServerClient,
@@ -702,21 +768,13 @@ func NewGlobalFromConfigStruct(c *Global) *api.Global {
 	}
 
 	return &api.Global{
-		Asn:              c.Config.As,
-		RouterId:         c.Config.RouterId.String(),
-		ListenPort:       c.Config.Port,
-		ListenAddresses:  l,
-		Families:         families,
-		UseMultiplePaths: c.UseMultiplePaths.Config.Enabled,
-		RouteSelectionOptions: &api.RouteSelectionOptionsConfig{
-			AlwaysCompareMed:         c.RouteSelectionOptions.Config.AlwaysCompareMed,
-			IgnoreAsPathLength:       c.RouteSelectionOptions.Config.IgnoreAsPathLength,
-			ExternalCompareRouterId:  c.RouteSelectionOptions.Config.ExternalCompareRouterId,
-			AdvertiseInactiveRoutes:  c.RouteSelectionOptions.Config.AdvertiseInactiveRoutes,
-			EnableAigp:               c.RouteSelectionOptions.Config.EnableAigp,
-			IgnoreNextHopIgpMetric:   c.RouteSelectionOptions.Config.IgnoreNextHopIgpMetric,
-			DisableBestPathSelection: c.RouteSelectionOptions.Config.DisableBestPathSelection,
-		},
+		Asn:                   c.Config.As,
+		RouterId:              c.Config.RouterId.String(),
+		ListenPort:            c.Config.Port,
+		ListenAddresses:       l,
+		Families:              families,
+		UseMultiplePaths:      c.UseMultiplePaths.Config.Enabled,
+		RouteSelectionOptions: NewRouteSelectionOptionsConfigFromConfigStruct(&c.RouteSelectionOptions.Config),
 		DefaultRouteDistance: &api.DefaultRouteDistance{
 			ExternalRouteDistance: uint32(c.DefaultRouteDistance.Config.ExternalRouteDistance),
 			InternalRouteDistance: uint32(c.DefaultRouteDistance.Config.InternalRouteDistance),
@@ -735,6 +793,10 @@ func NewGlobalFromConfigStruct(c *Global) *api.Global {
 			NotificationEnabled: c.GracefulRestart.Config.NotificationEnabled,
 			LonglivedEnabled:    c.GracefulRestart.Config.LongLivedEnabled,
 		},
+		BgpsecSigning: &api.BgpsecSigning{
+			PrivateKeyFile: c.BgpsecSigning.Config.PrivateKeyFile,
+			Ski:            c.BgpsecSigning.State.Ski,
+		},
 	}
 }
 
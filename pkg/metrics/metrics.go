@@ -98,6 +98,7 @@ var (
 	peerRouterIdLabels = []string{"peer", "router_id"}
 	peerStateLabels    = []string{"peer", "session_state", "admin_state"}
 	rfLabels           = []string{"peer", "route_family"}
+	outQueueLabels     = []string{"peer", "stat"}
 
 	bgpReceivedUpdateTotalDesc = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "received", "update_total"),
@@ -193,8 +194,8 @@ var (
 
 	bgpPeerOutQueueDesc = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "peer", "out_queue_count"),
-		"Length of the outgoing message queue",
-		peerLabels, nil,
+		"Length of the outgoing message queue (messages) and for how long the pending write to the peer has been blocked (blocked_seconds)",
+		outQueueLabels, nil,
 	)
 	bgpPeerFlopsDesc = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "peer", "flop_count"),
@@ -298,6 +299,8 @@ func (c *bgpCollector) Describe(out chan<- *prometheus.Desc) {
 	out <- bgpRoutesReceivedDesc
 	out <- bgpRoutesAcceptedDesc
 	out <- bgpRoutesAdvertisedDesc
+
+	describeSubsystems(out)
 }
 
 func (c *bgpCollector) Collect(out chan<- prometheus.Metric) {
@@ -340,8 +343,10 @@ func (c *bgpCollector) Collect(out chan<- prometheus.Metric) {
 		send(bgpSentDiscardedTotalDesc, msg.Sent.Discarded)
 		send(bgpSentMessageTotalDesc, msg.Sent.Total)
 
-		// The outbound queue message size
-		send(bgpPeerOutQueueDesc, uint64(peerState.GetOutQ()))
+		// The outbound queue message size and for how long the peer
+		// hasn't read what we are sending
+		out <- prometheus.MustNewConstMetric(bgpPeerOutQueueDesc, prometheus.CounterValue, float64(peerState.GetOutQ()), peerAddr, "messages")
+		out <- prometheus.MustNewConstMetric(bgpPeerOutQueueDesc, prometheus.CounterValue, peerState.GetQueues().GetOutputBlockedSeconds(), peerAddr, "blocked_seconds")
 		// The number of neighbor flops
 		send(bgpPeerFlopsDesc, uint64(peerState.GetFlops()))
 		// Uptime in seconds of the session
@@ -421,4 +426,11 @@ func (c *bgpCollector) Collect(out chan<- prometheus.Metric) {
 	if err != nil {
 		out <- prometheus.NewInvalidMetric(prometheus.NewDesc("error", "error during metric collection", nil, nil), err)
 	}
+
+	c.collectRib(out, bgpServer.Global)
+	c.collectNetlink(out)
+	c.collectRpki(out)
+	c.collectBmp(out)
+	c.collectMrt(out)
+	c.collectPolicy(out)
 }
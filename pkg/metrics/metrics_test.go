@@ -2,6 +2,9 @@ package metrics
 
 import (
 	"context"
+	"net/netip"
+	"path/filepath"
+	"reflect"
 	"testing"
 	"time"
 
@@ -167,6 +170,24 @@ func TestMetrics(test *testing.T) {
 
 	cancel()
 	<-goroutineCh
+
+	// the blocked time is in the out queue family
+	metrics, err := registry.Gather()
+	assert.NoError(err)
+	var stats []string
+	for _, m := range metrics {
+		if m.GetName() != "bgp_peer_out_queue_count" {
+			continue
+		}
+		for _, metric := range m.GetMetric() {
+			for _, l := range metric.GetLabel() {
+				if l.GetName() == "stat" {
+					stats = append(stats, l.GetValue())
+				}
+			}
+		}
+	}
+	assert.ElementsMatch([]string{"messages", "blocked_seconds"}, stats)
 }
 
 func TestFSMLoopMetrics(t *testing.T) {
@@ -208,6 +229,25 @@ func TestFSMLoopMetrics(t *testing.T) {
 	assert.Equal(uint64(1), *hist.Metric[0].Histogram.SampleCount)
 }
 
+func TestNetlinkTimingMetrics(t *testing.T) {
+	c := NewNetlinkTimingsCollector()
+	registry := prometheus.NewRegistry()
+	require.NoError(t, registry.Register(c))
+
+	c.Observe(server.NetlinkRouteReplace, time.Millisecond)
+	c.Observe(server.NetlinkRouteReplace, 2*time.Millisecond)
+	c.Observe(server.NetlinkRouteDelete, time.Millisecond)
+
+	metrics, err := registry.Gather()
+	require.NoError(t, err)
+	hist := getMetric(metrics, "netlink_route_replace_timing_sec")
+	require.NotNil(t, hist)
+	assert.Equal(t, uint64(2), hist.Metric[0].Histogram.GetSampleCount())
+	hist = getMetric(metrics, "netlink_route_delete_timing_sec")
+	require.NotNil(t, hist)
+	assert.Equal(t, uint64(1), hist.Metric[0].Histogram.GetSampleCount())
+}
+
 func getMetric(metrics []*dto.MetricFamily, metricName string) *dto.MetricFamily {
 	for _, m := range metrics {
 		if m.GetName() == metricName {
@@ -216,3 +256,70 @@ func getMetric(metrics []*dto.MetricFamily, metricName string) *dto.MetricFamily
 	}
 	return nil
 }
+
+func TestSubsystemMetrics(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+
+	s := server.NewBgpServer()
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(NewBgpCollector(s))
+	go s.Serve()
+	ctx := context.Background()
+	require.NoError(s.StartBgp(ctx, &api.StartBgpRequest{
+		Global: &api.Global{
+			Asn:        1,
+			RouterId:   "1.1.1.1",
+			ListenPort: -1,
+		},
+	}))
+	defer s.StopBgp(ctx, &api.StopBgpRequest{})
+
+	nlri, _ := bgp.NewIPAddrPrefix(netip.MustParsePrefix("10.1.0.0/24"))
+	nh, _ := bgp.NewPathAttributeNextHop(netip.MustParseAddr("10.0.0.1"))
+	_, err := s.AddPath(apiutil.AddPathRequest{Paths: []*apiutil.Path{{
+		Family: bgp.RF_IPv4_UC,
+		Nlri:   nlri,
+		Attrs:  []bgp.PathAttributeInterface{bgp.NewPathAttributeOrigin(0), nh},
+	}}})
+	require.NoError(err)
+	mrtFile := filepath.Join(t.TempDir(), "updates")
+	require.NoError(s.EnableMrt(ctx, &api.EnableMrtRequest{
+		DumpType: api.EnableMrtRequest_DUMP_TYPE_UPDATES,
+		Filename: mrtFile,
+	}))
+	// nobody listens on the ports
+	require.NoError(s.AddRpki(ctx, &api.AddRpkiRequest{Address: "127.0.0.1", Port: 1}))
+	require.NoError(s.AddBmp(ctx, &api.AddBmpRequest{Address: "127.0.0.1", Port: 1}))
+
+	metrics, err := registry.Gather()
+	require.NoError(err)
+	value := func(name string, labels map[string]string) float64 {
+		m := getMetric(metrics, name)
+		require.NotNil(m, name)
+		for _, metric := range m.Metric {
+			l := make(map[string]string)
+			for _, p := range metric.Label {
+				l[p.GetName()] = p.GetValue()
+			}
+			if !reflect.DeepEqual(l, labels) {
+				continue
+			}
+			if metric.Gauge != nil {
+				return metric.Gauge.GetValue()
+			}
+			return metric.Counter.GetValue()
+		}
+		require.Failf("no metric", "%s %v", name, labels)
+		return 0
+	}
+	assert.Equal(1.0, value("bgp_rib_prefixes", map[string]string{"route_family": "ipv4-unicast"}))
+	assert.Equal(1.0, value("bgp_rib_paths", map[string]string{"route_family": "ipv4-unicast"}))
+	assert.Equal(0.0, value("bgp_rib_prefixes", map[string]string{"route_family": "ipv6-unicast"}))
+	assert.Equal(0.0, value("bgp_rpki_up", map[string]string{"server": "127.0.0.1:1"}))
+	assert.Equal(0.0, value("bgp_rpki_roas", map[string]string{"server": "127.0.0.1:1", "route_family": "ipv4-unicast"}))
+	assert.Equal(0.0, value("bgp_bmp_up", map[string]string{"station": "127.0.0.1:1"}))
+	assert.Equal(0.0, value("bgp_bmp_queue_depth", map[string]string{"station": "127.0.0.1:1"}))
+	assert.Equal(0.0, value("bgp_mrt_written_bytes_total", map[string]string{"filename": mrtFile, "dump_type": "updates"}))
+	assert.Equal(1.0, value("bgp_policy_assignment_evaluations_total", map[string]string{"assignment": "global", "direction": "import"}))
+	assert.Equal(1.0, value("bgp_policy_assignment_accepts_total", map[string]string{"assignment": "global", "direction": "import"}))
+}
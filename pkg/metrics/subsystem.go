@@ -0,0 +1,409 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/osrg/gobgp/v4/api"
+	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
+	"github.com/osrg/gobgp/v4/pkg/server"
+)
+
+type netlinkTimingsCollector struct {
+	histograms []prometheus.Histogram
+}
+
+type NetlinkTimingsCollector interface {
+	server.NetlinkTimingHook
+	prometheus.Collector
+}
+
+var (
+	netlinkOperationInfixes = [server.NetlinkOperationTypeCount]string{
+		"route_replace",
+		"route_delete",
+	}
+
+	netlinkOperationDescriptions = [server.NetlinkOperationTypeCount]string{
+		"kernel route installation",
+		"kernel route removal",
+	}
+)
+
+func NewNetlinkTimingsCollector() NetlinkTimingsCollector {
+	const namespace = "netlink"
+	c := &netlinkTimingsCollector{
+		histograms: make([]prometheus.Histogram, server.NetlinkOperationTypeCount),
+	}
+	for i := range c.histograms {
+		c.histograms[i] = prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    prometheus.BuildFQName(namespace, netlinkOperationInfixes[i], "timing_sec"),
+			Help:    fmt.Sprintf("Histogram of %s timings", netlinkOperationDescriptions[i]),
+			Buckets: prometheus.ExponentialBuckets(0.0001, 4, 8),
+		})
+	}
+	return c
+}
+
+func (n *netlinkTimingsCollector) Observe(op server.NetlinkOperation, t time.Duration) {
+	n.histograms[op].Observe(t.Seconds())
+}
+
+func (n *netlinkTimingsCollector) Describe(descs chan<- *prometheus.Desc) {
+	for _, h := range n.histograms {
+		h.Describe(descs)
+	}
+}
+
+func (n *netlinkTimingsCollector) Collect(metrics chan<- prometheus.Metric) {
+	for _, h := range n.histograms {
+		h.Collect(metrics)
+	}
+}
+
+var (
+	ribLabels         = []string{"route_family"}
+	exportRuleLabels  = []string{"rule", "vrf"}
+	rpkiLabels        = []string{"server"}
+	rpkiFamilyLabels  = []string{"server", "route_family"}
+	rpkiFileLabels    = []string{"path", "route_family"}
+	bmpLabels         = []string{"station"}
+	mrtLabels         = []string{"filename", "dump_type"}
+	policyLabels      = []string{"policy"}
+	statementLabels   = []string{"statement"}
+	assignmentLabels  = []string{"assignment", "direction"}
+	rpkiRouteFamilies = []string{bgp.RF_IPv4_UC.String(), bgp.RF_IPv6_UC.String()}
+
+	bgpRibPrefixesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "rib", "prefixes"),
+		"Number of prefixes in the Loc-RIB",
+		ribLabels, nil,
+	)
+	bgpRibPathsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "rib", "paths"),
+		"Number of paths in the Loc-RIB",
+		ribLabels, nil,
+	)
+
+	netlinkExportExportedTotalDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "netlink_export", "exported_total"),
+		"Number of routes exported to the kernel by the rule",
+		exportRuleLabels, nil,
+	)
+	netlinkExportWithdrawnTotalDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "netlink_export", "withdrawn_total"),
+		"Number of routes withdrawn from the kernel by the rule",
+		exportRuleLabels, nil,
+	)
+	netlinkExportErrorsTotalDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "netlink_export", "errors_total"),
+		"Number of kernel errors while exporting the routes by the rule",
+		exportRuleLabels, nil,
+	)
+	netlinkExportNexthopFailedTotalDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "netlink_export", "nexthop_failed_total"),
+		"Number of routes not exported by the rule because of unreachable nexthops",
+		exportRuleLabels, nil,
+	)
+	netlinkExportDampenedTotalDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "netlink_export", "dampened_total"),
+		"Number of route updates dampened before the export",
+		nil, nil,
+	)
+	netlinkImportImportedTotalDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "netlink_import", "imported_total"),
+		"Number of routes imported from the kernel",
+		nil, nil,
+	)
+	netlinkImportWithdrawnTotalDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "netlink_import", "withdrawn_total"),
+		"Number of imported routes withdrawn",
+		nil, nil,
+	)
+	netlinkImportErrorsTotalDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "netlink_import", "errors_total"),
+		"Number of errors while importing the routes from the kernel",
+		nil, nil,
+	)
+
+	rpkiUpDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "rpki", "up"),
+		"Whether the session with the RPKI server is up (1) or not (0)",
+		rpkiLabels, nil,
+	)
+	rpkiRoasDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "rpki", "roas"),
+		"Number of ROAs received from the RPKI server",
+		rpkiFamilyLabels, nil,
+	)
+	rpkiFileRoasDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "rpki", "file_roas"),
+		"Number of ROAs loaded from the local RPKI file",
+		rpkiFileLabels, nil,
+	)
+
+	bmpUpDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "bmp", "up"),
+		"Whether the BMP station is connected (1) or not (0)",
+		bmpLabels, nil,
+	)
+	bmpQueueDepthDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "bmp", "queue_depth"),
+		"Number of messages queued to the BMP station",
+		bmpLabels, nil,
+	)
+	bmpDroppedTotalDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "bmp", "dropped_total"),
+		"Number of messages dropped because the queue to the BMP station was full",
+		bmpLabels, nil,
+	)
+	bmpReconnectsTotalDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "bmp", "reconnects_total"),
+		"Number of times the connection to the BMP station was established again",
+		bmpLabels, nil,
+	)
+	bmpResyncsTotalDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "bmp", "resyncs_total"),
+		"Number of full RIB resyncs to the BMP station",
+		bmpLabels, nil,
+	)
+
+	mrtWrittenBytesTotalDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "mrt", "written_bytes_total"),
+		"Number of MRT bytes written before the compression",
+		mrtLabels, nil,
+	)
+	mrtErrorsTotalDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "mrt", "errors_total"),
+		"Number of failed MRT writes",
+		mrtLabels, nil,
+	)
+	mrtRotationsTotalDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "mrt", "rotations_total"),
+		"Number of MRT file rotations",
+		mrtLabels, nil,
+	)
+
+	policyDescs     = newPolicyStatisticsDescs("policy", "policy", policyLabels)
+	statementDescs  = newPolicyStatisticsDescs("policy_statement", "statement", statementLabels)
+	assignmentDescs = newPolicyStatisticsDescs("policy_assignment", "policy assignment", assignmentLabels)
+)
+
+// policyStatisticsDescs is the evaluations, matches, accepts and rejects
+// counters of the policies, the statements or the assignments.
+type policyStatisticsDescs [4]*prometheus.Desc
+
+func newPolicyStatisticsDescs(subsystem, what string, labels []string) policyStatisticsDescs {
+	return policyStatisticsDescs{
+		prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "evaluations_total"),
+			fmt.Sprintf("Number of routes evaluated by the %s", what),
+			labels, nil,
+		),
+		prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "matches_total"),
+			fmt.Sprintf("Number of routes matched by the %s", what),
+			labels, nil,
+		),
+		prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "accepts_total"),
+			fmt.Sprintf("Number of routes accepted by the %s", what),
+			labels, nil,
+		),
+		prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "rejects_total"),
+			fmt.Sprintf("Number of routes rejected by the %s", what),
+			labels, nil,
+		),
+	}
+}
+
+func (d policyStatisticsDescs) describe(out chan<- *prometheus.Desc) {
+	for _, desc := range d {
+		out <- desc
+	}
+}
+
+func (d policyStatisticsDescs) collect(out chan<- prometheus.Metric, s *api.PolicyStatistics, labelValues ...string) {
+	for i, cnt := range []uint64{s.GetEvaluations(), s.GetMatches(), s.GetAccepts(), s.GetRejects()} {
+		out <- prometheus.MustNewConstMetric(d[i], prometheus.CounterValue, float64(cnt), labelValues...)
+	}
+}
+
+func describeSubsystems(out chan<- *prometheus.Desc) {
+	out <- bgpRibPrefixesDesc
+	out <- bgpRibPathsDesc
+
+	out <- netlinkExportExportedTotalDesc
+	out <- netlinkExportWithdrawnTotalDesc
+	out <- netlinkExportErrorsTotalDesc
+	out <- netlinkExportNexthopFailedTotalDesc
+	out <- netlinkExportDampenedTotalDesc
+	out <- netlinkImportImportedTotalDesc
+	out <- netlinkImportWithdrawnTotalDesc
+	out <- netlinkImportErrorsTotalDesc
+
+	out <- rpkiUpDesc
+	out <- rpkiRoasDesc
+	out <- rpkiFileRoasDesc
+
+	out <- bmpUpDesc
+	out <- bmpQueueDepthDesc
+	out <- bmpDroppedTotalDesc
+	out <- bmpReconnectsTotalDesc
+	out <- bmpResyncsTotalDesc
+
+	out <- mrtWrittenBytesTotalDesc
+	out <- mrtErrorsTotalDesc
+	out <- mrtRotationsTotalDesc
+
+	policyDescs.describe(out)
+	statementDescs.describe(out)
+	assignmentDescs.describe(out)
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func collectError(out chan<- prometheus.Metric, err error) {
+	out <- prometheus.NewInvalidMetric(prometheus.NewDesc("error", "error during metric collection", nil, nil), err)
+}
+
+func (c *bgpCollector) collectRib(out chan<- prometheus.Metric, global *api.Global) {
+	for _, f := range global.GetFamilies() {
+		family := bgp.Family(f)
+		rsp, err := c.server.GetTable(context.Background(), &api.GetTableRequest{
+			TableType: api.TableType_TABLE_TYPE_GLOBAL,
+			Family: &api.Family{
+				Afi:  api.Family_Afi(family.Afi()),
+				Safi: api.Family_Safi(family.Safi()),
+			},
+		})
+		if err != nil {
+			collectError(out, err)
+			continue
+		}
+		out <- prometheus.MustNewConstMetric(bgpRibPrefixesDesc, prometheus.GaugeValue, float64(rsp.NumDestination), family.String())
+		out <- prometheus.MustNewConstMetric(bgpRibPathsDesc, prometheus.GaugeValue, float64(rsp.NumPath), family.String())
+	}
+}
+
+func (c *bgpCollector) collectNetlink(out chan<- prometheus.Metric) {
+	nl, err := c.server.GetNetlink(context.Background(), &api.GetNetlinkRequest{})
+	if err != nil {
+		collectError(out, err)
+		return
+	}
+	if nl.ExportEnabled {
+		// the export client isn't running if it failed to start
+		if stats, err := c.server.GetNetlinkExportStats(context.Background(), &api.GetNetlinkExportStatsRequest{}); err == nil {
+			for _, r := range stats.Rules {
+				send := func(desc *prometheus.Desc, cnt uint64) {
+					out <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, float64(cnt), r.Rule, r.Vrf)
+				}
+				send(netlinkExportExportedTotalDesc, r.Exported)
+				send(netlinkExportWithdrawnTotalDesc, r.Withdrawn)
+				send(netlinkExportErrorsTotalDesc, r.Errors)
+				send(netlinkExportNexthopFailedTotalDesc, r.NexthopValidationFailures)
+			}
+			out <- prometheus.MustNewConstMetric(netlinkExportDampenedTotalDesc, prometheus.CounterValue, float64(stats.DampenedUpdates))
+		}
+	}
+	if nl.ImportEnabled {
+		if stats, err := c.server.GetNetlinkImportStats(context.Background(), &api.GetNetlinkImportStatsRequest{}); err == nil {
+			out <- prometheus.MustNewConstMetric(netlinkImportImportedTotalDesc, prometheus.CounterValue, float64(stats.Imported))
+			out <- prometheus.MustNewConstMetric(netlinkImportWithdrawnTotalDesc, prometheus.CounterValue, float64(stats.Withdrawn))
+			out <- prometheus.MustNewConstMetric(netlinkImportErrorsTotalDesc, prometheus.CounterValue, float64(stats.Errors))
+		}
+	}
+}
+
+func (c *bgpCollector) collectRpki(out chan<- prometheus.Metric) {
+	err := c.server.ListRpki(context.Background(), &api.ListRpkiRequest{}, func(r *api.Rpki) {
+		addr := net.JoinHostPort(r.GetConf().GetAddress(), strconv.Itoa(int(r.GetConf().GetRemotePort())))
+		state := r.GetState()
+		out <- prometheus.MustNewConstMetric(rpkiUpDesc, prometheus.GaugeValue, boolToFloat(state.GetUp()), addr)
+		for i, cnt := range []uint32{state.GetRecordIpv4(), state.GetRecordIpv6()} {
+			out <- prometheus.MustNewConstMetric(rpkiRoasDesc, prometheus.GaugeValue, float64(cnt), addr, rpkiRouteFamilies[i])
+		}
+	})
+	if err != nil {
+		collectError(out, err)
+	}
+	err = c.server.ListRpkiFile(context.Background(), &api.ListRpkiFileRequest{}, func(f *api.RpkiFile) {
+		for i, cnt := range []uint32{f.GetRecordIpv4(), f.GetRecordIpv6()} {
+			out <- prometheus.MustNewConstMetric(rpkiFileRoasDesc, prometheus.GaugeValue, float64(cnt), f.GetPath(), rpkiRouteFamilies[i])
+		}
+	})
+	if err != nil {
+		collectError(out, err)
+	}
+}
+
+func (c *bgpCollector) collectBmp(out chan<- prometheus.Metric) {
+	err := c.server.ListBmp(context.Background(), &api.ListBmpRequest{}, func(s *api.ListBmpResponse_BmpStation) {
+		addr := net.JoinHostPort(s.GetConf().GetAddress(), strconv.Itoa(int(s.GetConf().GetPort())))
+		state := s.GetState()
+		out <- prometheus.MustNewConstMetric(bmpUpDesc, prometheus.GaugeValue, boolToFloat(state.GetUp()), addr)
+		out <- prometheus.MustNewConstMetric(bmpQueueDepthDesc, prometheus.GaugeValue, float64(state.GetQueued()), addr)
+		out <- prometheus.MustNewConstMetric(bmpDroppedTotalDesc, prometheus.CounterValue, float64(state.GetDropped()), addr)
+		out <- prometheus.MustNewConstMetric(bmpReconnectsTotalDesc, prometheus.CounterValue, float64(state.GetReconnects()), addr)
+		out <- prometheus.MustNewConstMetric(bmpResyncsTotalDesc, prometheus.CounterValue, float64(state.GetResyncs()), addr)
+	})
+	if err != nil {
+		collectError(out, err)
+	}
+}
+
+func (c *bgpCollector) collectMrt(out chan<- prometheus.Metric) {
+	err := c.server.ListMrt(context.Background(), &api.ListMrtRequest{}, func(m *api.Mrt) {
+		dumpType := "updates"
+		if m.GetDumpType() == api.EnableMrtRequest_DUMP_TYPE_TABLE {
+			dumpType = "table"
+		}
+		send := func(desc *prometheus.Desc, cnt uint64) {
+			out <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, float64(cnt), m.GetFilename(), dumpType)
+		}
+		send(mrtWrittenBytesTotalDesc, m.GetBytes())
+		send(mrtErrorsTotalDesc, m.GetErrors())
+		send(mrtRotationsTotalDesc, m.GetRotations())
+	})
+	if err != nil {
+		collectError(out, err)
+	}
+}
+
+func (c *bgpCollector) collectPolicy(out chan<- prometheus.Metric) {
+	err := c.server.ListPolicy(context.Background(), &api.ListPolicyRequest{}, func(p *api.Policy) {
+		policyDescs.collect(out, p.GetStatistics(), p.GetName())
+	})
+	if err != nil {
+		collectError(out, err)
+	}
+	// the statements may be shared by the policies
+	err = c.server.ListStatement(context.Background(), &api.ListStatementRequest{}, func(s *api.Statement) {
+		statementDescs.collect(out, s.GetStatistics(), s.GetName())
+	})
+	if err != nil {
+		collectError(out, err)
+	}
+	err = c.server.ListPolicyAssignment(context.Background(), &api.ListPolicyAssignmentRequest{}, func(a *api.PolicyAssignment) {
+		dir := "import"
+		if a.GetDirection() == api.PolicyDirection_POLICY_DIRECTION_EXPORT {
+			dir = "export"
+		}
+		assignmentDescs.collect(out, a.GetStatistics(), a.GetName(), dir)
+	})
+	if err != nil {
+		collectError(out, err)
+	}
+}
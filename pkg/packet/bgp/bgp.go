@@ -370,8 +370,10 @@ type BGPCapabilityCode uint8
 const (
 	BGP_CAP_MULTIPROTOCOL               BGPCapabilityCode = 1
 	BGP_CAP_ROUTE_REFRESH               BGPCapabilityCode = 2
+	BGP_CAP_OUTBOUND_ROUTE_FILTERING    BGPCapabilityCode = 3
 	BGP_CAP_CARRYING_LABEL_INFO         BGPCapabilityCode = 4
 	BGP_CAP_EXTENDED_NEXTHOP            BGPCapabilityCode = 5
+	BGP_CAP_BGPSEC                      BGPCapabilityCode = 7
 	BGP_CAP_GRACEFUL_RESTART            BGPCapabilityCode = 64
 	BGP_CAP_FOUR_OCTET_AS_NUMBER        BGPCapabilityCode = 65
 	BGP_CAP_ADD_PATH                    BGPCapabilityCode = 69
@@ -385,9 +387,11 @@ const (
 var CapNameMap = map[BGPCapabilityCode]string{
 	BGP_CAP_MULTIPROTOCOL:               "multiprotocol",
 	BGP_CAP_ROUTE_REFRESH:               "route-refresh",
+	BGP_CAP_OUTBOUND_ROUTE_FILTERING:    "outbound-route-filtering",
 	BGP_CAP_CARRYING_LABEL_INFO:         "carrying-label-info",
 	BGP_CAP_GRACEFUL_RESTART:            "graceful-restart",
 	BGP_CAP_EXTENDED_NEXTHOP:            "extended-nexthop",
+	BGP_CAP_BGPSEC:                      "bgpsec",
 	BGP_CAP_FOUR_OCTET_AS_NUMBER:        "4-octet-as",
 	BGP_CAP_ADD_PATH:                    "add-path",
 	BGP_CAP_ENHANCED_ROUTE_REFRESH:      "enhanced-route-refresh",
@@ -1184,10 +1188,14 @@ func DecodeCapability(data []byte) (ParameterCapabilityInterface, error) {
 		c = &CapMultiProtocol{}
 	case BGP_CAP_ROUTE_REFRESH:
 		c = &CapRouteRefresh{}
+	case BGP_CAP_OUTBOUND_ROUTE_FILTERING:
+		c = &CapOutboundRouteFiltering{}
 	case BGP_CAP_CARRYING_LABEL_INFO:
 		c = &CapCarryingLabelInfo{}
 	case BGP_CAP_EXTENDED_NEXTHOP:
 		c = &CapExtendedNexthop{}
+	case BGP_CAP_BGPSEC:
+		c = &CapBGPsec{}
 	case BGP_CAP_GRACEFUL_RESTART:
 		c = &CapGracefulRestart{}
 	case BGP_CAP_FOUR_OCTET_AS_NUMBER:
@@ -10537,6 +10545,7 @@ const (
 	_
 	BGP_ATTR_TYPE_LS                          // = 29
 	BGP_ATTR_TYPE_LARGE_COMMUNITY BGPAttrType = 32
+	BGP_ATTR_TYPE_BGPSEC_PATH     BGPAttrType = 33
 	BGP_ATTR_TYPE_PREFIX_SID      BGPAttrType = 40
 )
 
@@ -10550,6 +10559,7 @@ const (
 	BGP_ERROR_FSM_ERROR
 	BGP_ERROR_CEASE
 	BGP_ERROR_ROUTE_REFRESH_MESSAGE_ERROR
+	BGP_ERROR_SEND_HOLD_TIMER_EXPIRED // RFC 9687
 )
 
 // NOTIFICATION Error Subcode for BGP_ERROR_MESSAGE_HEADER_ERROR
@@ -10702,6 +10712,8 @@ func (c NotificationErrorCode) String() string {
 	case BGP_ERROR_ROUTE_REFRESH_MESSAGE_ERROR:
 		codeStr = "route refresh"
 		subcodeList = []string{"invalid message length"}
+	case BGP_ERROR_SEND_HOLD_TIMER_EXPIRED:
+		codeStr = "send hold timer expired"
 	}
 	subcodeStr := func(idx uint8, l []string) string {
 		if len(l) == 0 || int(idx) > len(l)-1 {
@@ -10738,6 +10750,7 @@ var PathAttrFlags map[BGPAttrType]BGPAttrFlag = map[BGPAttrType]BGPAttrFlag{
 	BGP_ATTR_TYPE_AIGP:                     BGP_ATTR_FLAG_OPTIONAL,
 	BGP_ATTR_TYPE_LARGE_COMMUNITY:          BGP_ATTR_FLAG_TRANSITIVE | BGP_ATTR_FLAG_OPTIONAL,
 	BGP_ATTR_TYPE_LS:                       BGP_ATTR_FLAG_OPTIONAL,
+	BGP_ATTR_TYPE_BGPSEC_PATH:              BGP_ATTR_FLAG_OPTIONAL,
 	BGP_ATTR_TYPE_PREFIX_SID:               BGP_ATTR_FLAG_TRANSITIVE | BGP_ATTR_FLAG_OPTIONAL,
 }
 
@@ -11795,14 +11808,14 @@ func (p *PathAttributeMpReachNLRI) DecodeFromBytes(data []byte, options ...*Mars
 	eCode := uint8(BGP_ERROR_UPDATE_MESSAGE_ERROR)
 	eSubCode := uint8(BGP_ERROR_SUB_ATTRIBUTE_LENGTH_ERROR)
 	eData, _ := p.PathAttribute.Serialize(value, options...)
-	if p.Length < 3 {
-		return NewMessageError(eCode, eSubCode, eData, "mpreach header length is short")
-	}
 
 	var family Family
 	// In MRT dumps, AFI+SAFI+NLRI is implicit based on RIB Entry Header, see RFC 6396 4.3.4
 	onlyNexthop := IsMRTSerialization(options)
 	if !onlyNexthop {
+		if p.Length < 3 {
+			return NewMessageError(eCode, eSubCode, eData, "mpreach header length is short")
+		}
 		p.AFI = binary.BigEndian.Uint16(value[:2])
 		p.SAFI = value[2]
 		family = NewFamily(p.AFI, p.SAFI)
@@ -15291,6 +15304,8 @@ func GetPathAttribute(data []byte) (PathAttributeInterface, error) {
 		return &PathAttributeLs{}, nil
 	case BGP_ATTR_TYPE_PREFIX_SID:
 		return &PathAttributePrefixSID{}, nil
+	case BGP_ATTR_TYPE_BGPSEC_PATH:
+		return &PathAttributeBGPsecPath{}, nil
 	}
 	return &PathAttributeUnknown{}, nil
 }
@@ -15620,6 +15635,10 @@ type BGPRouteRefresh struct {
 	AFI         uint16
 	Demarcation uint8
 	SAFI        uint8
+	// WhenToRefresh and ORFs are only present in ROUTE-REFRESH messages
+	// carrying Outbound Route Filters (RFC 5291).
+	WhenToRefresh ORFWhenToRefresh
+	ORFs          []*RouteRefreshORF
 }
 
 func (msg *BGPRouteRefresh) DecodeFromBytes(data []byte, options ...*MarshallingOption) error {
@@ -15629,6 +15648,21 @@ func (msg *BGPRouteRefresh) DecodeFromBytes(data []byte, options ...*Marshalling
 	msg.AFI = binary.BigEndian.Uint16(data[:2])
 	msg.Demarcation = data[2]
 	msg.SAFI = data[3]
+	data = data[4:]
+	if len(data) == 0 {
+		return nil
+	}
+	msg.WhenToRefresh = ORFWhenToRefresh(data[0])
+	data = data[1:]
+	for len(data) > 0 {
+		o := &RouteRefreshORF{}
+		n, err := o.decodeFromBytes(data, msg.AFI)
+		if err != nil {
+			return err
+		}
+		msg.ORFs = append(msg.ORFs, o)
+		data = data[n:]
+	}
 	return nil
 }
 
@@ -15637,13 +15671,24 @@ func (msg *BGPRouteRefresh) Serialize(options ...*MarshallingOption) ([]byte, er
 	binary.BigEndian.PutUint16(buf[:2], msg.AFI)
 	buf[2] = msg.Demarcation
 	buf[3] = msg.SAFI
+	if msg.WhenToRefresh == 0 && len(msg.ORFs) == 0 {
+		return buf, nil
+	}
+	buf = append(buf, uint8(msg.WhenToRefresh))
+	for _, o := range msg.ORFs {
+		b, err := o.serialize()
+		if err != nil {
+			return nil, err
+		}
+		buf = append(buf, b...)
+	}
 	return buf, nil
 }
 
 func NewBGPRouteRefreshMessage(afi uint16, demarcation uint8, safi uint8) *BGPMessage {
 	return &BGPMessage{
 		Header: BGPHeader{Type: BGP_MSG_ROUTE_REFRESH},
-		Body:   &BGPRouteRefresh{afi, demarcation, safi},
+		Body:   &BGPRouteRefresh{AFI: afi, Demarcation: demarcation, SAFI: safi},
 	}
 }
 
@@ -15800,6 +15845,8 @@ func getErrorHandlingFromPathAttribute(t BGPAttrType) ErrorHandling {
 		return ERROR_HANDLING_TREAT_AS_WITHDRAW
 	case BGP_ATTR_TYPE_LARGE_COMMUNITY:
 		return ERROR_HANDLING_TREAT_AS_WITHDRAW
+	case BGP_ATTR_TYPE_BGPSEC_PATH:
+		return ERROR_HANDLING_TREAT_AS_WITHDRAW
 	case BGP_ATTR_TYPE_TUNNEL_ENCAP:
 		return ERROR_HANDLING_ATTRIBUTE_DISCARD
 	case BGP_ATTR_TYPE_AIGP:
@@ -3837,6 +3837,7 @@ func FuzzDecodeFromBytes(f *testing.F) {
 		(&CapLongLivedGracefulRestart{}).DecodeFromBytes(data)
 		(&CapFQDN{}).DecodeFromBytes(data)
 		(&CapSoftwareVersion{}).DecodeFromBytes(data)
+		(&CapBGPsec{}).DecodeFromBytes(data)
 		(&OptionParameterCapability{}).DecodeFromBytes(data)
 		(&BGPOpen{}).DecodeFromBytes(data)
 		(&IPAddrPrefix{}).decodeFromBytes(data, net.IPv4len)
@@ -3956,6 +3957,7 @@ func FuzzDecodeFromBytes(f *testing.F) {
 		(&PathAttributeIP6ExtendedCommunities{}).DecodeFromBytes(data)
 		(&PathAttributeAigp{}).DecodeFromBytes(data)
 		(&PathAttributeLargeCommunities{}).DecodeFromBytes(data)
+		(&PathAttributeBGPsecPath{}).DecodeFromBytes(data)
 		(&PathAttributeUnknown{}).DecodeFromBytes(data)
 		(&BGPUpdate{}).DecodeFromBytes(data)
 		(&BGPNotification{}).DecodeFromBytes(data)
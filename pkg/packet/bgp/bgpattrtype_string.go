@@ -29,6 +29,7 @@ func _() {
 	_ = x[BGP_ATTR_TYPE_AIGP-26]
 	_ = x[BGP_ATTR_TYPE_LS-29]
 	_ = x[BGP_ATTR_TYPE_LARGE_COMMUNITY-32]
+	_ = x[BGP_ATTR_TYPE_BGPSEC_PATH-33]
 	_ = x[BGP_ATTR_TYPE_PREFIX_SID-40]
 }
 
@@ -38,7 +39,7 @@ const (
 	_BGPAttrType_name_2 = "BGP_ATTR_TYPE_PMSI_TUNNELBGP_ATTR_TYPE_TUNNEL_ENCAP"
 	_BGPAttrType_name_3 = "BGP_ATTR_TYPE_IP6_EXTENDED_COMMUNITIESBGP_ATTR_TYPE_AIGP"
 	_BGPAttrType_name_4 = "BGP_ATTR_TYPE_LS"
-	_BGPAttrType_name_5 = "BGP_ATTR_TYPE_LARGE_COMMUNITY"
+	_BGPAttrType_name_5 = "BGP_ATTR_TYPE_LARGE_COMMUNITYBGP_ATTR_TYPE_BGPSEC_PATH"
 	_BGPAttrType_name_6 = "BGP_ATTR_TYPE_PREFIX_SID"
 )
 
@@ -47,6 +48,7 @@ var (
 	_BGPAttrType_index_1 = [...]uint8{0, 27, 56, 90, 112, 140}
 	_BGPAttrType_index_2 = [...]uint8{0, 25, 51}
 	_BGPAttrType_index_3 = [...]uint8{0, 38, 56}
+	_BGPAttrType_index_5 = [...]uint8{0, 29, 54}
 )
 
 func (i BGPAttrType) String() string {
@@ -65,8 +67,9 @@ func (i BGPAttrType) String() string {
 		return _BGPAttrType_name_3[_BGPAttrType_index_3[i]:_BGPAttrType_index_3[i+1]]
 	case i == 29:
 		return _BGPAttrType_name_4
-	case i == 32:
-		return _BGPAttrType_name_5
+	case 32 <= i && i <= 33:
+		i -= 32
+		return _BGPAttrType_name_5[_BGPAttrType_index_5[i]:_BGPAttrType_index_5[i+1]]
 	case i == 40:
 		return _BGPAttrType_name_6
 	default:
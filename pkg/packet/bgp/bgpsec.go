@@ -0,0 +1,366 @@
+package bgp
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// BGPsecDirection is the direction bit of the BGPsec capability, as defined
+// in [RFC 8205, section 2.1].
+//
+// [RFC 8205, section 2.1]: https://www.rfc-editor.org/rfc/rfc8205.html#section-2.1
+type BGPsecDirection uint8
+
+const (
+	// BGPSEC_DIRECTION_RECEIVE indicates that the sender is able to receive
+	// BGPsec UPDATE messages.
+	BGPSEC_DIRECTION_RECEIVE BGPsecDirection = 0
+	// BGPSEC_DIRECTION_SEND indicates that the sender is able to send
+	// BGPsec UPDATE messages.
+	BGPSEC_DIRECTION_SEND BGPsecDirection = 1
+)
+
+func (d BGPsecDirection) String() string {
+	switch d {
+	case BGPSEC_DIRECTION_RECEIVE:
+		return "receive"
+	case BGPSEC_DIRECTION_SEND:
+		return "send"
+	default:
+		return fmt.Sprintf("unknown(%d)", d)
+	}
+}
+
+const (
+	// BGPSEC_VERSION is the version of the BGPsec protocol advertised in the
+	// capability.
+	BGPSEC_VERSION = 0
+	// BGPSEC_ALGORITHM_SUITE_1 is the ECDSA P-256 with SHA-256 algorithm
+	// suite defined in RFC 8208.
+	BGPSEC_ALGORITHM_SUITE_1 = 1
+	// BGPSEC_SEGMENT_FLAG_CONFED is the Confed_Segment flag of a Secure_Path
+	// segment.
+	BGPSEC_SEGMENT_FLAG_CONFED = 0x80
+	// BGPSEC_SKI_LEN is the length of the Subject Key Identifier.
+	BGPSEC_SKI_LEN = 20
+)
+
+// CapBGPsec represents the BGPsec Capability, as defined in
+// [RFC 8205, section 2.1]. A speaker advertises one capability per
+// address family and direction.
+//
+// [RFC 8205, section 2.1]: https://www.rfc-editor.org/rfc/rfc8205.html#section-2.1
+type CapBGPsec struct {
+	DefaultParameterCapability
+	Version   uint8
+	Direction BGPsecDirection
+	AFI       uint16
+}
+
+func (c *CapBGPsec) DecodeFromBytes(data []byte) error {
+	if err := c.DefaultParameterCapability.DecodeFromBytes(data); err != nil {
+		return err
+	}
+	data = data[2:]
+	if len(data) < 3 {
+		return NewMessageError(BGP_ERROR_OPEN_MESSAGE_ERROR, BGP_ERROR_SUB_UNSUPPORTED_CAPABILITY, nil, "Not all CapabilityBGPsec bytes available")
+	}
+	c.Version = data[0] >> 4
+	c.Direction = BGPsecDirection((data[0] >> 3) & 0x1)
+	c.AFI = binary.BigEndian.Uint16(data[1:3])
+	return nil
+}
+
+func (c *CapBGPsec) Serialize() ([]byte, error) {
+	buf := make([]byte, 3)
+	buf[0] = c.Version<<4 | uint8(c.Direction&0x1)<<3
+	binary.BigEndian.PutUint16(buf[1:], c.AFI)
+	c.CapValue = buf
+	return c.DefaultParameterCapability.Serialize()
+}
+
+func (c *CapBGPsec) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Code      BGPCapabilityCode `json:"code"`
+		Version   uint8             `json:"version"`
+		Direction BGPsecDirection   `json:"direction"`
+		AFI       uint16            `json:"afi"`
+	}{
+		Code:      c.Code(),
+		Version:   c.Version,
+		Direction: c.Direction,
+		AFI:       c.AFI,
+	})
+}
+
+func NewCapBGPsec(direction BGPsecDirection, afi uint16) *CapBGPsec {
+	return &CapBGPsec{
+		DefaultParameterCapability: DefaultParameterCapability{
+			CapCode: BGP_CAP_BGPSEC,
+		},
+		Version:   BGPSEC_VERSION,
+		Direction: direction,
+		AFI:       afi,
+	}
+}
+
+// SecurePathSegment is one segment of the Secure_Path of the BGPsec_PATH
+// attribute.
+type SecurePathSegment struct {
+	PCount uint8  `json:"pcount"`
+	Flags  uint8  `json:"flags"`
+	AS     uint32 `json:"as"`
+}
+
+func (s *SecurePathSegment) serialize() []byte {
+	buf := make([]byte, 6)
+	buf[0] = s.PCount
+	buf[1] = s.Flags
+	binary.BigEndian.PutUint32(buf[2:], s.AS)
+	return buf
+}
+
+// BGPsecSignatureSegment is one segment of a Signature_Block of the
+// BGPsec_PATH attribute.
+type BGPsecSignatureSegment struct {
+	SKI       [BGPSEC_SKI_LEN]byte
+	Signature []byte
+}
+
+func (s *BGPsecSignatureSegment) serialize() []byte {
+	buf := make([]byte, BGPSEC_SKI_LEN+2, BGPSEC_SKI_LEN+2+len(s.Signature))
+	copy(buf, s.SKI[:])
+	binary.BigEndian.PutUint16(buf[BGPSEC_SKI_LEN:], uint16(len(s.Signature)))
+	return append(buf, s.Signature...)
+}
+
+func (s *BGPsecSignatureSegment) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		SKI       string `json:"ski"`
+		Signature string `json:"signature"`
+	}{
+		SKI:       hex.EncodeToString(s.SKI[:]),
+		Signature: hex.EncodeToString(s.Signature),
+	})
+}
+
+// BGPsecSignatureBlock is a Signature_Block of the BGPsec_PATH attribute.
+// Segments are ordered from the most recent signer to the origin.
+type BGPsecSignatureBlock struct {
+	AlgorithmSuite uint8                     `json:"algorithm_suite"`
+	Segments       []*BGPsecSignatureSegment `json:"segments"`
+}
+
+func (b *BGPsecSignatureBlock) len() int {
+	l := 3
+	for _, s := range b.Segments {
+		l += BGPSEC_SKI_LEN + 2 + len(s.Signature)
+	}
+	return l
+}
+
+// PathAttributeBGPsecPath represents the BGPsec_PATH attribute, as defined
+// in [RFC 8205, section 3]. SecurePath is ordered from the most recently
+// added AS to the origin AS.
+//
+// [RFC 8205, section 3]: https://www.rfc-editor.org/rfc/rfc8205.html#section-3
+type PathAttributeBGPsecPath struct {
+	PathAttribute
+	SecurePath      []*SecurePathSegment
+	SignatureBlocks []*BGPsecSignatureBlock
+}
+
+func (p *PathAttributeBGPsecPath) DecodeFromBytes(data []byte, options ...*MarshallingOption) error {
+	value, err := p.PathAttribute.DecodeFromBytes(data, options...)
+	if err != nil {
+		return err
+	}
+	eCode := uint8(BGP_ERROR_UPDATE_MESSAGE_ERROR)
+	eSubCode := uint8(BGP_ERROR_SUB_MALFORMED_ATTRIBUTE_LIST)
+	if len(value) < 2 {
+		return NewMessageError(eCode, eSubCode, nil, "BGPsec_PATH attribute length is short")
+	}
+	l := int(binary.BigEndian.Uint16(value[:2]))
+	if l < 8 || (l-2)%6 != 0 || len(value) < l {
+		return NewMessageError(eCode, eSubCode, nil, "invalid Secure_Path length")
+	}
+	p.SecurePath = make([]*SecurePathSegment, 0, (l-2)/6)
+	for buf := value[2:l]; len(buf) > 0; buf = buf[6:] {
+		p.SecurePath = append(p.SecurePath, &SecurePathSegment{
+			PCount: buf[0],
+			Flags:  buf[1],
+			AS:     binary.BigEndian.Uint32(buf[2:6]),
+		})
+	}
+	value = value[l:]
+	if len(value) == 0 {
+		return NewMessageError(eCode, eSubCode, nil, "no Signature_Block in BGPsec_PATH attribute")
+	}
+	p.SignatureBlocks = make([]*BGPsecSignatureBlock, 0, 1)
+	for len(value) > 0 {
+		if len(value) < 3 {
+			return NewMessageError(eCode, eSubCode, nil, "Signature_Block length is short")
+		}
+		l := int(binary.BigEndian.Uint16(value[:2]))
+		if l < 3 || len(value) < l {
+			return NewMessageError(eCode, eSubCode, nil, "invalid Signature_Block length")
+		}
+		b := &BGPsecSignatureBlock{
+			AlgorithmSuite: value[2],
+		}
+		for buf := value[3:l]; len(buf) > 0; {
+			if len(buf) < BGPSEC_SKI_LEN+2 {
+				return NewMessageError(eCode, eSubCode, nil, "Signature_Segment length is short")
+			}
+			s := &BGPsecSignatureSegment{}
+			copy(s.SKI[:], buf[:BGPSEC_SKI_LEN])
+			sl := int(binary.BigEndian.Uint16(buf[BGPSEC_SKI_LEN : BGPSEC_SKI_LEN+2]))
+			buf = buf[BGPSEC_SKI_LEN+2:]
+			if len(buf) < sl {
+				return NewMessageError(eCode, eSubCode, nil, "invalid signature length")
+			}
+			s.Signature = append([]byte(nil), buf[:sl]...)
+			buf = buf[sl:]
+			b.Segments = append(b.Segments, s)
+		}
+		p.SignatureBlocks = append(p.SignatureBlocks, b)
+		value = value[l:]
+	}
+	if len(p.SignatureBlocks) > 2 {
+		return NewMessageError(eCode, eSubCode, nil, "too many Signature_Blocks in BGPsec_PATH attribute")
+	}
+	return nil
+}
+
+func (p *PathAttributeBGPsecPath) Serialize(options ...*MarshallingOption) ([]byte, error) {
+	buf := make([]byte, 2, 2+6*len(p.SecurePath))
+	binary.BigEndian.PutUint16(buf, uint16(2+6*len(p.SecurePath)))
+	for _, s := range p.SecurePath {
+		buf = append(buf, s.serialize()...)
+	}
+	for _, b := range p.SignatureBlocks {
+		var hdr [3]byte
+		binary.BigEndian.PutUint16(hdr[:2], uint16(b.len()))
+		hdr[2] = b.AlgorithmSuite
+		buf = append(buf, hdr[:]...)
+		for _, s := range b.Segments {
+			buf = append(buf, s.serialize()...)
+		}
+	}
+	return p.PathAttribute.Serialize(buf, options...)
+}
+
+// SignatureBlock returns the Signature_Block using the given algorithm
+// suite, or nil if there is none.
+func (p *PathAttributeBGPsecPath) SignatureBlock(suite uint8) *BGPsecSignatureBlock {
+	for _, b := range p.SignatureBlocks {
+		if b.AlgorithmSuite == suite {
+			return b
+		}
+	}
+	return nil
+}
+
+// SignedData returns the octets hashed and signed by the speaker that added
+// the index-th (0 is the most recent) Secure_Path segment, as described in
+// [RFC 8205, section 4.2]. The signature of that speaker itself is not part
+// of the data, so it may be called before the signature is filled in.
+//
+// [RFC 8205, section 4.2]: https://www.rfc-editor.org/rfc/rfc8205.html#section-4.2
+func (p *PathAttributeBGPsecPath) SignedData(block *BGPsecSignatureBlock, index int, targetAS uint32, family Family, nlri NLRI) ([]byte, error) {
+	if index < 0 || index >= len(p.SecurePath) || len(block.Segments) != len(p.SecurePath) {
+		return nil, fmt.Errorf("invalid BGPsec segment index %d", index)
+	}
+	n, err := nlri.Serialize()
+	if err != nil {
+		return nil, err
+	}
+	buf := make([]byte, 4, 64)
+	binary.BigEndian.PutUint32(buf, targetAS)
+	for i := index; i < len(p.SecurePath); i++ {
+		if i > index {
+			buf = append(buf, block.Segments[i].serialize()...)
+		}
+		buf = append(buf, p.SecurePath[i].serialize()...)
+	}
+	buf = append(buf, block.AlgorithmSuite)
+	buf = binary.BigEndian.AppendUint16(buf, family.Afi())
+	buf = append(buf, family.Safi())
+	return append(buf, n...), nil
+}
+
+// AsPath returns the AS_PATH attribute equivalent to the Secure_Path, as
+// described in [RFC 8205, section 4.4].
+//
+// [RFC 8205, section 4.4]: https://www.rfc-editor.org/rfc/rfc8205.html#section-4.4
+func (p *PathAttributeBGPsecPath) AsPath() *PathAttributeAsPath {
+	params := make([]AsPathParamInterface, 0, 1)
+	var typ uint8
+	var list []uint32
+	for _, s := range p.SecurePath {
+		t := uint8(BGP_ASPATH_ATTR_TYPE_SEQ)
+		if s.Flags&BGPSEC_SEGMENT_FLAG_CONFED != 0 {
+			t = BGP_ASPATH_ATTR_TYPE_CONFED_SEQ
+		}
+		if t != typ && len(list) > 0 {
+			params = append(params, NewAs4PathParam(typ, list))
+			list = nil
+		}
+		typ = t
+		for range s.PCount {
+			list = append(list, s.AS)
+		}
+	}
+	if len(list) > 0 {
+		params = append(params, NewAs4PathParam(typ, list))
+	}
+	return NewPathAttributeAsPath(params)
+}
+
+func (p *PathAttributeBGPsecPath) String() string {
+	segs := make([]string, 0, len(p.SecurePath))
+	for _, s := range p.SecurePath {
+		if s.PCount == 1 {
+			segs = append(segs, fmt.Sprintf("%d", s.AS))
+		} else {
+			segs = append(segs, fmt.Sprintf("%d(x%d)", s.AS, s.PCount))
+		}
+	}
+	suites := make([]string, 0, len(p.SignatureBlocks))
+	for _, b := range p.SignatureBlocks {
+		suites = append(suites, fmt.Sprintf("%d", b.AlgorithmSuite))
+	}
+	return fmt.Sprintf("{BGPsecPath: %s, AlgorithmSuites: %s}", strings.Join(segs, " "), strings.Join(suites, ","))
+}
+
+func (p *PathAttributeBGPsecPath) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type            BGPAttrType             `json:"type"`
+		SecurePath      []*SecurePathSegment    `json:"secure_path"`
+		SignatureBlocks []*BGPsecSignatureBlock `json:"signature_blocks"`
+	}{
+		Type:            p.GetType(),
+		SecurePath:      p.SecurePath,
+		SignatureBlocks: p.SignatureBlocks,
+	})
+}
+
+func NewPathAttributeBGPsecPath(securePath []*SecurePathSegment, blocks []*BGPsecSignatureBlock) *PathAttributeBGPsecPath {
+	l := 2 + 6*len(securePath)
+	for _, b := range blocks {
+		l += b.len()
+	}
+	t := BGP_ATTR_TYPE_BGPSEC_PATH
+	return &PathAttributeBGPsecPath{
+		PathAttribute: PathAttribute{
+			Flags:  getPathAttrFlags(t, l),
+			Type:   t,
+			Length: uint16(l),
+		},
+		SecurePath:      securePath,
+		SignatureBlocks: blocks,
+	}
+}
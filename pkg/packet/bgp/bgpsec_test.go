@@ -0,0 +1,135 @@
+package bgp
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CapBGPsec(t *testing.T) {
+	assert := assert.New(t)
+	c1 := NewCapBGPsec(BGPSEC_DIRECTION_SEND, AFI_IP6)
+	buf, err := c1.Serialize()
+	require.NoError(t, err)
+	assert.Equal([]byte{0x07, 0x03, 0x08, 0x00, 0x02}, buf)
+
+	c2, err := DecodeCapability(buf)
+	require.NoError(t, err)
+	assert.Equal(c1, c2)
+
+	c3, err := DecodeCapability([]byte{0x07, 0x03, 0x00, 0x00, 0x01})
+	require.NoError(t, err)
+	assert.Equal(BGPSEC_DIRECTION_RECEIVE, c3.(*CapBGPsec).Direction)
+	assert.Equal(uint16(AFI_IP), c3.(*CapBGPsec).AFI)
+
+	_, err = DecodeCapability([]byte{0x07, 0x02, 0x00, 0x00})
+	assert.Error(err)
+}
+
+func testBGPsecPath() *PathAttributeBGPsecPath {
+	ski := func(b byte) (s [BGPSEC_SKI_LEN]byte) {
+		for i := range s {
+			s[i] = b
+		}
+		return s
+	}
+	return NewPathAttributeBGPsecPath(
+		[]*SecurePathSegment{
+			{PCount: 2, AS: 65002},
+			{PCount: 1, AS: 65001},
+		},
+		[]*BGPsecSignatureBlock{{
+			AlgorithmSuite: BGPSEC_ALGORITHM_SUITE_1,
+			Segments: []*BGPsecSignatureSegment{
+				{SKI: ski(2), Signature: []byte{0x22, 0x22, 0x22}},
+				{SKI: ski(1), Signature: []byte{0x11, 0x11}},
+			},
+		}},
+	)
+}
+
+func Test_PathAttributeBGPsecPath(t *testing.T) {
+	assert := assert.New(t)
+	p1 := testBGPsecPath()
+	buf, err := p1.Serialize()
+	require.NoError(t, err)
+	assert.Equal(p1.Len(), len(buf))
+
+	p2 := &PathAttributeBGPsecPath{}
+	require.NoError(t, p2.DecodeFromBytes(buf))
+	assert.Equal(p1, p2)
+	assert.Equal("{BGPsecPath: 65002(x2) 65001, AlgorithmSuites: 1}", p2.String())
+	assert.Equal([]uint32{65002, 65002, 65001}, p2.AsPath().Value[0].GetAS())
+	assert.NotNil(p2.SignatureBlock(BGPSEC_ALGORITHM_SUITE_1))
+	assert.Nil(p2.SignatureBlock(2))
+
+	// Secure_Path length which is not a multiple of the segment length
+	buf[4] = 0x0d
+	assert.Error((&PathAttributeBGPsecPath{}).DecodeFromBytes(buf))
+}
+
+func Test_BGPsecSignedData(t *testing.T) {
+	assert := assert.New(t)
+	p := testBGPsecPath()
+	block := p.SignatureBlock(BGPSEC_ALGORITHM_SUITE_1)
+	nlri, _ := NewIPAddrPrefix(netip.MustParsePrefix("10.0.0.0/8"))
+
+	data, err := p.SignedData(block, 0, 65003, RF_IPv4_UC, nlri)
+	require.NoError(t, err)
+	expected := []byte{
+		0x00, 0x00, 0xfd, 0xeb, // target AS 65003
+		0x02, 0x00, 0x00, 0x00, 0xfd, 0xea, // Secure_Path segment of 65002
+	}
+	expected = append(expected, block.Segments[1].serialize()...) // signature of 65001
+	expected = append(expected,
+		0x01, 0x00, 0x00, 0x00, 0xfd, 0xe9, // Secure_Path segment of 65001
+		0x01,             // algorithm suite
+		0x00, 0x01, 0x01, // AFI, SAFI
+		0x08, 0x0a, // NLRI
+	)
+	assert.Equal(expected, data)
+
+	data, err = p.SignedData(block, 1, 65002, RF_IPv4_UC, nlri)
+	require.NoError(t, err)
+	assert.Equal([]byte{
+		0x00, 0x00, 0xfd, 0xea,
+		0x01, 0x00, 0x00, 0x00, 0xfd, 0xe9,
+		0x01, 0x00, 0x01, 0x01, 0x08, 0x0a,
+	}, data)
+
+	_, err = p.SignedData(block, 2, 65002, RF_IPv4_UC, nlri)
+	assert.Error(err)
+}
+
+func Test_ValidateBGPsecUpdate(t *testing.T) {
+	assert := assert.New(t)
+	nlri, _ := NewIPAddrPrefix(netip.MustParsePrefix("10.0.0.0/8"))
+	mp, _ := NewPathAttributeMpReachNLRI(RF_IPv4_UC, []PathNLRI{{NLRI: nlri}}, netip.MustParseAddr("192.0.2.1"))
+	rfs := map[Family]BGPAddPathMode{RF_IPv4_UC: BGP_ADD_PATH_NONE}
+
+	msg := NewBGPUpdateMessage(nil, []PathAttributeInterface{NewPathAttributeOrigin(0), mp, testBGPsecPath()}, nil)
+	buf, err := msg.Serialize()
+	require.NoError(t, err)
+	msg, err = ParseBGPMessage(buf)
+	require.NoError(t, err)
+	ok, err := ValidateUpdateMsg(msg.Body.(*BGPUpdate), rfs, true, false, false)
+	assert.True(ok)
+	assert.NoError(err)
+
+	// BGPsec_PATH together with AS_PATH
+	aspath := NewPathAttributeAsPath([]AsPathParamInterface{NewAs4PathParam(BGP_ASPATH_ATTR_TYPE_SEQ, []uint32{65002})})
+	msg = NewBGPUpdateMessage(nil, []PathAttributeInterface{NewPathAttributeOrigin(0), aspath, mp, testBGPsecPath()}, nil)
+	ok, err = ValidateUpdateMsg(msg.Body.(*BGPUpdate), rfs, true, false, false)
+	assert.False(ok)
+	assert.Equal(ERROR_HANDLING_TREAT_AS_WITHDRAW, err.(*MessageError).ErrorHandling)
+
+	// missing signature segment
+	p := testBGPsecPath()
+	p.SignatureBlocks[0].Segments = p.SignatureBlocks[0].Segments[:1]
+	msg = NewBGPUpdateMessage(nil, []PathAttributeInterface{NewPathAttributeOrigin(0), mp, p}, nil)
+	ok, err = ValidateUpdateMsg(msg.Body.(*BGPUpdate), rfs, true, false, false)
+	assert.False(ok)
+	assert.Equal(ERROR_HANDLING_TREAT_AS_WITHDRAW, err.(*MessageError).ErrorHandling)
+}
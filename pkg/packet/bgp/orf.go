@@ -0,0 +1,433 @@
+package bgp
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net/netip"
+)
+
+// ORFType is the type of an Outbound Route Filter, as defined in [RFC 5291].
+//
+// [RFC 5291]: https://www.rfc-editor.org/rfc/rfc5291.html
+type ORFType uint8
+
+const (
+	// ORF_TYPE_ADDRESS_PREFIX is the Address Prefix ORF type defined in
+	// [RFC 5292, section 2].
+	//
+	// [RFC 5292, section 2]: https://www.rfc-editor.org/rfc/rfc5292.html#section-2
+	ORF_TYPE_ADDRESS_PREFIX ORFType = 64
+)
+
+func (t ORFType) String() string {
+	switch t {
+	case ORF_TYPE_ADDRESS_PREFIX:
+		return "address-prefix"
+	default:
+		return fmt.Sprintf("UnknownORFType(%d)", t)
+	}
+}
+
+// ORFSendReceive tells whether a speaker is willing to send and/or receive
+// ORF entries of a given type for an address family.
+type ORFSendReceive uint8
+
+const (
+	ORF_RECEIVE ORFSendReceive = 1
+	ORF_SEND    ORFSendReceive = 2
+	ORF_BOTH    ORFSendReceive = 3
+)
+
+func (m ORFSendReceive) String() string {
+	switch m {
+	case ORF_RECEIVE:
+		return "receive"
+	case ORF_SEND:
+		return "send"
+	case ORF_BOTH:
+		return "receive/send"
+	default:
+		return fmt.Sprintf("unknown(%d)", m)
+	}
+}
+
+// ORFWhenToRefresh is the "When-to-refresh" field of a ROUTE-REFRESH message
+// carrying ORF entries.
+type ORFWhenToRefresh uint8
+
+const (
+	ORF_WHEN_TO_REFRESH_IMMEDIATE ORFWhenToRefresh = 1
+	ORF_WHEN_TO_REFRESH_DEFER     ORFWhenToRefresh = 2
+)
+
+func (w ORFWhenToRefresh) String() string {
+	switch w {
+	case ORF_WHEN_TO_REFRESH_IMMEDIATE:
+		return "immediate"
+	case ORF_WHEN_TO_REFRESH_DEFER:
+		return "defer"
+	default:
+		return fmt.Sprintf("unknown(%d)", w)
+	}
+}
+
+// ORFAction is the action carried in the common part of an ORF entry.
+type ORFAction uint8
+
+const (
+	ORF_ACTION_ADD        ORFAction = 0
+	ORF_ACTION_REMOVE     ORFAction = 1
+	ORF_ACTION_REMOVE_ALL ORFAction = 2
+)
+
+func (a ORFAction) String() string {
+	switch a {
+	case ORF_ACTION_ADD:
+		return "add"
+	case ORF_ACTION_REMOVE:
+		return "remove"
+	case ORF_ACTION_REMOVE_ALL:
+		return "remove-all"
+	default:
+		return fmt.Sprintf("unknown(%d)", a)
+	}
+}
+
+// ORFMatch is the match field carried in the common part of an ORF entry.
+type ORFMatch uint8
+
+const (
+	ORF_MATCH_PERMIT ORFMatch = 0
+	ORF_MATCH_DENY   ORFMatch = 1
+)
+
+func (m ORFMatch) String() string {
+	switch m {
+	case ORF_MATCH_PERMIT:
+		return "permit"
+	case ORF_MATCH_DENY:
+		return "deny"
+	default:
+		return fmt.Sprintf("unknown(%d)", m)
+	}
+}
+
+type CapORFEntry struct {
+	Type ORFType
+	Mode ORFSendReceive
+}
+
+func (e *CapORFEntry) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type uint8 `json:"type"`
+		Mode uint8 `json:"mode"`
+	}{
+		Type: uint8(e.Type),
+		Mode: uint8(e.Mode),
+	})
+}
+
+type CapORFTuple struct {
+	Family  Family
+	Entries []*CapORFEntry
+}
+
+func (t *CapORFTuple) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Family  Family         `json:"family"`
+		Entries []*CapORFEntry `json:"entries"`
+	}{
+		Family:  t.Family,
+		Entries: t.Entries,
+	})
+}
+
+func NewCapORFTuple(family Family, entries []*CapORFEntry) *CapORFTuple {
+	return &CapORFTuple{
+		Family:  family,
+		Entries: entries,
+	}
+}
+
+// Mode returns the send/receive mode advertised for the given ORF type, or
+// zero if the type is not listed in the tuple.
+func (t *CapORFTuple) Mode(typ ORFType) ORFSendReceive {
+	for _, e := range t.Entries {
+		if e.Type == typ {
+			return e.Mode
+		}
+	}
+	return 0
+}
+
+// CapOutboundRouteFiltering represents the Outbound Route Filtering
+// Capability, as defined in [RFC 5291, section 4].
+//
+// [RFC 5291, section 4]: https://www.rfc-editor.org/rfc/rfc5291.html#section-4
+type CapOutboundRouteFiltering struct {
+	DefaultParameterCapability
+	Tuples []*CapORFTuple
+}
+
+func (c *CapOutboundRouteFiltering) DecodeFromBytes(data []byte) error {
+	if err := c.DefaultParameterCapability.DecodeFromBytes(data); err != nil {
+		return err
+	}
+	data = data[2 : 2+int(c.CapLen)]
+	c.Tuples = []*CapORFTuple{}
+	for len(data) > 0 {
+		if len(data) < 5 {
+			return NewMessageError(BGP_ERROR_OPEN_MESSAGE_ERROR, BGP_ERROR_SUB_UNSUPPORTED_CAPABILITY, nil, "Not all CapabilityOutboundRouteFiltering bytes available")
+		}
+		t := &CapORFTuple{
+			Family: NewFamily(binary.BigEndian.Uint16(data[:2]), data[3]),
+		}
+		num := int(data[4])
+		data = data[5:]
+		if len(data) < num*2 {
+			return NewMessageError(BGP_ERROR_OPEN_MESSAGE_ERROR, BGP_ERROR_SUB_UNSUPPORTED_CAPABILITY, nil, "Not all CapabilityOutboundRouteFiltering bytes available")
+		}
+		t.Entries = make([]*CapORFEntry, 0, num)
+		for i := 0; i < num; i++ {
+			t.Entries = append(t.Entries, &CapORFEntry{
+				Type: ORFType(data[0]),
+				Mode: ORFSendReceive(data[1]),
+			})
+			data = data[2:]
+		}
+		c.Tuples = append(c.Tuples, t)
+	}
+	return nil
+}
+
+func (c *CapOutboundRouteFiltering) Serialize() ([]byte, error) {
+	buf := make([]byte, 0, len(c.Tuples)*7)
+	for _, t := range c.Tuples {
+		var tbuf [5]byte
+		binary.BigEndian.PutUint16(tbuf[:2], t.Family.Afi())
+		tbuf[3] = t.Family.Safi()
+		tbuf[4] = uint8(len(t.Entries))
+		buf = append(buf, tbuf[:]...)
+		for _, e := range t.Entries {
+			buf = append(buf, uint8(e.Type), uint8(e.Mode))
+		}
+	}
+	c.CapValue = buf
+	return c.DefaultParameterCapability.Serialize()
+}
+
+func (c *CapOutboundRouteFiltering) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Code   BGPCapabilityCode `json:"code"`
+		Tuples []*CapORFTuple    `json:"tuples"`
+	}{
+		Code:   c.Code(),
+		Tuples: c.Tuples,
+	})
+}
+
+func NewCapOutboundRouteFiltering(tuples []*CapORFTuple) *CapOutboundRouteFiltering {
+	return &CapOutboundRouteFiltering{
+		DefaultParameterCapability: DefaultParameterCapability{
+			CapCode: BGP_CAP_OUTBOUND_ROUTE_FILTERING,
+		},
+		Tuples: tuples,
+	}
+}
+
+// AddressPrefixORFEntry is an Address Prefix ORF entry, as defined in
+// [RFC 5292, section 2]. Only Action is meaningful when it is
+// ORF_ACTION_REMOVE_ALL.
+//
+// MinLen and MaxLen follow the "ge" and "le" semantics of the RFC: zero means
+// the bound is not specified.
+//
+// [RFC 5292, section 2]: https://www.rfc-editor.org/rfc/rfc5292.html#section-2
+type AddressPrefixORFEntry struct {
+	Action   ORFAction
+	Match    ORFMatch
+	Sequence uint32
+	MinLen   uint8
+	MaxLen   uint8
+	Prefix   netip.Prefix
+}
+
+func (e *AddressPrefixORFEntry) decodeFromBytes(data []byte, afi uint16) (int, error) {
+	if len(data) < 1 {
+		return 0, NewMessageError(BGP_ERROR_ROUTE_REFRESH_MESSAGE_ERROR, BGP_ERROR_SUB_INVALID_MESSAGE_LENGTH, nil, "Not all AddressPrefixORFEntry bytes available")
+	}
+	e.Action = ORFAction(data[0] >> 6)
+	e.Match = ORFMatch((data[0] >> 5) & 0x1)
+	if e.Action == ORF_ACTION_REMOVE_ALL {
+		return 1, nil
+	}
+	if len(data) < 8 {
+		return 0, NewMessageError(BGP_ERROR_ROUTE_REFRESH_MESSAGE_ERROR, BGP_ERROR_SUB_INVALID_MESSAGE_LENGTH, nil, "Not all AddressPrefixORFEntry bytes available")
+	}
+	e.Sequence = binary.BigEndian.Uint32(data[1:5])
+	e.MinLen = data[5]
+	e.MaxLen = data[6]
+	bitlen := int(data[7])
+	addrlen := 4
+	if afi == AFI_IP6 {
+		addrlen = 16
+	}
+	bytelen := (bitlen + 7) / 8
+	if bitlen > addrlen*8 || len(data) < 8+bytelen {
+		return 0, NewMessageError(BGP_ERROR_ROUTE_REFRESH_MESSAGE_ERROR, BGP_ERROR_SUB_INVALID_MESSAGE_LENGTH, nil, "invalid AddressPrefixORFEntry prefix")
+	}
+	var b [16]byte
+	copy(b[:], data[8:8+bytelen])
+	addr, _ := netip.AddrFromSlice(b[:addrlen])
+	e.Prefix = netip.PrefixFrom(addr, bitlen).Masked()
+	return 8 + bytelen, nil
+}
+
+func (e *AddressPrefixORFEntry) serialize() []byte {
+	common := uint8(e.Action)<<6 | uint8(e.Match&0x1)<<5
+	if e.Action == ORF_ACTION_REMOVE_ALL {
+		return []byte{common}
+	}
+	bytelen := (e.Prefix.Bits() + 7) / 8
+	buf := make([]byte, 8+bytelen)
+	buf[0] = common
+	binary.BigEndian.PutUint32(buf[1:5], e.Sequence)
+	buf[5] = e.MinLen
+	buf[6] = e.MaxLen
+	buf[7] = uint8(e.Prefix.Bits())
+	copy(buf[8:], e.Prefix.Addr().AsSlice()[:bytelen])
+	return buf
+}
+
+// Matches reports whether the given prefix is covered by the entry, taking
+// the "ge" (MinLen) and "le" (MaxLen) bounds into account.
+func (e *AddressPrefixORFEntry) Matches(prefix netip.Prefix) bool {
+	if !e.Prefix.IsValid() || e.Prefix.Addr().Is4() != prefix.Addr().Is4() {
+		return false
+	}
+	if prefix.Bits() < e.Prefix.Bits() || !e.Prefix.Contains(prefix.Addr()) {
+		return false
+	}
+	minLen, maxLen := e.Prefix.Bits(), e.Prefix.Bits()
+	switch {
+	case e.MinLen != 0 && e.MaxLen != 0:
+		minLen, maxLen = int(e.MinLen), int(e.MaxLen)
+	case e.MinLen != 0:
+		minLen, maxLen = int(e.MinLen), e.Prefix.Addr().BitLen()
+	case e.MaxLen != 0:
+		maxLen = int(e.MaxLen)
+	}
+	return prefix.Bits() >= minLen && prefix.Bits() <= maxLen
+}
+
+func (e *AddressPrefixORFEntry) String() string {
+	if e.Action == ORF_ACTION_REMOVE_ALL {
+		return e.Action.String()
+	}
+	return fmt.Sprintf("%s seq %d %s %s ge %d le %d", e.Action, e.Sequence, e.Match, e.Prefix, e.MinLen, e.MaxLen)
+}
+
+func (e *AddressPrefixORFEntry) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Action   uint8  `json:"action"`
+		Match    uint8  `json:"match"`
+		Sequence uint32 `json:"sequence"`
+		MinLen   uint8  `json:"min_len"`
+		MaxLen   uint8  `json:"max_len"`
+		Prefix   string `json:"prefix"`
+	}{
+		Action:   uint8(e.Action),
+		Match:    uint8(e.Match),
+		Sequence: e.Sequence,
+		MinLen:   e.MinLen,
+		MaxLen:   e.MaxLen,
+		Prefix:   e.Prefix.String(),
+	})
+}
+
+func NewAddressPrefixORFEntry(action ORFAction, match ORFMatch, seq uint32, minLen, maxLen uint8, prefix netip.Prefix) *AddressPrefixORFEntry {
+	return &AddressPrefixORFEntry{
+		Action:   action,
+		Match:    match,
+		Sequence: seq,
+		MinLen:   minLen,
+		MaxLen:   maxLen,
+		Prefix:   prefix.Masked(),
+	}
+}
+
+// RouteRefreshORF is a group of ORF entries of the same type carried in a
+// ROUTE-REFRESH message. Entries of types other than ORF_TYPE_ADDRESS_PREFIX
+// are kept as raw bytes in Value.
+type RouteRefreshORF struct {
+	Type    ORFType
+	Entries []*AddressPrefixORFEntry
+	Value   []byte
+}
+
+func (o *RouteRefreshORF) decodeFromBytes(data []byte, afi uint16) (int, error) {
+	if len(data) < 3 {
+		return 0, NewMessageError(BGP_ERROR_ROUTE_REFRESH_MESSAGE_ERROR, BGP_ERROR_SUB_INVALID_MESSAGE_LENGTH, nil, "Not all RouteRefreshORF bytes available")
+	}
+	o.Type = ORFType(data[0])
+	length := int(binary.BigEndian.Uint16(data[1:3]))
+	if len(data) < 3+length {
+		return 0, NewMessageError(BGP_ERROR_ROUTE_REFRESH_MESSAGE_ERROR, BGP_ERROR_SUB_INVALID_MESSAGE_LENGTH, nil, "Not all RouteRefreshORF bytes available")
+	}
+	value := data[3 : 3+length]
+	if o.Type != ORF_TYPE_ADDRESS_PREFIX {
+		o.Value = value
+		return 3 + length, nil
+	}
+	for len(value) > 0 {
+		e := &AddressPrefixORFEntry{}
+		n, err := e.decodeFromBytes(value, afi)
+		if err != nil {
+			return 0, err
+		}
+		o.Entries = append(o.Entries, e)
+		value = value[n:]
+	}
+	return 3 + length, nil
+}
+
+func (o *RouteRefreshORF) serialize() ([]byte, error) {
+	value := o.Value
+	if o.Type == ORF_TYPE_ADDRESS_PREFIX {
+		value = make([]byte, 0, len(o.Entries)*8)
+		for _, e := range o.Entries {
+			value = append(value, e.serialize()...)
+		}
+	}
+	if len(value) > 0xffff {
+		return nil, fmt.Errorf("too many ORF entries: %d bytes", len(value))
+	}
+	buf := make([]byte, 3, 3+len(value))
+	buf[0] = uint8(o.Type)
+	binary.BigEndian.PutUint16(buf[1:3], uint16(len(value)))
+	return append(buf, value...), nil
+}
+
+func NewRouteRefreshAddressPrefixORF(entries []*AddressPrefixORFEntry) *RouteRefreshORF {
+	return &RouteRefreshORF{
+		Type:    ORF_TYPE_ADDRESS_PREFIX,
+		Entries: entries,
+	}
+}
+
+// NewBGPRouteRefreshORFMessage returns a ROUTE-REFRESH message carrying the
+// given ORF entries, as defined in [RFC 5291, section 5].
+//
+// [RFC 5291, section 5]: https://www.rfc-editor.org/rfc/rfc5291.html#section-5
+func NewBGPRouteRefreshORFMessage(family Family, when ORFWhenToRefresh, orfs []*RouteRefreshORF) *BGPMessage {
+	return &BGPMessage{
+		Header: BGPHeader{Type: BGP_MSG_ROUTE_REFRESH},
+		Body: &BGPRouteRefresh{
+			AFI:           family.Afi(),
+			SAFI:          family.Safi(),
+			WhenToRefresh: when,
+			ORFs:          orfs,
+		},
+	}
+}
@@ -0,0 +1,89 @@
+package bgp
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CapOutboundRouteFiltering(t *testing.T) {
+	assert := assert.New(t)
+	c1 := NewCapOutboundRouteFiltering([]*CapORFTuple{
+		NewCapORFTuple(RF_IPv4_UC, []*CapORFEntry{{Type: ORF_TYPE_ADDRESS_PREFIX, Mode: ORF_BOTH}}),
+		NewCapORFTuple(RF_IPv6_UC, []*CapORFEntry{{Type: ORF_TYPE_ADDRESS_PREFIX, Mode: ORF_SEND}}),
+	})
+	buf, err := c1.Serialize()
+	require.NoError(t, err)
+	assert.Equal([]byte{
+		0x03, 0x0e, // code 3, length 14
+		0x00, 0x01, 0x00, 0x01, 0x01, 0x40, 0x03, // ipv4-unicast, address-prefix both
+		0x00, 0x02, 0x00, 0x01, 0x01, 0x40, 0x02, // ipv6-unicast, address-prefix send
+	}, buf)
+
+	c2, err := DecodeCapability(buf)
+	require.NoError(t, err)
+	assert.Equal(c1, c2)
+	assert.Equal(ORF_SEND, c2.(*CapOutboundRouteFiltering).Tuples[1].Mode(ORF_TYPE_ADDRESS_PREFIX))
+
+	_, err = DecodeCapability([]byte{0x03, 0x06, 0x00, 0x01, 0x00, 0x01, 0x01, 0x40})
+	assert.Error(err)
+}
+
+func Test_RouteRefreshORF(t *testing.T) {
+	assert := assert.New(t)
+	entries := []*AddressPrefixORFEntry{
+		NewAddressPrefixORFEntry(ORF_ACTION_REMOVE_ALL, ORF_MATCH_PERMIT, 0, 0, 0, netip.Prefix{}),
+		NewAddressPrefixORFEntry(ORF_ACTION_ADD, ORF_MATCH_PERMIT, 10, 24, 32, netip.MustParsePrefix("10.0.0.0/8")),
+		NewAddressPrefixORFEntry(ORF_ACTION_ADD, ORF_MATCH_DENY, 20, 0, 0, netip.MustParsePrefix("0.0.0.0/0")),
+	}
+	m1 := NewBGPRouteRefreshORFMessage(RF_IPv4_UC, ORF_WHEN_TO_REFRESH_IMMEDIATE, []*RouteRefreshORF{NewRouteRefreshAddressPrefixORF(entries)})
+	buf, err := m1.Serialize()
+	require.NoError(t, err)
+
+	m2, err := ParseBGPMessage(buf)
+	require.NoError(t, err)
+	rr := m2.Body.(*BGPRouteRefresh)
+	assert.Equal(ORF_WHEN_TO_REFRESH_IMMEDIATE, rr.WhenToRefresh)
+	require.Len(t, rr.ORFs, 1)
+	assert.Equal(ORF_TYPE_ADDRESS_PREFIX, rr.ORFs[0].Type)
+	require.Len(t, rr.ORFs[0].Entries, 3)
+	assert.Equal(ORF_ACTION_REMOVE_ALL, rr.ORFs[0].Entries[0].Action)
+	assert.Equal(entries[1], rr.ORFs[0].Entries[1])
+	assert.Equal(entries[2], rr.ORFs[0].Entries[2])
+
+	// plain route refresh stays 4 bytes long
+	buf, err = NewBGPRouteRefreshMessage(AFI_IP6, 0, SAFI_UNICAST).Body.Serialize()
+	require.NoError(t, err)
+	assert.Len(buf, 4)
+
+	// truncated entry
+	err = (&BGPRouteRefresh{}).DecodeFromBytes([]byte{0x00, 0x01, 0x00, 0x01, 0x01, 0x40, 0x00, 0x03, 0x00, 0x00, 0x00})
+	assert.Error(err)
+}
+
+func Test_AddressPrefixORFEntryMatches(t *testing.T) {
+	tests := []struct {
+		name   string
+		entry  *AddressPrefixORFEntry
+		prefix string
+		want   bool
+	}{
+		{"exact", NewAddressPrefixORFEntry(ORF_ACTION_ADD, ORF_MATCH_PERMIT, 1, 0, 0, netip.MustParsePrefix("10.0.0.0/8")), "10.0.0.0/8", true},
+		{"exact longer", NewAddressPrefixORFEntry(ORF_ACTION_ADD, ORF_MATCH_PERMIT, 1, 0, 0, netip.MustParsePrefix("10.0.0.0/8")), "10.1.0.0/16", false},
+		{"ge", NewAddressPrefixORFEntry(ORF_ACTION_ADD, ORF_MATCH_PERMIT, 1, 16, 0, netip.MustParsePrefix("10.0.0.0/8")), "10.1.1.0/24", true},
+		{"ge shorter", NewAddressPrefixORFEntry(ORF_ACTION_ADD, ORF_MATCH_PERMIT, 1, 16, 0, netip.MustParsePrefix("10.0.0.0/8")), "10.0.0.0/8", false},
+		{"le", NewAddressPrefixORFEntry(ORF_ACTION_ADD, ORF_MATCH_PERMIT, 1, 0, 16, netip.MustParsePrefix("10.0.0.0/8")), "10.1.0.0/16", true},
+		{"le longer", NewAddressPrefixORFEntry(ORF_ACTION_ADD, ORF_MATCH_PERMIT, 1, 0, 16, netip.MustParsePrefix("10.0.0.0/8")), "10.1.1.0/24", false},
+		{"ge le", NewAddressPrefixORFEntry(ORF_ACTION_ADD, ORF_MATCH_PERMIT, 1, 20, 24, netip.MustParsePrefix("10.0.0.0/8")), "10.1.1.0/24", true},
+		{"not covered", NewAddressPrefixORFEntry(ORF_ACTION_ADD, ORF_MATCH_PERMIT, 1, 0, 32, netip.MustParsePrefix("10.0.0.0/8")), "11.0.0.0/8", false},
+		{"other family", NewAddressPrefixORFEntry(ORF_ACTION_ADD, ORF_MATCH_PERMIT, 1, 0, 128, netip.MustParsePrefix("::/0")), "10.0.0.0/8", false},
+		{"ipv6", NewAddressPrefixORFEntry(ORF_ACTION_ADD, ORF_MATCH_PERMIT, 1, 48, 0, netip.MustParsePrefix("2001:db8::/32")), "2001:db8:1::/48", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.entry.Matches(netip.MustParsePrefix(tt.prefix)))
+		})
+	}
+}
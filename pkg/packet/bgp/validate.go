@@ -66,6 +66,18 @@ func ValidateUpdateMsg(m *BGPUpdate, rfs map[Family]BGPAddPathMode, isEBGP bool,
 			return true, 0
 		}
 		mandatory := []BGPAttrType{BGP_ATTR_TYPE_ORIGIN, BGP_ATTR_TYPE_AS_PATH}
+		if _, ok := seen[BGP_ATTR_TYPE_BGPSEC_PATH]; ok {
+			// RFC 8205 5.2: the BGPsec_PATH attribute replaces AS_PATH,
+			// and must come along with MP_REACH_NLRI.
+			mandatory = []BGPAttrType{BGP_ATTR_TYPE_ORIGIN}
+			if _, ok := seen[BGP_ATTR_TYPE_AS_PATH]; ok || len(m.NLRI) > 0 {
+				eMsg := "BGPsec_PATH attribute must not come with AS_PATH attribute or NLRI field"
+				e := NewMessageErrorWithErrorHandling(eCode, eSubCodeAttrList, nil, ERROR_HANDLING_TREAT_AS_WITHDRAW, nil, eMsg)
+				if e.(*MessageError).Stronger(strongestError) {
+					strongestError = e
+				}
+			}
+		}
 		if len(m.NLRI) > 0 {
 			mandatory = append(mandatory, BGP_ATTR_TYPE_NEXT_HOP)
 		}
@@ -177,6 +189,19 @@ func ValidateAttribute(a PathAttributeInterface, rfs map[Family]BGPAddPathMode,
 				}
 			}
 		}
+	case *PathAttributeBGPsecPath:
+		// RFC 8205 5.2: each Signature_Block must have as many segments as
+		// the Secure_Path.
+		for _, b := range p.SignatureBlocks {
+			if len(b.Segments) != len(p.SecurePath) {
+				eMsg := "the number of Signature_Segments doesn't match the Secure_Path"
+				e := NewMessageErrorWithErrorHandling(eCode, uint8(BGP_ERROR_SUB_MALFORMED_ATTRIBUTE_LIST), nil, getErrorHandlingFromPathAttribute(p.GetType()), nil, eMsg)
+				if e.(*MessageError).Stronger(strongestError) {
+					strongestError = e
+				}
+				break
+			}
+		}
 	case *PathAttributeLargeCommunities:
 		uniq := make([]*LargeCommunity, 0, len(p.Values))
 		for _, x := range p.Values {
@@ -55,6 +55,12 @@ const (
 	BMP_PEER_FLAG_ADJ_RIB_TYP = 1 << 4
 )
 
+// RFC 9069 defines its own flags for the Loc-RIB instance peer type; the
+// F flag takes the place of the V flag of the other peer types.
+const (
+	BMP_PEER_FLAG_LOC_RIB_FILTERED = 1 << 7
+)
+
 func (h *BMPHeader) DecodeFromBytes(data []byte) error {
 	if len(data) < BMP_HEADER_SIZE {
 		return errors.New("invalid BMP header length")
@@ -88,7 +94,7 @@ type BMPPeerHeader struct {
 
 func NewBMPPeerHeader(t uint8, flags uint8, dist uint64, address netip.Addr, as uint32, id netip.Addr, stamp float64) *BMPPeerHeader {
 	// TODO: check id is v4
-	if address.Is6() {
+	if address.Is6() && t != BMP_PEER_TYPE_LOCAL_RIB {
 		flags |= BMP_PEER_FLAG_IPV6
 	}
 	h := &BMPPeerHeader{
@@ -115,6 +121,16 @@ func (h *BMPPeerHeader) IsAdjRIBOut() bool {
 	return h.Flags&BMP_PEER_FLAG_ADJ_RIB_TYP != 0
 }
 
+// IsLocRIBFiltered returns true if the F flag of a Loc-RIB instance peer
+// header is set (RFC 9069).
+func (h *BMPPeerHeader) IsLocRIBFiltered() bool {
+	return h.PeerType == BMP_PEER_TYPE_LOCAL_RIB && h.Flags&BMP_PEER_FLAG_LOC_RIB_FILTERED != 0
+}
+
+func (h *BMPPeerHeader) isIPv6() bool {
+	return h.PeerType != BMP_PEER_TYPE_LOCAL_RIB && h.Flags&BMP_PEER_FLAG_IPV6 != 0
+}
+
 func (h *BMPPeerHeader) DecodeFromBytes(data []byte) error {
 	if len(data) < BMP_PEER_HEADER_SIZE {
 		return errors.New("invalid BMP Peer header length")
@@ -122,7 +138,7 @@ func (h *BMPPeerHeader) DecodeFromBytes(data []byte) error {
 	h.PeerType = data[0]
 	h.Flags = data[1]
 	h.PeerDistinguisher = binary.BigEndian.Uint64(data[2:10])
-	if h.Flags&BMP_PEER_FLAG_IPV6 != 0 {
+	if h.isIPv6() {
 		h.PeerAddress, _ = netip.AddrFromSlice(data[10:26])
 	} else {
 		h.PeerAddress, _ = netip.AddrFromSlice(data[22:26])
@@ -141,7 +157,7 @@ func (h *BMPPeerHeader) Serialize() ([]byte, error) {
 	buf[0] = h.PeerType
 	buf[1] = h.Flags
 	binary.BigEndian.PutUint64(buf[2:10], h.PeerDistinguisher)
-	if h.Flags&BMP_PEER_FLAG_IPV6 != 0 {
+	if h.isIPv6() {
 		copy(buf[10:26], h.PeerAddress.AsSlice())
 	} else {
 		copy(buf[22:26], h.PeerAddress.AsSlice())
@@ -405,6 +421,7 @@ const (
 	BMP_PEER_DOWN_REASON_REMOTE_BGP_NOTIFICATION
 	BMP_PEER_DOWN_REASON_REMOTE_NO_NOTIFICATION
 	BMP_PEER_DOWN_REASON_PEER_DE_CONFIGURED
+	BMP_PEER_DOWN_REASON_LOCAL_TLV_DATA
 )
 
 type BMPPeerDownNotification struct {
@@ -420,7 +437,7 @@ func NewBMPPeerDownNotification(p BMPPeerHeader, reason uint8, notification *bgp
 	switch reason {
 	case BMP_PEER_DOWN_REASON_LOCAL_BGP_NOTIFICATION, BMP_PEER_DOWN_REASON_REMOTE_BGP_NOTIFICATION:
 		b.BGPNotification = notification
-	case BMP_PEER_DOWN_REASON_LOCAL_NO_NOTIFICATION:
+	case BMP_PEER_DOWN_REASON_LOCAL_NO_NOTIFICATION, BMP_PEER_DOWN_REASON_LOCAL_TLV_DATA:
 		b.Data = data
 	default:
 	}
@@ -476,15 +493,17 @@ type BMPPeerUpNotification struct {
 	RemotePort      uint16
 	SentOpenMsg     *bgp.BGPMessage
 	ReceivedOpenMsg *bgp.BGPMessage
+	Info            []BMPInfoTLVInterface
 }
 
-func NewBMPPeerUpNotification(p BMPPeerHeader, lAddr netip.Addr, lPort, rPort uint16, sent, recv *bgp.BGPMessage) *BMPMessage {
+func NewBMPPeerUpNotification(p BMPPeerHeader, lAddr netip.Addr, lPort, rPort uint16, sent, recv *bgp.BGPMessage, info ...BMPInfoTLVInterface) *BMPMessage {
 	b := &BMPPeerUpNotification{
 		LocalAddress:    lAddr,
 		LocalPort:       lPort,
 		RemotePort:      rPort,
 		SentOpenMsg:     sent,
 		ReceivedOpenMsg: recv,
+		Info:            info,
 	}
 	return &BMPMessage{
 		Header: BMPHeader{
@@ -497,7 +516,10 @@ func NewBMPPeerUpNotification(p BMPPeerHeader, lAddr netip.Addr, lPort, rPort ui
 }
 
 func (body *BMPPeerUpNotification) ParseBody(msg *BMPMessage, data []byte, options ...*bgp.MarshallingOption) error {
-	if msg.PeerHeader.Flags&BMP_PEER_FLAG_IPV6 != 0 {
+	if len(data) < 20 {
+		return errors.New("invalid BMP Peer Up notification length")
+	}
+	if msg.PeerHeader.isIPv6() {
 		body.LocalAddress, _ = netip.AddrFromSlice(data[:16])
 	} else {
 		body.LocalAddress, _ = netip.AddrFromSlice(data[12:16])
@@ -517,7 +539,9 @@ func (body *BMPPeerUpNotification) ParseBody(msg *BMPMessage, data []byte, optio
 	if err != nil {
 		return err
 	}
-	return nil
+	data = data[body.ReceivedOpenMsg.Header.Len:]
+	body.Info, err = parseInfoTLVs(data)
+	return err
 }
 
 func (body *BMPPeerUpNotification) Serialize(options ...*bgp.MarshallingOption) ([]byte, error) {
@@ -535,6 +559,13 @@ func (body *BMPPeerUpNotification) Serialize(options ...*bgp.MarshallingOption)
 	buf = append(buf, m...)
 	m, _ = body.ReceivedOpenMsg.Serialize(options...)
 	buf = append(buf, m...)
+	for _, tlv := range body.Info {
+		b, err := tlv.Serialize()
+		if err != nil {
+			return nil, err
+		}
+		buf = append(buf, b...)
+	}
 	return buf, nil
 }
 
@@ -544,6 +575,13 @@ const (
 	BMP_INIT_TLV_TYPE_SYS_NAME
 )
 
+// Peer Up information TLV types share the registry with the initiation
+// TLVs; RFC 9069 adds the VRF/Table Name TLV.
+const (
+	BMP_PEER_UP_TLV_TYPE_STRING         = BMP_INIT_TLV_TYPE_STRING
+	BMP_PEER_UP_TLV_TYPE_VRF_TABLE_NAME = 3
+)
+
 type BMPInfoTLVInterface interface {
 	ParseValue([]byte) error
 	Serialize() ([]byte, error)
@@ -623,6 +661,13 @@ func NewBMPInitiation(info []BMPInfoTLVInterface) *BMPMessage {
 }
 
 func (body *BMPInitiation) ParseBody(msg *BMPMessage, data []byte, options ...*bgp.MarshallingOption) error {
+	info, err := parseInfoTLVs(data)
+	body.Info = append(body.Info, info...)
+	return err
+}
+
+func parseInfoTLVs(data []byte) ([]BMPInfoTLVInterface, error) {
+	var info []BMPInfoTLVInterface
 	for len(data) >= 4 {
 		tl := BMPInfoTLV{
 			Type:   binary.BigEndian.Uint16(data[:2]),
@@ -630,22 +675,22 @@ func (body *BMPInitiation) ParseBody(msg *BMPMessage, data []byte, options ...*b
 		}
 		data = data[4:]
 		if len(data) < int(tl.Length) {
-			return fmt.Errorf("value length is not enough: %d bytes (%d bytes expected)", len(data), tl.Length)
+			return info, fmt.Errorf("value length is not enough: %d bytes (%d bytes expected)", len(data), tl.Length)
 		}
 		var tlv BMPInfoTLVInterface
 		switch tl.Type {
-		case BMP_INIT_TLV_TYPE_STRING, BMP_INIT_TLV_TYPE_SYS_DESCR, BMP_INIT_TLV_TYPE_SYS_NAME:
+		case BMP_INIT_TLV_TYPE_STRING, BMP_INIT_TLV_TYPE_SYS_DESCR, BMP_INIT_TLV_TYPE_SYS_NAME, BMP_PEER_UP_TLV_TYPE_VRF_TABLE_NAME:
 			tlv = &BMPInfoTLVString{BMPInfoTLV: tl}
 		default:
 			tlv = &BMPInfoTLVUnknown{BMPInfoTLV: tl}
 		}
 		if err := tlv.ParseValue(data); err != nil {
-			return err
+			return info, err
 		}
-		body.Info = append(body.Info, tlv)
+		info = append(info, tlv)
 		data = data[tl.Length:]
 	}
-	return nil
+	return info, nil
 }
 
 func (body *BMPInitiation) Serialize(options ...*bgp.MarshallingOption) ([]byte, error) {
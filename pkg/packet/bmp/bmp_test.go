@@ -57,6 +57,24 @@ func Test_PeerUpNotification(t *testing.T) {
 	verify(t, NewBMPPeerUpNotification(*p0, netip.MustParseAddr("10.0.0.3"), 10, 100, m, m))
 	p1 := NewBMPPeerHeader(0, 0, 1000, netip.MustParseAddr("fe80::6e40:8ff:feab:2c2a"), 70000, netip.MustParseAddr("10.0.0.2"), 1)
 	verify(t, NewBMPPeerUpNotification(*p1, netip.MustParseAddr("fe80::6e40:8ff:feab:2c2a"), 10, 100, m, m))
+	verify(t, NewBMPPeerUpNotification(*p0, netip.MustParseAddr("10.0.0.3"), 10, 100, m, m,
+		NewBMPInfoTLVString(BMP_PEER_UP_TLV_TYPE_STRING, "free-form UTF-8 string"),
+		NewBMPInfoTLVUnknown(0xff, []byte{0x01, 0x02})))
+}
+
+func Test_LocRIBPeerHeader(t *testing.T) {
+	m := bgp.NewTestBGPOpenMessage()
+	// the F flag shares the bit with the V flag of the other peer types
+	p0 := NewBMPPeerHeader(BMP_PEER_TYPE_LOCAL_RIB, BMP_PEER_FLAG_LOC_RIB_FILTERED, 1, netip.IPv4Unspecified(), 65000, netip.MustParseAddr("10.0.0.1"), 1)
+	assert.True(t, p0.IsLocRIBFiltered())
+	verify(t, NewBMPPeerUpNotification(*p0, netip.IPv4Unspecified(), 0, 0, m, m,
+		NewBMPInfoTLVString(BMP_PEER_UP_TLV_TYPE_VRF_TABLE_NAME, "vrf1")))
+	verify(t, NewBMPRouteMonitoring(*p0, bgp.NewTestBGPUpdateMessage()))
+	verify(t, NewBMPPeerDownNotification(*p0, BMP_PEER_DOWN_REASON_LOCAL_TLV_DATA, nil, []byte{0x0, 0x3, 0x0, 0x1, 'a'}))
+
+	p1 := NewBMPPeerHeader(BMP_PEER_TYPE_LOCAL_RIB, 0, 0, netip.IPv6Unspecified(), 65000, netip.MustParseAddr("10.0.0.1"), 1)
+	assert.False(t, p1.IsLocRIBFiltered())
+	assert.Equal(t, uint8(0), p1.Flags)
 }
 
 func Test_PeerDownNotification(t *testing.T) {
@@ -526,6 +526,10 @@ func parseRib(data []byte, family bgp.Family, isAddPath bool) (*Rib, error) {
 		safi = data[2]
 		data = data[3:]
 		family = bgp.NewFamily(afi, safi)
+		if !isRibGeneric(family) {
+			return nil, fmt.Errorf("RIB_GENERIC with the specific family %s", family)
+		}
+		u.Family = family
 	}
 	prefix, err := bgp.NLRIFromSlice(family, data)
 	if err != nil {
@@ -553,13 +557,12 @@ func parseRib(data []byte, family bgp.Family, isAddPath bool) (*Rib, error) {
 func (u *Rib) Serialize() ([]byte, error) {
 	buf := make([]byte, 4)
 	binary.BigEndian.PutUint32(buf, u.SequenceNumber)
-	switch u.Family {
-	case bgp.RF_FS_IPv4_UC, bgp.RF_IPv4_MC, bgp.RF_IPv6_UC, bgp.RF_IPv6_MC:
+	if isRibGeneric(u.Family) {
+		// RFC 6396 4.3.3
 		var bbuf [2]byte
 		binary.BigEndian.PutUint16(bbuf[:], u.Family.Afi())
 		buf = append(buf, bbuf[:]...)
 		buf = append(buf, u.Family.Safi())
-	default:
 	}
 	bbuf, err := u.Prefix.Serialize()
 	if err != nil {
@@ -581,6 +584,36 @@ func (u *Rib) Serialize() ([]byte, error) {
 	return buf, nil
 }
 
+// isRibGeneric reports whether the RIBs of the family are dumped with the
+// RIB_GENERIC subtypes, which have the AFI and SAFI.
+func isRibGeneric(family bgp.Family) bool {
+	switch family {
+	case bgp.RF_IPv4_UC, bgp.RF_IPv4_MC, bgp.RF_IPv6_UC, bgp.RF_IPv6_MC:
+		return false
+	}
+	return true
+}
+
+// RibSubtype returns the TABLE_DUMPv2 subtype of the RIBs of the family.
+func RibSubtype(family bgp.Family, isAddPath bool) MRTSubTypeTableDumpv2 {
+	t := RIB_GENERIC
+	switch family {
+	case bgp.RF_IPv4_UC:
+		t = RIB_IPV4_UNICAST
+	case bgp.RF_IPv4_MC:
+		t = RIB_IPV4_MULTICAST
+	case bgp.RF_IPv6_UC:
+		t = RIB_IPV6_UNICAST
+	case bgp.RF_IPv6_MC:
+		t = RIB_IPV6_MULTICAST
+	}
+	if isAddPath {
+		// RFC 8050: the *_ADDPATH subtypes follow the others
+		t += RIB_IPV4_UNICAST_ADDPATH - RIB_IPV4_UNICAST
+	}
+	return t
+}
+
 func NewRib(seq uint32, family bgp.Family, prefix bgp.NLRI, entries []*RibEntry) *Rib {
 	return &Rib{
 		SequenceNumber: seq,
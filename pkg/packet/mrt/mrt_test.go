@@ -230,6 +230,79 @@ func TestMrtRibWithAddPath(t *testing.T) {
 	assert.Equal(t, reflect.DeepEqual(r1, r2), true)
 }
 
+func TestMrtRibGeneric(t *testing.T) {
+	rd := bgp.NewRouteDistinguisherTwoOctetAS(65000, 100)
+	vpn4, _ := bgp.NewLabeledVPNIPAddrPrefix(netip.MustParsePrefix("10.0.0.0/24"), *bgp.NewMPLSLabelStack(100), rd)
+	vpn6, _ := bgp.NewLabeledVPNIPAddrPrefix(netip.MustParsePrefix("2001:db8::/64"), *bgp.NewMPLSLabelStack(200), rd)
+	labeled, _ := bgp.NewLabeledIPAddrPrefix(netip.MustParsePrefix("10.1.0.0/16"), *bgp.NewMPLSLabelStack(300))
+	evpn, _ := bgp.NewEVPNIPPrefixRoute(rd, bgp.EthernetSegmentIdentifier{}, 0, 24, netip.MustParseAddr("10.2.0.0"), netip.MustParseAddr("0.0.0.0"), 400)
+	dst, _ := bgp.NewIPAddrPrefix(netip.MustParsePrefix("10.3.0.0/24"))
+	fs, _ := bgp.NewFlowSpecUnicast(bgp.RF_FS_IPv4_UC, []bgp.FlowSpecComponentInterface{bgp.NewFlowSpecDestinationPrefix(dst)})
+
+	for _, tt := range []struct {
+		family  bgp.Family
+		nlri    bgp.NLRI
+		nexthop netip.Addr
+	}{
+		{bgp.RF_IPv4_VPN, vpn4, netip.MustParseAddr("192.0.2.1")},
+		{bgp.RF_IPv6_VPN, vpn6, netip.MustParseAddr("2001:db8::1")},
+		{bgp.RF_IPv4_MPLS, labeled, netip.MustParseAddr("192.0.2.1")},
+		{bgp.RF_EVPN, evpn, netip.MustParseAddr("192.0.2.1")},
+		{bgp.RF_FS_IPv4_UC, fs, netip.Addr{}},
+	} {
+		t.Run(tt.family.String(), func(t *testing.T) {
+			var nexthops []netip.Addr
+			if tt.nexthop.IsValid() {
+				nexthops = append(nexthops, tt.nexthop)
+			}
+			mp, err := bgp.NewPathAttributeMpReachNLRI(tt.family, []bgp.PathNLRI{{NLRI: tt.nlri}}, nexthops...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			attrs := []bgp.PathAttributeInterface{bgp.NewPathAttributeOrigin(0), mp}
+
+			for _, isAddPath := range []bool{false, true} {
+				st := RibSubtype(tt.family, isAddPath)
+				if isAddPath {
+					assert.Equal(t, RIB_GENERIC_ADDPATH, st)
+				} else {
+					assert.Equal(t, RIB_GENERIC, st)
+				}
+				e := NewRibEntry(1, uint32(time.Now().Unix()), 10, attrs, isAddPath)
+				m1, err := NewMRTMessage(time.Unix(1234, 0), TABLE_DUMPv2, st, NewRib(1, tt.family, tt.nlri, []*RibEntry{e}))
+				if err != nil {
+					t.Fatal(err)
+				}
+				b1, err := m1.Serialize()
+				if err != nil {
+					t.Fatal(err)
+				}
+				h, err := ParseHeader(b1[:MRT_COMMON_HEADER_LEN])
+				if err != nil {
+					t.Fatal(err)
+				}
+				m2, err := ParseBody(b1[MRT_COMMON_HEADER_LEN:], h)
+				if err != nil {
+					t.Fatal(err)
+				}
+				r := m2.Body.(*Rib)
+				assert.Equal(t, tt.family, r.Family)
+				assert.Equal(t, tt.nlri.String(), r.Prefix.String())
+				assert.Len(t, r.Entries, 1)
+				for _, a := range r.Entries[0].PathAttributes {
+					if mp, ok := a.(*bgp.PathAttributeMpReachNLRI); ok {
+						assert.Equal(t, tt.family, bgp.NewFamily(mp.AFI, mp.SAFI))
+						assert.Equal(t, tt.nexthop, mp.Nexthop)
+					}
+				}
+				if isAddPath {
+					assert.Equal(t, uint32(10), r.Entries[0].PathIdentifier)
+				}
+			}
+		})
+	}
+}
+
 func TestMrtGeoPeerTable(t *testing.T) {
 	p1, _ := NewGeoPeer(netip.MustParseAddr("192.168.0.1"), 28.031157, 86.899684)
 	p2, _ := NewGeoPeer(netip.MustParseAddr("192.168.0.1"), 35.360556, 138.727778)
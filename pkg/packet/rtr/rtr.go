@@ -23,7 +23,28 @@ import (
 )
 
 const (
-	RPKI_DEFAULT_PORT = 323
+	RPKI_DEFAULT_PORT     = 323
+	RPKI_TLS_DEFAULT_PORT = 324
+	RPKI_SSH_DEFAULT_PORT = 22
+	// the SSH subsystem name of the RTR protocol (RFC 6810 section 7.1)
+	RPKI_SSH_SUBSYSTEM = "rpki-rtr"
+)
+
+// The protocol versions; 0 is RFC 6810, 1 is RFC 8210 and 2 adds ASPA
+// (draft-ietf-sidrops-8210bis).
+const (
+	RTR_PROTOCOL_VERSION_0 uint8 = iota
+	RTR_PROTOCOL_VERSION_1
+	RTR_PROTOCOL_VERSION_2
+	RTR_MAX_PROTOCOL_VERSION = RTR_PROTOCOL_VERSION_2
+)
+
+// The default timing parameters of RFC 8210 section 6, used until the
+// cache sends them in End of Data.
+const (
+	RTR_DEFAULT_REFRESH_INTERVAL = 3600
+	RTR_DEFAULT_RETRY_INTERVAL   = 600
+	RTR_DEFAULT_EXPIRE_INTERVAL  = 7200
 )
 
 const (
@@ -36,8 +57,9 @@ const (
 	RTR_IPV6_PREFIX
 	RTR_END_OF_DATA
 	RTR_CACHE_RESET
-	_
+	RTR_ROUTER_KEY
 	RTR_ERROR_REPORT
+	RTR_ASPA
 )
 
 const (
@@ -48,7 +70,10 @@ const (
 	RTR_IPV4_PREFIX_LEN           = 20
 	RTR_IPV6_PREFIX_LEN           = 32
 	RTR_END_OF_DATA_LEN           = 12
+	RTR_END_OF_DATA_V1_LEN        = 24
 	RTR_CACHE_RESET_LEN           = 8
+	RTR_ROUTER_KEY_MIN_LEN        = 32
+	RTR_ASPA_MIN_LEN              = 12
 	RTR_MIN_LEN                   = 8
 	RTR_ERROR_REPORT_ERR_PDU_LEN  = 4
 	RTR_ERROR_REPORT_ERR_TEXT_LEN = 4
@@ -68,6 +93,8 @@ const (
 	UNSUPPORTED_PDU_TYPE
 	WITHDRAWAL_OF_UNKNOWN_RECORD
 	DUPLICATE_ANNOUNCEMENT_RECORD
+	UNEXPECTED_PROTOCOL_VERSION
+	ASPA_PROVIDER_LIST_ERROR
 )
 
 type RTRMessage interface {
@@ -284,13 +311,100 @@ func NewRTRIPPrefix(prefix netip.Addr, prefixLen, maxLen uint8, as uint32, flags
 	}
 }
 
+// RTRRouterKey is the Router Key PDU defined in RFC 8210 section 5.10. It
+// carries the BGPsec router key identified by SKI for the given AS.
+type RTRRouterKey struct {
+	Version uint8
+	Type    uint8
+	Flags   uint8
+	Len     uint32
+	SKI     [20]byte
+	AS      uint32
+	SPKI    []byte
+}
+
+func (m *RTRRouterKey) DecodeFromBytes(data []byte) error {
+	if len(data) < RTR_ROUTER_KEY_MIN_LEN {
+		return errors.New("data too short for RTRRouterKey")
+	}
+	m.Version = data[0]
+	m.Type = data[1]
+	m.Flags = data[2]
+	m.Len = binary.BigEndian.Uint32(data[4:8])
+	if m.Len < RTR_ROUTER_KEY_MIN_LEN || uint32(len(data)) < m.Len {
+		return errors.New("invalid length for RTRRouterKey")
+	}
+	copy(m.SKI[:], data[8:28])
+	m.AS = binary.BigEndian.Uint32(data[28:32])
+	m.SPKI = append([]byte(nil), data[32:m.Len]...)
+	return nil
+}
+
+func (m *RTRRouterKey) Serialize() ([]byte, error) {
+	data := make([]byte, RTR_ROUTER_KEY_MIN_LEN+len(m.SPKI))
+	data[0] = m.Version
+	data[1] = m.Type
+	data[2] = m.Flags
+	binary.BigEndian.PutUint32(data[4:8], uint32(len(data)))
+	copy(data[8:28], m.SKI[:])
+	binary.BigEndian.PutUint32(data[28:32], m.AS)
+	copy(data[32:], m.SPKI)
+	return data, nil
+}
+
+func NewRTRRouterKey(ski [20]byte, as uint32, spki []byte, flags uint8) *RTRRouterKey {
+	return &RTRRouterKey{
+		Version: 1,
+		Type:    RTR_ROUTER_KEY,
+		Flags:   flags,
+		Len:     uint32(RTR_ROUTER_KEY_MIN_LEN + len(spki)),
+		SKI:     ski,
+		AS:      as,
+		SPKI:    spki,
+	}
+}
+
+// RTREndOfData is the End of Data PDU. Since version 1 (RFC 8210 section
+// 5.8), it carries the timing parameters for the router.
 type RTREndOfData struct {
 	RTRCommon
+	RefreshInterval uint32
+	RetryInterval   uint32
+	ExpireInterval  uint32
+}
+
+func (m *RTREndOfData) DecodeFromBytes(data []byte) error {
+	if err := m.RTRCommon.DecodeFromBytes(data); err != nil {
+		return err
+	}
+	if m.Version == RTR_PROTOCOL_VERSION_0 {
+		return nil
+	}
+	if len(data) < RTR_END_OF_DATA_V1_LEN {
+		return errors.New("data too short for RTREndOfData")
+	}
+	m.RefreshInterval = binary.BigEndian.Uint32(data[12:16])
+	m.RetryInterval = binary.BigEndian.Uint32(data[16:20])
+	m.ExpireInterval = binary.BigEndian.Uint32(data[20:24])
+	return nil
+}
+
+func (m *RTREndOfData) Serialize() ([]byte, error) {
+	data, _ := m.RTRCommon.Serialize()
+	if m.Version != RTR_PROTOCOL_VERSION_0 {
+		if len(data) < RTR_END_OF_DATA_V1_LEN {
+			return nil, errors.New("invalid length for RTREndOfData")
+		}
+		binary.BigEndian.PutUint32(data[12:16], m.RefreshInterval)
+		binary.BigEndian.PutUint32(data[16:20], m.RetryInterval)
+		binary.BigEndian.PutUint32(data[20:24], m.ExpireInterval)
+	}
+	return data, nil
 }
 
 func NewRTREndOfData(id uint16, sn uint32) *RTREndOfData {
 	return &RTREndOfData{
-		RTRCommon{
+		RTRCommon: RTRCommon{
 			Type:         RTR_END_OF_DATA,
 			SessionID:    id,
 			Len:          RTR_END_OF_DATA_LEN,
@@ -299,6 +413,78 @@ func NewRTREndOfData(id uint16, sn uint32) *RTREndOfData {
 	}
 }
 
+// NewRTREndOfDataWithIntervals returns the End of Data PDU of version 1 or
+// later with the timing parameters.
+func NewRTREndOfDataWithIntervals(version uint8, id uint16, sn uint32, refresh, retry, expire uint32) *RTREndOfData {
+	return &RTREndOfData{
+		RTRCommon: RTRCommon{
+			Version:      version,
+			Type:         RTR_END_OF_DATA,
+			SessionID:    id,
+			Len:          RTR_END_OF_DATA_V1_LEN,
+			SerialNumber: sn,
+		},
+		RefreshInterval: refresh,
+		RetryInterval:   retry,
+		ExpireInterval:  expire,
+	}
+}
+
+// RTRASPA is the ASPA PDU of version 2 (draft-ietf-sidrops-8210bis). An
+// announcement replaces the providers of the customer AS; a withdrawal has
+// no providers.
+type RTRASPA struct {
+	Version     uint8
+	Type        uint8
+	Flags       uint8
+	Len         uint32
+	CustomerAS  uint32
+	ProviderASs []uint32
+}
+
+func (m *RTRASPA) DecodeFromBytes(data []byte) error {
+	if len(data) < RTR_ASPA_MIN_LEN {
+		return errors.New("data too short for RTRASPA")
+	}
+	m.Version = data[0]
+	m.Type = data[1]
+	m.Flags = data[2]
+	m.Len = binary.BigEndian.Uint32(data[4:8])
+	if m.Len < RTR_ASPA_MIN_LEN || uint32(len(data)) < m.Len || (m.Len-RTR_ASPA_MIN_LEN)%4 != 0 {
+		return errors.New("invalid length for RTRASPA")
+	}
+	m.CustomerAS = binary.BigEndian.Uint32(data[8:12])
+	m.ProviderASs = make([]uint32, 0, (m.Len-RTR_ASPA_MIN_LEN)/4)
+	for i := uint32(RTR_ASPA_MIN_LEN); i < m.Len; i += 4 {
+		m.ProviderASs = append(m.ProviderASs, binary.BigEndian.Uint32(data[i:i+4]))
+	}
+	return nil
+}
+
+func (m *RTRASPA) Serialize() ([]byte, error) {
+	data := make([]byte, RTR_ASPA_MIN_LEN+4*len(m.ProviderASs))
+	data[0] = m.Version
+	data[1] = m.Type
+	data[2] = m.Flags
+	binary.BigEndian.PutUint32(data[4:8], uint32(len(data)))
+	binary.BigEndian.PutUint32(data[8:12], m.CustomerAS)
+	for i, as := range m.ProviderASs {
+		binary.BigEndian.PutUint32(data[RTR_ASPA_MIN_LEN+4*i:], as)
+	}
+	return data, nil
+}
+
+func NewRTRASPA(customer uint32, providers []uint32, flags uint8) *RTRASPA {
+	return &RTRASPA{
+		Version:     RTR_PROTOCOL_VERSION_2,
+		Type:        RTR_ASPA,
+		Flags:       flags,
+		Len:         uint32(RTR_ASPA_MIN_LEN + 4*len(providers)),
+		CustomerAS:  customer,
+		ProviderASs: providers,
+	}
+}
+
 type RTRCacheReset struct {
 	RTRReset
 }
@@ -395,8 +581,12 @@ func ParseRTR(data []byte) (RTRMessage, error) {
 		msg = &RTREndOfData{}
 	case RTR_CACHE_RESET:
 		msg = &RTRCacheReset{}
+	case RTR_ROUTER_KEY:
+		msg = &RTRRouterKey{}
 	case RTR_ERROR_REPORT:
 		msg = &RTRErrorReport{}
+	case RTR_ASPA:
+		msg = &RTRASPA{}
 	default:
 		return nil, fmt.Errorf("unknown RTR message type %d", data[1])
 	}
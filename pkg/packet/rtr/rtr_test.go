@@ -90,12 +90,64 @@ func Test_RTREndOfData(t *testing.T) {
 	id := uint16(time.Now().Unix())
 	sn := randUint32()
 	verifyRTRMessage(t, NewRTREndOfData(id, sn))
+
+	m1 := NewRTREndOfDataWithIntervals(RTR_PROTOCOL_VERSION_1, id, sn, 900, 300, 3600)
+	verifyRTRMessage(t, m1)
+	buf, _ := m1.Serialize()
+	assert.Len(t, buf, RTR_END_OF_DATA_V1_LEN)
+	m2, err := ParseRTR(buf)
+	require.NoError(t, err)
+	eod := m2.(*RTREndOfData)
+	assert.Equal(t, uint32(900), eod.RefreshInterval)
+	assert.Equal(t, uint32(300), eod.RetryInterval)
+	assert.Equal(t, uint32(3600), eod.ExpireInterval)
+
+	// version 1 requires the timing parameters
+	_, err = ParseRTR(buf[:RTR_END_OF_DATA_LEN])
+	assert.Error(t, err)
+}
+
+func Test_RTRASPA(t *testing.T) {
+	verifyRTRMessage(t, NewRTRASPA(65001, []uint32{65002, 65003}, ANNOUNCEMENT))
+	verifyRTRMessage(t, NewRTRASPA(65001, nil, WITHDRAWAL))
+
+	buf, _ := NewRTRASPA(65001, []uint32{65002, 65003}, ANNOUNCEMENT).Serialize()
+	m, err := ParseRTR(buf)
+	require.NoError(t, err)
+	a := m.(*RTRASPA)
+	assert.Equal(t, RTR_PROTOCOL_VERSION_2, a.Version)
+	assert.Equal(t, uint32(65001), a.CustomerAS)
+	assert.Equal(t, []uint32{65002, 65003}, a.ProviderASs)
+
+	// the providers must be 4 octets each
+	_, err = ParseRTR([]byte{2, RTR_ASPA, 1, 0, 0, 0, 0, 14, 0, 0, 0, 1, 0, 0})
+	assert.Error(t, err)
 }
 
 func Test_RTRCacheReset(t *testing.T) {
 	verifyRTRMessage(t, NewRTRCacheReset())
 }
 
+func Test_RTRRouterKey(t *testing.T) {
+	var ski [20]byte
+	for i := range ski {
+		ski[i] = byte(i)
+	}
+	spki := []byte{0x30, 0x59, 0x30, 0x13, 0x06, 0x07, 0x2a, 0x86}
+	verifyRTRMessage(t, NewRTRRouterKey(ski, 65001, spki, ANNOUNCEMENT))
+	verifyRTRMessage(t, NewRTRRouterKey(ski, 65001, spki, WITHDRAWAL))
+
+	m, err := ParseRTR(func() []byte { b, _ := NewRTRRouterKey(ski, 65001, spki, ANNOUNCEMENT).Serialize(); return b }())
+	require.NoError(t, err)
+	k := m.(*RTRRouterKey)
+	assert.Equal(t, ski, k.SKI)
+	assert.Equal(t, uint32(65001), k.AS)
+	assert.Equal(t, spki, k.SPKI)
+
+	_, err = ParseRTR([]byte{1, RTR_ROUTER_KEY, 0, 0, 0, 0, 0, 40, 0})
+	assert.Error(t, err)
+}
+
 func Test_RTRErrorReport(t *testing.T) {
 	errPDU, _ := NewRTRResetQuery().Serialize()
 	errText1 := []byte("Couldn't send CacheResponce PDU")
@@ -131,6 +183,9 @@ func FuzzDecodeFromBytes(f *testing.F) {
 		(&RTRReset{}).DecodeFromBytes(data)
 		(&RTRCacheResponse{}).DecodeFromBytes(data)
 		(&RTRIPPrefix{}).DecodeFromBytes(data)
+		(&RTRRouterKey{}).DecodeFromBytes(data)
+		(&RTREndOfData{}).DecodeFromBytes(data)
+		(&RTRASPA{}).DecodeFromBytes(data)
 		(&RTRErrorReport{}).DecodeFromBytes(data)
 	})
 }
@@ -0,0 +1,313 @@
+// Copyright (C) 2025 Acnodal Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/netip"
+	"time"
+
+	api "github.com/osrg/gobgp/v4/api"
+	"github.com/osrg/gobgp/v4/internal/pkg/table"
+	"github.com/osrg/gobgp/v4/pkg/apiutil"
+	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
+)
+
+// lsSourceNetlink is the source of the topology of the host, derived from
+// the Linux link and address tables.
+const lsSourceNetlink = "netlink"
+
+// setLsLength sets the lengths of the BGP-LS NLRI built from the API, which
+// are used to split the UPDATE messages.
+func setLsLength(p *bgp.LsAddrPrefix) error {
+	b, err := p.NLRI.Serialize()
+	if err != nil {
+		return err
+	}
+	l := uint16(len(b))
+	p.Length = l
+	switch n := p.NLRI.(type) {
+	case *bgp.LsNodeNLRI:
+		n.Length = l
+	case *bgp.LsLinkNLRI:
+		n.Length = l
+	case *bgp.LsPrefixV4NLRI:
+		n.Length = l
+	case *bgp.LsPrefixV6NLRI:
+		n.Length = l
+	}
+	return nil
+}
+
+func newLsPath(protocol api.LsProtocolID, identifier uint64, typ api.LsNLRIType, n *api.LsAddrPrefix_LsNLRI, attr *api.LsAttribute) (*table.Path, error) {
+	nlri, err := apiutil.UnmarshalNLRI(bgp.RF_LS, &api.NLRI{Nlri: &api.NLRI_LsAddrPrefix{LsAddrPrefix: &api.LsAddrPrefix{
+		Type:       typ,
+		Nlri:       n,
+		ProtocolId: protocol,
+		Identifier: identifier,
+	}}})
+	if err != nil {
+		return nil, err
+	}
+	if err := setLsLength(nlri.(*bgp.LsAddrPrefix)); err != nil {
+		return nil, err
+	}
+	pathNlri := bgp.PathNLRI{NLRI: nlri}
+	mpreach, err := bgp.NewPathAttributeMpReachNLRI(bgp.RF_LS, []bgp.PathNLRI{pathNlri}, netip.IPv4Unspecified())
+	if err != nil {
+		return nil, err
+	}
+	attrs := []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+		mpreach,
+	}
+	if attr != nil {
+		a, err := apiutil.UnmarshalAttribute(&api.Attribute{Attr: &api.Attribute_Ls{Ls: attr}})
+		if err != nil {
+			return nil, err
+		}
+		if len(a.(*bgp.PathAttributeLs).TLVs) > 0 {
+			attrs = append(attrs, a)
+		}
+	}
+	return table.NewPath(bgp.RF_LS, nil, pathNlri, false, attrs, time.Now(), false), nil
+}
+
+// newLsPaths converts the topology to the BGP-LS paths keyed by the NLRI.
+func newLsPaths(t *api.LsTopology) (map[string]*table.Path, error) {
+	paths := make(map[string]*table.Path)
+	if t == nil {
+		return paths, nil
+	}
+	add := func(p *table.Path, err error) error {
+		if err != nil {
+			return err
+		}
+		paths[p.GetNlri().String()] = p
+		return nil
+	}
+	for _, n := range t.Nodes {
+		if n.Descriptor_ == nil {
+			return nil, fmt.Errorf("node descriptor missing")
+		}
+		nlri := &api.LsAddrPrefix_LsNLRI{Nlri: &api.LsAddrPrefix_LsNLRI_Node{Node: &api.LsNodeNLRI{
+			LocalNode: n.Descriptor_,
+		}}}
+		var attr *api.LsAttribute
+		if n.Attribute != nil {
+			attr = &api.LsAttribute{Node: n.Attribute}
+		}
+		if err := add(newLsPath(t.ProtocolId, t.Identifier, api.LsNLRIType_LS_NLRI_TYPE_NODE, nlri, attr)); err != nil {
+			return nil, fmt.Errorf("invalid node: %w", err)
+		}
+	}
+	for _, l := range t.Links {
+		if l.LocalNode == nil || l.RemoteNode == nil {
+			return nil, fmt.Errorf("link node descriptor missing")
+		}
+		desc := l.Descriptor_
+		if desc == nil {
+			desc = &api.LsLinkDescriptor{}
+		}
+		nlri := &api.LsAddrPrefix_LsNLRI{Nlri: &api.LsAddrPrefix_LsNLRI_Link{Link: &api.LsLinkNLRI{
+			LocalNode:      l.LocalNode,
+			RemoteNode:     l.RemoteNode,
+			LinkDescriptor: desc,
+		}}}
+		var attr *api.LsAttribute
+		if l.Attribute != nil {
+			attr = &api.LsAttribute{Link: l.Attribute}
+		}
+		if err := add(newLsPath(t.ProtocolId, t.Identifier, api.LsNLRIType_LS_NLRI_TYPE_LINK, nlri, attr)); err != nil {
+			return nil, fmt.Errorf("invalid link: %w", err)
+		}
+	}
+	for _, p := range t.Prefixes {
+		if p.LocalNode == nil {
+			return nil, fmt.Errorf("prefix node descriptor missing")
+		}
+		prefix, err := netip.ParsePrefix(p.Prefix)
+		if err != nil {
+			return nil, fmt.Errorf("invalid prefix: %w", err)
+		}
+		desc := &api.LsPrefixDescriptor{IpReachability: []string{prefix.Masked().String()}}
+		var nlri *api.LsAddrPrefix_LsNLRI
+		typ := api.LsNLRIType_LS_NLRI_TYPE_PREFIX_V4
+		if prefix.Addr().Is4() {
+			nlri = &api.LsAddrPrefix_LsNLRI{Nlri: &api.LsAddrPrefix_LsNLRI_PrefixV4{PrefixV4: &api.LsPrefixV4NLRI{
+				LocalNode:        p.LocalNode,
+				PrefixDescriptor: desc,
+			}}}
+		} else {
+			typ = api.LsNLRIType_LS_NLRI_TYPE_PREFIX_V6
+			nlri = &api.LsAddrPrefix_LsNLRI{Nlri: &api.LsAddrPrefix_LsNLRI_PrefixV6{PrefixV6: &api.LsPrefixV6NLRI{
+				LocalNode:        p.LocalNode,
+				PrefixDescriptor: desc,
+			}}}
+		}
+		var attr *api.LsAttribute
+		if p.Attribute != nil {
+			attr = &api.LsAttribute{Prefix: p.Attribute}
+		}
+		if err := add(newLsPath(t.ProtocolId, t.Identifier, typ, nlri, attr)); err != nil {
+			return nil, fmt.Errorf("invalid prefix %s: %w", p.Prefix, err)
+		}
+	}
+	return paths, nil
+}
+
+// updateLsTopology originates the BGP-LS paths of the topology elements
+// of the source and withdraws the ones which disappeared.
+func (s *BgpServer) updateLsTopology(source string, t *api.LsTopology, withdraw, replace bool) error {
+	if s.globalRib == nil {
+		return fmt.Errorf("BGP is not running")
+	}
+	paths, err := newLsPaths(t)
+	if err != nil {
+		return err
+	}
+	current := s.lsTopology[source]
+	if current == nil {
+		current = make(map[string]*table.Path)
+		s.lsTopology[source] = current
+	}
+	var l []*table.Path
+	if withdraw {
+		for key := range paths {
+			if p, ok := current[key]; ok {
+				l = append(l, p.Clone(true))
+				delete(current, key)
+			}
+		}
+	} else {
+		if replace {
+			for key, p := range current {
+				if _, ok := paths[key]; !ok {
+					l = append(l, p.Clone(true))
+					delete(current, key)
+				}
+			}
+		}
+		for key, p := range paths {
+			if p.Equal(current[key]) {
+				continue
+			}
+			l = append(l, p)
+			current[key] = p
+		}
+	}
+	if len(current) == 0 {
+		delete(s.lsTopology, source)
+	}
+	if len(l) == 0 {
+		return nil
+	}
+	s.logger.Debug("update BGP-LS topology",
+		slog.String("Topic", "BGP-LS"),
+		slog.String("Source", source),
+		slog.Int("Paths", len(l)))
+	return s.addPathList("", l)
+}
+
+// UpdateLsTopology originates the topology given by the request as BGP-LS
+// NLRIs.
+func (s *BgpServer) UpdateLsTopology(ctx context.Context, r *api.UpdateLsTopologyRequest) error {
+	if r == nil {
+		return fmt.Errorf("nil request")
+	}
+	switch r.Source {
+	case "":
+		return fmt.Errorf("empty source")
+	case lsSourceNetlink:
+		return fmt.Errorf("source %s is reserved", r.Source)
+	}
+	return s.mgmtOperation(func() error {
+		return s.updateLsTopology(r.Source, r.Topology, r.Withdraw, r.Replace)
+	}, true)
+}
+
+// lsHostLink is an interface of the host with its addresses.
+type lsHostLink struct {
+	Name  string
+	Index uint32
+	Addrs []netip.Prefix
+}
+
+// hostLsTopology models the host as a node with a link to a pseudonode per
+// interface, and the networks of the interface addresses as its prefixes.
+func hostLsTopology(links []lsHostLink, routerID netip.Addr, as, bgpLsID uint32, identifier uint64, hostname string) *api.LsTopology {
+	local := &api.LsNodeDescriptor{
+		Asn:         as,
+		BgpLsId:     bgpLsID,
+		IgpRouterId: routerID.String(),
+	}
+	t := &api.LsTopology{
+		ProtocolId: api.LsProtocolID_LS_PROTOCOL_ID_DIRECT,
+		Identifier: identifier,
+		Nodes: []*api.LsTopologyNode{{
+			Descriptor_: local,
+			Attribute: &api.LsAttributeNode{
+				Name:          hostname,
+				LocalRouterId: routerID.String(),
+			},
+		}},
+	}
+	for _, l := range links {
+		// OSPF style pseudonode ID of the router ID and the interface index
+		id := netip.AddrFrom4([4]byte{byte(l.Index >> 24), byte(l.Index >> 16), byte(l.Index >> 8), byte(l.Index)})
+		remote := &api.LsNodeDescriptor{
+			Asn:         as,
+			BgpLsId:     bgpLsID,
+			Pseudonode:  true,
+			IgpRouterId: routerID.String() + ":" + id.String(),
+		}
+		desc := &api.LsLinkDescriptor{LinkLocalId: l.Index}
+		seen := make(map[netip.Prefix]struct{})
+		for _, a := range l.Addrs {
+			if !a.Addr().IsGlobalUnicast() {
+				continue
+			}
+			if a.Addr().Is4() {
+				if desc.InterfaceAddrIpv4 == "" {
+					desc.InterfaceAddrIpv4 = a.Addr().String()
+				}
+			} else if desc.InterfaceAddrIpv6 == "" {
+				desc.InterfaceAddrIpv6 = a.Addr().String()
+			}
+			prefix := a.Masked()
+			if _, ok := seen[prefix]; ok {
+				continue
+			}
+			seen[prefix] = struct{}{}
+			t.Prefixes = append(t.Prefixes, &api.LsTopologyPrefix{
+				LocalNode: local,
+				Prefix:    prefix.String(),
+			})
+		}
+		t.Links = append(t.Links, &api.LsTopologyLink{
+			LocalNode:   local,
+			RemoteNode:  remote,
+			Descriptor_: desc,
+			Attribute: &api.LsAttributeLink{
+				Name:          l.Name,
+				LocalRouterId: routerID.String(),
+			},
+		})
+	}
+	return t
+}
@@ -0,0 +1,129 @@
+// Copyright (C) 2025 Acnodal Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"net/netip"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/osrg/gobgp/v4/api"
+	"github.com/osrg/gobgp/v4/pkg/apiutil"
+	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
+)
+
+func listLsPaths(t *testing.T, s *BgpServer) []string {
+	l := make([]string, 0)
+	err := s.ListPath(apiutil.ListPathRequest{TableType: api.TableType_TABLE_TYPE_GLOBAL, Family: bgp.RF_LS}, func(prefix bgp.NLRI, paths []*apiutil.Path) {
+		l = append(l, prefix.String())
+	})
+	require.NoError(t, err)
+	sort.Strings(l)
+	return l
+}
+
+func TestUpdateLsTopology(t *testing.T) {
+	assert := assert.New(t)
+	s := NewBgpServer()
+	go s.Serve()
+	err := s.StartBgp(context.Background(), &api.StartBgpRequest{
+		Global: &api.Global{
+			Asn:        65000,
+			RouterId:   "10.0.0.1",
+			ListenPort: -1,
+		},
+	})
+	require.NoError(t, err)
+	defer s.StopBgp(context.Background(), &api.StopBgpRequest{})
+
+	links := []lsHostLink{
+		{Name: "eth0", Index: 2, Addrs: []netip.Prefix{
+			netip.MustParsePrefix("192.168.0.1/24"),
+			netip.MustParsePrefix("2001:db8::1/64"),
+			netip.MustParsePrefix("fe80::1/64"),
+		}},
+		{Name: "eth1", Index: 3, Addrs: []netip.Prefix{netip.MustParsePrefix("192.168.1.1/24")}},
+	}
+	topo := hostLsTopology(links, netip.MustParseAddr("10.0.0.1"), 65000, 1, 0, "host1")
+	require.NoError(t, s.UpdateLsTopology(context.Background(), &api.UpdateLsTopologyRequest{
+		Source:   "test",
+		Topology: topo,
+	}))
+	// a node, two links and three prefixes without the link local one
+	l := listLsPaths(t, s)
+	assert.Len(l, 6)
+	assert.Contains(l, "NLRI { NODE { AS:65000 BGP-LS ID:1 10.0.0.1 DIRECT:0 } }")
+	assert.Contains(l, "NLRI { PREFIXv6 { LOCAL_NODE: 10.0.0.1 PREFIX: [2001:db8::/64] } }")
+
+	var attr *bgp.PathAttributeLs
+	err = s.ListPath(apiutil.ListPathRequest{TableType: api.TableType_TABLE_TYPE_GLOBAL, Family: bgp.RF_LS}, func(prefix bgp.NLRI, paths []*apiutil.Path) {
+		if prefix.(*bgp.LsAddrPrefix).Type == bgp.LS_NLRI_TYPE_NODE {
+			for _, a := range paths[0].Attrs {
+				if a, ok := a.(*bgp.PathAttributeLs); ok {
+					attr = a
+				}
+			}
+		}
+	})
+	require.NoError(t, err)
+	require.NotNil(t, attr)
+	assert.Equal("host1", *attr.Extract().Node.Name)
+
+	// eth1 disappeared
+	topo = hostLsTopology(links[:1], netip.MustParseAddr("10.0.0.1"), 65000, 1, 0, "host1")
+	require.NoError(t, s.UpdateLsTopology(context.Background(), &api.UpdateLsTopologyRequest{
+		Source:   "test",
+		Topology: topo,
+		Replace:  true,
+	}))
+	assert.Len(listLsPaths(t, s), 4)
+	assert.NotContains(listLsPaths(t, s), "NLRI { LINK { LOCAL_NODE: 10.0.0.1 REMOTE_NODE: 10.0.0.1:0.0.0.3 LINK: 3->0} }")
+
+	require.NoError(t, s.UpdateLsTopology(context.Background(), &api.UpdateLsTopologyRequest{
+		Source:   "test",
+		Topology: &api.LsTopology{ProtocolId: topo.ProtocolId, Prefixes: topo.Prefixes},
+		Withdraw: true,
+	}))
+	assert.Len(listLsPaths(t, s), 2)
+
+	assert.Error(s.UpdateLsTopology(context.Background(), &api.UpdateLsTopologyRequest{
+		Source:   lsSourceNetlink,
+		Topology: topo,
+	}))
+	assert.Error(s.UpdateLsTopology(context.Background(), &api.UpdateLsTopologyRequest{
+		Source: "test",
+		Topology: &api.LsTopology{Prefixes: []*api.LsTopologyPrefix{{
+			LocalNode: topo.Nodes[0].Descriptor_,
+			Prefix:    "invalid",
+		}}},
+	}))
+}
+
+func TestNewLsPathsLength(t *testing.T) {
+	topo := hostLsTopology([]lsHostLink{{Name: "eth0", Index: 2}}, netip.MustParseAddr("10.0.0.1"), 65000, 0, 0, "")
+	paths, err := newLsPaths(topo)
+	require.NoError(t, err)
+	for _, p := range paths {
+		nlri := p.GetNlri().(*bgp.LsAddrPrefix)
+		b, err := nlri.Serialize()
+		require.NoError(t, err)
+		assert.Equal(t, len(b), nlri.Len())
+	}
+}
@@ -17,11 +17,17 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
+	"encoding/binary"
 	"fmt"
+	"io"
 	"log/slog"
+	"maps"
 	"net"
 	"net/netip"
+	"slices"
 	"strconv"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -84,14 +90,25 @@ func (r ribout) update(p *table.Path) bool {
 	return true
 }
 
-func (b *bmpClient) tryConnect() *net.TCPConn {
+func (b *bmpClient) tryConnect() net.Conn {
 	interval := 1
 	for {
 		b.s.logger.Debug("Connecting to BMP server",
 			slog.String("Topic", "bmp"),
 			slog.String("Key", b.host))
-		conn, err := net.Dial("tcp", b.host)
+		var conn net.Conn
+		var err error
+		d := &net.Dialer{Timeout: bmpDialTimeout}
+		if b.tls != nil {
+			conn, err = tls.DialWithDialer(d, "tcp", b.host, b.tls)
+		} else {
+			conn, err = d.Dial("tcp", b.host)
+		}
 		if err != nil {
+			b.s.logger.Debug("failed to connect to BMP server",
+				slog.String("Topic", "bmp"),
+				slog.String("Key", b.host),
+				slog.String("Error", err.Error()))
 			select {
 			case <-b.dead:
 				return nil
@@ -105,7 +122,7 @@ func (b *bmpClient) tryConnect() *net.TCPConn {
 			b.s.logger.Debug("Connected to BMP server",
 				slog.String("Topic", "bmp"),
 				slog.String("Key", b.host))
-			return conn.(*net.TCPConn)
+			return conn
 		}
 	}
 }
@@ -114,19 +131,72 @@ func (b *bmpClient) Stop() {
 	close(b.dead)
 }
 
+const (
+	bmpDialTimeout = 30 * time.Second
+	// how long the queued messages are flushed for on Stop
+	bmpFlushTimeout = 5 * time.Second
+)
+
+var errBmpQueueFull = fmt.Errorf("bmp queue is full")
+
+// bmpQueue writes the messages to the BMP server in the background so
+// that a stalled server doesn't block the client.
+type bmpQueue struct {
+	ch   chan []byte
+	err  chan error
+	done chan struct{}
+	once sync.Once
+}
+
+func (b *bmpClient) newBmpQueue(conn net.Conn) *bmpQueue {
+	q := &bmpQueue{
+		ch:   make(chan []byte, b.c.QueueSize),
+		err:  make(chan error, 1),
+		done: make(chan struct{}),
+	}
+	go func() {
+		defer close(q.done)
+		for buf := range q.ch {
+			atomic.AddInt64(&b.queued, -1)
+			if _, err := conn.Write(buf); err != nil {
+				q.err <- err
+				return
+			}
+		}
+	}()
+	return q
+}
+
+func (q *bmpQueue) close() {
+	q.once.Do(func() {
+		close(q.ch)
+	})
+}
+
 func (b *bmpClient) loop() {
 	for {
 		conn := b.tryConnect()
 		if conn == nil {
 			break
 		}
+		if atomic.LoadInt64(&b.uptime) != 0 {
+			atomic.AddInt64(&b.reconnects, 1)
+		}
 		atomic.StoreInt64(&b.uptime, time.Now().Unix())
+		atomic.StoreInt32(&b.up, 1)
 
 		if func() bool {
 			defer func() {
+				atomic.StoreInt32(&b.up, 0)
 				atomic.StoreInt64(&b.downtime, time.Now().Unix())
 			}()
-			ops := []WatchOption{WatchPeer()}
+			defer conn.Close()
+			// the server gets the full RIB after every connection so what
+			// was sent over the previous one doesn't matter.
+			b.ribout = newribout()
+			atomic.StoreInt64(&b.queued, 0)
+
+			ops := []WatchOption{WatchPeer(), watchEndOfInit()}
 			if b.c.RouteMonitoringPolicy == oc.BMP_ROUTE_MONITORING_POLICY_TYPE_BOTH {
 				b.s.logger.Warn("both option for route-monitoring-policy is obsoleted", slog.String("Topic", "bmp"))
 			}
@@ -136,9 +206,16 @@ func (b *bmpClient) loop() {
 			if b.c.RouteMonitoringPolicy == oc.BMP_ROUTE_MONITORING_POLICY_TYPE_POST_POLICY || b.c.RouteMonitoringPolicy == oc.BMP_ROUTE_MONITORING_POLICY_TYPE_ALL {
 				ops = append(ops, WatchPostUpdate(true, "", ""))
 			}
-			if b.c.RouteMonitoringPolicy == oc.BMP_ROUTE_MONITORING_POLICY_TYPE_LOCAL_RIB || b.c.RouteMonitoringPolicy == oc.BMP_ROUTE_MONITORING_POLICY_TYPE_ALL {
+			locRib := b.c.RouteMonitoringPolicy == oc.BMP_ROUTE_MONITORING_POLICY_TYPE_LOCAL_RIB || b.c.RouteMonitoringPolicy == oc.BMP_ROUTE_MONITORING_POLICY_TYPE_ALL
+			if locRib {
 				ops = append(ops, WatchBestPath(true))
 			}
+			if b.c.AdjRibOutPrePolicyEnabled {
+				ops = append(ops, watchAdjRibOut(true, false))
+			}
+			if b.c.AdjRibOutPostPolicyEnabled {
+				ops = append(ops, watchAdjRibOut(true, true))
+			}
 			if b.c.RouteMirroringEnabled {
 				ops = append(ops, watchMessage(false))
 			}
@@ -154,9 +231,52 @@ func (b *bmpClient) loop() {
 				tickerCh = t.C
 			}
 
+			var queue *bmpQueue
+			var queueErr <-chan error
+			if b.c.QueueSize > 0 {
+				queue = b.newBmpQueue(conn)
+				defer queue.close()
+				queueErr = queue.err
+			}
+			// nothing is expected from the BMP server; reading detects that
+			// the server closes the session.
+			closed := make(chan struct{})
+			go func() {
+				_, _ = io.Copy(io.Discard, conn)
+				close(closed)
+			}()
+			// the resync, the messages before watchEventEndOfInit, waits
+			// for room in the queue instead of dropping the messages.
+			resyncing := true
+
 			write := func(msg *bmp.BMPMessage) error {
 				buf, _ := msg.Serialize()
-				_, err := conn.Write(buf)
+				var err error
+				if queue == nil {
+					_, err = conn.Write(buf)
+				} else if resyncing {
+					atomic.AddInt64(&b.queued, 1)
+					select {
+					case queue.ch <- buf:
+					case err = <-queue.err:
+					case <-b.dead:
+						err = fmt.Errorf("bmp client is stopped")
+					}
+					if err != nil {
+						atomic.AddInt64(&b.queued, -1)
+					}
+				} else {
+					atomic.AddInt64(&b.queued, 1)
+					select {
+					case queue.ch <- buf:
+					default:
+						atomic.AddInt64(&b.queued, -1)
+						atomic.AddInt64(&b.dropped, 1)
+						if b.c.QueueOverflowAction == oc.BMP_QUEUE_OVERFLOW_ACTION_TYPE_DISCONNECT {
+							err = errBmpQueueFull
+						}
+					}
+				}
 				if err != nil {
 					b.s.logger.Warn("failed to write to bmp server",
 						slog.String("Topic", "bmp"),
@@ -175,6 +295,41 @@ func (b *bmpClient) loop() {
 				return false
 			}
 
+			// RFC 9069: the Loc-RIB instances, the global table and the
+			// VRFs, are announced with the fabricated Peer Up messages.
+			var instance *bmpLocRib
+			vrfs := make(map[string]uint64)
+			syncVrfs := func() error {
+				instance = b.s.bmpLocRib()
+				for name, dist := range vrfs {
+					if v, ok := instance.vrfs[name]; !ok || bmpVrfDistinguisher(v) != dist {
+						if err := write(instance.peerDown(dist, name)); err != nil {
+							return err
+						}
+						delete(vrfs, name)
+					}
+				}
+				for name, v := range instance.vrfs {
+					if _, ok := vrfs[name]; !ok {
+						dist := bmpVrfDistinguisher(v)
+						if err := write(instance.peerUp(dist, name)); err != nil {
+							return err
+						}
+						vrfs[name] = dist
+					}
+				}
+				return nil
+			}
+			if locRib {
+				instance = b.s.bmpLocRib()
+				if err := write(instance.peerUp(0, table.GLOBAL_RIB_NAME)); err != nil {
+					return false
+				}
+				if err := syncVrfs(); err != nil {
+					return false
+				}
+			}
+
 			for {
 				select {
 				case ev := <-w.Event():
@@ -185,17 +340,24 @@ func (b *bmpClient) loop() {
 							AS:      msg.PeerAS,
 							ID:      msg.PeerID,
 						}
-						if msg.Payload == nil {
-							var pathList []*table.Path
-							if msg.Init {
-								pathList = msg.PathList
-							} else {
-								for _, p := range msg.PathList {
-									if b.ribout.update(p) {
-										pathList = append(pathList, p)
+						if msg.AdjRibOut {
+							for _, path := range msg.PathList {
+								for _, u := range table.CreateUpdateMsgFromPaths([]*table.Path{path}) {
+									payload, _ := u.Serialize()
+									if err := write(bmpPeerAdjRibOutRoute(msg.PostPolicy, msg.FourBytesAs, info, msg.Timestamp.Unix(), payload)); err != nil {
+										return false
 									}
 								}
 							}
+						} else if msg.Payload == nil {
+							var pathList []*table.Path
+							for _, p := range msg.PathList {
+								// the current paths are recorded too so
+								// that the later withdrawals are sent.
+								if b.ribout.update(p) || msg.Init {
+									pathList = append(pathList, p)
+								}
+							}
 							for _, path := range pathList {
 								for _, u := range table.CreateUpdateMsgFromPaths([]*table.Path{path}) {
 									payload, _ := u.Serialize()
@@ -209,17 +371,51 @@ func (b *bmpClient) loop() {
 						}
 					case *watchEventBestPath:
 						info := &table.PeerInfo{
-							Address: netip.MustParseAddr("0.0.0.0"),
-							AS:      b.s.bgpConfig.Global.Config.As,
-							ID:      b.s.bgpConfig.Global.Config.RouterId,
+							Address: netip.IPv4Unspecified(),
+							AS:      instance.as,
+							ID:      instance.id,
 						}
-						for _, p := range msg.PathList {
+						send := func(p *table.Path, dist uint64) error {
 							u := table.CreateUpdateMsgFromPaths([]*table.Path{p})[0]
-							if payload, err := u.Serialize(); err != nil {
+							payload, err := u.Serialize()
+							if err != nil {
+								return err
+							}
+							return write(bmpPeerRoute(bmp.BMP_PEER_TYPE_LOCAL_RIB, false, dist, true, info, p.GetTimestamp().Unix(), payload))
+						}
+						if slices.ContainsFunc(msg.PathList, isBmpVpnPath) || len(vrfs) > 0 {
+							if err := syncVrfs(); err != nil {
 								return false
-							} else if err = write(bmpPeerRoute(bmp.BMP_PEER_TYPE_LOCAL_RIB, false, 0, true, info, p.GetTimestamp().Unix(), payload)); err != nil {
+							}
+						}
+						for _, p := range msg.PathList {
+							if err := send(p, 0); err != nil {
 								return false
 							}
+							if !isBmpVpnPath(p) {
+								continue
+							}
+							for name, v := range instance.vrfs {
+								if table.CanImportToVrf(v, p) {
+									if err := send(p.ToLocal(), vrfs[name]); err != nil {
+										return false
+									}
+								}
+							}
+						}
+						if msg.Init {
+							for _, f := range instance.families {
+								if err := send(table.NewEOR(f), 0); err != nil {
+									return false
+								}
+							}
+							for _, dist := range vrfs {
+								for _, f := range []bgp.Family{bgp.RF_IPv4_UC, bgp.RF_IPv6_UC} {
+									if err := send(table.NewEOR(f), dist); err != nil {
+										return false
+									}
+								}
+							}
 						}
 					case *watchEventPeer:
 						if msg.Type != apiutil.PEER_EVENT_END_OF_INIT {
@@ -233,6 +429,13 @@ func (b *bmpClient) loop() {
 								}
 							}
 						}
+					case *watchEventEndOfInit:
+						resyncing = false
+						atomic.AddInt64(&b.resyncs, 1)
+						atomic.StoreInt64(&b.lastResync, msg.Timestamp.Unix())
+						b.s.logger.Debug("resynced with BMP server",
+							slog.String("Topic", "bmp"),
+							slog.String("Key", b.host))
 					case *watchEventMessage:
 						info := &table.PeerInfo{
 							Address: msg.PeerAddress,
@@ -244,24 +447,44 @@ func (b *bmpClient) loop() {
 						}
 					}
 				case <-tickerCh:
+					var adjRibOut map[netip.Addr]*bmpAdjRibOutCount
+					if b.c.AdjRibOutPrePolicyEnabled || b.c.AdjRibOutPostPolicyEnabled {
+						adjRibOut = b.s.bmpAdjRibOutCount(b.c.AdjRibOutPrePolicyEnabled, b.c.AdjRibOutPostPolicyEnabled)
+					}
 					var err error
 					listErr := b.s.ListPeer(context.Background(), &api.ListPeerRequest{EnableAdvertised: true},
 						func(peer *api.Peer) {
 							if err == nil && peer.State.SessionState == api.PeerState_SESSION_STATE_ESTABLISHED {
-								err = write(bmpPeerStats(bmp.BMP_PEER_TYPE_GLOBAL, 0, time.Now().Unix(), peer))
+								var counts *bmpAdjRibOutCount
+								if addr, e := netip.ParseAddr(peer.State.NeighborAddress); e == nil {
+									counts = adjRibOut[addr]
+								}
+								err = write(bmpPeerStats(bmp.BMP_PEER_TYPE_GLOBAL, 0, time.Now().Unix(), peer, counts))
 							}
 						})
 					if listErr != nil && err != nil {
 						return false
 					}
+				case <-queueErr:
+					return false
+				case <-closed:
+					b.s.logger.Warn("bmp server closed the session",
+						slog.String("Topic", "bmp"),
+						slog.String("Key", b.host))
+					return false
 				case <-b.dead:
 					term := bmp.NewBMPTermination([]bmp.BMPTermTLVInterface{
 						bmp.NewBMPTermTLV16(bmp.BMP_TERM_TLV_TYPE_REASON, bmp.BMP_TERM_REASON_PERMANENTLY_ADMIN),
 					})
+					resyncing = false
 					if err := write(term); err != nil {
-						return false
+						return true
+					}
+					if queue != nil {
+						queue.close()
+						_ = conn.SetWriteDeadline(time.Now().Add(bmpFlushTimeout))
+						<-queue.done
 					}
-					conn.Close()
 					return true
 				}
 			}
@@ -276,9 +499,29 @@ type bmpClient struct {
 	dead     chan struct{}
 	host     string
 	c        *oc.BmpServerConfig
+	tls      *tls.Config
 	ribout   ribout
 	uptime   int64
 	downtime int64
+	// the messages dropped because the queue was full
+	dropped int64
+	queued  int64
+	// the full RIB resyncs completed, one after every connection
+	resyncs    int64
+	lastResync int64
+	up         int32
+	// the connections established after the first one
+	reconnects int64
+}
+
+func bmpQueueOverflowActionToApi(a oc.BmpQueueOverflowActionType) api.AddBmpRequest_QueueOverflowAction {
+	switch a {
+	case oc.BMP_QUEUE_OVERFLOW_ACTION_TYPE_DROP:
+		return api.AddBmpRequest_QUEUE_OVERFLOW_ACTION_DROP
+	case oc.BMP_QUEUE_OVERFLOW_ACTION_TYPE_DISCONNECT:
+		return api.AddBmpRequest_QUEUE_OVERFLOW_ACTION_DISCONNECT
+	}
+	return api.AddBmpRequest_QUEUE_OVERFLOW_ACTION_UNSPECIFIED
 }
 
 func bmpPeerUp(ev *watchEventPeer, t uint8, policy bool, pd uint64) *bmp.BMPMessage {
@@ -300,7 +543,7 @@ func bmpPeerDown(ev *watchEventPeer, t uint8, policy bool, pd uint64) *bmp.BMPMe
 
 	reasonCode := bmp.BMP_peerDownByUnknownReason
 	switch ev.StateReason.Type {
-	case fsmDying, fsmInvalidMsg, fsmNotificationSent, fsmHoldTimerExpired, fsmIdleTimerExpired, fsmRestartTimerExpired:
+	case fsmDying, fsmInvalidMsg, fsmNotificationSent, fsmHoldTimerExpired, fsmIdleTimerExpired, fsmRestartTimerExpired, fsmSendHoldTimerExpired:
 		reasonCode = bmp.BMP_PEER_DOWN_REASON_LOCAL_BGP_NOTIFICATION
 	case fsmAdminDown:
 		reasonCode = bmp.BMP_PEER_DOWN_REASON_LOCAL_NO_NOTIFICATION
@@ -322,14 +565,53 @@ func bmpPeerRoute(t uint8, policy bool, pd uint64, fourBytesAs bool, peeri *tabl
 	if !fourBytesAs {
 		flags |= bmp.BMP_PEER_FLAG_TWO_AS
 	}
-	ph := bmp.NewBMPPeerHeader(t, flags, pd, netip.MustParseAddr(peeri.Address.String()), peeri.AS, netip.MustParseAddr(peeri.ID.String()), float64(timestamp))
+	ph := bmp.NewBMPPeerHeader(t, flags, pd, bmpPeerAddr(peeri.Address), peeri.AS, bmpPeerAddr(peeri.ID), float64(timestamp))
 	m := bmp.NewBMPRouteMonitoring(*ph, nil)
 	body := m.Body.(*bmp.BMPRouteMonitoring)
 	body.BGPUpdatePayload = payload
 	return m
 }
 
-func bmpPeerStats(peerType uint8, peerDist uint64, timestamp int64, peer *api.Peer) *bmp.BMPMessage {
+// bmpPeerAddr returns the unspecified address instead of the invalid one
+// of the locally originated paths.
+func bmpPeerAddr(a netip.Addr) netip.Addr {
+	if !a.IsValid() {
+		return netip.IPv4Unspecified()
+	}
+	return a
+}
+
+func bmpPeerAdjRibOutRoute(policy bool, fourBytesAs bool, peeri *table.PeerInfo, timestamp int64, payload []byte) *bmp.BMPMessage {
+	m := bmpPeerRoute(bmp.BMP_PEER_TYPE_GLOBAL, policy, 0, fourBytesAs, peeri, timestamp, payload)
+	m.PeerHeader.Flags |= bmp.BMP_PEER_FLAG_ADJ_RIB_TYP
+	return m
+}
+
+// bmpAdjRibOutCount is the number of the routes per family in the
+// Adj-RIB-Out of a peer, before and after the export policy is applied;
+// nil for the view not monitored.
+type bmpAdjRibOutCount struct {
+	pre  map[bgp.Family]uint64
+	post map[bgp.Family]uint64
+}
+
+func bmpAdjRibOutStats(counts map[bgp.Family]uint64, typ, perAfiSafiTyp uint16) []bmp.BMPStatsTLVInterface {
+	if counts == nil {
+		return nil
+	}
+	families := slices.Sorted(maps.Keys(counts))
+	total := uint64(0)
+	for _, f := range families {
+		total += counts[f]
+	}
+	tlvs := []bmp.BMPStatsTLVInterface{bmp.NewBMPStatsTLV64(typ, total)}
+	for _, f := range families {
+		tlvs = append(tlvs, bmp.NewBMPStatsTLVPerAfiSafi64(perAfiSafiTyp, f.Afi(), f.Safi(), counts[f]))
+	}
+	return tlvs
+}
+
+func bmpPeerStats(peerType uint8, peerDist uint64, timestamp int64, peer *api.Peer, adjRibOut *bmpAdjRibOutCount) *bmp.BMPMessage {
 	var peerFlags uint8 = 0
 	ph := bmp.NewBMPPeerHeader(peerType, peerFlags, peerDist, netip.MustParseAddr(peer.State.NeighborAddress), peer.State.PeerAsn, netip.MustParseAddr(peer.State.RouterId), float64(timestamp))
 	received := uint64(0)
@@ -338,15 +620,123 @@ func bmpPeerStats(peerType uint8, peerDist uint64, timestamp int64, peer *api.Pe
 		received += a.State.Received
 		accepted += a.State.Accepted
 	}
-	return bmp.NewBMPStatisticsReport(
-		*ph,
-		[]bmp.BMPStatsTLVInterface{
-			bmp.NewBMPStatsTLV64(bmp.BMP_STAT_TYPE_ADJ_RIB_IN, received),
-			bmp.NewBMPStatsTLV64(bmp.BMP_STAT_TYPE_LOC_RIB, accepted),
-			bmp.NewBMPStatsTLV32(bmp.BMP_STAT_TYPE_WITHDRAW_UPDATE, uint32(peer.State.Messages.Received.WithdrawUpdate)),
-			bmp.NewBMPStatsTLV32(bmp.BMP_STAT_TYPE_WITHDRAW_PREFIX, uint32(peer.State.Messages.Received.WithdrawPrefix)),
-		},
-	)
+	tlvs := []bmp.BMPStatsTLVInterface{
+		bmp.NewBMPStatsTLV64(bmp.BMP_STAT_TYPE_ADJ_RIB_IN, received),
+		bmp.NewBMPStatsTLV64(bmp.BMP_STAT_TYPE_LOC_RIB, accepted),
+		bmp.NewBMPStatsTLV32(bmp.BMP_STAT_TYPE_WITHDRAW_UPDATE, uint32(peer.State.Messages.Received.WithdrawUpdate)),
+		bmp.NewBMPStatsTLV32(bmp.BMP_STAT_TYPE_WITHDRAW_PREFIX, uint32(peer.State.Messages.Received.WithdrawPrefix)),
+	}
+	if adjRibOut != nil {
+		tlvs = append(tlvs, bmpAdjRibOutStats(adjRibOut.pre, bmp.BMP_STAT_TYPE_ADJ_RIB_OUT_PRE_POLICY, bmp.BMP_STAT_TYPE_PER_AFI_SAFI_ADJ_RIB_OUT_PRE_POLICY)...)
+		tlvs = append(tlvs, bmpAdjRibOutStats(adjRibOut.post, bmp.BMP_STAT_TYPE_ADJ_RIB_OUT_POST_POLICY, bmp.BMP_STAT_TYPE_PER_AFI_SAFI_ADJ_RIB_OUT_POST_POLICY)...)
+	}
+	return bmp.NewBMPStatisticsReport(*ph, tlvs)
+}
+
+// bmpAdjRibOutCount returns the number of the routes sent to the
+// established peers, recorded as the Adj-RIB-Out is monitored.
+func (s *BgpServer) bmpAdjRibOutCount(pre, post bool) map[netip.Addr]*bmpAdjRibOutCount {
+	count := func(peer *peer, a advertisedPaths) map[bgp.Family]uint64 {
+		counts := make(map[bgp.Family]uint64)
+		for _, f := range peer.configuredRFlist() {
+			counts[f] = 0
+		}
+		for f, keys := range a {
+			counts[f] = uint64(len(keys))
+		}
+		return counts
+	}
+	m := make(map[netip.Addr]*bmpAdjRibOutCount)
+	_ = s.mgmtOperation(func() error {
+		for addr, peer := range s.neighborMap {
+			if peer.State() != bgp.BGP_FSM_ESTABLISHED {
+				continue
+			}
+			c := &bmpAdjRibOutCount{}
+			if pre {
+				c.pre = count(peer, peer.adjRibOutPre)
+			}
+			if post {
+				c.post = count(peer, peer.adjRibOutPost)
+			}
+			m[addr] = c
+		}
+		return nil
+	}, false)
+	return m
+}
+
+// bmpLocRib is a snapshot of the Loc-RIB instances (RFC 9069).
+type bmpLocRib struct {
+	as       uint32
+	id       netip.Addr
+	families []bgp.Family
+	vrfs     map[string]*table.Vrf
+}
+
+func (s *BgpServer) bmpLocRib() *bmpLocRib {
+	l := &bmpLocRib{
+		vrfs: make(map[string]*table.Vrf),
+	}
+	_ = s.mgmtOperation(func() error {
+		l.as = s.bgpConfig.Global.Config.As
+		l.id = s.bgpConfig.Global.Config.RouterId
+		if !l.id.IsValid() {
+			l.id = netip.IPv4Unspecified()
+		}
+		l.families = s.globalRib.GetRFlist()
+		for name, v := range s.globalRib.Vrfs {
+			l.vrfs[name] = v.Clone()
+		}
+		return nil
+	}, false)
+	return l
+}
+
+func (l *bmpLocRib) peerHeader(dist uint64) *bmp.BMPPeerHeader {
+	return bmp.NewBMPPeerHeader(bmp.BMP_PEER_TYPE_LOCAL_RIB, 0, dist, netip.IPv4Unspecified(), l.as, l.id, float64(time.Now().Unix()))
+}
+
+// peerUp returns the Peer Up message of the Loc-RIB instance. The OPEN
+// message is fabricated with the capabilities of the instance and is
+// used for both the sent and received ones.
+func (l *bmpLocRib) peerUp(dist uint64, name string) *bmp.BMPMessage {
+	caps := []bgp.ParameterCapabilityInterface{bgp.NewCapFourOctetASNumber(l.as)}
+	for _, f := range l.families {
+		caps = append(caps, bgp.NewCapMultiProtocol(f))
+	}
+	as := l.as
+	if as > (1<<16)-1 {
+		as = bgp.AS_TRANS
+	}
+	open, _ := bgp.NewBGPOpenMessage(uint16(as), 0, l.id, []bgp.OptionParameterInterface{bgp.NewOptionParameterCapability(caps)})
+	return bmp.NewBMPPeerUpNotification(*l.peerHeader(dist), netip.IPv4Unspecified(), 0, 0, open, open,
+		bmp.NewBMPInfoTLVString(bmp.BMP_PEER_UP_TLV_TYPE_VRF_TABLE_NAME, name))
+}
+
+func (l *bmpLocRib) peerDown(dist uint64, name string) *bmp.BMPMessage {
+	data, _ := bmp.NewBMPInfoTLVString(bmp.BMP_PEER_UP_TLV_TYPE_VRF_TABLE_NAME, name).Serialize()
+	return bmp.NewBMPPeerDownNotification(*l.peerHeader(dist), bmp.BMP_PEER_DOWN_REASON_LOCAL_TLV_DATA, nil, data)
+}
+
+// bmpVrfDistinguisher returns the peer distinguisher of the Loc-RIB
+// instance of the VRF; the route distinguisher, or the VRF ID if the VRF
+// doesn't have one.
+func bmpVrfDistinguisher(v *table.Vrf) uint64 {
+	if v.Rd != nil {
+		if buf, err := v.Rd.Serialize(); err == nil && len(buf) == 8 {
+			return binary.BigEndian.Uint64(buf)
+		}
+	}
+	return uint64(v.Id)
+}
+
+func isBmpVpnPath(p *table.Path) bool {
+	switch p.GetFamily() {
+	case bgp.RF_IPv4_VPN, bgp.RF_IPv6_VPN:
+		return true
+	}
+	return false
 }
 
 func bmpPeerRouteMirroring(peerType uint8, peerDist uint64, peerInfo *table.PeerInfo, timestamp int64, msg *bgp.BGPMessage) *bmp.BMPMessage {
@@ -366,11 +756,19 @@ func (b *bmpClientManager) addServer(c *oc.BmpServerConfig) error {
 	if _, y := b.clientMap[host]; y {
 		return fmt.Errorf("bmp client %s is already configured", host)
 	}
+	var tlsConfig *tls.Config
+	if c.TlsEnabled {
+		var err error
+		if tlsConfig, err = newClientTLSConfig(c.Address, c.TlsCaFile, c.TlsCertFile, c.TlsKeyFile, c.TlsServerName); err != nil {
+			return err
+		}
+	}
 	b.clientMap[host] = &bmpClient{
 		s:      b.s,
 		dead:   make(chan struct{}),
 		host:   host,
 		c:      c,
+		tls:    tlsConfig,
 		ribout: newribout(),
 	}
 	go b.clientMap[host].loop()
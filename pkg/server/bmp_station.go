@@ -0,0 +1,812 @@
+// Copyright (C) 2025 Acnodal Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"net"
+	"net/netip"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/osrg/gobgp/v4/api"
+	"github.com/osrg/gobgp/v4/internal/pkg/table"
+	"github.com/osrg/gobgp/v4/pkg/config/oc"
+	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
+	"github.com/osrg/gobgp/v4/pkg/packet/bmp"
+	"github.com/osrg/gobgp/v4/pkg/packet/mrt"
+)
+
+// bmpStationMaxMessageSize is the maximum size of the BMP messages accepted
+// from the monitored routers.
+const bmpStationMaxMessageSize = 1 << 20
+
+type bmpStationPeerKey struct {
+	typ           uint8
+	distinguisher uint64
+	address       netip.Addr
+}
+
+// bmpStationPeer is a peer of a monitored router, or its Loc-RIB instance.
+type bmpStationPeer struct {
+	key          bmpStationPeerKey
+	as           uint32
+	id           netip.Addr
+	up           bool
+	timestamp    time.Time
+	localAddress netip.Addr
+	localAS      uint32
+	localPort    uint16
+	remotePort   uint16
+	// the ADD-PATH modes of the updates received from the peer
+	addPath      map[bgp.Family]bgp.BGPAddPathMode
+	adjRibInPre  *table.AdjRib
+	adjRibInPost *table.AdjRib
+	locRib       *table.AdjRib
+	stats        map[uint16]uint64
+}
+
+func newBmpStationPeer(logger *slog.Logger, key bmpStationPeerKey, as uint32, id netip.Addr, timestamp time.Time) *bmpStationPeer {
+	families := bmpStationFamilies()
+	return &bmpStationPeer{
+		key:          key,
+		as:           as,
+		id:           id,
+		up:           true,
+		timestamp:    timestamp,
+		addPath:      make(map[bgp.Family]bgp.BGPAddPathMode),
+		adjRibInPre:  table.NewAdjRib(logger, families),
+		adjRibInPost: table.NewAdjRib(logger, families),
+		locRib:       table.NewAdjRib(logger, families),
+		stats:        make(map[uint16]uint64),
+	}
+}
+
+// bmpStationFamilies returns all the families since the station can't know
+// which ones the monitored peers negotiated.
+func bmpStationFamilies() []bgp.Family {
+	families := make([]bgp.Family, 0, len(bgp.AddressFamilyValueMap))
+	for _, f := range bgp.AddressFamilyValueMap {
+		families = append(families, f)
+	}
+	return families
+}
+
+func (p *bmpStationPeer) peerInfo() *table.PeerInfo {
+	return &table.PeerInfo{
+		AS:           p.as,
+		LocalAS:      p.localAS,
+		ID:           p.id,
+		Address:      p.key.address,
+		LocalAddress: p.localAddress,
+	}
+}
+
+func (p *bmpStationPeer) isAddPath(family bgp.Family) bool {
+	return p.addPath[family]&bgp.BGP_ADD_PATH_RECEIVE > 0
+}
+
+func (p *bmpStationPeer) toApi(router string) *api.BmpMonitoredPeer {
+	families := bmpStationFamilies()
+	peer := &api.BmpMonitoredPeer{
+		Router:        router,
+		Type:          api.BmpMonitoredPeer_Type(p.key.typ + 1),
+		Distinguisher: p.key.distinguisher,
+		Address:       p.key.address.String(),
+		Asn:           p.as,
+		BgpId:         p.id.String(),
+		Up:            p.up,
+		Timestamp:     oc.ProtoTimestamp(p.timestamp.Unix()),
+		LocalPort:     uint32(p.localPort),
+		RemotePort:    uint32(p.remotePort),
+		AdjRibInPre:   uint64(p.adjRibInPre.Count(families)),
+		AdjRibInPost:  uint64(p.adjRibInPost.Count(families)),
+		LocRib:        uint64(p.locRib.Count(families)),
+		Statistics:    make(map[uint32]uint64, len(p.stats)),
+	}
+	if p.localAddress.IsValid() {
+		peer.LocalAddress = p.localAddress.String()
+	}
+	for t, v := range p.stats {
+		peer.Statistics[uint32(t)] = v
+	}
+	return peer
+}
+
+// bmpRouter is a router which sends the BMP messages to the station.
+type bmpRouter struct {
+	conn     net.Conn
+	address  netip.AddrPort
+	sysName  string
+	sysDescr string
+	uptime   time.Time
+	messages uint64
+	peers    map[bmpStationPeerKey]*bmpStationPeer
+}
+
+func (r *bmpRouter) toApi() *api.BmpRouter {
+	return &api.BmpRouter{
+		Address:  r.address.Addr().String(),
+		Port:     uint32(r.address.Port()),
+		SysName:  r.sysName,
+		SysDescr: r.sysDescr,
+		Uptime:   oc.ProtoTimestamp(r.uptime.Unix()),
+		Peers:    uint32(len(r.peers)),
+		Messages: r.messages,
+	}
+}
+
+// bmpStation accepts the BMP sessions from the monitored routers and keeps
+// the tables of their peers.
+type bmpStation struct {
+	s        *BgpServer
+	listener net.Listener
+	mrt      *bmpStationMrt
+	wg       sync.WaitGroup
+
+	mu sync.RWMutex
+	// keyed by the address of the router
+	routers map[netip.Addr]*bmpRouter
+}
+
+func newBmpStation(s *BgpServer, c *oc.BmpStationConfig) (*bmpStation, error) {
+	port := c.Port
+	if port == 0 {
+		port = bmp.BMP_DEFAULT_PORT
+	}
+	host := ""
+	if c.Address.IsValid() {
+		host = c.Address.String()
+	}
+	st := &bmpStation{
+		s:       s,
+		routers: make(map[netip.Addr]*bmpRouter),
+	}
+	if c.MrtFileName != "" {
+		m, err := newBmpStationMrt(st, c)
+		if err != nil {
+			return nil, err
+		}
+		st.mrt = m
+	}
+	l, err := net.Listen("tcp", net.JoinHostPort(host, strconv.Itoa(int(port))))
+	if err != nil {
+		if st.mrt != nil {
+			st.mrt.stop()
+		}
+		return nil, err
+	}
+	st.listener = l
+	st.wg.Add(1)
+	go st.serve()
+	return st, nil
+}
+
+func (st *bmpStation) stop() {
+	st.listener.Close()
+	st.mu.Lock()
+	for _, r := range st.routers {
+		r.conn.Close()
+	}
+	st.mu.Unlock()
+	st.wg.Wait()
+	if st.mrt != nil {
+		st.mrt.stop()
+	}
+}
+
+func (st *bmpStation) serve() {
+	defer st.wg.Done()
+	for {
+		conn, err := st.listener.Accept()
+		if err != nil {
+			return
+		}
+		st.wg.Add(1)
+		go func() {
+			defer st.wg.Done()
+			st.handleConn(conn)
+		}()
+	}
+}
+
+func (st *bmpStation) handleConn(conn net.Conn) {
+	address, err := netip.ParseAddrPort(conn.RemoteAddr().String())
+	if err != nil {
+		conn.Close()
+		return
+	}
+	address = netip.AddrPortFrom(address.Addr().Unmap(), address.Port())
+	r := &bmpRouter{
+		conn:    conn,
+		address: address,
+		uptime:  time.Now(),
+		peers:   make(map[bmpStationPeerKey]*bmpStationPeer),
+	}
+	st.mu.Lock()
+	if old, ok := st.routers[address.Addr()]; ok {
+		// the router reconnected
+		old.conn.Close()
+	}
+	st.routers[address.Addr()] = r
+	st.mu.Unlock()
+	st.s.logger.Info("BMP session established",
+		slog.String("Topic", "bmp"),
+		slog.String("Key", address.String()))
+
+	defer func() {
+		conn.Close()
+		st.mu.Lock()
+		if st.routers[address.Addr()] == r {
+			delete(st.routers, address.Addr())
+		}
+		st.mu.Unlock()
+		st.s.logger.Info("BMP session closed",
+			slog.String("Topic", "bmp"),
+			slog.String("Key", address.String()))
+	}()
+
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 0, bgp.BGP_MAX_MESSAGE_LENGTH), bmpStationMaxMessageSize)
+	scanner.Split(bmp.SplitBMP)
+	for scanner.Scan() {
+		data := scanner.Bytes()
+		msg, err := bmp.ParseBMPMessageWithOptions(data, func(h bmp.BMPPeerHeader) []*bgp.MarshallingOption {
+			st.mu.RLock()
+			defer st.mu.RUnlock()
+			if p, ok := r.peers[bmpStationPeerKey{typ: h.PeerType, distinguisher: h.PeerDistinguisher, address: h.PeerAddress}]; ok {
+				return []*bgp.MarshallingOption{{AddPath: p.addPath}}
+			}
+			return nil
+		})
+		if err != nil {
+			st.s.logger.Warn("failed to parse BMP message",
+				slog.String("Topic", "bmp"),
+				slog.String("Key", address.String()),
+				slog.String("Error", err.Error()))
+			continue
+		}
+		if body, ok := msg.Body.(*bmp.BMPRouteMonitoring); ok {
+			body.BGPUpdatePayload = slices.Clone(data[bmp.BMP_HEADER_SIZE+bmp.BMP_PEER_HEADER_SIZE:])
+		}
+		if !st.handleMessage(r, msg) {
+			return
+		}
+	}
+}
+
+func bmpTimestamp(h *bmp.BMPPeerHeader) time.Time {
+	if h.Timestamp == 0 {
+		return time.Now()
+	}
+	sec, frac := math.Modf(h.Timestamp)
+	return time.Unix(int64(sec), int64(frac*1e9))
+}
+
+// openAddPath returns the ADD-PATH modes of the updates which the sender of
+// the local OPEN message receives.
+func openAddPath(local, remote *bgp.BGPMessage) map[bgp.Family]bgp.BGPAddPathMode {
+	modes := func(m *bgp.BGPMessage) map[bgp.Family]bgp.BGPAddPathMode {
+		l := make(map[bgp.Family]bgp.BGPAddPathMode)
+		open, ok := m.Body.(*bgp.BGPOpen)
+		if !ok {
+			return l
+		}
+		for _, p := range open.OptParams {
+			if c, ok := p.(*bgp.OptionParameterCapability); ok {
+				for _, c := range c.Capability {
+					if a, ok := c.(*bgp.CapAddPath); ok {
+						for _, t := range a.Tuples {
+							l[t.Family] |= t.Mode
+						}
+					}
+				}
+			}
+		}
+		return l
+	}
+	l := make(map[bgp.Family]bgp.BGPAddPathMode)
+	if local == nil || remote == nil {
+		return l
+	}
+	remoteModes := modes(remote)
+	for f, m := range modes(local) {
+		if m&bgp.BGP_ADD_PATH_RECEIVE > 0 && remoteModes[f]&bgp.BGP_ADD_PATH_SEND > 0 {
+			l[f] = bgp.BGP_ADD_PATH_RECEIVE
+		}
+	}
+	return l
+}
+
+func openAS(m *bgp.BGPMessage) uint32 {
+	if m == nil {
+		return 0
+	}
+	open, ok := m.Body.(*bgp.BGPOpen)
+	if !ok {
+		return 0
+	}
+	for _, p := range open.OptParams {
+		if c, ok := p.(*bgp.OptionParameterCapability); ok {
+			for _, c := range c.Capability {
+				if a, ok := c.(*bgp.CapFourOctetASNumber); ok {
+					return a.CapValue
+				}
+			}
+		}
+	}
+	return uint32(open.MyAS)
+}
+
+// handleMessage updates the tables of the router with the message. Returns
+// false if the session is terminated.
+func (st *bmpStation) handleMessage(r *bmpRouter, msg *bmp.BMPMessage) bool {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	r.messages++
+
+	h := &msg.PeerHeader
+	key := bmpStationPeerKey{typ: h.PeerType, distinguisher: h.PeerDistinguisher, address: h.PeerAddress}
+	timestamp := bmpTimestamp(h)
+	peer := r.peers[key]
+	switch body := msg.Body.(type) {
+	case *bmp.BMPInitiation:
+		for _, tlv := range body.Info {
+			if s, ok := tlv.(*bmp.BMPInfoTLVString); ok {
+				switch s.Type {
+				case bmp.BMP_INIT_TLV_TYPE_SYS_NAME:
+					r.sysName = s.Value
+				case bmp.BMP_INIT_TLV_TYPE_SYS_DESCR:
+					r.sysDescr = s.Value
+				}
+			}
+		}
+	case *bmp.BMPTermination:
+		return false
+	case *bmp.BMPPeerUpNotification:
+		peer = newBmpStationPeer(st.s.logger, key, h.PeerAS, h.PeerBGPID, timestamp)
+		peer.localAddress = body.LocalAddress
+		peer.localPort = body.LocalPort
+		peer.remotePort = body.RemotePort
+		peer.localAS = openAS(body.SentOpenMsg)
+		peer.addPath = openAddPath(body.SentOpenMsg, body.ReceivedOpenMsg)
+		r.peers[key] = peer
+		// the Loc-RIB instances (RFC 9069) aren't BGP sessions
+		if st.mrt != nil && h.PeerType != bmp.BMP_PEER_TYPE_LOCAL_RIB {
+			st.mrt.stateChange(peer, mrt.IDLE, mrt.ESTABLISHED)
+		}
+	case *bmp.BMPPeerDownNotification:
+		if peer == nil {
+			break
+		}
+		families := bmpStationFamilies()
+		peer.up = false
+		peer.timestamp = timestamp
+		peer.adjRibInPre.Drop(families)
+		peer.adjRibInPost.Drop(families)
+		peer.locRib.Drop(families)
+		if st.mrt != nil && h.PeerType != bmp.BMP_PEER_TYPE_LOCAL_RIB {
+			st.mrt.stateChange(peer, mrt.ESTABLISHED, mrt.IDLE)
+		}
+	case *bmp.BMPRouteMonitoring:
+		if peer == nil || !peer.up {
+			// some routers send the routes of the Loc-RIB without the
+			// peer up notification
+			peer = newBmpStationPeer(st.s.logger, key, h.PeerAS, h.PeerBGPID, timestamp)
+			r.peers[key] = peer
+		}
+		if body.BGPUpdate == nil || body.BGPUpdate.Header.Type != bgp.BGP_MSG_UPDATE {
+			break
+		}
+		if h.IsAdjRIBOut() {
+			st.s.logger.Debug("ignore the routes of Adj-RIB-Out",
+				slog.String("Topic", "bmp"),
+				slog.String("Key", r.address.String()))
+			break
+		}
+		paths := table.ProcessMessage(body.BGPUpdate, peer.peerInfo(), timestamp, false)
+		switch {
+		case h.PeerType == bmp.BMP_PEER_TYPE_LOCAL_RIB:
+			peer.locRib.Update(paths)
+		case h.IsPostPolicy():
+			peer.adjRibInPost.Update(paths)
+		default:
+			peer.adjRibInPre.Update(paths)
+			if st.mrt != nil {
+				st.mrt.update(peer, h.Flags&bmp.BMP_PEER_FLAG_TWO_AS == 0, timestamp, body.BGPUpdate, body.BGPUpdatePayload)
+			}
+		}
+	case *bmp.BMPStatisticsReport:
+		if peer == nil {
+			break
+		}
+		for _, s := range body.Stats {
+			switch s := s.(type) {
+			case *bmp.BMPStatsTLV32:
+				peer.stats[s.Type] = uint64(s.Value)
+			case *bmp.BMPStatsTLV64:
+				peer.stats[s.Type] = s.Value
+			}
+		}
+	}
+	return true
+}
+
+func (st *bmpStation) listRouter(fn func(*api.BmpRouter)) {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	routers := make([]*bmpRouter, 0, len(st.routers))
+	for _, r := range st.routers {
+		routers = append(routers, r)
+	}
+	sort.Slice(routers, func(i, j int) bool {
+		return routers[i].address.Addr().Less(routers[j].address.Addr())
+	})
+	for _, r := range routers {
+		fn(r.toApi())
+	}
+}
+
+func (st *bmpStation) listPeer(router netip.Addr, fn func(*api.BmpMonitoredPeer)) {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	for _, r := range st.routers {
+		if router.IsValid() && r.address.Addr() != router {
+			continue
+		}
+		peers := make([]*bmpStationPeer, 0, len(r.peers))
+		for _, p := range r.peers {
+			peers = append(peers, p)
+		}
+		sort.Slice(peers, func(i, j int) bool {
+			a, b := peers[i].key, peers[j].key
+			if a.typ != b.typ {
+				return a.typ < b.typ
+			}
+			if a.distinguisher != b.distinguisher {
+				return a.distinguisher < b.distinguisher
+			}
+			return a.address.Less(b.address)
+		})
+		for _, p := range peers {
+			fn(p.toApi(r.address.Addr().String()))
+		}
+	}
+}
+
+// selectRib returns a copy of the table of the router.
+func (st *bmpStation) selectRib(router netip.Addr, ribType api.ListBmpRouteRequest_RibType, peerAddress netip.Addr, distinguisher uint64, family bgp.Family) (*table.Table, error) {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	r, ok := st.routers[router]
+	if !ok {
+		return nil, fmt.Errorf("router %s not found", router)
+	}
+	var peer *bmpStationPeer
+	for _, p := range r.peers {
+		if p.key.distinguisher != distinguisher {
+			continue
+		}
+		if ribType == api.ListBmpRouteRequest_RIB_TYPE_LOC_RIB {
+			if p.key.typ == bmp.BMP_PEER_TYPE_LOCAL_RIB {
+				peer = p
+				break
+			}
+		} else if p.key.typ != bmp.BMP_PEER_TYPE_LOCAL_RIB && p.key.address == peerAddress {
+			peer = p
+			break
+		}
+	}
+	if peer == nil {
+		return nil, fmt.Errorf("peer %s not found", peerAddress)
+	}
+	var rib *table.AdjRib
+	switch ribType {
+	case api.ListBmpRouteRequest_RIB_TYPE_ADJ_IN_PRE:
+		rib = peer.adjRibInPre
+	case api.ListBmpRouteRequest_RIB_TYPE_ADJ_IN_POST:
+		rib = peer.adjRibInPost
+	case api.ListBmpRouteRequest_RIB_TYPE_LOC_RIB:
+		rib = peer.locRib
+	default:
+		return nil, fmt.Errorf("unknown rib type %s", ribType)
+	}
+	return rib.Select(family, false)
+}
+
+// bmpStationMrt re-exports the routes collected by the station into MRT.
+type bmpStationMrt struct {
+	st               *bmpStation
+	c                *oc.BmpStationConfig
+	collectorID      netip.Addr
+	file             *os.File
+	rotationInterval uint64
+	dumpInterval     uint64
+	msgCh            chan []*mrt.MRTMessage
+	cancel           context.CancelFunc
+	done             chan struct{}
+}
+
+func newBmpStationMrt(st *bmpStation, c *oc.BmpStationConfig) (*bmpStationMrt, error) {
+	dumpType := c.MrtDumpType
+	if dumpType == "" {
+		dumpType = oc.MRT_TYPE_UPDATES
+	}
+	rInterval, dInterval, err := mrtIntervals(st.s.logger, dumpType, c.MrtRotationInterval, c.MrtDumpInterval)
+	if err != nil {
+		return nil, err
+	}
+	file, err := mrtFileOpen(st.s.logger, c.MrtFileName, rInterval)
+	if err != nil {
+		return nil, err
+	}
+	// the station may run without BGP
+	collectorID := netip.IPv4Unspecified()
+	if routerID := st.s.bgpConfig.Global.Config.RouterId; routerID.IsValid() {
+		collectorID = routerID
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	m := &bmpStationMrt{
+		st:               st,
+		c:                c,
+		collectorID:      collectorID,
+		file:             file,
+		rotationInterval: rInterval,
+		dumpInterval:     dInterval,
+		cancel:           cancel,
+		done:             make(chan struct{}),
+	}
+	if dumpType == oc.MRT_TYPE_UPDATES {
+		m.msgCh = make(chan []*mrt.MRTMessage, 1024)
+	}
+	go m.loop(ctx)
+	return m, nil
+}
+
+func (m *bmpStationMrt) stop() {
+	m.cancel()
+	<-m.done
+}
+
+func (m *bmpStationMrt) send(msgs ...*mrt.MRTMessage) {
+	if m.msgCh == nil {
+		return
+	}
+	m.msgCh <- msgs
+}
+
+func (m *bmpStationMrt) update(peer *bmpStationPeer, fourBytesAs bool, timestamp time.Time, msg *bgp.BGPMessage, payload []byte) {
+	if m.msgCh == nil {
+		return
+	}
+	u := msg.Body.(*bgp.BGPUpdate)
+	isAddPath := false
+	for _, p := range u.PathAttributes {
+		switch a := p.(type) {
+		case *bgp.PathAttributeMpReachNLRI:
+			isAddPath = peer.isAddPath(bgp.NewFamily(a.AFI, a.SAFI))
+		case *bgp.PathAttributeMpUnreachNLRI:
+			isAddPath = peer.isAddPath(bgp.NewFamily(a.AFI, a.SAFI))
+		}
+	}
+	if len(u.NLRI) > 0 || len(u.WithdrawnRoutes) > 0 {
+		isAddPath = peer.isAddPath(bgp.RF_IPv4_UC)
+	}
+	mp, _ := mrt.NewBGP4MPMessage(peer.as, peer.localAS, 0, peer.key.address, m.localAddress(peer), fourBytesAs, nil)
+	mp.BGPMessagePayload = payload
+	bm, err := mrt.NewMRTMessage(timestamp, mrt.BGP4MP, mrtMessageSubtype(isAddPath, fourBytesAs), mp)
+	if err != nil {
+		m.st.s.logger.Warn("Failed to create MRT BGP4MP message",
+			slog.String("Topic", "mrt"),
+			slog.String("Error", err.Error()))
+		return
+	}
+	m.send(bm)
+}
+
+func (m *bmpStationMrt) localAddress(peer *bmpStationPeer) netip.Addr {
+	if peer.localAddress.IsValid() {
+		return peer.localAddress
+	}
+	if peer.key.address.Is6() {
+		return netip.IPv6Unspecified()
+	}
+	return netip.IPv4Unspecified()
+}
+
+func (m *bmpStationMrt) stateChange(peer *bmpStationPeer, oldState, newState mrt.BGPState) {
+	if m.msgCh == nil {
+		return
+	}
+	sc, err := mrt.NewBGP4MPStateChange(peer.as, peer.localAS, 0, peer.key.address, m.localAddress(peer), true, oldState, newState)
+	if err != nil {
+		return
+	}
+	if bm, err := mrt.NewMRTMessage(time.Now(), mrt.BGP4MP, mrt.STATE_CHANGE_AS4, sc); err == nil {
+		m.send(bm)
+	}
+}
+
+// dumpTable dumps the pre-policy Adj-RIB-In of all the monitored peers.
+func (m *bmpStationMrt) dumpTable() []*mrt.MRTMessage {
+	st := m.st
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+
+	t := time.Now()
+	type group struct {
+		nlri     bgp.NLRI
+		entries  []*mrt.RibEntry
+		addPaths []*mrt.RibEntry
+	}
+	groups := make(map[bgp.Family]map[string]*group)
+	peers := make([]*mrt.Peer, 0)
+	addrs := make([]netip.Addr, 0, len(st.routers))
+	for a := range st.routers {
+		addrs = append(addrs, a)
+	}
+	slices.SortFunc(addrs, func(a, b netip.Addr) int { return a.Compare(b) })
+	for _, a := range addrs {
+		r := st.routers[a]
+		for _, peer := range r.peers {
+			if peer.key.typ == bmp.BMP_PEER_TYPE_LOCAL_RIB || !peer.up {
+				continue
+			}
+			idx := uint16(len(peers))
+			peers = append(peers, mrt.NewPeer(peer.id, peer.key.address, peer.as, true))
+			for _, path := range peer.adjRibInPre.PathList(bmpStationFamilies(), false) {
+				family := path.GetFamily()
+				if groups[family] == nil {
+					groups[family] = make(map[string]*group)
+				}
+				key := path.GetNlri().String()
+				g, ok := groups[family][key]
+				if !ok {
+					g = &group{nlri: path.GetNlri()}
+					groups[family][key] = g
+				}
+				if peer.isAddPath(family) {
+					g.addPaths = append(g.addPaths, mrt.NewRibEntry(idx, uint32(path.GetTimestamp().Unix()), path.RemoteID(), path.GetPathAttrs(), true))
+				} else {
+					g.entries = append(g.entries, mrt.NewRibEntry(idx, uint32(path.GetTimestamp().Unix()), 0, path.GetPathAttrs(), false))
+				}
+			}
+		}
+	}
+
+	bm, err := mrt.NewMRTMessage(t, mrt.TABLE_DUMPv2, mrt.PEER_INDEX_TABLE, mrt.NewPeerIndexTable(m.collectorID, "", peers))
+	if err != nil {
+		return nil
+	}
+	msgs := []*mrt.MRTMessage{bm}
+	seq := uint32(0)
+	appendTableDumpMsg := func(family bgp.Family, nlri bgp.NLRI, entries []*mrt.RibEntry, isAddPath bool) {
+		if len(entries) == 0 {
+			return
+		}
+		if bm, err := mrt.NewMRTMessage(t, mrt.TABLE_DUMPv2, mrt.RibSubtype(family, isAddPath), mrt.NewRib(seq, family, nlri, entries)); err != nil {
+			st.s.logger.Warn("Failed to create MRT TABLE_DUMPv2 message",
+				slog.String("Topic", "mrt"),
+				slog.String("Error", err.Error()))
+		} else {
+			msgs = append(msgs, bm)
+			seq++
+		}
+	}
+	for family, l := range groups {
+		for _, g := range l {
+			appendTableDumpMsg(family, g.nlri, g.entries, false)
+			appendTableDumpMsg(family, g.nlri, g.addPaths, true)
+		}
+	}
+	return msgs
+}
+
+func (m *bmpStationMrt) write(msgs []*mrt.MRTMessage) {
+	var b bytes.Buffer
+	for _, msg := range msgs {
+		buf, err := msg.Serialize()
+		if err != nil {
+			m.st.s.logger.Warn("Failed to serialize event",
+				slog.String("Topic", "mrt"),
+				slog.String("Error", err.Error()))
+			continue
+		}
+		b.Write(buf)
+	}
+	if b.Len() == 0 || m.file == nil {
+		return
+	}
+	if _, err := m.file.Write(b.Bytes()); err == nil {
+		m.file.Sync()
+	} else {
+		m.st.s.logger.Warn("Can't write to destination MRT file",
+			slog.String("Topic", "mrt"),
+			slog.String("Error", err.Error()))
+	}
+}
+
+func (m *bmpStationMrt) rotate() {
+	m.file.Close()
+	file, err := mrtFileOpen(m.st.s.logger, m.c.MrtFileName, m.rotationInterval)
+	if err != nil {
+		m.file = nil
+		m.st.s.logger.Warn("can't rotate MRT file",
+			slog.String("Topic", "mrt"),
+			slog.String("Error", err.Error()))
+		return
+	}
+	m.file = file
+}
+
+func (m *bmpStationMrt) loop(ctx context.Context) {
+	defer close(m.done)
+	ticker := func(interval uint64) (*time.Ticker, <-chan time.Time) {
+		if interval == 0 {
+			return nil, nil
+		}
+		t := time.NewTicker(time.Second * time.Duration(interval))
+		return t, t.C
+	}
+	rotator, rotatorCh := ticker(m.rotationInterval)
+	dump, dumpCh := ticker(m.dumpInterval)
+	defer func() {
+		if rotator != nil {
+			rotator.Stop()
+		}
+		if dump != nil {
+			dump.Stop()
+		}
+		if m.file != nil {
+			m.file.Close()
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			for {
+				select {
+				case msgs := <-m.msgCh:
+					m.write(msgs)
+				default:
+					return
+				}
+			}
+		case msgs := <-m.msgCh:
+			for len(m.msgCh) > 0 {
+				msgs = append(msgs, <-m.msgCh...)
+			}
+			m.write(msgs)
+		case <-rotatorCh:
+			m.rotate()
+			if m.msgCh == nil {
+				m.write(m.dumpTable())
+			}
+		case <-dumpCh:
+			m.write(m.dumpTable())
+		}
+	}
+}
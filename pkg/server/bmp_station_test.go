@@ -0,0 +1,208 @@
+// Copyright (C) 2025 Acnodal Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/netip"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/osrg/gobgp/v4/api"
+	"github.com/osrg/gobgp/v4/pkg/apiutil"
+	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
+	"github.com/osrg/gobgp/v4/pkg/packet/bmp"
+	"github.com/osrg/gobgp/v4/pkg/packet/mrt"
+)
+
+func bmpStationTestUpdate(t *testing.T, prefixes ...string) *bgp.BGPMessage {
+	nexthop, _ := bgp.NewPathAttributeNextHop(netip.MustParseAddr("10.0.0.2"))
+	attrs := []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+		bgp.NewPathAttributeAsPath([]bgp.AsPathParamInterface{bgp.NewAs4PathParam(bgp.BGP_ASPATH_ATTR_TYPE_SEQ, []uint32{65001})}),
+		nexthop,
+	}
+	nlri := make([]bgp.PathNLRI, 0, len(prefixes))
+	for _, p := range prefixes {
+		n, err := bgp.NewIPAddrPrefix(netip.MustParsePrefix(p))
+		require.NoError(t, err)
+		nlri = append(nlri, bgp.PathNLRI{NLRI: n})
+	}
+	return bgp.NewBGPUpdateMessage(nil, attrs, nlri)
+}
+
+func TestBmpStation(t *testing.T) {
+	assert := assert.New(t)
+	s := NewBgpServer()
+	go s.Serve()
+	defer s.Stop()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := l.Addr().(*net.TCPAddr).Port
+	l.Close()
+
+	// the name of the rotated file is formatted with the time
+	t.Chdir(t.TempDir())
+	filename := "updates.mrt"
+	require.NoError(t, s.EnableBmpStation(context.Background(), &api.EnableBmpStationRequest{
+		Address:     "127.0.0.1",
+		Port:        uint32(port),
+		MrtFilename: filename,
+		MrtDumpType: api.EnableMrtRequest_DUMP_TYPE_UPDATES,
+	}))
+	assert.Error(s.EnableBmpStation(context.Background(), &api.EnableBmpStationRequest{Port: uint32(port)}))
+
+	conn, err := net.Dial("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(port)))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	peerAddr := netip.MustParseAddr("10.0.0.2")
+	peerID := netip.MustParseAddr("2.2.2.2")
+	ts := float64(time.Now().Unix())
+	pre := bmp.NewBMPPeerHeader(bmp.BMP_PEER_TYPE_GLOBAL, 0, 0, peerAddr, 65001, peerID, ts)
+	post := bmp.NewBMPPeerHeader(bmp.BMP_PEER_TYPE_GLOBAL, bmp.BMP_PEER_FLAG_POST_POLICY, 0, peerAddr, 65001, peerID, ts)
+	locRib := bmp.NewBMPPeerHeader(bmp.BMP_PEER_TYPE_LOCAL_RIB, 0, 0, netip.IPv4Unspecified(), 65000, netip.MustParseAddr("1.1.1.1"), ts)
+
+	caps := func(as uint32) []bgp.OptionParameterInterface {
+		return []bgp.OptionParameterInterface{bgp.NewOptionParameterCapability([]bgp.ParameterCapabilityInterface{
+			bgp.NewCapMultiProtocol(bgp.RF_IPv4_UC),
+			bgp.NewCapFourOctetASNumber(as),
+		})}
+	}
+	sent, _ := bgp.NewBGPOpenMessage(65000, 90, netip.MustParseAddr("1.1.1.1"), caps(65000))
+	recv, _ := bgp.NewBGPOpenMessage(65001, 90, peerID, caps(65001))
+
+	msgs := []*bmp.BMPMessage{
+		bmp.NewBMPInitiation([]bmp.BMPInfoTLVInterface{
+			bmp.NewBMPInfoTLVString(bmp.BMP_INIT_TLV_TYPE_SYS_NAME, "router1"),
+			bmp.NewBMPInfoTLVString(bmp.BMP_INIT_TLV_TYPE_SYS_DESCR, "test router"),
+		}),
+		bmp.NewBMPPeerUpNotification(*pre, netip.MustParseAddr("10.0.0.1"), 179, 10000, sent, recv),
+		bmp.NewBMPRouteMonitoring(*pre, bmpStationTestUpdate(t, "192.168.0.0/24", "192.168.1.0/24")),
+		bmp.NewBMPRouteMonitoring(*post, bmpStationTestUpdate(t, "192.168.0.0/24")),
+		bmp.NewBMPRouteMonitoring(*locRib, bmpStationTestUpdate(t, "192.168.0.0/24", "192.168.1.0/24", "192.168.2.0/24")),
+		bmp.NewBMPStatisticsReport(*pre, []bmp.BMPStatsTLVInterface{
+			bmp.NewBMPStatsTLV32(bmp.BMP_STAT_TYPE_REJECTED, 1),
+		}),
+	}
+	w := bufio.NewWriter(conn)
+	for _, m := range msgs {
+		b, err := m.Serialize()
+		require.NoError(t, err)
+		_, err = w.Write(b)
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Flush())
+
+	listPeers := func() []*api.BmpMonitoredPeer {
+		l := make([]*api.BmpMonitoredPeer, 0)
+		require.NoError(t, s.ListBmpMonitoredPeer(context.Background(), &api.ListBmpMonitoredPeerRequest{Router: "127.0.0.1"}, func(p *api.BmpMonitoredPeer) {
+			l = append(l, p)
+		}))
+		return l
+	}
+	require.Eventually(t, func() bool {
+		l := listPeers()
+		return len(l) == 2 && len(l[0].Statistics) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	routers := make([]*api.BmpRouter, 0)
+	require.NoError(t, s.ListBmpRouter(context.Background(), &api.ListBmpRouterRequest{}, func(r *api.BmpRouter) {
+		routers = append(routers, r)
+	}))
+	require.Len(t, routers, 1)
+	assert.Equal("router1", routers[0].SysName)
+	assert.Equal("test router", routers[0].SysDescr)
+	assert.Equal(uint64(6), routers[0].Messages)
+
+	peers := listPeers()
+	assert.Equal(api.BmpMonitoredPeer_TYPE_GLOBAL, peers[0].Type)
+	assert.Equal("10.0.0.2", peers[0].Address)
+	assert.Equal(uint32(65001), peers[0].Asn)
+	assert.True(peers[0].Up)
+	assert.Equal("10.0.0.1", peers[0].LocalAddress)
+	assert.Equal(uint64(2), peers[0].AdjRibInPre)
+	assert.Equal(uint64(1), peers[0].AdjRibInPost)
+	assert.Equal(uint64(1), peers[0].Statistics[uint32(bmp.BMP_STAT_TYPE_REJECTED)])
+	assert.Equal(api.BmpMonitoredPeer_TYPE_LOC_RIB, peers[1].Type)
+	assert.Equal(uint64(3), peers[1].LocRib)
+
+	listRoutes := func(ribType api.ListBmpRouteRequest_RibType, peer string) []string {
+		l := make([]string, 0)
+		require.NoError(t, s.ListBmpRoute(context.Background(), &api.ListBmpRouteRequest{
+			Router:  "127.0.0.1",
+			RibType: ribType,
+			Peer:    peer,
+			Family:  &api.Family{Afi: api.Family_AFI_IP, Safi: api.Family_SAFI_UNICAST},
+		}, func(prefix bgp.NLRI, paths []*apiutil.Path) {
+			l = append(l, prefix.String())
+			assert.Len(paths, 1)
+		}))
+		return l
+	}
+	assert.ElementsMatch([]string{"192.168.0.0/24", "192.168.1.0/24"}, listRoutes(api.ListBmpRouteRequest_RIB_TYPE_ADJ_IN_PRE, "10.0.0.2"))
+	assert.ElementsMatch([]string{"192.168.0.0/24"}, listRoutes(api.ListBmpRouteRequest_RIB_TYPE_ADJ_IN_POST, "10.0.0.2"))
+	assert.Len(listRoutes(api.ListBmpRouteRequest_RIB_TYPE_LOC_RIB, ""), 3)
+	assert.Error(s.ListBmpRoute(context.Background(), &api.ListBmpRouteRequest{
+		Router:  "127.0.0.1",
+		RibType: api.ListBmpRouteRequest_RIB_TYPE_ADJ_IN_PRE,
+		Peer:    "10.0.0.3",
+		Family:  &api.Family{Afi: api.Family_AFI_IP, Safi: api.Family_SAFI_UNICAST},
+	}, func(bgp.NLRI, []*apiutil.Path) {}))
+
+	// the tables of the peer are dropped on peer down
+	b, err := bmp.NewBMPPeerDownNotification(*pre, bmp.BMP_PEER_DOWN_REASON_REMOTE_NO_NOTIFICATION, nil, nil).Serialize()
+	require.NoError(t, err)
+	_, err = conn.Write(b)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return !listPeers()[0].Up
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(uint64(0), listPeers()[0].AdjRibInPre)
+	assert.Empty(listRoutes(api.ListBmpRouteRequest_RIB_TYPE_ADJ_IN_PRE, "10.0.0.2"))
+
+	require.NoError(t, s.DisableBmpStation(context.Background(), &api.DisableBmpStationRequest{}))
+	assert.Error(s.ListBmpRouter(context.Background(), &api.ListBmpRouterRequest{}, func(*api.BmpRouter) {}))
+
+	// peer up, the pre-policy update and peer down are re-exported
+	f, err := os.Open(filename)
+	require.NoError(t, err)
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	scanner.Split(mrt.SplitMrt)
+	subtypes := make([]mrt.MRTSubTypeBGP4MP, 0)
+	for scanner.Scan() {
+		data := scanner.Bytes()
+		h, err := mrt.ParseHeader(data[:mrt.MRT_COMMON_HEADER_LEN])
+		require.NoError(t, err)
+		m, err := mrt.ParseBody(data[mrt.MRT_COMMON_HEADER_LEN:], h)
+		require.NoError(t, err)
+		subtypes = append(subtypes, mrt.MRTSubTypeBGP4MP(m.Header.SubType))
+		if msg, ok := m.Body.(*mrt.BGP4MPMessage); ok {
+			assert.Equal(peerAddr, msg.PeerIpAddress)
+			assert.Len(msg.BGPMessage.Body.(*bgp.BGPUpdate).NLRI, 2)
+		}
+	}
+	assert.Equal([]mrt.MRTSubTypeBGP4MP{mrt.STATE_CHANGE_AS4, mrt.MESSAGE_AS4, mrt.STATE_CHANGE_AS4}, subtypes)
+}
@@ -0,0 +1,406 @@
+// Copyright (C) 2025 Acnodal Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/osrg/gobgp/v4/api"
+	"github.com/osrg/gobgp/v4/internal/pkg/table"
+	"github.com/osrg/gobgp/v4/pkg/apiutil"
+	"github.com/osrg/gobgp/v4/pkg/config/oc"
+	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
+	"github.com/osrg/gobgp/v4/pkg/packet/bmp"
+)
+
+type bmpTestCollector struct {
+	mu sync.Mutex
+	// the Loc-RIB instances by the table names
+	locRibInstances map[string]uint64
+	// the Loc-RIB prefixes by the peer distinguishers
+	locRib map[uint64][]string
+	// the Adj-RIB-Out prefixes, pre-policy and post-policy
+	adjRibOut [2][]string
+	stats     map[uint16]uint64
+}
+
+func (c *bmpTestCollector) handle(m *bmp.BMPMessage) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	h := &m.PeerHeader
+	switch body := m.Body.(type) {
+	case *bmp.BMPPeerUpNotification:
+		if h.PeerType != bmp.BMP_PEER_TYPE_LOCAL_RIB {
+			return
+		}
+		for _, tlv := range body.Info {
+			if s, ok := tlv.(*bmp.BMPInfoTLVString); ok && s.Type == bmp.BMP_PEER_UP_TLV_TYPE_VRF_TABLE_NAME {
+				c.locRibInstances[s.Value] = h.PeerDistinguisher
+			}
+		}
+	case *bmp.BMPRouteMonitoring:
+		u := body.BGPUpdate.Body.(*bgp.BGPUpdate)
+		for _, n := range u.NLRI {
+			switch {
+			case h.PeerType == bmp.BMP_PEER_TYPE_LOCAL_RIB:
+				c.locRib[h.PeerDistinguisher] = append(c.locRib[h.PeerDistinguisher], n.NLRI.String())
+			case h.IsAdjRIBOut() && h.IsPostPolicy():
+				c.adjRibOut[1] = append(c.adjRibOut[1], n.NLRI.String())
+			case h.IsAdjRIBOut():
+				c.adjRibOut[0] = append(c.adjRibOut[0], n.NLRI.String())
+			}
+		}
+	case *bmp.BMPStatisticsReport:
+		for _, s := range body.Stats {
+			if s, ok := s.(*bmp.BMPStatsTLV64); ok {
+				c.stats[s.Type] = s.Value
+			}
+		}
+	}
+}
+
+func (c *bmpTestCollector) check(fn func(c *bmpTestCollector) bool) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return fn(c)
+}
+
+func TestBmpAdjRibOutAndLocRib(t *testing.T) {
+	ctx := context.Background()
+	s1 := runNewServer(t, 1, "1.1.1.1", 10179)
+	defer s1.StopBgp(ctx, &api.StopBgpRequest{})
+	s2 := runNewServer(t, 2, "2.2.2.2", 20179)
+	defer s2.StopBgp(ctx, &api.StopBgpRequest{})
+
+	addVrf(t, s1, "vrf1", "111:111", []string{"111:111"}, []string{"111:111"}, 1)
+
+	// rejects 10.0.2.0/24 toward the peers
+	ps, err := table.NewPrefixSet(oc.PrefixSet{
+		PrefixSetName: "ps1",
+		PrefixList:    []oc.Prefix{{IpPrefix: netip.MustParsePrefix("10.0.2.0/24"), MasklengthRange: "24..24"}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, s1.policy.AddDefinedSet(ps, false))
+	p, err := table.NewPolicy(oc.PolicyDefinition{
+		Name: "reject",
+		Statements: []oc.Statement{{
+			Name:       "stmt1",
+			Conditions: oc.Conditions{MatchPrefixSet: oc.MatchPrefixSet{PrefixSet: "ps1"}},
+			Actions:    oc.Actions{RouteDisposition: oc.ROUTE_DISPOSITION_REJECT_ROUTE},
+		}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, s1.policy.AddPolicy(p, false))
+	require.NoError(t, s1.policy.AddPolicyAssignment(table.GLOBAL_RIB_NAME, table.POLICY_DIRECTION_EXPORT, []*oc.PolicyDefinition{{Name: "reject"}}, table.ROUTE_TYPE_ACCEPT))
+
+	wg := waitEstablished(s1)
+	require.NoError(t, peerServers(t, ctx, []*BgpServer{s1, s2}, []oc.AfiSafiType{oc.AFI_SAFI_TYPE_IPV4_UNICAST}))
+	wg.Wait()
+
+	newPath := func(prefix string) *api.Path {
+		nh, _ := bgp.NewPathAttributeNextHop(netip.MustParseAddr("1.1.1.1"))
+		nlri, _ := bgp.NewIPAddrPrefix(netip.MustParsePrefix(prefix))
+		path, _ := apiutil.NewPath(bgp.RF_IPv4_UC, nlri, false, []bgp.PathAttributeInterface{bgp.NewPathAttributeOrigin(0), nh}, time.Now())
+		return path
+	}
+	addPath := func(vrf string, prefix string) {
+		_, err := s1.AddPath(apiutil.AddPathRequest{VRFID: vrf, Paths: []*apiutil.Path{mustApi2apiutilPath(newPath(prefix))}})
+		require.NoError(t, err)
+	}
+	addPath("", "10.0.1.0/24")
+	addPath("", "10.0.2.0/24")
+	addPath("vrf1", "10.1.0.0/24")
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	c := &bmpTestCollector{
+		locRibInstances: make(map[string]uint64),
+		locRib:          make(map[uint64][]string),
+		stats:           make(map[uint16]uint64),
+	}
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		scanner := bufio.NewScanner(conn)
+		scanner.Split(bmp.SplitBMP)
+		for scanner.Scan() {
+			m, err := bmp.ParseBMPMessage(scanner.Bytes())
+			if err != nil {
+				continue
+			}
+			c.handle(m)
+		}
+	}()
+
+	port := uint32(l.Addr().(*net.TCPAddr).Port)
+	require.NoError(t, s1.mgmtOperation(func() error {
+		return s1.bmpManager.addServer(&oc.BmpServerConfig{
+			Address:                    netip.MustParseAddr("127.0.0.1"),
+			Port:                       port,
+			RouteMonitoringPolicy:      oc.BMP_ROUTE_MONITORING_POLICY_TYPE_LOCAL_RIB,
+			StatisticsTimeout:          1,
+			AdjRibOutPrePolicyEnabled:  true,
+			AdjRibOutPostPolicyEnabled: true,
+		})
+	}, true))
+	defer s1.DeleteBmp(ctx, &api.DeleteBmpRequest{Address: "127.0.0.1", Port: port})
+
+	// the initial routes and then the ones sent after the connection
+	require.Eventually(t, func() bool {
+		return c.check(func(c *bmpTestCollector) bool {
+			return len(c.adjRibOut[1]) == 1
+		})
+	}, 10*time.Second, 10*time.Millisecond)
+	addPath("", "10.0.3.0/24")
+
+	rd, _ := bgp.ParseRouteDistinguisher("111:111")
+	buf, _ := rd.Serialize()
+	dist := uint64(0)
+	for _, b := range buf {
+		dist = dist<<8 | uint64(b)
+	}
+	require.Eventually(t, func() bool {
+		return c.check(func(c *bmpTestCollector) bool {
+			return len(c.adjRibOut[0]) == 3 && len(c.adjRibOut[1]) == 2 && len(c.locRib[dist]) == 1 && c.stats[bmp.BMP_STAT_TYPE_ADJ_RIB_OUT_POST_POLICY] == 2
+		})
+	}, 10*time.Second, 10*time.Millisecond)
+
+	c.check(func(c *bmpTestCollector) bool {
+		assert.ElementsMatch(t, []string{"10.0.1.0/24", "10.0.2.0/24", "10.0.3.0/24"}, c.adjRibOut[0])
+		assert.ElementsMatch(t, []string{"10.0.1.0/24", "10.0.3.0/24"}, c.adjRibOut[1])
+		assert.Equal(t, map[string]uint64{table.GLOBAL_RIB_NAME: 0, "vrf1": dist}, c.locRibInstances)
+		assert.Equal(t, []string{"10.1.0.0/24"}, c.locRib[dist])
+		assert.True(t, slices.Contains(c.locRib[0], "10.0.3.0/24"))
+		assert.Equal(t, uint64(3), c.stats[bmp.BMP_STAT_TYPE_ADJ_RIB_OUT_PRE_POLICY])
+		return true
+	})
+
+	// the withdrawn routes aren't counted anymore
+	require.NoError(t, s1.DeletePath(apiutil.DeletePathRequest{Paths: []*apiutil.Path{mustApi2apiutilPath(newPath("10.0.1.0/24"))}}))
+	require.Eventually(t, func() bool {
+		return c.check(func(c *bmpTestCollector) bool {
+			return c.stats[bmp.BMP_STAT_TYPE_ADJ_RIB_OUT_PRE_POLICY] == 2 && c.stats[bmp.BMP_STAT_TYPE_ADJ_RIB_OUT_POST_POLICY] == 1
+		})
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+func newBmpTestCert(t *testing.T, dir string, name string, template *x509.Certificate, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name+".pem"), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name+".key"), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600))
+	return cert, key
+}
+
+func TestBmpTLSResync(t *testing.T) {
+	ctx := context.Background()
+	s1 := runNewServer(t, 1, "1.1.1.1", 10179)
+	defer s1.StopBgp(ctx, &api.StopBgpRequest{})
+
+	nh, _ := bgp.NewPathAttributeNextHop(netip.MustParseAddr("1.1.1.1"))
+	nlri, _ := bgp.NewIPAddrPrefix(netip.MustParsePrefix("10.0.1.0/24"))
+	path, _ := apiutil.NewPath(bgp.RF_IPv4_UC, nlri, false, []bgp.PathAttributeInterface{bgp.NewPathAttributeOrigin(0), nh}, time.Now())
+	_, err := s1.AddPath(apiutil.AddPathRequest{Paths: []*apiutil.Path{mustApi2apiutilPath(path)}})
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	notAfter := time.Now().Add(time.Hour)
+	ca, caKey := newBmpTestCert(t, dir, "ca", &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}, nil, nil)
+	server, serverKey := newBmpTestCert(t, dir, "server", &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "collector"},
+		NotAfter:     notAfter,
+		DNSNames:     []string{"collector"},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, ca, caKey)
+	newBmpTestCert(t, dir, "client", &x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{CommonName: "client"},
+		NotAfter:     notAfter,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, ca, caKey)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+	l, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{server.Raw}, PrivateKey: serverKey}},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    pool,
+	})
+	require.NoError(t, err)
+	defer l.Close()
+
+	// the Loc-RIB routes and End-of-RIBs received over each connection
+	type session struct {
+		routes []string
+		eors   int
+	}
+	var mu sync.Mutex
+	var sessions []*session
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				ss := &session{}
+				mu.Lock()
+				sessions = append(sessions, ss)
+				first := len(sessions) == 1
+				mu.Unlock()
+				scanner := bufio.NewScanner(conn)
+				scanner.Split(bmp.SplitBMP)
+				for scanner.Scan() {
+					m, err := bmp.ParseBMPMessage(scanner.Bytes())
+					if err != nil {
+						continue
+					}
+					body, ok := m.Body.(*bmp.BMPRouteMonitoring)
+					if !ok || m.PeerHeader.PeerType != bmp.BMP_PEER_TYPE_LOCAL_RIB {
+						continue
+					}
+					u := body.BGPUpdate.Body.(*bgp.BGPUpdate)
+					mu.Lock()
+					if len(u.NLRI) == 0 && len(u.WithdrawnRoutes) == 0 && len(u.PathAttributes) == 0 {
+						ss.eors++
+					}
+					for _, n := range u.NLRI {
+						ss.routes = append(ss.routes, n.NLRI.String())
+					}
+					done := first && ss.eors > 0
+					mu.Unlock()
+					if done {
+						return
+					}
+				}
+			}()
+		}
+	}()
+
+	port := uint32(l.Addr().(*net.TCPAddr).Port)
+	require.NoError(t, s1.AddBmp(ctx, &api.AddBmpRequest{
+		Address:             "127.0.0.1",
+		Port:                port,
+		Policy:              api.AddBmpRequest_MONITORING_POLICY_LOCAL,
+		Tls:                 true,
+		TlsCaFile:           filepath.Join(dir, "ca.pem"),
+		TlsCertFile:         filepath.Join(dir, "client.pem"),
+		TlsKeyFile:          filepath.Join(dir, "client.key"),
+		TlsServerName:       "collector",
+		QueueSize:           16,
+		QueueOverflowAction: api.AddBmpRequest_QUEUE_OVERFLOW_ACTION_DISCONNECT,
+	}))
+	defer s1.DeleteBmp(ctx, &api.DeleteBmpRequest{Address: "127.0.0.1", Port: port})
+
+	// the collector closes the first session after the resync and the
+	// client reconnects and resyncs again.
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(sessions) == 2 && sessions[1].eors > 0
+	}, 10*time.Second, 10*time.Millisecond)
+	mu.Lock()
+	for _, ss := range sessions {
+		assert.Equal(t, []string{"10.0.1.0/24"}, ss.routes)
+		assert.Equal(t, 1, ss.eors)
+	}
+	mu.Unlock()
+
+	require.Eventually(t, func() bool {
+		var station *api.ListBmpResponse_BmpStation
+		require.NoError(t, s1.ListBmp(ctx, &api.ListBmpRequest{}, func(s *api.ListBmpResponse_BmpStation) {
+			station = s
+		}))
+		return station.Conf.Tls && station.Conf.QueueSize == 16 && station.State.Resyncs == 2
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// a CA not trusting the collector fails the handshake
+	other := t.TempDir()
+	newBmpTestCert(t, other, "ca", &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "other"},
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}, nil, nil)
+	conf, err := newClientTLSConfig(netip.MustParseAddr("127.0.0.1"), filepath.Join(other, "ca.pem"), "", "", "collector")
+	require.NoError(t, err)
+	conn, err := tls.Dial("tcp", l.Addr().String(), conf)
+	if err == nil {
+		conn.Close()
+	}
+	assert.Error(t, err)
+}
+
+func TestBmpRouteMonitoringPolicyFromAPI(t *testing.T) {
+	for p, want := range map[api.AddBmpRequest_MonitoringPolicy]oc.BmpRouteMonitoringPolicyType{
+		api.AddBmpRequest_MONITORING_POLICY_UNSPECIFIED: oc.BMP_ROUTE_MONITORING_POLICY_TYPE_PRE_POLICY,
+		api.AddBmpRequest_MONITORING_POLICY_PRE:         oc.BMP_ROUTE_MONITORING_POLICY_TYPE_PRE_POLICY,
+		api.AddBmpRequest_MONITORING_POLICY_POST:        oc.BMP_ROUTE_MONITORING_POLICY_TYPE_POST_POLICY,
+		api.AddBmpRequest_MONITORING_POLICY_BOTH:        oc.BMP_ROUTE_MONITORING_POLICY_TYPE_BOTH,
+		api.AddBmpRequest_MONITORING_POLICY_LOCAL:       oc.BMP_ROUTE_MONITORING_POLICY_TYPE_LOCAL_RIB,
+		api.AddBmpRequest_MONITORING_POLICY_ALL:         oc.BMP_ROUTE_MONITORING_POLICY_TYPE_ALL,
+	} {
+		got, err := newBmpRouteMonitoringPolicyFromAPI(p)
+		require.NoError(t, err)
+		assert.Equal(t, want, got, p.String())
+	}
+	_, err := newBmpRouteMonitoringPolicyFromAPI(api.AddBmpRequest_MonitoringPolicy(6))
+	assert.Error(t, err)
+}
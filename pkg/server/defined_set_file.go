@@ -0,0 +1,454 @@
+// Copyright (C) 2025 Acnodal Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/osrg/gobgp/v4/api"
+	"github.com/osrg/gobgp/v4/internal/pkg/table"
+	"github.com/osrg/gobgp/v4/pkg/config/oc"
+)
+
+// definedSetFile is a local file, typically generated from IRR objects by
+// bgpq4, which the defined set is replaced with the contents of.
+type definedSetFile struct {
+	config oc.DefinedSetFileConfig
+	state  oc.DefinedSetFileState
+	// the defined set of the last successful load
+	data *oc.DefinedSets
+}
+
+// definedSet returns the defined set of the last successful load.
+func (f *definedSetFile) definedSet() (table.DefinedSet, error) {
+	if f.config.Type == oc.DEFINED_SET_FILE_TYPE_AS_PATH {
+		return table.NewAsPathSet(f.data.BgpDefinedSets.AsPathSets[0])
+	}
+	return table.NewPrefixSet(f.data.PrefixSets[0])
+}
+
+type definedSetFileEvent struct {
+	path      string
+	timestamp time.Time
+}
+
+type definedSetFileManager struct {
+	eventCh chan *definedSetFileEvent
+	// the files by the absolute paths
+	files   map[string]*definedSetFile
+	watcher *fileWatcher
+}
+
+func newDefinedSetFileManager() *definedSetFileManager {
+	m := &definedSetFileManager{
+		eventCh: make(chan *definedSetFileEvent),
+		files:   make(map[string]*definedSetFile),
+	}
+	m.watcher = newFileWatcher(func(path string, done <-chan struct{}) {
+		select {
+		case m.eventCh <- &definedSetFileEvent{path: path, timestamp: time.Now()}:
+		case <-done:
+		}
+	})
+	return m
+}
+
+func parseASN(s string) (uint32, error) {
+	n, err := strconv.ParseUint(strings.TrimPrefix(strings.ToUpper(s), "AS"), 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid AS number %s", s)
+	}
+	return uint32(n), nil
+}
+
+// parseMaskLengthRange parses the mask length range like "16..24" of the
+// prefix.
+func parseMaskLengthRange(p netip.Prefix, s string) (string, error) {
+	elems := strings.Split(s, "..")
+	if len(elems) != 2 {
+		return "", fmt.Errorf("invalid mask length range %s", s)
+	}
+	min, err1 := strconv.ParseUint(elems[0], 10, 8)
+	max, err2 := strconv.ParseUint(elems[1], 10, 8)
+	if err1 != nil || err2 != nil || int(min) < p.Bits() || min > max || int(max) > p.Addr().BitLen() {
+		return "", fmt.Errorf("invalid mask length range %s of %s", s, p)
+	}
+	return s, nil
+}
+
+// parseDefinedSetText parses one prefix, optionally followed by the mask
+// length range, or one AS number per line. The lines starting with '#'
+// are comments.
+func parseDefinedSetText(b []byte, typ oc.DefinedSetFileType) ([]oc.Prefix, []uint32, error) {
+	var prefixes []oc.Prefix
+	var asns []uint32
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		err := func() error {
+			if typ == oc.DEFINED_SET_FILE_TYPE_AS_PATH {
+				if len(fields) != 1 {
+					return fmt.Errorf("invalid AS number %s", line)
+				}
+				asn, err := parseASN(fields[0])
+				if err != nil {
+					return err
+				}
+				asns = append(asns, asn)
+				return nil
+			}
+			if len(fields) > 2 {
+				return fmt.Errorf("invalid prefix %s", line)
+			}
+			p, err := netip.ParsePrefix(fields[0])
+			if err != nil {
+				return err
+			}
+			prefix := oc.Prefix{IpPrefix: p.Masked()}
+			if len(fields) == 2 {
+				if prefix.MasklengthRange, err = parseMaskLengthRange(p, fields[1]); err != nil {
+					return err
+				}
+			}
+			prefixes = append(prefixes, prefix)
+			return nil
+		}()
+		if err != nil {
+			return nil, nil, fmt.Errorf("line %d: %w", n, err)
+		}
+	}
+	return prefixes, asns, scanner.Err()
+}
+
+// parseDefinedSetBgpq4 parses the JSON output of bgpq4 -j, an object with
+// the list of the prefixes or the AS numbers (-t).
+func parseDefinedSetBgpq4(b []byte, typ oc.DefinedSetFileType) ([]oc.Prefix, []uint32, error) {
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, nil, err
+	}
+	if len(m) != 1 {
+		return nil, nil, fmt.Errorf("%d lists in the file", len(m))
+	}
+	var list json.RawMessage
+	for _, v := range m {
+		list = v
+	}
+	if typ == oc.DEFINED_SET_FILE_TYPE_AS_PATH {
+		var asns []uint32
+		if err := json.Unmarshal(list, &asns); err != nil {
+			return nil, nil, err
+		}
+		return nil, asns, nil
+	}
+	var l []struct {
+		Prefix       string `json:"prefix"`
+		Exact        bool   `json:"exact"`
+		GreaterEqual *uint8 `json:"greater-equal"`
+		LessEqual    *uint8 `json:"less-equal"`
+	}
+	if err := json.Unmarshal(list, &l); err != nil {
+		return nil, nil, err
+	}
+	prefixes := make([]oc.Prefix, 0, len(l))
+	for _, e := range l {
+		p, err := netip.ParsePrefix(e.Prefix)
+		if err != nil {
+			return nil, nil, err
+		}
+		prefix := oc.Prefix{IpPrefix: p.Masked()}
+		if !e.Exact && (e.GreaterEqual != nil || e.LessEqual != nil) {
+			min, max := uint8(p.Bits()), uint8(p.Addr().BitLen())
+			if e.GreaterEqual != nil {
+				min = *e.GreaterEqual
+			}
+			if e.LessEqual != nil {
+				max = *e.LessEqual
+			}
+			if prefix.MasklengthRange, err = parseMaskLengthRange(p, fmt.Sprintf("%d..%d", min, max)); err != nil {
+				return nil, nil, err
+			}
+		}
+		prefixes = append(prefixes, prefix)
+	}
+	return prefixes, nil, nil
+}
+
+// parseDefinedSetFile returns the defined set of the file. The AS numbers
+// make the AS path set matching them as the origin, which the set looks up
+// in a map instead of trying them one by one.
+func parseDefinedSetFile(b []byte, c oc.DefinedSetFileConfig) (*oc.DefinedSets, error) {
+	parse := parseDefinedSetText
+	if c.Format == oc.DEFINED_SET_FILE_FORMAT_TYPE_BGPQ4_JSON {
+		parse = parseDefinedSetBgpq4
+	}
+	prefixes, asns, err := parse(b, c.Type)
+	if err != nil {
+		return nil, err
+	}
+	d := &oc.DefinedSets{}
+	if c.Type == oc.DEFINED_SET_FILE_TYPE_AS_PATH {
+		l := make([]string, 0, len(asns))
+		for _, asn := range asns {
+			l = append(l, fmt.Sprintf("_%d$", asn))
+		}
+		d.BgpDefinedSets.AsPathSets = []oc.AsPathSet{{AsPathSetName: c.Name, AsPathList: l}}
+	} else {
+		if prefixes == nil {
+			prefixes = []oc.Prefix{}
+		}
+		d.PrefixSets = []oc.PrefixSet{{PrefixSetName: c.Name, PrefixList: prefixes}}
+	}
+	return d, nil
+}
+
+// loadDefinedSetFile reads the file, validates it and replaces the
+// contents of the defined set at once. The paths are evaluated again with
+// the policies the change affects. On failure the defined set is kept.
+func (s *BgpServer) loadDefinedSetFile(f *definedSetFile) error {
+	var added, removed []string
+	var changes []table.PolicyAssignmentChange
+	err := func() error {
+		b, err := os.ReadFile(f.config.Path)
+		if err != nil {
+			return err
+		}
+		data, err := parseDefinedSetFile(b, f.config)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", f.config.Path, err)
+		}
+		old := f.data
+		f.data = data
+		set, err := f.definedSet()
+		if err != nil {
+			f.data = old
+			return fmt.Errorf("invalid defined set in %s: %w", f.config.Path, err)
+		}
+		running := s.policy.Snapshot()
+		if added, removed, err = s.policy.ReplaceDefinedSet(set); err != nil {
+			f.data = old
+			return err
+		}
+		f.state.Entries = uint32(len(set.List()))
+		f.state.Added = uint32(len(added))
+		f.state.Removed = uint32(len(removed))
+		if len(added) == 0 && len(removed) == 0 {
+			return nil
+		}
+		s.policyUpdated(nil)
+		s.updateORF(set)
+		changes = running.ChangedAssignments(s.policy.Snapshot())
+		s.softResetPolicy(changes)
+		return nil
+	}()
+	if err != nil {
+		f.state.Error = err.Error()
+		s.logger.Warn("Failed to load defined set file",
+			slog.String("Topic", "Policy"),
+			slog.String("File", f.config.Path),
+			slog.String("Error", err.Error()))
+		return err
+	}
+	f.state.Error = ""
+	f.state.Loaded = time.Now().Unix()
+	f.state.Loads++
+	s.logger.Info("Loaded defined set file",
+		slog.String("Topic", "Policy"),
+		slog.String("File", f.config.Path),
+		slog.String("Name", f.config.Name),
+		slog.Int("Entries", int(f.state.Entries)),
+		slog.Int("Added", len(added)),
+		slog.Int("Removed", len(removed)),
+		slog.Int("ChangedAssignments", len(changes)))
+	for _, e := range added {
+		s.logger.Debug("Added defined set entry",
+			slog.String("Topic", "Policy"),
+			slog.String("Name", f.config.Name),
+			slog.String("Entry", e))
+	}
+	for _, e := range removed {
+		s.logger.Debug("Removed defined set entry",
+			slog.String("Topic", "Policy"),
+			slog.String("Name", f.config.Name),
+			slog.String("Entry", e))
+	}
+	return nil
+}
+
+// applyDefinedSetFiles replaces the defined sets with the contents of the
+// files again after the policy is replaced.
+func (s *BgpServer) applyDefinedSetFiles() {
+	for _, f := range s.definedSetFiles.files {
+		if f.data == nil {
+			continue
+		}
+		set, err := f.definedSet()
+		if err == nil {
+			_, _, err = s.policy.ReplaceDefinedSet(set)
+		}
+		if err != nil {
+			s.logger.Warn("Failed to apply defined set file",
+				slog.String("Topic", "Policy"),
+				slog.String("File", f.config.Path),
+				slog.String("Error", err.Error()))
+		}
+	}
+}
+
+// mergeDefinedSetFiles puts the defined sets of the files into the
+// configuration, so that the policies can refer to the sets the
+// configuration doesn't have.
+func (m *definedSetFileManager) mergeDefinedSetFiles(d *oc.DefinedSets) {
+	for _, f := range m.files {
+		if f.data == nil {
+			continue
+		}
+		for _, set := range f.data.PrefixSets {
+			i := slices.IndexFunc(d.PrefixSets, func(p oc.PrefixSet) bool { return p.PrefixSetName == set.PrefixSetName })
+			if i < 0 {
+				d.PrefixSets = append(d.PrefixSets, set)
+			} else {
+				d.PrefixSets[i] = set
+			}
+		}
+		for _, set := range f.data.BgpDefinedSets.AsPathSets {
+			l := d.BgpDefinedSets.AsPathSets
+			i := slices.IndexFunc(l, func(a oc.AsPathSet) bool { return a.AsPathSetName == set.AsPathSetName })
+			if i < 0 {
+				d.BgpDefinedSets.AsPathSets = append(l, set)
+			} else {
+				l[i] = set
+			}
+		}
+	}
+}
+
+// addDefinedSetFile loads the file and watches the directory of it, which
+// works with the files replaced by renaming too.
+func (s *BgpServer) addDefinedSetFile(c oc.DefinedSetFileConfig) error {
+	m := s.definedSetFiles
+	path, err := filepath.Abs(c.Path)
+	if err != nil {
+		return err
+	}
+	c.Path = path
+	if _, ok := m.files[path]; ok {
+		return fmt.Errorf("defined set file exists %s", path)
+	}
+	for _, f := range m.files {
+		if f.config.Name == c.Name && f.config.Type == c.Type {
+			return fmt.Errorf("defined set %s is loaded from %s", c.Name, f.config.Path)
+		}
+	}
+	if err := m.watcher.add(path); err != nil {
+		return err
+	}
+	f := &definedSetFile{config: c}
+	m.files[path] = f
+	if err := s.loadDefinedSetFile(f); err != nil {
+		_ = s.deleteDefinedSetFile(path)
+		return err
+	}
+	return nil
+}
+
+// deleteDefinedSetFile stops loading the file. The defined set is kept
+// with the contents of the last load since the policies may refer to it.
+func (s *BgpServer) deleteDefinedSetFile(path string) error {
+	m := s.definedSetFiles
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	if _, ok := m.files[path]; !ok {
+		return fmt.Errorf("defined set file doesn't exist %s", path)
+	}
+	delete(m.files, path)
+	m.watcher.remove(path)
+	return nil
+}
+
+// reloadDefinedSetFiles loads the file again, or all the files if the
+// path is empty.
+func (s *BgpServer) reloadDefinedSetFiles(path string) error {
+	m := s.definedSetFiles
+	if path == "" {
+		var errs []string
+		for _, p := range slices.Sorted(maps.Keys(m.files)) {
+			if err := s.loadDefinedSetFile(m.files[p]); err != nil {
+				errs = append(errs, err.Error())
+			}
+		}
+		if len(errs) > 0 {
+			return fmt.Errorf("%s", strings.Join(errs, "; "))
+		}
+		return nil
+	}
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	f, ok := m.files[path]
+	if !ok {
+		return fmt.Errorf("defined set file doesn't exist %s", path)
+	}
+	return s.loadDefinedSetFile(f)
+}
+
+func (s *BgpServer) handleDefinedSetFileEvent(ev *definedSetFileEvent) {
+	m := s.definedSetFiles
+	f, ok := m.files[ev.path]
+	if !ok {
+		return
+	}
+	_ = s.loadDefinedSetFile(f)
+}
+
+func definedSetFileTypeToApi(t oc.DefinedSetFileType) api.DefinedType {
+	if t == oc.DEFINED_SET_FILE_TYPE_AS_PATH {
+		return api.DefinedType_DEFINED_TYPE_AS_PATH
+	}
+	return api.DefinedType_DEFINED_TYPE_PREFIX
+}
+
+func definedSetFileFormatToApi(f oc.DefinedSetFileFormatType) api.AddDefinedSetFileRequest_Format {
+	switch f {
+	case oc.DEFINED_SET_FILE_FORMAT_TYPE_TEXT:
+		return api.AddDefinedSetFileRequest_FORMAT_TEXT
+	case oc.DEFINED_SET_FILE_FORMAT_TYPE_BGPQ4_JSON:
+		return api.AddDefinedSetFileRequest_FORMAT_BGPQ4_JSON
+	}
+	return api.AddDefinedSetFileRequest_FORMAT_UNSPECIFIED
+}
@@ -0,0 +1,205 @@
+// Copyright (C) 2025 Acnodal Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/osrg/gobgp/v4/api"
+	"github.com/osrg/gobgp/v4/internal/pkg/table"
+	"github.com/osrg/gobgp/v4/pkg/apiutil"
+	"github.com/osrg/gobgp/v4/pkg/config/oc"
+	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
+)
+
+func TestParseDefinedSetFile(t *testing.T) {
+	prefix := oc.DefinedSetFileConfig{Name: "ps", Type: oc.DEFINED_SET_FILE_TYPE_PREFIX, Format: oc.DEFINED_SET_FILE_FORMAT_TYPE_TEXT}
+	d, err := parseDefinedSetFile([]byte(`# AS-EXAMPLE
+10.0.0.0/8
+10.1.0.0/16 16..24 # more specifics
+2001:db8::1/32
+`), prefix)
+	require.NoError(t, err)
+	assert.Equal(t, []oc.PrefixSet{{PrefixSetName: "ps", PrefixList: []oc.Prefix{
+		{IpPrefix: netip.MustParsePrefix("10.0.0.0/8")},
+		{IpPrefix: netip.MustParsePrefix("10.1.0.0/16"), MasklengthRange: "16..24"},
+		{IpPrefix: netip.MustParsePrefix("2001:db8::/32")},
+	}}}, d.PrefixSets)
+	for _, s := range []string{"10.0.0.0", "10.0.0.0/8 4..8", "10.0.0.0/8 8..33", "10.0.0.0/8 8 24"} {
+		_, err = parseDefinedSetFile([]byte(s), prefix)
+		assert.Error(t, err, s)
+	}
+
+	as := oc.DefinedSetFileConfig{Name: "as", Type: oc.DEFINED_SET_FILE_TYPE_AS_PATH, Format: oc.DEFINED_SET_FILE_FORMAT_TYPE_TEXT}
+	d, err = parseDefinedSetFile([]byte("65001\nAS65002\n\n"), as)
+	require.NoError(t, err)
+	assert.Equal(t, []oc.AsPathSet{{AsPathSetName: "as", AsPathList: []string{"_65001$", "_65002$"}}}, d.BgpDefinedSets.AsPathSets)
+	_, err = parseDefinedSetFile([]byte("ASx"), as)
+	assert.Error(t, err)
+
+	// bgpq4 -j -l NN -R 24 and bgpq4 -j -t -l NN
+	prefix.Format = oc.DEFINED_SET_FILE_FORMAT_TYPE_BGPQ4_JSON
+	d, err = parseDefinedSetFile([]byte(`{ "NN": [
+ { "prefix": "192.0.2.0/24", "exact": true },
+ { "prefix": "198.51.100.0/22", "exact": false, "less-equal": 24 },
+ { "prefix": "203.0.113.0/24", "exact": false, "greater-equal": 25, "less-equal": 28 }
+] }`), prefix)
+	require.NoError(t, err)
+	assert.Equal(t, []oc.Prefix{
+		{IpPrefix: netip.MustParsePrefix("192.0.2.0/24")},
+		{IpPrefix: netip.MustParsePrefix("198.51.100.0/22"), MasklengthRange: "22..24"},
+		{IpPrefix: netip.MustParsePrefix("203.0.113.0/24"), MasklengthRange: "25..28"},
+	}, d.PrefixSets[0].PrefixList)
+	d, err = parseDefinedSetFile([]byte(`{ "NN": [] }`), prefix)
+	require.NoError(t, err)
+	assert.Empty(t, d.PrefixSets[0].PrefixList)
+	_, err = parseDefinedSetFile([]byte(`{ "NN": [], "MM": [] }`), prefix)
+	assert.Error(t, err)
+
+	as.Format = oc.DEFINED_SET_FILE_FORMAT_TYPE_BGPQ4_JSON
+	d, err = parseDefinedSetFile([]byte(`{ "NN": [ 65001, 65002 ] }`), as)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"_65001$", "_65002$"}, d.BgpDefinedSets.AsPathSets[0].AsPathList)
+}
+
+func TestDefinedSetFile(t *testing.T) {
+	ctx := context.Background()
+	s1 := runNewServer(t, 1, "1.1.1.1", 10179)
+	defer s1.StopBgp(ctx, &api.StopBgpRequest{})
+	s2 := runNewServer(t, 2, "2.2.2.2", 20179)
+	defer s2.StopBgp(ctx, &api.StopBgpRequest{})
+
+	path := filepath.Join(t.TempDir(), "bogons.txt")
+	writeFile := func(s string) {
+		// write and rename like the generators do
+		tmp := path + ".tmp"
+		require.NoError(t, os.WriteFile(tmp, []byte(s), 0o600))
+		require.NoError(t, os.Rename(tmp, path))
+	}
+	writeFile("10.2.0.0/16\n")
+	require.NoError(t, s1.AddDefinedSetFile(ctx, &api.AddDefinedSetFileRequest{
+		Name:        "bogons",
+		DefinedType: api.DefinedType_DEFINED_TYPE_PREFIX,
+		Path:        path,
+	}))
+	assert.Error(t, s1.AddDefinedSetFile(ctx, &api.AddDefinedSetFileRequest{
+		Name:        "bogons",
+		DefinedType: api.DefinedType_DEFINED_TYPE_PREFIX,
+		Path:        path + ".2",
+	}))
+
+	// s1 rejects the paths in the set
+	policy := &api.Policy{
+		Name: "bogons",
+		Statements: []*api.Statement{{
+			Name: "bogons",
+			Conditions: &api.Conditions{PrefixSet: &api.MatchSet{
+				Name: "bogons",
+				Type: api.MatchSet_TYPE_ANY,
+			}},
+			Actions: &api.Actions{RouteAction: api.RouteAction_ROUTE_ACTION_REJECT},
+		}},
+	}
+	require.NoError(t, s1.AddPolicy(ctx, &api.AddPolicyRequest{Policy: policy}))
+	require.NoError(t, s1.AddPolicyAssignment(ctx, &api.AddPolicyAssignmentRequest{Assignment: &api.PolicyAssignment{
+		Name:          table.GLOBAL_RIB_NAME,
+		Direction:     api.PolicyDirection_POLICY_DIRECTION_IMPORT,
+		Policies:      []*api.Policy{{Name: "bogons"}},
+		DefaultAction: api.RouteAction_ROUTE_ACTION_ACCEPT,
+	}}))
+
+	established := waitEstablished(s1, bgp.RF_IPv4_UC)
+	require.NoError(t, peerServers(t, ctx, []*BgpServer{s1, s2}, []oc.AfiSafiType{oc.AFI_SAFI_TYPE_IPV4_UNICAST}))
+	established.Wait()
+
+	prefix, _ := bgp.NewIPAddrPrefix(netip.MustParsePrefix("10.1.0.0/24"))
+	nh, _ := bgp.NewPathAttributeNextHop(netip.MustParseAddr("10.0.0.1"))
+	p, _ := apiutil.NewPath(bgp.RF_IPv4_UC, prefix, false, []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(0),
+		nh,
+	}, time.Now())
+	_, err := s2.AddPath(apiutil.AddPathRequest{Paths: []*apiutil.Path{mustApi2apiutilPath(p)}})
+	require.NoError(t, err)
+
+	global := func() int {
+		n := 0
+		require.NoError(t, s1.ListPath(apiutil.ListPathRequest{TableType: api.TableType_TABLE_TYPE_GLOBAL, Family: bgp.RF_IPv4_UC}, func(_ bgp.NLRI, paths []*apiutil.Path) {
+			n += len(paths)
+		}))
+		return n
+	}
+	assert.Eventually(t, func() bool { return global() == 1 }, 10*time.Second, 50*time.Millisecond)
+
+	files := func() []*api.DefinedSetFile {
+		var l []*api.DefinedSetFile
+		require.NoError(t, s1.ListDefinedSetFile(ctx, &api.ListDefinedSetFileRequest{}, func(f *api.DefinedSetFile) {
+			l = append(l, f)
+		}))
+		return l
+	}
+
+	// the change is applied to the received paths without soft reset
+	writeFile("10.2.0.0/16\n10.1.0.0/16 16..24\n")
+	assert.Eventually(t, func() bool { return global() == 0 }, 5*time.Second, 50*time.Millisecond)
+	l := files()
+	require.Len(t, l, 1)
+	assert.Equal(t, "bogons", l[0].Name)
+	assert.Equal(t, uint32(2), l[0].Entries)
+	assert.Equal(t, uint32(1), l[0].Added)
+	assert.Equal(t, uint32(0), l[0].Removed)
+	assert.Equal(t, uint64(2), l[0].Loads)
+
+	// the broken file keeps the set
+	require.NoError(t, os.WriteFile(path, []byte("10.1.0.0/33\n"), 0o600))
+	assert.Error(t, s1.ReloadDefinedSetFile(ctx, &api.ReloadDefinedSetFileRequest{Path: path}))
+	l = files()
+	assert.NotEmpty(t, l[0].Error)
+	assert.Equal(t, uint32(2), l[0].Entries)
+	assert.Equal(t, 0, global())
+
+	// replacing the policies keeps the set of the file
+	require.NoError(t, s1.SetPolicies(ctx, &api.SetPoliciesRequest{
+		DefinedSets: []*api.DefinedSet{{DefinedType: api.DefinedType_DEFINED_TYPE_PREFIX, Name: "bogons"}},
+		Policies:    []*api.Policy{policy},
+	}))
+	var sets []*api.DefinedSet
+	require.NoError(t, s1.ListDefinedSet(ctx, &api.ListDefinedSetRequest{DefinedType: api.DefinedType_DEFINED_TYPE_PREFIX, Name: "bogons"}, func(d *api.DefinedSet) {
+		sets = append(sets, d)
+	}))
+	require.Len(t, sets, 1)
+	assert.Len(t, sets[0].Prefixes, 2)
+
+	writeFile("10.2.0.0/16\n")
+	require.NoError(t, s1.ReloadDefinedSetFile(ctx, &api.ReloadDefinedSetFileRequest{}))
+	assert.Equal(t, 1, global())
+	l = files()
+	assert.Empty(t, l[0].Error)
+	assert.Equal(t, uint32(1), l[0].Removed)
+
+	// the set is kept after deleting the file
+	require.NoError(t, s1.DeleteDefinedSetFile(ctx, &api.DeleteDefinedSetFileRequest{Path: path}))
+	assert.Empty(t, files())
+	assert.Error(t, s1.DeleteDefinedSetFile(ctx, &api.DeleteDefinedSetFileRequest{Path: path}))
+	assert.Equal(t, 1, global())
+}
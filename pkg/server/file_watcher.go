@@ -0,0 +1,143 @@
+// Copyright (C) 2025 Acnodal Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// fileReloadDelay coalesces the events of a file being written.
+const fileReloadDelay = 200 * time.Millisecond
+
+// fileWatcher watches the directories of the local files loaded by the
+// server, which works with the files replaced by renaming too. A file is
+// reported once it hasn't changed for fileReloadDelay.
+type fileWatcher struct {
+	// called from the watcher goroutine with the absolute path of the
+	// file changed; it gives up when done is closed.
+	notify  func(path string, done <-chan struct{})
+	watcher *fsnotify.Watcher
+	done    chan struct{}
+	// the number of the files watched in the directory
+	dirs map[string]int
+}
+
+func newFileWatcher(notify func(path string, done <-chan struct{})) *fileWatcher {
+	return &fileWatcher{
+		notify: notify,
+		dirs:   make(map[string]int),
+	}
+}
+
+// pendingFile is the file changed, reported when the timer expires unless
+// the file changes again.
+type pendingFile struct {
+	path  string
+	timer *time.Timer
+}
+
+// add watches the directory of the file of the absolute path.
+func (w *fileWatcher) add(path string) error {
+	if w.watcher == nil {
+		watcher, err := fsnotify.NewWatcher()
+		if err != nil {
+			return err
+		}
+		w.watcher = watcher
+		w.done = make(chan struct{})
+		go w.loop(watcher, w.done)
+	}
+	dir := filepath.Dir(path)
+	if w.dirs[dir] == 0 {
+		if err := w.watcher.Add(dir); err != nil {
+			w.stop()
+			return err
+		}
+	}
+	w.dirs[dir]++
+	return nil
+}
+
+// remove stops watching the directory of the file if no other file in it
+// is watched.
+func (w *fileWatcher) remove(path string) {
+	dir := filepath.Dir(path)
+	if w.dirs[dir]--; w.dirs[dir] <= 0 {
+		delete(w.dirs, dir)
+		if w.watcher != nil {
+			_ = w.watcher.Remove(dir)
+		}
+	}
+	w.stop()
+}
+
+// stop closes the watcher when no file is watched.
+func (w *fileWatcher) stop() {
+	if len(w.dirs) == 0 && w.watcher != nil {
+		close(w.done)
+		w.watcher.Close()
+		w.watcher = nil
+	}
+}
+
+func (w *fileWatcher) loop(watcher *fsnotify.Watcher, done chan struct{}) {
+	pending := make(map[string]*pendingFile)
+	expired := make(chan *pendingFile)
+	defer func() {
+		for _, p := range pending {
+			p.timer.Stop()
+		}
+	}()
+	for {
+		select {
+		case ev, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if !ev.Has(fsnotify.Write) && !ev.Has(fsnotify.Create) && !ev.Has(fsnotify.Rename) {
+				continue
+			}
+			path := filepath.Clean(ev.Name)
+			if p, ok := pending[path]; ok {
+				p.timer.Stop()
+			}
+			p := &pendingFile{path: path}
+			p.timer = time.AfterFunc(fileReloadDelay, func() {
+				select {
+				case expired <- p:
+				case <-done:
+				}
+			})
+			pending[path] = p
+		case p := <-expired:
+			// the timer stopped by a later event may have expired already
+			if pending[p.path] != p {
+				continue
+			}
+			delete(pending, p.path)
+			w.notify(p.path, done)
+		case _, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+		case <-done:
+			return
+		}
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright (C) 2025 Acnodal Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileWatcher(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "a")
+	other := filepath.Join(dir, "b")
+
+	ch := make(chan string, 8)
+	w := newFileWatcher(func(path string, done <-chan struct{}) {
+		select {
+		case ch <- path:
+		case <-done:
+		}
+	})
+	require.NoError(t, w.add(path))
+	require.NoError(t, w.add(other))
+	assert.Equal(t, map[string]int{dir: 2}, w.dirs)
+
+	// the writes in a row are reported once
+	for i := range 5 {
+		require.NoError(t, os.WriteFile(path, []byte{byte(i)}, 0o644))
+		time.Sleep(fileReloadDelay / 10)
+	}
+	select {
+	case p := <-ch:
+		assert.Equal(t, path, p)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no notification")
+	}
+	select {
+	case p := <-ch:
+		t.Fatalf("notified again %s", p)
+	case <-time.After(2 * fileReloadDelay):
+	}
+
+	w.remove(path)
+	assert.NotNil(t, w.watcher)
+	w.remove(other)
+	assert.Nil(t, w.watcher)
+	assert.Empty(t, w.dirs)
+}
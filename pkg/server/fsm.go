@@ -66,6 +66,7 @@ const (
 	fsmHardReset
 	fsmDeConfigured
 	fsmBadPeerAS
+	fsmSendHoldTimerExpired
 )
 
 type fsmStateReason struct {
@@ -118,6 +119,8 @@ func (r fsmStateReason) String() string {
 		return "hard-reset"
 	case fsmBadPeerAS:
 		return "bad-peer-as"
+	case fsmSendHoldTimerExpired:
+		return "send-hold-timer-expired"
 	default:
 		return "unknown"
 	}
@@ -142,6 +145,11 @@ type fsmMsg struct {
 
 type fsmOutgoingMsg struct {
 	Paths []*table.Path
+	// Messages are sent as they are before Paths.
+	Messages []*bgp.BGPMessage
+	// Shared are sent after Paths, as they are serialized for the update
+	// group.
+	Shared *sharedUpdates
 }
 
 const (
@@ -369,6 +377,7 @@ type fsm struct {
 	adminState               adminStateRaw
 	adminStateCh             chan adminStateOperation
 	outgoingConnCh           chan outgoingConn
+	sendBlockedSince         atomic.Int64 // unix nano time when the pending write started
 
 	// only loop goroutine accesses; no lock required
 	outgoingConnMgr   *outgoingConnManager
@@ -384,6 +393,24 @@ type fsm struct {
 	h    *fsmHandler
 }
 
+// sendBlockedFor returns for how long the pending write to the peer has
+// been blocked.
+func (fsm *fsm) sendBlockedFor() time.Duration {
+	if t := fsm.sendBlockedSince.Load(); t != 0 {
+		return time.Since(time.Unix(0, t))
+	}
+	return 0
+}
+
+// sendHoldTime returns the send hold time (RFC 9687). The default is the
+// larger of 8 minutes and twice the negotiated hold time.
+func sendHoldTime(timers *oc.Timers) float64 {
+	if t := timers.Config.SendHoldTime; t > 0 {
+		return t
+	}
+	return max(480, 2*timers.State.NegotiatedHoldTime)
+}
+
 func (fsm *fsm) tryReceiveOutgoingConn() (outgoingConn, bool) {
 	select {
 	case item := <-fsm.outgoingConnCh:
@@ -602,6 +629,7 @@ func (fsm *fsm) stateChange(nextState bgp.FSMState, reason *fsmStateReason) {
 			keepalive = n / 3
 		}
 		fsm.pConf.Timers.State.KeepaliveInterval = keepalive
+		fsm.pConf.Timers.State.SendHoldTime = sendHoldTime(&fsm.pConf.Timers)
 
 		gr, ok := fsm.capMap[bgp.BGP_CAP_GRACEFUL_RESTART]
 		if fsm.pConf.GracefulRestart.Config.Enabled && ok {
@@ -1012,6 +1040,52 @@ func capAddPathFromConfig(pConf *oc.Neighbor) bgp.ParameterCapabilityInterface {
 	return bgp.NewCapAddPath(tuples)
 }
 
+func orfModeFromConfig(mode oc.OrfModeType) bgp.ORFSendReceive {
+	switch mode {
+	case oc.ORF_MODE_TYPE_RECEIVE:
+		return bgp.ORF_RECEIVE
+	case oc.ORF_MODE_TYPE_SEND:
+		return bgp.ORF_SEND
+	case oc.ORF_MODE_TYPE_BOTH:
+		return bgp.ORF_BOTH
+	}
+	return 0
+}
+
+func capORFFromConfig(pConf *oc.Neighbor) bgp.ParameterCapabilityInterface {
+	tuples := make([]*bgp.CapORFTuple, 0, len(pConf.AfiSafis))
+	for _, af := range pConf.AfiSafis {
+		// Address Prefix ORF is defined for IPv4/IPv6 unicast only.
+		if af.State.Family != bgp.RF_IPv4_UC && af.State.Family != bgp.RF_IPv6_UC {
+			continue
+		}
+		if mode := orfModeFromConfig(af.OutboundRouteFiltering.State.Mode); mode != 0 {
+			tuples = append(tuples, bgp.NewCapORFTuple(af.State.Family, []*bgp.CapORFEntry{{Type: bgp.ORF_TYPE_ADDRESS_PREFIX, Mode: mode}}))
+		}
+	}
+	if len(tuples) == 0 {
+		return nil
+	}
+	return bgp.NewCapOutboundRouteFiltering(tuples)
+}
+
+func capBGPsecFromConfig(pConf *oc.Neighbor) []bgp.ParameterCapabilityInterface {
+	caps := make([]bgp.ParameterCapabilityInterface, 0)
+	for _, af := range pConf.AfiSafis {
+		// BGPsec is defined for IPv4/IPv6 unicast only.
+		if af.State.Family != bgp.RF_IPv4_UC && af.State.Family != bgp.RF_IPv6_UC {
+			continue
+		}
+		if af.Bgpsec.State.Send {
+			caps = append(caps, bgp.NewCapBGPsec(bgp.BGPSEC_DIRECTION_SEND, af.State.Family.Afi()))
+		}
+		if af.Bgpsec.State.Receive {
+			caps = append(caps, bgp.NewCapBGPsec(bgp.BGPSEC_DIRECTION_RECEIVE, af.State.Family.Afi()))
+		}
+	}
+	return caps
+}
+
 func capabilitiesFromConfig(pConf *oc.Neighbor) []bgp.ParameterCapabilityInterface {
 	fqdn, _ := os.Hostname()
 	caps := make([]bgp.ParameterCapabilityInterface, 0, 4)
@@ -1088,6 +1162,14 @@ func capabilitiesFromConfig(pConf *oc.Neighbor) []bgp.ParameterCapabilityInterfa
 		caps = append(caps, capAddPathFromConfig(pConf))
 	}
 
+	// Outbound Route Filtering Capability
+	if c := capORFFromConfig(pConf); c != nil {
+		caps = append(caps, c)
+	}
+
+	// BGPsec Capability
+	caps = append(caps, capBGPsecFromConfig(pConf)...)
+
 	return caps
 }
 
@@ -1604,24 +1686,35 @@ func (h *fsmHandler) sendMessageloop(ctx context.Context, conn net.Conn, stateRe
 	defer wg.Done()
 	fsm := h.fsm
 	ticker := keepaliveTicker(fsm)
-	send := func(m *bgp.BGPMessage) error {
-		if fsm.twoByteAsTrans && m.Header.Type == bgp.BGP_MSG_UPDATE {
-			fsm.logger.Debug("update for 2byte AS peer",
-				slog.String("State", fsm.state.String()),
-				slog.Any("Data", m))
-			table.UpdatePathAttrs2ByteAs(m.Body.(*bgp.BGPUpdate))
-			table.UpdatePathAggregator2ByteAs(m.Body.(*bgp.BGPUpdate))
+	fsm.lock.Lock()
+	sendHold := time.Duration(fsm.pConf.Timers.State.SendHoldTime * float64(time.Second))
+	fsm.lock.Unlock()
+	write := func(m *bgp.BGPMessage, b []byte) error {
+		// RFC 9687: the write blocking for the send hold time means that
+		// the peer stopped reading.
+		fsm.sendBlockedSince.Store(time.Now().UnixNano())
+		if sendHold > 0 {
+			conn.SetWriteDeadline(time.Now().Add(sendHold))
 		}
-
-		b, err := m.Serialize(&bgp.MarshallingOption{AddPath: fsm.familyMap.Load().(map[bgp.Family]bgp.BGPAddPathMode)})
-		if err != nil {
-			fsm.logger.Warn("failed to serialize",
+		n, err := conn.Write(b)
+		fsm.sendBlockedSince.Store(0)
+		if errors.Is(err, os.ErrDeadlineExceeded) {
+			fsm.logger.Warn("send hold timer expired",
 				slog.String("State", fsm.state.String()),
-				slog.String("Error", err.Error()))
-			fsm.bgpMessageStateUpdate(0, false)
-			return nil
+				slog.Duration("SendHoldTime", sendHold))
+
+			m := bgp.NewBGPNotificationMessage(bgp.BGP_ERROR_SEND_HOLD_TIMER_EXPIRED, 0, nil)
+			if n == 0 {
+				// best effort; the peer is unlikely to read it.
+				_ = fsm.sendNotification(conn, m)
+			} else {
+				// a part of the message was written so the
+				// notification can't follow it.
+				conn.Close()
+			}
+			nonblockSendChannel(stateReasonCh, *newfsmStateReason(fsmSendHoldTimerExpired, m, nil))
+			return fmt.Errorf("closed")
 		}
-		_, err = conn.Write(b)
 		if err != nil {
 			fsm.logger.Warn("failed to send",
 				slog.String("State", fsm.state.String()),
@@ -1643,6 +1736,12 @@ func (h *fsmHandler) sendMessageloop(ctx context.Context, conn net.Conn, stateRe
 				slog.Any("attributes", update.PathAttributes))
 		case bgp.BGP_MSG_KEEPALIVE:
 			// nothing to do
+		case bgp.BGP_MSG_ROUTE_REFRESH:
+			rr := m.Body.(*bgp.BGPRouteRefresh)
+			fsm.logger.Debug("sent route-refresh",
+				slog.String("State", fsm.state.String()),
+				slog.String("Family", bgp.NewFamily(rr.AFI, rr.SAFI).String()),
+				slog.Int("ORFs", len(rr.ORFs)))
 		default:
 			fsm.logger.Error("unexpected message sent",
 				slog.String("State", fsm.state.String()),
@@ -1651,6 +1750,25 @@ func (h *fsmHandler) sendMessageloop(ctx context.Context, conn net.Conn, stateRe
 		}
 		return nil
 	}
+	send := func(m *bgp.BGPMessage) error {
+		if fsm.twoByteAsTrans && m.Header.Type == bgp.BGP_MSG_UPDATE {
+			fsm.logger.Debug("update for 2byte AS peer",
+				slog.String("State", fsm.state.String()),
+				slog.Any("Data", m))
+			table.UpdatePathAttrs2ByteAs(m.Body.(*bgp.BGPUpdate))
+			table.UpdatePathAggregator2ByteAs(m.Body.(*bgp.BGPUpdate))
+		}
+
+		b, err := m.Serialize(&bgp.MarshallingOption{AddPath: fsm.familyMap.Load().(map[bgp.Family]bgp.BGPAddPathMode)})
+		if err != nil {
+			fsm.logger.Warn("failed to serialize",
+				slog.String("State", fsm.state.String()),
+				slog.String("Error", err.Error()))
+			fsm.bgpMessageStateUpdate(0, false)
+			return nil
+		}
+		return write(m, b)
+	}
 
 	for {
 		select {
@@ -1659,12 +1777,24 @@ func (h *fsmHandler) sendMessageloop(ctx context.Context, conn net.Conn, stateRe
 		case o := <-h.outgoing.Out():
 			switch m := o.(type) {
 			case *fsmOutgoingMsg:
+				for _, msg := range m.Messages {
+					if err := send(msg); err != nil {
+						return nil
+					}
+				}
 				options := &bgp.MarshallingOption{AddPath: fsm.familyMap.Load().(map[bgp.Family]bgp.BGPAddPathMode)}
 				for _, msg := range table.CreateUpdateMsgFromPaths(m.Paths, options) {
 					if err := send(msg); err != nil {
 						return nil
 					}
 				}
+				if u := m.Shared; u != nil {
+					for i, msg := range u.messages {
+						if err := write(msg, u.bufs[i]); err != nil {
+							return nil
+						}
+					}
+				}
 			default:
 				return nil
 			}
@@ -1730,6 +1860,7 @@ func (h *fsmHandler) recvMessageloop(ctx context.Context, conn net.Conn, holdtim
 					}
 
 					table.UpdatePathAttrs4ByteAs(h.fsm.logger, body)
+					table.UpdatePathAttrsBGPsec(body)
 
 					if err = table.UpdatePathAggregator4ByteAs(body); err != nil {
 						m := err.(*bgp.MessageError)
@@ -1877,7 +2008,8 @@ func (h *fsmHandler) established(ctx context.Context) (bgp.FSMState, *fsmStateRe
 					err.Type == fsmNotificationSent &&
 						err.BGPNotification.Body.(*bgp.BGPNotification).ErrorCode == bgp.BGP_ERROR_HOLD_TIMER_EXPIRED ||
 					err.Type == fsmReadFailed ||
-					err.Type == fsmWriteFailed {
+					err.Type == fsmWriteFailed ||
+					err.Type == fsmSendHoldTimerExpired {
 					err = *newfsmStateReason(fsmGracefulRestart, nil, nil)
 					fsm.logger.Info("peer graceful restart", slog.String("State", fsm.state.String()))
 					fsm.gracefulRestartTimer.Reset(time.Duration(fsm.pConf.GracefulRestart.State.PeerRestartTime) * time.Second)
@@ -246,6 +246,53 @@ func TestFSMHandlerEstablish_HoldTimerExpired_GR_Enabled(t *testing.T) {
 	assert.Equal(uint8(bgp.BGP_ERROR_HOLD_TIMER_EXPIRED), sent.Body.(*bgp.BGPNotification).ErrorCode)
 }
 
+func TestFSMHandlerEstablish_SendHoldTimerExpired(t *testing.T) {
+	assert := assert.New(t)
+
+	// the peer never reads what we send
+	l, r := net.Pipe()
+	defer r.Close()
+	p, h := makePeerAndHandler(l)
+	t.Cleanup(func() { cleanPeerAndHandler(p, h) })
+
+	p.fsm.pConf.Timers.State.SendHoldTime = 1
+
+	h.outgoing.In() <- &fsmOutgoingMsg{Messages: []*bgp.BGPMessage{bgp.NewBGPKeepAliveMessage()}}
+
+	start := time.Now()
+	type result struct {
+		state  bgp.FSMState
+		reason *fsmStateReason
+	}
+	ch := make(chan result, 1)
+	go func() {
+		state, reason := h.established(t.Context())
+		ch <- result{state, reason}
+	}()
+	assert.Eventually(func() bool {
+		return p.fsm.sendBlockedFor() > 0
+	}, time.Second, 10*time.Millisecond)
+
+	res := <-ch
+	state, reason := res.state, res.reason
+	assert.Equal(bgp.BGP_FSM_IDLE, state)
+	assert.Equal(fsmSendHoldTimerExpired, reason.Type)
+	assert.Equal(uint8(bgp.BGP_ERROR_SEND_HOLD_TIMER_EXPIRED), reason.BGPNotification.Body.(*bgp.BGPNotification).ErrorCode)
+	assert.Less(time.Since(start), 3*time.Second)
+	assert.Zero(p.fsm.sendBlockedFor())
+}
+
+func TestSendHoldTime(t *testing.T) {
+	assert := assert.New(t)
+	timers := &oc.Timers{}
+	timers.State.NegotiatedHoldTime = 90
+	assert.Equal(float64(480), sendHoldTime(timers))
+	timers.State.NegotiatedHoldTime = 300
+	assert.Equal(float64(600), sendHoldTime(timers))
+	timers.Config.SendHoldTime = 30
+	assert.Equal(float64(30), sendHoldTime(timers))
+}
+
 func TestFSMHandlerOpenconfirm_HoldtimeZero(t *testing.T) {
 	assert := assert.New(t)
 
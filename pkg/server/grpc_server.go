@@ -25,6 +25,7 @@ import (
 	"math"
 	"net"
 	"net/netip"
+	"path/filepath"
 	"reflect"
 	"regexp"
 	"strconv"
@@ -230,12 +231,15 @@ func toPathAPI(binNlri []byte, binPattrs [][]byte, anyNlri *api.NLRI, anyPattrs
 		NlriBinary:         binNlri,
 		PattrsBinary:       binPattrs,
 		SourceAsn:          path.PeerASN,
+		Weight:             path.Weight,
+		Tag:                path.Tag,
 		// ListPath API fields only
 		SendMaxFiltered: path.SendMaxFiltered,
 		Filtered:        path.Filtered,
 		Validation:      path.Validation,
 		IsNetlink:       path.IsNetlink,
 		NetlinkIfName:   path.NetlinkIfName,
+		BestPathReason:  path.BestPathReason,
 	}
 	if path.PeerID.IsValid() {
 		p.SourceId = path.PeerID.String()
@@ -299,6 +303,8 @@ func (s *server) listPath(ctx context.Context, r *api.ListPathRequest, fn func(*
 		Family:         family,
 		SortType:       r.SortType,
 		EnableFiltered: r.EnableFiltered,
+
+		EnableBestPathReason: r.EnableBestPathReason,
 	}
 	if r.TableType != api.TableType_TABLE_TYPE_UNSPECIFIED && r.TableType != api.TableType_TABLE_TYPE_VRF {
 		for _, p := range r.Prefixes {
@@ -371,6 +377,12 @@ func (s *server) watchEvent(ctx context.Context, r *api.WatchEventRequest, fn fu
 	if r.GetPeer() != nil {
 		opts = append(opts, WatchPeer())
 	}
+	if r.GetRpki() != nil {
+		opts = append(opts, WatchValidation())
+	}
+	if r.GetPrefixLimit() != nil {
+		opts = append(opts, WatchPrefixLimit())
+	}
 	if t := r.GetTable(); t != nil {
 		for _, filter := range t.Filters {
 			switch filter.Type {
@@ -420,6 +432,38 @@ func (s *server) watchEvent(ctx context.Context, r *api.WatchEventRequest, fn fu
 			p := toPathApi(path, false, false, false)
 			simpleSend([]*api.Path{p}, timestamp)
 		},
+		OnValidation: func(l []*apiutil.WatchEventMessage_ValidationEvent, timestamp time.Time) {
+			send := func(changes []*api.WatchEventResponse_RpkiEvent_Change) {
+				fn(&api.WatchEventResponse{Event: &api.WatchEventResponse_Rpki{Rpki: &api.WatchEventResponse_RpkiEvent{Changes: changes}}}, timestamp)
+			}
+			changes := make([]*api.WatchEventResponse_RpkiEvent_Change, 0, len(l))
+			for _, v := range l {
+				changes = append(changes, &api.WatchEventResponse_RpkiEvent_Change{
+					Path:          toPathApi(v.Path, false, false, false),
+					OldValidation: v.OldValidation,
+				})
+				if r.BatchSize > 0 && len(changes) >= int(r.BatchSize) {
+					send(changes)
+					changes = make([]*api.WatchEventResponse_RpkiEvent_Change, 0, len(l))
+				}
+			}
+			if len(changes) > 0 {
+				send(changes)
+			}
+		},
+		OnPrefixLimit: func(ev *apiutil.WatchEventMessage_PrefixLimitEvent, timestamp time.Time) {
+			e := &api.WatchEventResponse_PrefixLimitEvent{
+				Type:        ev.Type,
+				PeerAddress: ev.PeerAddress.String(),
+				Count:       ev.Count,
+				Limit:       ev.Limit,
+				Shutdown:    ev.Shutdown,
+			}
+			if ev.Family != 0 {
+				e.Family = &api.Family{Afi: api.Family_Afi(ev.Family.Afi()), Safi: api.Family_Safi(ev.Family.Safi())}
+			}
+			fn(&api.WatchEventResponse{Event: &api.WatchEventResponse_PrefixLimit{PrefixLimit: e}}, timestamp)
+		},
 		OnPeerUpdate: func(peer *apiutil.WatchEventMessage_PeerEvent, timestamp time.Time) {
 			p := peer.Peer
 			remoteCaps, err := apiutil.MarshalCapabilities(p.State.RemoteCap)
@@ -494,10 +538,67 @@ func (s *server) DisablePeer(ctx context.Context, r *api.DisablePeerRequest) (*a
 	return &api.DisablePeerResponse{}, s.bgpServer.DisablePeer(ctx, r)
 }
 
+func (s *server) ListUpdateGroup(r *api.ListUpdateGroupRequest, stream api.GoBgpService_ListUpdateGroupServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	var sendErr error
+	fn := func(g *api.UpdateGroup) {
+		if sendErr = stream.Send(&api.ListUpdateGroupResponse{UpdateGroup: g}); sendErr != nil {
+			cancel()
+			return
+		}
+	}
+	err := s.bgpServer.ListUpdateGroup(ctx, r, fn)
+	if sendErr != nil {
+		return sendErr
+	}
+	return err
+}
+
 func (s *server) SetPolicies(ctx context.Context, r *api.SetPoliciesRequest) (*api.SetPoliciesResponse, error) {
 	return &api.SetPoliciesResponse{}, s.bgpServer.SetPolicies(ctx, r)
 }
 
+func (s *server) TestPolicy(ctx context.Context, r *api.TestPolicyRequest) (*api.TestPolicyResponse, error) {
+	return s.bgpServer.TestPolicy(ctx, r)
+}
+
+func (s *server) StartPolicyTransaction(ctx context.Context, r *api.StartPolicyTransactionRequest) (*api.StartPolicyTransactionResponse, error) {
+	id, err := s.bgpServer.StartPolicyTransaction(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return &api.StartPolicyTransactionResponse{Id: id}, nil
+}
+
+func (s *server) DiffPolicyTransaction(ctx context.Context, r *api.DiffPolicyTransactionRequest) (*api.DiffPolicyTransactionResponse, error) {
+	diffs, err := s.bgpServer.DiffPolicyTransaction(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return &api.DiffPolicyTransactionResponse{Diffs: diffs}, nil
+}
+
+func (s *server) CommitPolicyTransaction(ctx context.Context, r *api.CommitPolicyTransactionRequest) (*api.CommitPolicyTransactionResponse, error) {
+	version, err := s.bgpServer.CommitPolicyTransaction(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return &api.CommitPolicyTransactionResponse{Version: version}, nil
+}
+
+func (s *server) AbortPolicyTransaction(ctx context.Context, r *api.AbortPolicyTransactionRequest) (*api.AbortPolicyTransactionResponse, error) {
+	return &api.AbortPolicyTransactionResponse{}, s.bgpServer.AbortPolicyTransaction(ctx, r)
+}
+
+func (s *server) RollbackPolicy(ctx context.Context, r *api.RollbackPolicyRequest) (*api.RollbackPolicyResponse, error) {
+	version, err := s.bgpServer.RollbackPolicy(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return &api.RollbackPolicyResponse{Version: version}, nil
+}
+
 func newRoutingPolicyFromApiStruct(arg *api.SetPoliciesRequest) (*oc.RoutingPolicy, error) {
 	policyDefinitions := make([]oc.PolicyDefinition, 0, len(arg.Policies))
 	for _, p := range arg.Policies {
@@ -701,6 +802,60 @@ func (s *server) DisableMrt(ctx context.Context, r *api.DisableMrtRequest) (*api
 	return &api.DisableMrtResponse{}, s.bgpServer.DisableMrt(ctx, r)
 }
 
+func (s *server) ListMrt(r *api.ListMrtRequest, stream api.GoBgpService_ListMrtServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	var sendErr error
+	fn := func(m *api.Mrt) {
+		if sendErr = stream.Send(&api.ListMrtResponse{Mrt: m}); sendErr != nil {
+			cancel()
+			return
+		}
+	}
+	err := s.bgpServer.ListMrt(ctx, r, fn)
+	if sendErr != nil {
+		return sendErr
+	}
+	return err
+}
+
+func (s *server) StartMrtReplay(ctx context.Context, r *api.StartMrtReplayRequest) (*api.StartMrtReplayResponse, error) {
+	return &api.StartMrtReplayResponse{}, s.bgpServer.StartMrtReplay(ctx, r)
+}
+
+func (s *server) StopMrtReplay(ctx context.Context, r *api.StopMrtReplayRequest) (*api.StopMrtReplayResponse, error) {
+	return &api.StopMrtReplayResponse{}, s.bgpServer.StopMrtReplay(ctx, r)
+}
+
+func (s *server) PauseMrtReplay(ctx context.Context, r *api.PauseMrtReplayRequest) (*api.PauseMrtReplayResponse, error) {
+	return &api.PauseMrtReplayResponse{}, s.bgpServer.PauseMrtReplay(ctx, r)
+}
+
+func (s *server) ResumeMrtReplay(ctx context.Context, r *api.ResumeMrtReplayRequest) (*api.ResumeMrtReplayResponse, error) {
+	return &api.ResumeMrtReplayResponse{}, s.bgpServer.ResumeMrtReplay(ctx, r)
+}
+
+func (s *server) SeekMrtReplay(ctx context.Context, r *api.SeekMrtReplayRequest) (*api.SeekMrtReplayResponse, error) {
+	return &api.SeekMrtReplayResponse{}, s.bgpServer.SeekMrtReplay(ctx, r)
+}
+
+func (s *server) ListMrtReplay(r *api.ListMrtReplayRequest, stream api.GoBgpService_ListMrtReplayServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	var sendErr error
+	fn := func(r *api.MrtReplay) {
+		if sendErr = stream.Send(&api.ListMrtReplayResponse{Replay: r}); sendErr != nil {
+			cancel()
+			return
+		}
+	}
+	err := s.bgpServer.ListMrtReplay(ctx, r, fn)
+	if sendErr != nil {
+		return sendErr
+	}
+	return err
+}
+
 func (s *server) AddPathStream(stream api.GoBgpService_AddPathStreamServer) error {
 	for {
 		arg, err := stream.Recv()
@@ -729,6 +884,21 @@ func (s *server) AddPathStream(stream api.GoBgpService_AddPathStreamServer) erro
 	return stream.SendAndClose(&api.AddPathStreamResponse{})
 }
 
+func (s *server) UpdateLsTopology(stream api.GoBgpService_UpdateLsTopologyServer) error {
+	for {
+		arg, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		if err := s.bgpServer.UpdateLsTopology(stream.Context(), arg); err != nil {
+			return err
+		}
+	}
+	return stream.SendAndClose(&api.UpdateLsTopologyResponse{})
+}
+
 func (s *server) AddBmp(ctx context.Context, r *api.AddBmpRequest) (*api.AddBmpResponse, error) {
 	return &api.AddBmpResponse{}, s.bgpServer.AddBmp(ctx, r)
 }
@@ -754,6 +924,72 @@ func (s *server) ListBmp(r *api.ListBmpRequest, stream api.GoBgpService_ListBmpS
 	return err
 }
 
+func (s *server) EnableBmpStation(ctx context.Context, r *api.EnableBmpStationRequest) (*api.EnableBmpStationResponse, error) {
+	return &api.EnableBmpStationResponse{}, s.bgpServer.EnableBmpStation(ctx, r)
+}
+
+func (s *server) DisableBmpStation(ctx context.Context, r *api.DisableBmpStationRequest) (*api.DisableBmpStationResponse, error) {
+	return &api.DisableBmpStationResponse{}, s.bgpServer.DisableBmpStation(ctx, r)
+}
+
+func (s *server) ListBmpRouter(r *api.ListBmpRouterRequest, stream api.GoBgpService_ListBmpRouterServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	var sendErr error
+	fn := func(rsp *api.BmpRouter) {
+		if sendErr = stream.Send(&api.ListBmpRouterResponse{Router: rsp}); sendErr != nil {
+			cancel()
+			return
+		}
+	}
+	err := s.bgpServer.ListBmpRouter(ctx, r, fn)
+	if sendErr != nil {
+		return sendErr
+	}
+	return err
+}
+
+func (s *server) ListBmpMonitoredPeer(r *api.ListBmpMonitoredPeerRequest, stream api.GoBgpService_ListBmpMonitoredPeerServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	var sendErr error
+	fn := func(rsp *api.BmpMonitoredPeer) {
+		if sendErr = stream.Send(&api.ListBmpMonitoredPeerResponse{Peer: rsp}); sendErr != nil {
+			cancel()
+			return
+		}
+	}
+	err := s.bgpServer.ListBmpMonitoredPeer(ctx, r, fn)
+	if sendErr != nil {
+		return sendErr
+	}
+	return err
+}
+
+func (s *server) ListBmpRoute(r *api.ListBmpRouteRequest, stream api.GoBgpService_ListBmpRouteServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	var sendErr error
+	fn := func(prefix bgp.NLRI, paths []*apiutil.Path) {
+		d := &api.Destination{
+			Prefix: prefix.String(),
+			Paths:  make([]*api.Path, len(paths)),
+		}
+		for i, path := range paths {
+			d.Paths[i] = toPathApi(path, false, false, false)
+		}
+		if sendErr = stream.Send(&api.ListBmpRouteResponse{Destination: d}); sendErr != nil {
+			cancel()
+			return
+		}
+	}
+	err := s.bgpServer.ListBmpRoute(ctx, r, fn)
+	if sendErr != nil {
+		return sendErr
+	}
+	return err
+}
+
 func (s *server) AddRpki(ctx context.Context, r *api.AddRpkiRequest) (*api.AddRpkiResponse, error) {
 	return &api.AddRpkiResponse{}, s.bgpServer.AddRpki(ctx, r)
 }
@@ -808,6 +1044,35 @@ func (s *server) ListRpkiTable(r *api.ListRpkiTableRequest, stream api.GoBgpServ
 	return err
 }
 
+func (s *server) AddRpkiFile(ctx context.Context, r *api.AddRpkiFileRequest) (*api.AddRpkiFileResponse, error) {
+	return &api.AddRpkiFileResponse{}, s.bgpServer.AddRpkiFile(ctx, r)
+}
+
+func (s *server) DeleteRpkiFile(ctx context.Context, r *api.DeleteRpkiFileRequest) (*api.DeleteRpkiFileResponse, error) {
+	return &api.DeleteRpkiFileResponse{}, s.bgpServer.DeleteRpkiFile(ctx, r)
+}
+
+func (s *server) ReloadRpkiFile(ctx context.Context, r *api.ReloadRpkiFileRequest) (*api.ReloadRpkiFileResponse, error) {
+	return &api.ReloadRpkiFileResponse{}, s.bgpServer.ReloadRpkiFile(ctx, r)
+}
+
+func (s *server) ListRpkiFile(r *api.ListRpkiFileRequest, stream api.GoBgpService_ListRpkiFileServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	var sendErr error
+	fn := func(r *api.RpkiFile) {
+		if sendErr = stream.Send(&api.ListRpkiFileResponse{File: r}); sendErr != nil {
+			cancel()
+			return
+		}
+	}
+	err := s.bgpServer.ListRpkiFile(ctx, r, fn)
+	if sendErr != nil {
+		return sendErr
+	}
+	return err
+}
+
 func (s *server) EnableZebra(ctx context.Context, r *api.EnableZebraRequest) (*api.EnableZebraResponse, error) {
 	return &api.EnableZebraResponse{}, s.bgpServer.EnableZebra(ctx, r)
 }
@@ -900,6 +1165,13 @@ func readPrefixLimitFromAPIStruct(c *oc.PrefixLimit, a *api.PrefixLimit) {
 	}
 	c.Config.MaxPrefixes = a.MaxPrefixes
 	c.Config.ShutdownThresholdPct = oc.Percentage(a.ShutdownThresholdPct)
+	c.Config.WarningOnly = a.WarningOnly
+	c.Config.RestartTimer = a.RestartTimer
+	c.Config.CountType = oc.PREFIX_LIMIT_COUNT_TYPE_RECEIVED
+	if a.CountType == api.PrefixLimit_COUNT_TYPE_ACCEPTED {
+		c.Config.CountType = oc.PREFIX_LIMIT_COUNT_TYPE_ACCEPTED
+	}
+	c.Config.MaxPrefixesOut = a.MaxPrefixesOut
 }
 
 func readApplyPolicyFromAPIStruct(c *oc.ApplyPolicy, a *api.ApplyPolicy) {
@@ -941,6 +1213,28 @@ func readRouteSelectionOptionsFromAPIStruct(c *oc.RouteSelectionOptions, a *api.
 		c.Config.AdvertiseInactiveRoutes = a.Config.AdvertiseInactiveRoutes
 		c.Config.EnableAigp = a.Config.EnableAigp
 		c.Config.IgnoreNextHopIgpMetric = a.Config.IgnoreNextHopIgpMetric
+		c.Config.DeterministicMed = a.Config.DeterministicMed
+		c.Config.MedMissingAsWorst = a.Config.MedMissingAsWorst
+		c.Config.ConfedCompare = a.Config.ConfedCompare
+		c.Config.MultipathRelax = a.Config.MultipathRelax
+		c.Config.MultipathAsPathMatch = a.Config.MultipathAsPathMatch
+	}
+}
+
+func newRouteSelectionOptionsConfigFromAPIStruct(a *api.RouteSelectionOptionsConfig) oc.RouteSelectionOptionsConfig {
+	return oc.RouteSelectionOptionsConfig{
+		AlwaysCompareMed:         a.AlwaysCompareMed,
+		IgnoreAsPathLength:       a.IgnoreAsPathLength,
+		ExternalCompareRouterId:  a.ExternalCompareRouterId,
+		AdvertiseInactiveRoutes:  a.AdvertiseInactiveRoutes,
+		EnableAigp:               a.EnableAigp,
+		IgnoreNextHopIgpMetric:   a.IgnoreNextHopIgpMetric,
+		DisableBestPathSelection: a.DisableBestPathSelection,
+		DeterministicMed:         a.DeterministicMed,
+		MedMissingAsWorst:        a.MedMissingAsWorst,
+		ConfedCompare:            a.ConfedCompare,
+		MultipathRelax:           a.MultipathRelax,
+		MultipathAsPathMatch:     a.MultipathAsPathMatch,
 	}
 }
 
@@ -997,6 +1291,42 @@ func readAddPathsFromAPIStruct(c *oc.AddPaths, a *api.AddPaths) {
 	}
 }
 
+func readOutboundRouteFilteringFromAPIStruct(c *oc.OutboundRouteFiltering, a *api.OutboundRouteFiltering) {
+	if c == nil || a == nil {
+		return
+	}
+	if a.Config != nil {
+		if a.Config.Mode != api.OutboundRouteFilteringConfig_MODE_UNSPECIFIED {
+			c.Config.Mode = oc.IntToOrfModeTypeMap[int(a.Config.Mode)-1]
+		}
+		c.Config.PrefixSet = a.Config.PrefixSet
+	}
+}
+
+func readBgpsecFromAPIStruct(c *oc.Bgpsec, a *api.Bgpsec) {
+	if c == nil || a == nil {
+		return
+	}
+	if a.Config != nil {
+		c.Config.Send = a.Config.Send
+		c.Config.Receive = a.Config.Receive
+	}
+}
+
+func toOrfPrefixEntriesApi(entries []*bgp.AddressPrefixORFEntry) []*api.OrfPrefixEntry {
+	l := make([]*api.OrfPrefixEntry, 0, len(entries))
+	for _, e := range entries {
+		l = append(l, &api.OrfPrefixEntry{
+			Sequence: e.Sequence,
+			Deny:     e.Match == bgp.ORF_MATCH_DENY,
+			Prefix:   e.Prefix.String(),
+			MinLen:   uint32(e.MinLen),
+			MaxLen:   uint32(e.MaxLen),
+		})
+	}
+	return l
+}
+
 func PeerTypeFromApi(a api.PeerType) (oc.PeerType, error) {
 	switch a {
 	case api.PeerType_PEER_TYPE_EXTERNAL:
@@ -1069,6 +1399,8 @@ func newNeighborFromAPIStruct(a *api.Peer) (*oc.Neighbor, error) {
 			readRouteTargetMembershipFromAPIStruct(&afiSafi.RouteTargetMembership, af.RouteTargetMembership)
 			readLongLivedGracefulRestartFromAPIStruct(&afiSafi.LongLivedGracefulRestart, af.LongLivedGracefulRestart)
 			readAddPathsFromAPIStruct(&afiSafi.AddPaths, af.AddPaths)
+			readOutboundRouteFilteringFromAPIStruct(&afiSafi.OutboundRouteFiltering, af.OutboundRouteFiltering)
+			readBgpsecFromAPIStruct(&afiSafi.Bgpsec, af.Bgpsec)
 			pconf.AfiSafis = append(pconf.AfiSafis, afiSafi)
 		}
 	}
@@ -1080,10 +1412,12 @@ func newNeighborFromAPIStruct(a *api.Peer) (*oc.Neighbor, error) {
 			pconf.Timers.Config.KeepaliveInterval = float64(a.Timers.Config.KeepaliveInterval)
 			pconf.Timers.Config.MinimumAdvertisementInterval = float64(a.Timers.Config.MinimumAdvertisementInterval)
 			pconf.Timers.Config.IdleHoldTimeAfterReset = float64(a.Timers.Config.IdleHoldTimeAfterReset)
+			pconf.Timers.Config.SendHoldTime = float64(a.Timers.Config.SendHoldTime)
 		}
 		if a.Timers.State != nil {
 			pconf.Timers.State.KeepaliveInterval = float64(a.Timers.State.KeepaliveInterval)
 			pconf.Timers.State.NegotiatedHoldTime = float64(a.Timers.State.NegotiatedHoldTime)
+			pconf.Timers.State.SendHoldTime = float64(a.Timers.State.SendHoldTime)
 		}
 	}
 	if a.RouteReflector != nil {
@@ -1091,6 +1425,8 @@ func newNeighborFromAPIStruct(a *api.Peer) (*oc.Neighbor, error) {
 			pconf.RouteReflector.Config.RouteReflectorClusterId = id
 		}
 		pconf.RouteReflector.Config.RouteReflectorClient = a.RouteReflector.RouteReflectorClient
+		pconf.RouteReflector.Config.AdvertiseBestExternal = a.RouteReflector.AdvertiseBestExternal
+		pconf.RouteReflector.Config.DiversePath = a.RouteReflector.DiversePath
 	}
 	if a.RouteServer != nil {
 		pconf.RouteServer.Config.RouteServerClient = a.RouteServer.RouteServerClient
@@ -1211,6 +1547,8 @@ func newPeerGroupFromAPIStruct(a *api.PeerGroup) (*oc.PeerGroup, error) {
 			readRouteTargetMembershipFromAPIStruct(&afiSafi.RouteTargetMembership, af.RouteTargetMembership)
 			readLongLivedGracefulRestartFromAPIStruct(&afiSafi.LongLivedGracefulRestart, af.LongLivedGracefulRestart)
 			readAddPathsFromAPIStruct(&afiSafi.AddPaths, af.AddPaths)
+			readOutboundRouteFilteringFromAPIStruct(&afiSafi.OutboundRouteFiltering, af.OutboundRouteFiltering)
+			readBgpsecFromAPIStruct(&afiSafi.Bgpsec, af.Bgpsec)
 			pconf.AfiSafis = append(pconf.AfiSafis, afiSafi)
 		}
 	}
@@ -1222,10 +1560,12 @@ func newPeerGroupFromAPIStruct(a *api.PeerGroup) (*oc.PeerGroup, error) {
 			pconf.Timers.Config.KeepaliveInterval = float64(a.Timers.Config.KeepaliveInterval)
 			pconf.Timers.Config.MinimumAdvertisementInterval = float64(a.Timers.Config.MinimumAdvertisementInterval)
 			pconf.Timers.Config.IdleHoldTimeAfterReset = float64(a.Timers.Config.IdleHoldTimeAfterReset)
+			pconf.Timers.Config.SendHoldTime = float64(a.Timers.Config.SendHoldTime)
 		}
 		if a.Timers.State != nil {
 			pconf.Timers.State.KeepaliveInterval = float64(a.Timers.State.KeepaliveInterval)
 			pconf.Timers.State.NegotiatedHoldTime = float64(a.Timers.State.NegotiatedHoldTime)
+			pconf.Timers.State.SendHoldTime = float64(a.Timers.State.SendHoldTime)
 		}
 	}
 	if a.RouteReflector != nil {
@@ -1233,6 +1573,8 @@ func newPeerGroupFromAPIStruct(a *api.PeerGroup) (*oc.PeerGroup, error) {
 			pconf.RouteReflector.Config.RouteReflectorClusterId = id
 		}
 		pconf.RouteReflector.Config.RouteReflectorClient = a.RouteReflector.RouteReflectorClient
+		pconf.RouteReflector.Config.AdvertiseBestExternal = a.RouteReflector.AdvertiseBestExternal
+		pconf.RouteReflector.Config.DiversePath = a.RouteReflector.DiversePath
 	}
 	if a.RouteServer != nil {
 		pconf.RouteServer.Config.RouteServerClient = a.RouteServer.RouteServerClient
@@ -1601,6 +1943,41 @@ func toStatementApi(s *oc.Statement) *api.Statement {
 	default:
 		cs.RpkiResult = api.ValidationState_VALIDATION_STATE_UNSPECIFIED
 	}
+	switch s.Conditions.BgpConditions.BgpsecValidationResult {
+	case oc.BGPSEC_VALIDATION_RESULT_TYPE_NOT_SIGNED:
+		cs.BgpsecResult = api.BgpsecValidationState_BGPSEC_VALIDATION_STATE_NOT_SIGNED
+	case oc.BGPSEC_VALIDATION_RESULT_TYPE_VALID:
+		cs.BgpsecResult = api.BgpsecValidationState_BGPSEC_VALIDATION_STATE_VALID
+	case oc.BGPSEC_VALIDATION_RESULT_TYPE_INVALID:
+		cs.BgpsecResult = api.BgpsecValidationState_BGPSEC_VALIDATION_STATE_INVALID
+	}
+	cs.CallPolicy = s.Conditions.CallPolicy
+	cs.OriginAsns = s.Conditions.BgpConditions.OriginAsInList
+	cs.NeighborAsns = s.Conditions.BgpConditions.NeighborAsInList
+	if s.Conditions.BgpConditions.PrefixLengthRange != "" {
+		if c, err := table.NewPrefixLengthCondition(s.Conditions.BgpConditions.PrefixLengthRange); err == nil {
+			min, max := c.Range()
+			cs.PrefixLengthRange = &api.PrefixLengthRange{Min: uint32(min), Max: uint32(max)}
+		}
+	}
+	for _, t := range s.Conditions.BgpConditions.SourcePeerTypeList {
+		if i := t.ToInt(); i >= 0 {
+			cs.SourcePeerTypes = append(cs.SourcePeerTypes, api.SourcePeerType(i+1))
+		}
+	}
+	if s.Conditions.BgpConditions.PathAge.Operator != "" {
+		cs.PathAge = &api.PathAge{
+			Seconds: s.Conditions.BgpConditions.PathAge.Value,
+			Type:    table.ToComparisonApi(s.Conditions.BgpConditions.PathAge.Operator),
+		}
+	}
+	if s.Conditions.BgpConditions.AigpRange != "" {
+		if c, err := table.NewAigpCondition(s.Conditions.BgpConditions.AigpRange); err == nil {
+			min, max := c.Range()
+			cs.AigpRange = &api.AigpRange{Min: min, Max: max}
+		}
+	}
+	cs.AttributeTypePresent = uint32(s.Conditions.BgpConditions.AttributeTypePresent)
 
 	as := &api.Actions{
 		RouteAction: func() api.RouteAction {
@@ -1734,6 +2111,92 @@ func toStatementApi(s *oc.Statement) *api.Statement {
 			}
 			return &api.OriginAction{Origin: apiOrigin}
 		}(),
+		AsPathReplace: func() *api.AsPathReplaceAction {
+			c := s.Actions.BgpActions.SetAsPathReplace
+			if len(c.AsList) == 0 {
+				return nil
+			}
+			a := &api.AsPathReplaceAction{
+				ReplaceAsn: c.ReplaceAs,
+			}
+			for _, as := range c.AsList {
+				if as == "any" {
+					a.Any = true
+					continue
+				}
+				asn, err := strconv.ParseUint(as, 10, 32)
+				if err != nil {
+					return nil
+				}
+				a.Asns = append(a.Asns, uint32(asn))
+			}
+			return a
+		}(),
+		AsPathRemovePrivate: func() *api.AsPathRemovePrivateAction {
+			var removePrivate api.RemovePrivate
+			switch s.Actions.BgpActions.SetAsPathRemovePrivateAs {
+			case oc.REMOVE_PRIVATE_AS_OPTION_ALL:
+				removePrivate = api.RemovePrivate_REMOVE_PRIVATE_ALL
+			case oc.REMOVE_PRIVATE_AS_OPTION_REPLACE:
+				removePrivate = api.RemovePrivate_REMOVE_PRIVATE_REPLACE
+			default:
+				return nil
+			}
+			return &api.AsPathRemovePrivateAction{RemovePrivate: removePrivate}
+		}(),
+		AsPathExclude: func() *api.AsPathExcludeAction {
+			if len(s.Actions.BgpActions.SetAsPathExclude) == 0 {
+				return nil
+			}
+			return &api.AsPathExcludeAction{Asns: s.Actions.BgpActions.SetAsPathExclude}
+		}(),
+		Weight: func() *api.WeightAction {
+			if s.Actions.BgpActions.SetWeight == 0 {
+				return nil
+			}
+			return &api.WeightAction{Value: s.Actions.BgpActions.SetWeight}
+		}(),
+		Tag: func() *api.TagAction {
+			if s.Actions.IgpActions.SetTag == "" {
+				return nil
+			}
+			tag, err := strconv.ParseUint(string(s.Actions.IgpActions.SetTag), 0, 32)
+			if err != nil {
+				return nil
+			}
+			return &api.TagAction{Value: uint32(tag)}
+		}(),
+		Aigp: func() *api.AigpAction {
+			aigpStr := strings.TrimSpace(string(s.Actions.BgpActions.SetAigp))
+			if len(aigpStr) == 0 {
+				return nil
+			}
+			matches := _regexpMedActionType.FindStringSubmatch(aigpStr)
+			if len(matches) == 0 {
+				return nil
+			}
+			action := api.AigpAction_TYPE_REPLACE
+			switch matches[1] {
+			case "+", "-":
+				action = api.AigpAction_TYPE_MOD
+			}
+			value, err := strconv.ParseInt(matches[1]+matches[2], 10, 64)
+			if err != nil {
+				return nil
+			}
+			return &api.AigpAction{
+				Value: value,
+				Type:  action,
+			}
+		}(),
+		LinkBandwidth: func() *api.LinkBandwidthAction {
+			if s.Actions.BgpActions.SetLinkBandwidth == 0 {
+				return nil
+			}
+			return &api.LinkBandwidthAction{Bandwidth: s.Actions.BgpActions.SetLinkBandwidth}
+		}(),
+		FlowControl:   api.FlowControl(oc.FlowControlTypeToIntMap[s.Actions.FlowControl]),
+		GotoStatement: s.Actions.GotoStatement,
 	}
 	return &api.Statement{
 		Name:       s.Name,
@@ -1834,6 +2297,49 @@ func newMedEqConditionFromApiStruct(a *api.MedEq) (*table.MedEqCondition, error)
 	return table.NewMedEqCondition(a.Value)
 }
 
+func newPrefixLengthConditionFromApiStruct(a *api.PrefixLengthRange) (*table.PrefixLengthCondition, error) {
+	if a == nil {
+		return nil, nil
+	}
+	return table.NewPrefixLengthCondition(fmt.Sprintf("%d..%d", a.Min, a.Max))
+}
+
+func newSourcePeerTypeConditionFromApiStruct(a []api.SourcePeerType) (*table.SourcePeerTypeCondition, error) {
+	l := make([]oc.SourcePeerType, 0, len(a))
+	for _, t := range a {
+		typ, ok := oc.IntToSourcePeerTypeMap[int(t)-1]
+		if !ok {
+			return nil, fmt.Errorf("invalid source peer type: %s", t)
+		}
+		l = append(l, typ)
+	}
+	return table.NewSourcePeerTypeCondition(l)
+}
+
+func newPathAgeConditionFromApiStruct(a *api.PathAge) (*table.PathAgeCondition, error) {
+	if a == nil {
+		return nil, nil
+	}
+	return table.NewPathAgeCondition(oc.PathAge{
+		Operator: toOcAttributeComparison(a.Type),
+		Value:    a.Seconds,
+	})
+}
+
+func newAigpConditionFromApiStruct(a *api.AigpRange) (*table.AigpCondition, error) {
+	if a == nil {
+		return nil, nil
+	}
+	return table.NewAigpCondition(fmt.Sprintf("%d..%d", a.Min, a.Max))
+}
+
+func newAttributePresentConditionFromApiStruct(a uint32) (*table.AttributePresentCondition, error) {
+	if a > math.MaxUint8 {
+		return nil, fmt.Errorf("invalid attribute type code: %d", a)
+	}
+	return table.NewAttributePresentCondition(uint8(a))
+}
+
 func newAsPathConditionFromApiStruct(a *api.MatchSet) (*table.AsPathCondition, error) {
 	if a == nil {
 		return nil, nil
@@ -1867,6 +2373,22 @@ func newRpkiValidationConditionFromApiStruct(a api.ValidationState) (*table.Rpki
 	return table.NewRpkiValidationCondition(c)
 }
 
+func newBgpsecValidationConditionFromApiStruct(a api.BgpsecValidationState) (*table.BGPsecValidationCondition, error) {
+	c := oc.BgpsecValidationResultType("")
+	switch a {
+	case api.BgpsecValidationState_BGPSEC_VALIDATION_STATE_NOT_SIGNED:
+		c = oc.BGPSEC_VALIDATION_RESULT_TYPE_NOT_SIGNED
+	case api.BgpsecValidationState_BGPSEC_VALIDATION_STATE_VALID:
+		c = oc.BGPSEC_VALIDATION_RESULT_TYPE_VALID
+	case api.BgpsecValidationState_BGPSEC_VALIDATION_STATE_INVALID:
+		c = oc.BGPSEC_VALIDATION_RESULT_TYPE_INVALID
+	default:
+		return nil, nil
+	}
+
+	return table.NewBGPsecValidationCondition(c)
+}
+
 func newRouteTypeConditionFromApiStruct(a api.Conditions_RouteType) (*table.RouteTypeCondition, error) {
 	if a == 0 {
 		return nil, nil
@@ -2088,6 +2610,71 @@ func newAsPathPrependActionFromApiStruct(a *api.AsPrependAction) (*table.AsPathP
 	})
 }
 
+func newAsPathReplaceActionFromApiStruct(a *api.AsPathReplaceAction) (*table.AsPathReplaceAction, error) {
+	if a == nil {
+		return nil, nil
+	}
+	c := oc.SetAsPathReplace{
+		ReplaceAs: a.ReplaceAsn,
+	}
+	if a.Any {
+		c.AsList = []string{"any"}
+	} else {
+		for _, asn := range a.Asns {
+			c.AsList = append(c.AsList, strconv.FormatUint(uint64(asn), 10))
+		}
+	}
+	return table.NewAsPathReplaceAction(c)
+}
+
+func newRemovePrivateAsActionFromApiStruct(a *api.AsPathRemovePrivateAction) (*table.RemovePrivateAsAction, error) {
+	if a == nil {
+		return nil, nil
+	}
+	switch a.RemovePrivate {
+	case api.RemovePrivate_REMOVE_PRIVATE_ALL:
+		return table.NewRemovePrivateAsAction(oc.REMOVE_PRIVATE_AS_OPTION_ALL)
+	case api.RemovePrivate_REMOVE_PRIVATE_REPLACE:
+		return table.NewRemovePrivateAsAction(oc.REMOVE_PRIVATE_AS_OPTION_REPLACE)
+	}
+	return nil, nil
+}
+
+func newAsPathExcludeActionFromApiStruct(a *api.AsPathExcludeAction) (*table.AsPathExcludeAction, error) {
+	if a == nil {
+		return nil, nil
+	}
+	return table.NewAsPathExcludeAction(a.Asns)
+}
+
+func newWeightActionFromApiStruct(a *api.WeightAction) (*table.WeightAction, error) {
+	if a == nil {
+		return nil, nil
+	}
+	return table.NewWeightAction(a.Value)
+}
+
+func newTagActionFromApiStruct(a *api.TagAction) (*table.TagAction, error) {
+	if a == nil {
+		return nil, nil
+	}
+	return table.NewTagAction(oc.TagType(strconv.FormatUint(uint64(a.Value), 10)))
+}
+
+func newAigpActionFromApiStruct(a *api.AigpAction) (*table.AigpAction, error) {
+	if a == nil {
+		return nil, nil
+	}
+	return table.NewAigpActionFromApiStruct(table.MedActionType(a.Type), a.Value), nil
+}
+
+func newLinkBandwidthActionFromApiStruct(a *api.LinkBandwidthAction) (*table.LinkBandwidthAction, error) {
+	if a == nil {
+		return nil, nil
+	}
+	return table.NewLinkBandwidthAction(a.Bandwidth)
+}
+
 func newNexthopActionFromApiStruct(a *api.NexthopAction) (*table.NexthopAction, error) {
 	if a == nil {
 		return nil, nil
@@ -2114,6 +2701,8 @@ func newStatementFromApiStruct(a *api.Statement) (*table.Statement, error) {
 	var ra table.Action
 	var as []table.Action
 	var cs []table.Condition
+	var flow oc.FlowControlType
+	var to string
 	var err error
 	if a.Conditions != nil {
 		cfs := []func() (table.Condition, error){
@@ -2132,6 +2721,9 @@ func newStatementFromApiStruct(a *api.Statement) (*table.Statement, error) {
 			func() (table.Condition, error) {
 				return newRpkiValidationConditionFromApiStruct(a.Conditions.RpkiResult)
 			},
+			func() (table.Condition, error) {
+				return newBgpsecValidationConditionFromApiStruct(a.Conditions.BgpsecResult)
+			},
 			func() (table.Condition, error) {
 				return newRouteTypeConditionFromApiStruct(a.Conditions.RouteType)
 			},
@@ -2162,6 +2754,30 @@ func newStatementFromApiStruct(a *api.Statement) (*table.Statement, error) {
 			func() (table.Condition, error) {
 				return newMedEqConditionFromApiStruct(a.Conditions.MedEq)
 			},
+			func() (table.Condition, error) {
+				return table.NewCallPolicyCondition(a.Conditions.CallPolicy)
+			},
+			func() (table.Condition, error) {
+				return table.NewOriginAsCondition(a.Conditions.OriginAsns)
+			},
+			func() (table.Condition, error) {
+				return table.NewNeighborAsCondition(a.Conditions.NeighborAsns)
+			},
+			func() (table.Condition, error) {
+				return newPrefixLengthConditionFromApiStruct(a.Conditions.PrefixLengthRange)
+			},
+			func() (table.Condition, error) {
+				return newSourcePeerTypeConditionFromApiStruct(a.Conditions.SourcePeerTypes)
+			},
+			func() (table.Condition, error) {
+				return newPathAgeConditionFromApiStruct(a.Conditions.PathAge)
+			},
+			func() (table.Condition, error) {
+				return newAigpConditionFromApiStruct(a.Conditions.AigpRange)
+			},
+			func() (table.Condition, error) {
+				return newAttributePresentConditionFromApiStruct(a.Conditions.AttributeTypePresent)
+			},
 		}
 		cs = make([]table.Condition, 0, len(cfs))
 		for _, f := range cfs {
@@ -2195,6 +2811,15 @@ func newStatementFromApiStruct(a *api.Statement) (*table.Statement, error) {
 			func() (table.Action, error) {
 				return newLocalPrefActionFromApiStruct(a.Actions.LocalPref)
 			},
+			func() (table.Action, error) {
+				return newAsPathReplaceActionFromApiStruct(a.Actions.AsPathReplace)
+			},
+			func() (table.Action, error) {
+				return newRemovePrivateAsActionFromApiStruct(a.Actions.AsPathRemovePrivate)
+			},
+			func() (table.Action, error) {
+				return newAsPathExcludeActionFromApiStruct(a.Actions.AsPathExclude)
+			},
 			func() (table.Action, error) {
 				return newAsPathPrependActionFromApiStruct(a.Actions.AsPrepend)
 			},
@@ -2204,6 +2829,18 @@ func newStatementFromApiStruct(a *api.Statement) (*table.Statement, error) {
 			func() (table.Action, error) {
 				return newOriginActionFromApiStruct(a.Actions.OriginAction)
 			},
+			func() (table.Action, error) {
+				return newWeightActionFromApiStruct(a.Actions.Weight)
+			},
+			func() (table.Action, error) {
+				return newTagActionFromApiStruct(a.Actions.Tag)
+			},
+			func() (table.Action, error) {
+				return newAigpActionFromApiStruct(a.Actions.Aigp)
+			},
+			func() (table.Action, error) {
+				return newLinkBandwidthActionFromApiStruct(a.Actions.LinkBandwidth)
+			},
 		}
 		as = make([]table.Action, 0, len(afs))
 		for _, f := range afs {
@@ -2215,12 +2852,25 @@ func newStatementFromApiStruct(a *api.Statement) (*table.Statement, error) {
 				as = append(as, a)
 			}
 		}
+		c, ok := oc.IntToFlowControlTypeMap[int(a.Actions.FlowControl)]
+		if !ok {
+			return nil, fmt.Errorf("invalid flow control: %d", a.Actions.FlowControl)
+		}
+		flow, to, err = table.NewFlowControl(c, a.Actions.GotoStatement)
+		if err != nil {
+			return nil, err
+		}
+		if flow != "" && !reflect.ValueOf(ra).IsNil() {
+			return nil, fmt.Errorf("statement %s can't have both route action and flow control", a.Name)
+		}
 	}
 	return &table.Statement{
-		Name:        a.Name,
-		Conditions:  cs,
-		RouteAction: ra,
-		ModActions:  as,
+		Name:          a.Name,
+		Conditions:    cs,
+		RouteAction:   ra,
+		ModActions:    as,
+		FlowControl:   flow,
+		GotoStatement: to,
 	}, nil
 }
 
@@ -2308,6 +2958,11 @@ func newRoaListFromTableStructList(origin []*table.ROA) []*api.Roa {
 				RemotePort: uint32(port),
 			},
 		})
+		// the sources of the local files are the absolute paths
+		if filepath.IsAbs(r.Src) {
+			l[len(l)-1].Conf = &api.RPKIConf{}
+			l[len(l)-1].File = r.Src
+		}
 	}
 	return l
 }
@@ -2329,6 +2984,40 @@ func (s *server) ListPolicy(r *api.ListPolicyRequest, stream api.GoBgpService_Li
 	return err
 }
 
+func (s *server) ListPolicyTransaction(r *api.ListPolicyTransactionRequest, stream api.GoBgpService_ListPolicyTransactionServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	var sendErr error
+	fn := func(tx *api.PolicyTransaction) {
+		if sendErr = stream.Send(&api.ListPolicyTransactionResponse{Transaction: tx}); sendErr != nil {
+			cancel()
+			return
+		}
+	}
+	err := s.bgpServer.ListPolicyTransaction(ctx, r, fn)
+	if sendErr != nil {
+		return sendErr
+	}
+	return err
+}
+
+func (s *server) ListPolicyVersion(r *api.ListPolicyVersionRequest, stream api.GoBgpService_ListPolicyVersionServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	var sendErr error
+	fn := func(v *api.PolicyVersion) {
+		if sendErr = stream.Send(&api.ListPolicyVersionResponse{Version: v}); sendErr != nil {
+			cancel()
+			return
+		}
+	}
+	err := s.bgpServer.ListPolicyVersion(ctx, r, fn)
+	if sendErr != nil {
+		return sendErr
+	}
+	return err
+}
+
 func (s *server) AddPolicy(ctx context.Context, r *api.AddPolicyRequest) (*api.AddPolicyResponse, error) {
 	return &api.AddPolicyResponse{}, s.bgpServer.AddPolicy(ctx, r)
 }
@@ -2385,6 +3074,39 @@ func (s *server) SetPolicyAssignment(ctx context.Context, r *api.SetPolicyAssign
 	return &api.SetPolicyAssignmentResponse{}, s.bgpServer.SetPolicyAssignment(ctx, r)
 }
 
+func (s *server) AddDefinedSetFile(ctx context.Context, r *api.AddDefinedSetFileRequest) (*api.AddDefinedSetFileResponse, error) {
+	return &api.AddDefinedSetFileResponse{}, s.bgpServer.AddDefinedSetFile(ctx, r)
+}
+
+func (s *server) DeleteDefinedSetFile(ctx context.Context, r *api.DeleteDefinedSetFileRequest) (*api.DeleteDefinedSetFileResponse, error) {
+	return &api.DeleteDefinedSetFileResponse{}, s.bgpServer.DeleteDefinedSetFile(ctx, r)
+}
+
+func (s *server) ReloadDefinedSetFile(ctx context.Context, r *api.ReloadDefinedSetFileRequest) (*api.ReloadDefinedSetFileResponse, error) {
+	return &api.ReloadDefinedSetFileResponse{}, s.bgpServer.ReloadDefinedSetFile(ctx, r)
+}
+
+func (s *server) ListDefinedSetFile(r *api.ListDefinedSetFileRequest, stream api.GoBgpService_ListDefinedSetFileServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	var sendErr error
+	fn := func(r *api.DefinedSetFile) {
+		if sendErr = stream.Send(&api.ListDefinedSetFileResponse{File: r}); sendErr != nil {
+			cancel()
+			return
+		}
+	}
+	err := s.bgpServer.ListDefinedSetFile(ctx, r, fn)
+	if sendErr != nil {
+		return sendErr
+	}
+	return err
+}
+
+func (s *server) ResetPolicyStatistics(ctx context.Context, r *api.ResetPolicyStatisticsRequest) (*api.ResetPolicyStatisticsResponse, error) {
+	return &api.ResetPolicyStatisticsResponse{}, s.bgpServer.ResetPolicyStatistics(ctx, r)
+}
+
 func (s *server) GetBgp(ctx context.Context, r *api.GetBgpRequest) (*api.GetBgpResponse, error) {
 	return s.bgpServer.GetBgp(ctx, r)
 }
@@ -2428,15 +3150,7 @@ func newGlobalFromAPIStruct(a *api.Global) *oc.Global {
 	}
 	if a.RouteSelectionOptions != nil {
 		global.RouteSelectionOptions = oc.RouteSelectionOptions{
-			Config: oc.RouteSelectionOptionsConfig{
-				AlwaysCompareMed:         a.RouteSelectionOptions.AlwaysCompareMed,
-				IgnoreAsPathLength:       a.RouteSelectionOptions.IgnoreAsPathLength,
-				ExternalCompareRouterId:  a.RouteSelectionOptions.ExternalCompareRouterId,
-				AdvertiseInactiveRoutes:  a.RouteSelectionOptions.AdvertiseInactiveRoutes,
-				EnableAigp:               a.RouteSelectionOptions.EnableAigp,
-				IgnoreNextHopIgpMetric:   a.RouteSelectionOptions.IgnoreNextHopIgpMetric,
-				DisableBestPathSelection: a.RouteSelectionOptions.DisableBestPathSelection,
-			},
+			Config: newRouteSelectionOptionsConfigFromAPIStruct(a.RouteSelectionOptions),
 		}
 	}
 	if a.DefaultRouteDistance != nil {
@@ -2469,6 +3183,9 @@ func newGlobalFromAPIStruct(a *api.Global) *oc.Global {
 			},
 		}
 	}
+	if a.BgpsecSigning != nil {
+		global.BgpsecSigning.Config.PrivateKeyFile = a.BgpsecSigning.PrivateKeyFile
+	}
 	return global
 }
 
@@ -22,9 +22,11 @@ import (
 	"log/slog"
 	"net/netip"
 	"os"
+	"slices"
 	"sort"
 	"time"
 
+	api "github.com/osrg/gobgp/v4/api"
 	"github.com/osrg/gobgp/v4/internal/pkg/table"
 	"github.com/osrg/gobgp/v4/pkg/config/oc"
 	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
@@ -40,7 +42,7 @@ type mrtWriter struct {
 	cancel           context.CancelFunc
 	s                *BgpServer
 	c                *oc.MrtConfig
-	file             *os.File
+	out              *mrtOutput
 	rotationInterval uint64
 	dumpInterval     uint64
 }
@@ -49,6 +51,61 @@ func (m *mrtWriter) Stop() {
 	m.cancel()
 }
 
+func (m *mrtWriter) toApi() *api.Mrt {
+	r := &api.Mrt{
+		Filename:  m.c.FileName,
+		Vrf:       m.c.Vrf,
+		Bytes:     m.out.bytes.Load(),
+		Errors:    m.out.errors.Load(),
+		Rotations: m.out.rotations.Load(),
+		LastWrite: oc.ProtoTimestamp(m.out.lastWrite.Load()),
+	}
+	switch m.c.DumpType {
+	case oc.MRT_TYPE_UPDATES:
+		r.DumpType = api.EnableMrtRequest_DUMP_TYPE_UPDATES
+	case oc.MRT_TYPE_TABLE:
+		r.DumpType = api.EnableMrtRequest_DUMP_TYPE_TABLE
+	}
+	return r
+}
+
+// mrtRibAttrs returns the path attributes of the RIB entry of the path;
+// MP_REACH_NLRI of the paths converted from the other family, like the ones
+// of a VRF, is replaced with the one of the family of the RIB.
+func mrtRibAttrs(path *table.Path) []bgp.PathAttributeInterface {
+	attrs := path.GetPathAttrs()
+	family := path.GetFamily()
+	for i, a := range attrs {
+		mp, ok := a.(*bgp.PathAttributeMpReachNLRI)
+		if !ok || bgp.NewFamily(mp.AFI, mp.SAFI) == family {
+			continue
+		}
+		l := make([]bgp.PathAttributeInterface, len(attrs))
+		copy(l, attrs)
+		if nmp, err := bgp.NewPathAttributeMpReachNLRI(family, []bgp.PathNLRI{{NLRI: path.GetNlri()}}, mp.Nexthop, mp.LinkLocalNexthop); err == nil {
+			l[i] = nmp
+		}
+		return l
+	}
+	return attrs
+}
+
+// mrtVrfFamilies are the families of the global RIB which have the paths
+// of the VRFs.
+var mrtVrfFamilies = []bgp.Family{bgp.RF_IPv4_VPN, bgp.RF_IPv6_VPN, bgp.RF_FS_IPv4_VPN, bgp.RF_FS_IPv6_VPN, bgp.RF_EVPN}
+
+func mrtMessageSubtype(isAddPath, fourBytesAs bool) mrt.MRTSubTypeBGP4MP {
+	switch {
+	case isAddPath && fourBytesAs:
+		return mrt.MESSAGE_AS4_ADDPATH
+	case isAddPath:
+		return mrt.MESSAGE_ADDPATH
+	case fourBytesAs:
+		return mrt.MESSAGE_AS4
+	}
+	return mrt.MESSAGE
+}
+
 type dumpPeer struct {
 	index uint16
 
@@ -68,11 +125,16 @@ func (m *mrtWriter) dumpTable() []*mrt.MRTMessage {
 	peermap := make(map[netip.Addr]dumpPeer)
 
 	idx := func(p *table.Path) uint16 {
-		if p, ok := peermap[p.GetSource().Address]; ok {
+		addr := p.GetSource().Address
+		if !addr.IsValid() {
+			// locally generated routes have no source address
+			addr = netip.IPv4Unspecified()
+		}
+		if p, ok := peermap[addr]; ok {
 			return p.index
 		}
 		newIdx := uint16(len(peermap))
-		if p.GetSource().Address == netip.IPv4Unspecified() {
+		if addr == netip.IPv4Unspecified() {
 			// Adding dummy Peer record for locally generated routes
 			peermap[netip.IPv4Unspecified()] = dumpPeer{
 				index: newIdx,
@@ -90,28 +152,9 @@ func (m *mrtWriter) dumpTable() []*mrt.MRTMessage {
 		return newIdx
 	}
 
-	subtype := func(p *table.Path, isAddPath bool) mrt.MRTSubTypeTableDumpv2 {
-		t := mrt.RIB_GENERIC
-		switch p.GetFamily() {
-		case bgp.RF_IPv4_UC:
-			t = mrt.RIB_IPV4_UNICAST
-		case bgp.RF_IPv4_MC:
-			t = mrt.RIB_IPV4_MULTICAST
-		case bgp.RF_IPv6_UC:
-			t = mrt.RIB_IPV6_UNICAST
-		case bgp.RF_IPv6_MC:
-			t = mrt.RIB_IPV6_MULTICAST
-		}
-		if isAddPath {
-			// Shift non-additional-path version to *_ADDPATH
-			t += 6
-		}
-		return t
-	}
-
 	seq := uint32(0)
 	appendTableDumpMsg := func(path *table.Path, entries []*mrt.RibEntry, isAddPath bool) {
-		st := subtype(path, isAddPath)
+		st := mrt.RibSubtype(path.GetFamily(), isAddPath)
 		if bm, err := mrt.NewMRTMessage(t, mrt.TABLE_DUMPv2, st, mrt.NewRib(seq, path.GetFamily(), path.GetNlri(), entries)); err != nil {
 			m.s.logger.Warn("Failed to create MRT TABLE_DUMPv2 message",
 				slog.String("Topic", "mrt"),
@@ -138,32 +181,65 @@ func (m *mrtWriter) dumpTable() []*mrt.MRTMessage {
 		as = peer.AS()
 		rib = m.s.rsRib
 	}
+	var vrf *table.Vrf
+	if m.c.Vrf != "" {
+		var ok bool
+		if vrf, ok = rib.Vrfs[m.c.Vrf]; !ok {
+			return []*mrt.MRTMessage{}
+		}
+	}
 
+	// the paths of a VRF are grouped by the prefixes without the RDs
+	type group struct {
+		paths []*table.Path
+	}
 	for family, t := range rib.Tables {
+		if vrf != nil && !slices.Contains(mrtVrfFamilies, family) {
+			continue
+		}
+		groups := make(map[string]*group)
+		keys := make([]string, 0)
 		for _, dst := range t.GetDestinations() {
-			if paths := dst.GetKnownPathList(id, as); len(paths) > 0 {
-				entries := make([]*mrt.RibEntry, 0)
-				entriesAddPath := make([]*mrt.RibEntry, 0)
-				for _, path := range paths {
-					isAddPath := false
-					if path.IsLocal() {
-						isAddPath = true
-					} else if neighbor, ok := m.s.neighborMap[path.GetSource().Address]; ok {
-						isAddPath = neighbor.isAddPathReceiveEnabled(family)
-					}
-					if !isAddPath {
-						entries = append(entries, mrt.NewRibEntry(idx(path), uint32(path.GetTimestamp().Unix()), 0, path.GetPathAttrs(), false))
-					} else {
-						entriesAddPath = append(entriesAddPath, mrt.NewRibEntry(idx(path), uint32(path.GetTimestamp().Unix()), path.RemoteID(), path.GetPathAttrs(), true))
+			for _, path := range dst.GetKnownPathList(id, as) {
+				if vrf != nil {
+					if !table.CanImportToVrf(vrf, path) {
+						continue
 					}
+					path = path.ToLocal()
 				}
-				if len(entries) > 0 {
-					appendTableDumpMsg(paths[0], entries, false)
+				key := path.GetNlri().String()
+				g, ok := groups[key]
+				if !ok {
+					g = &group{}
+					groups[key] = g
+					keys = append(keys, key)
+				}
+				g.paths = append(g.paths, path)
+			}
+		}
+		for _, key := range keys {
+			paths := groups[key].paths
+			entries := make([]*mrt.RibEntry, 0)
+			entriesAddPath := make([]*mrt.RibEntry, 0)
+			for _, path := range paths {
+				isAddPath := false
+				if path.IsLocal() {
+					isAddPath = true
+				} else if neighbor, ok := m.s.neighborMap[path.GetSource().Address]; ok {
+					isAddPath = neighbor.isAddPathReceiveEnabled(family)
 				}
-				if len(entriesAddPath) > 0 {
-					appendTableDumpMsg(paths[0], entriesAddPath, true)
+				if !isAddPath {
+					entries = append(entries, mrt.NewRibEntry(idx(path), uint32(path.GetTimestamp().Unix()), 0, mrtRibAttrs(path), false))
+				} else {
+					entriesAddPath = append(entriesAddPath, mrt.NewRibEntry(idx(path), uint32(path.GetTimestamp().Unix()), path.RemoteID(), mrtRibAttrs(path), true))
 				}
 			}
+			if len(entries) > 0 {
+				appendTableDumpMsg(paths[0], entries, false)
+			}
+			if len(entriesAddPath) > 0 {
+				appendTableDumpMsg(paths[0], entriesAddPath, true)
+			}
 		}
 	}
 
@@ -211,9 +287,7 @@ func (m *mrtWriter) loop(ctx context.Context) error {
 	}()
 
 	defer func() {
-		if m.file != nil {
-			m.file.Close()
-		}
+		m.out.Close()
 		if m.rotationInterval != 0 {
 			rotator.Stop()
 		}
@@ -233,15 +307,7 @@ func (m *mrtWriter) loop(ctx context.Context) error {
 			mp, _ := mrt.NewBGP4MPMessage(e.PeerAS, e.LocalAS, 0, netip.MustParseAddr(e.PeerAddress.String()), netip.MustParseAddr(e.LocalAddress.String()), e.FourBytesAs, nil)
 			mp.BGPMessagePayload = e.Payload
 			isAddPath := e.Neighbor.IsAddPathReceiveEnabled(e.PathList[0].GetFamily())
-			subtype := mrt.MESSAGE
-			switch {
-			case isAddPath && e.FourBytesAs:
-				subtype = mrt.MESSAGE_AS4_ADDPATH
-			case isAddPath:
-				subtype = mrt.MESSAGE_ADDPATH
-			case e.FourBytesAs:
-				subtype = mrt.MESSAGE_AS4
-			}
+			subtype := mrtMessageSubtype(isAddPath, e.FourBytesAs)
 			if bm, err := mrt.NewMRTMessage(e.Timestamp, mrt.BGP4MP, subtype, mp); err != nil {
 				m.s.logger.Warn("Failed to create MRT BGP4MP message",
 					slog.String("Topic", "mrt"),
@@ -258,9 +324,7 @@ func (m *mrtWriter) loop(ctx context.Context) error {
 
 	writeToFile := func(msgs []*mrt.MRTMessage) {
 		w := func(buf []byte) {
-			if _, err := m.file.Write(buf); err == nil {
-				m.file.Sync()
-			} else {
+			if err := m.out.Write(buf); err != nil {
 				m.s.logger.Warn("Can't write to destination MRT file",
 					slog.String("Topic", "mrt"),
 					slog.String("Error", err.Error()),
@@ -288,14 +352,8 @@ func (m *mrtWriter) loop(ctx context.Context) error {
 	}
 
 	rotateFile := func() {
-		m.file.Close()
-		file, err := mrtFileOpen(m.s.logger, m.c.FileName, m.rotationInterval)
-		if err == nil {
-			m.file = file
-		} else {
-			m.s.logger.Warn("can't rotate MRT file",
-				slog.String("Topic", "mrt"),
-				slog.String("Error", err.Error()))
+		if m.out.isFile() {
+			m.out.rotate(false)
 		}
 	}
 
@@ -335,6 +393,10 @@ func mrtFileOpen(logger *slog.Logger, filename string, rInterval uint64) (*os.Fi
 	if rInterval != 0 {
 		realname = time.Now().Format(filename)
 	}
+	return mrtCreateFile(logger, realname, rInterval)
+}
+
+func mrtCreateFile(logger *slog.Logger, realname string, rInterval uint64) (*os.File, error) {
 	logger.Debug("Setting new MRT destination file",
 		slog.String("Topic", "mrt"),
 		slog.String("Filename", realname),
@@ -374,7 +436,7 @@ func mrtFileOpen(logger *slog.Logger, filename string, rInterval uint64) (*os.Fi
 }
 
 func newMrtWriter(s *BgpServer, c *oc.MrtConfig, rInterval, dInterval uint64) (*mrtWriter, error) {
-	file, err := mrtFileOpen(s.logger, c.FileName, rInterval)
+	out, err := newMrtOutput(s.logger, c, rInterval)
 	if err != nil {
 		return nil, err
 	}
@@ -383,7 +445,7 @@ func newMrtWriter(s *BgpServer, c *oc.MrtConfig, rInterval, dInterval uint64) (*
 		cancel:           cancel,
 		s:                s,
 		c:                c,
-		file:             file,
+		out:              out,
 		rotationInterval: rInterval,
 		dumpInterval:     dInterval,
 	}
@@ -396,28 +458,23 @@ type mrtManager struct {
 	writer    map[string]*mrtWriter
 }
 
-func (m *mrtManager) enable(c *oc.MrtConfig) error {
-	if _, ok := m.writer[c.FileName]; ok {
-		return fmt.Errorf("%s already exists", c.FileName)
-	}
-
-	rInterval := c.RotationInterval
-	dInterval := c.DumpInterval
-
+// mrtIntervals validates the intervals of the dump type and raises them to
+// the minimums.
+func mrtIntervals(logger *slog.Logger, dumpType oc.MrtType, rInterval, dInterval uint64) (uint64, uint64, error) {
 	setRotationMin := func() {
 		if rInterval < minRotationInterval {
-			m.bgpServer.logger.Info("use minimum mrt rotation interval",
+			logger.Info("use minimum mrt rotation interval",
 				slog.String("Topic", "mrt"),
 				slog.Int("Interval", minRotationInterval))
 			rInterval = minRotationInterval
 		}
 	}
 
-	switch c.DumpType {
+	switch dumpType {
 	case oc.MRT_TYPE_TABLE:
 		if rInterval == 0 {
 			if dInterval < minDumpInterval {
-				m.bgpServer.logger.Info("use minimum mrt dump interval",
+				logger.Info("use minimum mrt dump interval",
 					slog.String("Topic", "mrt"),
 					slog.Int("Interval", minDumpInterval))
 				dInterval = minDumpInterval
@@ -425,16 +482,38 @@ func (m *mrtManager) enable(c *oc.MrtConfig) error {
 		} else if dInterval == 0 {
 			setRotationMin()
 		} else {
-			return fmt.Errorf("can't specify both intervals in the table dump type")
+			return 0, 0, fmt.Errorf("can't specify both intervals in the table dump type")
 		}
 	case oc.MRT_TYPE_UPDATES:
 		// ignore the dump interval
 		dInterval = 0
-		if c.TableName.IsValid() {
-			return fmt.Errorf("can't specify the table name with the update dump type")
-		}
 		setRotationMin()
 	}
+	return rInterval, dInterval, nil
+}
+
+func (m *mrtManager) enable(c *oc.MrtConfig) error {
+	if _, ok := m.writer[c.FileName]; ok {
+		return fmt.Errorf("%s already exists", c.FileName)
+	}
+
+	if c.DumpType == oc.MRT_TYPE_UPDATES && (c.TableName.IsValid() || c.Vrf != "") {
+		return fmt.Errorf("can't specify the table name or the vrf with the update dump type")
+	}
+	if c.TableName.IsValid() && c.Vrf != "" {
+		return fmt.Errorf("can't specify both the table name and the vrf")
+	}
+	if c.SinkType != "" && c.SinkType != oc.MRT_SINK_TYPE_FILE && (c.RotationSize != 0 || c.RetentionFiles != 0 || c.RetentionSize != 0) {
+		return fmt.Errorf("can't specify the rotation size or the retention with the %s sink", c.SinkType)
+	}
+	rInterval, dInterval := uint64(0), uint64(0)
+	// the updates are rotated only by the size without the rotation interval
+	if c.DumpType != oc.MRT_TYPE_UPDATES || c.RotationInterval != 0 || c.RotationSize == 0 {
+		var err error
+		if rInterval, dInterval, err = mrtIntervals(m.bgpServer.logger, c.DumpType, c.RotationInterval, c.DumpInterval); err != nil {
+			return err
+		}
+	}
 
 	w, err := newMrtWriter(m.bgpServer, c, rInterval, dInterval)
 	if err == nil {
@@ -0,0 +1,543 @@
+// Copyright (C) 2025 Acnodal Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bufio"
+	"compress/bzip2"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/netip"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/osrg/gobgp/v4/api"
+	"github.com/osrg/gobgp/v4/internal/pkg/table"
+	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
+	"github.com/osrg/gobgp/v4/pkg/packet/mrt"
+)
+
+// errMrtReplayRewind stops reading the file to seek backward.
+var errMrtReplayRewind = errors.New("rewind")
+
+// mrtReplay replays the BGP4MP updates in an MRT file into the global RIB
+// as the routes from the synthetic peers. The records are read and paced in
+// its own goroutine and applied with mgmtOperation.
+type mrtReplay struct {
+	s        *BgpServer
+	name     string
+	filename string
+	speed    float64
+	unpaced  bool
+	// the synthetic peers by the addresses of the peers in the file
+	peers      map[netip.Addr]*table.PeerInfo
+	mappedOnly bool
+	config     *api.StartMrtReplayRequest
+	cancel     context.CancelFunc
+	// the control requests to the goroutine
+	wake chan struct{}
+
+	// the synthetic peers which the routes were injected from, accessed
+	// only in mgmtOperation
+	sources map[netip.Addr]*table.PeerInfo
+
+	mu         sync.Mutex
+	paused     bool
+	seek       time.Duration
+	seekQueued bool
+	state      api.MrtReplay_State
+	start      time.Time
+	position   time.Time
+	records    uint64
+	updates    uint64
+	withdraws  uint64
+	errors     uint64
+	err        string
+}
+
+func newMrtReplay(s *BgpServer, r *api.StartMrtReplayRequest) (*mrtReplay, error) {
+	m := &mrtReplay{
+		s:          s,
+		name:       r.Name,
+		filename:   r.Filename,
+		speed:      r.Speed,
+		unpaced:    r.Unpaced,
+		peers:      make(map[netip.Addr]*table.PeerInfo),
+		mappedOnly: r.MappedPeersOnly,
+		config:     r,
+		wake:       make(chan struct{}, 1),
+		sources:    make(map[netip.Addr]*table.PeerInfo),
+		state:      api.MrtReplay_STATE_RUNNING,
+	}
+	if m.filename == "" {
+		return nil, fmt.Errorf("empty filename")
+	}
+	if m.name == "" {
+		m.name = m.filename
+	}
+	if m.speed < 0 {
+		return nil, fmt.Errorf("invalid speed %f", m.speed)
+	} else if m.speed == 0 {
+		m.speed = 1
+	}
+	for _, p := range r.Peers {
+		addr, err := netip.ParseAddr(p.MrtAddress)
+		if err != nil {
+			return nil, fmt.Errorf("invalid mrt address %q", p.MrtAddress)
+		}
+		info := &table.PeerInfo{AS: p.Asn}
+		if p.Address != "" {
+			if info.Address, err = netip.ParseAddr(p.Address); err != nil {
+				return nil, fmt.Errorf("invalid address %q", p.Address)
+			}
+		}
+		if p.RouterId != "" {
+			if info.ID, err = netip.ParseAddr(p.RouterId); err != nil || !info.ID.Is4() {
+				return nil, fmt.Errorf("invalid router id %q", p.RouterId)
+			}
+		}
+		m.peers[addr] = info
+	}
+	return m, nil
+}
+
+// source returns the synthetic peer of the peer in the file, or nil if the
+// records of the peer are skipped.
+func (m *mrtReplay) source(addr netip.Addr, as uint32) *table.PeerInfo {
+	if src, ok := m.sources[addr]; ok {
+		return src
+	}
+	info := &table.PeerInfo{AS: as, Address: addr}
+	if p, ok := m.peers[addr]; ok {
+		if p.AS != 0 {
+			info.AS = p.AS
+		}
+		if p.Address.IsValid() {
+			info.Address = p.Address
+		}
+		info.ID = p.ID
+	} else if m.mappedOnly {
+		return nil
+	}
+	if !info.ID.IsValid() {
+		if info.Address.Is4() {
+			info.ID = info.Address
+		} else {
+			info.ID = netip.IPv4Unspecified()
+		}
+	}
+	m.sources[addr] = info
+	return info
+}
+
+// withdraw withdraws the routes from the synthetic peer, or from all the
+// synthetic peers if src is nil.
+func (m *mrtReplay) withdraw(src *table.PeerInfo) {
+	rib := m.s.globalRib
+	var withdrawn []*table.Path
+	for addr, info := range m.sources {
+		if src != nil && src != info {
+			continue
+		}
+		for _, path := range rib.GetPathListWithSource(table.GLOBAL_RIB_NAME, rib.GetRFlist(), info) {
+			withdrawn = append(withdrawn, path.Clone(true))
+		}
+		if src == nil {
+			delete(m.sources, addr)
+		}
+	}
+	if len(withdrawn) > 0 {
+		m.s.propagateUpdate(nil, withdrawn)
+	}
+	m.mu.Lock()
+	m.withdraws += uint64(len(withdrawn))
+	m.mu.Unlock()
+}
+
+// apply injects the routes in the record; peers is the last PEER_INDEX_TABLE.
+func (m *mrtReplay) apply(msg *mrt.MRTMessage, peers []*mrt.Peer) {
+	now := time.Now()
+	var paths []*table.Path
+	switch body := msg.Body.(type) {
+	case *mrt.BGP4MPMessage:
+		switch mrt.MRTSubTypeBGP4MP(msg.Header.SubType) {
+		case mrt.MESSAGE_LOCAL, mrt.MESSAGE_AS4_LOCAL, mrt.MESSAGE_LOCAL_ADDPATH, mrt.MESSAGE_AS4_LOCAL_ADDPATH:
+			return
+		}
+		if body.BGPMessage == nil || body.BGPMessage.Header.Type != bgp.BGP_MSG_UPDATE {
+			return
+		}
+		src := m.source(body.PeerIpAddress, body.PeerAS)
+		if src == nil {
+			return
+		}
+		for _, path := range table.ProcessMessage(body.BGPMessage, src, now, false) {
+			if !path.IsEOR() {
+				paths = append(paths, path)
+			}
+		}
+	case *mrt.BGP4MPStateChange:
+		// the routes are withdrawn when the session goes down
+		if body.OldState == mrt.ESTABLISHED && body.NewState != mrt.ESTABLISHED {
+			if src, ok := m.sources[body.PeerIpAddress]; ok {
+				m.withdraw(src)
+			}
+		}
+		return
+	case *mrt.Rib:
+		for _, e := range body.Entries {
+			if int(e.PeerIndex) >= len(peers) {
+				continue
+			}
+			src := m.source(peers[e.PeerIndex].IpAddress, peers[e.PeerIndex].AS)
+			if src == nil {
+				continue
+			}
+			nlri := bgp.PathNLRI{NLRI: body.Prefix, ID: e.PathIdentifier}
+			attrs := make([]bgp.PathAttributeInterface, 0, len(e.PathAttributes))
+			for _, a := range e.PathAttributes {
+				// MP_REACH_NLRI in RIB entries has only the nexthops
+				if mp, ok := a.(*bgp.PathAttributeMpReachNLRI); ok {
+					nmp, err := bgp.NewPathAttributeMpReachNLRI(body.Family, []bgp.PathNLRI{nlri}, mp.Nexthop, mp.LinkLocalNexthop)
+					if err != nil {
+						continue
+					}
+					a = nmp
+				}
+				attrs = append(attrs, a)
+			}
+			paths = append(paths, table.NewPath(body.Family, src, nlri, false, attrs, now, false))
+		}
+	default:
+		return
+	}
+	if len(paths) == 0 {
+		return
+	}
+	m.s.propagateUpdate(nil, paths)
+	m.mu.Lock()
+	for _, path := range paths {
+		if path.IsWithdraw {
+			m.withdraws++
+		} else {
+			m.updates++
+		}
+	}
+	m.mu.Unlock()
+}
+
+func (m *mrtReplay) open() (io.ReadCloser, io.Reader, error) {
+	f, err := os.Open(m.filename)
+	if err != nil {
+		return nil, nil, err
+	}
+	var r io.Reader = bufio.NewReader(f)
+	switch {
+	case strings.HasSuffix(m.filename, ".gz"):
+		if r, err = gzip.NewReader(r); err != nil {
+			f.Close()
+			return nil, nil, err
+		}
+	case strings.HasSuffix(m.filename, ".bz2"):
+		r = bzip2.NewReader(r)
+	}
+	return f, r, nil
+}
+
+// pace waits until the record at the timestamp is due, handling the control
+// requests meanwhile. base is the wall clock time when the record at baseTs
+// was replayed, and zero after the replay is resumed or seeked.
+func (m *mrtReplay) pace(ctx context.Context, ts time.Time, until, base, baseTs *time.Time) error {
+	for {
+		m.mu.Lock()
+		if m.seekQueued {
+			target := m.start.Add(m.seek)
+			if target.Before(m.position) {
+				m.mu.Unlock()
+				return errMrtReplayRewind
+			}
+			m.seekQueued = false
+			*until = target
+			*base = time.Time{}
+		}
+		paused := m.paused
+		if paused {
+			m.state = api.MrtReplay_STATE_PAUSED
+		} else {
+			m.state = api.MrtReplay_STATE_RUNNING
+		}
+		m.mu.Unlock()
+
+		if paused {
+			*base = time.Time{}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-m.wake:
+			}
+			continue
+		}
+		if m.unpaced || ts.Before(*until) {
+			return nil
+		}
+		if base.IsZero() {
+			*base, *baseTs = time.Now(), ts
+			return nil
+		}
+		d := time.Until(base.Add(time.Duration(float64(ts.Sub(*baseTs)) / m.speed)))
+		if d <= 0 {
+			return nil
+		}
+		timer := time.NewTimer(d)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-m.wake:
+			timer.Stop()
+		case <-timer.C:
+			return nil
+		}
+	}
+}
+
+// replay replays the file from the start; it returns nil at the end of the
+// file.
+func (m *mrtReplay) replay(ctx context.Context) error {
+	f, r, err := m.open()
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var until, base, baseTs time.Time
+	var peers []*mrt.Peer
+	for {
+		buf := make([]byte, mrt.MRT_COMMON_HEADER_LEN)
+		if _, err := io.ReadFull(r, buf); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		h, err := mrt.ParseHeader(buf)
+		if err != nil {
+			return err
+		}
+		buf = make([]byte, h.Len)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return err
+		}
+		msg, err := mrt.ParseBody(buf, h)
+		if err != nil {
+			m.s.logger.Warn("Failed to parse MRT record",
+				slog.String("Topic", "mrt"),
+				slog.String("Name", m.name),
+				slog.String("Error", err.Error()))
+			m.mu.Lock()
+			m.errors++
+			m.mu.Unlock()
+			continue
+		}
+		if t, ok := msg.Body.(*mrt.PeerIndexTable); ok {
+			peers = t.Peers
+			continue
+		}
+
+		ts := h.GetTime()
+		m.mu.Lock()
+		if m.start.IsZero() {
+			m.start = ts
+		}
+		m.mu.Unlock()
+		if err := m.pace(ctx, ts, &until, &base, &baseTs); err != nil {
+			return err
+		}
+		if err := m.s.mgmtOperation(func() error {
+			if ctx.Err() == nil {
+				m.apply(msg, peers)
+			}
+			return nil
+		}, true); err != nil {
+			return err
+		}
+		m.mu.Lock()
+		m.records++
+		if ts.After(m.position) {
+			m.position = ts
+		}
+		m.mu.Unlock()
+	}
+}
+
+func (m *mrtReplay) loop(ctx context.Context) {
+	for {
+		err := m.replay(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != errMrtReplayRewind {
+			m.mu.Lock()
+			if err != nil {
+				m.state = api.MrtReplay_STATE_FAILED
+				m.err = err.Error()
+				m.s.logger.Warn("MRT replay failed",
+					slog.String("Topic", "mrt"),
+					slog.String("Name", m.name),
+					slog.String("Error", err.Error()))
+			} else {
+				m.state = api.MrtReplay_STATE_FINISHED
+			}
+			m.mu.Unlock()
+			// a seek replays the file again
+			for seek := false; !seek; {
+				select {
+				case <-ctx.Done():
+					return
+				case <-m.wake:
+				}
+				m.mu.Lock()
+				seek = m.seekQueued
+				m.mu.Unlock()
+			}
+		}
+		if err := m.s.mgmtOperation(func() error {
+			if ctx.Err() == nil {
+				m.withdraw(nil)
+			}
+			return nil
+		}, true); err != nil {
+			return
+		}
+		m.mu.Lock()
+		m.state = api.MrtReplay_STATE_RUNNING
+		m.err = ""
+		m.start = time.Time{}
+		m.position = time.Time{}
+		m.mu.Unlock()
+	}
+}
+
+func (m *mrtReplay) notify() {
+	select {
+	case m.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (m *mrtReplay) setPaused(paused bool) {
+	m.mu.Lock()
+	m.paused = paused
+	m.mu.Unlock()
+	m.notify()
+}
+
+func (m *mrtReplay) setSeek(offset time.Duration) {
+	m.mu.Lock()
+	m.seek = offset
+	m.seekQueued = true
+	m.mu.Unlock()
+	m.notify()
+}
+
+func (m *mrtReplay) toApi() *api.MrtReplay {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	r := &api.MrtReplay{
+		Name:            m.name,
+		Filename:        m.filename,
+		Speed:           m.speed,
+		Unpaced:         m.unpaced,
+		Peers:           m.config.Peers,
+		MappedPeersOnly: m.mappedOnly,
+		State:           m.state,
+		Records:         m.records,
+		Updates:         m.updates,
+		Withdraws:       m.withdraws,
+		Errors:          m.errors,
+		Error:           m.err,
+	}
+	if !m.start.IsZero() {
+		r.Start = timestamppb.New(m.start)
+	}
+	if !m.position.IsZero() {
+		r.Position = timestamppb.New(m.position)
+	}
+	return r
+}
+
+type mrtReplayManager struct {
+	s       *BgpServer
+	replays map[string]*mrtReplay
+}
+
+func newMrtReplayManager(s *BgpServer) *mrtReplayManager {
+	return &mrtReplayManager{
+		s:       s,
+		replays: make(map[string]*mrtReplay),
+	}
+}
+
+func (m *mrtReplayManager) start(r *api.StartMrtReplayRequest) error {
+	replay, err := newMrtReplay(m.s, r)
+	if err != nil {
+		return err
+	}
+	if _, ok := m.replays[replay.name]; ok {
+		return fmt.Errorf("mrt replay %s already exists", replay.name)
+	}
+	if _, err := os.Stat(replay.filename); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	replay.cancel = cancel
+	m.replays[replay.name] = replay
+	go replay.loop(ctx)
+	return nil
+}
+
+func (m *mrtReplayManager) get(name string) (*mrtReplay, error) {
+	replay, ok := m.replays[name]
+	if !ok {
+		return nil, fmt.Errorf("mrt replay %s not found", name)
+	}
+	return replay, nil
+}
+
+func (m *mrtReplayManager) stop(name string) error {
+	replay, err := m.get(name)
+	if err != nil {
+		return err
+	}
+	replay.cancel()
+	replay.withdraw(nil)
+	delete(m.replays, name)
+	return nil
+}
+
+func (m *mrtReplayManager) stopAll() {
+	for _, replay := range m.replays {
+		replay.cancel()
+	}
+	clear(m.replays)
+}
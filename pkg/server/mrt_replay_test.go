@@ -0,0 +1,176 @@
+// Copyright (C) 2025 Acnodal Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/osrg/gobgp/v4/api"
+	"github.com/osrg/gobgp/v4/internal/pkg/table"
+	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
+	"github.com/osrg/gobgp/v4/pkg/packet/mrt"
+)
+
+func writeMrtReplayFile(t *testing.T, filename string, start time.Time) {
+	local := netip.MustParseAddr("192.0.2.254")
+	update := func(withdraw bool, prefix string) *bgp.BGPMessage {
+		nlri, _ := bgp.NewIPAddrPrefix(netip.MustParsePrefix(prefix))
+		if withdraw {
+			return bgp.NewBGPUpdateMessage([]bgp.PathNLRI{{NLRI: nlri}}, nil, nil)
+		}
+		nh, _ := bgp.NewPathAttributeNextHop(netip.MustParseAddr("192.0.2.100"))
+		attrs := []bgp.PathAttributeInterface{
+			bgp.NewPathAttributeOrigin(0),
+			bgp.NewPathAttributeAsPath([]bgp.AsPathParamInterface{bgp.NewAs4PathParam(bgp.BGP_ASPATH_ATTR_TYPE_SEQ, []uint32{65001})}),
+			nh,
+		}
+		return bgp.NewBGPUpdateMessage(nil, attrs, []bgp.PathNLRI{{NLRI: nlri}})
+	}
+	message := func(as uint32, peer string, msg *bgp.BGPMessage) mrt.Body {
+		b, err := mrt.NewBGP4MPMessage(as, 65000, 0, netip.MustParseAddr(peer), local, true, msg)
+		require.NoError(t, err)
+		return b
+	}
+	stateChange, err := mrt.NewBGP4MPStateChange(65002, 65000, 0, netip.MustParseAddr("192.0.2.2"), local, true, mrt.ESTABLISHED, mrt.IDLE)
+	require.NoError(t, err)
+
+	var data []byte
+	for _, r := range []struct {
+		offset  time.Duration
+		subtype mrt.MRTSubTypeBGP4MP
+		body    mrt.Body
+	}{
+		{0, mrt.MESSAGE_AS4, message(65001, "192.0.2.1", update(false, "10.0.0.0/24"))},
+		{time.Second, mrt.MESSAGE_AS4, message(65002, "192.0.2.2", update(false, "10.0.1.0/24"))},
+		{time.Hour, mrt.MESSAGE_AS4, message(65001, "192.0.2.1", update(true, "10.0.0.0/24"))},
+		{time.Hour + time.Second, mrt.STATE_CHANGE_AS4, stateChange},
+	} {
+		msg, err := mrt.NewMRTMessage(start.Add(r.offset), mrt.BGP4MP, r.subtype, r.body)
+		require.NoError(t, err)
+		b, err := msg.Serialize()
+		require.NoError(t, err)
+		data = append(data, b...)
+	}
+	require.NoError(t, os.WriteFile(filename, data, 0o644))
+}
+
+func TestMrtReplay(t *testing.T) {
+	ctx := context.Background()
+	s := runNewServer(t, 65000, "1.1.1.1", 10179)
+	defer s.StopBgp(ctx, &api.StopBgpRequest{})
+
+	filename := filepath.Join(t.TempDir(), "updates.mrt")
+	start := time.Unix(1700000000, 0).UTC()
+	writeMrtReplayFile(t, filename, start)
+
+	require.NoError(t, s.StartMrtReplay(ctx, &api.StartMrtReplayRequest{
+		Name:     "r1",
+		Filename: filename,
+		Peers: []*api.MrtReplayPeer{{
+			MrtAddress: "192.0.2.2",
+			Address:    "198.51.100.1",
+			Asn:        65100,
+		}},
+	}))
+	err := s.StartMrtReplay(ctx, &api.StartMrtReplayRequest{Name: "r1", Filename: filename})
+	assert.Error(t, err)
+
+	// the sources of the paths in the global rib
+	sources := func() []string {
+		var l []string
+		require.NoError(t, s.mgmtOperation(func() error {
+			for _, path := range s.globalRib.GetPathList(table.GLOBAL_RIB_NAME, 0, []bgp.Family{bgp.RF_IPv4_UC}) {
+				src := path.GetSource()
+				l = append(l, fmt.Sprintf("%s %s %s %d", path.GetPrefix(), src.Address, src.ID, src.AS))
+			}
+			return nil
+		}, false))
+		sort.Strings(l)
+		return l
+	}
+	replay := func() *api.MrtReplay {
+		var r *api.MrtReplay
+		require.NoError(t, s.ListMrtReplay(ctx, &api.ListMrtReplayRequest{Name: "r1"}, func(m *api.MrtReplay) { r = m }))
+		require.NotNil(t, r)
+		return r
+	}
+	running := []string{
+		"10.0.0.0/24 192.0.2.1 192.0.2.1 65001",
+		"10.0.1.0/24 198.51.100.1 198.51.100.1 65100",
+	}
+
+	// the third record is an hour later
+	assert.Eventually(t, func() bool { return len(sources()) == 2 }, 10*time.Second, 50*time.Millisecond)
+	assert.Equal(t, running, sources())
+	r := replay()
+	assert.Equal(t, api.MrtReplay_STATE_RUNNING, r.State)
+	assert.Equal(t, start, r.Start.AsTime())
+	assert.Equal(t, start.Add(time.Second), r.Position.AsTime())
+	assert.Equal(t, uint64(2), r.Records)
+
+	require.NoError(t, s.PauseMrtReplay(ctx, &api.PauseMrtReplayRequest{Name: "r1"}))
+	assert.Eventually(t, func() bool { return replay().State == api.MrtReplay_STATE_PAUSED }, 10*time.Second, 50*time.Millisecond)
+
+	// the withdrawal and the session down of the mapped peer
+	require.NoError(t, s.SeekMrtReplay(ctx, &api.SeekMrtReplayRequest{Name: "r1", Offset: 3600}))
+	require.NoError(t, s.ResumeMrtReplay(ctx, &api.ResumeMrtReplayRequest{Name: "r1"}))
+	assert.Eventually(t, func() bool { return replay().State == api.MrtReplay_STATE_FINISHED }, 10*time.Second, 50*time.Millisecond)
+	assert.Empty(t, sources())
+	r = replay()
+	assert.Equal(t, uint64(4), r.Records)
+	assert.Equal(t, uint64(2), r.Updates)
+	assert.Equal(t, uint64(2), r.Withdraws)
+
+	// seeking backward replays from the start
+	require.NoError(t, s.SeekMrtReplay(ctx, &api.SeekMrtReplayRequest{Name: "r1", Offset: 0}))
+	assert.Eventually(t, func() bool { return len(sources()) == 2 }, 10*time.Second, 50*time.Millisecond)
+	assert.Equal(t, running, sources())
+	assert.Equal(t, api.MrtReplay_STATE_RUNNING, replay().State)
+
+	require.NoError(t, s.StopMrtReplay(ctx, &api.StopMrtReplayRequest{Name: "r1"}))
+	assert.Empty(t, sources())
+	assert.Error(t, s.StopMrtReplay(ctx, &api.StopMrtReplayRequest{Name: "r1"}))
+
+	// only the mapped peers, as fast as possible
+	require.NoError(t, s.StartMrtReplay(ctx, &api.StartMrtReplayRequest{
+		Filename:        filename,
+		Unpaced:         true,
+		MappedPeersOnly: true,
+		Peers:           []*api.MrtReplayPeer{{MrtAddress: "192.0.2.1", RouterId: "10.10.10.10"}},
+	}))
+	var l []*api.MrtReplay
+	require.NoError(t, s.ListMrtReplay(ctx, &api.ListMrtReplayRequest{}, func(m *api.MrtReplay) { l = append(l, m) }))
+	require.Len(t, l, 1)
+	assert.Equal(t, filename, l[0].Name)
+	assert.Eventually(t, func() bool {
+		var r *api.MrtReplay
+		require.NoError(t, s.ListMrtReplay(ctx, &api.ListMrtReplayRequest{Name: filename}, func(m *api.MrtReplay) { r = m }))
+		return r.State == api.MrtReplay_STATE_FINISHED && r.Withdraws == 1
+	}, 10*time.Second, 50*time.Millisecond)
+	assert.Empty(t, sources())
+
+	assert.Error(t, s.StartMrtReplay(ctx, &api.StartMrtReplayRequest{Filename: filepath.Join(t.TempDir(), "none")}))
+}
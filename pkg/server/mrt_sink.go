@@ -0,0 +1,332 @@
+// Copyright (C) 2025 Acnodal Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/dsnet/compress/bzip2"
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/osrg/gobgp/v4/pkg/config/oc"
+)
+
+// mrtSink is the destination of the MRT records.
+type mrtSink interface {
+	io.WriteCloser
+	// Flush makes the records written so far visible to the reader.
+	Flush() error
+}
+
+type mrtFileSink struct {
+	*os.File
+}
+
+func (s *mrtFileSink) Flush() error {
+	return s.Sync()
+}
+
+type mrtStreamSink struct {
+	io.WriteCloser
+}
+
+func (s *mrtStreamSink) Flush() error {
+	return nil
+}
+
+// mrtOpenPipe opens the named pipe, which fails without the reader.
+func mrtOpenPipe(name string) (mrtSink, error) {
+	info, err := os.Stat(name)
+	if err != nil {
+		return nil, err
+	}
+	if info.Mode()&os.ModeNamedPipe == 0 {
+		return nil, fmt.Errorf("%s isn't a named pipe", name)
+	}
+	f, err := os.OpenFile(name, os.O_WRONLY|syscall.O_NONBLOCK, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &mrtStreamSink{f}, nil
+}
+
+func mrtDialUnix(name string) (mrtSink, error) {
+	conn, err := net.DialTimeout("unix", name, time.Second)
+	if err != nil {
+		return nil, err
+	}
+	return &mrtStreamSink{conn}, nil
+}
+
+// mrtCountingSink counts the bytes written to the sink, after the
+// compression.
+type mrtCountingSink struct {
+	mrtSink
+	written *uint64
+}
+
+func (s *mrtCountingSink) Write(b []byte) (int, error) {
+	n, err := s.mrtSink.Write(b)
+	*s.written += uint64(n)
+	return n, err
+}
+
+// mrtCompressSink compresses the records written to the sink.
+type mrtCompressSink struct {
+	io.WriteCloser
+	// flush writes out the records compressed so far; nil if the
+	// compression writes whole blocks only
+	flush func() error
+	sink  mrtSink
+}
+
+func (s *mrtCompressSink) Flush() error {
+	if s.flush != nil {
+		if err := s.flush(); err != nil {
+			return err
+		}
+	}
+	return s.sink.Flush()
+}
+
+func (s *mrtCompressSink) Close() error {
+	err := s.WriteCloser.Close()
+	if cerr := s.sink.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+func mrtCompress(sink mrtSink, compression oc.MrtCompressionType) (mrtSink, error) {
+	switch compression {
+	case oc.MRT_COMPRESSION_TYPE_GZIP:
+		w := gzip.NewWriter(sink)
+		return &mrtCompressSink{WriteCloser: w, flush: w.Flush, sink: sink}, nil
+	case oc.MRT_COMPRESSION_TYPE_BZIP2:
+		// the blocks of 900 KB as the bzip2 command does by default
+		w, err := bzip2.NewWriter(sink, &bzip2.WriterConfig{Level: bzip2.BestCompression})
+		if err != nil {
+			return nil, err
+		}
+		return &mrtCompressSink{WriteCloser: w, sink: sink}, nil
+	case oc.MRT_COMPRESSION_TYPE_ZSTD:
+		w, err := zstd.NewWriter(sink, zstd.WithEncoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		return &mrtCompressSink{WriteCloser: w, flush: w.Flush, sink: sink}, nil
+	}
+	return sink, nil
+}
+
+// mrtOutput writes the records to the sink of the config, compressing them,
+// rotating the files and removing the old ones. The sinks other than the
+// files are reopened when they fail, dropping the records meanwhile.
+type mrtOutput struct {
+	logger           *slog.Logger
+	c                *oc.MrtConfig
+	rotationInterval uint64
+
+	sink mrtSink
+	// the name of the current file, without the sequence number
+	name string
+	seq  int
+	// the bytes written to the current file after the compression
+	written uint64
+	// the files opened, from the oldest
+	files []string
+
+	// the statistics, read outside of the writer
+	bytes     atomic.Uint64
+	errors    atomic.Uint64
+	rotations atomic.Uint64
+	lastWrite atomic.Int64
+}
+
+func (o *mrtOutput) isFile() bool {
+	return o.c.SinkType == "" || o.c.SinkType == oc.MRT_SINK_TYPE_FILE
+}
+
+// mrtSequenceName inserts the sequence number of the rotation by size before
+// the extension.
+func mrtSequenceName(name string, seq int) string {
+	if seq == 0 {
+		return name
+	}
+	ext := filepath.Ext(name)
+	return name[:len(name)-len(ext)] + "." + strconv.Itoa(seq) + ext
+}
+
+func (o *mrtOutput) open(bySize bool) error {
+	var sink mrtSink
+	var err error
+	o.written = 0
+	switch o.c.SinkType {
+	case oc.MRT_SINK_TYPE_PIPE:
+		sink, err = mrtOpenPipe(o.c.FileName)
+	case oc.MRT_SINK_TYPE_UNIX_SOCKET:
+		sink, err = mrtDialUnix(o.c.FileName)
+	default:
+		name := o.c.FileName
+		if o.rotationInterval != 0 {
+			name = time.Now().Format(name)
+		}
+		if name != o.name {
+			o.seq = 0
+		} else if bySize {
+			o.seq++
+		}
+		o.name = name
+		realname := mrtSequenceName(name, o.seq)
+		var file *os.File
+		if file, err = mrtCreateFile(o.logger, realname, o.rotationInterval); err == nil {
+			sink = &mrtCountingSink{mrtSink: &mrtFileSink{file}, written: &o.written}
+			if len(o.files) == 0 || o.files[len(o.files)-1] != realname {
+				o.files = append(o.files, realname)
+			}
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if o.sink, err = mrtCompress(sink, o.c.Compression); err != nil {
+		sink.Close()
+		return err
+	}
+	return nil
+}
+
+func (o *mrtOutput) close() {
+	if o.sink == nil {
+		return
+	}
+	if err := o.sink.Close(); err != nil {
+		o.logger.Warn("Failed to close MRT destination",
+			slog.String("Topic", "mrt"),
+			slog.String("Filename", o.c.FileName),
+			slog.String("Error", err.Error()))
+	}
+	o.sink = nil
+}
+
+// retain removes the oldest files over the retention limits, except the
+// current one.
+func (o *mrtOutput) retain() {
+	if o.c.RetentionFiles == 0 && o.c.RetentionSize == 0 {
+		return
+	}
+	sizes := make([]uint64, len(o.files))
+	total := uint64(0)
+	for i, name := range o.files {
+		if info, err := os.Stat(name); err == nil {
+			sizes[i] = uint64(info.Size())
+			total += sizes[i]
+		}
+	}
+	i := 0
+	for ; i < len(o.files)-1; i++ {
+		overFiles := o.c.RetentionFiles != 0 && uint32(len(o.files)-i) > o.c.RetentionFiles
+		overSize := o.c.RetentionSize != 0 && total > o.c.RetentionSize
+		if !overFiles && !overSize {
+			break
+		}
+		if err := os.Remove(o.files[i]); err != nil && !os.IsNotExist(err) {
+			o.logger.Warn("Failed to remove MRT file",
+				slog.String("Topic", "mrt"),
+				slog.String("Filename", o.files[i]),
+				slog.String("Error", err.Error()))
+		}
+		total -= sizes[i]
+	}
+	o.files = o.files[i:]
+}
+
+func (o *mrtOutput) rotate(bySize bool) {
+	o.rotations.Add(1)
+	o.close()
+	if err := o.open(bySize); err != nil {
+		o.logger.Warn("can't rotate MRT file",
+			slog.String("Topic", "mrt"),
+			slog.String("Error", err.Error()))
+		return
+	}
+	o.retain()
+}
+
+func (o *mrtOutput) Write(b []byte) error {
+	if o.sink == nil {
+		// reconnect to the reader, or retry the failed rotation
+		if err := o.open(false); err != nil {
+			o.errors.Add(1)
+			return err
+		}
+		if !o.isFile() {
+			o.logger.Info("MRT destination connected",
+				slog.String("Topic", "mrt"),
+				slog.String("Filename", o.c.FileName))
+		}
+	}
+	_, err := o.sink.Write(b)
+	if err == nil {
+		err = o.sink.Flush()
+	}
+	if err != nil {
+		if !o.isFile() {
+			o.close()
+		}
+		o.errors.Add(1)
+		return err
+	}
+	o.bytes.Add(uint64(len(b)))
+	o.lastWrite.Store(time.Now().Unix())
+	if o.isFile() && o.c.RotationSize != 0 && o.written >= o.c.RotationSize {
+		o.rotate(true)
+	}
+	return nil
+}
+
+func (o *mrtOutput) Close() {
+	o.close()
+}
+
+func newMrtOutput(logger *slog.Logger, c *oc.MrtConfig, rInterval uint64) (*mrtOutput, error) {
+	o := &mrtOutput{
+		logger:           logger,
+		c:                c,
+		rotationInterval: rInterval,
+	}
+	if err := o.open(false); err != nil {
+		if o.isFile() {
+			return nil, err
+		}
+		// the reader may come later
+		logger.Info("MRT destination isn't ready",
+			slog.String("Topic", "mrt"),
+			slog.String("Filename", c.FileName),
+			slog.String("Error", err.Error()))
+	}
+	return o, nil
+}
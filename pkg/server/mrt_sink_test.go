@@ -0,0 +1,190 @@
+// Copyright (C) 2025 Acnodal Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"compress/bzip2"
+	"compress/gzip"
+	"context"
+	"crypto/rand"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/osrg/gobgp/v4/api"
+	"github.com/osrg/gobgp/v4/pkg/config/oc"
+)
+
+func TestMrtSequenceName(t *testing.T) {
+	assert.Equal(t, "/tmp/updates.mrt.gz", mrtSequenceName("/tmp/updates.mrt.gz", 0))
+	assert.Equal(t, "/tmp/updates.mrt.2.gz", mrtSequenceName("/tmp/updates.mrt.gz", 2))
+	assert.Equal(t, "/tmp/updates.1", mrtSequenceName("/tmp/updates", 1))
+}
+
+func TestMrtOutputRotation(t *testing.T) {
+	dir := t.TempDir()
+	o, err := newMrtOutput(logger, &oc.MrtConfig{
+		FileName:       filepath.Join(dir, "updates.gz"),
+		Compression:    oc.MRT_COMPRESSION_TYPE_GZIP,
+		RotationSize:   100,
+		RetentionFiles: 3,
+	}, 0)
+	require.NoError(t, err)
+
+	// the size is counted after the compression
+	record := make([]byte, 60)
+	_, err = rand.Read(record)
+	require.NoError(t, err)
+	for range 9 {
+		require.NoError(t, o.Write(record))
+	}
+	// the current file is readable before it's closed
+	read := func(name string) []byte {
+		f, err := os.Open(filepath.Join(dir, name))
+		require.NoError(t, err)
+		defer f.Close()
+		r, err := gzip.NewReader(f)
+		require.NoError(t, err)
+		b, _ := io.ReadAll(r)
+		return b
+	}
+	assert.Equal(t, record, read("updates.4.gz"))
+	assert.Equal(t, uint64(9*len(record)), o.bytes.Load())
+	assert.Equal(t, uint64(4), o.rotations.Load())
+	o.Close()
+
+	// rotated every two records and the three latest files are kept
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	assert.ElementsMatch(t, []string{"updates.2.gz", "updates.3.gz", "updates.4.gz"}, names)
+	assert.Equal(t, append(record, record...), read("updates.3.gz"))
+	assert.Equal(t, record, read("updates.4.gz"))
+
+	// the total size
+	dir = t.TempDir()
+	o, err = newMrtOutput(logger, &oc.MrtConfig{
+		FileName:      filepath.Join(dir, "updates"),
+		RotationSize:  100,
+		RetentionSize: 250,
+	}, 0)
+	require.NoError(t, err)
+	for range 10 {
+		require.NoError(t, o.Write(record))
+	}
+	o.Close()
+	entries, err = os.ReadDir(dir)
+	require.NoError(t, err)
+	names = nil
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	// the empty current file after the last rotation
+	assert.ElementsMatch(t, []string{"updates.3", "updates.4", "updates.5"}, names)
+}
+
+func TestMrtOutputCompression(t *testing.T) {
+	for _, tt := range []struct {
+		compression oc.MrtCompressionType
+		reader      func(io.Reader) (io.Reader, error)
+		// the records can be read before the file is closed
+		flushed bool
+	}{
+		{oc.MRT_COMPRESSION_TYPE_GZIP, func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) }, true},
+		{oc.MRT_COMPRESSION_TYPE_BZIP2, func(r io.Reader) (io.Reader, error) { return bzip2.NewReader(r), nil }, false},
+		{oc.MRT_COMPRESSION_TYPE_ZSTD, func(r io.Reader) (io.Reader, error) { return zstd.NewReader(r) }, true},
+	} {
+		t.Run(string(tt.compression), func(t *testing.T) {
+			name := filepath.Join(t.TempDir(), "updates")
+			o, err := newMrtOutput(logger, &oc.MrtConfig{FileName: name, Compression: tt.compression}, 0)
+			require.NoError(t, err)
+			read := func() string {
+				f, err := os.Open(name)
+				require.NoError(t, err)
+				defer f.Close()
+				r, err := tt.reader(f)
+				require.NoError(t, err)
+				b, _ := io.ReadAll(r)
+				return string(b)
+			}
+			require.NoError(t, o.Write([]byte("hello ")))
+			require.NoError(t, o.Write([]byte("world")))
+			if tt.flushed {
+				assert.Equal(t, "hello world", read())
+				assert.Positive(t, o.written)
+			}
+			o.Close()
+			assert.Equal(t, "hello world", read())
+		})
+	}
+}
+
+func TestMrtOutputUnixSocket(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "mrt.sock")
+	c := &oc.MrtConfig{FileName: name, SinkType: oc.MRT_SINK_TYPE_UNIX_SOCKET}
+
+	// the consumer isn't listening yet
+	o, err := newMrtOutput(logger, c, 0)
+	require.NoError(t, err)
+	assert.Error(t, o.Write([]byte("dropped")))
+
+	l, err := net.Listen("unix", name)
+	require.NoError(t, err)
+	defer l.Close()
+	received := make(chan []byte)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		b, _ := io.ReadAll(conn)
+		received <- b
+	}()
+	require.NoError(t, o.Write([]byte("hello")))
+	o.Close()
+	assert.Equal(t, "hello", string(<-received))
+}
+
+func TestDisableMrt(t *testing.T) {
+	ctx := context.Background()
+	s := runNewServer(t, 1, "1.1.1.1", 10179)
+	defer s.StopBgp(ctx, &api.StopBgpRequest{})
+
+	name := filepath.Join(t.TempDir(), "updates")
+	require.NoError(t, s.EnableMrt(ctx, &api.EnableMrtRequest{
+		DumpType:     api.EnableMrtRequest_DUMP_TYPE_UPDATES,
+		Filename:     name,
+		Compression:  api.EnableMrtRequest_COMPRESSION_GZIP,
+		RotationSize: 1 << 20,
+	}))
+	assert.Error(t, s.EnableMrt(ctx, &api.EnableMrtRequest{
+		DumpType:     api.EnableMrtRequest_DUMP_TYPE_UPDATES,
+		Filename:     filepath.Join(t.TempDir(), "mrt.sock"),
+		Sink:         api.EnableMrtRequest_SINK_UNIX_SOCKET,
+		RotationSize: 1 << 20,
+	}))
+	require.NoError(t, s.DisableMrt(ctx, &api.DisableMrtRequest{Filename: name}))
+	assert.Error(t, s.DisableMrt(ctx, &api.DisableMrtRequest{Filename: name}))
+}
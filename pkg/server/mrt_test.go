@@ -0,0 +1,113 @@
+// Copyright (C) 2025 Acnodal Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/osrg/gobgp/v4/api"
+	"github.com/osrg/gobgp/v4/pkg/apiutil"
+	"github.com/osrg/gobgp/v4/pkg/config/oc"
+	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
+	"github.com/osrg/gobgp/v4/pkg/packet/mrt"
+)
+
+// mrtDumpRibs serializes the TABLE_DUMPv2 messages of the writer, parses
+// them back and returns the RIB entries by the families and the prefixes.
+func mrtDumpRibs(t *testing.T, m *mrtWriter) map[bgp.Family][]string {
+	var buf bytes.Buffer
+	for _, msg := range m.dumpTable() {
+		b, err := msg.Serialize()
+		require.NoError(t, err)
+		buf.Write(b)
+	}
+
+	ribs := make(map[bgp.Family][]string)
+	scanner := bufio.NewScanner(&buf)
+	scanner.Split(mrt.SplitMrt)
+	for scanner.Scan() {
+		data := scanner.Bytes()
+		h, err := mrt.ParseHeader(data[:mrt.MRT_COMMON_HEADER_LEN])
+		require.NoError(t, err)
+		msg, err := mrt.ParseBody(data[mrt.MRT_COMMON_HEADER_LEN:], h)
+		require.NoError(t, err)
+		if rib, ok := msg.Body.(*mrt.Rib); ok {
+			require.NotEmpty(t, rib.Entries)
+			ribs[rib.Family] = append(ribs[rib.Family], rib.Prefix.String())
+		}
+	}
+	require.NoError(t, scanner.Err())
+	return ribs
+}
+
+func TestMrtDumpTable(t *testing.T) {
+	s := runNewServer(t, 1, "1.1.1.1", 10179)
+	defer s.StopBgp(context.Background(), &api.StopBgpRequest{})
+
+	addVrf(t, s, "vrf1", "111:111", []string{"111:111"}, []string{"111:111"}, 1)
+
+	add := func(vrf string, family bgp.Family, nlri bgp.NLRI, nexthop string) {
+		attrs := []bgp.PathAttributeInterface{bgp.NewPathAttributeOrigin(0)}
+		if family == bgp.RF_IPv4_UC {
+			nh, _ := bgp.NewPathAttributeNextHop(netip.MustParseAddr(nexthop))
+			attrs = append(attrs, nh)
+		} else {
+			mp, err := bgp.NewPathAttributeMpReachNLRI(family, []bgp.PathNLRI{{NLRI: nlri}}, netip.MustParseAddr(nexthop))
+			require.NoError(t, err)
+			attrs = append(attrs, mp)
+		}
+		path, err := apiutil.NewPath(family, nlri, false, attrs, time.Now())
+		require.NoError(t, err)
+		_, err = s.AddPath(apiutil.AddPathRequest{VRFID: vrf, Paths: []*apiutil.Path{mustApi2apiutilPath(path)}})
+		require.NoError(t, err)
+	}
+
+	rd, _ := bgp.ParseRouteDistinguisher("222:222")
+	v4, _ := bgp.NewIPAddrPrefix(netip.MustParsePrefix("10.0.0.0/24"))
+	v6, _ := bgp.NewIPAddrPrefix(netip.MustParsePrefix("2001:db8::/64"))
+	vpn, _ := bgp.NewLabeledVPNIPAddrPrefix(netip.MustParsePrefix("10.1.0.0/24"), *bgp.NewMPLSLabelStack(100), rd)
+	labeled, _ := bgp.NewLabeledIPAddrPrefix(netip.MustParsePrefix("10.2.0.0/24"), *bgp.NewMPLSLabelStack(200))
+	evpn, _ := bgp.NewEVPNIPPrefixRoute(rd, bgp.EthernetSegmentIdentifier{}, 0, 24, netip.MustParseAddr("10.3.0.0"), netip.MustParseAddr("0.0.0.0"), 300)
+	vrf, _ := bgp.NewIPAddrPrefix(netip.MustParsePrefix("10.4.0.0/24"))
+	add("", bgp.RF_IPv4_UC, v4, "192.0.2.1")
+	add("", bgp.RF_IPv6_UC, v6, "2001:db8::1")
+	add("", bgp.RF_IPv4_VPN, vpn, "192.0.2.1")
+	add("", bgp.RF_IPv4_MPLS, labeled, "192.0.2.1")
+	add("", bgp.RF_EVPN, evpn, "192.0.2.1")
+	add("vrf1", bgp.RF_IPv4_UC, vrf, "192.0.2.2")
+
+	ribs := mrtDumpRibs(t, &mrtWriter{s: s, c: &oc.MrtConfig{DumpType: oc.MRT_TYPE_TABLE}})
+	assert.Equal(t, []string{"10.0.0.0/24"}, ribs[bgp.RF_IPv4_UC])
+	assert.Equal(t, []string{"2001:db8::/64"}, ribs[bgp.RF_IPv6_UC])
+	assert.ElementsMatch(t, []string{vpn.String(), "111:111:10.4.0.0/24"}, ribs[bgp.RF_IPv4_VPN])
+	assert.Equal(t, []string{labeled.String()}, ribs[bgp.RF_IPv4_MPLS])
+	assert.Equal(t, []string{evpn.String()}, ribs[bgp.RF_EVPN])
+
+	// only the paths imported to the VRF, without the RDs
+	ribs = mrtDumpRibs(t, &mrtWriter{s: s, c: &oc.MrtConfig{DumpType: oc.MRT_TYPE_TABLE, Vrf: "vrf1"}})
+	assert.Equal(t, map[bgp.Family][]string{bgp.RF_IPv4_UC: {"10.4.0.0/24"}}, ribs)
+
+	ribs = mrtDumpRibs(t, &mrtWriter{s: s, c: &oc.MrtConfig{DumpType: oc.MRT_TYPE_TABLE, Vrf: "none"}})
+	assert.Empty(t, ribs)
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"log/slog"
 	"net"
+	"slices"
 	"sync"
 	"time"
 
@@ -43,6 +44,7 @@ type exportRule struct {
 	Name             string
 	Communities      []uint32              // Standard communities (32-bit)
 	LargeCommunities []*bgp.LargeCommunity // Large communities (96-bit)
+	Tags             []uint32              // Route tags set by the policy
 	VrfName          string                // VRF name (empty = global table)
 	TableId          int                   // Linux routing table ID
 	Metric           uint32                // Route metric
@@ -77,6 +79,20 @@ type exportStats struct {
 	LastErrorMsg      string    // Last error message
 }
 
+// exportRuleKey identifies the statistics of an export rule in a Linux VRF
+type exportRuleKey struct {
+	Rule string
+	Vrf  string
+}
+
+// exportRuleStats tracks export operation statistics of an export rule
+type exportRuleStats struct {
+	Exported      uint64 // Routes exported by the rule
+	Withdrawn     uint64 // Routes exported by the rule and withdrawn
+	Errors        uint64 // Kernel errors
+	NexthopFailed uint64 // Nexthop validation failures
+}
+
 // vrfExportConfig holds per-VRF export configuration
 type vrfExportConfig struct {
 	VrfName            string                // GoBGP VRF name
@@ -107,8 +123,12 @@ type netlinkExportClient struct {
 	dampenMu          sync.Mutex
 
 	// Statistics
-	stats   exportStats
-	statsMu sync.RWMutex
+	stats     exportStats
+	ruleStats map[exportRuleKey]*exportRuleStats
+	statsMu   sync.RWMutex
+
+	// Kernel programming latency
+	timingHook NetlinkTimingHook
 
 	// Route protocol
 	routeProtocol int
@@ -141,6 +161,8 @@ func newNetlinkExportClient(server *BgpServer, logger *slog.Logger, routeProtoco
 		rdToVrf:           make(map[string]string),
 		vrfRules:          make(map[string]*vrfExportConfig),
 		pendingUpdates:    make(map[string]*dampenEntry),
+		ruleStats:         make(map[exportRuleKey]*exportRuleStats),
+		timingHook:        server.netlinkHook,
 		routeProtocol:     routeProtocol,
 		dampeningInterval: dampeningInterval,
 		stopCh:            make(chan struct{}),
@@ -156,6 +178,34 @@ func newNetlinkExportClient(server *BgpServer, logger *slog.Logger, routeProtoco
 	return client, nil
 }
 
+// routeReplace installs the route, observing the kernel programming latency
+func (e *netlinkExportClient) routeReplace(route *go_netlink.Route) error {
+	start := time.Now()
+	err := e.client.RouteReplace(route)
+	e.timingHook.Observe(NetlinkRouteReplace, time.Since(start))
+	return err
+}
+
+// routeDel removes the route, observing the kernel programming latency
+func (e *netlinkExportClient) routeDel(route *go_netlink.Route) error {
+	start := time.Now()
+	err := e.client.RouteDel(route)
+	e.timingHook.Observe(NetlinkRouteDelete, time.Since(start))
+	return err
+}
+
+// ruleStatsLocked returns the statistics of the rule in the VRF; statsMu
+// must be held
+func (e *netlinkExportClient) ruleStatsLocked(rule, vrf string) *exportRuleStats {
+	key := exportRuleKey{Rule: rule, Vrf: vrf}
+	st, ok := e.ruleStats[key]
+	if !ok {
+		st = &exportRuleStats{}
+		e.ruleStats[key] = st
+	}
+	return st
+}
+
 // cleanupStaleRoutes removes any routes with our protocol that were left behind from previous runs
 func (e *netlinkExportClient) cleanupStaleRoutes() error {
 	e.logger.Info("Cleaning up stale netlink routes from previous runs",
@@ -220,7 +270,7 @@ func (e *netlinkExportClient) cleanupStaleRoutes() error {
 					slog.Int("Protocol", int(route.Protocol)),
 					slog.Int("Metric", route.Priority))
 
-				if err := e.client.RouteDel(&route); err != nil {
+				if err := e.routeDel(&route); err != nil {
 					e.logger.Warn("Failed to delete stale route",
 						slog.String("Topic", "netlink"),
 						slog.String("Prefix", route.Dst.String()),
@@ -417,6 +467,7 @@ func (e *netlinkExportClient) reEvaluateAllRoutes(pathList []*table.Path) {
 	routesToWithdraw := make([]struct {
 		vrf    string
 		prefix string
+		rule   string
 		route  *go_netlink.Route
 	}, 0)
 
@@ -428,8 +479,9 @@ func (e *netlinkExportClient) reEvaluateAllRoutes(pathList []*table.Path) {
 				routesToWithdraw = append(routesToWithdraw, struct {
 					vrf    string
 					prefix string
+					rule   string
 					route  *go_netlink.Route
-				}{vrfName, prefix, info.Route})
+				}{vrfName, prefix, info.RuleName, info.Route})
 			}
 		}
 	}
@@ -443,12 +495,13 @@ func (e *netlinkExportClient) reEvaluateAllRoutes(pathList []*table.Path) {
 			slog.String("VRF", w.vrf))
 
 		// Delete the route directly
-		err := e.client.RouteDel(w.route)
+		err := e.routeDel(w.route)
 		if err != nil {
 			e.statsMu.Lock()
 			e.stats.Errors++
 			e.stats.LastError = time.Now()
 			e.stats.LastErrorMsg = fmt.Sprintf("RouteDel failed for %s: %v", w.prefix, err)
+			e.ruleStatsLocked(w.rule, w.vrf).Errors++
 			e.statsMu.Unlock()
 			e.logger.Warn("Failed to withdraw route",
 				slog.String("Topic", "netlink"),
@@ -467,6 +520,7 @@ func (e *netlinkExportClient) reEvaluateAllRoutes(pathList []*table.Path) {
 			e.statsMu.Lock()
 			e.stats.Withdrawn++
 			e.stats.LastWithdraw = time.Now()
+			e.ruleStatsLocked(w.rule, w.vrf).Withdrawn++
 			e.statsMu.Unlock()
 		}
 	}
@@ -475,10 +529,15 @@ func (e *netlinkExportClient) reEvaluateAllRoutes(pathList []*table.Path) {
 		slog.String("Topic", "netlink"))
 }
 
-// matchesRule checks if a path matches an export rule's community filters
+// matchesRule checks if a path matches an export rule's community and tag filters
 func (e *netlinkExportClient) matchesRule(path *table.Path, rule *exportRule) bool {
-	// If no community filters specified, match all routes
-	if len(rule.Communities) == 0 && len(rule.LargeCommunities) == 0 {
+	// If no filters specified, match all routes
+	if len(rule.Communities) == 0 && len(rule.LargeCommunities) == 0 && len(rule.Tags) == 0 {
+		return true
+	}
+
+	// Check route tags (OR logic - match if the path has any tag from the list)
+	if slices.Contains(rule.Tags, path.GetTag()) {
 		return true
 	}
 
@@ -596,6 +655,9 @@ func (e *netlinkExportClient) exportRoute(path *table.Path, rule *exportRule) er
 				slog.String("Nexthop", nexthop.String()),
 				slog.String("Rule", rule.Name),
 				slog.String("VRF", rule.VrfName))
+			e.statsMu.Lock()
+			e.ruleStatsLocked(rule.Name, rule.VrfName).NexthopFailed++
+			e.statsMu.Unlock()
 			return fmt.Errorf("nexthop %s not reachable", nexthop.String())
 		}
 	}
@@ -628,7 +690,7 @@ func (e *netlinkExportClient) exportRoute(path *table.Path, rule *exportRule) er
 					slog.Int("NewTable", rule.TableId))
 
 				// Delete the old route
-				if err := e.client.RouteDel(existingRoute); err != nil {
+				if err := e.routeDel(existingRoute); err != nil {
 					e.logger.Warn("Failed to delete old route during parameter change",
 						slog.String("Topic", "netlink"),
 						slog.String("Prefix", prefix),
@@ -694,12 +756,13 @@ func (e *netlinkExportClient) exportRoute(path *table.Path, rule *exportRule) er
 	}
 
 	// Add the route
-	err = e.client.RouteReplace(route)
+	err = e.routeReplace(route)
 	if err != nil {
 		e.statsMu.Lock()
 		e.stats.Errors++
 		e.stats.LastError = time.Now()
 		e.stats.LastErrorMsg = fmt.Sprintf("RouteReplace failed for %s: %v", prefix, err)
+		e.ruleStatsLocked(rule.Name, rule.VrfName).Errors++
 		e.statsMu.Unlock()
 
 		e.logger.Warn("Failed to export route",
@@ -727,6 +790,7 @@ func (e *netlinkExportClient) exportRoute(path *table.Path, rule *exportRule) er
 	e.statsMu.Lock()
 	e.stats.Exported++
 	e.stats.LastExport = time.Now()
+	e.ruleStatsLocked(rule.Name, rule.VrfName).Exported++
 	e.statsMu.Unlock()
 
 	e.logger.Info("Exported route to Linux",
@@ -773,15 +837,17 @@ func (e *netlinkExportClient) withdrawRoute(path *table.Path, vrfName string) er
 		return nil // Not exported, nothing to do
 	}
 	route := info.Route
+	ruleName := info.RuleName
 	e.mu.RUnlock()
 
 	// Delete the route
-	err := e.client.RouteDel(route)
+	err := e.routeDel(route)
 	if err != nil {
 		e.statsMu.Lock()
 		e.stats.Errors++
 		e.stats.LastError = time.Now()
 		e.stats.LastErrorMsg = fmt.Sprintf("RouteDel failed for %s: %v", prefix, err)
+		e.ruleStatsLocked(ruleName, vrfName).Errors++
 		e.statsMu.Unlock()
 
 		e.logger.Warn("Failed to withdraw route",
@@ -803,6 +869,7 @@ func (e *netlinkExportClient) withdrawRoute(path *table.Path, vrfName string) er
 	e.statsMu.Lock()
 	e.stats.Withdrawn++
 	e.stats.LastWithdraw = time.Now()
+	e.ruleStatsLocked(ruleName, vrfName).Withdrawn++
 	e.statsMu.Unlock()
 
 	e.logger.Info("Withdrew route from Linux",
@@ -1070,6 +1137,17 @@ func (e *netlinkExportClient) getStats() exportStats {
 	return e.stats
 }
 
+// getRuleStats returns current export statistics per rule and VRF
+func (e *netlinkExportClient) getRuleStats() map[exportRuleKey]exportRuleStats {
+	e.statsMu.RLock()
+	defer e.statsMu.RUnlock()
+	stats := make(map[exportRuleKey]exportRuleStats, len(e.ruleStats))
+	for key, st := range e.ruleStats {
+		stats[key] = *st
+	}
+	return stats
+}
+
 // listExported returns all currently exported routes
 func (e *netlinkExportClient) listExported() map[string]map[string]*exportedRouteInfo {
 	e.mu.RLock()
@@ -1103,6 +1181,7 @@ func (e *netlinkExportClient) getRules() []*exportRule {
 			Name:             rule.Name,
 			Communities:      make([]uint32, len(rule.Communities)),
 			LargeCommunities: make([]*bgp.LargeCommunity, len(rule.LargeCommunities)),
+			Tags:             slices.Clone(rule.Tags),
 			VrfName:          rule.VrfName,
 			TableId:          rule.TableId,
 			Metric:           rule.Metric,
@@ -1152,7 +1231,7 @@ func (e *netlinkExportClient) flush() error {
 
 	// Delete all routes
 	for _, route := range routesToDelete {
-		err := e.client.RouteDel(route)
+		err := e.routeDel(route)
 		if err != nil {
 			e.logger.Warn("Failed to delete route during flush",
 				slog.String("Topic", "netlink"),
@@ -0,0 +1,221 @@
+//go:build linux
+
+// Copyright (C) 2025 Acnodal Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"log/slog"
+	"net"
+	"net/netip"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/osrg/gobgp/v4/pkg/config/oc"
+
+	go_netlink "github.com/vishvananda/netlink"
+)
+
+const (
+	// netlinkLsScanDelay coalesces the link and address updates coming in
+	// a burst, like the ones of an interface brought up with its addresses.
+	netlinkLsScanDelay = 500 * time.Millisecond
+	// netlinkLsPollInterval is the interval of the scans while the updates
+	// can't be subscribed to.
+	netlinkLsPollInterval = 5 * time.Second
+)
+
+// listHostLinks returns the interfaces which are up, with their addresses.
+// The loopback interfaces are skipped unless they are listed explicitly.
+func listHostLinks(interfaces []string) ([]lsHostLink, error) {
+	links, err := go_netlink.LinkList()
+	if err != nil {
+		return nil, err
+	}
+	l := make([]lsHostLink, 0, len(links))
+	for _, link := range links {
+		attrs := link.Attrs()
+		if len(interfaces) > 0 {
+			if !slices.Contains(interfaces, attrs.Name) {
+				continue
+			}
+		} else if attrs.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+		if attrs.Flags&net.FlagUp == 0 {
+			continue
+		}
+		addrs, err := go_netlink.AddrList(link, go_netlink.FAMILY_ALL)
+		if err != nil {
+			return nil, err
+		}
+		h := lsHostLink{Name: attrs.Name, Index: uint32(attrs.Index)}
+		for _, a := range addrs {
+			addr, ok := netip.AddrFromSlice(a.IP)
+			if !ok {
+				continue
+			}
+			ones, _ := a.Mask.Size()
+			h.Addrs = append(h.Addrs, netip.PrefixFrom(addr.Unmap(), ones))
+		}
+		l = append(l, h)
+	}
+	return l, nil
+}
+
+// netlinkLsClient originates the topology of the host as BGP-LS NLRIs and
+// keeps it in sync with the link and address tables.
+type netlinkLsClient struct {
+	server   *BgpServer
+	dead     chan struct{}
+	stopped  chan struct{}
+	rescanCh chan struct{}
+}
+
+func newNetlinkLsClient(s *BgpServer) *netlinkLsClient {
+	c := &netlinkLsClient{
+		server:   s,
+		dead:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+		rescanCh: make(chan struct{}, 1),
+	}
+	go c.loop()
+	return c
+}
+
+func (c *netlinkLsClient) run() {
+	s := c.server
+	var config oc.NetlinkLs
+	var global oc.GlobalConfig
+	s.mgmtOperation(func() error {
+		config = s.bgpConfig.Netlink.Ls
+		global = s.bgpConfig.Global.Config
+		return nil
+	}, false)
+	links, err := listHostLinks(config.InterfaceList)
+	if err == nil {
+		hostname, _ := os.Hostname()
+		t := hostLsTopology(links, global.RouterId, global.As, config.BgpLsId, config.Identifier, hostname)
+		err = s.mgmtOperation(func() error {
+			return s.updateLsTopology(lsSourceNetlink, t, false, true)
+		}, false)
+	}
+	if err != nil {
+		s.logger.Warn("failed to originate the BGP-LS topology of the host",
+			slog.String("Topic", "netlink"),
+			slog.String("Error", err.Error()))
+	}
+}
+
+func (c *netlinkLsClient) loop() {
+	s := c.server
+	s.logger.Debug("starting netlink BGP-LS loop", slog.String("Topic", "netlink"))
+	defer close(c.stopped)
+
+	linkCh := make(chan go_netlink.LinkUpdate)
+	addrCh := make(chan go_netlink.AddrUpdate)
+	done := make(chan struct{})
+	defer close(done)
+	if err := go_netlink.LinkSubscribe(linkCh, done); err != nil {
+		s.logger.Warn("failed to subscribe to link updates",
+			slog.String("Topic", "netlink"),
+			slog.String("Error", err.Error()))
+		linkCh = nil
+	}
+	if err := go_netlink.AddrSubscribe(addrCh, done); err != nil {
+		s.logger.Warn("failed to subscribe to address updates",
+			slog.String("Topic", "netlink"),
+			slog.String("Error", err.Error()))
+		addrCh = nil
+	}
+
+	// the tables are polled only without the subscriptions
+	var poll *time.Ticker
+	var pollCh <-chan time.Time
+	startPoll := func() {
+		if poll == nil {
+			poll = time.NewTicker(netlinkLsPollInterval)
+			pollCh = poll.C
+		}
+	}
+	defer func() {
+		if poll != nil {
+			poll.Stop()
+		}
+	}()
+	if linkCh == nil || addrCh == nil {
+		startPoll()
+	}
+	scan := time.NewTimer(netlinkLsScanDelay)
+	scan.Stop()
+	defer scan.Stop()
+
+	c.run()
+	for {
+		select {
+		case <-c.dead:
+			return
+		case _, ok := <-linkCh:
+			if !ok {
+				linkCh = nil
+				startPoll()
+				continue
+			}
+			scan.Reset(netlinkLsScanDelay)
+		case _, ok := <-addrCh:
+			if !ok {
+				addrCh = nil
+				startPoll()
+				continue
+			}
+			scan.Reset(netlinkLsScanDelay)
+		case <-scan.C:
+			c.run()
+		case <-c.rescanCh:
+			c.run()
+		case <-pollCh:
+			c.run()
+		}
+	}
+}
+
+// rescan triggers an immediate scan (called when the configuration is
+// changed)
+func (c *netlinkLsClient) rescan() {
+	select {
+	case c.rescanCh <- struct{}{}:
+	default:
+	}
+}
+
+// stop stops the loop and withdraws the topology of the host.
+func (c *netlinkLsClient) stop() {
+	close(c.dead)
+	<-c.stopped
+	s := c.server
+	err := s.mgmtOperation(func() error {
+		if s.globalRib == nil {
+			return nil
+		}
+		return s.updateLsTopology(lsSourceNetlink, nil, false, true)
+	}, false)
+	if err != nil {
+		s.logger.Warn("failed to withdraw the BGP-LS topology of the host",
+			slog.String("Topic", "netlink"),
+			slog.String("Error", err.Error()))
+	}
+}
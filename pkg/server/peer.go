@@ -18,6 +18,7 @@ package server
 import (
 	"fmt"
 	"log/slog"
+	"maps"
 	"net"
 	"net/netip"
 	"slices"
@@ -96,28 +97,51 @@ func newDynamicPeer(g *oc.Global, neighborAddress string, pg *oc.PeerGroup, loc
 }
 
 type peer struct {
-	tableId           string
-	fsm               *fsm
-	adjRibIn          *table.AdjRib
-	policy            *table.RoutingPolicy
-	localRib          *table.TableManager
-	peerInfo          *table.PeerInfo
-	prefixLimitWarned map[bgp.Family]bool
+	tableId      string
+	fsm          *fsm
+	adjRibIn     *table.AdjRib
+	policy       *table.RoutingPolicy
+	localRib     *table.TableManager
+	peerInfo     *table.PeerInfo
+	prefixLimits map[bgp.Family]*prefixLimitState
+	// restarts the session shut down by the prefix limit
+	prefixLimitRestart     *time.Timer
+	prefixLimitRestartTime time.Time
 	// map of path local identifiers sent for that prefix
 	sentPaths           map[table.PathDestLocalKey]map[uint32]struct{}
 	sendMaxPathFiltered map[table.PathLocalKey]struct{}
-	llgrEndChs          []chan struct{}
-	longLivedRunning    bool
+	// local identifier of the alternative path sent for that prefix,
+	// which doesn't count against send-max
+	sentAlternativePaths map[table.PathDestLocalKey]map[table.AlternativePath]uint32
+	llgrEndChs           []chan struct{}
+	longLivedRunning     bool
+	// Address Prefix ORF received from and sent to the peer
+	receivedORF map[bgp.Family]*table.PrefixORF
+	sentORF     map[bgp.Family][]*bgp.AddressPrefixORFEntry
+	// the paths advertised to the peer for the families it may send ORF
+	// for, which are withdrawn when a new ORF doesn't permit them
+	orfAdvertised advertisedPaths
+	// the paths sent to the peer before and after the export policy,
+	// recorded while the pre-policy and post-policy Adj-RIB-Out are
+	// watched; nil otherwise
+	adjRibOutPre  advertisedPaths
+	adjRibOutPost advertisedPaths
+	// protected by BgpServer's shared mutex
+	updateGroup *updateGroup
 }
 
 func newPeer(g *oc.Global, conf *oc.Neighbor, state bgp.FSMState, loc *table.TableManager, policy *table.RoutingPolicy, logger *slog.Logger) *peer {
 	peer := &peer{
-		localRib:            loc,
-		policy:              policy,
-		fsm:                 newFSM(g, conf, state, logger.With(slog.String("Topic", "Peer"), slog.String("Key", conf.State.NeighborAddress.String()))),
-		prefixLimitWarned:   make(map[bgp.Family]bool),
-		sentPaths:           make(map[table.PathDestLocalKey]map[uint32]struct{}),
-		sendMaxPathFiltered: make(map[table.PathLocalKey]struct{}),
+		localRib:             loc,
+		policy:               policy,
+		fsm:                  newFSM(g, conf, state, logger.With(slog.String("Topic", "Peer"), slog.String("Key", conf.State.NeighborAddress.String()))),
+		prefixLimits:         make(map[bgp.Family]*prefixLimitState),
+		sentPaths:            make(map[table.PathDestLocalKey]map[uint32]struct{}),
+		sendMaxPathFiltered:  make(map[table.PathLocalKey]struct{}),
+		sentAlternativePaths: make(map[table.PathDestLocalKey]map[table.AlternativePath]uint32),
+		receivedORF:          make(map[bgp.Family]*table.PrefixORF),
+		sentORF:              make(map[bgp.Family][]*bgp.AddressPrefixORFEntry),
+		orfAdvertised:        make(advertisedPaths),
 	}
 	if peer.isRouteServerClient() {
 		peer.tableId = conf.State.NeighborAddress.String()
@@ -126,6 +150,7 @@ func newPeer(g *oc.Global, conf *oc.Neighbor, state bgp.FSMState, loc *table.Tab
 	}
 	rfs, _ := oc.AfiSafis(conf.AfiSafis).ToRfList()
 	peer.adjRibIn = table.NewAdjRib(peer.fsm.logger, rfs)
+	peer.initPrefixLimits(conf.AfiSafis)
 	return peer
 }
 
@@ -231,6 +256,30 @@ func (peer *peer) getAddPathSendMax(family bgp.Family) uint8 {
 	return 0
 }
 
+func (peer *peer) alternativePaths() []table.AlternativePath {
+	peer.fsm.lock.Lock()
+	defer peer.fsm.lock.Unlock()
+	var alts []table.AlternativePath
+	if peer.fsm.pConf.RouteReflector.Config.AdvertiseBestExternal {
+		alts = append(alts, table.ALTERNATIVE_PATH_BEST_EXTERNAL)
+	}
+	if peer.fsm.pConf.RouteReflector.Config.DiversePath {
+		alts = append(alts, table.ALTERNATIVE_PATH_DIVERSE)
+	}
+	return alts
+}
+
+// alternativePath returns the path advertised instead of the best path
+// when add-path isn't used. The diverse path takes precedence over the
+// best external path.
+func (peer *peer) alternativePath() table.AlternativePath {
+	alts := peer.alternativePaths()
+	if len(alts) == 0 {
+		return table.ALTERNATIVE_PATH_NONE
+	}
+	return alts[len(alts)-1]
+}
+
 func (peer *peer) getRoutesCount(family bgp.Family, dstPrefix string) uint8 {
 	destLocalKey := table.NewPathDestLocalKey(family, dstPrefix)
 	if identifiers, ok := peer.sentPaths[*destLocalKey]; ok {
@@ -244,6 +293,25 @@ func (peer *peer) getRoutesCount(family bgp.Family, dstPrefix string) uint8 {
 	return 0
 }
 
+// isSentWithinSendMax reports whether the path takes one of the send-max
+// slots of its destination.
+func (peer *peer) isSentWithinSendMax(path *table.Path) bool {
+	_, ok := peer.sentPaths[path.GetDestLocalKey()][path.LocalID()]
+	return ok
+}
+
+// sentDestLocalKey returns the key the paths to the destination of the
+// path are recorded with once sent to the peer.
+func (peer *peer) sentDestLocalKey(path *table.Path) table.PathDestLocalKey {
+	peer.fsm.lock.Lock()
+	peerVrf := peer.fsm.pConf.Config.Vrf
+	peer.fsm.lock.Unlock()
+	if peerVrf != "" {
+		path = path.ToLocal()
+	}
+	return path.GetDestLocalKey()
+}
+
 func (peer *peer) updateRoutes(paths ...*table.Path) {
 	if len(paths) == 0 {
 		return
@@ -252,9 +320,12 @@ func (peer *peer) updateRoutes(paths ...*table.Path) {
 		localKey := path.GetLocalKey()
 		destLocalKey := localKey.PathDestLocalKey
 		identifiers, destExists := peer.sentPaths[destLocalKey]
-		if path.IsWithdraw && destExists {
-			delete(identifiers, path.LocalID())
-		} else if !path.IsWithdraw {
+		if path.IsWithdraw {
+			if destExists {
+				delete(identifiers, path.LocalID())
+			}
+			peer.unsetAlternativeSent(destLocalKey, path.LocalID())
+		} else {
 			if !destExists {
 				peer.sentPaths[destLocalKey] = make(map[uint32]struct{})
 			}
@@ -290,11 +361,194 @@ func (peer *peer) hasPathAlreadyBeenSent(path *table.Path) bool {
 		return false
 	}
 	destLocalKey := path.GetDestLocalKey()
-	if _, dstExist := peer.sentPaths[destLocalKey]; !dstExist {
+	return peer.isSent(destLocalKey, path.LocalID())
+}
+
+// isSent reports whether the path with the local identifier has been sent
+// for the destination, either within send-max or as an alternative path.
+func (peer *peer) isSent(destLocalKey table.PathDestLocalKey, id uint32) bool {
+	if _, ok := peer.sentPaths[destLocalKey][id]; ok {
+		return true
+	}
+	for _, sent := range peer.sentAlternativePaths[destLocalKey] {
+		if sent == id {
+			return true
+		}
+	}
+	return false
+}
+
+// setAlternativeSent records the path sent as the alternative path for
+// its destination.
+func (peer *peer) setAlternativeSent(alt table.AlternativePath, path *table.Path) {
+	destLocalKey := path.GetDestLocalKey()
+	if _, ok := peer.sentAlternativePaths[destLocalKey]; !ok {
+		peer.sentAlternativePaths[destLocalKey] = make(map[table.AlternativePath]uint32)
+	}
+	peer.sentAlternativePaths[destLocalKey][alt] = path.LocalID()
+}
+
+// unsetAlternativeSent forgets the path with the local identifier sent as
+// an alternative path for the destination.
+func (peer *peer) unsetAlternativeSent(destLocalKey table.PathDestLocalKey, id uint32) {
+	sent, ok := peer.sentAlternativePaths[destLocalKey]
+	if !ok {
+		return
+	}
+	maps.DeleteFunc(sent, func(_ table.AlternativePath, v uint32) bool { return v == id })
+	if len(sent) == 0 {
+		delete(peer.sentAlternativePaths, destLocalKey)
+	}
+}
+
+func (peer *peer) getORFMode(family bgp.Family) (local, remote bgp.ORFSendReceive) {
+	peer.fsm.lock.Lock()
+	defer peer.fsm.lock.Unlock()
+	for _, a := range peer.fsm.pConf.AfiSafis {
+		if a.State.Family == family {
+			local = orfModeFromConfig(a.OutboundRouteFiltering.State.Mode)
+			break
+		}
+	}
+	for _, c := range peer.fsm.capMap[bgp.BGP_CAP_OUTBOUND_ROUTE_FILTERING] {
+		for _, t := range c.(*bgp.CapOutboundRouteFiltering).Tuples {
+			if t.Family == family {
+				remote |= t.Mode(bgp.ORF_TYPE_ADDRESS_PREFIX)
+			}
+		}
+	}
+	return local, remote
+}
+
+// isORFReceiveEnabled reports whether the peer is allowed to send us
+// Address Prefix ORF for the family.
+func (peer *peer) isORFReceiveEnabled(family bgp.Family) bool {
+	local, remote := peer.getORFMode(family)
+	return local&bgp.ORF_RECEIVE > 0 && remote&bgp.ORF_SEND > 0
+}
+
+// isORFSendEnabled reports whether we are allowed to send Address Prefix
+// ORF for the family to the peer.
+func (peer *peer) isORFSendEnabled(family bgp.Family) bool {
+	local, remote := peer.getORFMode(family)
+	return local&bgp.ORF_SEND > 0 && remote&bgp.ORF_RECEIVE > 0
+}
+
+func (peer *peer) getBGPsecMode(family bgp.Family) (local, remote oc.BgpsecState) {
+	peer.fsm.lock.Lock()
+	defer peer.fsm.lock.Unlock()
+	for _, a := range peer.fsm.pConf.AfiSafis {
+		if a.State.Family == family {
+			local = a.Bgpsec.State
+			break
+		}
+	}
+	for _, c := range peer.fsm.capMap[bgp.BGP_CAP_BGPSEC] {
+		if c := c.(*bgp.CapBGPsec); c.AFI == family.Afi() {
+			switch c.Direction {
+			case bgp.BGPSEC_DIRECTION_SEND:
+				remote.Send = true
+			case bgp.BGPSEC_DIRECTION_RECEIVE:
+				remote.Receive = true
+			}
+		}
+	}
+	return local, remote
+}
+
+// isBGPsecSendEnabled reports whether we may send BGPsec updates for the
+// family to the peer.
+func (peer *peer) isBGPsecSendEnabled(family bgp.Family) bool {
+	// RFC 8205 2.2
+	// BGPsec requires the four-octet AS number capability.
+	peer.fsm.lock.Lock()
+	_, fourOctet := peer.fsm.capMap[bgp.BGP_CAP_FOUR_OCTET_AS_NUMBER]
+	peer.fsm.lock.Unlock()
+	if !fourOctet {
 		return false
 	}
-	_, pathExist := peer.sentPaths[destLocalKey][path.LocalID()]
-	return pathExist
+	local, remote := peer.getBGPsecMode(family)
+	return local.Send && remote.Receive
+}
+
+// isBGPsecReceiveEnabled reports whether the peer may send us BGPsec
+// updates for the family.
+func (peer *peer) isBGPsecReceiveEnabled(family bgp.Family) bool {
+	local, remote := peer.getBGPsecMode(family)
+	return local.Receive && remote.Send
+}
+
+func (peer *peer) orfPermit(path *table.Path) bool {
+	return peer.receivedORF[path.GetFamily()].Permit(path)
+}
+
+// advertisedPaths is the set of the paths sent to a peer, per family.
+type advertisedPaths map[bgp.Family]map[table.PathLocalKey]struct{}
+
+func (a advertisedPaths) update(key table.PathLocalKey, withdraw bool) {
+	if withdraw {
+		delete(a[key.Family], key)
+		return
+	}
+	if _, ok := a[key.Family]; !ok {
+		a[key.Family] = make(map[table.PathLocalKey]struct{})
+	}
+	a[key.Family][key] = struct{}{}
+}
+
+func (a advertisedPaths) has(key table.PathLocalKey) bool {
+	_, ok := a[key.Family][key]
+	return ok
+}
+
+func (peer *peer) advertisedKey(destLocalKey table.PathDestLocalKey, id uint32) table.PathLocalKey {
+	key := table.PathLocalKey{PathDestLocalKey: destLocalKey}
+	if peer.isAddPathSendEnabled(destLocalKey.Family) {
+		key.Id = id
+	}
+	return key
+}
+
+// recordAdvertised records the paths sent to the peer in a, only for the
+// families enabled if enable isn't nil.
+func (peer *peer) recordAdvertised(a advertisedPaths, paths []*table.Path, enable func(bgp.Family) bool) {
+	enabled := make(map[bgp.Family]bool)
+	for _, path := range paths {
+		if path.IsEOR() {
+			continue
+		}
+		family := path.GetFamily()
+		e, ok := enabled[family]
+		if !ok {
+			e = enable == nil || enable(family)
+			enabled[family] = e
+		}
+		if e {
+			a.update(peer.advertisedKey(path.GetDestLocalKey(), path.LocalID()), path.IsWithdraw)
+		}
+	}
+}
+
+// recordORFAdvertised records the paths sent to the peer for the families
+// it may send ORF for.
+func (peer *peer) recordORFAdvertised(paths []*table.Path) {
+	peer.recordAdvertised(peer.orfAdvertised, paths, peer.isORFReceiveEnabled)
+}
+
+// isORFAdvertised reports whether the path has been sent to the peer.
+func (peer *peer) isORFAdvertised(path *table.Path) bool {
+	return peer.orfAdvertised.has(peer.advertisedKey(peer.sentDestLocalKey(path), path.LocalID()))
+}
+
+// recordAdjRibOut records the paths sent to the peer before and after the
+// export policy for the Adj-RIB-Out statistics.
+func (peer *peer) recordAdjRibOut(pre, post []*table.Path) {
+	if peer.adjRibOutPre != nil {
+		peer.recordAdvertised(peer.adjRibOutPre, pre, nil)
+	}
+	if peer.adjRibOutPost != nil {
+		peer.recordAdvertised(peer.adjRibOutPost, post, nil)
+	}
 }
 
 func (peer *peer) isDynamicNeighbor() bool {
@@ -546,24 +800,6 @@ func (peer *peer) sendNotification(msg *bgp.BGPMessage) {
 	nonblockSendChannel(peer.fsm.notification, msg)
 }
 
-func (peer *peer) isPrefixLimit(k bgp.Family, c *oc.PrefixLimitConfig) bool {
-	if maxPrefixes := int(c.MaxPrefixes); maxPrefixes > 0 {
-		count := peer.adjRibIn.Count([]bgp.Family{k})
-		pct := int(c.ShutdownThresholdPct)
-		if pct > 0 && !peer.prefixLimitWarned[k] && count > maxPrefixes*pct/100 {
-			peer.prefixLimitWarned[k] = true
-			peer.fsm.logger.Warn("prefix limit reached",
-				slog.String("Family", k.String()),
-				slog.Int("Pct", pct))
-		}
-		if count > maxPrefixes {
-			peer.fsm.logger.Warn("prefix limit reached", slog.String("Family", k.String()))
-			return true
-		}
-	}
-	return false
-}
-
 func (peer *peer) updatePrefixLimitConfig(c []oc.AfiSafi) (bool, error) {
 	peer.fsm.lock.Lock()
 	x := peer.fsm.pConf.AfiSafis
@@ -576,7 +812,6 @@ func (peer *peer) updatePrefixLimitConfig(c []oc.AfiSafi) (bool, error) {
 	for _, e := range x {
 		m[e.State.Family] = e.PrefixLimit.Config
 	}
-	reachLimit := false
 	for _, e := range y {
 		if p, ok := m[e.State.Family]; !ok {
 			return false, fmt.Errorf("changing supported afi-safi is not allowed")
@@ -586,21 +821,23 @@ func (peer *peer) updatePrefixLimitConfig(c []oc.AfiSafi) (bool, error) {
 				slog.Uint64("OldMaxPrefixes", uint64(p.MaxPrefixes)),
 				slog.Uint64("NewMaxPrefixes", uint64(e.PrefixLimit.Config.MaxPrefixes)),
 				slog.Int("OldShutdownThresholdPct", int(p.ShutdownThresholdPct)),
-				slog.Int("NewShutdownThresholdPct", int(e.PrefixLimit.Config.ShutdownThresholdPct)))
-
-			peer.prefixLimitWarned[e.State.Family] = false
-			if peer.isPrefixLimit(e.State.Family, &e.PrefixLimit.Config) {
-				reachLimit = true
-			}
+				slog.Int("NewShutdownThresholdPct", int(e.PrefixLimit.Config.ShutdownThresholdPct)),
+				slog.Bool("WarningOnly", e.PrefixLimit.Config.WarningOnly),
+				slog.String("CountType", string(e.PrefixLimit.Config.CountType)),
+				slog.Uint64("MaxPrefixesOut", uint64(e.PrefixLimit.Config.MaxPrefixesOut)))
+
+			st := peer.prefixLimitState(e.State.Family)
+			st.thresholdExceeded = false
+			st.limitExceeded = false
 		}
 	}
 	peer.fsm.lock.Lock()
 	peer.fsm.pConf.AfiSafis = c
 	peer.fsm.lock.Unlock()
-	return reachLimit, nil
+	return peer.initPrefixLimits(c), nil
 }
 
-func (peer *peer) handleUpdate(e *fsmMsg) ([]*table.Path, []bgp.Family, bool) {
+func (peer *peer) handleUpdate(e *fsmMsg) ([]*table.Path, []bgp.Family) {
 	m := e.MsgData.(*bgp.BGPMessage)
 	update := m.Body.(*bgp.BGPUpdate)
 
@@ -660,17 +897,9 @@ func (peer *peer) handleUpdate(e *fsmMsg) ([]*table.Path, []bgp.Family, bool) {
 			paths = append(paths, path)
 		}
 		peer.adjRibIn.Update(pathList)
-		peer.fsm.lock.Lock()
-		peerAfiSafis := peer.fsm.pConf.AfiSafis
-		peer.fsm.lock.Unlock()
-		for _, af := range peerAfiSafis {
-			if isLimit := peer.isPrefixLimit(af.State.Family, &af.PrefixLimit.Config); isLimit {
-				return nil, nil, true
-			}
-		}
-		return paths, eor, false
+		return paths, eor
 	}
-	return nil, nil, false
+	return nil, nil
 }
 
 func (peer *peer) startFSM(wg *sync.WaitGroup, callback fsmCallback) {
@@ -0,0 +1,402 @@
+// Copyright (C) 2025 Acnodal Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	api "github.com/osrg/gobgp/v4/api"
+	"github.com/osrg/gobgp/v4/internal/pkg/table"
+)
+
+const (
+	defaultPolicyHistorySize = 10
+	// the transactions left open, e.g. by a client gone away, would keep
+	// the candidates forever
+	defaultPolicyTransactionTimeout = time.Hour
+)
+
+// policyTransaction stages the policy changes in the candidate, a copy of
+// the running policy when the transaction started.
+type policyTransaction struct {
+	id        string
+	created   time.Time
+	used      time.Time
+	base      *table.PolicySnapshot
+	candidate *table.RoutingPolicy
+	changes   uint32
+}
+
+type policyVersion struct {
+	version   uint32
+	committed time.Time
+	comment   string
+	snapshot  *table.PolicySnapshot
+}
+
+type policyTransactionManager struct {
+	transactions map[string]*policyTransaction
+	// the committed versions, from the oldest
+	versions    []*policyVersion
+	lastVersion uint32
+	historySize int
+	// the idle time after which the transaction is discarded
+	timeout time.Duration
+}
+
+func newPolicyTransactionManager(historySize int) *policyTransactionManager {
+	if historySize <= 0 {
+		historySize = defaultPolicyHistorySize
+	}
+	return &policyTransactionManager{
+		transactions: make(map[string]*policyTransaction),
+		historySize:  historySize,
+		timeout:      defaultPolicyTransactionTimeout,
+	}
+}
+
+// expire discards the transactions which have been idle for the timeout.
+func (m *policyTransactionManager) expire(now time.Time) {
+	maps.DeleteFunc(m.transactions, func(_ string, tx *policyTransaction) bool {
+		return now.Sub(tx.used) > m.timeout
+	})
+}
+
+// get returns the transaction unless it has expired.
+func (m *policyTransactionManager) get(id string) (*policyTransaction, bool) {
+	now := time.Now()
+	m.expire(now)
+	tx, ok := m.transactions[id]
+	if ok {
+		tx.used = now
+	}
+	return tx, ok
+}
+
+func (m *policyTransactionManager) addVersion(snapshot *table.PolicySnapshot, comment string) uint32 {
+	m.lastVersion++
+	m.versions = append(m.versions, &policyVersion{
+		version:   m.lastVersion,
+		committed: time.Now(),
+		comment:   comment,
+		snapshot:  snapshot,
+	})
+	if len(m.versions) > m.historySize {
+		m.versions = slices.Delete(m.versions, 0, len(m.versions)-m.historySize)
+	}
+	return m.lastVersion
+}
+
+// policyTarget returns the candidate policy of the transaction, or the
+// running one if the id is empty.
+func (s *BgpServer) policyTarget(id string) (*table.RoutingPolicy, error) {
+	if id == "" {
+		return s.policy, nil
+	}
+	tx, ok := s.policyTransactions.get(id)
+	if !ok {
+		return nil, fmt.Errorf("not found policy transaction %s", id)
+	}
+	return tx.candidate, nil
+}
+
+// policyModified finishes the policy change; the change in the running
+// policy is applied to the update groups and the one in the candidate is
+// counted.
+func (s *BgpServer) policyModified(id string, err error) error {
+	if id == "" {
+		return s.policyUpdated(err)
+	}
+	if err == nil {
+		s.policyTransactions.transactions[id].changes++
+	}
+	return err
+}
+
+// loadPolicy replaces the running policy with the snapshot, soft-resets
+// the peers whose policies are changed and records the new version. On
+// failure, the running policy is kept and no version is recorded.
+func (s *BgpServer) loadPolicy(snapshot *table.PolicySnapshot, comment string) (uint32, error) {
+	m := s.policyTransactions
+	running := s.policy.Snapshot()
+	if err := s.policy.Load(snapshot); err != nil {
+		return 0, err
+	}
+	// the defined sets may have been loaded again after the snapshot
+	s.applyDefinedSetFiles()
+	// the peers added after the snapshot was taken
+	for _, peer := range s.neighborMap {
+		if !snapshot.HasAssignment(peer.ID()) && peer.isRouteServerClient() {
+			peer.fsm.lock.Lock()
+			c := peer.fsm.pConf.ApplyPolicy
+			peer.fsm.lock.Unlock()
+			if err := s.policy.SetPeerPolicy(peer.ID(), c); err != nil {
+				// back to the running policy
+				if err := s.policy.Load(running); err != nil {
+					s.logger.Error("failed to restore policy",
+						slog.String("Topic", "Policy"),
+						slog.String("Error", err.Error()))
+				}
+				s.applyDefinedSetFiles()
+				return 0, err
+			}
+		}
+	}
+	s.policyUpdated(nil)
+
+	changes := running.ChangedAssignments(s.policy.Snapshot())
+	s.softResetPolicy(changes)
+	if len(m.versions) == 0 || len(m.versions[len(m.versions)-1].snapshot.Diff(running)) != 0 {
+		// keep the changes made outside of the transactions to roll back to
+		m.addVersion(running, "")
+	}
+	version := m.addVersion(snapshot, comment)
+	s.logger.Info("policy loaded",
+		slog.String("Topic", "Policy"),
+		slog.Uint64("Version", uint64(version)),
+		slog.Int("ChangedAssignments", len(changes)))
+	return version, nil
+}
+
+// softResetPolicy runs the paths through the changed policies; the paths
+// received from the peers again for the import policies and the ones to
+// the peers for the export policies.
+func (s *BgpServer) softResetPolicy(changes []table.PolicyAssignmentChange) {
+	in := make(map[*peer]bool)
+	out := make(map[*peer]bool)
+	for _, c := range changes {
+		for _, peer := range s.neighborMap {
+			rs := peer.isRouteServerClient()
+			switch {
+			case c.ID == table.GLOBAL_RIB_NAME && rs:
+			case c.ID == table.GLOBAL_RIB_NAME && c.Direction == table.POLICY_DIRECTION_IMPORT:
+				in[peer] = true
+			case c.ID == table.GLOBAL_RIB_NAME:
+				out[peer] = true
+			case c.Direction == table.POLICY_DIRECTION_IMPORT:
+				// the paths from all the peers go through the import
+				// policies of the route server clients
+				in[peer] = true
+			case c.ID == peer.ID():
+				out[peer] = true
+			}
+		}
+	}
+	for _, peer := range slices.SortedFunc(maps.Keys(in), comparePeers) {
+		s.propagateUpdate(peer, peer.adjRibIn.PathList(familiesForSoftreset(peer, 0), true))
+	}
+	for _, peer := range slices.SortedFunc(maps.Keys(out), comparePeers) {
+		if err := s.softResetOut(peer.ID(), 0, false); err != nil {
+			peer.fsm.logger.Warn("failed to soft reset out",
+				slog.String("Topic", "Policy"),
+				slog.String("Error", err.Error()))
+		}
+	}
+}
+
+func comparePeers(a, b *peer) int {
+	return strings.Compare(a.ID(), b.ID())
+}
+
+func (s *BgpServer) StartPolicyTransaction(ctx context.Context, r *api.StartPolicyTransactionRequest) (string, error) {
+	if r == nil {
+		return "", fmt.Errorf("nil request")
+	}
+	var id string
+	err := s.mgmtOperation(func() error {
+		id = r.Id
+		if id == "" {
+			id = uuid.New().String()
+		}
+		now := time.Now()
+		s.policyTransactions.expire(now)
+		if _, ok := s.policyTransactions.transactions[id]; ok {
+			return fmt.Errorf("policy transaction %s already exists", id)
+		}
+		base := s.policy.Snapshot()
+		candidate, err := table.NewRoutingPolicyFromSnapshot(s.logger, base)
+		if err != nil {
+			return err
+		}
+		s.policyTransactions.transactions[id] = &policyTransaction{
+			id:        id,
+			created:   now,
+			used:      now,
+			base:      base,
+			candidate: candidate,
+		}
+		return nil
+	}, false)
+	return id, err
+}
+
+func toPolicyDiffApi(l []table.PolicyDiff) []*api.PolicyDiff {
+	diffs := make([]*api.PolicyDiff, 0, len(l))
+	for _, d := range l {
+		diffs = append(diffs, &api.PolicyDiff{
+			Kind:   d.Kind,
+			Name:   d.Name,
+			Before: d.Before,
+			After:  d.After,
+		})
+	}
+	return diffs
+}
+
+func (s *BgpServer) DiffPolicyTransaction(ctx context.Context, r *api.DiffPolicyTransactionRequest) ([]*api.PolicyDiff, error) {
+	if r == nil {
+		return nil, fmt.Errorf("nil request")
+	}
+	var diffs []*api.PolicyDiff
+	err := s.mgmtOperation(func() error {
+		tx, ok := s.policyTransactions.get(r.Id)
+		if !ok {
+			return fmt.Errorf("not found policy transaction %s", r.Id)
+		}
+		diffs = toPolicyDiffApi(s.policy.Snapshot().Diff(tx.candidate.Snapshot()))
+		return nil
+	}, false)
+	return diffs, err
+}
+
+// CommitPolicyTransaction replaces the running policy with the candidate
+// at once. It fails if the running policy has been changed since the
+// transaction started.
+func (s *BgpServer) CommitPolicyTransaction(ctx context.Context, r *api.CommitPolicyTransactionRequest) (uint32, error) {
+	if r == nil {
+		return 0, fmt.Errorf("nil request")
+	}
+	var version uint32
+	err := s.mgmtOperation(func() error {
+		tx, ok := s.policyTransactions.get(r.Id)
+		if !ok {
+			return fmt.Errorf("not found policy transaction %s", r.Id)
+		}
+		if len(tx.base.Diff(s.policy.Snapshot())) != 0 {
+			return fmt.Errorf("running policy has been changed since policy transaction %s started", r.Id)
+		}
+		var err error
+		if version, err = s.loadPolicy(tx.candidate.Snapshot(), r.Comment); err != nil {
+			return err
+		}
+		delete(s.policyTransactions.transactions, r.Id)
+		return nil
+	}, false)
+	return version, err
+}
+
+func (s *BgpServer) AbortPolicyTransaction(ctx context.Context, r *api.AbortPolicyTransactionRequest) error {
+	if r == nil {
+		return fmt.Errorf("nil request")
+	}
+	return s.mgmtOperation(func() error {
+		if _, ok := s.policyTransactions.get(r.Id); !ok {
+			return fmt.Errorf("not found policy transaction %s", r.Id)
+		}
+		delete(s.policyTransactions.transactions, r.Id)
+		return nil
+	}, false)
+}
+
+func (s *BgpServer) ListPolicyTransaction(ctx context.Context, r *api.ListPolicyTransactionRequest, fn func(*api.PolicyTransaction)) error {
+	if r == nil {
+		return fmt.Errorf("nil request")
+	}
+	var l []*api.PolicyTransaction
+	err := s.mgmtOperation(func() error {
+		s.policyTransactions.expire(time.Now())
+		for _, tx := range s.policyTransactions.transactions {
+			l = append(l, &api.PolicyTransaction{
+				Id:      tx.id,
+				Created: timestamppb.New(tx.created),
+				Changes: tx.changes,
+			})
+		}
+		return nil
+	}, false)
+	if err != nil {
+		return err
+	}
+	slices.SortFunc(l, func(a, b *api.PolicyTransaction) int {
+		return strings.Compare(a.Id, b.Id)
+	})
+	for _, tx := range l {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		fn(tx)
+	}
+	return nil
+}
+
+func (s *BgpServer) ListPolicyVersion(ctx context.Context, r *api.ListPolicyVersionRequest, fn func(*api.PolicyVersion)) error {
+	if r == nil {
+		return fmt.Errorf("nil request")
+	}
+	var l []*api.PolicyVersion
+	err := s.mgmtOperation(func() error {
+		versions := s.policyTransactions.versions
+		running := len(versions) != 0 && len(versions[len(versions)-1].snapshot.Diff(s.policy.Snapshot())) == 0
+		for i, v := range versions {
+			l = append(l, &api.PolicyVersion{
+				Version:   v.version,
+				Committed: timestamppb.New(v.committed),
+				Comment:   v.comment,
+				Running:   running && i == len(versions)-1,
+			})
+		}
+		return nil
+	}, false)
+	if err != nil {
+		return err
+	}
+	for _, v := range l {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		fn(v)
+	}
+	return nil
+}
+
+// RollbackPolicy replaces the running policy with the one of the version,
+// recorded as the new version.
+func (s *BgpServer) RollbackPolicy(ctx context.Context, r *api.RollbackPolicyRequest) (uint32, error) {
+	if r == nil {
+		return 0, fmt.Errorf("nil request")
+	}
+	var version uint32
+	err := s.mgmtOperation(func() error {
+		for _, v := range s.policyTransactions.versions {
+			if v.version == r.Version {
+				var err error
+				version, err = s.loadPolicy(v.snapshot, fmt.Sprintf("rollback to %d", v.version))
+				return err
+			}
+		}
+		return fmt.Errorf("not found policy version %d", r.Version)
+	}, false)
+	return version, err
+}
@@ -0,0 +1,195 @@
+// Copyright (C) 2025 Acnodal Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/osrg/gobgp/v4/api"
+	"github.com/osrg/gobgp/v4/internal/pkg/table"
+	"github.com/osrg/gobgp/v4/pkg/apiutil"
+	"github.com/osrg/gobgp/v4/pkg/config/oc"
+	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
+)
+
+func TestPolicyTransaction(t *testing.T) {
+	ctx := context.Background()
+	s1 := runNewServer(t, 1, "1.1.1.1", 10179)
+	defer s1.StopBgp(ctx, &api.StopBgpRequest{})
+	s2 := runNewServer(t, 2, "2.2.2.2", 20179)
+	defer s2.StopBgp(ctx, &api.StopBgpRequest{})
+
+	established := waitEstablished(s1, bgp.RF_IPv4_UC)
+	require.NoError(t, peerServers(t, ctx, []*BgpServer{s1, s2}, []oc.AfiSafiType{oc.AFI_SAFI_TYPE_IPV4_UNICAST}))
+	established.Wait()
+
+	for _, prefix := range []string{"10.0.0.0/24", "10.1.0.0/24"} {
+		nlri, _ := bgp.NewIPAddrPrefix(netip.MustParsePrefix(prefix))
+		nh, _ := bgp.NewPathAttributeNextHop(netip.MustParseAddr("10.0.0.1"))
+		path, _ := apiutil.NewPath(bgp.RF_IPv4_UC, nlri, false, []bgp.PathAttributeInterface{bgp.NewPathAttributeOrigin(0), nh}, time.Now())
+		_, err := s2.AddPath(apiutil.AddPathRequest{Paths: []*apiutil.Path{mustApi2apiutilPath(path)}})
+		require.NoError(t, err)
+	}
+	global := func() int {
+		n := 0
+		require.NoError(t, s1.ListPath(apiutil.ListPathRequest{TableType: api.TableType_TABLE_TYPE_GLOBAL, Family: bgp.RF_IPv4_UC}, func(_ bgp.NLRI, paths []*apiutil.Path) {
+			n += len(paths)
+		}))
+		return n
+	}
+	assert.Eventually(t, func() bool { return global() == 2 }, 10*time.Second, 50*time.Millisecond)
+
+	// the policy rejecting 10.0.0.0/24 is built in the steps
+	id, err := s1.StartPolicyTransaction(ctx, &api.StartPolicyTransactionRequest{})
+	require.NoError(t, err)
+	require.NoError(t, s1.AddDefinedSet(ctx, &api.AddDefinedSetRequest{
+		TransactionId: id,
+		DefinedSet: &api.DefinedSet{
+			DefinedType: api.DefinedType_DEFINED_TYPE_PREFIX,
+			Name:        "ps1",
+			Prefixes:    []*api.Prefix{{IpPrefix: "10.0.0.0/24", MaskLengthMin: 24, MaskLengthMax: 24}},
+		},
+	}))
+	p1 := &api.Policy{
+		Name: "p1",
+		Statements: []*api.Statement{{
+			Name:       "s1",
+			Conditions: &api.Conditions{PrefixSet: &api.MatchSet{Name: "ps1", Type: api.MatchSet_TYPE_ANY}},
+			Actions:    &api.Actions{RouteAction: api.RouteAction_ROUTE_ACTION_REJECT},
+		}},
+	}
+	require.NoError(t, s1.AddPolicy(ctx, &api.AddPolicyRequest{TransactionId: id, Policy: p1}))
+	require.NoError(t, s1.SetPolicyAssignment(ctx, &api.SetPolicyAssignmentRequest{
+		TransactionId: id,
+		Assignment: &api.PolicyAssignment{
+			Name:          table.GLOBAL_RIB_NAME,
+			Direction:     api.PolicyDirection_POLICY_DIRECTION_IMPORT,
+			Policies:      []*api.Policy{{Name: "p1"}},
+			DefaultAction: api.RouteAction_ROUTE_ACTION_ACCEPT,
+		},
+	}))
+	assert.Error(t, s1.AddPolicy(ctx, &api.AddPolicyRequest{TransactionId: "none", Policy: p1}))
+
+	// nothing is applied until committed
+	var policies []*api.Policy
+	require.NoError(t, s1.ListPolicy(ctx, &api.ListPolicyRequest{}, func(p *api.Policy) { policies = append(policies, p) }))
+	assert.Empty(t, policies)
+	var txs []*api.PolicyTransaction
+	require.NoError(t, s1.ListPolicyTransaction(ctx, &api.ListPolicyTransactionRequest{}, func(tx *api.PolicyTransaction) { txs = append(txs, tx) }))
+	require.Len(t, txs, 1)
+	assert.Equal(t, uint32(3), txs[0].Changes)
+
+	diffs, err := s1.DiffPolicyTransaction(ctx, &api.DiffPolicyTransactionRequest{Id: id})
+	require.NoError(t, err)
+	var kinds []string
+	for _, d := range diffs {
+		kinds = append(kinds, d.Kind+" "+d.Name)
+	}
+	assert.Equal(t, []string{"assignment global", "policy p1", "prefix-set ps1", "statement s1"}, kinds)
+
+	// the candidate can be tested before the commit
+	rsp, err := s1.TestPolicy(ctx, &api.TestPolicyRequest{
+		TransactionId: id,
+		Direction:     api.PolicyDirection_POLICY_DIRECTION_IMPORT,
+		Lookup: &api.TestPolicyRequest_Lookup{
+			Neighbor: "127.0.0.1",
+			Family:   &api.Family{Afi: api.Family_AFI_IP, Safi: api.Family_SAFI_UNICAST},
+			Prefix:   "10.0.0.0/24",
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, api.TestPolicyResponse_DECISION_REJECT, rsp.Decision)
+
+	// the paths received are evaluated again with the committed policy
+	version, err := s1.CommitPolicyTransaction(ctx, &api.CommitPolicyTransactionRequest{Id: id, Comment: "reject ps1"})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), version)
+	assert.Eventually(t, func() bool { return global() == 1 }, 10*time.Second, 50*time.Millisecond)
+	_, err = s1.CommitPolicyTransaction(ctx, &api.CommitPolicyTransactionRequest{Id: id})
+	assert.Error(t, err)
+
+	// the running policy is changed after the transaction started
+	id, err = s1.StartPolicyTransaction(ctx, &api.StartPolicyTransactionRequest{Id: "tx2"})
+	require.NoError(t, err)
+	assert.Equal(t, "tx2", id)
+	_, err = s1.StartPolicyTransaction(ctx, &api.StartPolicyTransactionRequest{Id: "tx2"})
+	assert.Error(t, err)
+	require.NoError(t, s1.DeleteDefinedSet(ctx, &api.DeleteDefinedSetRequest{
+		TransactionId: id,
+		DefinedSet: &api.DefinedSet{
+			DefinedType: api.DefinedType_DEFINED_TYPE_PREFIX,
+			Name:        "ps1",
+			Prefixes:    []*api.Prefix{{IpPrefix: "10.0.0.0/24", MaskLengthMin: 24, MaskLengthMax: 24}},
+		},
+	}))
+	require.NoError(t, s1.AddDefinedSet(ctx, &api.AddDefinedSetRequest{DefinedSet: &api.DefinedSet{
+		DefinedType: api.DefinedType_DEFINED_TYPE_PREFIX,
+		Name:        "ps1",
+		Prefixes:    []*api.Prefix{{IpPrefix: "10.1.0.0/24", MaskLengthMin: 24, MaskLengthMax: 24}},
+	}}))
+	_, err = s1.CommitPolicyTransaction(ctx, &api.CommitPolicyTransactionRequest{Id: id})
+	assert.Error(t, err)
+	require.NoError(t, s1.AbortPolicyTransaction(ctx, &api.AbortPolicyTransactionRequest{Id: id}))
+	assert.Error(t, s1.AbortPolicyTransaction(ctx, &api.AbortPolicyTransactionRequest{Id: id}))
+
+	// the policy before the first commit
+	version, err = s1.RollbackPolicy(ctx, &api.RollbackPolicyRequest{Version: 1})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(4), version)
+	assert.Eventually(t, func() bool { return global() == 2 }, 10*time.Second, 50*time.Millisecond)
+
+	var versions []*api.PolicyVersion
+	require.NoError(t, s1.ListPolicyVersion(ctx, &api.ListPolicyVersionRequest{}, func(v *api.PolicyVersion) { versions = append(versions, v) }))
+	require.Len(t, versions, 4)
+	assert.Equal(t, "reject ps1", versions[1].Comment)
+	// the change outside of the transactions
+	assert.Equal(t, uint32(3), versions[2].Version)
+	assert.Equal(t, "rollback to 1", versions[3].Comment)
+	assert.True(t, versions[3].Running)
+
+	_, err = s1.RollbackPolicy(ctx, &api.RollbackPolicyRequest{Version: 10})
+	assert.Error(t, err)
+}
+
+func TestPolicyHistorySize(t *testing.T) {
+	m := newPolicyTransactionManager(2)
+	for range 3 {
+		m.addVersion(&table.PolicySnapshot{}, "")
+	}
+	require.Len(t, m.versions, 2)
+	assert.Equal(t, uint32(2), m.versions[0].version)
+	assert.Equal(t, uint32(3), m.versions[1].version)
+}
+
+func TestPolicyTransactionTimeout(t *testing.T) {
+	m := newPolicyTransactionManager(0)
+	m.timeout = time.Minute
+	now := time.Now()
+	m.transactions["tx1"] = &policyTransaction{id: "tx1", used: now.Add(-2 * time.Minute)}
+	m.transactions["tx2"] = &policyTransaction{id: "tx2", used: now.Add(-30 * time.Second)}
+	_, ok := m.get("tx1")
+	assert.False(t, ok)
+	tx, ok := m.get("tx2")
+	require.True(t, ok)
+	assert.True(t, tx.used.After(now))
+	assert.Len(t, m.transactions, 1)
+}
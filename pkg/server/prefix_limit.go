@@ -0,0 +1,336 @@
+// Copyright (C) 2025 Acnodal Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"log/slog"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	api "github.com/osrg/gobgp/v4/api"
+	"github.com/osrg/gobgp/v4/internal/pkg/table"
+	"github.com/osrg/gobgp/v4/pkg/config/oc"
+	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
+)
+
+// prefixLimitState is the runtime state of the prefix limits of a family.
+type prefixLimitState struct {
+	// the paths accepted by the import policy, tracked only when the
+	// limit counts the accepted prefixes
+	accepted map[table.PathLocalKey]struct{}
+	// the paths advertised to the peer, tracked only with max-prefixes-out
+	advertised map[table.PathLocalKey]struct{}
+	// the latest paths not advertised because of max-prefixes-out
+	dropped        map[table.PathLocalKey]*table.Path
+	maxPrefixesOut int
+
+	thresholdExceeded bool
+	limitExceeded     bool
+	limitOutExceeded  bool
+}
+
+func (peer *peer) prefixLimitState(family bgp.Family) *prefixLimitState {
+	st, ok := peer.prefixLimits[family]
+	if !ok {
+		st = &prefixLimitState{}
+		peer.prefixLimits[family] = st
+	}
+	return st
+}
+
+// initPrefixLimits starts or stops tracking the accepted and advertised
+// paths of the families as configured. It returns true when the accepted
+// paths have to be counted from scratch, which needs soft reset in.
+func (peer *peer) initPrefixLimits(afiSafis []oc.AfiSafi) bool {
+	needsSoftResetIn := false
+	for _, a := range afiSafis {
+		c := &a.PrefixLimit.Config
+		st := peer.prefixLimitState(a.State.Family)
+		if c.MaxPrefixes > 0 && c.CountType == oc.PREFIX_LIMIT_COUNT_TYPE_ACCEPTED {
+			if st.accepted == nil {
+				st.accepted = make(map[table.PathLocalKey]struct{})
+				needsSoftResetIn = true
+			}
+		} else {
+			st.accepted = nil
+		}
+		st.maxPrefixesOut = int(c.MaxPrefixesOut)
+		if st.maxPrefixesOut > 0 {
+			if st.advertised == nil {
+				st.advertised = make(map[table.PathLocalKey]struct{})
+				st.dropped = make(map[table.PathLocalKey]*table.Path)
+			}
+		} else {
+			st.advertised = nil
+			st.dropped = nil
+			st.limitOutExceeded = false
+		}
+	}
+	return needsSoftResetIn
+}
+
+// resetPrefixLimits clears the state of the session going down. The
+// accepted paths are removed by the withdrawals of the dropped paths.
+func (peer *peer) resetPrefixLimits() {
+	for _, st := range peer.prefixLimits {
+		if st.advertised != nil {
+			st.advertised = make(map[table.PathLocalKey]struct{})
+			st.dropped = make(map[table.PathLocalKey]*table.Path)
+		}
+		st.thresholdExceeded = false
+		st.limitExceeded = false
+		st.limitOutExceeded = false
+	}
+}
+
+// updateAcceptedPath records whether the path received from the peer is
+// accepted by the import policy.
+func (peer *peer) updateAcceptedPath(path *table.Path, accepted bool) {
+	st, ok := peer.prefixLimits[path.GetFamily()]
+	if !ok || st.accepted == nil {
+		return
+	}
+	key := table.PathLocalKey{PathDestLocalKey: path.GetDestLocalKey(), Id: path.RemoteID()}
+	if accepted {
+		st.accepted[key] = struct{}{}
+	} else {
+		delete(st.accepted, key)
+	}
+}
+
+func (peer *peer) prefixCount(family bgp.Family, c *oc.PrefixLimitConfig) int {
+	if c.CountType == oc.PREFIX_LIMIT_COUNT_TYPE_ACCEPTED {
+		return len(peer.prefixLimitState(family).accepted)
+	}
+	return peer.adjRibIn.Count([]bgp.Family{family})
+}
+
+func (peer *peer) newPrefixLimitEvent(t api.WatchEventResponse_PrefixLimitEvent_Type, family bgp.Family, count, limit int) *watchEventPrefixLimit {
+	peer.fsm.lock.Lock()
+	addr := peer.fsm.pConf.State.NeighborAddress
+	peer.fsm.lock.Unlock()
+	return &watchEventPrefixLimit{
+		Type:        t,
+		PeerAddress: addr,
+		Family:      family,
+		Count:       uint32(count),
+		Limit:       uint32(limit),
+		Timestamp:   time.Now(),
+	}
+}
+
+// checkPrefixLimit checks the prefixes from the peer against the limit of
+// the family. It returns the events of the thresholds newly crossed and
+// whether the session has to be shut down.
+func (peer *peer) checkPrefixLimit(family bgp.Family, c *oc.PrefixLimitConfig) ([]*watchEventPrefixLimit, bool) {
+	maxPrefixes := int(c.MaxPrefixes)
+	if maxPrefixes == 0 {
+		return nil, false
+	}
+	var events []*watchEventPrefixLimit
+	st := peer.prefixLimitState(family)
+	count := peer.prefixCount(family, c)
+	if pct := int(c.ShutdownThresholdPct); pct > 0 {
+		if count > maxPrefixes*pct/100 {
+			if !st.thresholdExceeded {
+				st.thresholdExceeded = true
+				peer.fsm.logger.Warn("prefix limit threshold reached",
+					slog.String("Family", family.String()),
+					slog.Int("Pct", pct),
+					slog.Int("Count", count))
+				events = append(events, peer.newPrefixLimitEvent(api.WatchEventResponse_PrefixLimitEvent_TYPE_THRESHOLD, family, count, maxPrefixes))
+			}
+		} else {
+			st.thresholdExceeded = false
+		}
+	}
+	if count <= maxPrefixes {
+		st.limitExceeded = false
+		return events, false
+	}
+	if !st.limitExceeded {
+		st.limitExceeded = true
+		peer.fsm.logger.Warn("prefix limit reached",
+			slog.String("Family", family.String()),
+			slog.Int("Count", count),
+			slog.Int("MaxPrefixes", maxPrefixes),
+			slog.Bool("WarningOnly", c.WarningOnly))
+		ev := peer.newPrefixLimitEvent(api.WatchEventResponse_PrefixLimitEvent_TYPE_LIMIT, family, count, maxPrefixes)
+		ev.Shutdown = !c.WarningOnly
+		events = append(events, ev)
+	}
+	return events, !c.WarningOnly
+}
+
+// limitAdvertisedPaths drops the paths of the new prefixes over
+// max-prefixes-out. The withdrawals of the dropped prefixes aren't sent,
+// and the dropped prefixes are advertised once the peer has room for them.
+func (peer *peer) limitAdvertisedPaths(paths []*table.Path) ([]*table.Path, []*watchEventPrefixLimit) {
+	limited := false
+	for _, st := range peer.prefixLimits {
+		if st.advertised != nil {
+			limited = true
+			break
+		}
+	}
+	if !limited {
+		return paths, nil
+	}
+	var events []*watchEventPrefixLimit
+	l := make([]*table.Path, 0, len(paths))
+	for _, path := range paths {
+		family := path.GetFamily()
+		st, ok := peer.prefixLimits[family]
+		if !ok || st.advertised == nil || path.IsEOR() {
+			l = append(l, path)
+			continue
+		}
+		key := table.PathLocalKey{PathDestLocalKey: path.GetDestLocalKey()}
+		if peer.isAddPathSendEnabled(family) {
+			key.Id = path.GetLocalKey().Id
+		}
+		if path.IsWithdraw {
+			if _, ok := st.dropped[key]; ok {
+				// the peer never got the prefix
+				delete(st.dropped, key)
+				continue
+			}
+			delete(st.advertised, key)
+		} else if _, ok := st.advertised[key]; !ok {
+			if len(st.advertised) >= st.maxPrefixesOut {
+				st.dropped[key] = path
+				if !st.limitOutExceeded {
+					st.limitOutExceeded = true
+					peer.fsm.logger.Warn("outbound prefix limit reached",
+						slog.String("Family", family.String()),
+						slog.Int("MaxPrefixesOut", st.maxPrefixesOut))
+					events = append(events, peer.newPrefixLimitEvent(api.WatchEventResponse_PrefixLimitEvent_TYPE_LIMIT_OUT, family, len(st.advertised), st.maxPrefixesOut))
+				}
+				continue
+			}
+			delete(st.dropped, key)
+			st.advertised[key] = struct{}{}
+		}
+		l = append(l, path)
+	}
+	// advertise the dropped prefixes for which the withdrawals made room
+	for _, st := range peer.prefixLimits {
+		for key, path := range st.dropped {
+			if len(st.advertised) >= st.maxPrefixesOut {
+				break
+			}
+			delete(st.dropped, key)
+			st.advertised[key] = struct{}{}
+			l = append(l, path)
+		}
+		if len(st.dropped) == 0 {
+			st.limitOutExceeded = false
+		}
+	}
+	return l, events
+}
+
+// toPrefixLimitStateApi returns the current state of the prefix limits of
+// the family.
+func (peer *peer) toPrefixLimitStateApi(family bgp.Family, c *oc.PrefixLimitConfig) *api.PrefixLimitState {
+	st := peer.prefixLimitState(family)
+	s := &api.PrefixLimitState{
+		ThresholdExceeded: st.thresholdExceeded,
+		LimitExceeded:     st.limitExceeded,
+		LimitOutExceeded:  st.limitOutExceeded,
+	}
+	if c.MaxPrefixes > 0 {
+		s.Count = uint32(peer.prefixCount(family, c))
+		s.UtilizationPct = uint32(uint64(s.Count) * 100 / uint64(c.MaxPrefixes))
+	}
+	if st.advertised != nil && st.maxPrefixesOut > 0 {
+		s.CountOut = uint32(len(st.advertised))
+		s.UtilizationOutPct = uint32(len(st.advertised) * 100 / st.maxPrefixesOut)
+	}
+	if !peer.prefixLimitRestartTime.IsZero() {
+		s.RestartTime = timestamppb.New(peer.prefixLimitRestartTime)
+	}
+	return s
+}
+
+// checkPrefixLimits checks the limits of the families counting the
+// prefixes of the given type, or all the families if the type is empty,
+// and shuts down the session if needed. It returns true if the session is
+// shut down.
+func (s *BgpServer) checkPrefixLimits(peer *peer, countType oc.PrefixLimitCountType) bool {
+	peer.fsm.lock.Lock()
+	afiSafis := peer.fsm.pConf.AfiSafis
+	peer.fsm.lock.Unlock()
+	for _, a := range afiSafis {
+		c := &a.PrefixLimit.Config
+		t := c.CountType
+		if t == "" {
+			t = oc.PREFIX_LIMIT_COUNT_TYPE_RECEIVED
+		}
+		if countType != "" && t != countType {
+			continue
+		}
+		events, shutdown := peer.checkPrefixLimit(a.State.Family, c)
+		for _, ev := range events {
+			s.notifyWatcher(watchEventTypePrefixLimit, ev)
+		}
+		if shutdown {
+			s.shutdownByPrefixLimit(peer, c)
+			return true
+		}
+	}
+	return false
+}
+
+// shutdownByPrefixLimit shuts down the session exceeding the prefix limit
+// and schedules the restart after the restart timer.
+func (s *BgpServer) shutdownByPrefixLimit(peer *peer, c *oc.PrefixLimitConfig) {
+	_ = s.setAdminState(peer.ID(), "", adminStatePfxCt)
+	if c.RestartTimer <= 0 || peer.prefixLimitRestart != nil {
+		return
+	}
+	d := time.Duration(c.RestartTimer * float64(time.Second))
+	peer.fsm.logger.Info("prefix limit restart timer started", slog.Duration("Duration", d))
+	peer.prefixLimitRestartTime = time.Now().Add(d)
+	peer.fsm.lock.Lock()
+	addr := peer.fsm.pConf.State.NeighborAddress
+	peer.fsm.lock.Unlock()
+	var timer *time.Timer
+	timer = time.AfterFunc(d, func() {
+		_ = s.mgmtOperation(func() error {
+			if s.neighborMap[addr] != peer || peer.prefixLimitRestart != timer {
+				return nil
+			}
+			peer.stopPrefixLimitRestart()
+			if peer.AdminState() != adminStatePfxCt {
+				return nil
+			}
+			peer.fsm.logger.Info("prefix limit restart timer expired")
+			s.notifyWatcher(watchEventTypePrefixLimit, peer.newPrefixLimitEvent(api.WatchEventResponse_PrefixLimitEvent_TYPE_RESTART, bgp.Family(0), 0, 0))
+			return s.setAdminState(peer.ID(), "", adminStateUp)
+		}, false)
+	})
+	peer.prefixLimitRestart = timer
+}
+
+func (peer *peer) stopPrefixLimitRestart() {
+	if peer.prefixLimitRestart != nil {
+		peer.prefixLimitRestart.Stop()
+		peer.prefixLimitRestart = nil
+		peer.prefixLimitRestartTime = time.Time{}
+	}
+}
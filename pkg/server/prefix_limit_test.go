@@ -0,0 +1,251 @@
+// Copyright (C) 2025 Acnodal Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/osrg/gobgp/v4/api"
+	"github.com/osrg/gobgp/v4/internal/pkg/table"
+	"github.com/osrg/gobgp/v4/pkg/apiutil"
+	"github.com/osrg/gobgp/v4/pkg/config/oc"
+	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
+)
+
+func newPrefixLimitTestPaths(p *peer, withdraw bool, prefixes ...string) []*table.Path {
+	l := make([]*table.Path, 0, len(prefixes))
+	for _, s := range prefixes {
+		nlri, _ := bgp.NewIPAddrPrefix(netip.MustParsePrefix(s))
+		l = append(l, table.NewPath(bgp.RF_IPv4_UC, p.peerInfo, bgp.PathNLRI{NLRI: nlri}, withdraw, []bgp.PathAttributeInterface{bgp.NewPathAttributeOrigin(0)}, time.Now(), false))
+	}
+	return l
+}
+
+func TestCheckPrefixLimit(t *testing.T) {
+	rib := table.NewTableManager(logger, []bgp.Family{bgp.RF_IPv4_UC})
+	p := newPeerandInfo(t, 65000, 65001, "192.168.0.1", rib)
+	c := &oc.PrefixLimitConfig{MaxPrefixes: 2, ShutdownThresholdPct: 50}
+
+	p.adjRibIn.Update(newPrefixLimitTestPaths(p, false, "10.0.0.0/24"))
+	events, shutdown := p.checkPrefixLimit(bgp.RF_IPv4_UC, c)
+	assert.Empty(t, events)
+	assert.False(t, shutdown)
+
+	p.adjRibIn.Update(newPrefixLimitTestPaths(p, false, "10.0.1.0/24"))
+	events, shutdown = p.checkPrefixLimit(bgp.RF_IPv4_UC, c)
+	require.Len(t, events, 1)
+	assert.Equal(t, api.WatchEventResponse_PrefixLimitEvent_TYPE_THRESHOLD, events[0].Type)
+	assert.Equal(t, uint32(2), events[0].Count)
+	assert.False(t, shutdown)
+
+	// warning-only keeps the session and notifies the excess once
+	c.WarningOnly = true
+	p.adjRibIn.Update(newPrefixLimitTestPaths(p, false, "10.0.2.0/24"))
+	events, shutdown = p.checkPrefixLimit(bgp.RF_IPv4_UC, c)
+	require.Len(t, events, 1)
+	assert.Equal(t, api.WatchEventResponse_PrefixLimitEvent_TYPE_LIMIT, events[0].Type)
+	assert.False(t, events[0].Shutdown)
+	assert.False(t, shutdown)
+	events, _ = p.checkPrefixLimit(bgp.RF_IPv4_UC, c)
+	assert.Empty(t, events)
+	s := p.toPrefixLimitStateApi(bgp.RF_IPv4_UC, c)
+	assert.Equal(t, uint32(3), s.Count)
+	assert.Equal(t, uint32(150), s.UtilizationPct)
+	assert.True(t, s.LimitExceeded)
+
+	// the thresholds are notified again after falling below them
+	p.adjRibIn.Update(newPrefixLimitTestPaths(p, true, "10.0.1.0/24", "10.0.2.0/24"))
+	events, _ = p.checkPrefixLimit(bgp.RF_IPv4_UC, c)
+	assert.Empty(t, events)
+	c.WarningOnly = false
+	p.adjRibIn.Update(newPrefixLimitTestPaths(p, false, "10.0.1.0/24", "10.0.2.0/24"))
+	events, shutdown = p.checkPrefixLimit(bgp.RF_IPv4_UC, c)
+	require.Len(t, events, 2)
+	assert.True(t, events[1].Shutdown)
+	assert.True(t, shutdown)
+
+	// the accepted paths are counted
+	c.CountType = oc.PREFIX_LIMIT_COUNT_TYPE_ACCEPTED
+	assert.True(t, p.initPrefixLimits([]oc.AfiSafi{{
+		State:       oc.AfiSafiState{Family: bgp.RF_IPv4_UC},
+		PrefixLimit: oc.PrefixLimit{Config: *c},
+	}}))
+	for i, path := range newPrefixLimitTestPaths(p, false, "10.0.0.0/24", "10.0.1.0/24", "10.0.2.0/24") {
+		p.updateAcceptedPath(path, i != 0)
+	}
+	assert.Equal(t, 2, p.prefixCount(bgp.RF_IPv4_UC, c))
+}
+
+func TestLimitAdvertisedPaths(t *testing.T) {
+	rib := table.NewTableManager(logger, []bgp.Family{bgp.RF_IPv4_UC})
+	p := newPeerandInfo(t, 65000, 65001, "192.168.0.1", rib)
+	paths := newPrefixLimitTestPaths(p, false, "10.0.0.0/24", "10.0.1.0/24", "10.0.2.0/24")
+
+	l, events := p.limitAdvertisedPaths(paths)
+	assert.Len(t, l, 3)
+	assert.Empty(t, events)
+
+	p.initPrefixLimits([]oc.AfiSafi{{
+		State:       oc.AfiSafiState{Family: bgp.RF_IPv4_UC},
+		PrefixLimit: oc.PrefixLimit{Config: oc.PrefixLimitConfig{MaxPrefixesOut: 2}},
+	}})
+	l, events = p.limitAdvertisedPaths(paths)
+	assert.Equal(t, paths[:2], l)
+	require.Len(t, events, 1)
+	assert.Equal(t, api.WatchEventResponse_PrefixLimitEvent_TYPE_LIMIT_OUT, events[0].Type)
+	assert.True(t, p.prefixLimits[bgp.RF_IPv4_UC].limitOutExceeded)
+
+	// the advertised prefixes are updated, and the withdrawal makes room
+	l, events = p.limitAdvertisedPaths([]*table.Path{paths[0], paths[2]})
+	assert.Equal(t, paths[:1], l)
+	assert.Empty(t, events)
+	withdrawals := newPrefixLimitTestPaths(p, true, "10.0.0.0/24")
+	l, _ = p.limitAdvertisedPaths(append(withdrawals, paths[2]))
+	assert.Len(t, l, 2)
+	assert.False(t, p.prefixLimits[bgp.RF_IPv4_UC].limitOutExceeded)
+	s := p.toPrefixLimitStateApi(bgp.RF_IPv4_UC, &oc.PrefixLimitConfig{MaxPrefixesOut: 2})
+	assert.Equal(t, uint32(2), s.CountOut)
+	assert.Equal(t, uint32(100), s.UtilizationOutPct)
+
+	// the withdrawal of the dropped prefix isn't sent
+	dropped := newPrefixLimitTestPaths(p, false, "10.0.3.0/24", "10.0.4.0/24")
+	l, _ = p.limitAdvertisedPaths(dropped)
+	assert.Empty(t, l)
+	assert.True(t, p.prefixLimits[bgp.RF_IPv4_UC].limitOutExceeded)
+	l, _ = p.limitAdvertisedPaths(newPrefixLimitTestPaths(p, true, "10.0.3.0/24"))
+	assert.Empty(t, l)
+
+	// the dropped prefix is advertised once the withdrawal makes room
+	withdrawals = newPrefixLimitTestPaths(p, true, "10.0.2.0/24")
+	l, events = p.limitAdvertisedPaths(withdrawals)
+	assert.Equal(t, []*table.Path{withdrawals[0], dropped[1]}, l)
+	assert.Empty(t, events)
+	assert.False(t, p.prefixLimits[bgp.RF_IPv4_UC].limitOutExceeded)
+	assert.Empty(t, p.prefixLimits[bgp.RF_IPv4_UC].dropped)
+}
+
+func TestPrefixLimit(t *testing.T) {
+	ctx := context.Background()
+	s1 := runNewServer(t, 1, "1.1.1.1", 10179)
+	defer s1.StopBgp(ctx, &api.StopBgpRequest{})
+	s2 := runNewServer(t, 2, "2.2.2.2", 20179)
+	defer s2.StopBgp(ctx, &api.StopBgpRequest{})
+
+	// s1 rejects 10.9.0.0/16, which doesn't count for the limit
+	require.NoError(t, s1.AddDefinedSet(ctx, &api.AddDefinedSetRequest{DefinedSet: &api.DefinedSet{
+		DefinedType: api.DefinedType_DEFINED_TYPE_PREFIX,
+		Name:        "ps",
+		Prefixes:    []*api.Prefix{{IpPrefix: "10.9.0.0/16", MaskLengthMin: 16, MaskLengthMax: 24}},
+	}}))
+	require.NoError(t, s1.AddPolicy(ctx, &api.AddPolicyRequest{Policy: &api.Policy{
+		Name: "reject",
+		Statements: []*api.Statement{{
+			Name:       "reject",
+			Conditions: &api.Conditions{PrefixSet: &api.MatchSet{Name: "ps", Type: api.MatchSet_TYPE_ANY}},
+			Actions:    &api.Actions{RouteAction: api.RouteAction_ROUTE_ACTION_REJECT},
+		}},
+	}}))
+	require.NoError(t, s1.AddPolicyAssignment(ctx, &api.AddPolicyAssignmentRequest{Assignment: &api.PolicyAssignment{
+		Name:          table.GLOBAL_RIB_NAME,
+		Direction:     api.PolicyDirection_POLICY_DIRECTION_IMPORT,
+		Policies:      []*api.Policy{{Name: "reject"}},
+		DefaultAction: api.RouteAction_ROUTE_ACTION_ACCEPT,
+	}}))
+
+	events := make(chan *apiutil.WatchEventMessage_PrefixLimitEvent, 16)
+	wctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	require.NoError(t, s1.WatchEvent(wctx, WatchEventMessageCallbacks{
+		OnPrefixLimit: func(ev *apiutil.WatchEventMessage_PrefixLimitEvent, _ time.Time) {
+			events <- ev
+		},
+	}, WatchPrefixLimit()))
+
+	family := &api.Family{Afi: api.Family_AFI_IP, Safi: api.Family_SAFI_UNICAST}
+	established := waitEstablished(s1, bgp.RF_IPv4_UC)
+	require.NoError(t, s1.AddPeer(ctx, &api.AddPeerRequest{Peer: &api.Peer{
+		Conf:      &api.PeerConf{NeighborAddress: "127.0.0.1", PeerAsn: 2},
+		Transport: &api.Transport{RemotePort: 20179, PassiveMode: true},
+		Timers:    &api.Timers{Config: &api.TimersConfig{ConnectRetry: 1, IdleHoldTimeAfterReset: 1}},
+		AfiSafis: []*api.AfiSafi{{
+			Config: &api.AfiSafiConfig{Family: family, Enabled: true},
+			PrefixLimits: &api.PrefixLimit{
+				Family:               family,
+				MaxPrefixes:          2,
+				ShutdownThresholdPct: 50,
+				RestartTimer:         1,
+				CountType:            api.PrefixLimit_COUNT_TYPE_ACCEPTED,
+			},
+		}},
+	}}))
+	require.NoError(t, peerTwoServers(t, ctx, s2, s1, []oc.AfiSafiType{oc.AFI_SAFI_TYPE_IPV4_UNICAST}, false))
+	established.Wait()
+
+	add := func(prefixes ...string) {
+		nh, _ := bgp.NewPathAttributeNextHop(netip.MustParseAddr("10.0.0.1"))
+		for _, s := range prefixes {
+			prefix, _ := bgp.NewIPAddrPrefix(netip.MustParsePrefix(s))
+			p, _ := apiutil.NewPath(bgp.RF_IPv4_UC, prefix, false, []bgp.PathAttributeInterface{
+				bgp.NewPathAttributeOrigin(0),
+				nh,
+			}, time.Now())
+			_, err := s2.AddPath(apiutil.AddPathRequest{Paths: []*apiutil.Path{mustApi2apiutilPath(p)}})
+			require.NoError(t, err)
+		}
+	}
+	state := func() *api.PrefixLimitState {
+		var s *api.PrefixLimitState
+		require.NoError(t, s1.ListPeer(ctx, &api.ListPeerRequest{}, func(p *api.Peer) {
+			s = p.AfiSafis[0].GetPrefixLimits().GetState()
+		}))
+		return s
+	}
+	next := func() *apiutil.WatchEventMessage_PrefixLimitEvent {
+		select {
+		case ev := <-events:
+			return ev
+		case <-time.After(10 * time.Second):
+			t.Fatal("no prefix limit event")
+		}
+		return nil
+	}
+
+	add("10.1.0.0/24", "10.9.0.0/24", "10.9.1.0/24")
+	assert.Eventually(t, func() bool { return state().GetCount() == 1 }, 10*time.Second, 50*time.Millisecond)
+	assert.Equal(t, uint32(50), state().UtilizationPct)
+
+	add("10.2.0.0/24")
+	ev := next()
+	assert.Equal(t, api.WatchEventResponse_PrefixLimitEvent_TYPE_THRESHOLD, ev.Type)
+	assert.Equal(t, uint32(2), ev.Count)
+
+	add("10.3.0.0/24")
+	ev = next()
+	assert.Equal(t, api.WatchEventResponse_PrefixLimitEvent_TYPE_LIMIT, ev.Type)
+	assert.True(t, ev.Shutdown)
+	assert.Equal(t, bgp.RF_IPv4_UC, ev.Family)
+	assert.NotNil(t, state().RestartTime)
+
+	// the session is restarted after the restart timer
+	ev = next()
+	assert.Equal(t, api.WatchEventResponse_PrefixLimitEvent_TYPE_RESTART, ev.Type)
+}
@@ -17,15 +17,25 @@ package server
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/binary"
 	"fmt"
 	"io"
 	"log/slog"
 	"net"
 	"net/netip"
+	"os"
+	"os/user"
+	"path/filepath"
 	"strconv"
 	"time"
 
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+
+	"github.com/osrg/gobgp/v4/api"
 	"github.com/osrg/gobgp/v4/internal/pkg/table"
 	"github.com/osrg/gobgp/v4/pkg/config/oc"
 	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
@@ -47,13 +57,15 @@ const (
 	roaDisconnected
 	roaRTR
 	roaLifetimeout
+	roaRefresh
+	roaFileReload
 )
 
 type roaEvent struct {
 	EventType roaEventType
 	Src       string
 	Data      []byte
-	conn      *net.TCPConn
+	conn      io.ReadWriteCloser
 	timestamp time.Time
 }
 
@@ -61,35 +73,81 @@ type roaManager struct {
 	eventCh   chan *roaEvent
 	clientMap map[string]*roaClient
 	table     *table.ROATable
+	keys      *table.RouterKeyTable
+	aspas     *table.ASPATable
 	logger    *slog.Logger
+
+	// the local files by the absolute paths
+	files   map[string]*rpkiFile
+	watcher *fileWatcher
 }
 
-func newROAManager(table *table.ROATable, logger *slog.Logger) *roaManager {
+func newROAManager(roaTable *table.ROATable, keys *table.RouterKeyTable, logger *slog.Logger) *roaManager {
 	m := &roaManager{
 		eventCh:   make(chan *roaEvent),
 		clientMap: make(map[string]*roaClient),
-		table:     table,
+		table:     roaTable,
+		keys:      keys,
+		aspas:     table.NewASPATable(),
 		logger:    logger,
+
+		files: make(map[string]*rpkiFile),
 	}
+	m.watcher = newFileWatcher(func(path string, done <-chan struct{}) {
+		select {
+		case m.eventCh <- &roaEvent{EventType: roaFileReload, Src: path, timestamp: time.Now()}:
+		case <-done:
+		}
+	})
 	return m
 }
 
+// deleteAll removes all the data learned from the source.
+func (m *roaManager) deleteAll(src string) {
+	m.table.DeleteAll(src)
+	m.keys.DeleteAll(src)
+	m.aspas.DeleteAll(src)
+}
+
 func (m *roaManager) enabled() bool {
-	return len(m.clientMap) != 0
+	return len(m.clientMap) != 0 || len(m.files) != 0
 }
 
-func (m *roaManager) AddServer(host string, lifetime int64) error {
-	address, port, err := net.SplitHostPort(host)
-	if err != nil {
-		return err
+// updating reports whether a cache has been queried and hasn't sent all
+// the changes yet, which are complete at End of Data.
+func (m *roaManager) updating() bool {
+	for _, c := range m.clientMap {
+		if c.updating {
+			return true
+		}
 	}
+	return false
+}
+
+func (m *roaManager) AddServer(c *oc.RpkiServerConfig) error {
+	host := net.JoinHostPort(c.Address.String(), strconv.Itoa(int(c.Port)))
+	lifetime := c.RecordLifetime
 	if lifetime == 0 {
 		lifetime = 3600
 	}
 	if _, ok := m.clientMap[host]; ok {
 		return fmt.Errorf("ROA server exists %s", host)
 	}
-	m.clientMap[host] = newRoaClient(address, port, m.eventCh, lifetime)
+	var tlsConfig *tls.Config
+	var sshConfig *ssh.ClientConfig
+	switch c.Transport {
+	case oc.RPKI_TRANSPORT_TYPE_TLS:
+		var err error
+		if tlsConfig, err = newClientTLSConfig(c.Address, c.TlsCaFile, c.TlsCertFile, c.TlsKeyFile, c.TlsServerName); err != nil {
+			return err
+		}
+	case oc.RPKI_TRANSPORT_TYPE_SSH:
+		var err error
+		if sshConfig, err = newRTRSSHConfig(c); err != nil {
+			return err
+		}
+	}
+	m.clientMap[host] = newRoaClient(c, tlsConfig, sshConfig, m.eventCh, lifetime)
 	return nil
 }
 
@@ -99,7 +157,7 @@ func (m *roaManager) DeleteServer(host string) error {
 		return fmt.Errorf("ROA server doesn't exists %s", host)
 	}
 	client.stop()
-	m.table.DeleteAll(host)
+	m.deleteAll(host)
 	delete(m.clientMap, host)
 	return nil
 }
@@ -119,7 +177,7 @@ func (m *roaManager) Disable(address string) error {
 		add, _, _ := net.SplitHostPort(network)
 		if add == address {
 			client.reset()
-			m.table.DeleteAll(add)
+			m.deleteAll(add)
 			return nil
 		}
 	}
@@ -134,13 +192,25 @@ func (m *roaManager) SoftReset(address string) error {
 	for network, client := range m.clientMap {
 		add, _, _ := net.SplitHostPort(network)
 		if add == address {
-			m.table.DeleteAll(network)
+			m.deleteAll(network)
 			return client.softReset()
 		}
 	}
 	return fmt.Errorf("ROA server not found %s", address)
 }
 
+func rpkiTransportToApi(t oc.RpkiTransportType) api.AddRpkiRequest_Transport {
+	switch t {
+	case oc.RPKI_TRANSPORT_TYPE_TCP:
+		return api.AddRpkiRequest_TRANSPORT_TCP
+	case oc.RPKI_TRANSPORT_TYPE_TLS:
+		return api.AddRpkiRequest_TRANSPORT_TLS
+	case oc.RPKI_TRANSPORT_TYPE_SSH:
+		return api.AddRpkiRequest_TRANSPORT_SSH
+	}
+	return api.AddRpkiRequest_TRANSPORT_UNSPECIFIED
+}
+
 func (m *roaManager) ReceiveROA() chan *roaEvent {
 	return m.eventCh
 }
@@ -153,7 +223,19 @@ func (c *roaClient) lifetimeout() {
 	}
 }
 
+func (c *roaClient) refreshTimeout() {
+	c.eventCh <- &roaEvent{
+		EventType: roaRefresh,
+		Src:       c.host,
+		timestamp: time.Now(),
+	}
+}
+
 func (m *roaManager) HandleROAEvent(ev *roaEvent) {
+	if ev.EventType == roaFileReload {
+		m.handleFileEvent(ev)
+		return
+	}
 	client, y := m.clientMap[ev.Src]
 	if !y {
 		if ev.EventType == roaConnected {
@@ -172,19 +254,49 @@ func (m *roaManager) HandleROAEvent(ev *roaEvent) {
 		client.state.Downtime = time.Now().Unix()
 		// clear state
 		client.endOfData = false
+		client.updating = false
 		client.pendingROAs = make([]*table.ROA, 0)
+		client.pendingKeys = make([]*table.RouterKey, 0)
+		client.pendingASPAs = make([]*table.ASPA, 0)
 		client.state.RpkiMessages = oc.RpkiMessages{}
 		client.conn = nil
-		go client.tryConnect()
-		client.timer = time.AfterFunc(time.Duration(client.lifetime)*time.Second, client.lifetimeout)
+		client.stopRefresh()
+		// a new session negotiates the version again from the highest
+		// one unless it is the retry with the lower version.
+		if !client.downgrading {
+			client.version = client.maxVersion
+		}
+		client.downgrading = false
+		client.negotiated = false
+		go client.tryConnect(client.retry)
+		if client.timer != nil {
+			client.timer.Stop()
+		}
+		client.timer = time.AfterFunc(time.Duration(client.expire)*time.Second, client.lifetimeout)
 		client.oldSessionID = client.sessionID
 	case roaConnected:
 		m.logger.Info("ROA server is connected",
 			slog.String("Topic", "rpki"),
-			slog.Any("Key", ev.Src))
+			slog.Any("Key", ev.Src),
+			slog.Int("Version", int(client.version)))
 		client.conn = ev.conn
 		client.state.Uptime = time.Now().Unix()
-		go client.established()
+		if err := client.softReset(); err != nil {
+			m.logger.Error("Failed to send reset query",
+				slog.String("Topic", "rpki"),
+				slog.String("Host", client.host),
+				slog.String("Error", err.Error()))
+		}
+		go client.established(ev.conn)
+	case roaRefresh:
+		if client.conn != nil && client.endOfData {
+			if err := client.enable(client.serialNumber); err != nil {
+				m.logger.Error("Failed to send serial query",
+					slog.String("Topic", "rpki"),
+					slog.String("Host", client.host),
+					slog.String("Error", err.Error()))
+			}
+		}
 	case roaRTR:
 		m.handleRTRMsg(client, &client.state, ev.Data)
 	case roaLifetimeout:
@@ -205,14 +317,71 @@ func (m *roaManager) HandleROAEvent(ev *roaEvent) {
 				slog.String("Topic", "rpki"),
 				slog.String("Key", client.host),
 			)
-			m.table.DeleteAll(client.host)
+			m.deleteAll(client.host)
+		}
+	}
+}
+
+// checkVersion negotiates the protocol version (RFC 8210 section 7) with
+// the PDU and returns false if the PDU must not be processed.
+func (m *roaManager) checkVersion(client *roaClient, buf []byte) bool {
+	if len(buf) < rtr.RTR_MIN_LEN {
+		return true
+	}
+	version, typ := buf[0], buf[1]
+	if !client.negotiated && typ == rtr.RTR_ERROR_REPORT && binary.BigEndian.Uint16(buf[2:4]) == rtr.UNSUPPORTED_PROTOCOL_VERSION {
+		if client.version == rtr.RTR_PROTOCOL_VERSION_0 {
+			return true
+		}
+		// retry with the version of the cache if it is lower
+		if version < client.version {
+			client.version = version
+		} else {
+			client.version--
+		}
+		m.logger.Info("Downgrade the RTR protocol version",
+			slog.String("Topic", "rpki"),
+			slog.String("Host", client.host),
+			slog.Int("Version", int(client.version)))
+		client.downgrading = true
+		client.reset()
+		return false
+	}
+	switch {
+	case version == client.version:
+	case !client.negotiated && version < client.version:
+		m.logger.Info("Downgrade the RTR protocol version",
+			slog.String("Topic", "rpki"),
+			slog.String("Host", client.host),
+			slog.Int("Version", int(version)))
+		client.version = version
+	default:
+		m.logger.Warn("Unexpected RTR protocol version",
+			slog.String("Topic", "rpki"),
+			slog.String("Host", client.host),
+			slog.Int("Version", int(version)))
+		code := rtr.UNEXPECTED_PROTOCOL_VERSION
+		if !client.negotiated {
+			code = rtr.UNSUPPORTED_PROTOCOL_VERSION
 		}
+		client.sendErrorReport(code, buf, "unexpected protocol version")
+		client.reset()
+		return false
 	}
+	// a Serial Notify may be sent before the cache knows the version
+	if typ != rtr.RTR_SERIAL_NOTIFY {
+		client.negotiated = true
+	}
+	return true
 }
 
 func (m *roaManager) handleRTRMsg(client *roaClient, state *oc.RpkiServerState, buf []byte) {
 	received := &state.RpkiMessages.RpkiReceived
 
+	if !m.checkVersion(client, buf) {
+		return
+	}
+
 	m1, err := rtr.ParseRTR(buf)
 	if err == nil {
 		switch msg := m1.(type) {
@@ -243,6 +412,7 @@ func (m *roaManager) handleRTRMsg(client *roaClient, state *oc.RpkiServerState,
 		case *rtr.RTRCacheResponse:
 			received.CacheResponse++
 			client.endOfData = false
+			client.updating = true
 		case *rtr.RTRIPPrefix:
 			family := bgp.AFI_IP
 			if msg.Type == rtr.RTR_IPV4_PREFIX {
@@ -254,31 +424,81 @@ func (m *roaManager) handleRTRMsg(client *roaClient, state *oc.RpkiServerState,
 			roa := table.NewROA(family, msg.Prefix.AsSlice(), msg.PrefixLen, msg.MaxLen, msg.AS, client.host)
 			if msg.Flags&1 == 1 {
 				if client.endOfData {
-					m.table.Add(roa)
+					m.addROA(roa)
 				} else {
 					client.pendingROAs = append(client.pendingROAs, roa)
 				}
 			} else {
 				m.table.Delete(roa)
 			}
+		case *rtr.RTRRouterKey:
+			received.RouterKey++
+			pub, err := x509.ParsePKIXPublicKey(msg.SPKI)
+			key, ok := pub.(*ecdsa.PublicKey)
+			if err != nil || !ok {
+				m.logger.Warn("Ignore an unsupported router key",
+					slog.String("Topic", "rpki"),
+					slog.String("Host", client.host),
+					slog.Uint64("AS", uint64(msg.AS)))
+				break
+			}
+			rk := table.NewRouterKey(msg.AS, msg.SKI, key, client.host)
+			if msg.Flags&1 == 1 {
+				if client.endOfData {
+					m.addRouterKey(rk)
+				} else {
+					client.pendingKeys = append(client.pendingKeys, rk)
+				}
+			} else if !m.keys.Delete(rk) {
+				m.logger.Info("Can't withdraw a router key",
+					slog.String("Topic", "rpki"),
+					slog.Uint64("AS", uint64(msg.AS)))
+			}
+		case *rtr.RTRASPA:
+			received.Aspa++
+			if msg.Flags&1 == 1 {
+				aspa := table.NewASPA(msg.CustomerAS, msg.ProviderASs, client.host)
+				if client.endOfData {
+					m.addASPA(aspa)
+				} else {
+					client.pendingASPAs = append(client.pendingASPAs, aspa)
+				}
+			} else if !m.aspas.Delete(msg.CustomerAS, client.host) {
+				m.logger.Info("Can't withdraw an ASPA",
+					slog.String("Topic", "rpki"),
+					slog.Uint64("AS", uint64(msg.CustomerAS)))
+			}
 		case *rtr.RTREndOfData:
 			received.EndOfData++
 			if client.sessionID != msg.SessionID {
 				// remove all ROAs related with the
 				// previous session
-				m.table.DeleteAll(client.host)
+				m.deleteAll(client.host)
+			}
+			if msg.Version >= rtr.RTR_PROTOCOL_VERSION_1 {
+				client.setIntervals(msg.RefreshInterval, msg.RetryInterval, msg.ExpireInterval)
 			}
 			client.sessionID = msg.SessionID
 			client.serialNumber = msg.SerialNumber
 			client.endOfData = true
+			client.updating = false
 			if client.timer != nil {
 				client.timer.Stop()
 				client.timer = nil
 			}
 			for _, roa := range client.pendingROAs {
-				m.table.Add(roa)
+				m.addROA(roa)
 			}
 			client.pendingROAs = make([]*table.ROA, 0)
+			for _, key := range client.pendingKeys {
+				m.addRouterKey(key)
+			}
+			client.pendingKeys = make([]*table.RouterKey, 0)
+			for _, aspa := range client.pendingASPAs {
+				m.addASPA(aspa)
+			}
+			client.pendingASPAs = make([]*table.ASPA, 0)
+			client.startRefresh()
 		case *rtr.RTRCacheReset:
 			if err := client.softReset(); err != nil {
 				m.logger.Error("Failed to send soft reset",
@@ -289,6 +509,8 @@ func (m *roaManager) handleRTRMsg(client *roaClient, state *oc.RpkiServerState,
 			received.CacheReset++
 		case *rtr.RTRErrorReport:
 			received.Error++
+			// no data follows the query
+			client.updating = false
 		}
 	} else {
 		m.logger.Info("Failed to parse an RTR message",
@@ -302,6 +524,8 @@ func (m *roaManager) handleRTRMsg(client *roaClient, state *oc.RpkiServerState,
 func (m *roaManager) GetServers() []*oc.RpkiServer {
 	recordsV4, prefixesV4 := m.table.Info(bgp.RF_IPv4_UC)
 	recordsV6, prefixesV6 := m.table.Info(bgp.RF_IPv6_UC)
+	keys := m.keys.Info()
+	aspas := m.aspas.Info()
 
 	l := make([]*oc.RpkiServer, 0, len(m.clientMap))
 	for _, client := range m.clientMap {
@@ -322,7 +546,13 @@ func (m *roaManager) GetServers() []*oc.RpkiServer {
 		state.RecordsV6 = f(recordsV6, client.host)
 		state.PrefixesV4 = f(prefixesV4, client.host)
 		state.PrefixesV6 = f(prefixesV6, client.host)
+		state.RouterKeys = f(keys, client.host)
+		state.Aspas = f(aspas, client.host)
 		state.SerialNumber = client.serialNumber
+		state.ProtocolVersion = client.version
+		state.RefreshInterval = client.refresh
+		state.RetryInterval = client.retry
+		state.ExpireInterval = client.expire
 
 		addr, port, _ := net.SplitHostPort(client.host)
 		l = append(l, &oc.RpkiServer{
@@ -330,7 +560,8 @@ func (m *roaManager) GetServers() []*oc.RpkiServer {
 				Address: netip.MustParseAddr(addr),
 				// Note: RpkiServerConfig.Port is uint32 type, but the TCP/UDP
 				// port is 16-bit length.
-				Port: func() uint32 { p, _ := strconv.ParseUint(port, 10, 16); return uint32(p) }(),
+				Port:      func() uint32 { p, _ := strconv.ParseUint(port, 10, 16); return uint32(p) }(),
+				Transport: client.config.Transport,
 			},
 			State: client.state,
 		})
@@ -340,7 +571,10 @@ func (m *roaManager) GetServers() []*oc.RpkiServer {
 
 type roaClient struct {
 	host         string
-	conn         *net.TCPConn
+	config       *oc.RpkiServerConfig
+	tls          *tls.Config
+	ssh          *ssh.ClientConfig
+	conn         io.ReadWriteCloser
 	state        oc.RpkiServerState
 	eventCh      chan *roaEvent
 	sessionID    uint16
@@ -349,34 +583,99 @@ type roaClient struct {
 	timer        *time.Timer
 	lifetime     int64
 	endOfData    bool
+	updating     bool
 	pendingROAs  []*table.ROA
+	pendingKeys  []*table.RouterKey
+	pendingASPAs []*table.ASPA
 	cancelfnc    context.CancelFunc
 	ctx          context.Context
+
+	// the protocol version of the session and the highest one to
+	// negotiate
+	version     uint8
+	maxVersion  uint8
+	negotiated  bool
+	downgrading bool
+	// the timing parameters in seconds; the configured ones until the
+	// cache sends them (RFC 8210 section 6).
+	refresh      uint32
+	retry        uint32
+	expire       uint32
+	refreshTimer *time.Timer
 }
 
-func newRoaClient(address, port string, ch chan *roaEvent, lifetime int64) *roaClient {
+func newRoaClient(c *oc.RpkiServerConfig, tlsConfig *tls.Config, sshConfig *ssh.ClientConfig, ch chan *roaEvent, lifetime int64) *roaClient {
 	ctx, cancel := context.WithCancel(context.Background())
-	c := &roaClient{
-		host:        net.JoinHostPort(address, port),
-		eventCh:     ch,
-		lifetime:    lifetime,
-		pendingROAs: make([]*table.ROA, 0),
-		ctx:         ctx,
-		cancelfnc:   cancel,
+	maxVersion := rtr.RTR_MAX_PROTOCOL_VERSION
+	if v := c.MaxProtocolVersion.ToInt(); v >= 0 {
+		maxVersion = uint8(v)
+	}
+	client := &roaClient{
+		host:         net.JoinHostPort(c.Address.String(), strconv.Itoa(int(c.Port))),
+		config:       c,
+		tls:          tlsConfig,
+		ssh:          sshConfig,
+		eventCh:      ch,
+		lifetime:     lifetime,
+		pendingROAs:  make([]*table.ROA, 0),
+		pendingKeys:  make([]*table.RouterKey, 0),
+		pendingASPAs: make([]*table.ASPA, 0),
+		ctx:          ctx,
+		cancelfnc:    cancel,
+		version:      maxVersion,
+		maxVersion:   maxVersion,
+		refresh:      uint32(c.RefreshTime),
+		retry:        connectRetryInterval,
+		expire:       uint32(lifetime),
+	}
+	go client.tryConnect(client.retry)
+	return client
+}
+
+// setIntervals applies the timing parameters of End of Data; the ones out
+// of the ranges of RFC 8210 section 6 are ignored.
+func (c *roaClient) setIntervals(refresh, retry, expire uint32) {
+	if refresh >= 1 && refresh <= 86400 {
+		c.refresh = refresh
+	}
+	if retry >= 1 && retry <= 7200 {
+		c.retry = retry
 	}
-	go c.tryConnect()
-	return c
+	if expire >= 600 && expire <= 172800 && expire > c.refresh && expire > c.retry {
+		c.expire = expire
+	}
+}
+
+func (c *roaClient) startRefresh() {
+	c.stopRefresh()
+	if c.refresh > 0 {
+		c.refreshTimer = time.AfterFunc(time.Duration(c.refresh)*time.Second, c.refreshTimeout)
+	}
+}
+
+func (c *roaClient) stopRefresh() {
+	if c.refreshTimer != nil {
+		c.refreshTimer.Stop()
+		c.refreshTimer = nil
+	}
+}
+
+func (c *roaClient) write(m rtr.RTRMessage) error {
+	data, _ := m.Serialize()
+	_, err := c.conn.Write(data)
+	return err
 }
 
 func (c *roaClient) enable(serial uint32) error {
 	if c.conn != nil {
 		r := rtr.NewRTRSerialQuery(c.sessionID, serial)
-		data, _ := r.Serialize()
-		_, err := c.conn.Write(data)
-		if err != nil {
+		r.Version = c.version
+		if err := c.write(r); err != nil {
 			return err
 		}
 		c.state.RpkiMessages.RpkiSent.SerialQuery++
+		// the changes are processed once the cache sends all of them
+		c.updating = true
 	}
 	return nil
 }
@@ -384,18 +683,32 @@ func (c *roaClient) enable(serial uint32) error {
 func (c *roaClient) softReset() error {
 	if c.conn != nil {
 		r := rtr.NewRTRResetQuery()
-		data, _ := r.Serialize()
-		_, err := c.conn.Write(data)
-		if err != nil {
+		r.Version = c.version
+		if err := c.write(r); err != nil {
 			return err
 		}
 		c.state.RpkiMessages.RpkiSent.ResetQuery++
+		// the data deleted before the query comes back at End of Data
+		c.updating = true
 		c.endOfData = false
 		c.pendingROAs = make([]*table.ROA, 0)
+		c.pendingKeys = make([]*table.RouterKey, 0)
+		c.pendingASPAs = make([]*table.ASPA, 0)
 	}
 	return nil
 }
 
+func (c *roaClient) sendErrorReport(code uint16, pdu []byte, text string) {
+	if c.conn != nil {
+		r := rtr.NewRTRErrorReport(code, pdu, []byte(text))
+		if r == nil {
+			return
+		}
+		r.Version = c.version
+		_ = c.write(r)
+	}
+}
+
 func (c *roaClient) reset() {
 	if c.conn != nil {
 		c.conn.Close()
@@ -404,24 +717,43 @@ func (c *roaClient) reset() {
 
 func (c *roaClient) stop() {
 	c.cancelfnc()
+	c.stopRefresh()
+	if c.timer != nil {
+		c.timer.Stop()
+	}
 	c.reset()
 }
 
-func (c *roaClient) tryConnect() {
+func (c *roaClient) dial() (io.ReadWriteCloser, error) {
+	d := &net.Dialer{Timeout: connectRetryInterval * time.Second}
+	switch c.config.Transport {
+	case oc.RPKI_TRANSPORT_TYPE_TLS:
+		td := &tls.Dialer{NetDialer: d, Config: c.tls}
+		return td.DialContext(c.ctx, "tcp", c.host)
+	case oc.RPKI_TRANSPORT_TYPE_SSH:
+		return dialRTRSSH(c.ctx, d, c.host, c.ssh)
+	}
+	return d.DialContext(c.ctx, "tcp", c.host)
+}
+
+func (c *roaClient) tryConnect(retry uint32) {
 	for {
 		select {
 		case <-c.ctx.Done():
 			return
 		default:
 		}
-		if conn, err := net.Dial("tcp", c.host); err != nil {
-			// better to use context with timeout
-			time.Sleep(connectRetryInterval * time.Second)
+		if conn, err := c.dial(); err != nil {
+			select {
+			case <-c.ctx.Done():
+				return
+			case <-time.After(time.Duration(retry) * time.Second):
+			}
 		} else {
 			c.eventCh <- &roaEvent{
 				EventType: roaConnected,
 				Src:       c.host,
-				conn:      conn.(*net.TCPConn),
+				conn:      conn,
 				timestamp: time.Now(),
 			}
 			return
@@ -429,9 +761,9 @@ func (c *roaClient) tryConnect() {
 	}
 }
 
-func (c *roaClient) established() (err error) {
+func (c *roaClient) established(conn io.ReadWriteCloser) (err error) {
 	defer func() {
-		c.conn.Close()
+		conn.Close()
 		c.eventCh <- &roaEvent{
 			EventType: roaDisconnected,
 			Src:       c.host,
@@ -439,13 +771,9 @@ func (c *roaClient) established() (err error) {
 		}
 	}()
 
-	if err := c.softReset(); err != nil {
-		return err
-	}
-
 	for {
 		header := make([]byte, rtr.RTR_MIN_LEN)
-		if _, err = io.ReadFull(c.conn, header); err != nil {
+		if _, err = io.ReadFull(conn, header); err != nil {
 			return err
 		}
 		totalLen := binary.BigEndian.Uint32(header[4:8])
@@ -454,7 +782,7 @@ func (c *roaClient) established() (err error) {
 		}
 
 		body := make([]byte, totalLen-rtr.RTR_MIN_LEN)
-		if _, err = io.ReadFull(c.conn, body); err != nil {
+		if _, err = io.ReadFull(conn, body); err != nil {
 			return err
 		}
 
@@ -466,3 +794,109 @@ func (c *roaClient) established() (err error) {
 		}
 	}
 }
+
+// rtrSSHConn is the RTR session over the rpki-rtr SSH subsystem (RFC 6810
+// section 7.1).
+type rtrSSHConn struct {
+	client  *ssh.Client
+	session *ssh.Session
+	io.Reader
+	io.WriteCloser
+}
+
+// newRTRSSHConfig returns the SSH client configuration authenticating with
+// the private key and verifying the host key with the known hosts file,
+// the ones of the user running gobgpd if not given.
+func newRTRSSHConfig(c *oc.RpkiServerConfig) (*ssh.ClientConfig, error) {
+	name := c.SshUsername
+	keyFile := c.SshPrivateKeyFile
+	knownHostsFile := c.SshKnownHostsFile
+	if name == "" || keyFile == "" || knownHostsFile == "" {
+		u, err := user.Current()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the current user: %w", err)
+		}
+		if name == "" {
+			name = u.Username
+		}
+		if keyFile == "" {
+			keyFile = filepath.Join(u.HomeDir, ".ssh", "id_ed25519")
+		}
+		if knownHostsFile == "" {
+			knownHostsFile = filepath.Join(u.HomeDir, ".ssh", "known_hosts")
+		}
+	}
+	pem, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ssh private key file: %w", err)
+	}
+	signer, err := ssh.ParsePrivateKey(pem)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ssh private key file %s: %w", keyFile, err)
+	}
+	hostKeyCallback, err := knownhosts.New(knownHostsFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ssh known hosts file: %w", err)
+	}
+	return &ssh.ClientConfig{
+		User:            name,
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
+		HostKeyCallback: hostKeyCallback,
+	}, nil
+}
+
+// dialRTRSSH opens the session once the server is authenticated and the
+// subsystem is started.
+func dialRTRSSH(ctx context.Context, d *net.Dialer, host string, config *ssh.ClientConfig) (io.ReadWriteCloser, error) {
+	conn, err := d.DialContext(ctx, "tcp", host)
+	if err != nil {
+		return nil, err
+	}
+	// the handshake doesn't take the context
+	stop := context.AfterFunc(ctx, func() {
+		conn.Close()
+	})
+	defer stop()
+	if err := conn.SetDeadline(time.Now().Add(d.Timeout)); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	sc, chans, reqs, err := ssh.NewClientConn(conn, host, config)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	client := ssh.NewClient(sc, chans, reqs)
+	rc, err := func() (*rtrSSHConn, error) {
+		session, err := client.NewSession()
+		if err != nil {
+			return nil, err
+		}
+		w, err := session.StdinPipe()
+		if err != nil {
+			return nil, err
+		}
+		r, err := session.StdoutPipe()
+		if err != nil {
+			return nil, err
+		}
+		if err := session.RequestSubsystem(rtr.RPKI_SSH_SUBSYSTEM); err != nil {
+			return nil, err
+		}
+		return &rtrSSHConn{client: client, session: session, Reader: r, WriteCloser: w}, nil
+	}()
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		rc.Close()
+		return nil, err
+	}
+	return rc, nil
+}
+
+func (c *rtrSSHConn) Close() error {
+	c.session.Close()
+	return c.client.Close()
+}
@@ -0,0 +1,571 @@
+// Copyright (C) 2025 Acnodal Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/osrg/gobgp/v4/api"
+	"github.com/osrg/gobgp/v4/internal/pkg/table"
+	"github.com/osrg/gobgp/v4/pkg/config/oc"
+	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
+)
+
+// rpkiFile is a local file of RPKI data, loaded without an RPKI server. The
+// path is the source of the data in the tables.
+type rpkiFile struct {
+	config oc.RpkiFileConfig
+	state  oc.RpkiFileState
+	// the data of the last successful load
+	data *rpkiFileData
+}
+
+type rpkiFileData struct {
+	roas  []*table.ROA
+	keys  []*table.RouterKey
+	aspas []*table.ASPA
+
+	// the SLURM filters (RFC 8416 section 3.3)
+	prefixFilters []slurmPrefixFilter
+	keyFilters    []slurmKeyFilter
+	aspaFilters   []uint32
+}
+
+func (d *rpkiFileData) filters() int {
+	return len(d.prefixFilters) + len(d.keyFilters) + len(d.aspaFilters)
+}
+
+type slurmPrefixFilter struct {
+	// invalid if the filter has no prefix
+	prefix netip.Prefix
+	asn    *uint32
+}
+
+func (f *slurmPrefixFilter) match(roa *table.ROA) bool {
+	if f.asn != nil && *f.asn != roa.AS {
+		return false
+	}
+	if f.prefix.IsValid() {
+		addr, _ := netip.AddrFromSlice(roa.Network.IP)
+		ones, _ := roa.Network.Mask.Size()
+		if addr.Unmap().Is4() != f.prefix.Addr().Is4() || ones < f.prefix.Bits() || !f.prefix.Contains(addr.Unmap()) {
+			return false
+		}
+	}
+	return true
+}
+
+type slurmKeyFilter struct {
+	asn *uint32
+	ski *[bgp.BGPSEC_SKI_LEN]byte
+}
+
+func (f *slurmKeyFilter) match(key *table.RouterKey) bool {
+	return (f.asn == nil || *f.asn == key.AS) && (f.ski == nil || *f.ski == key.SKI)
+}
+
+// rpkiASN is the AS number in the JSON files, either a number or a string
+// like "AS65001".
+type rpkiASN uint32
+
+func (a *rpkiASN) UnmarshalJSON(b []byte) error {
+	s := strings.Trim(string(b), `"`)
+	s = strings.TrimPrefix(strings.ToUpper(s), "AS")
+	n, err := strconv.ParseUint(s, 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid AS number %s", string(b))
+	}
+	*a = rpkiASN(n)
+	return nil
+}
+
+func newFileROA(prefix string, maxLen uint8, as uint32, src string) (*table.ROA, error) {
+	p, err := netip.ParsePrefix(prefix)
+	if err != nil {
+		return nil, err
+	}
+	family := bgp.AFI_IP
+	if p.Addr().Is6() {
+		family = bgp.AFI_IP6
+	}
+	if maxLen == 0 {
+		maxLen = uint8(p.Bits())
+	}
+	if int(maxLen) < p.Bits() || int(maxLen) > p.Addr().BitLen() {
+		return nil, fmt.Errorf("invalid max length %d of %s", maxLen, prefix)
+	}
+	return table.NewROA(family, p.Masked().Addr().AsSlice(), uint8(p.Bits()), maxLen, as, src), nil
+}
+
+func newFileRouterKey(as uint32, ski, spki []byte, src string) (*table.RouterKey, error) {
+	if len(ski) != bgp.BGPSEC_SKI_LEN {
+		return nil, fmt.Errorf("invalid SKI length %d", len(ski))
+	}
+	pub, err := x509.ParsePKIXPublicKey(spki)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := pub.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("unsupported router key of AS %d", as)
+	}
+	return table.NewRouterKey(as, [bgp.BGPSEC_SKI_LEN]byte(ski), key, src), nil
+}
+
+// parseVRPFile parses the JSON export of rpki-client or Routinator.
+func parseVRPFile(b []byte, src string) (*rpkiFileData, error) {
+	var f struct {
+		Roas []struct {
+			Asn       rpkiASN `json:"asn"`
+			Prefix    string  `json:"prefix"`
+			MaxLength uint8   `json:"maxLength"`
+		} `json:"roas"`
+		BgpsecKeys []struct {
+			Asn    rpkiASN `json:"asn"`
+			Ski    string  `json:"ski"`
+			Pubkey string  `json:"pubkey"`
+		} `json:"bgpsec_keys"`
+		Aspas []struct {
+			CustomerAsid *rpkiASN  `json:"customer_asid"`
+			Customer     *rpkiASN  `json:"customer"`
+			Providers    []rpkiASN `json:"providers"`
+		} `json:"aspas"`
+	}
+	if err := json.Unmarshal(b, &f); err != nil {
+		return nil, err
+	}
+	d := &rpkiFileData{}
+	for _, r := range f.Roas {
+		roa, err := newFileROA(r.Prefix, r.MaxLength, uint32(r.Asn), src)
+		if err != nil {
+			return nil, err
+		}
+		d.roas = append(d.roas, roa)
+	}
+	for _, k := range f.BgpsecKeys {
+		ski, err := hex.DecodeString(k.Ski)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SKI %s: %w", k.Ski, err)
+		}
+		spki, err := base64.StdEncoding.DecodeString(k.Pubkey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid public key of AS %d: %w", k.Asn, err)
+		}
+		key, err := newFileRouterKey(uint32(k.Asn), ski, spki, src)
+		if err != nil {
+			return nil, err
+		}
+		d.keys = append(d.keys, key)
+	}
+	for _, a := range f.Aspas {
+		customer := a.CustomerAsid
+		if customer == nil {
+			customer = a.Customer
+		}
+		if customer == nil {
+			return nil, fmt.Errorf("ASPA without the customer")
+		}
+		providers := make([]uint32, 0, len(a.Providers))
+		for _, p := range a.Providers {
+			providers = append(providers, uint32(p))
+		}
+		d.aspas = append(d.aspas, table.NewASPA(uint32(*customer), providers, src))
+	}
+	return d, nil
+}
+
+// decodeSLURMBase64 decodes the base64url encoding without padding used
+// in SLURM (RFC 8416 section 3.3.2).
+func decodeSLURMBase64(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+}
+
+// parseSLURMFile parses the SLURM file (RFC 8416) including the ASPA
+// members of version 2.
+func parseSLURMFile(b []byte, src string) (*rpkiFileData, error) {
+	var f struct {
+		SlurmVersion            int `json:"slurmVersion"`
+		ValidationOutputFilters struct {
+			PrefixFilters []struct {
+				Prefix string  `json:"prefix"`
+				Asn    *uint32 `json:"asn"`
+			} `json:"prefixFilters"`
+			BgpsecFilters []struct {
+				Asn *uint32 `json:"asn"`
+				SKI string  `json:"SKI"`
+			} `json:"bgpsecFilters"`
+			AspaFilters []struct {
+				CustomerAsid uint32 `json:"customerAsid"`
+			} `json:"aspaFilters"`
+		} `json:"validationOutputFilters"`
+		LocallyAddedAssertions struct {
+			PrefixAssertions []struct {
+				Prefix          string `json:"prefix"`
+				Asn             uint32 `json:"asn"`
+				MaxPrefixLength uint8  `json:"maxPrefixLength"`
+			} `json:"prefixAssertions"`
+			BgpsecAssertions []struct {
+				Asn             uint32 `json:"asn"`
+				SKI             string `json:"SKI"`
+				RouterPublicKey string `json:"routerPublicKey"`
+			} `json:"bgpsecAssertions"`
+			AspaAssertions []struct {
+				CustomerAsid uint32   `json:"customerAsid"`
+				ProviderSet  []uint32 `json:"providerSet"`
+			} `json:"aspaAssertions"`
+		} `json:"locallyAddedAssertions"`
+	}
+	if err := json.Unmarshal(b, &f); err != nil {
+		return nil, err
+	}
+	if f.SlurmVersion != 1 && f.SlurmVersion != 2 {
+		return nil, fmt.Errorf("unsupported SLURM version %d", f.SlurmVersion)
+	}
+	d := &rpkiFileData{}
+	for _, p := range f.ValidationOutputFilters.PrefixFilters {
+		filter := slurmPrefixFilter{asn: p.Asn}
+		if p.Prefix != "" {
+			prefix, err := netip.ParsePrefix(p.Prefix)
+			if err != nil {
+				return nil, err
+			}
+			filter.prefix = prefix.Masked()
+		} else if p.Asn == nil {
+			return nil, fmt.Errorf("prefix filter without prefix and asn")
+		}
+		d.prefixFilters = append(d.prefixFilters, filter)
+	}
+	for _, k := range f.ValidationOutputFilters.BgpsecFilters {
+		filter := slurmKeyFilter{asn: k.Asn}
+		if k.SKI != "" {
+			ski, err := decodeSLURMBase64(k.SKI)
+			if err != nil || len(ski) != bgp.BGPSEC_SKI_LEN {
+				return nil, fmt.Errorf("invalid SKI %s", k.SKI)
+			}
+			filter.ski = (*[bgp.BGPSEC_SKI_LEN]byte)(ski)
+		} else if k.Asn == nil {
+			return nil, fmt.Errorf("bgpsec filter without asn and SKI")
+		}
+		d.keyFilters = append(d.keyFilters, filter)
+	}
+	for _, a := range f.ValidationOutputFilters.AspaFilters {
+		d.aspaFilters = append(d.aspaFilters, a.CustomerAsid)
+	}
+	for _, p := range f.LocallyAddedAssertions.PrefixAssertions {
+		roa, err := newFileROA(p.Prefix, p.MaxPrefixLength, p.Asn, src)
+		if err != nil {
+			return nil, err
+		}
+		d.roas = append(d.roas, roa)
+	}
+	for _, k := range f.LocallyAddedAssertions.BgpsecAssertions {
+		ski, err := decodeSLURMBase64(k.SKI)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SKI %s", k.SKI)
+		}
+		spki, err := decodeSLURMBase64(k.RouterPublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid public key of AS %d: %w", k.Asn, err)
+		}
+		key, err := newFileRouterKey(k.Asn, ski, spki, src)
+		if err != nil {
+			return nil, err
+		}
+		d.keys = append(d.keys, key)
+	}
+	for _, a := range f.LocallyAddedAssertions.AspaAssertions {
+		d.aspas = append(d.aspas, table.NewASPA(a.CustomerAsid, a.ProviderSet, src))
+	}
+	return d, nil
+}
+
+// filterROA reports whether the ROA is dropped by the SLURM filters.
+func (m *roaManager) filterROA(roa *table.ROA) bool {
+	for _, f := range m.files {
+		if f.data == nil {
+			continue
+		}
+		for i := range f.data.prefixFilters {
+			if f.data.prefixFilters[i].match(roa) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (m *roaManager) filterRouterKey(key *table.RouterKey) bool {
+	for _, f := range m.files {
+		if f.data == nil {
+			continue
+		}
+		for i := range f.data.keyFilters {
+			if f.data.keyFilters[i].match(key) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (m *roaManager) filterASPA(aspa *table.ASPA) bool {
+	for _, f := range m.files {
+		if f.data == nil {
+			continue
+		}
+		for _, customer := range f.data.aspaFilters {
+			if customer == aspa.CustomerAS {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (m *roaManager) addROA(roa *table.ROA) {
+	if !m.filterROA(roa) {
+		m.table.Add(roa)
+	}
+}
+
+func (m *roaManager) addRouterKey(key *table.RouterKey) {
+	if !m.filterRouterKey(key) {
+		m.keys.Add(key)
+	}
+}
+
+func (m *roaManager) addASPA(aspa *table.ASPA) {
+	if !m.filterASPA(aspa) {
+		m.aspas.Add(aspa)
+	}
+}
+
+// applyFile replaces the data of the file in the tables. The SLURM
+// assertions are added after the filters so they are never filtered.
+func (m *roaManager) applyFile(f *rpkiFile) {
+	m.deleteAll(f.config.Path)
+	if f.data == nil {
+		return
+	}
+	slurm := f.config.Format == oc.RPKI_FILE_FORMAT_TYPE_SLURM
+	for _, roa := range f.data.roas {
+		if slurm {
+			m.table.Add(roa)
+		} else {
+			m.addROA(roa)
+		}
+	}
+	for _, key := range f.data.keys {
+		if slurm {
+			m.keys.Add(key)
+		} else {
+			m.addRouterKey(key)
+		}
+	}
+	for _, aspa := range f.data.aspas {
+		if slurm {
+			m.aspas.Add(aspa)
+		} else {
+			m.addASPA(aspa)
+		}
+	}
+}
+
+// applyFilters applies the changed SLURM filters to the data of all the
+// sources; the RPKI servers are asked for all the data again.
+func (m *roaManager) applyFilters() {
+	for _, f := range m.files {
+		m.applyFile(f)
+	}
+	for host, client := range m.clientMap {
+		if client.conn == nil {
+			continue
+		}
+		m.deleteAll(host)
+		if err := client.softReset(); err != nil {
+			m.logger.Error("Failed to send reset query",
+				slog.String("Topic", "rpki"),
+				slog.String("Host", host),
+				slog.String("Error", err.Error()))
+		}
+	}
+}
+
+// loadFile reads the file and replaces the data from it. On failure the
+// data of the last successful load is kept.
+func (m *roaManager) loadFile(f *rpkiFile) error {
+	err := func() error {
+		b, err := os.ReadFile(f.config.Path)
+		if err != nil {
+			return err
+		}
+		var data *rpkiFileData
+		switch f.config.Format {
+		case oc.RPKI_FILE_FORMAT_TYPE_SLURM:
+			data, err = parseSLURMFile(b, f.config.Path)
+		default:
+			data, err = parseVRPFile(b, f.config.Path)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", f.config.Path, err)
+		}
+		old := f.data
+		f.data = data
+		if f.config.Format == oc.RPKI_FILE_FORMAT_TYPE_SLURM && ((old != nil && old.filters() != 0) || data.filters() != 0) {
+			m.applyFilters()
+		} else {
+			m.applyFile(f)
+		}
+		return nil
+	}()
+	if err != nil {
+		f.state.Error = err.Error()
+		m.logger.Warn("Failed to load RPKI file",
+			slog.String("Topic", "rpki"),
+			slog.String("File", f.config.Path),
+			slog.String("Error", err.Error()))
+		return err
+	}
+	f.state.Error = ""
+	f.state.Loaded = time.Now().Unix()
+	f.state.Loads++
+	m.logger.Info("Loaded RPKI file",
+		slog.String("Topic", "rpki"),
+		slog.String("File", f.config.Path),
+		slog.Int("ROAs", len(f.data.roas)),
+		slog.Int("RouterKeys", len(f.data.keys)),
+		slog.Int("ASPAs", len(f.data.aspas)),
+		slog.Int("Filters", f.data.filters()))
+	return nil
+}
+
+// AddFile loads the file and watches the directory of it, which works with
+// the files replaced by renaming too.
+func (m *roaManager) AddFile(c oc.RpkiFileConfig) error {
+	path, err := filepath.Abs(c.Path)
+	if err != nil {
+		return err
+	}
+	c.Path = path
+	if _, ok := m.files[path]; ok {
+		return fmt.Errorf("rpki file exists %s", path)
+	}
+	if err := m.watcher.add(path); err != nil {
+		return err
+	}
+	f := &rpkiFile{config: c}
+	m.files[path] = f
+	if err := m.loadFile(f); err != nil {
+		_ = m.DeleteFile(path)
+		return err
+	}
+	return nil
+}
+
+func (m *roaManager) DeleteFile(path string) error {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	f, ok := m.files[path]
+	if !ok {
+		return fmt.Errorf("rpki file doesn't exist %s", path)
+	}
+	delete(m.files, path)
+	m.watcher.remove(path)
+	if f.config.Format == oc.RPKI_FILE_FORMAT_TYPE_SLURM && f.data != nil && f.data.filters() != 0 {
+		m.deleteAll(path)
+		m.applyFilters()
+	} else {
+		m.deleteAll(path)
+	}
+	return nil
+}
+
+// ReloadFiles loads the file again, or all the files if the path is empty.
+func (m *roaManager) ReloadFiles(path string) error {
+	if path == "" {
+		var errs []string
+		for _, f := range m.files {
+			if err := m.loadFile(f); err != nil {
+				errs = append(errs, err.Error())
+			}
+		}
+		if len(errs) > 0 {
+			return fmt.Errorf("%s", strings.Join(errs, "; "))
+		}
+		return nil
+	}
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	f, ok := m.files[path]
+	if !ok {
+		return fmt.Errorf("rpki file doesn't exist %s", path)
+	}
+	return m.loadFile(f)
+}
+
+func (m *roaManager) handleFileEvent(ev *roaEvent) {
+	f, ok := m.files[ev.Src]
+	if !ok {
+		return
+	}
+	_ = m.loadFile(f)
+}
+
+func (m *roaManager) GetFiles() []*oc.RpkiFile {
+	records4, _ := m.table.Info(bgp.RF_IPv4_UC)
+	records6, _ := m.table.Info(bgp.RF_IPv6_UC)
+	keys := m.keys.Info()
+	aspas := m.aspas.Info()
+	l := make([]*oc.RpkiFile, 0, len(m.files))
+	for path, f := range m.files {
+		state := f.state
+		state.RecordsV4 = records4[path]
+		state.RecordsV6 = records6[path]
+		state.RouterKeys = keys[path]
+		state.Aspas = aspas[path]
+		if f.data != nil {
+			state.Filters = uint32(f.data.filters())
+		}
+		l = append(l, &oc.RpkiFile{Config: f.config, State: state})
+	}
+	return l
+}
+
+func rpkiFileFormatToApi(f oc.RpkiFileFormatType) api.AddRpkiFileRequest_Format {
+	switch f {
+	case oc.RPKI_FILE_FORMAT_TYPE_JSON_VRP:
+		return api.AddRpkiFileRequest_FORMAT_JSON_VRP
+	case oc.RPKI_FILE_FORMAT_TYPE_SLURM:
+		return api.AddRpkiFileRequest_FORMAT_SLURM
+	}
+	return api.AddRpkiFileRequest_FORMAT_UNSPECIFIED
+}
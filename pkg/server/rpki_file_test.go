@@ -0,0 +1,251 @@
+// Copyright (C) 2025 Acnodal Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/osrg/gobgp/v4/api"
+	"github.com/osrg/gobgp/v4/internal/pkg/table"
+	"github.com/osrg/gobgp/v4/pkg/apiutil"
+	"github.com/osrg/gobgp/v4/pkg/config/oc"
+	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
+)
+
+func TestParseVRPFile(t *testing.T) {
+	// rpki-client uses the numbers and Routinator the strings
+	d, err := parseVRPFile([]byte(`{
+  "metadata": {"buildtime": "2026-01-01T00:00:00Z"},
+  "roas": [
+    {"asn": 65001, "prefix": "10.0.0.0/8", "maxLength": 24, "ta": "test"},
+    {"asn": "AS65002", "prefix": "2001:db8::/32", "maxLength": 48, "ta": "test"}
+  ],
+  "aspas": [
+    {"customer_asid": 65001, "providers": [65003, 65002]}
+  ]
+}`), "/vrps.json")
+	require.NoError(t, err)
+	require.Len(t, d.roas, 2)
+	assert.Equal(t, uint32(65001), d.roas[0].AS)
+	assert.Equal(t, "10.0.0.0/8", d.roas[0].Network.String())
+	assert.Equal(t, uint8(24), d.roas[0].MaxLen)
+	assert.Equal(t, uint32(65002), d.roas[1].AS)
+	assert.Equal(t, "/vrps.json", d.roas[1].Src)
+	require.Len(t, d.aspas, 1)
+	assert.ElementsMatch(t, []uint32{65002, 65003}, d.aspas[0].ProviderASs)
+
+	_, err = parseVRPFile([]byte(`{"roas": [{"asn": 1, "prefix": "10.0.0.0/8", "maxLength": 4}]}`), "/vrps.json")
+	assert.Error(t, err)
+	_, err = parseVRPFile([]byte(`{"roas": [{"asn": "ASx", "prefix": "10.0.0.0/8"}]}`), "/vrps.json")
+	assert.Error(t, err)
+}
+
+func TestParseSLURMFile(t *testing.T) {
+	d, err := parseSLURMFile([]byte(`{
+  "slurmVersion": 1,
+  "validationOutputFilters": {
+    "prefixFilters": [
+      {"prefix": "10.1.0.0/16", "comment": "all the origins"},
+      {"asn": 65009},
+      {"prefix": "10.2.0.0/16", "asn": 65001}
+    ],
+    "bgpsecFilters": []
+  },
+  "locallyAddedAssertions": {
+    "prefixAssertions": [
+      {"asn": 65005, "prefix": "192.0.2.0/24", "maxPrefixLength": 24}
+    ],
+    "bgpsecAssertions": []
+  }
+}`), "/slurm.json")
+	require.NoError(t, err)
+	assert.Equal(t, 3, d.filters())
+	require.Len(t, d.roas, 1)
+
+	roa := func(prefix string, as uint32) bool {
+		r, err := newFileROA(prefix, 0, as, "")
+		require.NoError(t, err)
+		return slices.ContainsFunc(d.prefixFilters, func(f slurmPrefixFilter) bool { return f.match(r) })
+	}
+	assert.True(t, roa("10.1.0.0/16", 65001))
+	assert.True(t, roa("10.1.2.0/24", 65002))
+	assert.False(t, roa("10.0.0.0/8", 65001))
+	assert.True(t, roa("172.16.0.0/12", 65009))
+	assert.True(t, roa("10.2.3.0/24", 65001))
+	assert.False(t, roa("10.2.3.0/24", 65002))
+	assert.False(t, roa("2001:db8::/32", 65001))
+
+	_, err = parseSLURMFile([]byte(`{"slurmVersion": 3}`), "/slurm.json")
+	assert.Error(t, err)
+}
+
+func TestRpkiFile(t *testing.T) {
+	ctx := context.Background()
+	s := runNewServer(t, 1, "1.1.1.1", 10179)
+	defer s.StopBgp(ctx, &api.StopBgpRequest{})
+
+	dir := t.TempDir()
+	vrps := filepath.Join(dir, "vrps.json")
+	slurm := filepath.Join(dir, "slurm.json")
+	writeVRPs := func(roas ...string) {
+		// write and rename like the exporters do
+		tmp := vrps + ".tmp"
+		require.NoError(t, os.WriteFile(tmp, fmt.Appendf(nil, `{"roas": [%s]}`, strings.Join(roas, ",")), 0o600))
+		require.NoError(t, os.Rename(tmp, vrps))
+	}
+	writeVRPs(`{"asn": 65001, "prefix": "10.0.0.0/8", "maxLength": 24}`,
+		`{"asn": 65002, "prefix": "10.1.0.0/16", "maxLength": 16}`)
+	require.NoError(t, os.WriteFile(slurm, []byte(`{
+  "slurmVersion": 1,
+  "validationOutputFilters": {"prefixFilters": [{"prefix": "10.1.0.0/16"}]},
+  "locallyAddedAssertions": {"prefixAssertions": [{"asn": 65005, "prefix": "192.0.2.0/24"}]}
+}`), 0o600))
+
+	table := func() map[string]string {
+		m := map[string]string{}
+		require.NoError(t, s.ListRpkiTable(ctx, &api.ListRpkiTableRequest{}, func(r *api.Roa) {
+			m[fmt.Sprintf("%s/%d", r.Prefix, r.Prefixlen)] = r.File
+		}))
+		return m
+	}
+
+	require.NoError(t, s.AddRpkiFile(ctx, &api.AddRpkiFileRequest{Path: vrps}))
+	assert.Equal(t, map[string]string{"10.0.0.0/8": vrps, "10.1.0.0/16": vrps}, table())
+
+	// the filter drops the ROA of the other file and the assertion is added
+	require.NoError(t, s.AddRpkiFile(ctx, &api.AddRpkiFileRequest{Path: slurm, Format: api.AddRpkiFileRequest_FORMAT_SLURM}))
+	assert.Equal(t, map[string]string{"10.0.0.0/8": vrps, "192.0.2.0/24": slurm}, table())
+
+	// reloaded on change
+	writeVRPs(`{"asn": 65001, "prefix": "10.0.0.0/8", "maxLength": 24}`,
+		`{"asn": 65003, "prefix": "172.16.0.0/12", "maxLength": 24}`)
+	assert.Eventually(t, func() bool {
+		_, ok := table()["172.16.0.0/12"]
+		return ok
+	}, 5*time.Second, 50*time.Millisecond)
+
+	// the broken file keeps the last data
+	require.NoError(t, os.WriteFile(vrps, []byte(`{"roas": [`), 0o600))
+	assert.Error(t, s.ReloadRpkiFile(ctx, &api.ReloadRpkiFileRequest{Path: vrps}))
+	var files []*api.RpkiFile
+	require.NoError(t, s.ListRpkiFile(ctx, &api.ListRpkiFileRequest{}, func(f *api.RpkiFile) {
+		files = append(files, f)
+	}))
+	require.Len(t, files, 2)
+	assert.Equal(t, slurm, files[0].Path)
+	assert.Equal(t, uint32(1), files[0].Filters)
+	assert.Equal(t, vrps, files[1].Path)
+	assert.Equal(t, uint32(2), files[1].RecordIpv4)
+	assert.NotEmpty(t, files[1].Error)
+
+	// deleting the SLURM file removes the filters
+	writeVRPs(`{"asn": 65002, "prefix": "10.1.0.0/16", "maxLength": 16}`)
+	require.NoError(t, s.ReloadRpkiFile(ctx, &api.ReloadRpkiFileRequest{}))
+	require.NoError(t, s.DeleteRpkiFile(ctx, &api.DeleteRpkiFileRequest{Path: slurm}))
+	assert.Equal(t, map[string]string{"10.1.0.0/16": vrps}, table())
+	require.NoError(t, s.DeleteRpkiFile(ctx, &api.DeleteRpkiFileRequest{Path: vrps}))
+	assert.Empty(t, table())
+	assert.Error(t, s.AddRpkiFile(ctx, &api.AddRpkiFileRequest{Path: filepath.Join(dir, "none.json")}))
+}
+
+func TestRpkiValidationChange(t *testing.T) {
+	ctx := context.Background()
+	s1 := runNewServer(t, 1, "1.1.1.1", 10179)
+	defer s1.StopBgp(ctx, &api.StopBgpRequest{})
+	s2 := runNewServer(t, 2, "2.2.2.2", 20179)
+	defer s2.StopBgp(ctx, &api.StopBgpRequest{})
+
+	// s1 rejects the invalid paths
+	require.NoError(t, s1.AddPolicy(ctx, &api.AddPolicyRequest{Policy: &api.Policy{
+		Name: "rpki",
+		Statements: []*api.Statement{{
+			Name:       "invalid",
+			Conditions: &api.Conditions{RpkiResult: api.ValidationState_VALIDATION_STATE_INVALID},
+			Actions:    &api.Actions{RouteAction: api.RouteAction_ROUTE_ACTION_REJECT},
+		}},
+	}}))
+	require.NoError(t, s1.AddPolicyAssignment(ctx, &api.AddPolicyAssignmentRequest{Assignment: &api.PolicyAssignment{
+		Name:          table.GLOBAL_RIB_NAME,
+		Direction:     api.PolicyDirection_POLICY_DIRECTION_IMPORT,
+		Policies:      []*api.Policy{{Name: "rpki"}},
+		DefaultAction: api.RouteAction_ROUTE_ACTION_ACCEPT,
+	}}))
+
+	vrps := filepath.Join(t.TempDir(), "vrps.json")
+	writeVRPs := func(as uint32) {
+		require.NoError(t, os.WriteFile(vrps, fmt.Appendf(nil, `{"roas": [{"asn": %d, "prefix": "10.0.0.0/8", "maxLength": 24}]}`, as), 0o600))
+	}
+	writeVRPs(2)
+	require.NoError(t, s1.AddRpkiFile(ctx, &api.AddRpkiFileRequest{Path: vrps}))
+
+	type change struct {
+		prefix   string
+		old, new api.ValidationState
+	}
+	ch := make(chan change, 8)
+	wctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	require.NoError(t, s1.WatchEvent(wctx, WatchEventMessageCallbacks{
+		OnValidation: func(l []*apiutil.WatchEventMessage_ValidationEvent, _ time.Time) {
+			for _, v := range l {
+				ch <- change{v.Path.Nlri.String(), v.OldValidation.State, v.Path.Validation.State}
+			}
+		},
+	}, WatchValidation()))
+
+	established := waitEstablished(s1, bgp.RF_IPv4_UC)
+	require.NoError(t, peerServers(t, ctx, []*BgpServer{s1, s2}, []oc.AfiSafiType{oc.AFI_SAFI_TYPE_IPV4_UNICAST}))
+	established.Wait()
+
+	prefix, _ := bgp.NewIPAddrPrefix(netip.MustParsePrefix("10.1.0.0/24"))
+	nh, _ := bgp.NewPathAttributeNextHop(netip.MustParseAddr("10.0.0.1"))
+	path, _ := apiutil.NewPath(bgp.RF_IPv4_UC, prefix, false, []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(0),
+		nh,
+	}, time.Now())
+	_, err := s2.AddPath(apiutil.AddPathRequest{Paths: []*apiutil.Path{mustApi2apiutilPath(path)}})
+	require.NoError(t, err)
+
+	global := func() int {
+		n := 0
+		require.NoError(t, s1.ListPath(apiutil.ListPathRequest{TableType: api.TableType_TABLE_TYPE_GLOBAL, Family: bgp.RF_IPv4_UC}, func(_ bgp.NLRI, paths []*apiutil.Path) {
+			n += len(paths)
+		}))
+		return n
+	}
+	assert.Eventually(t, func() bool { return global() == 1 }, 10*time.Second, 50*time.Millisecond)
+
+	// the ROA for another AS makes the path invalid without soft reset
+	writeVRPs(3)
+	require.NoError(t, s1.ReloadRpkiFile(ctx, &api.ReloadRpkiFileRequest{Path: vrps}))
+	assert.Equal(t, change{"10.1.0.0/24", api.ValidationState_VALIDATION_STATE_VALID, api.ValidationState_VALIDATION_STATE_INVALID}, <-ch)
+	assert.Equal(t, 0, global())
+
+	require.NoError(t, s1.DeleteRpkiFile(ctx, &api.DeleteRpkiFileRequest{Path: vrps}))
+	assert.Equal(t, change{"10.1.0.0/24", api.ValidationState_VALIDATION_STATE_INVALID, api.ValidationState_VALIDATION_STATE_NOT_FOUND}, <-ch)
+	assert.Equal(t, 1, global())
+}
@@ -0,0 +1,425 @@
+// Copyright (C) 2025 Acnodal Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/binary"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+
+	api "github.com/osrg/gobgp/v4/api"
+	"github.com/osrg/gobgp/v4/internal/pkg/table"
+	"github.com/osrg/gobgp/v4/pkg/config/oc"
+	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
+	"github.com/osrg/gobgp/v4/pkg/packet/rtr"
+)
+
+// rtrTestCache is a minimal RPKI cache which serves one ROA, one ASPA
+// (version 2 only) and the timing parameters (version 1 or later) and
+// rejects the versions higher than its own.
+type rtrTestCache struct {
+	version uint8
+	// the versions of the Reset Queries received
+	queries chan uint8
+}
+
+func (c *rtrTestCache) serve(t *testing.T, l net.Listener) {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		go c.handle(t, conn)
+	}
+}
+
+func (c *rtrTestCache) write(conn io.Writer, m rtr.RTRMessage) error {
+	data, _ := m.Serialize()
+	data[0] = c.version
+	_, err := conn.Write(data)
+	return err
+}
+
+func (c *rtrTestCache) handle(t *testing.T, conn io.ReadWriteCloser) {
+	defer conn.Close()
+	for {
+		header := make([]byte, rtr.RTR_MIN_LEN)
+		if _, err := io.ReadFull(conn, header); err != nil {
+			return
+		}
+		body := make([]byte, binary.BigEndian.Uint32(header[4:8])-rtr.RTR_MIN_LEN)
+		if _, err := io.ReadFull(conn, body); err != nil {
+			return
+		}
+		if header[1] != rtr.RTR_RESET_QUERY {
+			continue
+		}
+		c.queries <- header[0]
+		if header[0] > c.version {
+			_ = c.write(conn, rtr.NewRTRErrorReport(rtr.UNSUPPORTED_PROTOCOL_VERSION, append(header, body...), nil))
+			return
+		}
+		msgs := []rtr.RTRMessage{
+			rtr.NewRTRCacheResponse(1),
+			rtr.NewRTRIPPrefix(netip.MustParseAddr("10.0.0.0"), 8, 24, 65001, 1),
+		}
+		if c.version >= rtr.RTR_PROTOCOL_VERSION_2 {
+			msgs = append(msgs, rtr.NewRTRASPA(65001, []uint32{65002, 65003}, 1))
+		}
+		if c.version >= rtr.RTR_PROTOCOL_VERSION_1 {
+			msgs = append(msgs, rtr.NewRTREndOfDataWithIntervals(c.version, 1, 1, 120, 60, 900))
+		} else {
+			msgs = append(msgs, rtr.NewRTREndOfData(1, 1))
+		}
+		for _, m := range msgs {
+			if err := c.write(conn, m); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func waitRpki(t *testing.T, s *BgpServer, cond func(*api.Rpki) bool) *api.Rpki {
+	var found *api.Rpki
+	assert.Eventually(t, func() bool {
+		found = nil
+		require.NoError(t, s.ListRpki(context.Background(), &api.ListRpkiRequest{}, func(r *api.Rpki) {
+			if cond(r) {
+				found = r
+			}
+		}))
+		return found != nil
+	}, 10*time.Second, 50*time.Millisecond)
+	return found
+}
+
+func TestRpkiVersionNegotiation(t *testing.T) {
+	ctx := context.Background()
+	s := runNewServer(t, 1, "1.1.1.1", 10179)
+	defer s.StopBgp(ctx, &api.StopBgpRequest{})
+
+	for _, tt := range []struct {
+		name    string
+		version uint8
+		queries []uint8
+		aspas   uint32
+	}{
+		{name: "v2", version: 2, queries: []uint8{2}, aspas: 1},
+		{name: "v1", version: 1, queries: []uint8{2, 1}},
+		{name: "v0", version: 0, queries: []uint8{2, 0}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			l, err := net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(t, err)
+			defer l.Close()
+			cache := &rtrTestCache{version: tt.version, queries: make(chan uint8, 8)}
+			go cache.serve(t, l)
+
+			port := uint32(l.Addr().(*net.TCPAddr).Port)
+			require.NoError(t, s.AddRpki(ctx, &api.AddRpkiRequest{
+				Address: "127.0.0.1",
+				Port:    port,
+			}))
+			defer func() {
+				require.NoError(t, s.DeleteRpki(ctx, &api.DeleteRpkiRequest{Address: "127.0.0.1", Port: port}))
+			}()
+
+			r := waitRpki(t, s, func(r *api.Rpki) bool {
+				return r.State.EndOfData > 0 && r.State.RecordIpv4 == 1
+			})
+			require.NotNil(t, r)
+			assert.Equal(t, uint32(tt.version), r.State.Version)
+			assert.Equal(t, tt.aspas, r.State.Aspas)
+			if tt.version >= rtr.RTR_PROTOCOL_VERSION_1 {
+				assert.Equal(t, uint32(120), r.State.RefreshInterval)
+				assert.Equal(t, uint32(60), r.State.RetryInterval)
+				assert.Equal(t, uint32(900), r.State.ExpireInterval)
+			}
+			for _, v := range tt.queries {
+				assert.Equal(t, v, <-cache.queries)
+			}
+		})
+	}
+}
+
+func TestRpkiTLS(t *testing.T) {
+	ctx := context.Background()
+	s := runNewServer(t, 1, "1.1.1.1", 10179)
+	defer s.StopBgp(ctx, &api.StopBgpRequest{})
+
+	dir := t.TempDir()
+	notAfter := time.Now().Add(time.Hour)
+	ca, caKey := newBmpTestCert(t, dir, "ca", &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}, nil, nil)
+	server, serverKey := newBmpTestCert(t, dir, "server", &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "cache"},
+		NotAfter:     notAfter,
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, ca, caKey)
+
+	l, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{server.Raw}, PrivateKey: serverKey}},
+	})
+	require.NoError(t, err)
+	defer l.Close()
+	cache := &rtrTestCache{version: rtr.RTR_MAX_PROTOCOL_VERSION, queries: make(chan uint8, 8)}
+	go cache.serve(t, l)
+
+	port := uint32(l.Addr().(*net.TCPAddr).Port)
+	require.NoError(t, s.AddRpki(ctx, &api.AddRpkiRequest{
+		Address:   "127.0.0.1",
+		Port:      port,
+		Transport: api.AddRpkiRequest_TRANSPORT_TLS,
+		TlsCaFile: filepath.Join(dir, "ca.pem"),
+	}))
+	r := waitRpki(t, s, func(r *api.Rpki) bool {
+		return r.State.EndOfData > 0
+	})
+	require.NotNil(t, r)
+	assert.Equal(t, api.AddRpkiRequest_TRANSPORT_TLS, r.Conf.Transport)
+	assert.Equal(t, uint32(1), r.State.RecordIpv4)
+	assert.Equal(t, uint32(1), r.State.Aspas)
+	var lookup []uint32
+	require.NoError(t, s.mgmtOperation(func() error {
+		var ok bool
+		if lookup, ok = s.roaManager.aspas.Lookup(65001); !ok {
+			return fmt.Errorf("no ASPA")
+		}
+		return nil
+	}, false))
+	assert.Equal(t, []uint32{65002, 65003}, lookup)
+	require.NoError(t, s.DeleteRpki(ctx, &api.DeleteRpkiRequest{Address: "127.0.0.1"}))
+
+	// the CA file which doesn't exist fails immediately
+	err = s.AddRpki(ctx, &api.AddRpkiRequest{
+		Address:   "127.0.0.1",
+		Port:      port,
+		Transport: api.AddRpkiRequest_TRANSPORT_TLS,
+		TlsCaFile: filepath.Join(dir, "none.pem"),
+	})
+	assert.Error(t, err)
+}
+
+// serveSSH runs the cache as the rpki-rtr subsystem of the SSH server.
+func (c *rtrTestCache) serveSSH(t *testing.T, l net.Listener, config *ssh.ServerConfig) {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			_, chans, reqs, err := ssh.NewServerConn(conn, config)
+			if err != nil {
+				conn.Close()
+				return
+			}
+			go ssh.DiscardRequests(reqs)
+			for nc := range chans {
+				if nc.ChannelType() != "session" {
+					_ = nc.Reject(ssh.UnknownChannelType, "")
+					continue
+				}
+				ch, requests, err := nc.Accept()
+				if err != nil {
+					return
+				}
+				go func() {
+					for req := range requests {
+						// the payload is the length-prefixed subsystem name
+						ok := req.Type == "subsystem" && len(req.Payload) > 4 && string(req.Payload[4:]) == rtr.RPKI_SSH_SUBSYSTEM
+						_ = req.Reply(ok, nil)
+						if ok {
+							go c.handle(t, ch)
+						}
+					}
+				}()
+			}
+		}()
+	}
+}
+
+func TestRpkiSSH(t *testing.T) {
+	ctx := context.Background()
+	s := runNewServer(t, 1, "1.1.1.1", 10179)
+	defer s.StopBgp(ctx, &api.StopBgpRequest{})
+
+	_, hostKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	hostSigner, err := ssh.NewSignerFromKey(hostKey)
+	require.NoError(t, err)
+	clientPub, clientKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	authorized, err := ssh.NewPublicKey(clientPub)
+	require.NoError(t, err)
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: func(meta ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if meta.User() == "rpki" && bytes.Equal(key.Marshal(), authorized.Marshal()) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("unknown key")
+		},
+	}
+	config.AddHostKey(hostSigner)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	cache := &rtrTestCache{version: rtr.RTR_MAX_PROTOCOL_VERSION, queries: make(chan uint8, 8)}
+	go cache.serveSSH(t, l, config)
+
+	dir := t.TempDir()
+	block, err := ssh.MarshalPrivateKey(clientKey, "")
+	require.NoError(t, err)
+	keyFile := filepath.Join(dir, "id_ed25519")
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(block), 0o600))
+	knownHostsFile := filepath.Join(dir, "known_hosts")
+	line := knownhosts.Line([]string{knownhosts.Normalize(l.Addr().String())}, hostSigner.PublicKey())
+	require.NoError(t, os.WriteFile(knownHostsFile, []byte(line+"\n"), 0o600))
+
+	port := uint32(l.Addr().(*net.TCPAddr).Port)
+	require.NoError(t, s.AddRpki(ctx, &api.AddRpkiRequest{
+		Address:           "127.0.0.1",
+		Port:              port,
+		Transport:         api.AddRpkiRequest_TRANSPORT_SSH,
+		SshUsername:       "rpki",
+		SshPrivateKeyFile: keyFile,
+		SshKnownHostsFile: knownHostsFile,
+	}))
+	r := waitRpki(t, s, func(r *api.Rpki) bool {
+		return r.State.EndOfData > 0
+	})
+	require.NotNil(t, r)
+	assert.Equal(t, api.AddRpkiRequest_TRANSPORT_SSH, r.Conf.Transport)
+	assert.Equal(t, uint32(1), r.State.RecordIpv4)
+	require.NoError(t, s.DeleteRpki(ctx, &api.DeleteRpkiRequest{Address: "127.0.0.1"}))
+
+	// the private key file which doesn't exist fails immediately
+	err = s.AddRpki(ctx, &api.AddRpkiRequest{
+		Address:           "127.0.0.1",
+		Port:              port,
+		Transport:         api.AddRpkiRequest_TRANSPORT_SSH,
+		SshUsername:       "rpki",
+		SshPrivateKeyFile: filepath.Join(dir, "none"),
+		SshKnownHostsFile: knownHostsFile,
+	})
+	assert.Error(t, err)
+}
+
+// rtrDiscardConn is the connection to a cache which drops the queries.
+type rtrDiscardConn struct{}
+
+func (rtrDiscardConn) Read([]byte) (int, error)    { return 0, io.EOF }
+func (rtrDiscardConn) Write(b []byte) (int, error) { return len(b), nil }
+func (rtrDiscardConn) Close() error                { return nil }
+
+func TestRpkiSoftResetImportPolicy(t *testing.T) {
+	assert := assert.New(t)
+	s := NewBgpServer()
+	families := []bgp.Family{bgp.RF_IPv4_UC}
+	s.globalRib = table.NewTableManager(logger, families)
+	// only the valid paths are accepted
+	require.NoError(t, s.policy.Reset(&oc.RoutingPolicy{
+		PolicyDefinitions: []oc.PolicyDefinition{{
+			Name: "rpki",
+			Statements: []oc.Statement{{
+				Name: "valid",
+				Conditions: oc.Conditions{
+					BgpConditions: oc.BgpConditions{RpkiValidationResult: oc.RPKI_VALIDATION_RESULT_TYPE_VALID},
+				},
+				Actions: oc.Actions{RouteDisposition: oc.ROUTE_DISPOSITION_ACCEPT_ROUTE},
+			}},
+		}},
+	}, map[string]oc.ApplyPolicy{
+		table.GLOBAL_RIB_NAME: {Config: oc.ApplyPolicyConfig{
+			ImportPolicyList:    []string{"rpki"},
+			DefaultImportPolicy: oc.DEFAULT_POLICY_TYPE_REJECT_ROUTE,
+		}},
+	}))
+	p := newPeerandInfo(t, 65000, 65001, "10.0.0.1", s.globalRib)
+	p.policy = s.policy
+	s.neighborMap[netip.MustParseAddr("10.0.0.1")] = p
+
+	client := &roaClient{host: "127.0.0.1:323", conn: rtrDiscardConn{}}
+	s.roaManager.clientMap[client.host] = client
+	count := func() int {
+		return len(s.globalRib.GetBestPathList(table.GLOBAL_RIB_NAME, 0, families))
+	}
+	// the cache sends the same ROA each time
+	sync := func(check bool) {
+		for _, m := range []rtr.RTRMessage{
+			rtr.NewRTRCacheResponse(1),
+			rtr.NewRTRIPPrefix(netip.MustParseAddr("10.0.0.0"), 8, 24, 65001, 1),
+			rtr.NewRTREndOfData(1, 1),
+		} {
+			data, _ := m.Serialize()
+			s.roaManager.HandleROAEvent(&roaEvent{EventType: roaRTR, Src: client.host, Data: data})
+			s.processROAChanges()
+			if check {
+				assert.Equal(1, count())
+			}
+		}
+	}
+	sync(false)
+
+	nlri, _ := bgp.NewIPAddrPrefix(netip.MustParsePrefix("10.10.0.0/24"))
+	nh, _ := bgp.NewPathAttributeNextHop(netip.MustParseAddr("10.0.0.1"))
+	path := table.NewPath(bgp.RF_IPv4_UC, p.peerInfo, bgp.PathNLRI{NLRI: nlri}, false, []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(0),
+		bgp.NewPathAttributeAsPath([]bgp.AsPathParamInterface{bgp.NewAs4PathParam(bgp.BGP_ASPATH_ATTR_TYPE_SEQ, []uint32{65001})}),
+		nh,
+	}, time.Now(), false)
+	p.adjRibIn.Update([]*table.Path{path})
+	s.propagateUpdate(p, []*table.Path{path})
+	require.Equal(t, 1, count())
+
+	// the ROAs deleted by the soft reset don't reach the import policy
+	// before the cache sends them again
+	require.NoError(t, s.roaManager.SoftReset("127.0.0.1"))
+	assert.True(client.updating)
+	s.processROAChanges()
+	assert.Equal(1, count())
+	sync(true)
+	assert.False(client.updating)
+	assert.Nil(s.roaTable.TakeChanges())
+}
@@ -19,14 +19,17 @@ package server
 import (
 	"bytes"
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"net"
 	"net/netip"
+	"path/filepath"
 	"slices"
 	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -46,6 +49,7 @@ import (
 	"github.com/osrg/gobgp/v4/pkg/config/oc"
 	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
 	"github.com/osrg/gobgp/v4/pkg/packet/bmp"
+	"github.com/osrg/gobgp/v4/pkg/packet/rtr"
 	"github.com/osrg/gobgp/v4/pkg/zebra"
 )
 
@@ -68,12 +72,34 @@ type nopTimingHook struct{}
 
 func (n nopTimingHook) Observe(op FSMOperation, tOp, tWait time.Duration) {}
 
+type NetlinkOperation uint
+
+const (
+	NetlinkRouteReplace NetlinkOperation = iota
+	NetlinkRouteDelete
+
+	NetlinkOperationTypeCount
+)
+
+// NetlinkTimingHook observes how long the kernel takes to program the
+// exported routes.
+type NetlinkTimingHook interface {
+	Observe(op NetlinkOperation, t time.Duration)
+}
+
+type nopNetlinkTimingHook struct{}
+
+func (n nopNetlinkTimingHook) Observe(op NetlinkOperation, t time.Duration) {}
+
 type options struct {
 	grpcAddress string
 	grpcOption  []grpc.ServerOption
 	logger      *slog.Logger
 	logLevelVar *slog.LevelVar
 	timingHook  FSMTimingHook
+	netlinkHook NetlinkTimingHook
+	// the number of the committed policy versions kept to roll back to
+	policyHistorySize int
 }
 
 type ServerOption func(*options)
@@ -103,6 +129,18 @@ func TimingHookOption(hook FSMTimingHook) ServerOption {
 	}
 }
 
+func NetlinkTimingHookOption(hook NetlinkTimingHook) ServerOption {
+	return func(o *options) {
+		o.netlinkHook = hook
+	}
+}
+
+func PolicyHistorySizeOption(size int) ServerOption {
+	return func(o *options) {
+		o.policyHistorySize = size
+	}
+}
+
 type sharedData struct {
 	mu sync.Mutex
 }
@@ -114,27 +152,43 @@ func newSharedData() *sharedData {
 }
 
 type BgpServer struct {
-	shared       *sharedData
-	apiServer    *server
-	bgpConfig    oc.Bgp
-	acceptCh     chan net.Conn
-	mgmtCh       chan *mgmtOp
-	policy       *table.RoutingPolicy
-	listeners    []*netutils.TCPListener
-	neighborMap  map[netip.Addr]*peer
-	peerGroupMap map[string]*peerGroup
-	globalRib    *table.TableManager
-	rsRib        *table.TableManager
-	roaManager   *roaManager
-	watcherMap   map[watchEventType][]*watcher
-	zclient      *zebraClient
-	bmpManager   *bmpClientManager
-	mrtManager   *mrtManager
-	roaTable     *table.ROATable
-	uuidMap      map[string]uuid.UUID
-	logger       *slog.Logger
-	logLevelVar  *slog.LevelVar
-	timingHook   FSMTimingHook
+	shared    *sharedData
+	apiServer *server
+	bgpConfig oc.Bgp
+	acceptCh  chan net.Conn
+	mgmtCh    chan *mgmtOp
+	policy    *table.RoutingPolicy
+	// the transactions staging the policy changes and the committed
+	// policy versions
+	policyTransactions *policyTransactionManager
+	// the local files the defined sets are loaded from
+	definedSetFiles *definedSetFileManager
+	listeners       []*netutils.TCPListener
+	neighborMap     map[netip.Addr]*peer
+	peerGroupMap    map[string]*peerGroup
+	// update groups by their keys, see updateGroupKey
+	updateGroups  map[string]*updateGroup
+	updateGroupID uint64
+	// BGP-LS paths originated from the topology sources
+	lsTopology map[string]map[string]*table.Path
+	globalRib  *table.TableManager
+	rsRib      *table.TableManager
+	roaManager *roaManager
+	watcherMap map[watchEventType][]*watcher
+	zclient    *zebraClient
+	bmpManager *bmpClientManager
+	bmpStation *bmpStation
+	mrtManager *mrtManager
+	mrtReplays *mrtReplayManager
+	roaTable   *table.ROATable
+	// router keys and the local signer for BGPsec
+	routerKeyTable *table.RouterKeyTable
+	bgpsecSigner   *table.BGPsecSigner
+	uuidMap        map[string]uuid.UUID
+	logger         *slog.Logger
+	logLevelVar    *slog.LevelVar
+	timingHook     FSMTimingHook
+	netlinkHook    NetlinkTimingHook
 	// manage lifecycle of the server
 	isServing     atomic.Bool
 	shutdownWG    *sync.WaitGroup
@@ -142,12 +196,14 @@ type BgpServer struct {
 	runningCancel context.CancelFunc
 	// netlink integration
 	netlinkClient       *netlinkClient
+	netlinkLsClient     *netlinkLsClient
 	netlinkExportClient *netlinkExportClient
 }
 
 func NewBgpServer(opt ...ServerOption) *BgpServer {
 	opts := options{
-		timingHook: nopTimingHook{},
+		timingHook:  nopTimingHook{},
+		netlinkHook: nopNetlinkTimingHook{},
 	}
 	for _, o := range opt {
 		o(&opts)
@@ -159,25 +215,33 @@ func NewBgpServer(opt ...ServerOption) *BgpServer {
 		lvl = nil
 	}
 	roaTable := table.NewROATable(logger)
+	routerKeyTable := table.NewRouterKeyTable()
 	shared := newSharedData()
 
 	s := &BgpServer{
-		shared:       shared,
-		neighborMap:  make(map[netip.Addr]*peer),
-		peerGroupMap: make(map[string]*peerGroup),
-		policy:       table.NewRoutingPolicy(logger),
-		mgmtCh:       make(chan *mgmtOp, 1),
-		watcherMap:   make(map[watchEventType][]*watcher),
-		uuidMap:      make(map[string]uuid.UUID),
-		roaManager:   newROAManager(roaTable, logger),
-		roaTable:     roaTable,
-		logger:       logger,
-		logLevelVar:  lvl,
-		timingHook:   opts.timingHook,
-		shutdownWG:   &sync.WaitGroup{},
+		shared:             shared,
+		neighborMap:        make(map[netip.Addr]*peer),
+		peerGroupMap:       make(map[string]*peerGroup),
+		updateGroups:       make(map[string]*updateGroup),
+		lsTopology:         make(map[string]map[string]*table.Path),
+		policy:             table.NewRoutingPolicy(logger),
+		policyTransactions: newPolicyTransactionManager(opts.policyHistorySize),
+		definedSetFiles:    newDefinedSetFileManager(),
+		mgmtCh:             make(chan *mgmtOp, 1),
+		watcherMap:         make(map[watchEventType][]*watcher),
+		uuidMap:            make(map[string]uuid.UUID),
+		roaManager:         newROAManager(roaTable, routerKeyTable, logger),
+		roaTable:           roaTable,
+		routerKeyTable:     routerKeyTable,
+		logger:             logger,
+		logLevelVar:        lvl,
+		timingHook:         opts.timingHook,
+		netlinkHook:        opts.netlinkHook,
+		shutdownWG:         &sync.WaitGroup{},
 	}
 	s.bmpManager = newBmpClientManager(s)
 	s.mrtManager = newMrtManager(s)
+	s.mrtReplays = newMrtReplayManager(s)
 	if len(opts.grpcAddress) != 0 {
 		grpc.EnableTracing = false
 		s.apiServer = newAPIserver(s, shared, grpc.NewServer(opts.grpcOption...), opts.grpcAddress)
@@ -397,8 +461,13 @@ func (s *BgpServer) Serve() {
 			tWait := tStart.Sub(ev.timestamp)
 			s.shared.mu.Lock()
 			s.roaManager.HandleROAEvent(ev)
+			s.processROAChanges()
 			s.shared.mu.Unlock()
 			s.timingHook.Observe(FSMROAEvent, time.Since(tStart), tWait)
+		case ev := <-s.definedSetFiles.eventCh:
+			s.shared.mu.Lock()
+			s.handleDefinedSetFileEvent(ev)
+			s.shared.mu.Unlock()
 		}
 	}
 }
@@ -422,7 +491,16 @@ func (s *BgpServer) matchLongestDynamicNeighborPrefix(a string) *peerGroup {
 	return longestPG
 }
 
-func sendfsmOutgoingMsg(peer *peer, paths []*table.Path) {
+// sendfsmOutgoingMsg sends the paths to the peer; pre are the paths before
+// the export policy was applied, for the pre-policy Adj-RIB-Out watchers.
+func (s *BgpServer) sendfsmOutgoingMsg(peer *peer, paths, pre []*table.Path) {
+	paths, events := peer.limitAdvertisedPaths(paths)
+	for _, ev := range events {
+		s.notifyWatcher(watchEventTypePrefixLimit, ev)
+	}
+	peer.recordORFAdvertised(paths)
+	// the paths are cloned before the fsm goroutine touches them
+	s.notifyAdjRibOutWatcher(peer, pre, paths)
 	peer.fsm.outgoingCh.In() <- &fsmOutgoingMsg{
 		Paths: paths,
 	}
@@ -538,9 +616,27 @@ func filterpath(peer *peer, path, old *table.Path) *table.Path {
 			return nil
 		}
 	}
+
+	// RFC 5291 Outbound Route Filtering
+	// The ORF received from the peer works as an implicit export filter.
+	// Withdrawals are always sent since the ORF may have been changed
+	// after the path was advertised.
+	if !path.IsWithdraw && !peer.orfPermit(path) {
+		peer.fsm.logger.Debug("Filtered by ORF, ignore", slog.Any("Path", path))
+		if old != nil {
+			return old.Clone(true)
+		}
+		return nil
+	}
 	return path
 }
 
+// validateBGPsec validates the BGPsec_PATH attribute of the path against
+// the AS of the peer which sent it.
+func (s *BgpServer) validateBGPsec(path *table.Path) oc.BgpsecValidationResultType {
+	return s.routerKeyTable.Validate(path, path.GetSource().AS)
+}
+
 func (s *BgpServer) prePolicyFilterpath(peer *peer, path, old *table.Path) (*table.Path, *table.PolicyOptions, bool) {
 	// Special handling for RTM NLRI.
 	if path != nil && path.GetFamily() == bgp.RF_RTC_UC && !path.IsWithdraw {
@@ -654,26 +750,61 @@ func (s *BgpServer) postFilterpath(peer *peer, path *table.Path) *table.Path {
 		path.RemoveLocalPref()
 	}
 
+	// sign or strip BGPsec_PATH. The path here is already a copy made by
+	// UpdatePathAttrs() so it can be modified, except for route server
+	// clients.
+	if path != nil && !path.IsWithdraw && !path.IsEOR() {
+		if peer.isRouteServerClient() && path.GetBGPsecPath() != nil {
+			path = path.Clone(false)
+		}
+		family := path.GetFamily()
+		send := (family == bgp.RF_IPv4_UC || family == bgp.RF_IPv6_UC) && peer.isBGPsecSendEnabled(family)
+		ebgp := !peer.isIBGPPeer() && !peer.isRouteServerClient()
+		peer.fsm.lock.Lock()
+		localAS, peerAS := peer.fsm.pConf.Config.LocalAs, peer.fsm.pConf.Config.PeerAs
+		peer.fsm.lock.Unlock()
+		if err := path.UpdateBGPsecPath(s.bgpsecSigner, send, ebgp, localAS, peerAS); err != nil {
+			peer.fsm.logger.Warn("failed to sign BGPsec path, sent unsigned",
+				slog.String("Prefix", path.GetPrefix()),
+				slog.String("Error", err.Error()))
+		}
+	}
+
 	return path
 }
 
-func (s *BgpServer) filterpath(peer *peer, path, old *table.Path) *table.Path {
+// filterpath returns the path to send to the peer, and the path before the
+// export policy is applied.
+func (s *BgpServer) filterpath(peer *peer, path, old *table.Path) (*table.Path, *table.Path) {
+	return s.exportFilterpath(peer, path, old, false, nil)
+}
+
+// exportFilterpath is filterpath which leaves the hit counters of the
+// export policies alone if noCount is true, and counts the decisions for
+// the assignments of sharedWith too (see table.PolicyOptions).
+func (s *BgpServer) exportFilterpath(peer *peer, path, old *table.Path, noCount bool, sharedWith []string) (*table.Path, *table.Path) {
 	path, options, stop := s.prePolicyFilterpath(peer, path, old)
 	if stop {
-		return nil
+		return nil, nil
 	}
+	pre := path
 	options.Validate = s.roaTable.Validate
+	options.ValidateBGPsec = s.validateBGPsec
+	options.NoCount = noCount
+	options.SharedWith = sharedWith
 	path = peer.policy.ApplyPolicy(peer.TableID(), table.POLICY_DIRECTION_EXPORT, path, options)
 	// When 'path' is filtered (path == nil), check 'old' has been sent to this peer.
 	// If it has, send withdrawal to the peer.
 	if path == nil && old != nil {
+		// the decision on the path has been counted already
+		options.NoCount = true
 		o := peer.policy.ApplyPolicy(peer.TableID(), table.POLICY_DIRECTION_EXPORT, old, options)
 		if o != nil {
 			path = old.Clone(true)
 		}
 	}
 
-	return s.postFilterpath(peer, path)
+	return s.postFilterpath(peer, path), pre
 }
 
 func clonePathList(pathList []*table.Path) []*table.Path {
@@ -839,6 +970,120 @@ func (s *BgpServer) notifyPostPolicyUpdateWatcher(peer *peer, pathList []*table.
 	s.notifyWatcher(watchEventTypePostUpdate, ev)
 }
 
+func (s *BgpServer) newWatchEventAdjRibOut(peer *peer, pathList []*table.Path, postPolicy bool) *watchEventUpdate {
+	n := s.toConfig(peer, false)
+	peer.fsm.lock.Lock()
+	defer peer.fsm.lock.Unlock()
+	_, y := peer.fsm.capMap[bgp.BGP_CAP_FOUR_OCTET_AS_NUMBER]
+	return &watchEventUpdate{
+		PeerAS:       peer.fsm.pConf.State.PeerAs,
+		LocalAS:      peer.fsm.pConf.Config.LocalAs,
+		PeerAddress:  peer.fsm.pConf.State.NeighborAddress,
+		LocalAddress: peer.fsm.pConf.Transport.State.LocalAddress,
+		PeerID:       peer.fsm.pConf.State.RemoteRouterId,
+		FourBytesAs:  y,
+		Timestamp:    time.Now(),
+		PostPolicy:   postPolicy,
+		AdjRibOut:    true,
+		PathList:     pathList,
+		Neighbor:     n,
+	}
+}
+
+// notifyAdjRibOutWatcher notifies the paths sent to the peer; pre are the
+// paths before the export policy was applied. The End-of-RIB markers are
+// reported in both views. The paths are recorded for the Adj-RIB-Out
+// statistics too; a peer established after the watch started sent nothing
+// before.
+func (s *BgpServer) notifyAdjRibOutWatcher(peer *peer, pre, post []*table.Path) {
+	if s.isWatched(watchEventTypePreAdjRibOut) && peer.adjRibOutPre == nil {
+		peer.adjRibOutPre = make(advertisedPaths)
+	}
+	if s.isWatched(watchEventTypePostAdjRibOut) && peer.adjRibOutPost == nil {
+		peer.adjRibOutPost = make(advertisedPaths)
+	}
+	peer.recordAdjRibOut(pre, post)
+	if s.isWatched(watchEventTypePreAdjRibOut) {
+		l := clonePathList(pre)
+		for _, p := range post {
+			if p.IsEOR() {
+				l = append(l, p)
+			}
+		}
+		if len(l) > 0 {
+			s.notifyWatcher(watchEventTypePreAdjRibOut, s.newWatchEventAdjRibOut(peer, l, false))
+		}
+	}
+	if s.isWatched(watchEventTypePostAdjRibOut) {
+		if l := clonePathList(post); len(l) > 0 {
+			s.notifyWatcher(watchEventTypePostAdjRibOut, s.newWatchEventAdjRibOut(peer, l, true))
+		}
+	}
+}
+
+// startAdjRibOutCount records the Adj-RIB-Out of the established peers
+// when the view starts to be watched; notifyAdjRibOutWatcher records the
+// paths sent afterwards.
+func (s *BgpServer) startAdjRibOutCount(postPolicy bool) {
+	for _, peer := range s.neighborMap {
+		if peer.State() != bgp.BGP_FSM_ESTABLISHED {
+			continue
+		}
+		pre, post := s.getAdjRibOut(peer, peer.configuredRFlist())
+		a := make(advertisedPaths)
+		if postPolicy {
+			peer.recordAdvertised(a, post, nil)
+			peer.adjRibOutPost = a
+		} else {
+			peer.recordAdvertised(a, pre, nil)
+			peer.adjRibOutPre = a
+		}
+	}
+}
+
+// stopAdjRibOutCount drops the paths recorded for the view no longer
+// watched.
+func (s *BgpServer) stopAdjRibOutCount() {
+	pre, post := s.isWatched(watchEventTypePreAdjRibOut), s.isWatched(watchEventTypePostAdjRibOut)
+	for _, peer := range s.neighborMap {
+		if !pre {
+			peer.adjRibOutPre = nil
+		}
+		if !post {
+			peer.adjRibOutPost = nil
+		}
+	}
+}
+
+// getAdjRibOut returns the paths which would be sent to the peer, before
+// and after the export policy is applied.
+func (s *BgpServer) getAdjRibOut(peer *peer, rfList []bgp.Family) ([]*table.Path, []*table.Path) {
+	pre := make([]*table.Path, 0)
+	post := make([]*table.Path, 0)
+	if peer.isSecondaryRouteEnabled() {
+		post, _, _ = s.viewBestFromLocal(peer, rfList, false)
+		return pre, post
+	}
+	for _, family := range peer.toGlobalFamilies(rfList) {
+		for _, path := range s.getPossibleBest(peer, family) {
+			p, options, stop := s.prePolicyFilterpath(peer, path, nil)
+			if stop || p == nil {
+				continue
+			}
+			pre = append(pre, p)
+			options.Validate = s.roaTable.Validate
+			options.ValidateBGPsec = s.validateBGPsec
+			options.NoCount = true
+			if p = peer.policy.ApplyPolicy(peer.TableID(), table.POLICY_DIRECTION_EXPORT, p, options); p != nil {
+				if p = s.postFilterpath(peer, p); p != nil {
+					post = append(post, p)
+				}
+			}
+		}
+	}
+	return pre, post
+}
+
 func newWatchEventPeer(peer *peer, m *fsmMsg, newState, oldState bgp.FSMState, t apiutil.PeerEventType) *watchEventPeer {
 	peer.fsm.lock.Lock()
 	sentOpen := buildopen(peer.fsm.gConf, peer.fsm.pConf)
@@ -922,12 +1167,29 @@ func (s *BgpServer) getPossibleBest(peer *peer, family bgp.Family) []*table.Path
 	if peer.isAddPathSendEnabled(family) {
 		return peer.localRib.GetPathList(peer.TableID(), peer.AS(), []bgp.Family{family})
 	}
+	if alt := peer.alternativePath(); alt != table.ALTERNATIVE_PATH_NONE {
+		return peer.localRib.GetAlternativePathList(peer.TableID(), peer.AS(), alt, peer.peerInfo, []bgp.Family{family})
+	}
 	return peer.localRib.GetBestPathList(peer.TableID(), peer.AS(), []bgp.Family{family})
 }
 
-func (s *BgpServer) getBestFromLocal(peer *peer, rfList []bgp.Family, addEOR bool) ([]*table.Path, []*table.Path) {
+// getBestFromLocal returns the paths to send to the peer, the paths
+// filtered out and, if watched, the paths before the export policy is
+// applied.
+func (s *BgpServer) getBestFromLocal(peer *peer, rfList []bgp.Family, addEOR bool) ([]*table.Path, []*table.Path, []*table.Path) {
+	return s.bestFromLocal(peer, rfList, addEOR, false)
+}
+
+// viewBestFromLocal is getBestFromLocal for the views of the paths which
+// would be sent, which leave the hit counters of the export policies alone.
+func (s *BgpServer) viewBestFromLocal(peer *peer, rfList []bgp.Family, addEOR bool) ([]*table.Path, []*table.Path, []*table.Path) {
+	return s.bestFromLocal(peer, rfList, addEOR, true)
+}
+
+func (s *BgpServer) bestFromLocal(peer *peer, rfList []bgp.Family, addEOR, noCount bool) ([]*table.Path, []*table.Path, []*table.Path) {
 	pathList := []*table.Path{}
 	filtered := []*table.Path{}
+	var preList []*table.Path
 
 	if peer.isSecondaryRouteEnabled() {
 		for _, family := range peer.toGlobalFamilies(rfList) {
@@ -942,14 +1204,19 @@ func (s *BgpServer) getBestFromLocal(peer *peer, rfList []bgp.Family, addEOR boo
 				}
 				dl = append(dl, u)
 			}
-			pathList = append(pathList, s.sendSecondaryRoutes(peer, nil, dl)...)
+			pathList = append(pathList, s.sendSecondaryRoutes(peer, nil, dl, noCount)...)
 		}
-		return pathList, filtered
+		return pathList, filtered, nil
 	}
 
+	watched := s.isWatched(watchEventTypePreAdjRibOut)
 	for _, family := range peer.toGlobalFamilies(rfList) {
 		for _, path := range s.getPossibleBest(peer, family) {
-			if p := s.filterpath(peer, path, nil); p != nil {
+			p, pre := s.exportFilterpath(peer, path, nil, noCount, nil)
+			if pre != nil && watched {
+				preList = append(preList, pre)
+			}
+			if p != nil {
 				pathList = append(pathList, p)
 			} else {
 				filtered = append(filtered, path)
@@ -969,7 +1236,7 @@ func (s *BgpServer) getBestFromLocal(peer *peer, rfList []bgp.Family, addEOR boo
 			}
 		}
 	}
-	return pathList, filtered
+	return pathList, filtered, preList
 }
 
 func needToAdvertise(peer *peer) bool {
@@ -988,7 +1255,8 @@ func needToAdvertise(peer *peer) bool {
 	return true
 }
 
-func (s *BgpServer) sendSecondaryRoutes(peer *peer, newPath *table.Path, dsts []*table.Update) []*table.Path {
+func (s *BgpServer) sendSecondaryRoutes(peer *peer, newPath *table.Path, dsts []*table.Update, noCount bool) []*table.Path {
+	// the pre-policy view isn't reported for the secondary routes
 	if !needToAdvertise(peer) {
 		return nil
 	}
@@ -1000,6 +1268,8 @@ func (s *BgpServer) sendSecondaryRoutes(peer *peer, newPath *table.Path, dsts []
 			return nil
 		}
 		options.Validate = s.roaTable.Validate
+		options.ValidateBGPsec = s.validateBGPsec
+		options.NoCount = noCount
 		path = peer.policy.ApplyPolicy(peer.TableID(), table.POLICY_DIRECTION_EXPORT, path, options)
 		if path != nil {
 			return s.postFilterpath(peer, path)
@@ -1035,32 +1305,41 @@ func (s *BgpServer) sendSecondaryRoutes(peer *peer, newPath *table.Path, dsts []
 	return pl
 }
 
-func (s *BgpServer) processOutgoingPaths(peer *peer, paths, olds []*table.Path) []*table.Path {
+// processOutgoingPaths returns the paths to send to the peer and, if
+// watched, the paths before the export policy is applied. The export
+// policy decisions are counted for the assignments of sharedWith too.
+func (s *BgpServer) processOutgoingPaths(peer *peer, paths, olds []*table.Path, sharedWith []string) ([]*table.Path, []*table.Path) {
 	if !needToAdvertise(peer) {
-		return nil
+		return nil, nil
 	}
 
+	watched := s.isWatched(watchEventTypePreAdjRibOut)
 	outgoing := make([]*table.Path, 0, len(paths))
+	var preList []*table.Path
 	for idx, path := range paths {
 		var old *table.Path
 		if olds != nil {
 			old = olds[idx]
 		}
-		if p := s.filterpath(peer, path, old); p != nil {
+		p, pre := s.exportFilterpath(peer, path, old, false, sharedWith)
+		if pre != nil && watched {
+			preList = append(preList, pre)
+		}
+		if p != nil {
 			outgoing = append(outgoing, p)
 		}
 	}
-	return outgoing
+	return outgoing, preList
 }
 
-func (s *BgpServer) handleRouteRefresh(peer *peer, e *fsmMsg) []*table.Path {
+func (s *BgpServer) handleRouteRefresh(peer *peer, e *fsmMsg) ([]*table.Path, []*table.Path) {
 	m := e.MsgData.(*bgp.BGPMessage)
 	rr := m.Body.(*bgp.BGPRouteRefresh)
 	rf := bgp.NewFamily(rr.AFI, rr.SAFI)
 
 	if y := peer.IsFamilyEnabled(rf); !y {
 		peer.fsm.logger.Warn("Route family isn't supported", slog.String("Family", rf.String()))
-		return nil
+		return nil, nil
 	}
 
 	peer.fsm.lock.Lock()
@@ -1068,11 +1347,139 @@ func (s *BgpServer) handleRouteRefresh(peer *peer, e *fsmMsg) []*table.Path {
 	peer.fsm.lock.Unlock()
 	if !ok {
 		peer.fsm.logger.Warn("ROUTE_REFRESH received but the capability wasn't advertised")
-		return nil
+		return nil, nil
+	}
+	if len(rr.ORFs) > 0 {
+		return s.handleORF(peer, rf, rr)
 	}
 	rfList := []bgp.Family{rf}
-	accepted, _ := s.getBestFromLocal(peer, rfList, true)
-	return accepted
+	accepted, _, pre := s.getBestFromLocal(peer, rfList, true)
+	return accepted, pre
+}
+
+func (s *BgpServer) handleORF(peer *peer, rf bgp.Family, rr *bgp.BGPRouteRefresh) ([]*table.Path, []*table.Path) {
+	prev := peer.receivedORF[rf]
+	if !peer.isORFReceiveEnabled(rf) {
+		// RFC 5291 5.
+		// ORF entries of a type that wasn't negotiated are ignored and the
+		// message is handled as a plain route refresh.
+		peer.fsm.logger.Warn("ORF received but the capability wasn't negotiated", slog.String("Family", rf.String()))
+	} else {
+		next := prev.Clone()
+		for _, orf := range rr.ORFs {
+			if orf.Type != bgp.ORF_TYPE_ADDRESS_PREFIX {
+				peer.fsm.logger.Warn("unsupported ORF type", slog.String("Family", rf.String()), slog.String("Type", orf.Type.String()))
+				continue
+			}
+			next.Update(orf.Entries)
+		}
+		peer.receivedORF[rf] = next
+		peer.fsm.logger.Info("ORF updated",
+			slog.String("Family", rf.String()),
+			slog.Int("Entries", next.Len()),
+			slog.String("WhenToRefresh", rr.WhenToRefresh.String()))
+		if rr.WhenToRefresh == bgp.ORF_WHEN_TO_REFRESH_DEFER {
+			return nil, nil
+		}
+	}
+
+	accepted, filtered, pre := s.getBestFromLocal(peer, []bgp.Family{rf}, true)
+	// withdraw the paths which were sent before, leaving alone the ones
+	// the export policy has never let through
+	for _, path := range filtered {
+		if peer.isORFAdvertised(path) {
+			accepted = append(accepted, path.Clone(true))
+		}
+	}
+	return accepted, pre
+}
+
+// orfEntriesFromPrefixSet converts the prefixes of the family in the
+// prefix-set into permit entries of Address Prefix ORF.
+func (s *BgpServer) orfEntriesFromPrefixSet(name string, family bgp.Family) ([]*bgp.AddressPrefixORFEntry, error) {
+	sets, err := s.policy.GetDefinedSet(table.DEFINED_TYPE_PREFIX, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(sets.PrefixSets) == 0 {
+		return nil, fmt.Errorf("prefix set %s not found", name)
+	}
+	const seqStep = 5
+	entries := make([]*bgp.AddressPrefixORFEntry, 0, len(sets.PrefixSets[0].PrefixList))
+	for _, p := range sets.PrefixSets[0].PrefixList {
+		if p.IpPrefix.Addr().Is4() != (family == bgp.RF_IPv4_UC) {
+			continue
+		}
+		min, max, err := oc.ParseMaskLength(p.IpPrefix.String(), p.MasklengthRange)
+		if err != nil {
+			return nil, err
+		}
+		// zero means unspecified for both "ge" and "le"
+		var minLen, maxLen uint8
+		if bits := p.IpPrefix.Bits(); min != bits || max != bits {
+			if min > bits {
+				minLen = uint8(min)
+			}
+			maxLen = uint8(max)
+		}
+		seq := uint32(len(entries)+1) * seqStep
+		entries = append(entries, bgp.NewAddressPrefixORFEntry(bgp.ORF_ACTION_ADD, bgp.ORF_MATCH_PERMIT, seq, minLen, maxLen, p.IpPrefix))
+	}
+	return entries, nil
+}
+
+// sendORF pushes the prefix-set configured for the families as Address
+// Prefix ORF, replacing the list sent before. If prefixSet is not empty,
+// only the families using that prefix-set are pushed and only when the
+// list has been changed.
+func (s *BgpServer) sendORF(peer *peer, families []bgp.Family, prefixSet string) {
+	// keep each ROUTE-REFRESH message within the maximum message size
+	const maxEntries = 128
+	var msgs []*bgp.BGPMessage
+	for _, family := range families {
+		if !peer.isORFSendEnabled(family) {
+			continue
+		}
+		peer.fsm.lock.Lock()
+		var name string
+		if c := peer.fsm.pConf.GetAfiSafi(family); c != nil {
+			name = c.OutboundRouteFiltering.State.PrefixSet
+		}
+		peer.fsm.lock.Unlock()
+		if name == "" || (prefixSet != "" && name != prefixSet) {
+			continue
+		}
+		entries, err := s.orfEntriesFromPrefixSet(name, family)
+		if err != nil {
+			// send an empty list not to leave the stale one on the peer
+			peer.fsm.logger.Warn("failed to build ORF",
+				slog.String("Family", family.String()),
+				slog.String("PrefixSet", name),
+				slog.String("Error", err.Error()))
+		}
+		if prefixSet != "" && slices.EqualFunc(entries, peer.sentORF[family], func(a, b *bgp.AddressPrefixORFEntry) bool { return *a == *b }) {
+			continue
+		}
+		peer.sentORF[family] = entries
+
+		l := append([]*bgp.AddressPrefixORFEntry{bgp.NewAddressPrefixORFEntry(bgp.ORF_ACTION_REMOVE_ALL, bgp.ORF_MATCH_PERMIT, 0, 0, 0, netip.Prefix{})}, entries...)
+		for len(l) > 0 {
+			n := min(len(l), maxEntries)
+			// RFC 5291 4.
+			// The peer defers re-advertisement till the last message.
+			when := bgp.ORF_WHEN_TO_REFRESH_DEFER
+			if n == len(l) {
+				when = bgp.ORF_WHEN_TO_REFRESH_IMMEDIATE
+			}
+			msgs = append(msgs, bgp.NewBGPRouteRefreshORFMessage(family, when, []*bgp.RouteRefreshORF{bgp.NewRouteRefreshAddressPrefixORF(l[:n])}))
+			l = l[n:]
+		}
+	}
+	if len(msgs) > 0 {
+		peer.fsm.outgoingCh.In() <- &fsmOutgoingMsg{
+			Messages: msgs,
+		}
+	}
 }
 
 func (s *BgpServer) propagateUpdate(peer *peer, pathList []*table.Path) {
@@ -1095,6 +1502,7 @@ func (s *BgpServer) propagateUpdate(peer *peer, pathList []*table.Path) {
 	}
 
 	for _, path := range pathList {
+		received := path
 		if vrf {
 			peer.fsm.lock.Lock()
 			peerVrf := peer.fsm.pConf.Config.Vrf
@@ -1106,7 +1514,8 @@ func (s *BgpServer) propagateUpdate(peer *peer, pathList []*table.Path) {
 		}
 
 		policyOptions := &table.PolicyOptions{
-			Validate: s.roaTable.Validate,
+			Validate:       s.roaTable.Validate,
+			ValidateBGPsec: s.validateBGPsec,
 		}
 
 		if !rs && peer != nil {
@@ -1118,6 +1527,9 @@ func (s *BgpServer) propagateUpdate(peer *peer, pathList []*table.Path) {
 		} else {
 			path = path.Clone(true)
 		}
+		if peer != nil {
+			peer.updateAcceptedPath(received, !path.IsWithdraw)
+		}
 
 		if !rs {
 			s.notifyPostPolicyUpdateWatcher(peer, []*table.Path{path})
@@ -1145,7 +1557,7 @@ func (s *BgpServer) propagateUpdate(peer *peer, pathList []*table.Path) {
 				if path.IsWithdraw {
 					// Note: The paths to be withdrawn are filtered because the
 					// given RT on RTM NLRI is already removed from adj-RIB-in.
-					_, candidates = s.getBestFromLocal(peer, fs, true)
+					_, candidates, _ = s.viewBestFromLocal(peer, fs, true)
 				} else {
 					// https://github.com/osrg/gobgp/issues/1777
 					// Ignore duplicate Membership announcements
@@ -1183,10 +1595,10 @@ func (s *BgpServer) propagateUpdate(peer *peer, pathList []*table.Path) {
 				if path.IsWithdraw {
 					// Skips filtering because the paths are already filtered
 					// and the withdrawal does not need the path attributes.
-					sendfsmOutgoingMsg(peer, paths)
+					s.sendfsmOutgoingMsg(peer, paths, paths)
 				} else if !peer.getRtcEORWait() {
-					paths = s.processOutgoingPaths(peer, paths, nil)
-					sendfsmOutgoingMsg(peer, paths)
+					paths, pre := s.processOutgoingPaths(peer, paths, nil, nil)
+					s.sendfsmOutgoingMsg(peer, paths, pre)
 				} else {
 					peer.fsm.logger.Debug("Nothing sent in response to RT received. Waiting for RTC EOR.", slog.Any("Path", path))
 				}
@@ -1220,6 +1632,94 @@ func dstsToPaths(id string, as uint32, dsts []*table.Update) ([]*table.Path, []*
 	return bestList, oldList, mpathList
 }
 
+func alternativesToPaths(peer *peer, alt table.AlternativePath, dsts []*table.Update) ([]*table.Path, []*table.Path) {
+	bestList := make([]*table.Path, 0, len(dsts))
+	oldList := make([]*table.Path, 0, len(dsts))
+
+	for _, dst := range dsts {
+		best, old := dst.GetAlternativeChanges(peer.TableID(), peer.AS(), alt, peer.peerInfo)
+		bestList = append(bestList, best)
+		oldList = append(oldList, old)
+	}
+	return bestList, oldList
+}
+
+// alternativeAddPaths keeps the alternative paths sent to the add-path
+// peer up to date. Each alternative path has a slot of its own, outside
+// send-max. When the alternative path changes, the path which held the
+// slot takes a free send-max slot or is withdrawn. It returns the paths to
+// send and the paths before the export policy is applied.
+func (s *BgpServer) alternativeAddPaths(peer *peer, dsts []*table.Update) ([]*table.Path, []*table.Path) {
+	alts := peer.alternativePaths()
+	if len(alts) == 0 {
+		return nil, nil
+	}
+	var pathList, preList []*table.Path
+	for _, dst := range dsts {
+		lookup := func(id uint32) *table.Path {
+			for _, l := range [][]*table.Path{dst.KnownPathList, dst.OldKnownPathList} {
+				for _, p := range l {
+					if p.LocalID() == id {
+						return p
+					}
+				}
+			}
+			return nil
+		}
+		var key table.PathDestLocalKey
+		if len(dst.KnownPathList) > 0 {
+			key = peer.sentDestLocalKey(dst.KnownPathList[0])
+		} else if len(dst.OldKnownPathList) > 0 {
+			key = peer.sentDestLocalKey(dst.OldKnownPathList[0])
+		} else {
+			continue
+		}
+		for _, alt := range alts {
+			var path, pre *table.Path
+			if p := dst.GetAlternativePath(peer.TableID(), peer.AS(), alt, peer.peerInfo); p != nil {
+				path, pre = s.filterpath(peer, p, nil)
+			}
+			sentID, held := peer.sentAlternativePaths[key][alt]
+			if held && path != nil && sentID == path.LocalID() {
+				continue
+			}
+			if held {
+				delete(peer.sentAlternativePaths[key], alt)
+				if old := lookup(sentID); old != nil && !peer.isSent(key, sentID) {
+					withdraw := true
+					if slices.Contains(dst.KnownPathList, old) {
+						if o, _ := s.exportFilterpath(peer, old, nil, true, nil); o != nil {
+							// already advertised, it only needs a free send-max slot
+							if peer.getRoutesCount(key.Family, key.Prefix) < peer.getAddPathSendMax(key.Family) {
+								peer.updateRoutes(o)
+								withdraw = false
+							} else {
+								peer.sendMaxPathFiltered[o.GetLocalKey()] = struct{}{}
+							}
+						}
+					}
+					if withdraw {
+						if w, wpre := s.filterpath(peer, old.Clone(true), nil); w != nil {
+							pathList = append(pathList, w)
+							preList = append(preList, wpre)
+						}
+					}
+				}
+			}
+			if path == nil {
+				continue
+			}
+			if !peer.isSent(key, path.LocalID()) {
+				peer.unsetPathSendMaxFiltered(path)
+				pathList = append(pathList, path)
+				preList = append(preList, pre)
+			}
+			peer.setAlternativeSent(alt, path)
+		}
+	}
+	return pathList, preList
+}
+
 func (s *BgpServer) propagateUpdateToNeighbors(rib *table.TableManager, source *peer, newPath *table.Path, dsts []*table.Update, needOld bool) {
 	if table.SelectionOptions.DisableBestPathSelection {
 		return
@@ -1231,6 +1731,8 @@ func (s *BgpServer) propagateUpdateToNeighbors(rib *table.TableManager, source *
 		s.notifyBestWatcher(gBestList, mpathList)
 	}
 	family := newPath.GetFamily()
+	// the peers in update groups are sent the messages built once per group
+	var batches groupBatches
 	for _, targetPeer := range s.neighborMap {
 		if source == nil && targetPeer.isRouteServerClient() || source != nil && source.isRouteServerClient() != targetPeer.isRouteServerClient() {
 			continue
@@ -1254,6 +1756,7 @@ func (s *BgpServer) propagateUpdateToNeighbors(rib *table.TableManager, source *
 			return family
 		}()
 		if targetPeer.isAddPathSendEnabled(f) {
+			var preList []*table.Path
 			// in case of multiple paths to the same destination, we need to
 			// filter the paths before counting the number of paths to be sent.
 			if newPath.IsWithdraw {
@@ -1262,14 +1765,20 @@ func (s *BgpServer) propagateUpdateToNeighbors(rib *table.TableManager, source *
 					for _, d := range dsts {
 						toDelete := d.GetWithdrawnPath()
 						toActuallyDelete := make([]*table.Path, 0, len(toDelete))
+						// the alternative paths don't free send-max slots
+						freed := 0
 						for _, p := range toDelete {
 							// if the path is filtered, there is no need to send the withdrawal
-							p := s.filterpath(targetPeer, p, nil)
+							p, pre := s.filterpath(targetPeer, p, nil)
 							// the path was never advertized to the peer
 							if p == nil || targetPeer.unsetPathSendMaxFiltered(p) {
 								continue
 							}
+							if targetPeer.isSentWithinSendMax(p) {
+								freed++
+							}
 							toActuallyDelete = append(toActuallyDelete, p)
+							preList = append(preList, pre)
 						}
 
 						if len(toActuallyDelete) == 0 {
@@ -1281,7 +1790,7 @@ func (s *BgpServer) propagateUpdateToNeighbors(rib *table.TableManager, source *
 
 						// the destination has been removed from the table
 						// e.g. no more paths to it
-						if destination == nil {
+						if destination == nil || freed == 0 {
 							continue
 						}
 
@@ -1290,14 +1799,15 @@ func (s *BgpServer) propagateUpdateToNeighbors(rib *table.TableManager, source *
 						for _, p := range knownPathList {
 							// If the path is filtered by policies, there is no need to send the path
 							// Otherwise, we send only paths that were previously filtered because of the max path limit
-							p := s.filterpath(targetPeer, p, nil)
+							p, pre := s.filterpath(targetPeer, p, nil)
 							if p == nil || !targetPeer.isPathSendMaxFiltered(p) {
 								continue
 							}
 							// We unset the flag as the path is not filtered anymore
 							targetPeer.unsetPathSendMaxFiltered(p)
 							toAdd = append(toAdd, p)
-							if len(toAdd) == len(toActuallyDelete) {
+							preList = append(preList, pre)
+							if len(toAdd) == freed {
 								break
 							}
 						}
@@ -1306,14 +1816,18 @@ func (s *BgpServer) propagateUpdateToNeighbors(rib *table.TableManager, source *
 					targetPeer.updateRoutes(l...)
 					return l
 				}()
+				altList, altPreList := s.alternativeAddPaths(targetPeer, dsts)
+				bestList = append(bestList, altList...)
+				preList = append(preList, altPreList...)
 			} else {
 				alreadySent := targetPeer.hasPathAlreadyBeenSent(newPath)
-				newPath := s.filterpath(targetPeer, newPath, nil)
+				newPath, pre := s.filterpath(targetPeer, newPath, nil)
 				// if the path is not filtered and the path has already been sent or land in the limit, we can send it
 				if newPath == nil {
 					bestList = []*table.Path{}
 				} else if alreadySent || targetPeer.getRoutesCount(f, newPath.GetPrefix()) < targetPeer.getAddPathSendMax(f) {
 					bestList = []*table.Path{newPath}
+					preList = []*table.Path{pre}
 					if !alreadySent {
 						targetPeer.updateRoutes(newPath)
 					}
@@ -1329,22 +1843,30 @@ func (s *BgpServer) propagateUpdateToNeighbors(rib *table.TableManager, source *
 					}
 				} else {
 					bestList = []*table.Path{}
+				}
+				altList, altPreList := s.alternativeAddPaths(targetPeer, dsts)
+				bestList = append(bestList, altList...)
+				preList = append(preList, altPreList...)
+				// the path may have taken the slot of an alternative path
+				if newPath != nil && !targetPeer.hasPathAlreadyBeenSent(newPath) {
 					targetPeer.sendMaxPathFiltered[newPath.GetLocalKey()] = struct{}{}
 					targetPeer.fsm.logger.Warn("exceeding max routes for prefix", slog.String("Prefix", newPath.GetPrefix()))
 				}
 			}
 			if needToAdvertise(targetPeer) && len(bestList) > 0 {
-				sendfsmOutgoingMsg(targetPeer, bestList)
+				s.sendfsmOutgoingMsg(targetPeer, bestList, preList)
 			}
 		} else {
 			if targetPeer.isRouteServerClient() {
 				if targetPeer.isSecondaryRouteEnabled() {
-					if paths := s.sendSecondaryRoutes(targetPeer, newPath, dsts); len(paths) > 0 {
-						sendfsmOutgoingMsg(targetPeer, paths)
+					if paths := s.sendSecondaryRoutes(targetPeer, newPath, dsts, false); len(paths) > 0 {
+						s.sendfsmOutgoingMsg(targetPeer, paths, nil)
 					}
 					continue
 				}
 				bestList, oldList, _ = dstsToPaths(targetPeer.TableID(), targetPeer.AS(), dsts)
+			} else if alt := targetPeer.alternativePath(); alt != table.ALTERNATIVE_PATH_NONE {
+				bestList, oldList = alternativesToPaths(targetPeer, alt, dsts)
 			} else {
 				bestList = gBestList
 				oldList = gOldList
@@ -1352,15 +1874,20 @@ func (s *BgpServer) propagateUpdateToNeighbors(rib *table.TableManager, source *
 			if !needOld {
 				oldList = nil
 			}
-			if paths := s.processOutgoingPaths(targetPeer, bestList, oldList); len(paths) > 0 {
-				sendfsmOutgoingMsg(targetPeer, paths)
+			if batches.add(targetPeer, bestList, oldList) {
+				continue
+			}
+			if paths, pre := s.processOutgoingPaths(targetPeer, bestList, oldList, nil); len(paths) > 0 {
+				s.sendfsmOutgoingMsg(targetPeer, paths, pre)
 			}
 		}
 	}
+	s.sendGroupBatches(&batches)
 }
 
 func (s *BgpServer) stopNeighbor(peer *peer, oldState bgp.FSMState, e *fsmMsg) {
 	peer.stopPeerRestarting()
+	s.leaveUpdateGroup(peer)
 	delete(s.neighborMap, netip.MustParseAddr(peer.ID()))
 	peer.stopFSM()
 	s.broadcastPeerState(peer, bgp.BGP_FSM_IDLE, oldState, e)
@@ -1413,7 +1940,12 @@ func (s *BgpServer) handleFSMMessage(peer *peer, e *fsmMsg) {
 			}
 			peer.fsm.lock.Unlock()
 
-			peer.prefixLimitWarned = make(map[bgp.Family]bool)
+			peer.resetPrefixLimits()
+			peer.receivedORF = make(map[bgp.Family]*table.PrefixORF)
+			peer.sentORF = make(map[bgp.Family][]*bgp.AddressPrefixORFEntry)
+			peer.orfAdvertised = make(advertisedPaths)
+			peer.adjRibOutPre = nil
+			peer.adjRibOutPost = nil
 			s.propagateUpdate(peer, peer.DropAll(dropFamilies))
 
 			peer.fsm.lock.Lock()
@@ -1512,6 +2044,9 @@ func (s *BgpServer) handleFSMMessage(peer *peer, e *fsmMsg) {
 
 		drainChannel(peer.fsm.outgoingCh.Out())
 
+		if nextState != bgp.BGP_FSM_ESTABLISHED {
+			s.leaveUpdateGroup(peer)
+		}
 		if nextState == bgp.BGP_FSM_ESTABLISHED {
 			peer.fsm.lock.Lock()
 
@@ -1612,6 +2147,8 @@ func (s *BgpServer) handleFSMMessage(peer *peer, e *fsmMsg) {
 				}
 			}
 
+			s.joinUpdateGroup(peer)
+
 			deferralExpiredFunc := func(family bgp.Family) func() {
 				//nolint: errcheck // ignore error
 				return func() {
@@ -1620,6 +2157,8 @@ func (s *BgpServer) handleFSMMessage(peer *peer, e *fsmMsg) {
 					}, false)
 				}
 			}
+			s.sendORF(peer, peer.negotiatedRFList(), "")
+
 			peer.fsm.lock.Lock()
 			notLocalRestarting := !peer.fsm.pConf.GracefulRestart.State.LocalRestarting
 			peer.fsm.lock.Unlock()
@@ -1633,14 +2172,14 @@ func (s *BgpServer) handleFSMMessage(peer *peer, e *fsmMsg) {
 				// However, when the peer is graceful restarting, give up
 				// waiting sending non-route-target NLRIs since the peer won't send
 				// any routes (and EORs) before we send ours (or deferral-timer expires).
-				var pathList []*table.Path
+				var pathList, preList []*table.Path
 				peer.fsm.lock.Lock()
 				c := peer.fsm.pConf.GetAfiSafi(bgp.RF_RTC_UC)
 				notPeerRestarting := !peer.fsm.pConf.GracefulRestart.State.PeerRestarting
 				peer.fsm.lock.Unlock()
 				if y := peer.IsFamilyEnabled(bgp.RF_RTC_UC); y && notPeerRestarting && c.RouteTargetMembership.Config.DeferralTime > 0 {
 					peer.setRtcEORWait(true)
-					pathList, _ = s.getBestFromLocal(peer, []bgp.Family{bgp.RF_RTC_UC}, true)
+					pathList, _, preList = s.getBestFromLocal(peer, []bgp.Family{bgp.RF_RTC_UC}, true)
 					t := c.RouteTargetMembership.Config.DeferralTime
 					for _, f := range peer.negotiatedRFList() {
 						if f != bgp.RF_RTC_UC {
@@ -1648,11 +2187,11 @@ func (s *BgpServer) handleFSMMessage(peer *peer, e *fsmMsg) {
 						}
 					}
 				} else {
-					pathList, _ = s.getBestFromLocal(peer, peer.negotiatedRFList(), true)
+					pathList, _, preList = s.getBestFromLocal(peer, peer.negotiatedRFList(), true)
 				}
 
 				if len(pathList) > 0 {
-					sendfsmOutgoingMsg(peer, pathList)
+					s.sendfsmOutgoingMsg(peer, pathList, preList)
 				}
 			} else {
 				// RFC 4724 4.1
@@ -1680,9 +2219,9 @@ func (s *BgpServer) handleFSMMessage(peer *peer, e *fsmMsg) {
 						if !p.isGracefulRestartEnabled() && !peerLocalRestarting {
 							continue
 						}
-						paths, _ := s.getBestFromLocal(p, p.configuredRFlist(), true)
+						paths, _, pre := s.getBestFromLocal(p, p.configuredRFlist(), true)
 						if len(paths) > 0 {
-							sendfsmOutgoingMsg(p, paths)
+							s.sendfsmOutgoingMsg(p, paths, pre)
 						}
 					}
 					peer.fsm.logger.Info("sync finished")
@@ -1724,13 +2263,12 @@ func (s *BgpServer) handleFSMMessage(peer *peer, e *fsmMsg) {
 		}
 		switch m.Header.Type {
 		case bgp.BGP_MSG_ROUTE_REFRESH:
-			if paths := s.handleRouteRefresh(peer, e); len(paths) > 0 {
-				sendfsmOutgoingMsg(peer, paths)
+			if paths, pre := s.handleRouteRefresh(peer, e); len(paths) > 0 {
+				s.sendfsmOutgoingMsg(peer, paths, pre)
 			}
 		case bgp.BGP_MSG_UPDATE:
-			pathList, eor, isLimit := peer.handleUpdate(e)
-			if isLimit {
-				_ = s.setAdminState(peer.ID(), "", adminStatePfxCt)
+			pathList, eor := peer.handleUpdate(e)
+			if s.checkPrefixLimits(peer, oc.PREFIX_LIMIT_COUNT_TYPE_RECEIVED) {
 				return
 			}
 			if m.Header.Type == bgp.BGP_MSG_UPDATE {
@@ -1739,6 +2277,9 @@ func (s *BgpServer) handleFSMMessage(peer *peer, e *fsmMsg) {
 
 			if len(pathList) > 0 {
 				s.propagateUpdate(peer, pathList)
+				if s.checkPrefixLimits(peer, oc.PREFIX_LIMIT_COUNT_TYPE_ACCEPTED) {
+					return
+				}
 			}
 
 			peer.fsm.lock.Lock()
@@ -1794,9 +2335,9 @@ func (s *BgpServer) handleFSMMessage(peer *peer, e *fsmMsg) {
 							if !p.isGracefulRestartEnabled() && !peerLocalRestarting {
 								continue
 							}
-							paths, _ := s.getBestFromLocal(p, p.negotiatedRFList(), true)
+							paths, _, pre := s.getBestFromLocal(p, p.negotiatedRFList(), true)
 							if len(paths) > 0 {
-								sendfsmOutgoingMsg(p, paths)
+								s.sendfsmOutgoingMsg(p, paths, pre)
 							}
 						}
 						s.logger.Info("sync finished",
@@ -1835,8 +2376,8 @@ func (s *BgpServer) handleFSMMessage(peer *peer, e *fsmMsg) {
 							families = append(families, f)
 						}
 					}
-					if paths, _ := s.getBestFromLocal(peer, families, true); len(paths) > 0 {
-						sendfsmOutgoingMsg(peer, paths)
+					if paths, _, pre := s.getBestFromLocal(peer, families, true); len(paths) > 0 {
+						s.sendfsmOutgoingMsg(peer, paths, pre)
 					}
 				}
 			}
@@ -1906,6 +2447,8 @@ func (s *BgpServer) parseExportRule(ruleConfig *oc.NetlinkExportRule) (*exportRu
 		rule.LargeCommunities = append(rule.LargeCommunities, lc)
 	}
 
+	rule.Tags = slices.Clone(ruleConfig.TagList)
+
 	return rule, nil
 }
 
@@ -1919,6 +2462,17 @@ func (s *BgpServer) StartNetlink(ctx context.Context) error {
 		s.netlinkClient = n
 	}
 
+	if s.bgpConfig.Netlink.Ls.Enabled {
+		if s.netlinkLsClient == nil {
+			s.netlinkLsClient = newNetlinkLsClient(s)
+		} else {
+			s.netlinkLsClient.rescan()
+		}
+	} else if s.netlinkLsClient != nil {
+		s.netlinkLsClient.stop()
+		s.netlinkLsClient = nil
+	}
+
 	// Initialize export client if export is enabled
 	if s.bgpConfig.Netlink.Export.Enabled {
 		// Create export client if it doesn't exist
@@ -2025,6 +2579,25 @@ func (s *BgpServer) GetNetlinkExportStats(ctx context.Context, req *api.GetNetli
 
 	stats := s.netlinkExportClient.getStats()
 
+	ruleStats := s.netlinkExportClient.getRuleStats()
+	rules := make([]*api.GetNetlinkExportStatsResponse_RuleStats, 0, len(ruleStats))
+	for key, st := range ruleStats {
+		rules = append(rules, &api.GetNetlinkExportStatsResponse_RuleStats{
+			Rule:                      key.Rule,
+			Vrf:                       key.Vrf,
+			Exported:                  st.Exported,
+			Withdrawn:                 st.Withdrawn,
+			Errors:                    st.Errors,
+			NexthopValidationFailures: st.NexthopFailed,
+		})
+	}
+	slices.SortFunc(rules, func(a, b *api.GetNetlinkExportStatsResponse_RuleStats) int {
+		if c := strings.Compare(a.Rule, b.Rule); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Vrf, b.Vrf)
+	})
+
 	return &api.GetNetlinkExportStatsResponse{
 		Exported:                  stats.Exported,
 		Withdrawn:                 stats.Withdrawn,
@@ -2036,6 +2609,7 @@ func (s *BgpServer) GetNetlinkExportStats(ctx context.Context, req *api.GetNetli
 		LastWithdrawTime:          stats.LastWithdraw.Unix(),
 		LastErrorTime:             stats.LastError.Unix(),
 		LastErrorMsg:              stats.LastErrorMsg,
+		Rules:                     rules,
 	}, nil
 }
 
@@ -2077,6 +2651,7 @@ func (s *BgpServer) ListNetlinkExportRules(ctx context.Context, req *api.ListNet
 			Name:               rule.Name,
 			CommunityList:      communityList,
 			LargeCommunityList: largeCommunityList,
+			TagList:            rule.Tags,
 			Vrf:                rule.VrfName,
 			TableId:            int32(rule.TableId),
 			Metric:             rule.Metric,
@@ -2120,14 +2695,34 @@ func (s *BgpServer) ListNetlinkExportRules(ctx context.Context, req *api.ListNet
 	}, nil
 }
 
+// newBmpRouteMonitoringPolicyFromAPI converts the policy of the API, whose
+// enum starts with UNSPECIFIED, which is PRE, unlike the config one.
+func newBmpRouteMonitoringPolicyFromAPI(p api.AddBmpRequest_MonitoringPolicy) (oc.BmpRouteMonitoringPolicyType, error) {
+	if _, ok := api.AddBmpRequest_MonitoringPolicy_name[int32(p)]; !ok {
+		return "", fmt.Errorf("invalid bmp route monitoring policy: %v", p)
+	}
+	if p == api.AddBmpRequest_MONITORING_POLICY_UNSPECIFIED {
+		return oc.BMP_ROUTE_MONITORING_POLICY_TYPE_PRE_POLICY, nil
+	}
+	return oc.IntToBmpRouteMonitoringPolicyTypeMap[int(p)-1], nil
+}
+
 func (s *BgpServer) AddBmp(ctx context.Context, r *api.AddBmpRequest) error {
 	if r == nil {
 		return fmt.Errorf("nil request")
 	}
 	return s.mgmtOperation(func() error {
-		_, ok := api.AddBmpRequest_MonitoringPolicy_name[int32(r.Policy)]
-		if !ok {
-			return fmt.Errorf("invalid bmp route monitoring policy: %v", r.Policy)
+		policy, err := newBmpRouteMonitoringPolicyFromAPI(r.Policy)
+		if err != nil {
+			return err
+		}
+		overflowAction := oc.BMP_QUEUE_OVERFLOW_ACTION_TYPE_DROP
+		switch r.QueueOverflowAction {
+		case api.AddBmpRequest_QUEUE_OVERFLOW_ACTION_UNSPECIFIED, api.AddBmpRequest_QUEUE_OVERFLOW_ACTION_DROP:
+		case api.AddBmpRequest_QUEUE_OVERFLOW_ACTION_DISCONNECT:
+			overflowAction = oc.BMP_QUEUE_OVERFLOW_ACTION_TYPE_DISCONNECT
+		default:
+			return fmt.Errorf("invalid bmp queue overflow action: %v", r.QueueOverflowAction)
 		}
 		port := r.Port
 		if port == 0 {
@@ -2148,12 +2743,21 @@ func (s *BgpServer) AddBmp(ctx context.Context, r *api.AddBmpRequest) error {
 			slog.String("Policy", r.Policy.String()))
 
 		return s.bmpManager.addServer(&oc.BmpServerConfig{
-			Address:               netip.MustParseAddr(r.Address),
-			Port:                  port,
-			SysName:               sysname,
-			SysDescr:              sysDescr,
-			RouteMonitoringPolicy: oc.IntToBmpRouteMonitoringPolicyTypeMap[int(r.Policy)],
-			StatisticsTimeout:     uint16(r.StatisticsTimeout),
+			Address:                    netip.MustParseAddr(r.Address),
+			Port:                       port,
+			SysName:                    sysname,
+			SysDescr:                   sysDescr,
+			RouteMonitoringPolicy:      policy,
+			StatisticsTimeout:          uint16(r.StatisticsTimeout),
+			AdjRibOutPrePolicyEnabled:  r.AdjRibOutPrePolicy,
+			AdjRibOutPostPolicyEnabled: r.AdjRibOutPostPolicy,
+			TlsEnabled:                 r.Tls,
+			TlsCaFile:                  r.TlsCaFile,
+			TlsCertFile:                r.TlsCertFile,
+			TlsKeyFile:                 r.TlsKeyFile,
+			TlsServerName:              r.TlsServerName,
+			QueueSize:                  r.QueueSize,
+			QueueOverflowAction:        overflowAction,
 		})
 	}, true)
 }
@@ -2219,12 +2823,21 @@ func (s *BgpServer) ListBmp(ctx context.Context, req *api.ListBmpRequest, fn fun
 		for _, s := range s.bmpManager.clientMap {
 			stations = append(stations, &api.ListBmpResponse_BmpStation{
 				Conf: &api.ListBmpResponse_BmpStation_Conf{
-					Address: s.c.Address.String(),
-					Port:    s.c.Port,
+					Address:             s.c.Address.String(),
+					Port:                s.c.Port,
+					Tls:                 s.c.TlsEnabled,
+					QueueSize:           s.c.QueueSize,
+					QueueOverflowAction: bmpQueueOverflowActionToApi(s.c.QueueOverflowAction),
 				},
 				State: &api.ListBmpResponse_BmpStation_State{
-					Uptime:   oc.ProtoTimestamp(atomic.LoadInt64(&s.uptime)),
-					Downtime: oc.ProtoTimestamp(atomic.LoadInt64(&s.downtime)),
+					Uptime:     oc.ProtoTimestamp(atomic.LoadInt64(&s.uptime)),
+					Downtime:   oc.ProtoTimestamp(atomic.LoadInt64(&s.downtime)),
+					Dropped:    uint64(atomic.LoadInt64(&s.dropped)),
+					Queued:     uint32(atomic.LoadInt64(&s.queued)),
+					Resyncs:    uint64(atomic.LoadInt64(&s.resyncs)),
+					LastResync: oc.ProtoTimestamp(atomic.LoadInt64(&s.lastResync)),
+					Up:         atomic.LoadInt32(&s.up) != 0,
+					Reconnects: uint64(atomic.LoadInt64(&s.reconnects)),
 				},
 			})
 		}
@@ -2242,52 +2855,214 @@ func (s *BgpServer) ListBmp(ctx context.Context, req *api.ListBmpRequest, fn fun
 	return nil
 }
 
-func (s *BgpServer) StopBgp(ctx context.Context, r *api.StopBgpRequest) error {
+// EnableBmpStation starts accepting the BMP sessions from the monitored
+// routers.
+func (s *BgpServer) EnableBmpStation(ctx context.Context, r *api.EnableBmpStationRequest) error {
 	if r == nil {
 		return fmt.Errorf("nil request")
 	}
-	err := s.mgmtOperation(func() error {
-		defer s.runningCancel()
-
-		for address, neighbor := range s.neighborMap {
-			c := &oc.Neighbor{Config: oc.NeighborConfig{
-				NeighborAddress: address,
-			}}
-			neighbor.fsm.lock.Lock()
-			sendNotification := !r.AllowGracefulRestart || !neighbor.fsm.pConf.GracefulRestart.Config.Enabled
-			neighbor.fsm.lock.Unlock()
-			if err := s.deleteNeighbor(c, bgp.BGP_ERROR_CEASE, bgp.BGP_ERROR_SUB_PEER_DECONFIGURED, sendNotification); err != nil {
-				return err
-			}
-		}
-		for _, l := range s.listeners {
-			l.Close()
+	c := &oc.BmpStationConfig{
+		Enabled:             true,
+		Port:                r.Port,
+		MrtFileName:         r.MrtFilename,
+		MrtDumpInterval:     r.MrtDumpInterval,
+		MrtRotationInterval: r.MrtRotationInterval,
+	}
+	if r.Address != "" {
+		addr, err := netip.ParseAddr(r.Address)
+		if err != nil {
+			return fmt.Errorf("invalid address: %s", r.Address)
 		}
-		s.bgpConfig.Global = oc.Global{}
-		return nil
+		c.Address = addr
+	}
+	switch r.MrtDumpType {
+	case api.EnableMrtRequest_DUMP_TYPE_UPDATES:
+		c.MrtDumpType = oc.MRT_TYPE_UPDATES
+	case api.EnableMrtRequest_DUMP_TYPE_TABLE:
+		c.MrtDumpType = oc.MRT_TYPE_TABLE
+	}
+	return s.mgmtOperation(func() error {
+		return s.enableBmpStation(c)
 	}, false)
+}
+
+func (s *BgpServer) enableBmpStation(c *oc.BmpStationConfig) error {
+	if s.bmpStation != nil {
+		return fmt.Errorf("bmp station is already enabled")
+	}
+	st, err := newBmpStation(s, c)
 	if err != nil {
 		return err
 	}
-
-	s.shutdownWG.Wait()
+	s.logger.Info("bmp station enabled",
+		slog.String("Topic", "bmp"),
+		slog.String("Key", st.listener.Addr().String()))
+	s.bmpStation = st
 	return nil
 }
 
-func (s *BgpServer) SetPolicies(ctx context.Context, r *api.SetPoliciesRequest) error {
+// DisableBmpStation closes the BMP sessions from the monitored routers and
+// drops their tables.
+func (s *BgpServer) DisableBmpStation(ctx context.Context, r *api.DisableBmpStationRequest) error {
 	if r == nil {
 		return fmt.Errorf("nil request")
 	}
-	rp, err := newRoutingPolicyFromApiStruct(r)
+	return s.mgmtOperation(func() error {
+		if s.bmpStation == nil {
+			return fmt.Errorf("bmp station is not enabled")
+		}
+		s.bmpStation.stop()
+		s.bmpStation = nil
+		return nil
+	}, false)
+}
+
+func (s *BgpServer) getBmpStation() (*bmpStation, error) {
+	var st *bmpStation
+	err := s.mgmtOperation(func() error {
+		if s.bmpStation == nil {
+			return fmt.Errorf("bmp station is not enabled")
+		}
+		st = s.bmpStation
+		return nil
+	}, false)
+	return st, err
+}
+
+func (s *BgpServer) ListBmpRouter(ctx context.Context, r *api.ListBmpRouterRequest, fn func(*api.BmpRouter)) error {
+	if r == nil {
+		return fmt.Errorf("nil request")
+	}
+	st, err := s.getBmpStation()
 	if err != nil {
 		return err
 	}
-
-	getConfig := func(id string) (*oc.ApplyPolicy, error) {
-		f := func(id string, dir table.PolicyDirection) (oc.DefaultPolicyType, []string, error) {
-			rt, policies, err := s.policy.GetPolicyAssignment(id, dir)
-			if err != nil {
-				return oc.DEFAULT_POLICY_TYPE_REJECT_ROUTE, nil, err
+	var l []*api.BmpRouter
+	st.listRouter(func(r *api.BmpRouter) {
+		l = append(l, r)
+	})
+	for _, r := range l {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		fn(r)
+	}
+	return nil
+}
+
+func (s *BgpServer) ListBmpMonitoredPeer(ctx context.Context, r *api.ListBmpMonitoredPeerRequest, fn func(*api.BmpMonitoredPeer)) error {
+	if r == nil {
+		return fmt.Errorf("nil request")
+	}
+	var router netip.Addr
+	if r.Router != "" {
+		addr, err := netip.ParseAddr(r.Router)
+		if err != nil {
+			return fmt.Errorf("invalid router address: %s", r.Router)
+		}
+		router = addr
+	}
+	st, err := s.getBmpStation()
+	if err != nil {
+		return err
+	}
+	var l []*api.BmpMonitoredPeer
+	st.listPeer(router, func(p *api.BmpMonitoredPeer) {
+		l = append(l, p)
+	})
+	for _, p := range l {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		fn(p)
+	}
+	return nil
+}
+
+func (s *BgpServer) ListBmpRoute(ctx context.Context, r *api.ListBmpRouteRequest, fn func(prefix bgp.NLRI, paths []*apiutil.Path)) error {
+	if r == nil || r.Family == nil {
+		return fmt.Errorf("nil request")
+	}
+	router, err := netip.ParseAddr(r.Router)
+	if err != nil {
+		return fmt.Errorf("invalid router address: %s", r.Router)
+	}
+	var peer netip.Addr
+	if r.RibType != api.ListBmpRouteRequest_RIB_TYPE_LOC_RIB {
+		peer, err = netip.ParseAddr(r.Peer)
+		if err != nil {
+			return fmt.Errorf("invalid peer address: %s", r.Peer)
+		}
+	}
+	st, err := s.getBmpStation()
+	if err != nil {
+		return err
+	}
+	tbl, err := st.selectRib(router, r.RibType, peer, r.Distinguisher, bgp.NewFamily(uint16(r.Family.Afi), uint8(r.Family.Safi)))
+	if err != nil {
+		return err
+	}
+	for _, dst := range tbl.GetDestinations() {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		knownPathList := dst.GetAllKnownPathList()
+		paths := make([]*apiutil.Path, 0, len(knownPathList))
+		for _, path := range knownPathList {
+			paths = append(paths, toPathApiUtil(path))
+		}
+		fn(dst.GetNlri(), paths)
+	}
+	return nil
+}
+
+func (s *BgpServer) StopBgp(ctx context.Context, r *api.StopBgpRequest) error {
+	if r == nil {
+		return fmt.Errorf("nil request")
+	}
+	err := s.mgmtOperation(func() error {
+		defer s.runningCancel()
+
+		for address, neighbor := range s.neighborMap {
+			c := &oc.Neighbor{Config: oc.NeighborConfig{
+				NeighborAddress: address,
+			}}
+			neighbor.fsm.lock.Lock()
+			sendNotification := !r.AllowGracefulRestart || !neighbor.fsm.pConf.GracefulRestart.Config.Enabled
+			neighbor.fsm.lock.Unlock()
+			if err := s.deleteNeighbor(c, bgp.BGP_ERROR_CEASE, bgp.BGP_ERROR_SUB_PEER_DECONFIGURED, sendNotification); err != nil {
+				return err
+			}
+		}
+		for _, l := range s.listeners {
+			l.Close()
+		}
+		s.mrtReplays.stopAll()
+		s.bgpConfig.Global = oc.Global{}
+		return nil
+	}, false)
+	if err != nil {
+		return err
+	}
+
+	s.shutdownWG.Wait()
+	return nil
+}
+
+func (s *BgpServer) SetPolicies(ctx context.Context, r *api.SetPoliciesRequest) error {
+	if r == nil {
+		return fmt.Errorf("nil request")
+	}
+	rp, err := newRoutingPolicyFromApiStruct(r)
+	if err != nil {
+		return err
+	}
+
+	getConfig := func(id string) (*oc.ApplyPolicy, error) {
+		f := func(id string, dir table.PolicyDirection) (oc.DefaultPolicyType, []string, error) {
+			rt, policies, err := s.policy.GetPolicyAssignment(id, dir)
+			if err != nil {
+				return oc.DEFAULT_POLICY_TYPE_REJECT_ROUTE, nil, err
 			}
 			names := make([]string, 0, len(policies))
 			for _, p := range policies {
@@ -2332,7 +3107,8 @@ func (s *BgpServer) SetPolicies(ctx context.Context, r *api.SetPoliciesRequest)
 			}
 			ap[peer.ID()] = *a
 		}
-		return s.policy.Reset(rp, ap)
+		s.definedSetFiles.mergeDefinedSetFiles(&rp.DefinedSets)
+		return s.policyUpdated(s.policy.Reset(rp, ap))
 	}, false)
 }
 
@@ -2720,6 +3496,16 @@ func (s *BgpServer) StartBgp(ctx context.Context, r *api.StartBgpRequest) error
 			s.acceptCh = acceptCh
 		}
 
+		if file := c.BgpsecSigning.Config.PrivateKeyFile; file != "" {
+			signer, err := loadBGPsecSigner(file)
+			if err != nil {
+				return err
+			}
+			s.bgpsecSigner = signer
+			c.BgpsecSigning.State.PrivateKeyFile = file
+			c.BgpsecSigning.State.Ski = hex.EncodeToString(signer.SKI[:])
+		}
+
 		rfs, _ := oc.AfiSafis(c.AfiSafis).ToRfList()
 		s.globalRib = table.NewTableManager(s.logger, rfs)
 		s.rsRib = table.NewTableManager(s.logger, rfs)
@@ -2756,6 +3542,11 @@ func (s *BgpServer) ListVrf(ctx context.Context, r *api.ListVrfRequest, fn func(
 			}
 		}
 
+		var opts *api.RouteSelectionOptionsConfig
+		if v.SelectionOptions != nil {
+			opts = oc.NewRouteSelectionOptionsConfigFromConfigStruct(v.SelectionOptions)
+		}
+
 		return &api.Vrf{
 			Name:                    v.Name,
 			Rd:                      d,
@@ -2764,6 +3555,7 @@ func (s *BgpServer) ListVrf(ctx context.Context, r *api.ListVrfRequest, fn func(
 			ExportRt:                ert,
 			NetlinkImportEnabled:    netlinkImportEnabled,
 			NetlinkImportInterfaces: netlinkImportInterfaces,
+			RouteSelectionOptions:   opts,
 		}
 	}
 	var l []*api.Vrf
@@ -2817,7 +3609,13 @@ func (s *BgpServer) AddVrf(ctx context.Context, r *api.AddVrfRequest) error {
 			LocalID: s.bgpConfig.Global.Config.RouterId,
 		}
 
-		if pathList, err := s.globalRib.AddVrf(name, id, rd, im, ex, pi); err != nil {
+		var opts *oc.RouteSelectionOptionsConfig
+		if r.Vrf.RouteSelectionOptions != nil {
+			c := newRouteSelectionOptionsConfigFromAPIStruct(r.Vrf.RouteSelectionOptions)
+			opts = &c
+		}
+
+		if pathList, err := s.globalRib.AddVrf(name, id, rd, im, ex, pi, opts); err != nil {
 			return err
 		} else if len(pathList) > 0 {
 			s.propagateUpdate(nil, pathList)
@@ -2938,7 +3736,7 @@ func (s *BgpServer) softResetOut(addr string, family bgp.Family, deferral bool)
 			}
 		}
 
-		pathList, _ := s.getBestFromLocal(peer, families, true)
+		pathList, _, pre := s.getBestFromLocal(peer, families, true)
 		if len(pathList) > 0 {
 			if deferral {
 				pathList = func() []*table.Path {
@@ -2951,7 +3749,7 @@ func (s *BgpServer) softResetOut(addr string, family bgp.Family, deferral bool)
 					return l
 				}()
 			}
-			sendfsmOutgoingMsg(peer, pathList)
+			s.sendfsmOutgoingMsg(peer, pathList, pre)
 		}
 	}
 	return nil
@@ -2982,6 +3780,62 @@ func (s *BgpServer) sReset(addr string, family bgp.Family) error {
 	return s.softResetOut(addr, family, false)
 }
 
+// processROAChanges notifies the changes of the RPKI validation states of
+// the paths in Adj-RIB-In caused by the ROA updates and applies the import
+// policies with the RPKI condition to them again.
+func (s *BgpServer) processROAChanges() {
+	if s.roaManager.updating() {
+		// wait for End of Data
+		return
+	}
+	changes := s.roaTable.TakeChanges()
+	if changes == nil {
+		return
+	}
+	watched := s.isWatched(watchEventTypeValidation)
+	now := time.Now()
+	families := []bgp.Family{bgp.RF_IPv4_UC, bgp.RF_IPv6_UC}
+	for _, peer := range s.neighborMap {
+		id := table.GLOBAL_RIB_NAME
+		if peer.isRouteServerClient() {
+			id = peer.TableID()
+		}
+		reapply := s.policy.UsesCondition(id, table.POLICY_DIRECTION_IMPORT, table.CONDITION_RPKI)
+		if !watched && !reapply {
+			continue
+		}
+		var l []validationChange
+		var pathList []*table.Path
+		for _, path := range peer.adjRibIn.PathList(families, true) {
+			if !changes.Covers(path) {
+				continue
+			}
+			old, cur := s.roaTable.ValidateChanges(path, changes)
+			if old.Status == cur.Status && old.Reason == cur.Reason {
+				continue
+			}
+			l = append(l, validationChange{Path: path, Old: old, New: cur})
+			pathList = append(pathList, path)
+		}
+		if len(l) == 0 {
+			continue
+		}
+		s.logger.Debug("RPKI validation changed",
+			slog.String("Topic", "rpki"),
+			slog.String("Key", peer.ID()),
+			slog.Int("Paths", len(l)))
+		if watched {
+			s.notifyWatcher(watchEventTypeValidation, &watchEventValidation{
+				Changes:   l,
+				Timestamp: now,
+			})
+		}
+		if reapply {
+			s.propagateUpdate(peer, pathList)
+		}
+	}
+}
+
 func (s *BgpServer) validateTable(r *table.Table) (v map[*table.Path]*table.Validation) {
 	if s.roaManager.enabled() {
 		v = make(map[*table.Path]*table.Validation, len(r.GetDestinations()))
@@ -3083,7 +3937,9 @@ func (s *BgpServer) getAdjRib(addr string, family bgp.Family, in bool, enableFil
 				for _, path := range peer.adjRibIn.PathList([]bgp.Family{family}, true) {
 					pathLocalKey := path.GetLocalKey()
 					options := &table.PolicyOptions{
-						Validate: s.roaTable.Validate,
+						Validate:       s.roaTable.Validate,
+						ValidateBGPsec: s.validateBGPsec,
+						NoCount:        true,
 					}
 					p := s.policy.ApplyPolicy(peer.TableID(), table.POLICY_DIRECTION_IMPORT, path, options)
 					if p == nil {
@@ -3104,13 +3960,15 @@ func (s *BgpServer) getAdjRib(addr string, family bgp.Family, in bool, enableFil
 						continue
 					}
 					options.Validate = s.roaTable.Validate
+					options.ValidateBGPsec = s.validateBGPsec
+					options.NoCount = true
 					if p = peer.policy.ApplyPolicy(peer.TableID(), table.POLICY_DIRECTION_EXPORT, p, options); p == nil {
 						filtered[pathLocalKey] = table.PolicyFiltered
 					}
 					pathList = append(pathList, path)
 				}
 			} else {
-				pathList, _ = s.getBestFromLocal(peer, peer.configuredRFlist(), true)
+				pathList, _, _ = s.viewBestFromLocal(peer, peer.configuredRFlist(), true)
 			}
 			toUpdate = make([]*table.Path, 0, len(pathList))
 			for _, path := range pathList {
@@ -3159,6 +4017,36 @@ func (s *BgpServer) ListPath(r apiutil.ListPathRequest, fn func(prefix bgp.NLRI,
 		return err
 	}
 
+	var reasons map[*table.Path]table.BestPathReason
+	// the route selection options of the destinations, nil for the
+	// global ones
+	var opts map[*table.Destination]*oc.RouteSelectionOptionsConfig
+	multipath := s.bgpConfig.Global.UseMultiplePaths.Config.Enabled
+	if r.EnableBestPathReason || multipath {
+		switch r.TableType {
+		case api.TableType_TABLE_TYPE_LOCAL, api.TableType_TABLE_TYPE_GLOBAL, api.TableType_TABLE_TYPE_VRF:
+			err = s.mgmtOperation(func() error {
+				m := s.globalRib
+				vrf := ""
+				if r.TableType == api.TableType_TABLE_TYPE_VRF {
+					vrf = r.Name
+				} else if r.Name != "" {
+					m = s.rsRib
+				}
+				if r.EnableBestPathReason {
+					reasons = m.BestPathReasons(tbl, vrf)
+				}
+				if multipath {
+					opts = m.SelectionOptions(tbl, vrf)
+				}
+				return nil
+			}, true)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	err = func() error {
 		for _, dst := range tbl.GetDestinations() {
 			prefix := dst.GetNlri()
@@ -3176,10 +4064,13 @@ func (s *BgpServer) ListPath(r apiutil.ListPathRequest, fn func(prefix bgp.NLRI,
 						case api.TableType_TABLE_TYPE_LOCAL, api.TableType_TABLE_TYPE_GLOBAL:
 							p.Best = true
 						}
-					} else if s.bgpConfig.Global.UseMultiplePaths.Config.Enabled && path.Compare(knownPathList[i-1]) == 0 {
+					} else if multipath && table.IsMultiPath(knownPathList[0], path, opts[dst]) {
 						p.Best = true
 					}
 				}
+				if reason, ok := reasons[path]; ok {
+					p.BestPathReason = api.BestPathReason(reason)
+				}
 				if r.EnableFiltered && filtered[path.GetLocalKey()]&table.PolicyFiltered > 0 {
 					p.Filtered = true
 				}
@@ -3249,7 +4140,7 @@ func (s *BgpServer) getAdjRibInfo(addr string, family bgp.Family, in bool) (info
 			adjRib = peer.adjRibIn
 		} else {
 			adjRib = table.NewAdjRib(s.logger, peer.configuredRFlist())
-			accepted, _ := s.getBestFromLocal(peer, peer.configuredRFlist(), false)
+			accepted, _, _ := s.viewBestFromLocal(peer, peer.configuredRFlist(), false)
 			adjRib.UpdateAdjRibOut(accepted)
 		}
 		info, err = adjRib.TableInfo(family)
@@ -3340,15 +4231,29 @@ func (s *BgpServer) GetBgp(ctx context.Context, r *api.GetBgpRequest) (rsp *api.
 		for _, addr := range g.Config.LocalAddressList {
 			l = append(l, addr.String())
 		}
+		families := make([]uint32, 0)
+		if s.globalRib != nil {
+			for _, f := range s.globalRib.GetRFlist() {
+				families = append(families, uint32(f))
+			}
+			slices.Sort(families)
+		}
 		rsp = &api.GetBgpResponse{
 			Global: &api.Global{
 				Asn:              g.Config.As,
 				RouterId:         g.Config.RouterId.String(),
 				ListenPort:       g.Config.Port,
 				ListenAddresses:  l,
+				Families:         families,
 				UseMultiplePaths: g.UseMultiplePaths.Config.Enabled,
 			},
 		}
+		if g.BgpsecSigning.Config.PrivateKeyFile != "" {
+			rsp.Global.BgpsecSigning = &api.BgpsecSigning{
+				PrivateKeyFile: g.BgpsecSigning.State.PrivateKeyFile,
+				Ski:            g.BgpsecSigning.State.Ski,
+			}
+		}
 		return nil
 	}, false)
 	return rsp, err
@@ -3453,7 +4358,7 @@ func (s *BgpServer) ListPeer(ctx context.Context, r *api.ListPeerRequest, fn fun
 							received = uint64(peer.adjRibIn.Count(flist))
 							accepted = uint64(peer.adjRibIn.Accepted(flist))
 							if getAdvertised {
-								pathList, _ := s.getBestFromLocal(peer, flist, false)
+								pathList, _, _ := s.viewBestFromLocal(peer, flist, false)
 								advertised = uint64(len(pathList))
 							}
 						}
@@ -3464,6 +4369,30 @@ func (s *BgpServer) ListPeer(ctx context.Context, r *api.ListPeerRequest, fn fun
 							Accepted:   accepted,
 							Advertised: advertised,
 						}
+						if orf := p.AfiSafis[i].OutboundRouteFiltering; orf != nil && orf.Config.Mode != api.OutboundRouteFilteringConfig_MODE_UNSPECIFIED {
+							established := sesstionState == bgp.BGP_FSM_ESTABLISHED
+							orf.State = &api.OutboundRouteFilteringState{
+								Send:     established && peer.isORFSendEnabled(family),
+								Receive:  established && peer.isORFReceiveEnabled(family),
+								Received: toOrfPrefixEntriesApi(peer.receivedORF[family].Entries()),
+								Sent:     toOrfPrefixEntriesApi(peer.sentORF[family]),
+							}
+						}
+						if limit := p.AfiSafis[i].PrefixLimits; limit != nil {
+							peer.fsm.lock.Lock()
+							a := peer.fsm.pConf.GetAfiSafi(family)
+							peer.fsm.lock.Unlock()
+							if a != nil {
+								limit.State = peer.toPrefixLimitStateApi(family, &a.PrefixLimit.Config)
+							}
+						}
+						if b := p.AfiSafis[i].Bgpsec; b != nil && (b.Config.Send || b.Config.Receive) {
+							established := sesstionState == bgp.BGP_FSM_ESTABLISHED
+							b.State = &api.BgpsecState{
+								Send:    established && peer.isBGPsecSendEnabled(family),
+								Receive: established && peer.isBGPsecReceiveEnabled(family),
+							}
+						}
 					}
 				}
 			}
@@ -3479,6 +4408,12 @@ func (s *BgpServer) ListPeer(ctx context.Context, r *api.ListPeerRequest, fn fun
 					p.State.Ipv6LinkLocalNexthop = peer.peerInfo.IPv6LinkLocalNexthop.String()
 				}
 			}
+			outQ := uint32(peer.fsm.outgoingCh.Len())
+			p.State.OutQ = outQ
+			p.State.Queues = &api.Queues{
+				Output:               outQ,
+				OutputBlockedSeconds: peer.fsm.sendBlockedFor().Seconds(),
+			}
 			l = append(l, p)
 		}
 		return nil
@@ -3553,6 +4488,10 @@ func (s *BgpServer) addNeighbor(c *oc.Neighbor) error {
 		return fmt.Errorf("can't be both route-server-client and route-reflector-client")
 	}
 
+	if c.RouteServer.Config.RouteServerClient && (c.RouteReflector.Config.AdvertiseBestExternal || c.RouteReflector.Config.DiversePath) {
+		return fmt.Errorf("advertise-best-external and diverse-path can't be used for route-server-client")
+	}
+
 	if s.bgpConfig.Global.Config.Port > 0 {
 		for _, l := range s.listListeners(addr) {
 			if c.Config.AuthPassword != "" {
@@ -3701,6 +4640,7 @@ func (s *BgpServer) deleteNeighbor(c *oc.Neighbor, code, subcode uint8, sendNoti
 		n.fsm.deconfiguredNotification <- bgp.NewBGPNotificationMessage(code, subcode, nil)
 	}
 	s.propagateUpdate(n, n.DropAll(n.configuredRFlist()))
+	n.stopPrefixLimitRestart()
 	s.stopNeighbor(n, -1, nil)
 	return nil
 }
@@ -3875,16 +4815,32 @@ func (s *BgpServer) updateNeighbor(c *oc.Neighbor) (needsSoftResetIn bool, err e
 		peer.fsm.pConf.Timers.Config = c.Timers.Config
 	}
 
-	isLimit, err := peer.updatePrefixLimitConfig(c.AfiSafis)
-	if isLimit && err == nil {
-		err = s.setAdminState(addr, "", adminStatePfxCt)
+	rrConf := c.RouteReflector.Config
+	if original.RouteReflector.Config.AdvertiseBestExternal != rrConf.AdvertiseBestExternal || original.RouteReflector.Config.DiversePath != rrConf.DiversePath {
+		peer.fsm.logger.Info("Update route reflector options",
+			slog.Bool("AdvertiseBestExternal", rrConf.AdvertiseBestExternal),
+			slog.Bool("DiversePath", rrConf.DiversePath))
+		peer.fsm.lock.Lock()
+		peer.fsm.pConf.RouteReflector.Config.AdvertiseBestExternal = rrConf.AdvertiseBestExternal
+		peer.fsm.pConf.RouteReflector.Config.DiversePath = rrConf.DiversePath
+		peer.fsm.lock.Unlock()
+		if err := s.softResetOut(peer.ID(), bgp.Family(0), false); err != nil {
+			peer.fsm.logger.Warn("failed to advertise the paths", slog.String("Err", err.Error()))
+		}
 	}
+
+	countAccepted, err := peer.updatePrefixLimitConfig(c.AfiSafis)
 	if err != nil {
 		peer.fsm.logger.Error("failed to update prefixLimit", slog.String("Err", err.Error()))
 		// rollback to original state
 		peer.fsm.pConf = original
 		return needsSoftResetIn, err
 	}
+	if countAccepted {
+		needsSoftResetIn = true
+	}
+	s.checkPrefixLimits(peer, "")
+	s.joinUpdateGroup(peer)
 
 	return needsSoftResetIn, err
 }
@@ -4144,7 +5100,15 @@ func (s *BgpServer) AddDefinedSet(ctx context.Context, r *api.AddDefinedSetReque
 		if err != nil {
 			return err
 		}
-		return s.policy.AddDefinedSet(set, r.GetReplace())
+		policy, err := s.policyTarget(r.TransactionId)
+		if err != nil {
+			return err
+		}
+		if err := policy.AddDefinedSet(set, r.GetReplace()); err != nil || r.TransactionId != "" {
+			return s.policyModified(r.TransactionId, err)
+		}
+		s.updateORF(set)
+		return nil
 	}, false)
 }
 
@@ -4157,8 +5121,126 @@ func (s *BgpServer) DeleteDefinedSet(ctx context.Context, r *api.DeleteDefinedSe
 		if err != nil {
 			return err
 		}
-		return s.policy.DeleteDefinedSet(set, r.All)
+		policy, err := s.policyTarget(r.TransactionId)
+		if err != nil {
+			return err
+		}
+		if err := policy.DeleteDefinedSet(set, r.All); err != nil || r.TransactionId != "" {
+			return s.policyModified(r.TransactionId, err)
+		}
+		s.updateORF(set)
+		return nil
+	}, false)
+}
+
+// updateORF re-sends the ORF of the peers pushing the changed prefix-set.
+func (s *BgpServer) AddDefinedSetFile(ctx context.Context, r *api.AddDefinedSetFileRequest) error {
+	if r == nil {
+		return fmt.Errorf("nil request")
+	}
+	c := oc.DefinedSetFileConfig{Path: r.Path, Name: r.Name}
+	switch r.DefinedType {
+	case api.DefinedType_DEFINED_TYPE_PREFIX:
+		c.Type = oc.DEFINED_SET_FILE_TYPE_PREFIX
+	case api.DefinedType_DEFINED_TYPE_AS_PATH:
+		c.Type = oc.DEFINED_SET_FILE_TYPE_AS_PATH
+	default:
+		return fmt.Errorf("unsupported defined type %v", r.DefinedType)
+	}
+	switch r.Format {
+	case api.AddDefinedSetFileRequest_FORMAT_UNSPECIFIED, api.AddDefinedSetFileRequest_FORMAT_TEXT:
+		c.Format = oc.DEFINED_SET_FILE_FORMAT_TYPE_TEXT
+	case api.AddDefinedSetFileRequest_FORMAT_BGPQ4_JSON:
+		c.Format = oc.DEFINED_SET_FILE_FORMAT_TYPE_BGPQ4_JSON
+	default:
+		return fmt.Errorf("invalid format %v", r.Format)
+	}
+	if c.Path == "" {
+		return fmt.Errorf("empty path")
+	}
+	if c.Name == "" {
+		return fmt.Errorf("empty defined set name")
+	}
+	return s.mgmtOperation(func() error {
+		return s.addDefinedSetFile(c)
+	}, false)
+}
+
+func (s *BgpServer) DeleteDefinedSetFile(ctx context.Context, r *api.DeleteDefinedSetFileRequest) error {
+	if r == nil {
+		return fmt.Errorf("nil request")
+	}
+	return s.mgmtOperation(func() error {
+		return s.deleteDefinedSetFile(r.Path)
+	}, false)
+}
+
+func (s *BgpServer) ReloadDefinedSetFile(ctx context.Context, r *api.ReloadDefinedSetFileRequest) error {
+	if r == nil {
+		return fmt.Errorf("nil request")
+	}
+	return s.mgmtOperation(func() error {
+		return s.reloadDefinedSetFiles(r.Path)
+	}, false)
+}
+
+func (s *BgpServer) ListDefinedSetFile(ctx context.Context, r *api.ListDefinedSetFileRequest, fn func(*api.DefinedSetFile)) error {
+	if r == nil {
+		return fmt.Errorf("nil request")
+	}
+	var l []*api.DefinedSetFile
+	err := s.mgmtOperation(func() error {
+		path := r.Path
+		if path != "" {
+			var err error
+			if path, err = filepath.Abs(path); err != nil {
+				return err
+			}
+		}
+		for _, f := range s.definedSetFiles.files {
+			if path != "" && path != f.config.Path {
+				continue
+			}
+			file := &api.DefinedSetFile{
+				Path:        f.config.Path,
+				Name:        f.config.Name,
+				DefinedType: definedSetFileTypeToApi(f.config.Type),
+				Format:      definedSetFileFormatToApi(f.config.Format),
+				Loads:       f.state.Loads,
+				Error:       f.state.Error,
+				Entries:     f.state.Entries,
+				Added:       f.state.Added,
+				Removed:     f.state.Removed,
+			}
+			if f.state.Loaded != 0 {
+				file.Loaded = oc.ProtoTimestamp(f.state.Loaded)
+			}
+			l = append(l, file)
+		}
+		return nil
 	}, false)
+	if err != nil {
+		return err
+	}
+	slices.SortFunc(l, func(a, b *api.DefinedSetFile) int { return strings.Compare(a.Path, b.Path) })
+	for _, f := range l {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		fn(f)
+	}
+	return nil
+}
+
+func (s *BgpServer) updateORF(set table.DefinedSet) {
+	if set.Type() != table.DEFINED_TYPE_PREFIX {
+		return
+	}
+	for _, peer := range s.neighborMap {
+		if peer.State() == bgp.BGP_FSM_ESTABLISHED {
+			s.sendORF(peer, peer.negotiatedRFList(), set.Name())
+		}
+	}
 }
 
 func (s *BgpServer) ListStatement(ctx context.Context, r *api.ListStatementRequest, fn func(*api.Statement)) error {
@@ -4167,10 +5249,14 @@ func (s *BgpServer) ListStatement(ctx context.Context, r *api.ListStatementReque
 	}
 	var l []*api.Statement
 	err := s.mgmtOperation(func() error {
-		s := s.policy.GetStatement(r.Name)
-		l = make([]*api.Statement, 0, len(s))
-		for _, st := range s {
-			l = append(l, toStatementApi(st))
+		sl := s.policy.GetStatement(r.Name)
+		l = make([]*api.Statement, 0, len(sl))
+		for _, st := range sl {
+			a := toStatementApi(st)
+			if stats, err := s.policy.StatementStatistics(st.Name); err == nil {
+				a.Statistics = stats.ToApi()
+			}
+			l = append(l, a)
 		}
 		return nil
 	}, false)
@@ -4195,7 +5281,11 @@ func (s *BgpServer) AddStatement(ctx context.Context, r *api.AddStatementRequest
 		if err != nil {
 			return err
 		}
-		return s.policy.AddStatement(st)
+		policy, err := s.policyTarget(r.TransactionId)
+		if err != nil {
+			return err
+		}
+		return s.policyModified(r.TransactionId, policy.AddStatement(st))
 	}, false)
 }
 
@@ -4204,11 +5294,15 @@ func (s *BgpServer) DeleteStatement(ctx context.Context, r *api.DeleteStatementR
 		return fmt.Errorf("nil request")
 	}
 	return s.mgmtOperation(func() error {
+		policy, err := s.policyTarget(r.TransactionId)
+		if err != nil {
+			return err
+		}
 		st, err := newStatementFromApiStruct(r.Statement)
 		if err == nil {
-			err = s.policy.DeleteStatement(st, r.All)
+			err = policy.DeleteStatement(st, r.All)
 		}
-		return err
+		return s.policyModified(r.TransactionId, err)
 	}, false)
 }
 
@@ -4221,7 +5315,14 @@ func (s *BgpServer) ListPolicy(ctx context.Context, r *api.ListPolicyRequest, fn
 		pl := s.policy.GetPolicy(r.Name)
 		l = make([]*api.Policy, 0, len(pl))
 		for _, p := range pl {
-			l = append(l, table.ToPolicyApi(p))
+			a := table.ToPolicyApi(p)
+			if stats, stmts, err := s.policy.PolicyStatistics(p.Name); err == nil && len(stmts) == len(a.Statements) {
+				a.Statistics = stats.ToApi()
+				for i, st := range a.Statements {
+					st.Statistics = stmts[i].ToApi()
+				}
+			}
+			l = append(l, a)
 		}
 		return nil
 	}, false)
@@ -4242,11 +5343,15 @@ func (s *BgpServer) AddPolicy(ctx context.Context, r *api.AddPolicyRequest) erro
 		return fmt.Errorf("nil request")
 	}
 	return s.mgmtOperation(func() error {
+		policy, err := s.policyTarget(r.TransactionId)
+		if err != nil {
+			return err
+		}
 		p, err := newPolicyFromApiStruct(r.Policy)
 		if err == nil {
-			err = s.policy.AddPolicy(p, r.ReferExistingStatements)
+			err = policy.AddPolicy(p, r.ReferExistingStatements)
 		}
-		return err
+		return s.policyModified(r.TransactionId, err)
 	}, false)
 }
 
@@ -4259,6 +5364,10 @@ func (s *BgpServer) DeletePolicy(ctx context.Context, r *api.DeletePolicyRequest
 		if err != nil {
 			return err
 		}
+		policy, err := s.policyTarget(r.TransactionId)
+		if err != nil {
+			return err
+		}
 
 		l := make([]string, 0, len(s.neighborMap)+1)
 		for _, peer := range s.neighborMap {
@@ -4266,7 +5375,7 @@ func (s *BgpServer) DeletePolicy(ctx context.Context, r *api.DeletePolicyRequest
 		}
 		l = append(l, table.GLOBAL_RIB_NAME)
 
-		return s.policy.DeletePolicy(p, r.All, r.PreserveStatements, l)
+		return s.policyModified(r.TransactionId, policy.DeletePolicy(p, r.All, r.PreserveStatements, l))
 	}, false)
 }
 
@@ -4334,10 +5443,11 @@ func (s *BgpServer) ListPolicyAssignment(ctx context.Context, r *api.ListPolicyA
 					return err
 				}
 				t := &table.PolicyAssignment{
-					Name:     name,
-					Type:     dir,
-					Default:  rt,
-					Policies: policies,
+					Name:       name,
+					Type:       dir,
+					Default:    rt,
+					Policies:   policies,
+					Statistics: s.policy.AssignmentStatistics(id, dir),
 				}
 				a = append(a, table.NewAPIPolicyAssignmentFromTableStruct(t))
 			}
@@ -4365,7 +5475,11 @@ func (s *BgpServer) AddPolicyAssignment(ctx context.Context, r *api.AddPolicyAss
 		if err != nil {
 			return err
 		}
-		return s.policy.AddPolicyAssignment(id, dir, toPolicyDefinition(r.Assignment.Policies), defaultRouteType(r.Assignment.DefaultAction))
+		policy, err := s.policyTarget(r.TransactionId)
+		if err != nil {
+			return err
+		}
+		return s.policyModified(r.TransactionId, policy.AddPolicyAssignment(id, dir, toPolicyDefinition(r.Assignment.Policies), defaultRouteType(r.Assignment.DefaultAction)))
 	}, false)
 }
 
@@ -4378,7 +5492,11 @@ func (s *BgpServer) DeletePolicyAssignment(ctx context.Context, r *api.DeletePol
 		if err != nil {
 			return err
 		}
-		return s.policy.DeletePolicyAssignment(id, dir, toPolicyDefinition(r.Assignment.Policies), r.All)
+		policy, err := s.policyTarget(r.TransactionId)
+		if err != nil {
+			return err
+		}
+		return s.policyModified(r.TransactionId, policy.DeletePolicyAssignment(id, dir, toPolicyDefinition(r.Assignment.Policies), r.All))
 	}, false)
 }
 
@@ -4391,11 +5509,230 @@ func (s *BgpServer) SetPolicyAssignment(ctx context.Context, r *api.SetPolicyAss
 		if err != nil {
 			return err
 		}
-		return s.policy.SetPolicyAssignment(id, dir, toPolicyDefinition(r.Assignment.Policies), defaultRouteType(r.Assignment.DefaultAction))
+		policy, err := s.policyTarget(r.TransactionId)
+		if err != nil {
+			return err
+		}
+		return s.policyModified(r.TransactionId, policy.SetPolicyAssignment(id, dir, toPolicyDefinition(r.Assignment.Policies), defaultRouteType(r.Assignment.DefaultAction)))
 	}, false)
 }
 
-func (s *BgpServer) EnableMrt(ctx context.Context, r *api.EnableMrtRequest) error {
+func (s *BgpServer) ResetPolicyStatistics(ctx context.Context, r *api.ResetPolicyStatisticsRequest) error {
+	if r == nil {
+		return fmt.Errorf("nil request")
+	}
+	return s.mgmtOperation(func() error {
+		if r.Name == "" && r.Assignment == "" {
+			return s.policy.ResetStatistics("")
+		}
+		if r.Name != "" {
+			if err := s.policy.ResetStatistics(r.Name); err != nil {
+				return err
+			}
+		}
+		if r.Assignment == "" {
+			return nil
+		}
+		d := r.Direction
+		if d == api.PolicyDirection_POLICY_DIRECTION_UNSPECIFIED {
+			d = api.PolicyDirection_POLICY_DIRECTION_IMPORT
+		}
+		id, dir, err := s.toPolicyInfo(r.Assignment, d)
+		if err != nil {
+			return err
+		}
+		if r.Direction == api.PolicyDirection_POLICY_DIRECTION_UNSPECIFIED {
+			dir = table.POLICY_DIRECTION_NONE
+		}
+		return s.policy.ResetAssignmentStatistics(id, dir)
+	}, false)
+}
+
+func policyDecisionToApi(t table.RouteType) api.TestPolicyResponse_Decision {
+	switch t {
+	case table.ROUTE_TYPE_ACCEPT:
+		return api.TestPolicyResponse_DECISION_ACCEPT
+	case table.ROUTE_TYPE_REJECT:
+		return api.TestPolicyResponse_DECISION_REJECT
+	}
+	return api.TestPolicyResponse_DECISION_CONTINUE
+}
+
+// policyAttributeDiff returns the path attributes changed by the policies.
+func policyAttributeDiff(before, after *table.Path) []*api.TestPolicyResponse_AttributeDiff {
+	attrs := func(p *table.Path) map[bgp.BGPAttrType]string {
+		m := make(map[bgp.BGPAttrType]string)
+		for _, a := range p.GetPathAttrs() {
+			m[a.GetType()] = a.String()
+		}
+		return m
+	}
+	b, a := attrs(before), attrs(after)
+	types := make([]bgp.BGPAttrType, 0, len(b)+len(a))
+	for t := range b {
+		types = append(types, t)
+	}
+	for t := range a {
+		if _, ok := b[t]; !ok {
+			types = append(types, t)
+		}
+	}
+	slices.Sort(types)
+	var diff []*api.TestPolicyResponse_AttributeDiff
+	for _, t := range types {
+		if b[t] != a[t] {
+			diff = append(diff, &api.TestPolicyResponse_AttributeDiff{
+				Type:   t.String(),
+				Before: b[t],
+				After:  a[t],
+			})
+		}
+	}
+	return diff
+}
+
+// testPolicyPath returns the path of the TestPolicy request, the one in the
+// RIB or the synthetic one from the source.
+func (s *BgpServer) testPolicyPath(r *api.TestPolicyRequest, source *table.PeerInfo) (*table.Path, error) {
+	if r.Path != nil {
+		p, err := api2apiutilPath(r.Path)
+		if err != nil {
+			return nil, err
+		}
+		path, err := apiutil2Path(p, false)
+		if err != nil {
+			return nil, err
+		}
+		if source != nil {
+			path = table.NewPath(path.GetFamily(), source, bgp.PathNLRI{NLRI: path.GetNlri()}, false, path.GetPathAttrs(), path.GetTimestamp(), false)
+		}
+		return path, nil
+	}
+	if r.Lookup == nil || r.Lookup.Family == nil || r.Lookup.Prefix == "" {
+		return nil, fmt.Errorf("no path to test")
+	}
+	family := bgp.NewFamily(uint16(r.Lookup.Family.Afi), uint8(r.Lookup.Family.Safi))
+	var paths []*table.Path
+	if r.Lookup.Neighbor != "" {
+		addr, err := netip.ParseAddr(r.Lookup.Neighbor)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse address: %v", err)
+		}
+		peer, ok := s.neighborMap[addr]
+		if !ok {
+			return nil, fmt.Errorf("neighbor that has %v doesn't exist", addr)
+		}
+		paths = peer.adjRibIn.PathList([]bgp.Family{family}, false)
+	} else {
+		paths = s.globalRib.GetBestPathList(table.GLOBAL_RIB_NAME, 0, []bgp.Family{family})
+	}
+	for _, path := range paths {
+		if path.GetNlri().String() == r.Lookup.Prefix {
+			return path, nil
+		}
+	}
+	return nil, fmt.Errorf("not found path to %s", r.Lookup.Prefix)
+}
+
+// TestPolicy runs the path through the policies, or the import or export
+// policies of the neighbor, without applying anything and returns the
+// decision and how the policies reached it.
+func (s *BgpServer) TestPolicy(ctx context.Context, r *api.TestPolicyRequest) (*api.TestPolicyResponse, error) {
+	if r == nil {
+		return nil, fmt.Errorf("nil request")
+	}
+	var rsp *api.TestPolicyResponse
+	err := s.mgmtOperation(func() error {
+		dir := table.POLICY_DIRECTION_NONE
+		switch r.Direction {
+		case api.PolicyDirection_POLICY_DIRECTION_IMPORT:
+			dir = table.POLICY_DIRECTION_IMPORT
+		case api.PolicyDirection_POLICY_DIRECTION_EXPORT:
+			dir = table.POLICY_DIRECTION_EXPORT
+		default:
+			if len(r.Policies) == 0 {
+				return fmt.Errorf("invalid policy direction")
+			}
+		}
+		id := table.GLOBAL_RIB_NAME
+		var peer *peer
+		if r.Neighbor != "" {
+			addr, err := netip.ParseAddr(r.Neighbor)
+			if err != nil {
+				return fmt.Errorf("failed to parse address: %v", err)
+			}
+			var ok bool
+			if peer, ok = s.neighborMap[addr]; !ok {
+				return fmt.Errorf("neighbor that has %v doesn't exist", addr)
+			}
+			id = peer.TableID()
+		}
+
+		var source *table.PeerInfo
+		if peer != nil && dir != table.POLICY_DIRECTION_EXPORT {
+			source = peer.peerInfo
+		}
+		path, err := s.testPolicyPath(r, source)
+		if err != nil {
+			return err
+		}
+		rsp = &api.TestPolicyResponse{
+			Path: toPathApi(toPathApiUtil(path), false, false, false),
+		}
+
+		options := &table.PolicyOptions{}
+		if peer != nil {
+			if dir == table.POLICY_DIRECTION_EXPORT {
+				p, o, stop := s.prePolicyFilterpath(peer, path, nil)
+				if stop || p == nil {
+					rsp.Decision = api.TestPolicyResponse_DECISION_REJECT
+					rsp.PrePolicyFiltered = true
+					return nil
+				}
+				path, options = p, o
+			} else if !peer.isRouteServerClient() {
+				options.Info = peer.peerInfo
+			}
+		}
+		options.Validate = s.roaTable.Validate
+		options.ValidateBGPsec = s.validateBGPsec
+
+		policy, err := s.policyTarget(r.TransactionId)
+		if err != nil {
+			return err
+		}
+		result, after, trace, err := policy.TestPolicy(id, dir, r.Policies, path, options)
+		if err != nil {
+			return err
+		}
+		rsp.Decision = policyDecisionToApi(result)
+		rsp.DefaultPolicy = trace.Default
+		for _, st := range trace.Statements {
+			conditions := make([]*api.TestPolicyResponse_Condition, 0, len(st.Conditions))
+			for _, c := range st.Conditions {
+				conditions = append(conditions, &api.TestPolicyResponse_Condition{
+					Type:    c.Type.String(),
+					Set:     c.Name,
+					Matched: c.Matched,
+				})
+			}
+			rsp.Statements = append(rsp.Statements, &api.TestPolicyResponse_Statement{
+				Policy:     st.Policy,
+				Name:       st.Statement,
+				Conditions: conditions,
+				Matched:    st.Matched,
+				Actions:    st.Actions,
+				Decision:   policyDecisionToApi(st.Result),
+			})
+		}
+		rsp.Result = toPathApi(toPathApiUtil(after), false, false, false)
+		rsp.Diff = policyAttributeDiff(path, after)
+		return nil
+	}, false)
+	return rsp, err
+}
+
+func (s *BgpServer) EnableMrt(ctx context.Context, r *api.EnableMrtRequest) error {
 	if r == nil {
 		return status.Errorf(codes.InvalidArgument, "null request")
 	}
@@ -4410,12 +5747,44 @@ func (s *BgpServer) EnableMrt(ctx context.Context, r *api.EnableMrtRequest) erro
 		dump_type = oc.MRT_TYPE_TABLE
 	}
 
+	var compression oc.MrtCompressionType
+	switch r.Compression {
+	case api.EnableMrtRequest_COMPRESSION_UNSPECIFIED:
+		compression = oc.MRT_COMPRESSION_TYPE_NONE
+	case api.EnableMrtRequest_COMPRESSION_GZIP:
+		compression = oc.MRT_COMPRESSION_TYPE_GZIP
+	case api.EnableMrtRequest_COMPRESSION_BZIP2:
+		compression = oc.MRT_COMPRESSION_TYPE_BZIP2
+	case api.EnableMrtRequest_COMPRESSION_ZSTD:
+		compression = oc.MRT_COMPRESSION_TYPE_ZSTD
+	default:
+		return status.Errorf(codes.InvalidArgument, "invalid compression %v", r.Compression)
+	}
+
+	var sink oc.MrtSinkType
+	switch r.Sink {
+	case api.EnableMrtRequest_SINK_UNSPECIFIED:
+		sink = oc.MRT_SINK_TYPE_FILE
+	case api.EnableMrtRequest_SINK_PIPE:
+		sink = oc.MRT_SINK_TYPE_PIPE
+	case api.EnableMrtRequest_SINK_UNIX_SOCKET:
+		sink = oc.MRT_SINK_TYPE_UNIX_SOCKET
+	default:
+		return status.Errorf(codes.InvalidArgument, "invalid sink %v", r.Sink)
+	}
+
 	return s.mgmtOperation(func() error {
 		return s.mrtManager.enable(&oc.MrtConfig{
 			DumpInterval:     r.DumpInterval,
 			RotationInterval: r.RotationInterval,
 			DumpType:         dump_type,
 			FileName:         r.Filename,
+			Vrf:              r.Vrf,
+			Compression:      compression,
+			SinkType:         sink,
+			RotationSize:     r.RotationSize,
+			RetentionFiles:   r.RetentionFiles,
+			RetentionSize:    r.RetentionSize,
 		})
 	}, false)
 }
@@ -4425,8 +5794,119 @@ func (s *BgpServer) DisableMrt(ctx context.Context, r *api.DisableMrtRequest) er
 		return fmt.Errorf("nil request")
 	}
 	return s.mgmtOperation(func() error {
-		return s.mrtManager.disable(&oc.MrtConfig{})
+		return s.mrtManager.disable(&oc.MrtConfig{FileName: r.Filename})
+	}, false)
+}
+
+func (s *BgpServer) ListMrt(ctx context.Context, r *api.ListMrtRequest, fn func(*api.Mrt)) error {
+	if r == nil {
+		return fmt.Errorf("nil request")
+	}
+	var l []*api.Mrt
+	err := s.mgmtOperation(func() error {
+		for name, w := range s.mrtManager.writer {
+			if r.Filename != "" && r.Filename != name {
+				continue
+			}
+			l = append(l, w.toApi())
+		}
+		return nil
 	}, false)
+	if err != nil {
+		return err
+	}
+	slices.SortFunc(l, func(a, b *api.Mrt) int { return strings.Compare(a.Filename, b.Filename) })
+	for _, m := range l {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		fn(m)
+	}
+	return nil
+}
+
+func (s *BgpServer) StartMrtReplay(ctx context.Context, r *api.StartMrtReplayRequest) error {
+	if r == nil {
+		return fmt.Errorf("nil request")
+	}
+	return s.mgmtOperation(func() error {
+		return s.mrtReplays.start(r)
+	}, true)
+}
+
+func (s *BgpServer) StopMrtReplay(ctx context.Context, r *api.StopMrtReplayRequest) error {
+	if r == nil {
+		return fmt.Errorf("nil request")
+	}
+	return s.mgmtOperation(func() error {
+		return s.mrtReplays.stop(r.Name)
+	}, true)
+}
+
+func (s *BgpServer) PauseMrtReplay(ctx context.Context, r *api.PauseMrtReplayRequest) error {
+	if r == nil {
+		return fmt.Errorf("nil request")
+	}
+	return s.mgmtOperation(func() error {
+		replay, err := s.mrtReplays.get(r.Name)
+		if err == nil {
+			replay.setPaused(true)
+		}
+		return err
+	}, true)
+}
+
+func (s *BgpServer) ResumeMrtReplay(ctx context.Context, r *api.ResumeMrtReplayRequest) error {
+	if r == nil {
+		return fmt.Errorf("nil request")
+	}
+	return s.mgmtOperation(func() error {
+		replay, err := s.mrtReplays.get(r.Name)
+		if err == nil {
+			replay.setPaused(false)
+		}
+		return err
+	}, true)
+}
+
+func (s *BgpServer) SeekMrtReplay(ctx context.Context, r *api.SeekMrtReplayRequest) error {
+	if r == nil {
+		return fmt.Errorf("nil request")
+	}
+	return s.mgmtOperation(func() error {
+		replay, err := s.mrtReplays.get(r.Name)
+		if err == nil {
+			replay.setSeek(time.Duration(r.Offset) * time.Second)
+		}
+		return err
+	}, true)
+}
+
+func (s *BgpServer) ListMrtReplay(ctx context.Context, r *api.ListMrtReplayRequest, fn func(*api.MrtReplay)) error {
+	if r == nil {
+		return fmt.Errorf("nil request")
+	}
+	var l []*api.MrtReplay
+	err := s.mgmtOperation(func() error {
+		for name, replay := range s.mrtReplays.replays {
+			if r.Name != "" && r.Name != name {
+				continue
+			}
+			l = append(l, replay.toApi())
+		}
+		return nil
+	}, false)
+	if err != nil {
+		return err
+	}
+	slices.SortFunc(l, func(a, b *api.MrtReplay) int { return strings.Compare(a.Name, b.Name) })
+	for _, replay := range l {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		fn(replay)
+	}
+	return nil
 }
 
 func (s *BgpServer) ListRpki(ctx context.Context, r *api.ListRpkiRequest, fn func(*api.Rpki)) error {
@@ -4442,6 +5922,7 @@ func (s *BgpServer) ListRpki(ctx context.Context, r *api.ListRpkiRequest, fn fun
 				Conf: &api.RPKIConf{
 					Address:    r.Config.Address.String(),
 					RemotePort: r.Config.Port,
+					Transport:  rpkiTransportToApi(r.Config.Transport),
 				},
 				State: &api.RPKIState{
 					Uptime:        oc.ProtoTimestamp(r.State.Uptime),
@@ -4454,6 +5935,8 @@ func (s *BgpServer) ListRpki(ctx context.Context, r *api.ListRpkiRequest, fn fun
 					Serial:        r.State.SerialNumber,
 					ReceivedIpv4:  received.Ipv4Prefix,
 					ReceivedIpv6:  received.Ipv6Prefix,
+					RouterKey:     received.RouterKey,
+					RouterKeys:    r.State.RouterKeys,
 					SerialNotify:  received.SerialNotify,
 					CacheReset:    received.CacheReset,
 					CacheResponse: received.CacheResponse,
@@ -4461,6 +5944,13 @@ func (s *BgpServer) ListRpki(ctx context.Context, r *api.ListRpkiRequest, fn fun
 					Error:         received.Error,
 					SerialQuery:   sent.SerialQuery,
 					ResetQuery:    sent.ResetQuery,
+					Aspa:          received.Aspa,
+					Aspas:         r.State.Aspas,
+
+					Version:         uint32(r.State.ProtocolVersion),
+					RefreshInterval: r.State.RefreshInterval,
+					RetryInterval:   r.State.RetryInterval,
+					ExpireInterval:  r.State.ExpireInterval,
 				},
 			}
 			l = append(l, rpki)
@@ -4508,12 +5998,148 @@ func (s *BgpServer) ListRpkiTable(ctx context.Context, r *api.ListRpkiTableReque
 	return nil
 }
 
+func (s *BgpServer) AddRpkiFile(ctx context.Context, r *api.AddRpkiFileRequest) error {
+	if r == nil {
+		return fmt.Errorf("nil request")
+	}
+	c := oc.RpkiFileConfig{Path: r.Path}
+	switch r.Format {
+	case api.AddRpkiFileRequest_FORMAT_UNSPECIFIED, api.AddRpkiFileRequest_FORMAT_JSON_VRP:
+		c.Format = oc.RPKI_FILE_FORMAT_TYPE_JSON_VRP
+	case api.AddRpkiFileRequest_FORMAT_SLURM:
+		c.Format = oc.RPKI_FILE_FORMAT_TYPE_SLURM
+	default:
+		return fmt.Errorf("invalid format %v", r.Format)
+	}
+	if c.Path == "" {
+		return fmt.Errorf("empty path")
+	}
+	return s.mgmtOperation(func() error {
+		defer s.processROAChanges()
+		return s.roaManager.AddFile(c)
+	}, false)
+}
+
+func (s *BgpServer) DeleteRpkiFile(ctx context.Context, r *api.DeleteRpkiFileRequest) error {
+	if r == nil {
+		return fmt.Errorf("nil request")
+	}
+	return s.mgmtOperation(func() error {
+		defer s.processROAChanges()
+		return s.roaManager.DeleteFile(r.Path)
+	}, false)
+}
+
+func (s *BgpServer) ReloadRpkiFile(ctx context.Context, r *api.ReloadRpkiFileRequest) error {
+	if r == nil {
+		return fmt.Errorf("nil request")
+	}
+	return s.mgmtOperation(func() error {
+		defer s.processROAChanges()
+		return s.roaManager.ReloadFiles(r.Path)
+	}, false)
+}
+
+func (s *BgpServer) ListRpkiFile(ctx context.Context, r *api.ListRpkiFileRequest, fn func(*api.RpkiFile)) error {
+	if r == nil {
+		return fmt.Errorf("nil request")
+	}
+	var l []*api.RpkiFile
+	err := s.mgmtOperation(func() error {
+		path := r.Path
+		if path != "" {
+			var err error
+			if path, err = filepath.Abs(path); err != nil {
+				return err
+			}
+		}
+		for _, f := range s.roaManager.GetFiles() {
+			if path != "" && path != f.Config.Path {
+				continue
+			}
+			file := &api.RpkiFile{
+				Path:       f.Config.Path,
+				Format:     rpkiFileFormatToApi(f.Config.Format),
+				Loads:      f.State.Loads,
+				Error:      f.State.Error,
+				RecordIpv4: f.State.RecordsV4,
+				RecordIpv6: f.State.RecordsV6,
+				RouterKeys: f.State.RouterKeys,
+				Aspas:      f.State.Aspas,
+				Filters:    f.State.Filters,
+			}
+			if f.State.Loaded != 0 {
+				file.Loaded = oc.ProtoTimestamp(f.State.Loaded)
+			}
+			l = append(l, file)
+		}
+		return nil
+	}, false)
+	if err != nil {
+		return err
+	}
+	slices.SortFunc(l, func(a, b *api.RpkiFile) int { return strings.Compare(a.Path, b.Path) })
+	for _, f := range l {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		fn(f)
+	}
+	return nil
+}
+
 func (s *BgpServer) AddRpki(ctx context.Context, r *api.AddRpkiRequest) error {
 	if r == nil {
 		return fmt.Errorf("nil request")
 	}
+	address, err := netip.ParseAddr(r.Address)
+	if err != nil {
+		return fmt.Errorf("invalid address %s: %w", r.Address, err)
+	}
+	c := &oc.RpkiServerConfig{
+		Address:           address,
+		Port:              r.Port,
+		RecordLifetime:    r.Lifetime,
+		TlsCaFile:         r.TlsCaFile,
+		TlsCertFile:       r.TlsCertFile,
+		TlsKeyFile:        r.TlsKeyFile,
+		TlsServerName:     r.TlsServerName,
+		SshUsername:       r.SshUsername,
+		SshPrivateKeyFile: r.SshPrivateKeyFile,
+		SshKnownHostsFile: r.SshKnownHostsFile,
+	}
+	switch r.Transport {
+	case api.AddRpkiRequest_TRANSPORT_UNSPECIFIED, api.AddRpkiRequest_TRANSPORT_TCP:
+		c.Transport = oc.RPKI_TRANSPORT_TYPE_TCP
+	case api.AddRpkiRequest_TRANSPORT_TLS:
+		c.Transport = oc.RPKI_TRANSPORT_TYPE_TLS
+	case api.AddRpkiRequest_TRANSPORT_SSH:
+		c.Transport = oc.RPKI_TRANSPORT_TYPE_SSH
+	default:
+		return fmt.Errorf("invalid transport %v", r.Transport)
+	}
+	switch r.MaxVersion {
+	case api.AddRpkiRequest_VERSION_UNSPECIFIED, api.AddRpkiRequest_VERSION_2:
+		c.MaxProtocolVersion = oc.RTR_PROTOCOL_VERSION_TYPE_V2
+	case api.AddRpkiRequest_VERSION_1:
+		c.MaxProtocolVersion = oc.RTR_PROTOCOL_VERSION_TYPE_V1
+	case api.AddRpkiRequest_VERSION_0:
+		c.MaxProtocolVersion = oc.RTR_PROTOCOL_VERSION_TYPE_V0
+	default:
+		return fmt.Errorf("invalid version %v", r.MaxVersion)
+	}
+	if c.Port == 0 {
+		switch c.Transport {
+		case oc.RPKI_TRANSPORT_TYPE_TLS:
+			c.Port = rtr.RPKI_TLS_DEFAULT_PORT
+		case oc.RPKI_TRANSPORT_TYPE_SSH:
+			c.Port = rtr.RPKI_SSH_DEFAULT_PORT
+		default:
+			c.Port = rtr.RPKI_DEFAULT_PORT
+		}
+	}
 	return s.mgmtOperation(func() error {
-		return s.roaManager.AddServer(net.JoinHostPort(r.Address, strconv.Itoa(int(r.Port))), r.Lifetime)
+		return s.roaManager.AddServer(c)
 	}, false)
 }
 
@@ -4522,7 +6148,16 @@ func (s *BgpServer) DeleteRpki(ctx context.Context, r *api.DeleteRpkiRequest) er
 		return fmt.Errorf("nil request")
 	}
 	return s.mgmtOperation(func() error {
-		return s.roaManager.DeleteServer(r.Address)
+		defer s.processROAChanges()
+		if r.Port != 0 {
+			return s.roaManager.DeleteServer(net.JoinHostPort(r.Address, strconv.Itoa(int(r.Port))))
+		}
+		for host := range s.roaManager.clientMap {
+			if address, _, _ := net.SplitHostPort(host); address == r.Address {
+				return s.roaManager.DeleteServer(host)
+			}
+		}
+		return fmt.Errorf("ROA server not found %s", r.Address)
 	}, false)
 }
 
@@ -4540,6 +6175,7 @@ func (s *BgpServer) DisableRpki(ctx context.Context, r *api.DisableRpkiRequest)
 		return fmt.Errorf("nil request")
 	}
 	return s.mgmtOperation(func() error {
+		defer s.processROAChanges()
 		return s.roaManager.Disable(r.Address)
 	}, false)
 }
@@ -4549,6 +6185,7 @@ func (s *BgpServer) ResetRpki(ctx context.Context, r *api.ResetRpkiRequest) erro
 		return fmt.Errorf("nil request")
 	}
 	return s.mgmtOperation(func() error {
+		defer s.processROAChanges()
 		if r.Soft {
 			return s.roaManager.SoftReset(r.Address)
 		}
@@ -4590,6 +6227,8 @@ func convertFSMStateReasonToAPI(stateReason *fsmStateReason) (api.PeerState_Disc
 		disconnectReason = api.PeerState_DISCONNECT_REASON_DECONFIGURED
 	case fsmBadPeerAS:
 		disconnectReason = api.PeerState_DISCONNECT_REASON_BAD_PEER_AS
+	case fsmSendHoldTimerExpired:
+		disconnectReason = api.PeerState_DISCONNECT_REASON_SEND_HOLD_TIMER_EXPIRED
 	default:
 		disconnectReason = api.PeerState_DISCONNECT_REASON_UNSPECIFIED
 	}
@@ -4611,6 +6250,8 @@ func toPathApiUtil(path *table.Path) *apiutil.Path {
 		IsNexthopInvalid:   path.IsNexthopInvalid,
 		RemoteID:           path.RemoteID(),
 		LocalID:            path.LocalID(),
+		Weight:             path.GetWeight(),
+		Tag:                path.GetTag(),
 	}
 	if s := path.GetSource(); s != nil {
 		p.PeerASN = s.AS
@@ -4623,10 +6264,12 @@ func toPathApiUtil(path *table.Path) *apiutil.Path {
 }
 
 type WatchEventMessageCallbacks struct {
-	OnPathUpdate func([]*apiutil.Path, time.Time)
-	OnBestPath   func([]*apiutil.Path, time.Time)
-	OnPathEor    func(*apiutil.Path, time.Time)
-	OnPeerUpdate func(*apiutil.WatchEventMessage_PeerEvent, time.Time)
+	OnPathUpdate  func([]*apiutil.Path, time.Time)
+	OnBestPath    func([]*apiutil.Path, time.Time)
+	OnPathEor     func(*apiutil.Path, time.Time)
+	OnPeerUpdate  func(*apiutil.WatchEventMessage_PeerEvent, time.Time)
+	OnValidation  func([]*apiutil.WatchEventMessage_ValidationEvent, time.Time)
+	OnPrefixLimit func(*apiutil.WatchEventMessage_PrefixLimitEvent, time.Time)
 }
 
 func (s *BgpServer) WatchEvent(ctx context.Context, callbacks WatchEventMessageCallbacks, opts ...WatchOption) error {
@@ -4680,6 +6323,32 @@ func (s *BgpServer) WatchEvent(ctx context.Context, callbacks WatchEventMessageC
 						}
 					}
 
+				case *watchEventValidation:
+					if callbacks.OnValidation != nil {
+						l := make([]*apiutil.WatchEventMessage_ValidationEvent, 0, len(msg.Changes))
+						for _, c := range msg.Changes {
+							p := toPathApiUtil(c.Path)
+							p.Validation = newValidationFromTableStruct(c.New)
+							l = append(l, &apiutil.WatchEventMessage_ValidationEvent{
+								Path:          p,
+								OldValidation: newValidationFromTableStruct(c.Old),
+							})
+						}
+						callbacks.OnValidation(l, msg.Timestamp)
+					}
+
+				case *watchEventPrefixLimit:
+					if callbacks.OnPrefixLimit != nil {
+						callbacks.OnPrefixLimit(&apiutil.WatchEventMessage_PrefixLimitEvent{
+							Type:        msg.Type,
+							PeerAddress: msg.PeerAddress,
+							Family:      msg.Family,
+							Count:       msg.Count,
+							Limit:       msg.Limit,
+							Shutdown:    msg.Shutdown,
+						}, msg.Timestamp)
+					}
+
 				case *watchEventEor:
 					if callbacks.OnPathEor != nil {
 						eor := table.NewEOR(msg.Family)
@@ -4789,6 +6458,13 @@ const (
 	watchEventTypePeerState  watchEventType = "peerstate"
 	watchEventTypeRecvMsg    watchEventType = "receivedmessage"
 	watchEventTypeEor        watchEventType = "eor"
+	// the paths sent to the peers, before and after the export policy
+	watchEventTypePreAdjRibOut  watchEventType = "preadjribout"
+	watchEventTypePostAdjRibOut watchEventType = "postadjribout"
+	// the RPKI validation states changed by the ROA updates
+	watchEventTypeValidation watchEventType = "validation"
+	// the prefix limits of the peers crossing the thresholds
+	watchEventTypePrefixLimit watchEventType = "prefixlimit"
 )
 
 type watchEvent any
@@ -4804,6 +6480,7 @@ type watchEventUpdate struct {
 	Timestamp    time.Time
 	Payload      []byte
 	PostPolicy   bool
+	AdjRibOut    bool
 	Init         bool
 	PathList     []*table.Path
 	Neighbor     *oc.Neighbor
@@ -4834,6 +6511,13 @@ type watchEventBestPath struct {
 	MultiPathList [][]*table.Path
 	Vrf           map[uint32]bool
 	Timestamp     time.Time
+	Init          bool
+}
+
+// watchEventEndOfInit follows the events of the current state notified
+// when the watch starts.
+type watchEventEndOfInit struct {
+	Timestamp time.Time
 }
 
 type watchEventMessage struct {
@@ -4854,6 +6538,31 @@ type watchEventEor struct {
 	Timestamp time.Time
 }
 
+type validationChange struct {
+	Path *table.Path
+	Old  *table.Validation
+	New  *table.Validation
+}
+
+// watchEventValidation reports the paths in Adj-RIB-In of a peer whose
+// RPKI validation states are changed by the ROA updates.
+type watchEventValidation struct {
+	Changes   []validationChange
+	Timestamp time.Time
+}
+
+// watchEventPrefixLimit reports a peer crossing a threshold of the prefix
+// limit of a family, or restarted after being shut down by the limit.
+type watchEventPrefixLimit struct {
+	Type        api.WatchEventResponse_PrefixLimitEvent_Type
+	PeerAddress netip.Addr
+	Family      bgp.Family
+	Count       uint32
+	Limit       uint32
+	Shutdown    bool
+	Timestamp   time.Time
+}
+
 type watchOptions struct {
 	bestPath         bool
 	preUpdate        bool
@@ -4868,6 +6577,16 @@ type watchOptions struct {
 	recvMessage    bool
 	initEor        bool
 	eor            bool
+
+	preAdjRibOut  bool
+	postAdjRibOut bool
+	initAdjRibOut bool
+
+	endOfInit bool
+
+	validation bool
+	// the prefix limit events
+	prefixLimit bool
 }
 
 type WatchOption func(*watchOptions)
@@ -4938,6 +6657,22 @@ func WatchEor(current bool) WatchOption {
 	}
 }
 
+// WatchValidation watches the changes of the RPKI validation states of the
+// paths in Adj-RIB-In.
+func WatchValidation() WatchOption {
+	return func(o *watchOptions) {
+		o.validation = true
+	}
+}
+
+// WatchPrefixLimit watches the prefix limits of the peers crossing the
+// thresholds.
+func WatchPrefixLimit() WatchOption {
+	return func(o *watchOptions) {
+		o.prefixLimit = true
+	}
+}
+
 func WatchPeer() WatchOption {
 	return func(o *watchOptions) {
 		o.peerState = true
@@ -4957,6 +6692,29 @@ func watchMessage(isSent bool) WatchOption {
 	}
 }
 
+// watchAdjRibOut watches the paths sent to the peers (RFC 8671), before
+// the export policy is applied if postPolicy is false.
+func watchAdjRibOut(current bool, postPolicy bool) WatchOption {
+	return func(o *watchOptions) {
+		if postPolicy {
+			o.postAdjRibOut = true
+		} else {
+			o.preAdjRibOut = true
+		}
+		if current {
+			o.initAdjRibOut = true
+		}
+	}
+}
+
+// watchEndOfInit notifies watchEventEndOfInit after the events of the
+// current state.
+func watchEndOfInit() WatchOption {
+	return func(o *watchOptions) {
+		o.endOfInit = true
+	}
+}
+
 type watcher struct {
 	opts   watchOptions
 	realCh chan watchEvent
@@ -4996,6 +6754,7 @@ func (w *watcher) Stop() {
 				}
 			}
 		}
+		w.s.stopAdjRibOutCount()
 
 		cleanInfiniteChannel(w.ch)
 		// the loop function goroutine might be blocked for
@@ -5059,6 +6818,24 @@ func (s *BgpServer) watch(opts ...WatchOption) (w *watcher) {
 		if w.opts.eor {
 			register(watchEventTypeEor, w)
 		}
+		if w.opts.preAdjRibOut {
+			if !s.isWatched(watchEventTypePreAdjRibOut) {
+				s.startAdjRibOutCount(false)
+			}
+			register(watchEventTypePreAdjRibOut, w)
+		}
+		if w.opts.postAdjRibOut {
+			if !s.isWatched(watchEventTypePostAdjRibOut) {
+				s.startAdjRibOutCount(true)
+			}
+			register(watchEventTypePostAdjRibOut, w)
+		}
+		if w.opts.validation {
+			register(watchEventTypeValidation, w)
+		}
+		if w.opts.prefixLimit {
+			register(watchEventTypePrefixLimit, w)
+		}
 		if w.opts.peerState {
 			for _, p := range s.neighborMap {
 				state := p.State()
@@ -5074,6 +6851,7 @@ func (s *BgpServer) watch(opts ...WatchOption) (w *watcher) {
 				PathList:      s.globalRib.GetBestPathList(table.GLOBAL_RIB_NAME, 0, nil),
 				MultiPathList: s.globalRib.GetBestMultiPathList(table.GLOBAL_RIB_NAME, nil),
 				Timestamp:     time.Now(),
+				Init:          true,
 			})
 		}
 		if w.opts.initEor && s.active() == nil {
@@ -5198,6 +6976,32 @@ func (s *BgpServer) watch(opts ...WatchOption) (w *watcher) {
 				}
 			}
 		}
+		if w.opts.initAdjRibOut && s.active() == nil {
+			for _, peer := range s.neighborMap {
+				if peer.State() != bgp.BGP_FSM_ESTABLISHED {
+					continue
+				}
+				pre, post := s.getAdjRibOut(peer, peer.configuredRFlist())
+				for _, postPolicy := range []bool{false, true} {
+					if (postPolicy && !w.opts.postAdjRibOut) || (!postPolicy && !w.opts.preAdjRibOut) {
+						continue
+					}
+					paths := clonePathList(pre)
+					if postPolicy {
+						paths = clonePathList(post)
+					}
+					for _, rf := range peer.configuredRFlist() {
+						paths = append(paths, table.NewEOR(rf))
+					}
+					ev := s.newWatchEventAdjRibOut(peer, paths, postPolicy)
+					ev.Init = true
+					w.notify(ev)
+				}
+			}
+		}
+		if w.opts.endOfInit {
+			w.notify(&watchEventEndOfInit{Timestamp: time.Now()})
+		}
 		if w.opts.recvMessage {
 			register(watchEventTypeRecvMsg, w)
 		}
@@ -17,10 +17,18 @@ package server
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
 	"fmt"
 	"log/slog"
 	"net"
 	"net/netip"
+	"os"
+	"path/filepath"
 	"runtime"
 	"slices"
 	"strconv"
@@ -32,6 +40,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/osrg/gobgp/v4/api"
 	"github.com/osrg/gobgp/v4/internal/pkg/table"
@@ -226,6 +235,379 @@ func TestListPolicyAssignment(t *testing.T) {
 	assert.Equal(4, len(ps))
 }
 
+func TestPolicyChainingApi(t *testing.T) {
+	s := NewBgpServer()
+	go s.Serve()
+	require.NoError(t, s.StartBgp(context.Background(), &api.StartBgpRequest{
+		Global: &api.Global{
+			Asn:        1,
+			RouterId:   "1.1.1.1",
+			ListenPort: -1,
+		},
+	}))
+	defer s.StopBgp(context.Background(), &api.StopBgpRequest{})
+
+	require.NoError(t, s.AddPolicy(context.Background(), &api.AddPolicyRequest{Policy: &api.Policy{
+		Name: "sub",
+		Statements: []*api.Statement{{
+			Name:    "sub1",
+			Actions: &api.Actions{RouteAction: api.RouteAction_ROUTE_ACTION_ACCEPT},
+		}},
+	}}))
+	pd := &api.Policy{
+		Name: "main",
+		Statements: []*api.Statement{
+			{
+				Name:       "main1",
+				Conditions: &api.Conditions{CallPolicy: "sub"},
+				Actions:    &api.Actions{FlowControl: api.FlowControl_FLOW_CONTROL_GOTO, GotoStatement: "main3"},
+			},
+			{
+				Name:    "main2",
+				Actions: &api.Actions{FlowControl: api.FlowControl_FLOW_CONTROL_NEXT_POLICY},
+			},
+			{
+				Name:    "main3",
+				Actions: &api.Actions{RouteAction: api.RouteAction_ROUTE_ACTION_REJECT},
+			},
+		},
+	}
+	require.NoError(t, s.AddPolicy(context.Background(), &api.AddPolicyRequest{Policy: pd}))
+
+	var policies []*api.Policy
+	require.NoError(t, s.ListPolicy(context.Background(), &api.ListPolicyRequest{Name: "main"}, func(p *api.Policy) { policies = append(policies, p) }))
+	require.Len(t, policies, 1)
+	require.Len(t, policies[0].Statements, 3)
+	assert.Equal(t, "sub", policies[0].Statements[0].Conditions.CallPolicy)
+	assert.Equal(t, api.FlowControl_FLOW_CONTROL_GOTO, policies[0].Statements[0].Actions.FlowControl)
+	assert.Equal(t, "main3", policies[0].Statements[0].Actions.GotoStatement)
+	assert.Equal(t, api.FlowControl_FLOW_CONTROL_NEXT_POLICY, policies[0].Statements[1].Actions.FlowControl)
+
+	// the policy calling itself through the other one
+	assert.Error(t, s.AddStatement(context.Background(), &api.AddStatementRequest{Statement: &api.Statement{
+		Name:       "sub1",
+		Conditions: &api.Conditions{CallPolicy: "main"},
+	}}))
+	assert.Error(t, s.AddPolicy(context.Background(), &api.AddPolicyRequest{Policy: &api.Policy{
+		Name: "invalid",
+		Statements: []*api.Statement{{
+			Name:    "invalid1",
+			Actions: &api.Actions{RouteAction: api.RouteAction_ROUTE_ACTION_ACCEPT, FlowControl: api.FlowControl_FLOW_CONTROL_NEXT_POLICY},
+		}},
+	}}))
+	assert.Error(t, s.DeletePolicy(context.Background(), &api.DeletePolicyRequest{Policy: &api.Policy{Name: "sub"}, All: true}))
+}
+
+func TestPolicyExpandedActionsApi(t *testing.T) {
+	s := NewBgpServer()
+	go s.Serve()
+	require.NoError(t, s.StartBgp(context.Background(), &api.StartBgpRequest{
+		Global: &api.Global{
+			Asn:        1,
+			RouterId:   "1.1.1.1",
+			ListenPort: -1,
+		},
+	}))
+	defer s.StopBgp(context.Background(), &api.StopBgpRequest{})
+
+	actions := &api.Actions{
+		RouteAction:         api.RouteAction_ROUTE_ACTION_ACCEPT,
+		AsPathReplace:       &api.AsPathReplaceAction{Asns: []uint32{100, 200}, ReplaceAsn: 300},
+		AsPathRemovePrivate: &api.AsPathRemovePrivateAction{RemovePrivate: api.RemovePrivate_REMOVE_PRIVATE_REPLACE},
+		AsPathExclude:       &api.AsPathExcludeAction{Asns: []uint32{400}},
+		Weight:              &api.WeightAction{Value: 10},
+		Tag:                 &api.TagAction{Value: 20},
+		Aigp:                &api.AigpAction{Type: api.AigpAction_TYPE_MOD, Value: -30},
+		LinkBandwidth:       &api.LinkBandwidthAction{Bandwidth: 1000},
+	}
+	require.NoError(t, s.AddPolicy(context.Background(), &api.AddPolicyRequest{Policy: &api.Policy{
+		Name:       "p1",
+		Statements: []*api.Statement{{Name: "s1", Actions: actions}},
+	}}))
+
+	var policies []*api.Policy
+	require.NoError(t, s.ListPolicy(context.Background(), &api.ListPolicyRequest{Name: "p1"}, func(p *api.Policy) { policies = append(policies, p) }))
+	require.Len(t, policies, 1)
+	require.Len(t, policies[0].Statements, 1)
+	assert.True(t, proto.Equal(actions, policies[0].Statements[0].Actions), policies[0].Statements[0].Actions)
+}
+
+func TestPolicyAdditionalConditionsApi(t *testing.T) {
+	s := NewBgpServer()
+	go s.Serve()
+	require.NoError(t, s.StartBgp(context.Background(), &api.StartBgpRequest{
+		Global: &api.Global{
+			Asn:        1,
+			RouterId:   "1.1.1.1",
+			ListenPort: -1,
+		},
+	}))
+	defer s.StopBgp(context.Background(), &api.StopBgpRequest{})
+
+	conditions := &api.Conditions{
+		OriginAsns:           []uint32{100, 200},
+		NeighborAsns:         []uint32{300},
+		PrefixLengthRange:    &api.PrefixLengthRange{Min: 16, Max: 24},
+		SourcePeerTypes:      []api.SourcePeerType{api.SourcePeerType_SOURCE_PEER_TYPE_IBGP, api.SourcePeerType_SOURCE_PEER_TYPE_NETLINK},
+		PathAge:              &api.PathAge{Type: api.Comparison_COMPARISON_GE, Seconds: 60},
+		AigpRange:            &api.AigpRange{Min: 10, Max: 20},
+		AttributeTypePresent: uint32(bgp.BGP_ATTR_TYPE_AIGP),
+	}
+	require.NoError(t, s.AddPolicy(context.Background(), &api.AddPolicyRequest{Policy: &api.Policy{
+		Name: "p1",
+		Statements: []*api.Statement{{
+			Name:       "s1",
+			Conditions: conditions,
+			Actions:    &api.Actions{RouteAction: api.RouteAction_ROUTE_ACTION_ACCEPT},
+		}},
+	}}))
+
+	var policies []*api.Policy
+	require.NoError(t, s.ListPolicy(context.Background(), &api.ListPolicyRequest{Name: "p1"}, func(p *api.Policy) { policies = append(policies, p) }))
+	require.Len(t, policies, 1)
+	require.Len(t, policies[0].Statements, 1)
+	c := policies[0].Statements[0].Conditions
+	assert.Equal(t, conditions.OriginAsns, c.OriginAsns)
+	assert.Equal(t, conditions.NeighborAsns, c.NeighborAsns)
+	assert.True(t, proto.Equal(conditions.PrefixLengthRange, c.PrefixLengthRange))
+	assert.Equal(t, conditions.SourcePeerTypes, c.SourcePeerTypes)
+	assert.True(t, proto.Equal(conditions.PathAge, c.PathAge))
+	assert.True(t, proto.Equal(conditions.AigpRange, c.AigpRange))
+	assert.Equal(t, conditions.AttributeTypePresent, c.AttributeTypePresent)
+
+	err := s.AddPolicy(context.Background(), &api.AddPolicyRequest{Policy: &api.Policy{
+		Name: "p2",
+		Statements: []*api.Statement{{
+			Name:       "s1",
+			Conditions: &api.Conditions{PrefixLengthRange: &api.PrefixLengthRange{Min: 24, Max: 16}},
+		}},
+	}})
+	assert.Error(t, err)
+}
+
+func TestPolicyStatisticsApi(t *testing.T) {
+	ctx := context.Background()
+	s := NewBgpServer()
+	go s.Serve()
+	require.NoError(t, s.StartBgp(ctx, &api.StartBgpRequest{
+		Global: &api.Global{
+			Asn:        1,
+			RouterId:   "1.1.1.1",
+			ListenPort: -1,
+		},
+	}))
+	defer s.StopBgp(ctx, &api.StopBgpRequest{})
+
+	require.NoError(t, s.AddDefinedSet(ctx, &api.AddDefinedSetRequest{DefinedSet: &api.DefinedSet{
+		DefinedType: api.DefinedType_DEFINED_TYPE_PREFIX,
+		Name:        "d1",
+		Prefixes:    []*api.Prefix{{IpPrefix: "10.0.0.0/24", MaskLengthMin: 24, MaskLengthMax: 24}},
+	}}))
+	p1 := &api.Policy{
+		Name: "p1",
+		Statements: []*api.Statement{{
+			Name:       "s1",
+			Conditions: &api.Conditions{PrefixSet: &api.MatchSet{Name: "d1", Type: api.MatchSet_TYPE_ANY}},
+			Actions:    &api.Actions{RouteAction: api.RouteAction_ROUTE_ACTION_REJECT},
+		}},
+	}
+	require.NoError(t, s.AddPolicy(ctx, &api.AddPolicyRequest{Policy: p1}))
+	require.NoError(t, s.AddPolicyAssignment(ctx, &api.AddPolicyAssignmentRequest{
+		Assignment: &api.PolicyAssignment{
+			Name:          table.GLOBAL_RIB_NAME,
+			Direction:     api.PolicyDirection_POLICY_DIRECTION_IMPORT,
+			Policies:      []*api.Policy{p1},
+			DefaultAction: api.RouteAction_ROUTE_ACTION_ACCEPT,
+		},
+	}))
+	for _, prefix := range []string{"10.0.0.0/24", "10.0.1.0/24"} {
+		nlri, _ := bgp.NewIPAddrPrefix(netip.MustParsePrefix(prefix))
+		nh, _ := bgp.NewPathAttributeNextHop(netip.MustParseAddr("10.0.0.1"))
+		_, err := s.AddPath(apiutil.AddPathRequest{Paths: []*apiutil.Path{{
+			Family: bgp.RF_IPv4_UC,
+			Nlri:   nlri,
+			Attrs:  []bgp.PathAttributeInterface{bgp.NewPathAttributeOrigin(0), nh},
+		}}})
+		require.NoError(t, err)
+	}
+
+	check := func(assignment, policy, statement *api.PolicyStatistics) {
+		var policies []*api.Policy
+		require.NoError(t, s.ListPolicy(ctx, &api.ListPolicyRequest{Name: "p1"}, func(p *api.Policy) { policies = append(policies, p) }))
+		require.Len(t, policies, 1)
+		assert.True(t, proto.Equal(policy, policies[0].Statistics), policies[0].Statistics)
+		require.Len(t, policies[0].Statements, 1)
+		assert.True(t, proto.Equal(statement, policies[0].Statements[0].Statistics), policies[0].Statements[0].Statistics)
+
+		var stmts []*api.Statement
+		require.NoError(t, s.ListStatement(ctx, &api.ListStatementRequest{Name: "s1"}, func(st *api.Statement) { stmts = append(stmts, st) }))
+		require.Len(t, stmts, 1)
+		assert.True(t, proto.Equal(statement, stmts[0].Statistics), stmts[0].Statistics)
+
+		var assignments []*api.PolicyAssignment
+		require.NoError(t, s.ListPolicyAssignment(ctx, &api.ListPolicyAssignmentRequest{
+			Name:      table.GLOBAL_RIB_NAME,
+			Direction: api.PolicyDirection_POLICY_DIRECTION_IMPORT,
+		}, func(a *api.PolicyAssignment) { assignments = append(assignments, a) }))
+		require.Len(t, assignments, 1)
+		assert.True(t, proto.Equal(assignment, assignments[0].Statistics), assignments[0].Statistics)
+		require.Len(t, assignments[0].Policies, 1)
+		assert.True(t, proto.Equal(policy, assignments[0].Policies[0].Statistics), assignments[0].Policies[0].Statistics)
+	}
+	check(&api.PolicyStatistics{Evaluations: 2, Matches: 1, Accepts: 1, Rejects: 1},
+		&api.PolicyStatistics{Evaluations: 2, Matches: 1, Rejects: 1},
+		&api.PolicyStatistics{Evaluations: 2, Matches: 1, Rejects: 1})
+
+	require.NoError(t, s.ResetPolicyStatistics(ctx, &api.ResetPolicyStatisticsRequest{Assignment: table.GLOBAL_RIB_NAME}))
+	check(&api.PolicyStatistics{},
+		&api.PolicyStatistics{Evaluations: 2, Matches: 1, Rejects: 1},
+		&api.PolicyStatistics{Evaluations: 2, Matches: 1, Rejects: 1})
+	require.NoError(t, s.ResetPolicyStatistics(ctx, &api.ResetPolicyStatisticsRequest{}))
+	check(&api.PolicyStatistics{}, &api.PolicyStatistics{}, &api.PolicyStatistics{})
+	assert.Error(t, s.ResetPolicyStatistics(ctx, &api.ResetPolicyStatisticsRequest{Name: "p2"}))
+}
+
+func TestPolicyStatisticsExport(t *testing.T) {
+	s, rib := newUpdateGroupTestServer(t)
+	p := addEstablishedPeer(t, s, rib, 65001, "10.0.0.1", "10.0.0.254")
+
+	rp := &oc.RoutingPolicy{
+		DefinedSets: oc.DefinedSets{
+			PrefixSets: []oc.PrefixSet{{
+				PrefixSetName: "ps1",
+				PrefixList:    []oc.Prefix{{IpPrefix: netip.MustParsePrefix("10.2.0.0/24")}},
+			}},
+		},
+		PolicyDefinitions: []oc.PolicyDefinition{{
+			Name: "p1",
+			Statements: []oc.Statement{{
+				Name:       "s1",
+				Conditions: oc.Conditions{MatchPrefixSet: oc.MatchPrefixSet{PrefixSet: "ps1"}},
+				Actions:    oc.Actions{RouteDisposition: oc.ROUTE_DISPOSITION_REJECT_ROUTE},
+			}},
+		}},
+	}
+	ap := map[string]oc.ApplyPolicy{
+		table.GLOBAL_RIB_NAME: {Config: oc.ApplyPolicyConfig{ExportPolicyList: []string{"p1"}, DefaultExportPolicy: oc.DEFAULT_POLICY_TYPE_ACCEPT_ROUTE}},
+	}
+	require.NoError(t, s.policyUpdated(s.policy.Reset(rp, ap)))
+
+	var paths []*table.Path
+	for _, prefix := range []string{"10.1.0.0/24", "10.2.0.0/24"} {
+		path := newUpdateGroupTestPath(prefix, 0)
+		paths = append(paths, path)
+		s.propagateUpdateToNeighbors(rib, nil, path, rib.Update(path), true)
+	}
+	stats := func() table.PolicyStatistics {
+		return s.policy.AssignmentStatistics(table.GLOBAL_RIB_NAME, table.POLICY_DIRECTION_EXPORT)
+	}
+	assert.Equal(t, table.PolicyStatistics{Evaluations: 2, Matches: 1, Accepts: 1, Rejects: 1}, stats())
+
+	// the views of Adj-RIB-Out aren't counted
+	pre, post := s.getAdjRibOut(p, []bgp.Family{bgp.RF_IPv4_UC})
+	assert.Len(t, pre, 2)
+	assert.Len(t, post, 1)
+	accepted, _, _ := s.viewBestFromLocal(p, []bgp.Family{bgp.RF_IPv4_UC}, false)
+	assert.Len(t, accepted, 1)
+	assert.Equal(t, table.PolicyStatistics{Evaluations: 2, Matches: 1, Accepts: 1, Rejects: 1}, stats())
+
+	// the old path checked for the withdrawal isn't counted again
+	w, _ := s.filterpath(p, paths[1], paths[0])
+	require.NotNil(t, w)
+	assert.True(t, w.IsWithdraw)
+	assert.Equal(t, table.PolicyStatistics{Evaluations: 3, Matches: 2, Accepts: 1, Rejects: 2}, stats())
+}
+
+func TestTestPolicy(t *testing.T) {
+	ctx := context.Background()
+	s := runNewServer(t, 1, "1.1.1.1", 10179)
+	defer s.StopBgp(ctx, &api.StopBgpRequest{})
+
+	newPath := func(prefix string) *api.Path {
+		nlri, _ := bgp.NewIPAddrPrefix(netip.MustParsePrefix(prefix))
+		nh, _ := bgp.NewPathAttributeNextHop(netip.MustParseAddr("10.0.0.1"))
+		attrs := []bgp.PathAttributeInterface{bgp.NewPathAttributeOrigin(0), nh}
+		return toPathApi(toPathApiUtil(table.NewPath(bgp.RF_IPv4_UC, nil, bgp.PathNLRI{NLRI: nlri}, false, attrs, time.Now(), false)), false, false, false)
+	}
+	_, err := s.AddPath(apiutil.AddPathRequest{Paths: []*apiutil.Path{mustApi2apiutilPath(newPath("10.0.0.0/24"))}})
+	require.NoError(t, err)
+
+	require.NoError(t, s.AddDefinedSet(ctx, &api.AddDefinedSetRequest{DefinedSet: &api.DefinedSet{
+		DefinedType: api.DefinedType_DEFINED_TYPE_PREFIX,
+		Name:        "d1",
+		Prefixes:    []*api.Prefix{{IpPrefix: "10.0.0.0/24", MaskLengthMin: 24, MaskLengthMax: 24}},
+	}}))
+	p1 := &api.Policy{
+		Name: "p1",
+		Statements: []*api.Statement{{
+			Name:       "s1",
+			Conditions: &api.Conditions{PrefixSet: &api.MatchSet{Name: "d1", Type: api.MatchSet_TYPE_ANY}},
+			Actions: &api.Actions{
+				Med:         &api.MedAction{Type: api.MedAction_TYPE_REPLACE, Value: 100},
+				RouteAction: api.RouteAction_ROUTE_ACTION_ACCEPT,
+			},
+		}},
+	}
+	require.NoError(t, s.AddPolicy(ctx, &api.AddPolicyRequest{Policy: p1}))
+	require.NoError(t, s.AddPolicyAssignment(ctx, &api.AddPolicyAssignmentRequest{
+		Assignment: &api.PolicyAssignment{
+			Name:          table.GLOBAL_RIB_NAME,
+			Direction:     api.PolicyDirection_POLICY_DIRECTION_IMPORT,
+			Policies:      []*api.Policy{p1},
+			DefaultAction: api.RouteAction_ROUTE_ACTION_REJECT,
+		},
+	}))
+
+	// the path in the rib, through the named policy
+	rsp, err := s.TestPolicy(ctx, &api.TestPolicyRequest{
+		Policies: []string{"p1"},
+		Lookup: &api.TestPolicyRequest_Lookup{
+			Family: &api.Family{Afi: api.Family_AFI_IP, Safi: api.Family_SAFI_UNICAST},
+			Prefix: "10.0.0.0/24",
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, api.TestPolicyResponse_DECISION_ACCEPT, rsp.Decision)
+	assert.False(t, rsp.DefaultPolicy)
+	require.Len(t, rsp.Statements, 1)
+	assert.Equal(t, "p1", rsp.Statements[0].Policy)
+	assert.True(t, rsp.Statements[0].Matched)
+	assert.Equal(t, []*api.TestPolicyResponse_Condition{{Type: "prefix-set", Set: "d1", Matched: true}}, rsp.Statements[0].Conditions)
+	assert.Equal(t, []string{"set-med 100"}, rsp.Statements[0].Actions)
+	require.Len(t, rsp.Diff, 1)
+	assert.Equal(t, bgp.BGP_ATTR_TYPE_MULTI_EXIT_DISC.String(), rsp.Diff[0].Type)
+	assert.Empty(t, rsp.Diff[0].Before)
+
+	// the synthetic path, through the import policies of the global rib
+	rsp, err = s.TestPolicy(ctx, &api.TestPolicyRequest{
+		Direction: api.PolicyDirection_POLICY_DIRECTION_IMPORT,
+		Path:      newPath("10.1.0.0/24"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, api.TestPolicyResponse_DECISION_REJECT, rsp.Decision)
+	assert.True(t, rsp.DefaultPolicy)
+	require.Len(t, rsp.Statements, 1)
+	assert.False(t, rsp.Statements[0].Matched)
+	assert.Empty(t, rsp.Diff)
+
+	// the rib isn't changed
+	var paths int
+	require.NoError(t, s.ListPath(apiutil.ListPathRequest{TableType: api.TableType_TABLE_TYPE_GLOBAL, Family: bgp.RF_IPv4_UC}, func(_ bgp.NLRI, l []*apiutil.Path) {
+		paths += len(l)
+		for _, p := range l {
+			for _, a := range p.Attrs {
+				assert.NotEqual(t, bgp.BGP_ATTR_TYPE_MULTI_EXIT_DISC, a.GetType())
+			}
+		}
+	}))
+	assert.Equal(t, 1, paths)
+
+	_, err = s.TestPolicy(ctx, &api.TestPolicyRequest{Policies: []string{"none"}, Path: newPath("10.1.0.0/24")})
+	assert.Error(t, err)
+	_, err = s.TestPolicy(ctx, &api.TestPolicyRequest{Policies: []string{"p1"}})
+	assert.Error(t, err)
+}
+
 //nolint:errcheck // WatchEvent won't return an error here
 func waitState(s *BgpServer, state api.PeerState_SessionState, expectedFamilies ...bgp.Family) *sync.WaitGroup {
 	wg := &sync.WaitGroup{}
@@ -755,9 +1137,11 @@ func TestListPathEnableMultipath(t *testing.T) {
 	require.NoError(t, err)
 
 	tests := []struct {
-		name         string
-		useMultiPath bool
-		expectedBest int
+		name                 string
+		useMultiPath         bool
+		multipathAsPathMatch bool
+		multipathRelax       bool
+		expectedBest         int
 	}{
 		{
 			name:         "without multipath",
@@ -769,6 +1153,19 @@ func TestListPathEnableMultipath(t *testing.T) {
 			useMultiPath: true,
 			expectedBest: 2,
 		},
+		{
+			name:                 "with multipath as path match",
+			useMultiPath:         true,
+			multipathAsPathMatch: true,
+			expectedBest:         1,
+		},
+		{
+			name:                 "with multipath as path match and relax",
+			useMultiPath:         true,
+			multipathAsPathMatch: true,
+			multipathRelax:       true,
+			expectedBest:         2,
+		},
 	}
 
 	for _, tt := range tests {
@@ -781,6 +1178,10 @@ func TestListPathEnableMultipath(t *testing.T) {
 					RouterId:         "1.1.1.1",
 					UseMultiplePaths: tt.useMultiPath,
 					ListenPort:       -1,
+					RouteSelectionOptions: &api.RouteSelectionOptionsConfig{
+						MultipathAsPathMatch: tt.multipathAsPathMatch,
+						MultipathRelax:       tt.multipathRelax,
+					},
 				},
 			})
 			require.NoError(t, err)
@@ -795,6 +1196,8 @@ func TestListPathEnableMultipath(t *testing.T) {
 				apiutil.ListPathRequest{
 					TableType: api.TableType_TABLE_TYPE_LOCAL,
 					Family:    bgp.RF_IPv4_UC,
+
+					EnableBestPathReason: true,
 				},
 				func(prefix bgp.NLRI, paths []*apiutil.Path) {
 					// We should only see 10.0.0.0/24
@@ -811,6 +1214,7 @@ func TestListPathEnableMultipath(t *testing.T) {
 						if path.Best {
 							bestCount++
 						}
+						require.NotEqual(t, api.BestPathReason_BEST_PATH_REASON_UNSPECIFIED, path.BestPathReason)
 					}
 					require.Equal(t, tt.expectedBest, bestCount, "%d best path(s) expected", tt.expectedBest)
 				},
@@ -1070,7 +1474,7 @@ func TestNumGoroutineWithAddDeleteNeighbor(t *testing.T) {
 	assert.Equal(num, runtime.NumGoroutine())
 }
 
-func newPeerandInfo(t *testing.T, myAs, as uint32, address string, rib *table.TableManager) *peer {
+func newPeerandInfo(t testing.TB, myAs, as uint32, address string, rib *table.TableManager) *peer {
 	addr := netip.MustParseAddr(address)
 	nConf := &oc.Neighbor{Config: oc.NeighborConfig{PeerAs: as, NeighborAddress: addr}, State: oc.NeighborState{PeerAs: as, NeighborAddress: netip.MustParseAddr(address), RemoteRouterId: addr}}
 	gConf := &oc.Global{Config: oc.GlobalConfig{As: myAs}}
@@ -1235,7 +1639,7 @@ func TestFilterpathWithRejectPolicy(t *testing.T) {
 		new, old := process(rib2, []*table.Path{path1})
 		assert.Equal(t, new, path1)
 		s := NewBgpServer()
-		path2 := s.filterpath(p2, new, old)
+		path2, _ := s.filterpath(p2, new, old)
 		if addCommunity {
 			assert.True(t, path2.IsWithdraw)
 		} else {
@@ -3253,3 +3657,582 @@ func TestEBGPRouteStuck(test *testing.T) {
 		assertPathCount(collect, peers[2], 1)
 	}, 20*time.Second, 1*time.Millisecond)
 }
+
+func TestOutboundRouteFiltering(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	server1 := NewBgpServer()
+	go server1.Serve()
+	err := server1.StartBgp(ctx, &api.StartBgpRequest{
+		Global: &api.Global{
+			Asn:        1,
+			RouterId:   "1.1.1.1",
+			ListenPort: 10179,
+		},
+	})
+	assert.NoError(err)
+	defer server1.StopBgp(ctx, &api.StopBgpRequest{})
+
+	server2 := NewBgpServer()
+	go server2.Serve()
+	err = server2.StartBgp(ctx, &api.StartBgpRequest{
+		Global: &api.Global{
+			Asn:        2,
+			RouterId:   "2.2.2.2",
+			ListenPort: -1,
+		},
+	})
+	assert.NoError(err)
+	defer server2.StopBgp(ctx, &api.StopBgpRequest{})
+
+	family := &api.Family{
+		Afi:  api.Family_AFI_IP,
+		Safi: api.Family_SAFI_UNICAST,
+	}
+	bgpFamily := bgp.NewFamily(uint16(family.Afi), uint8(family.Safi))
+	afiSafis := func(mode api.OutboundRouteFilteringConfig_Mode, prefixSet string) []*api.AfiSafi {
+		return []*api.AfiSafi{{
+			Config: &api.AfiSafiConfig{
+				Family:  family,
+				Enabled: true,
+			},
+			OutboundRouteFiltering: &api.OutboundRouteFiltering{
+				Config: &api.OutboundRouteFilteringConfig{
+					Mode:      mode,
+					PrefixSet: prefixSet,
+				},
+			},
+		}}
+	}
+
+	// server2 pushes the prefix-set "orf" to server1.
+	d1 := &api.DefinedSet{
+		DefinedType: api.DefinedType_DEFINED_TYPE_PREFIX,
+		Name:        "orf",
+		Prefixes: []*api.Prefix{
+			{
+				IpPrefix:      "10.1.0.0/16",
+				MaskLengthMin: 16,
+				MaskLengthMax: 24,
+			},
+		},
+	}
+	err = server2.AddDefinedSet(ctx, &api.AddDefinedSetRequest{DefinedSet: d1})
+	assert.NoError(err)
+
+	attrs := []*api.Attribute{
+		{
+			Attr: &api.Attribute_Origin{Origin: &api.OriginAttribute{
+				Origin: 0,
+			}},
+		},
+		{
+			Attr: &api.Attribute_NextHop{NextHop: &api.NextHopAttribute{
+				NextHop: "10.0.0.1",
+			}},
+		},
+	}
+	for _, prefix := range []string{"10.1.0.0", "10.2.0.0"} {
+		_, err = server1.AddPath(apiutil.AddPathRequest{Paths: []*apiutil.Path{
+			mustApi2apiutilPath(&api.Path{
+				Family: family,
+				Nlri: &api.NLRI{Nlri: &api.NLRI_Prefix{Prefix: &api.IPAddressPrefix{
+					Prefix:    prefix,
+					PrefixLen: 24,
+				}}},
+				Pattrs: attrs,
+			}),
+		}})
+		assert.NoError(err)
+	}
+
+	peer1 := &api.Peer{
+		Conf: &api.PeerConf{
+			NeighborAddress: "127.0.0.1",
+			PeerAsn:         2,
+		},
+		Transport: &api.Transport{
+			PassiveMode: true,
+		},
+		AfiSafis: afiSafis(api.OutboundRouteFilteringConfig_MODE_RECEIVE, ""),
+	}
+	err = server1.AddPeer(ctx, &api.AddPeerRequest{Peer: peer1})
+	assert.NoError(err)
+
+	peer2 := &api.Peer{
+		Conf: &api.PeerConf{
+			NeighborAddress: "127.0.0.1",
+			PeerAsn:         1,
+		},
+		Transport: &api.Transport{
+			RemotePort: 10179,
+		},
+		Timers: &api.Timers{
+			Config: &api.TimersConfig{
+				ConnectRetry:           1,
+				IdleHoldTimeAfterReset: 1,
+			},
+		},
+		AfiSafis: afiSafis(api.OutboundRouteFilteringConfig_MODE_SEND, "orf"),
+	}
+
+	establishedWg := waitEstablished(server1)
+	err = server2.AddPeer(ctx, &api.AddPeerRequest{Peer: peer2})
+	assert.NoError(err)
+	establishedWg.Wait()
+
+	received := func() []string {
+		var l []string
+		err := server2.ListPath(apiutil.ListPathRequest{
+			TableType: api.TableType_TABLE_TYPE_ADJ_IN,
+			Family:    bgpFamily,
+			Name:      "127.0.0.1",
+		}, func(prefix bgp.NLRI, _ []*apiutil.Path) {
+			l = append(l, prefix.String())
+		})
+		assert.NoError(err)
+		slices.Sort(l)
+		return l
+	}
+	assert.Eventually(func() bool {
+		return slices.Equal(received(), []string{"10.1.0.0/24"})
+	}, 10*time.Second, 100*time.Millisecond)
+
+	var state *api.OutboundRouteFilteringState
+	err = server1.ListPeer(ctx, &api.ListPeerRequest{}, func(p *api.Peer) {
+		state = p.AfiSafis[0].OutboundRouteFiltering.State
+	})
+	assert.NoError(err)
+	assert.True(state.Receive)
+	assert.False(state.Send)
+	assert.Equal(1, len(state.Received))
+	assert.Equal("10.1.0.0/16", state.Received[0].Prefix)
+	assert.Equal(uint32(24), state.Received[0].MaxLen)
+
+	// the updated prefix-set is pushed again
+	d1.Prefixes = append(d1.Prefixes, &api.Prefix{
+		IpPrefix:      "10.2.0.0/24",
+		MaskLengthMin: 24,
+		MaskLengthMax: 24,
+	})
+	err = server2.AddDefinedSet(ctx, &api.AddDefinedSetRequest{DefinedSet: d1, Replace: true})
+	assert.NoError(err)
+	assert.Eventually(func() bool {
+		return slices.Equal(received(), []string{"10.1.0.0/24", "10.2.0.0/24"})
+	}, 10*time.Second, 100*time.Millisecond)
+}
+
+func TestOutboundRouteFilteringWithdraw(t *testing.T) {
+	assert := assert.New(t)
+	s, rib := newUpdateGroupTestServer(t)
+
+	p := addEstablishedPeer(t, s, rib, 65001, "10.0.0.1", "10.0.0.254")
+	p.fsm.pConf.AfiSafis[0].OutboundRouteFiltering.State.Mode = oc.ORF_MODE_TYPE_RECEIVE
+	p.fsm.capMap[bgp.BGP_CAP_OUTBOUND_ROUTE_FILTERING] = []bgp.ParameterCapabilityInterface{
+		bgp.NewCapOutboundRouteFiltering([]*bgp.CapORFTuple{
+			bgp.NewCapORFTuple(bgp.RF_IPv4_UC, []*bgp.CapORFEntry{{Type: bgp.ORF_TYPE_ADDRESS_PREFIX, Mode: bgp.ORF_SEND}}),
+		}),
+	}
+	require.True(t, p.isORFReceiveEnabled(bgp.RF_IPv4_UC))
+	s.joinUpdateGroup(p)
+	require.Nil(t, p.updateGroup)
+
+	// the export policy never lets 10.2.0.0/24 through
+	rp := &oc.RoutingPolicy{
+		DefinedSets: oc.DefinedSets{
+			PrefixSets: []oc.PrefixSet{{
+				PrefixSetName: "ps1",
+				PrefixList:    []oc.Prefix{{IpPrefix: netip.MustParsePrefix("10.2.0.0/24")}},
+			}},
+		},
+		PolicyDefinitions: []oc.PolicyDefinition{{
+			Name: "p1",
+			Statements: []oc.Statement{{
+				Name:       "s1",
+				Conditions: oc.Conditions{MatchPrefixSet: oc.MatchPrefixSet{PrefixSet: "ps1"}},
+				Actions:    oc.Actions{RouteDisposition: oc.ROUTE_DISPOSITION_REJECT_ROUTE},
+			}},
+		}},
+	}
+	ap := map[string]oc.ApplyPolicy{
+		table.GLOBAL_RIB_NAME: {Config: oc.ApplyPolicyConfig{ExportPolicyList: []string{"p1"}, DefaultExportPolicy: oc.DEFAULT_POLICY_TYPE_ACCEPT_ROUTE}},
+	}
+	require.NoError(t, s.policyUpdated(s.policy.Reset(rp, ap)))
+
+	for _, prefix := range []string{"10.1.0.0/24", "10.2.0.0/24"} {
+		path := newUpdateGroupTestPath(prefix, 0)
+		s.propagateUpdateToNeighbors(rib, nil, path, rib.Update(path), true)
+	}
+	m := recvOutgoing(t, p)
+	require.Len(t, m.Paths, 1)
+	assert.Equal("10.1.0.0/24", m.Paths[0].GetPrefix())
+	assert.Len(p.orfAdvertised[bgp.RF_IPv4_UC], 1)
+
+	// the ORF permits neither prefix, only the one sent is withdrawn
+	rr := bgp.NewBGPRouteRefreshORFMessage(bgp.RF_IPv4_UC, bgp.ORF_WHEN_TO_REFRESH_IMMEDIATE, []*bgp.RouteRefreshORF{
+		bgp.NewRouteRefreshAddressPrefixORF([]*bgp.AddressPrefixORFEntry{
+			bgp.NewAddressPrefixORFEntry(bgp.ORF_ACTION_ADD, bgp.ORF_MATCH_PERMIT, 5, 0, 0, netip.MustParsePrefix("10.3.0.0/16")),
+		}),
+	}).Body.(*bgp.BGPRouteRefresh)
+	paths, _ := s.handleORF(p, bgp.RF_IPv4_UC, rr)
+	require.Len(t, paths, 1)
+	assert.True(paths[0].IsWithdraw)
+	assert.Equal("10.1.0.0/24", paths[0].GetPrefix())
+
+	s.sendfsmOutgoingMsg(p, paths, nil)
+	recvOutgoing(t, p)
+	assert.Empty(p.orfAdvertised[bgp.RF_IPv4_UC])
+
+	// nothing is left to withdraw
+	paths, _ = s.handleORF(p, bgp.RF_IPv4_UC, rr)
+	assert.Empty(paths)
+}
+func TestBGPsec(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	keyFile := filepath.Join(t.TempDir(), "router.key")
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), 0o600))
+
+	server1 := NewBgpServer()
+	go server1.Serve()
+	err = server1.StartBgp(ctx, &api.StartBgpRequest{
+		Global: &api.Global{
+			Asn:        1,
+			RouterId:   "1.1.1.1",
+			ListenPort: 10179,
+		},
+	})
+	assert.NoError(err)
+	defer server1.StopBgp(ctx, &api.StopBgpRequest{})
+
+	server2 := NewBgpServer()
+	go server2.Serve()
+	err = server2.StartBgp(ctx, &api.StartBgpRequest{
+		Global: &api.Global{
+			Asn:           2,
+			RouterId:      "2.2.2.2",
+			ListenPort:    -1,
+			BgpsecSigning: &api.BgpsecSigning{PrivateKeyFile: keyFile},
+		},
+	})
+	assert.NoError(err)
+	defer server2.StopBgp(ctx, &api.StopBgpRequest{})
+
+	rsp, err := server2.GetBgp(ctx, &api.GetBgpRequest{})
+	require.NoError(t, err)
+	ski, err := table.BGPsecSKI(&key.PublicKey)
+	require.NoError(t, err)
+	assert.Equal(hex.EncodeToString(ski[:]), rsp.Global.BgpsecSigning.Ski)
+
+	// server1 rejects the paths with invalid signatures
+	err = server1.AddPolicy(ctx, &api.AddPolicyRequest{Policy: &api.Policy{
+		Name: "bgpsec",
+		Statements: []*api.Statement{{
+			Name:       "invalid",
+			Conditions: &api.Conditions{BgpsecResult: api.BgpsecValidationState_BGPSEC_VALIDATION_STATE_INVALID},
+			Actions:    &api.Actions{RouteAction: api.RouteAction_ROUTE_ACTION_REJECT},
+		}},
+	}})
+	assert.NoError(err)
+	err = server1.AddPolicyAssignment(ctx, &api.AddPolicyAssignmentRequest{Assignment: &api.PolicyAssignment{
+		Name:          table.GLOBAL_RIB_NAME,
+		Direction:     api.PolicyDirection_POLICY_DIRECTION_IMPORT,
+		DefaultAction: api.RouteAction_ROUTE_ACTION_ACCEPT,
+		Policies:      []*api.Policy{{Name: "bgpsec"}},
+	}})
+	assert.NoError(err)
+
+	addKey := func() error {
+		server1.routerKeyTable.Add(table.NewRouterKey(2, ski, &key.PublicKey, "test"))
+		return nil
+	}
+	require.NoError(t, server1.mgmtOperation(addKey, false))
+
+	family := &api.Family{
+		Afi:  api.Family_AFI_IP,
+		Safi: api.Family_SAFI_UNICAST,
+	}
+	bgpFamily := bgp.NewFamily(uint16(family.Afi), uint8(family.Safi))
+	afiSafis := func(send, receive bool) []*api.AfiSafi {
+		return []*api.AfiSafi{{
+			Config: &api.AfiSafiConfig{
+				Family:  family,
+				Enabled: true,
+			},
+			Bgpsec: &api.Bgpsec{
+				Config: &api.BgpsecConfig{
+					Send:    send,
+					Receive: receive,
+				},
+			},
+		}}
+	}
+
+	_, err = server2.AddPath(apiutil.AddPathRequest{Paths: []*apiutil.Path{
+		mustApi2apiutilPath(&api.Path{
+			Family: family,
+			Nlri: &api.NLRI{Nlri: &api.NLRI_Prefix{Prefix: &api.IPAddressPrefix{
+				Prefix:    "10.1.0.0",
+				PrefixLen: 24,
+			}}},
+			Pattrs: []*api.Attribute{
+				{Attr: &api.Attribute_Origin{Origin: &api.OriginAttribute{Origin: 0}}},
+				{Attr: &api.Attribute_NextHop{NextHop: &api.NextHopAttribute{NextHop: "10.0.0.1"}}},
+			},
+		}),
+	}})
+	assert.NoError(err)
+
+	peer1 := &api.Peer{
+		Conf: &api.PeerConf{
+			NeighborAddress: "127.0.0.1",
+			PeerAsn:         2,
+		},
+		Transport: &api.Transport{
+			PassiveMode: true,
+		},
+		AfiSafis: afiSafis(false, true),
+	}
+	err = server1.AddPeer(ctx, &api.AddPeerRequest{Peer: peer1})
+	assert.NoError(err)
+
+	peer2 := &api.Peer{
+		Conf: &api.PeerConf{
+			NeighborAddress: "127.0.0.1",
+			PeerAsn:         1,
+		},
+		Transport: &api.Transport{
+			RemotePort: 10179,
+		},
+		Timers: &api.Timers{
+			Config: &api.TimersConfig{
+				ConnectRetry:           1,
+				IdleHoldTimeAfterReset: 1,
+			},
+		},
+		AfiSafis: afiSafis(true, false),
+	}
+
+	establishedWg := waitEstablished(server1)
+	err = server2.AddPeer(ctx, &api.AddPeerRequest{Peer: peer2})
+	assert.NoError(err)
+	establishedWg.Wait()
+
+	listPaths := func(tableType api.TableType, name string) []*apiutil.Path {
+		var l []*apiutil.Path
+		err := server1.ListPath(apiutil.ListPathRequest{
+			TableType: tableType,
+			Family:    bgpFamily,
+			Name:      name,
+		}, func(_ bgp.NLRI, paths []*apiutil.Path) {
+			l = append(l, paths...)
+		})
+		assert.NoError(err)
+		return l
+	}
+	assert.Eventually(func() bool {
+		return len(listPaths(api.TableType_TABLE_TYPE_GLOBAL, "")) == 1
+	}, 10*time.Second, 100*time.Millisecond)
+
+	paths := listPaths(api.TableType_TABLE_TYPE_ADJ_IN, "127.0.0.1")
+	require.Len(t, paths, 1)
+	var bgpsecPath *bgp.PathAttributeBGPsecPath
+	var asPath *bgp.PathAttributeAsPath
+	for _, a := range paths[0].Attrs {
+		switch v := a.(type) {
+		case *bgp.PathAttributeBGPsecPath:
+			bgpsecPath = v
+		case *bgp.PathAttributeAsPath:
+			asPath = v
+		}
+	}
+	require.NotNil(t, bgpsecPath)
+	assert.Equal([]*bgp.SecurePathSegment{{PCount: 1, AS: 2}}, bgpsecPath.SecurePath)
+	require.NotNil(t, asPath)
+	assert.Equal([]uint32{2}, asPath.Value[0].GetAS())
+
+	err = server1.ListPeer(ctx, &api.ListPeerRequest{}, func(p *api.Peer) {
+		assert.True(p.AfiSafis[0].Bgpsec.State.Receive)
+		assert.False(p.AfiSafis[0].Bgpsec.State.Send)
+	})
+	assert.NoError(err)
+
+	// without the router key, the signature can't be verified
+	deleteKey := func() error {
+		server1.routerKeyTable.DeleteAll("test")
+		return nil
+	}
+	require.NoError(t, server1.mgmtOperation(deleteKey, false))
+	err = server1.ResetPeer(ctx, &api.ResetPeerRequest{
+		Address:   "127.0.0.1",
+		Soft:      true,
+		Direction: api.ResetPeerRequest_DIRECTION_IN,
+	})
+	assert.NoError(err)
+	assert.Eventually(func() bool {
+		return len(listPaths(api.TableType_TABLE_TYPE_GLOBAL, "")) == 0
+	}, 10*time.Second, 100*time.Millisecond)
+}
+
+func newAlternativeTestPath(prefix, source string, as, localPref uint32, nexthop string) *table.Path {
+	info := &table.PeerInfo{
+		AS:      as,
+		LocalAS: 65000,
+		ID:      netip.MustParseAddr(source),
+		Address: netip.MustParseAddr(source),
+	}
+	nlri, _ := bgp.NewIPAddrPrefix(netip.MustParsePrefix(prefix))
+	nh, _ := bgp.NewPathAttributeNextHop(netip.MustParseAddr(nexthop))
+	attrs := []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(0),
+		bgp.NewPathAttributeAsPath(nil),
+		nh,
+		bgp.NewPathAttributeLocalPref(localPref),
+	}
+	return table.NewPath(bgp.RF_IPv4_UC, info, bgp.PathNLRI{NLRI: nlri}, false, attrs, time.Now(), false)
+}
+
+func TestAdvertiseBestExternal(t *testing.T) {
+	assert := assert.New(t)
+	s, rib := newUpdateGroupTestServer(t)
+
+	p1 := addEstablishedPeer(t, s, rib, 65000, "10.0.0.1", "10.0.0.254")
+	p2 := addEstablishedPeer(t, s, rib, 65000, "10.0.0.2", "10.0.0.254")
+	p1.fsm.pConf.RouteReflector.Config.AdvertiseBestExternal = true
+	s.joinUpdateGroup(p1)
+	assert.NotEqual(p1.updateGroup, p2.updateGroup)
+
+	external := newAlternativeTestPath("10.10.0.0/24", "10.0.0.100", 65100, 100, "10.0.0.100")
+	s.propagateUpdateToNeighbors(rib, nil, external, rib.Update(external), true)
+	for _, p := range []*peer{p1, p2} {
+		m := recvOutgoing(t, p)
+		require.Len(t, m.Paths, 1)
+		assert.False(m.Paths[0].IsWithdraw)
+	}
+
+	// the iBGP path becomes the best, which isn't advertised to the
+	// iBGP peers
+	internal := newAlternativeTestPath("10.10.0.0/24", "10.0.0.50", 65000, 200, "10.0.0.50")
+	s.propagateUpdateToNeighbors(rib, nil, internal, rib.Update(internal), true)
+	m := recvOutgoing(t, p2)
+	require.Len(t, m.Paths, 1)
+	assert.True(m.Paths[0].IsWithdraw)
+	// p1 keeps the best external path
+	assert.Equal(0, p1.fsm.outgoingCh.Len())
+
+	paths, _, _ := s.getBestFromLocal(p1, []bgp.Family{bgp.RF_IPv4_UC}, false)
+	require.Len(t, paths, 1)
+	assert.Equal(netip.MustParseAddr("10.0.0.100"), paths[0].GetNexthop())
+}
+
+func TestAdvertiseBestExternalRouteReflectorClient(t *testing.T) {
+	assert := assert.New(t)
+	s, rib := newUpdateGroupTestServer(t)
+
+	client := addEstablishedPeer(t, s, rib, 65000, "10.0.0.1", "10.0.0.254")
+	client.fsm.pConf.RouteReflector.Config.RouteReflectorClient = true
+	client.fsm.pConf.RouteReflector.Config.AdvertiseBestExternal = true
+	client.fsm.pConf.RouteReflector.State.RouteReflectorClusterId = netip.MustParseAddr("10.0.0.254")
+	client.peerInfo.RouteReflectorClient = true
+	s.joinUpdateGroup(client)
+
+	external := newAlternativeTestPath("10.10.0.0/24", "10.0.0.100", 65100, 100, "10.0.0.100")
+	s.propagateUpdateToNeighbors(rib, nil, external, rib.Update(external), true)
+	m := recvOutgoing(t, client)
+	require.Len(t, m.Paths, 1)
+	assert.Equal(netip.MustParseAddr("10.0.0.100"), m.Paths[0].GetNexthop())
+
+	// the iBGP best path can be reflected to the client, so it's
+	// advertised instead of the best external path
+	internal := newAlternativeTestPath("10.10.0.0/24", "10.0.0.50", 65000, 200, "10.0.0.50")
+	s.propagateUpdateToNeighbors(rib, nil, internal, rib.Update(internal), true)
+	m = recvOutgoing(t, client)
+	require.Len(t, m.Paths, 1)
+	assert.False(m.Paths[0].IsWithdraw)
+	assert.Equal(netip.MustParseAddr("10.0.0.50"), m.Paths[0].GetNexthop())
+
+	paths, _, _ := s.getBestFromLocal(client, []bgp.Family{bgp.RF_IPv4_UC}, false)
+	require.Len(t, paths, 1)
+	assert.Equal(netip.MustParseAddr("10.0.0.50"), paths[0].GetNexthop())
+}
+
+func TestDiversePathAddPathSendMax(t *testing.T) {
+	assert := assert.New(t)
+	s, rib := newUpdateGroupTestServer(t)
+
+	p := addEstablishedPeer(t, s, rib, 65000, "10.0.0.1", "10.0.0.254")
+	p.fsm.pConf.RouteReflector.Config.RouteReflectorClient = true
+	p.fsm.pConf.RouteReflector.Config.DiversePath = true
+	p.fsm.pConf.RouteReflector.State.RouteReflectorClusterId = netip.MustParseAddr("10.0.0.254")
+	p.peerInfo.RouteReflectorClient = true
+	p.fsm.pConf.AfiSafis[0].AddPaths.Config.SendMax = 1
+	p.fsm.familyMap.Store(map[bgp.Family]bgp.BGPAddPathMode{bgp.RF_IPv4_UC: bgp.BGP_ADD_PATH_SEND})
+	// the diverse path doesn't raise send-max
+	assert.Equal(uint8(1), p.getAddPathSendMax(bgp.RF_IPv4_UC))
+
+	propagate := func(path *table.Path) {
+		s.propagateUpdateToNeighbors(rib, nil, path, rib.Update(path), true)
+	}
+	nexthops := func(m *fsmOutgoingMsg) map[netip.Addr]bool {
+		l := make(map[netip.Addr]bool)
+		for _, path := range m.Paths {
+			l[path.GetNexthop()] = path.IsWithdraw
+		}
+		return l
+	}
+
+	best := newAlternativeTestPath("10.10.0.0/24", "10.0.0.10", 65000, 200, "10.0.0.10")
+	propagate(best)
+	assert.Equal(map[netip.Addr]bool{netip.MustParseAddr("10.0.0.10"): false}, nexthops(recvOutgoing(t, p)))
+
+	// neither within send-max nor diverse
+	same := newAlternativeTestPath("10.10.0.0/24", "10.0.0.11", 65000, 150, "10.0.0.10")
+	propagate(same)
+	assert.Equal(0, p.fsm.outgoingCh.Len())
+	assert.True(p.isPathSendMaxFiltered(same))
+
+	// the diverse path takes its own slot
+	diverse := newAlternativeTestPath("10.10.0.0/24", "10.0.0.12", 65000, 100, "10.0.0.12")
+	propagate(diverse)
+	assert.Equal(map[netip.Addr]bool{netip.MustParseAddr("10.0.0.12"): false}, nexthops(recvOutgoing(t, p)))
+	assert.Equal(uint8(1), p.getRoutesCount(bgp.RF_IPv4_UC, "10.10.0.0/24"))
+
+	// the new diverse path replaces the old one, which is withdrawn as
+	// send-max is reached
+	other := newAlternativeTestPath("10.10.0.0/24", "10.0.0.13", 65000, 120, "10.0.0.13")
+	propagate(other)
+	assert.Equal(map[netip.Addr]bool{
+		netip.MustParseAddr("10.0.0.12"): true,
+		netip.MustParseAddr("10.0.0.13"): false,
+	}, nexthops(recvOutgoing(t, p)))
+	assert.True(p.isPathSendMaxFiltered(diverse))
+
+	// the old diverse path gets the slot back
+	propagate(other.Clone(true))
+	assert.Equal(map[netip.Addr]bool{
+		netip.MustParseAddr("10.0.0.12"): false,
+		netip.MustParseAddr("10.0.0.13"): true,
+	}, nexthops(recvOutgoing(t, p)))
+	assert.False(p.isPathSendMaxFiltered(diverse))
+	assert.Equal(uint8(1), p.getRoutesCount(bgp.RF_IPv4_UC, "10.10.0.0/24"))
+	assert.Equal(0, p.fsm.outgoingCh.Len())
+}
+
+func TestBGPsecSendRequiresFourOctetAS(t *testing.T) {
+	rib := table.NewTableManager(logger, []bgp.Family{bgp.RF_IPv4_UC})
+	p := newPeerandInfo(t, 1, 2, "10.0.0.1", rib)
+	p.fsm.pConf.AfiSafis[0].Bgpsec.State.Send = true
+	p.fsm.capMap = map[bgp.BGPCapabilityCode][]bgp.ParameterCapabilityInterface{
+		bgp.BGP_CAP_BGPSEC: {bgp.NewCapBGPsec(bgp.BGPSEC_DIRECTION_RECEIVE, bgp.AFI_IP)},
+	}
+	assert.False(t, p.isBGPsecSendEnabled(bgp.RF_IPv4_UC))
+
+	p.fsm.capMap[bgp.BGP_CAP_FOUR_OCTET_AS_NUMBER] = []bgp.ParameterCapabilityInterface{bgp.NewCapFourOctetASNumber(2)}
+	assert.True(t, p.isBGPsecSendEnabled(bgp.RF_IPv4_UC))
+}
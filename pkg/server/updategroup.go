@@ -0,0 +1,375 @@
+// Copyright (C) 2025 Acnodal Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/netip"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	api "github.com/osrg/gobgp/v4/api"
+	"github.com/osrg/gobgp/v4/internal/pkg/table"
+	"github.com/osrg/gobgp/v4/pkg/config/oc"
+	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
+)
+
+// updateGroup is a set of established peers which get the same UPDATE
+// messages: the peers have the same export policy, the same negotiated
+// families and capabilities and the same settings affecting the path
+// attributes. The outgoing paths are filtered with one of the members and
+// the messages are built and serialized once for all of them.
+type updateGroup struct {
+	id      uint64
+	key     string
+	members []*peer
+	created time.Time
+	// attributes shared by the members, for ListUpdateGroup
+	info *api.UpdateGroup
+
+	updatesBuilt uint64
+	updatesSent  uint64
+}
+
+func (g *updateGroup) toApi() *api.UpdateGroup {
+	u := &api.UpdateGroup{
+		Id:                   g.id,
+		Members:              make([]string, 0, len(g.members)),
+		Type:                 g.info.Type,
+		PeerAsn:              g.info.PeerAsn,
+		LocalAsn:             g.info.LocalAsn,
+		LocalAddress:         g.info.LocalAddress,
+		RouteServerClient:    g.info.RouteServerClient,
+		RouteReflectorClient: g.info.RouteReflectorClient,
+		Families:             g.info.Families,
+		ExportPolicies:       g.info.ExportPolicies,
+		DefaultExportAction:  g.info.DefaultExportAction,
+		Created:              timestamppb.New(g.created),
+		UpdatesBuilt:         g.updatesBuilt,
+		UpdatesSent:          g.updatesSent,
+	}
+	for _, p := range g.members {
+		u.Members = append(u.Members, p.ID())
+	}
+	slices.Sort(u.Members)
+	return u
+}
+
+// sharedUpdates are the UPDATE messages serialized once for the members of
+// an update group.
+type sharedUpdates struct {
+	messages []*bgp.BGPMessage
+	bufs     [][]byte
+}
+
+// updateGroupKey returns the key identifying the update group of the peer
+// and the attributes shared by the group. An empty key means that the peer
+// can't share UPDATE messages with others, because the outgoing paths
+// depend on its own state: the Route Target Constraint, the ORF received
+// from it, the export policy referring to its address, the outbound
+// prefix limit and so on.
+func (s *BgpServer) updateGroupKey(peer *peer) (string, *api.UpdateGroup) {
+	if peer.State() != bgp.BGP_FSM_ESTABLISHED {
+		return "", nil
+	}
+	fmap := peer.fsm.familyMap.Load().(map[bgp.Family]bgp.BGPAddPathMode)
+	families := make([]bgp.Family, 0, len(fmap))
+	for f := range fmap {
+		if f == bgp.RF_RTC_UC || peer.isORFReceiveEnabled(f) {
+			return "", nil
+		}
+		if st, ok := peer.prefixLimits[f]; ok && st.advertised != nil {
+			return "", nil
+		}
+		families = append(families, f)
+	}
+	slices.SortFunc(families, func(a, b bgp.Family) int { return cmp.Compare(uint32(a), uint32(b)) })
+
+	id := peer.TableID()
+	if s.policy.PeerDependent(id, table.POLICY_DIRECTION_EXPORT) {
+		return "", nil
+	}
+	def, policies, _ := s.policy.GetPolicyAssignment(id, table.POLICY_DIRECTION_EXPORT)
+
+	var b strings.Builder
+	peer.fsm.lock.Lock()
+	c := peer.fsm.pConf
+	_, fourOctet := peer.fsm.capMap[bgp.BGP_CAP_FOUR_OCTET_AS_NUMBER]
+	if !fourOctet || c.RouteServer.Config.SecondaryRoute {
+		peer.fsm.lock.Unlock()
+		return "", nil
+	}
+	// the peer AS matters for the AS loop check, which route server
+	// clients skip, and for replace-peer-as. Route server clients in
+	// different ASes can share the updates otherwise.
+	peerAS := c.State.PeerAs
+	if c.RouteServer.Config.RouteServerClient && !c.AsPathOptions.State.ReplacePeerAs {
+		peerAS = 0
+	}
+	info := &api.UpdateGroup{
+		Type:                 api.PeerType_PEER_TYPE_EXTERNAL,
+		PeerAsn:              peerAS,
+		LocalAsn:             c.Config.LocalAs,
+		LocalAddress:         c.Transport.State.LocalAddress.String(),
+		RouteServerClient:    c.RouteServer.Config.RouteServerClient,
+		RouteReflectorClient: c.RouteReflector.Config.RouteReflectorClient,
+		DefaultExportAction:  api.RouteAction(def),
+	}
+	if c.State.PeerType == oc.PEER_TYPE_INTERNAL {
+		info.Type = api.PeerType_PEER_TYPE_INTERNAL
+	}
+	fmt.Fprintf(&b, "type=%s as=%d local-as=%d rs=%t rr=%t cluster-id=%s best-external=%t diverse=%t vrf=%s remove-private=%s replace-peer-as=%t allow-local-loop=%t llgr=%t",
+		c.State.PeerType, peerAS, c.Config.LocalAs,
+		c.RouteServer.Config.RouteServerClient, c.RouteReflector.Config.RouteReflectorClient,
+		c.RouteReflector.State.RouteReflectorClusterId,
+		c.RouteReflector.Config.AdvertiseBestExternal, c.RouteReflector.Config.DiversePath, c.Config.Vrf, c.State.RemovePrivateAs,
+		c.AsPathOptions.State.ReplacePeerAs, c.AsPathOptions.Config.AllowAsPathLoopLocal,
+		c.GracefulRestart.Config.LongLivedEnabled)
+	peer.fsm.lock.Unlock()
+
+	if i := peer.peerInfo; i != nil {
+		fmt.Fprintf(&b, " local-address=%s confed=%t nexthop=%s,%s,%s", i.LocalAddress, i.Confederation, i.IPv4Nexthop, i.IPv6Nexthop, i.IPv6LinkLocalNexthop)
+	}
+	llgr, _ := peer.llgrFamilies()
+	for _, f := range families {
+		fmt.Fprintf(&b, " %s=%d,%t,%t", f, fmap[f], peer.isBGPsecSendEnabled(f), slices.Contains(llgr, f))
+		info.Families = append(info.Families, &api.Family{Afi: api.Family_Afi(f.Afi()), Safi: api.Family_Safi(f.Safi())})
+	}
+	fmt.Fprintf(&b, " export=%d", def)
+	for _, p := range policies {
+		fmt.Fprintf(&b, ",%s", p.Name)
+		info.ExportPolicies = append(info.ExportPolicies, p.Name)
+	}
+	return b.String(), info
+}
+
+// joinUpdateGroup moves the peer to the update group matching its current
+// state and configuration, creating it if necessary.
+func (s *BgpServer) joinUpdateGroup(peer *peer) {
+	key, info := s.updateGroupKey(peer)
+	if g := peer.updateGroup; g != nil {
+		if g.key == key {
+			return
+		}
+		s.leaveUpdateGroup(peer)
+	}
+	if key == "" {
+		return
+	}
+	g, ok := s.updateGroups[key]
+	if !ok {
+		s.updateGroupID++
+		g = &updateGroup{
+			id:      s.updateGroupID,
+			key:     key,
+			created: time.Now(),
+			info:    info,
+		}
+		s.updateGroups[key] = g
+	}
+	g.members = append(g.members, peer)
+	peer.updateGroup = g
+	peer.fsm.logger.Debug("joined update group",
+		slog.Uint64("UpdateGroup", g.id),
+		slog.Int("Members", len(g.members)))
+}
+
+func (s *BgpServer) leaveUpdateGroup(leaving *peer) {
+	g := leaving.updateGroup
+	if g == nil {
+		return
+	}
+	leaving.updateGroup = nil
+	g.members = slices.DeleteFunc(g.members, func(p *peer) bool { return p == leaving })
+	if len(g.members) == 0 {
+		delete(s.updateGroups, g.key)
+	}
+}
+
+// refreshUpdateGroups re-evaluates the update groups of all the peers,
+// after the changes of the configuration or the policies.
+func (s *BgpServer) refreshUpdateGroups() {
+	for _, peer := range s.neighborMap {
+		s.joinUpdateGroup(peer)
+	}
+}
+
+// policyUpdated refreshes the update groups after a successful change of
+// the policies, which may split or merge the groups.
+func (s *BgpServer) policyUpdated(err error) error {
+	if err == nil {
+		s.refreshUpdateGroups()
+	}
+	return err
+}
+
+// isUpdateSource reports whether one of the paths comes from the peer, in
+// which case the peer needs its own outgoing paths (see
+// filterPathFromSourcePeer).
+func isUpdateSource(peer *peer, paths ...[]*table.Path) bool {
+	peer.fsm.lock.Lock()
+	id, addr := peer.fsm.pConf.State.RemoteRouterId, peer.fsm.pConf.State.NeighborAddress
+	peer.fsm.lock.Unlock()
+	for _, l := range paths {
+		for _, p := range l {
+			if p == nil {
+				continue
+			}
+			if src := p.GetSource(); src.ID == id || src.Address == addr {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// groupBatch is the members of an update group which are sent the same
+// outgoing paths built from best and old.
+type groupBatch struct {
+	group     *updateGroup
+	best, old []*table.Path
+	members   []*peer
+}
+
+type groupBatches struct {
+	batches []*groupBatch
+	byGroup map[*updateGroup][]*groupBatch
+}
+
+// add queues the propagated paths for the peer and reports whether the
+// peer is handled by the update group.
+func (b *groupBatches) add(target *peer, best, old []*table.Path) bool {
+	g := target.updateGroup
+	if g == nil || len(g.members) < 2 || isUpdateSource(target, best, old) {
+		return false
+	}
+	for _, x := range b.byGroup[g] {
+		if slices.Equal(x.best, best) && slices.Equal(x.old, old) {
+			x.members = append(x.members, target)
+			return true
+		}
+	}
+	if b.byGroup == nil {
+		b.byGroup = make(map[*updateGroup][]*groupBatch)
+	}
+	x := &groupBatch{group: g, best: best, old: old, members: []*peer{target}}
+	b.byGroup[g] = append(b.byGroup[g], x)
+	b.batches = append(b.batches, x)
+	return true
+}
+
+// sendGroupBatches filters the paths with the first member of each batch
+// and sends the UPDATE messages, serialized once, to all the members. The
+// export policy decisions are counted for the assignments of all the
+// members.
+func (s *BgpServer) sendGroupBatches(b *groupBatches) {
+	for _, x := range b.batches {
+		members := slices.DeleteFunc(x.members, func(p *peer) bool { return !needToAdvertise(p) })
+		if len(members) == 0 {
+			continue
+		}
+		sharedWith := make([]string, 0, len(members)-1)
+		for _, p := range members[1:] {
+			sharedWith = append(sharedWith, p.TableID())
+		}
+		paths, pre := s.processOutgoingPaths(members[0], x.best, x.old, sharedWith)
+		if len(paths) == 0 {
+			continue
+		}
+		if len(members) == 1 {
+			s.sendfsmOutgoingMsg(members[0], paths, pre)
+			continue
+		}
+		u := s.buildSharedUpdates(members[0], paths)
+		if len(u.bufs) == 0 {
+			continue
+		}
+		x.group.updatesBuilt += uint64(len(u.bufs))
+		for _, p := range members {
+			p.fsm.outgoingCh.In() <- &fsmOutgoingMsg{Shared: u}
+			x.group.updatesSent += uint64(len(u.bufs))
+			s.notifyAdjRibOutWatcher(p, pre, paths)
+		}
+	}
+}
+
+func (s *BgpServer) buildSharedUpdates(peer *peer, paths []*table.Path) *sharedUpdates {
+	options := &bgp.MarshallingOption{AddPath: peer.fsm.familyMap.Load().(map[bgp.Family]bgp.BGPAddPathMode)}
+	msgs := table.CreateUpdateMsgFromPaths(paths, options)
+	u := &sharedUpdates{
+		messages: make([]*bgp.BGPMessage, 0, len(msgs)),
+		bufs:     make([][]byte, 0, len(msgs)),
+	}
+	for _, m := range msgs {
+		b, err := m.Serialize(options)
+		if err != nil {
+			s.logger.Warn("failed to serialize",
+				slog.String("Topic", "Peer"),
+				slog.Uint64("UpdateGroup", peer.updateGroup.id),
+				slog.String("Error", err.Error()))
+			continue
+		}
+		u.messages = append(u.messages, m)
+		u.bufs = append(u.bufs, b)
+	}
+	return u
+}
+
+// ListUpdateGroup lists the update groups, or the group of the peer if the
+// address is given.
+func (s *BgpServer) ListUpdateGroup(ctx context.Context, r *api.ListUpdateGroupRequest, fn func(*api.UpdateGroup)) error {
+	if r == nil {
+		return fmt.Errorf("nil request")
+	}
+	var l []*api.UpdateGroup
+	err := s.mgmtOperation(func() error {
+		if r.Address != "" {
+			addr, err := netip.ParseAddr(r.Address)
+			if err != nil {
+				return fmt.Errorf("invalid address: %s", r.Address)
+			}
+			peer, ok := s.neighborMap[addr]
+			if !ok {
+				return fmt.Errorf("neighbor that has %v doesn't exist", r.Address)
+			}
+			if g := peer.updateGroup; g != nil {
+				l = append(l, g.toApi())
+			}
+			return nil
+		}
+		for _, g := range s.updateGroups {
+			l = append(l, g.toApi())
+		}
+		return nil
+	}, false)
+	if err != nil {
+		return err
+	}
+	slices.SortFunc(l, func(a, b *api.UpdateGroup) int { return cmp.Compare(a.Id, b.Id) })
+	for _, g := range l {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		fn(g)
+	}
+	return nil
+}
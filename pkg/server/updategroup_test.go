@@ -0,0 +1,341 @@
+// Copyright (C) 2025 Acnodal Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/osrg/gobgp/v4/internal/pkg/table"
+	"github.com/osrg/gobgp/v4/pkg/config/oc"
+	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
+)
+
+func newUpdateGroupTestServer(tb testing.TB) (*BgpServer, *table.TableManager) {
+	s := NewBgpServer()
+	require.NoError(tb, s.policy.Reset(&oc.RoutingPolicy{}, nil))
+	return s, table.NewTableManager(logger, []bgp.Family{bgp.RF_IPv4_UC})
+}
+
+// addEstablishedPeer adds an established peer to the server, which joins
+// the update group.
+func addEstablishedPeer(tb testing.TB, s *BgpServer, rib *table.TableManager, as uint32, address, localAddress string) *peer {
+	p := newPeerandInfo(tb, 65000, as, address, rib)
+	p.policy = s.policy
+	p.peerInfo.LocalAddress = netip.MustParseAddr(localAddress)
+	p.fsm.capMap = map[bgp.BGPCapabilityCode][]bgp.ParameterCapabilityInterface{
+		bgp.BGP_CAP_FOUR_OCTET_AS_NUMBER: {bgp.NewCapFourOctetASNumber(65000)},
+	}
+	p.fsm.state.Store(bgp.BGP_FSM_ESTABLISHED)
+	s.neighborMap[netip.MustParseAddr(address)] = p
+	s.joinUpdateGroup(p)
+	return p
+}
+
+// addEstablishedRouteServerClient adds an established route server client
+// to the server, which joins the update group.
+func addEstablishedRouteServerClient(tb testing.TB, s *BgpServer, rib *table.TableManager, as uint32, address, localAddress string) *peer {
+	p := addEstablishedPeer(tb, s, rib, as, address, localAddress)
+	p.fsm.pConf.RouteServer.Config.RouteServerClient = true
+	p.tableId = address
+	require.NoError(tb, s.policy.SetPeerPolicy(address, oc.ApplyPolicy{}))
+	s.joinUpdateGroup(p)
+	return p
+}
+
+func newUpdateGroupTestPath(prefix string, med uint32) *table.Path {
+	source := &table.PeerInfo{
+		AS:      65100,
+		LocalAS: 65000,
+		ID:      netip.MustParseAddr("10.0.0.100"),
+		Address: netip.MustParseAddr("10.0.0.100"),
+	}
+	nlri, _ := bgp.NewIPAddrPrefix(netip.MustParsePrefix(prefix))
+	nexthop, _ := bgp.NewPathAttributeNextHop(netip.MustParseAddr("10.0.0.100"))
+	attrs := []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(0),
+		bgp.NewPathAttributeAsPath([]bgp.AsPathParamInterface{bgp.NewAs4PathParam(bgp.BGP_ASPATH_ATTR_TYPE_SEQ, []uint32{65100})}),
+		nexthop,
+		bgp.NewPathAttributeMultiExitDisc(med),
+	}
+	return table.NewPath(bgp.RF_IPv4_UC, source, bgp.PathNLRI{NLRI: nlri}, false, attrs, time.Now(), false)
+}
+
+func recvOutgoing(t *testing.T, p *peer) *fsmOutgoingMsg {
+	select {
+	case o := <-p.fsm.outgoingCh.Out():
+		return o.(*fsmOutgoingMsg)
+	case <-time.After(time.Second):
+		t.Fatalf("no outgoing message for %s", p.ID())
+	}
+	return nil
+}
+
+func TestUpdateGroup(t *testing.T) {
+	assert := assert.New(t)
+	s, rib := newUpdateGroupTestServer(t)
+
+	p1 := addEstablishedPeer(t, s, rib, 65001, "10.0.0.1", "10.0.0.254")
+	p2 := addEstablishedPeer(t, s, rib, 65001, "10.0.0.2", "10.0.0.254")
+	// different peer AS
+	p3 := addEstablishedPeer(t, s, rib, 65003, "10.0.0.3", "10.0.0.254")
+
+	require.NotNil(t, p1.updateGroup)
+	assert.Equal(p1.updateGroup, p2.updateGroup)
+	assert.NotEqual(p1.updateGroup, p3.updateGroup)
+	assert.Len(s.updateGroups, 2)
+
+	path := newUpdateGroupTestPath("10.10.0.0/24", 0)
+	s.propagateUpdateToNeighbors(rib, nil, path, rib.Update(path), true)
+
+	// p1 and p2 get the same serialized messages
+	m1, m2 := recvOutgoing(t, p1), recvOutgoing(t, p2)
+	require.NotNil(t, m1.Shared)
+	assert.Same(m1.Shared, m2.Shared)
+	assert.Len(m1.Shared.bufs, 1)
+	assert.Empty(m1.Paths)
+	// p3 is alone in its group
+	m3 := recvOutgoing(t, p3)
+	assert.Nil(m3.Shared)
+	assert.Len(m3.Paths, 1)
+
+	g := p1.updateGroup.toApi()
+	assert.Equal([]string{"10.0.0.1", "10.0.0.2"}, g.Members)
+	assert.Equal(uint64(1), g.UpdatesBuilt)
+	assert.Equal(uint64(2), g.UpdatesSent)
+
+	// the peer leaves the group when the session goes down
+	p2.fsm.state.Store(bgp.BGP_FSM_IDLE)
+	s.leaveUpdateGroup(p2)
+	assert.Nil(p2.updateGroup)
+	assert.Equal([]*peer{p1}, p1.updateGroup.members)
+
+	s.leaveUpdateGroup(p1)
+	assert.Len(s.updateGroups, 1)
+}
+
+func TestUpdateGroupPeerDependentPolicy(t *testing.T) {
+	assert := assert.New(t)
+	s, rib := newUpdateGroupTestServer(t)
+
+	p1 := addEstablishedPeer(t, s, rib, 65001, "10.0.0.1", "10.0.0.254")
+	p2 := addEstablishedPeer(t, s, rib, 65001, "10.0.0.2", "10.0.0.254")
+	assert.Equal(p1.updateGroup, p2.updateGroup)
+
+	rp := &oc.RoutingPolicy{
+		DefinedSets: oc.DefinedSets{
+			NeighborSets: []oc.NeighborSet{{NeighborSetName: "ns1", NeighborInfoList: []string{"10.0.0.1"}}},
+		},
+		PolicyDefinitions: []oc.PolicyDefinition{{
+			Name: "p1",
+			Statements: []oc.Statement{{
+				Name:       "s1",
+				Conditions: oc.Conditions{MatchNeighborSet: oc.MatchNeighborSet{NeighborSet: "ns1"}},
+				Actions:    oc.Actions{RouteDisposition: oc.ROUTE_DISPOSITION_REJECT_ROUTE},
+			}},
+		}},
+	}
+	ap := map[string]oc.ApplyPolicy{
+		table.GLOBAL_RIB_NAME: {Config: oc.ApplyPolicyConfig{ExportPolicyList: []string{"p1"}, DefaultExportPolicy: oc.DEFAULT_POLICY_TYPE_ACCEPT_ROUTE}},
+	}
+	require.NoError(t, s.policyUpdated(s.policy.Reset(rp, ap)))
+
+	// the export policy depends on the neighbor, so the peers can't share
+	// the updates
+	assert.Nil(p1.updateGroup)
+	assert.Nil(p2.updateGroup)
+	assert.Empty(s.updateGroups)
+
+	path := newUpdateGroupTestPath("10.10.0.0/24", 0)
+	s.propagateUpdateToNeighbors(rib, nil, path, rib.Update(path), true)
+	m2 := recvOutgoing(t, p2)
+	assert.Len(m2.Paths, 1)
+	select {
+	case <-p1.fsm.outgoingCh.Out():
+		t.Fatal("rejected path sent to 10.0.0.1")
+	default:
+	}
+
+	require.NoError(t, s.policyUpdated(s.policy.Reset(&oc.RoutingPolicy{}, map[string]oc.ApplyPolicy{table.GLOBAL_RIB_NAME: {}})))
+	require.NotNil(t, p1.updateGroup)
+	assert.Equal(p1.updateGroup, p2.updateGroup)
+}
+
+func TestUpdateGroupRouteServerClients(t *testing.T) {
+	assert := assert.New(t)
+	s, rib := newUpdateGroupTestServer(t)
+
+	// route server clients in different ASes share the updates
+	p1 := addEstablishedRouteServerClient(t, s, rib, 65001, "10.0.0.1", "10.0.0.254")
+	p2 := addEstablishedRouteServerClient(t, s, rib, 65002, "10.0.0.2", "10.0.0.254")
+	require.NotNil(t, p1.updateGroup)
+	assert.Equal(p1.updateGroup, p2.updateGroup)
+	assert.Equal(uint32(0), p1.updateGroup.toApi().PeerAsn)
+
+	source := newPeerandInfo(t, 65000, 65100, "10.0.0.100", rib)
+	source.fsm.pConf.RouteServer.Config.RouteServerClient = true
+	path := newUpdateGroupTestPath("10.10.0.0/24", 0)
+	s.propagateUpdateToNeighbors(rib, source, path, rib.Update(path), true)
+	m1, m2 := recvOutgoing(t, p1), recvOutgoing(t, p2)
+	require.NotNil(t, m1.Shared)
+	assert.Same(m1.Shared, m2.Shared)
+
+	// replace-peer-as rewrites the AS path with the peer AS
+	p2.fsm.pConf.AsPathOptions.State.ReplacePeerAs = true
+	s.joinUpdateGroup(p2)
+	assert.NotEqual(p1.updateGroup, p2.updateGroup)
+
+	// the peer AS matters for the AS loop check of the other peers
+	p3 := addEstablishedPeer(t, s, rib, 65003, "10.0.0.3", "10.0.0.254")
+	p4 := addEstablishedPeer(t, s, rib, 65004, "10.0.0.4", "10.0.0.254")
+	assert.NotEqual(p3.updateGroup, p4.updateGroup)
+}
+
+func TestUpdateGroupPolicyStatistics(t *testing.T) {
+	assert := assert.New(t)
+	s, rib := newUpdateGroupTestServer(t)
+
+	rp := &oc.RoutingPolicy{
+		DefinedSets: oc.DefinedSets{
+			PrefixSets: []oc.PrefixSet{{
+				PrefixSetName: "ps1",
+				PrefixList:    []oc.Prefix{{IpPrefix: netip.MustParsePrefix("10.20.0.0/24")}},
+			}},
+		},
+		PolicyDefinitions: []oc.PolicyDefinition{{
+			Name: "p1",
+			Statements: []oc.Statement{{
+				Name:       "s1",
+				Conditions: oc.Conditions{MatchPrefixSet: oc.MatchPrefixSet{PrefixSet: "ps1"}},
+				Actions:    oc.Actions{RouteDisposition: oc.ROUTE_DISPOSITION_REJECT_ROUTE},
+			}},
+		}},
+	}
+	export := oc.ApplyPolicy{Config: oc.ApplyPolicyConfig{ExportPolicyList: []string{"p1"}, DefaultExportPolicy: oc.DEFAULT_POLICY_TYPE_ACCEPT_ROUTE}}
+	ap := map[string]oc.ApplyPolicy{
+		table.GLOBAL_RIB_NAME: {},
+		"10.0.0.1":            export,
+		"10.0.0.2":            export,
+	}
+	require.NoError(t, s.policy.Reset(rp, ap))
+
+	// route server clients with their own assignments of the same policy
+	p1 := addEstablishedRouteServerClient(t, s, rib, 65001, "10.0.0.1", "10.0.0.254")
+	p2 := addEstablishedRouteServerClient(t, s, rib, 65002, "10.0.0.2", "10.0.0.254")
+	require.NoError(t, s.policy.SetPeerPolicy("10.0.0.1", export))
+	require.NoError(t, s.policy.SetPeerPolicy("10.0.0.2", export))
+	s.refreshUpdateGroups()
+	require.NotNil(t, p1.updateGroup)
+	assert.Equal(p1.updateGroup, p2.updateGroup)
+
+	source := newPeerandInfo(t, 65000, 65100, "10.0.0.100", rib)
+	source.fsm.pConf.RouteServer.Config.RouteServerClient = true
+	for _, prefix := range []string{"10.10.0.0/24", "10.20.0.0/24"} {
+		path := newUpdateGroupTestPath(prefix, 0)
+		s.propagateUpdateToNeighbors(rib, source, path, rib.Update(path), true)
+	}
+	m1, m2 := recvOutgoing(t, p1), recvOutgoing(t, p2)
+	require.NotNil(t, m1.Shared)
+	assert.Same(m1.Shared, m2.Shared)
+
+	// every member is credited with the decisions made once for the group
+	for _, id := range []string{"10.0.0.1", "10.0.0.2"} {
+		assert.Equal(table.PolicyStatistics{Evaluations: 2, Matches: 1, Accepts: 1, Rejects: 1},
+			s.policy.AssignmentStatistics(id, table.POLICY_DIRECTION_EXPORT), id)
+	}
+	st, _, err := s.policy.PolicyStatistics("p1")
+	require.NoError(t, err)
+	assert.Equal(table.PolicyStatistics{Evaluations: 4, Matches: 2, Rejects: 2}, st)
+}
+
+// BenchmarkUpdateGroups propagates a path to 500 route server clients in
+// different ASes, which are in a single update group or each in its own
+// group, and serializes the UPDATE messages as the send loop of the peers
+// does.
+func BenchmarkUpdateGroups(b *testing.B) {
+	const numPeers = 500
+	for _, grouped := range []bool{true, false} {
+		b.Run(fmt.Sprintf("grouped=%t", grouped), func(b *testing.B) {
+			s, rib := newUpdateGroupTestServer(b)
+			source := newPeerandInfo(b, 65000, 65100, "10.0.0.100", rib)
+			source.fsm.pConf.RouteServer.Config.RouteServerClient = true
+			peers := make([]*peer, 0, numPeers)
+			for i := range numPeers {
+				address := fmt.Sprintf("10.1.%d.%d", i/250, i%250+1)
+				local := "10.1.255.254"
+				if !grouped {
+					// the peers with different local addresses get
+					// different next hops
+					local = fmt.Sprintf("10.2.%d.%d", i/250, i%250+1)
+				}
+				// 64512-65011, not including 65100 in the AS path of the route
+				peers = append(peers, addEstablishedRouteServerClient(b, s, rib, 64512+uint32(i), address, local))
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; b.Loop(); i++ {
+				path := newUpdateGroupTestPath("10.10.0.0/24", uint32(i))
+				s.propagateUpdateToNeighbors(rib, source, path, rib.Update(path), true)
+				for _, p := range peers {
+					m := (<-p.fsm.outgoingCh.Out()).(*fsmOutgoingMsg)
+					options := &bgp.MarshallingOption{AddPath: p.fsm.familyMap.Load().(map[bgp.Family]bgp.BGPAddPathMode)}
+					for _, msg := range table.CreateUpdateMsgFromPaths(m.Paths, options) {
+						if _, err := msg.Serialize(options); err != nil {
+							b.Fatal(err)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestPreAdjRibOutWatcher(t *testing.T) {
+	assert := assert.New(t)
+	s, rib := newUpdateGroupTestServer(t)
+	go s.Serve()
+	w := s.watch(watchAdjRibOut(false, false))
+	defer w.Stop()
+
+	p1 := addEstablishedPeer(t, s, rib, 65001, "10.0.0.1", "10.0.0.254")
+	open, _ := bgp.NewBGPOpenMessage(65001, 90, netip.MustParseAddr("10.0.0.1"), nil)
+	p1.fsm.lock.Lock()
+	p1.fsm.recvOpen = open
+	p1.fsm.lock.Unlock()
+	rib.Update(newUpdateGroupTestPath("10.10.0.0/24", 0))
+	// the read-only view of the paths to the peer isn't reported
+	paths, _, pre := s.getBestFromLocal(p1, []bgp.Family{bgp.RF_IPv4_UC}, false)
+	assert.Len(paths, 1)
+	assert.Len(pre, 1)
+
+	path := newUpdateGroupTestPath("10.20.0.0/24", 0)
+	s.propagateUpdateToNeighbors(rib, nil, path, rib.Update(path), true)
+	recvOutgoing(t, p1)
+	select {
+	case ev := <-w.Event():
+		u := ev.(*watchEventUpdate)
+		assert.False(u.PostPolicy)
+		require.Len(t, u.PathList, 1)
+		assert.Equal("10.20.0.0/24", u.PathList[0].GetPrefix())
+	case <-time.After(time.Second):
+		t.Fatal("no pre-policy Adj-RIB-Out event")
+	}
+}
@@ -16,6 +16,12 @@
 package server
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/netip"
+	"os"
+
 	"github.com/eapache/channels"
 
 	"github.com/osrg/gobgp/v4/pkg/packet/bgp"
@@ -81,3 +87,35 @@ func decodeAdministrativeCommunication(data []byte) (string, []byte) {
 	}
 	return string(data[1 : communicationLen+1]), data[communicationLen+1:]
 }
+
+// newClientTLSConfig returns the TLS config of the sessions to the BMP and
+// RPKI servers. If the CA file is set, only its certificates are trusted.
+// The server certificate is verified with the address if serverName is
+// empty.
+func newClientTLSConfig(address netip.Addr, caFile, certFile, keyFile, serverName string) (*tls.Config, error) {
+	conf := &tls.Config{
+		ServerName: serverName,
+	}
+	if conf.ServerName == "" {
+		conf.ServerName = address.String()
+	}
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tls ca file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificate in tls ca file %s", caFile)
+		}
+		conf.RootCAs = pool
+	}
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load tls key pair: %w", err)
+		}
+		conf.Certificates = []tls.Certificate{cert}
+	}
+	return conf, nil
+}
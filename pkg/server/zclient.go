@@ -181,6 +181,10 @@ func newIPRouteBody(dst []*table.Path, vrfID uint32, z *zebraClient) (body *zebr
 	if err == nil {
 		msgFlags |= zebra.MessageMetric.ToEach(version, z.client.Software)
 	}
+	tag := path.GetTag()
+	if tag != 0 {
+		msgFlags |= zebra.MessageTag.ToEach(version, z.client.Software)
+	}
 	var flags zebra.Flag
 	if path.IsIBGP() {
 		flags = zebra.FlagIBGP.ToEach(z.client.Version, z.client.Software) | zebra.FlagAllowRecursion
@@ -198,6 +202,7 @@ func newIPRouteBody(dst []*table.Path, vrfID uint32, z *zebraClient) (body *zebr
 		},
 		Nexthops: nexthops,
 		Metric:   med,
+		Tag:      tag,
 	}, path.IsWithdraw
 }
 
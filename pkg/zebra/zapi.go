@@ -1003,7 +1003,8 @@ const ( // For FRRouting version 4, 5 and 6 (ZAPI version 5 and 6).
 	MessageDistance MessageFlag = 0x02
 	// MessageMetric is referred in zclient
 	MessageMetric MessageFlag = 0x04
-	messageTag    MessageFlag = 0x08
+	// MessageTag is referred in zclient
+	MessageTag MessageFlag = 0x08
 	// MessageMTU is referred in zclient_test
 	MessageMTU    MessageFlag = 0x10
 	messageSRCPFX MessageFlag = 0x20
@@ -1043,12 +1044,12 @@ func (f MessageFlag) ToEach(version uint8, software Software) MessageFlag {
 		switch f {
 		case MessageMTU:
 			return zapi3MessageMTU
-		case messageTag:
+		case MessageTag:
 			return zapi3MessageTag
 		}
 	}
 	switch f { // zapi version 4
-	case MessageDistance, MessageMetric, messageTag, MessageMTU, messageSRCPFX:
+	case MessageDistance, MessageMetric, MessageTag, MessageMTU, messageSRCPFX:
 		return f << 1
 	}
 	return f
@@ -1071,7 +1072,7 @@ func (f MessageFlag) string(version uint8, software Software) string {
 	if f&MessageMTU.ToEach(version, software) > 0 {
 		ss = append(ss, "MTU")
 	}
-	if f&messageTag.ToEach(version, software) > 0 {
+	if f&MessageTag.ToEach(version, software) > 0 {
 		ss = append(ss, "TAG")
 	}
 	if version > 3 && f&messageSRCPFX.ToEach(version, software) > 0 {
@@ -2596,7 +2597,7 @@ type IPRouteBody struct {
 	nhgid          uint32      // added in frr8
 	Distance       uint8       // FRR4&FRR5&FRR6&FRR7.x&FRR8
 	Metric         uint32      // FRR4&FRR5&FRR6&FRR7.x&FRR8
-	tag            uint32      // FRR4&FRR5&FRR6&FRR7.x&FRR8
+	Tag            uint32      // FRR4&FRR5&FRR6&FRR7.x&FRR8
 	Mtu            uint32      // FRR4&FRR5&FRR6&FRR7.x&FRR8
 	tableID        uint32      // FRR5&FRR6&FRR7.x&FRR8 (nh_vrf_id in FRR4)
 	srteColor      uint32      // added in frr7.5, FRR7.5&FRR8
@@ -2792,9 +2793,9 @@ func (b *IPRouteBody) serialize(version uint8, software Software) ([]byte, error
 		binary.BigEndian.PutUint32(tmpbuf, b.Metric)
 		buf = append(buf, tmpbuf...)
 	}
-	if b.Message&messageTag.ToEach(version, software) > 0 {
+	if b.Message&MessageTag.ToEach(version, software) > 0 {
 		tmpbuf := make([]byte, 4)
-		binary.BigEndian.PutUint32(tmpbuf, b.tag)
+		binary.BigEndian.PutUint32(tmpbuf, b.Tag)
 		buf = append(buf, tmpbuf...)
 	}
 	if b.Message&MessageMTU.ToEach(version, software) > 0 {
@@ -3059,12 +3060,12 @@ func (b *IPRouteBody) decodeFromBytes(data []byte, version uint8, software Softw
 		b.Metric = binary.BigEndian.Uint32(data[pos : pos+4])
 		pos += 4
 	}
-	if b.Message&messageTag.ToEach(version, software) > 0 {
+	if b.Message&MessageTag.ToEach(version, software) > 0 {
 		if pos+4 > rest {
 			return fmt.Errorf("MessageTag message length invalid pos:%d rest:%d", pos, rest)
 		}
 		// frr: STREAM_GETL(s, api->tag);
-		b.tag = binary.BigEndian.Uint32(data[pos : pos+4])
+		b.Tag = binary.BigEndian.Uint32(data[pos : pos+4])
 		pos += 4
 	}
 	// frr3 and quagga does not have MESSAGE_MTU
@@ -3117,7 +3118,7 @@ func (b *IPRouteBody) string(version uint8, software Software) string {
 	}
 	return s + fmt.Sprintf(
 		", nhgid:%d, distance: %d, metric: %d, mtu: %d, tag: %d",
-		b.nhgid, b.Distance, b.Metric, b.Mtu, b.tag)
+		b.nhgid, b.Distance, b.Metric, b.Mtu, b.Tag)
 }
 
 // lookupBody is combination of nexthopLookupBody and imporetLookupBody